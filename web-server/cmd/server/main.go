@@ -8,6 +8,7 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"rootstock/web-server/config"
 	"rootstock/web-server/global/events"
@@ -93,8 +94,8 @@ func run() error {
 	defer crtRepo.Shutdown()
 	crtOps := certops.NewOps(crtRepo)
 
-	// MQTT server (embedded Mochi broker, mTLS on port 8883)
-	mqttServer, mqttCleanup, err := server.NewMQTTServer(cfg)
+	// MQTT server (embedded Mochi broker, mTLS on port 8883, registry-checked connects)
+	mqttServer, mqttCleanup, err := server.NewMQTTServer(cfg, dOps)
 	if err != nil {
 		return fmt.Errorf("create mqtt server: %w", err)
 	}
@@ -105,19 +106,64 @@ func run() error {
 	defer mRepo.Shutdown()
 	mOps := mqttops.NewOps(mRepo)
 
-	// RPC server (Connect RPC + /enroll + /ca, returns MQTTFlows for subscription wiring)
-	rpcHandler, mqttFlows, rpcCleanup, err := server.NewRPCServer(ctx, cfg, pool, iRepo, dOps, crtOps, mOps)
+	// Ingest pipeline (bounded worker pool between the broker and the reading flows) and the
+	// per-device and per-campaign rate limits in front of it
+	ingestPipeline := server.NewIngestPipeline(ctx, cfg.Ingest, observability.GetMeter("ingest-pipeline"))
+	rateLimiter := server.NewIngestRateLimiter(cfg.Ingest.RateLimit, observability.GetMeter("ingest-rate-limit"))
+
+	// RPC server (Connect RPC + /enroll + /ca + /crl + /ocsp, returns MQTTFlows for subscription wiring)
+	rpcHandler, mqttFlows, rpcCleanup, err := server.NewRPCServer(ctx, cfg, pool, iRepo, dOps, crtOps, mOps, ingestPipeline, rateLimiter)
 	if err != nil {
 		return fmt.Errorf("create rpc server: %w", err)
 	}
 	defer rpcCleanup()
 
-	// MQTT subscriptions (telemetry + renewal callbacks wired to flows)
-	if err := server.SetupMQTTSubscriptions(ctx, mqttServer, mqttFlows); err != nil {
+	// Drain deferred after rpcCleanup so queued readings drain before repos shut down.
+	defer func() {
+		drainCtx, drainCancel := context.WithTimeout(context.WithoutCancel(ctx), time.Duration(cfg.Ingest.DrainTimeoutSeconds)*time.Second)
+		defer drainCancel()
+		if err := ingestPipeline.Drain(drainCtx); err != nil {
+			logger.Error(ctx, "ingest pipeline drain incomplete", map[string]interface{}{"error": err.Error()})
+			return
+		}
+		logger.Info(ctx, "ingest pipeline drained", nil)
+	}()
+
+	// MQTT subscriptions (telemetry, health, config ack and renewal callbacks wired to flows, behind per-device and per-campaign rate limits)
+	if err := server.SetupMQTTSubscriptions(ctx, mqttServer, mqttFlows, cfg.MQTT, ingestPipeline, rateLimiter); err != nil {
 		return fmt.Errorf("setup mqtt subscriptions: %w", err)
 	}
 
-	errChan := make(chan error, 2)
+	// HTTPS telemetry (devices that post readings instead of publishing them: same certificates, ACL, rate limits and acks as the broker)
+	telemetryServer, telemetryCleanup, err := server.NewTelemetryHTTPServer(ctx, cfg, dOps, mqttFlows, ingestPipeline, rateLimiter)
+	if err != nil {
+		return fmt.Errorf("create telemetry http server: %w", err)
+	}
+	defer telemetryCleanup()
+
+	// Device config: bring a device's config up to date when it subscribes to it
+	if err := mqttServer.AddHook(&server.MQTTConfigSyncHook{}, &server.MQTTConfigSyncHookConfig{
+		Pusher: mqttFlows.DeviceConfig,
+		Jobs:   ingestPipeline,
+	}); err != nil {
+		return fmt.Errorf("add mqtt config sync hook: %w", err)
+	}
+	configMonitor := server.NewConfigMonitor(ctx, time.Duration(cfg.MQTT.ConfigSweepMinutes)*time.Minute, mqttFlows.DeviceConfig)
+	defer configMonitor.Stop()
+
+	// Device commands: resend pending commands when a device subscribes to its command topic
+	if err := mqttServer.AddHook(&server.MQTTCommandSyncHook{}, &server.MQTTCommandSyncHookConfig{
+		Resender: mqttFlows.DeviceCommand,
+		Jobs:     ingestPipeline,
+	}); err != nil {
+		return fmt.Errorf("add mqtt command sync hook: %w", err)
+	}
+
+	// Device health: raise the silent alert for devices that stopped sending heartbeats
+	healthMonitor := server.NewHealthMonitor(ctx, time.Duration(cfg.Health.CheckIntervalMinutes)*time.Minute, mqttFlows.DeviceHealth)
+	defer healthMonitor.Stop()
+
+	errChan := make(chan error, 3)
 
 	// Start RPC listener
 	rpcAddr := fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port)
@@ -141,11 +187,24 @@ func run() error {
 		}
 	}()
 
+	// Start HTTPS telemetry listener
+	if telemetryServer != nil {
+		go func() {
+			logger.Info(ctx, "telemetry https server listening", map[string]interface{}{"port": cfg.TelemetryHTTP.Port})
+			if err := telemetryServer.ListenAndServeTLS("", ""); err != nil && err != http.ErrServerClosed {
+				errChan <- fmt.Errorf("telemetry https serve: %w", err)
+			}
+		}()
+	}
+
 	select {
 	case <-ctx.Done():
 		logger.Info(ctx, "shutting down servers...", nil)
 		rpcServer.Close()
 		mqttServer.Close()
+		if telemetryServer != nil {
+			telemetryServer.Close()
+		}
 		return nil
 	case err := <-errChan:
 		return err
@@ -22,6 +22,10 @@ type Config struct {
 	Events        EventsConfig        `koanf:"events"`
 	Cert          CertConfig          `koanf:"cert"`
 	MQTT          MQTTConfig          `koanf:"mqtt"`
+	TelemetryHTTP TelemetryHTTPConfig `koanf:"telemetry_http"`
+	Ingest        IngestConfig        `koanf:"ingest"`
+	Health        HealthConfig        `koanf:"health"`
+	Commands      CommandsConfig      `koanf:"commands"`
 	Export        ExportConfig        `koanf:"export"`
 	SMTP          SMTPConfig          `koanf:"smtp"`
 }
@@ -84,15 +88,81 @@ type EventsConfig struct {
 }
 
 type CertConfig struct {
-	CACertPath       string `koanf:"ca_cert_path"`
-	CAKeyPath        string `koanf:"ca_key_path"`
-	CertLifetimeDays int    `koanf:"cert_lifetime_days"`
+	CACertPath               string `koanf:"ca_cert_path"`
+	CAKeyPath                string `koanf:"ca_key_path"`
+	CertLifetimeDays         int    `koanf:"cert_lifetime_days"`
+	EnrollmentCodeTTLMinutes int    `koanf:"enrollment_code_ttl_minutes"`
+	CRLValidityHours         int    `koanf:"crl_validity_hours"`    // nextUpdate of each signed CRL
+	OCSPValidityMinutes      int    `koanf:"ocsp_validity_minutes"` // nextUpdate of each OCSP response
+	RevocationBaseURL        string `koanf:"revocation_base_url"`   // when set, issued certs point at {url}/crl and {url}/ocsp
 }
 
 type MQTTConfig struct {
 	Port            int      `koanf:"port"`
 	ServerSANs      []string `koanf:"server_sans"`
 	GracePeriodDays int      `koanf:"grace_period_days"`
+	MaxBatchSize    int      `koanf:"max_batch_size"` // readings per data-batch upload
+
+	// Server certificate. With ServerCertFile and ServerKeyFile set, the broker serves that
+	// pair and reloads it when the files change; otherwise it issues its own from the CA and
+	// re-issues ServerCertRenewBeforeHours before each one expires.
+	ServerCertLifetimeHours    int    `koanf:"server_cert_lifetime_hours"`
+	ServerCertRenewBeforeHours int    `koanf:"server_cert_renew_before_hours"`
+	ServerCertFile             string `koanf:"server_cert_file"`
+	ServerKeyFile              string `koanf:"server_key_file"`
+	// How often to re-push device config for campaigns that ended or were cancelled; 0 disables.
+	// Enrollment and publish changes push immediately.
+	ConfigSweepMinutes int `koanf:"config_sweep_minutes"`
+}
+
+// TelemetryHTTPConfig is the HTTPS listener for devices that post readings instead of
+// publishing them over MQTT. It serves the broker's server certificate and honours its
+// grace period; a port of 0 disables it.
+type TelemetryHTTPConfig struct {
+	Port              int   `koanf:"port"`
+	MaxBodyBytes      int64 `koanf:"max_body_bytes"`
+	AckTimeoutSeconds int   `koanf:"ack_timeout_seconds"` // how long a request waits for its acknowledgement
+}
+
+type IngestConfig struct {
+	NearDuplicateWindowMs int             `koanf:"near_duplicate_window_ms"` // identical values closer than this are flagged
+	RegionBufferMeters    float64         `koanf:"region_buffer_meters"`     // tolerance outside campaign regions
+	LocationDriftMeters   float64         `koanf:"location_drift_meters"`    // distance from deployment point that flags a device; 0 disables
+	MaxFutureDriftSeconds int             `koanf:"max_future_drift_seconds"` // timestamps further ahead of ingestion are quarantined; 0 disables
+	MaxBacklogHours       int             `koanf:"max_backlog_hours"`        // timestamps older than this at ingestion are quarantined; 0 disables
+	ClockSkewAlertSeconds int             `koanf:"clock_skew_alert_seconds"` // device clock skew estimate that notifies the owner; 0 disables
+	Workers               int             `koanf:"workers"`
+	QueueSize             int             `koanf:"queue_size"`         // total queued messages across all workers
+	EnqueueTimeoutMs      int             `koanf:"enqueue_timeout_ms"` // backpressure wait before shedding; 0 sheds immediately
+	DrainTimeoutSeconds   int             `koanf:"drain_timeout_seconds"`
+	RateLimit             RateLimitConfig `koanf:"rate_limit"`
+}
+
+// RateLimitConfig bounds how fast telemetry is taken from the broker. Buckets refill at the
+// sustained rate up to the burst; a rate of 0 disables that limit.
+type RateLimitConfig struct {
+	DeviceRatePerMinute    int `koanf:"device_rate_per_minute"`
+	DeviceBurst            int `koanf:"device_burst"`
+	CampaignRatePerSecond  int `koanf:"campaign_rate_per_second"`
+	CampaignBurst          int `koanf:"campaign_burst"`
+	ViolationWindowSeconds int `koanf:"violation_window_seconds"`
+	ViolationFlagThreshold int `koanf:"violation_flag_threshold"` // device violations in one window that flag it for security review; 0 disables
+	ControlRatePerMinute   int `koanf:"control_rate_per_minute"`  // per device and control channel (health, config, command, shadow, ota, enroll)
+	ControlBurst           int `koanf:"control_burst"`
+}
+
+// HealthConfig sets when device heartbeats (rootstock/{id}/health) notify the owner.
+type HealthConfig struct {
+	LowBatteryPercent    float64 `koanf:"low_battery_percent"`    // 0 disables the battery alert
+	SilentHours          int     `koanf:"silent_hours"`           // hours without a heartbeat before the owner is told; 0 disables
+	CheckIntervalMinutes int     `koanf:"check_interval_minutes"` // how often to look for silent devices
+}
+
+// CommandsConfig bounds how long a device command (rootstock/{id}/command) waits to be
+// acknowledged before it expires.
+type CommandsConfig struct {
+	DefaultTTLMinutes int `koanf:"default_ttl_minutes"`
+	MaxTTLMinutes     int `koanf:"max_ttl_minutes"`
 }
 
 type ExportConfig struct {
@@ -45,14 +45,59 @@ func defaults() Config {
 			AppName: "rootstock",
 		},
 		Cert: CertConfig{
-			CACertPath:       "/certs/ca.crt",
-			CAKeyPath:        "/certs/ca.key",
-			CertLifetimeDays: 90,
+			CACertPath:               "/certs/ca.crt",
+			CAKeyPath:                "/certs/ca.key",
+			CertLifetimeDays:         90,
+			EnrollmentCodeTTLMinutes: 15,
+			CRLValidityHours:         24,
+			OCSPValidityMinutes:      60,
 		},
 		MQTT: MQTTConfig{
 			Port:            8883,
 			ServerSANs:      []string{"localhost", "web-server"},
 			GracePeriodDays: 7,
+			MaxBatchSize:    1000,
+
+			ServerCertLifetimeHours:    24,
+			ServerCertRenewBeforeHours: 6,
+
+			ConfigSweepMinutes: 15,
+		},
+		TelemetryHTTP: TelemetryHTTPConfig{
+			Port:              8884,
+			MaxBodyBytes:      1 << 20,
+			AckTimeoutSeconds: 30,
+		},
+		Ingest: IngestConfig{
+			NearDuplicateWindowMs: 2000,
+			RegionBufferMeters:    50,
+			LocationDriftMeters:   500,
+			MaxFutureDriftSeconds: 300,
+			MaxBacklogHours:       720,
+			ClockSkewAlertSeconds: 120,
+			Workers:               8,
+			QueueSize:             4096,
+			EnqueueTimeoutMs:      250,
+			DrainTimeoutSeconds:   30,
+			RateLimit: RateLimitConfig{
+				DeviceRatePerMinute:    60,
+				DeviceBurst:            20,
+				CampaignRatePerSecond:  500,
+				CampaignBurst:          2000,
+				ViolationWindowSeconds: 600,
+				ViolationFlagThreshold: 200,
+				ControlRatePerMinute:   30,
+				ControlBurst:           10,
+			},
+		},
+		Health: HealthConfig{
+			LowBatteryPercent:    15,
+			SilentHours:          6,
+			CheckIntervalMinutes: 15,
+		},
+		Commands: CommandsConfig{
+			DefaultTTLMinutes: 60,
+			MaxTTLMinutes:     1440,
 		},
 		Export: ExportConfig{
 			HMACSecret: "dev-hmac-secret-change-in-prod",
@@ -46,11 +46,20 @@ func (f *DashboardFlow) Run(ctx context.Context, campaignID string) (*Dashboard,
 	}
 	for _, db := range devices {
 		d.DeviceBreakdown = append(d.DeviceBreakdown, DeviceBreakdownItem{
-			PseudoDeviceID: db.PseudoDeviceID,
-			DeviceClass:    db.DeviceClass,
-			AcceptanceRate: db.AcceptanceRate,
-			ReadingCount:   db.ReadingCount,
-			LastSeen:       db.LastSeen,
+			PseudoDeviceID:  db.PseudoDeviceID,
+			DeviceClass:     db.DeviceClass,
+			AcceptanceRate:  db.AcceptanceRate,
+			ReadingCount:    db.ReadingCount,
+			LastSeen:        db.LastSeen,
+			Online:          db.Online,
+			LastConnectedAt: db.LastConnectedAt,
+			BatteryPercent:  db.BatteryPercent,
+			RSSIDbm:         db.RSSIDbm,
+			LastHeartbeatAt: db.LastHeartbeatAt,
+			HealthAlerts:    db.HealthAlerts,
+
+			ConfigVersion:      db.ConfigVersion,
+			ConfigAckedVersion: db.ConfigAckedVersion,
 		})
 	}
 
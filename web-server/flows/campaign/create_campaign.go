@@ -2,12 +2,26 @@ package campaign
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
 
 	campaignops "rootstock/web-server/ops/campaign"
 	graphops "rootstock/web-server/ops/graph"
+	"rootstock/web-server/ops/pure"
 )
 
+// ErrInvalidParameterPolicy is returned when a campaign names an unknown precision or
+// undeclared-parameter policy.
+var ErrInvalidParameterPolicy = errors.New("invalid parameter policy")
+
+// ErrInvalidSamplingInterval is returned when a campaign sets a minimum sampling interval that is not positive.
+var ErrInvalidSamplingInterval = errors.New("minimum sampling interval must be positive")
+
+// ErrInvalidFirmwareRequirement is returned when an eligibility rule's firmware requirement
+// is neither a version nor a version constraint.
+var ErrInvalidFirmwareRequirement = errors.New("invalid firmware requirement")
+
 // CreateCampaignFlow orchestrates campaign creation.
 type CreateCampaignFlow struct {
 	campaignOps *campaignops.Ops
@@ -21,6 +35,27 @@ func NewCreateCampaignFlow(campaignOps *campaignops.Ops, graphOps *graphops.Ops)
 
 // Run creates a campaign with parameters, regions, window, and eligibility.
 func (f *CreateCampaignFlow) Run(ctx context.Context, input CreateCampaignInput) (*Campaign, error) {
+	switch input.PrecisionPolicy {
+	case "", pure.PrecisionRound, pure.PrecisionQuarantine:
+	default:
+		return nil, ErrInvalidParameterPolicy
+	}
+	switch input.UndeclaredParameterPolicy {
+	case "", pure.UndeclaredDrop, pure.UndeclaredExclude, pure.UndeclaredQuarantine:
+	default:
+		return nil, ErrInvalidParameterPolicy
+	}
+
+	if input.MinSamplingIntervalSecs != nil && *input.MinSamplingIntervalSecs <= 0 {
+		return nil, ErrInvalidSamplingInterval
+	}
+
+	for _, e := range input.Eligibility {
+		if _, err := pure.ParseFirmwareRequirement(e.FirmwareMin); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidFirmwareRequirement, err)
+		}
+	}
+
 	result, err := f.campaignOps.CreateCampaign(ctx, toOpsCampaignInput(input))
 	if err != nil {
 		return nil, err
@@ -45,6 +80,7 @@ func toOpsCampaignInput(in CreateCampaignInput) campaignops.CreateCampaignInput
 			MinRange:  p.MinRange,
 			MaxRange:  p.MaxRange,
 			Precision: p.Precision,
+			Required:  p.Required,
 		}
 	}
 	regions := make([]campaignops.RegionInput, len(in.Regions))
@@ -68,6 +104,10 @@ func toOpsCampaignInput(in CreateCampaignInput) campaignops.CreateCampaignInput
 		Parameters:  params,
 		Regions:     regions,
 		Eligibility: elig,
+
+		PrecisionPolicy:           in.PrecisionPolicy,
+		UndeclaredParameterPolicy: in.UndeclaredParameterPolicy,
+		MinSamplingIntervalSecs:   in.MinSamplingIntervalSecs,
 	}
 }
 
@@ -22,11 +22,20 @@ type ParameterQualityItem struct {
 
 // DeviceBreakdownItem holds per-device stats.
 type DeviceBreakdownItem struct {
-	PseudoDeviceID string
-	DeviceClass    string
-	AcceptanceRate float64
-	ReadingCount   int
-	LastSeen       *string
+	PseudoDeviceID  string
+	DeviceClass     string
+	AcceptanceRate  float64
+	ReadingCount    int
+	LastSeen        *string
+	Online          bool     // has an open broker session
+	LastConnectedAt *string  // start of the latest broker session
+	BatteryPercent  *float64 // from the latest heartbeat
+	RSSIDbm         *int
+	LastHeartbeatAt *string
+	HealthAlerts    []string // raised health alerts
+
+	ConfigVersion      *int64 // config last pushed; nil if none
+	ConfigAckedVersion *int64 // config the device last acknowledged
 }
 
 // EnrollmentFunnelItem holds enrollment stage counts.
@@ -11,6 +11,10 @@ type CreateCampaignInput struct {
 	Parameters  []ParameterInput
 	Regions     []RegionInput
 	Eligibility []EligibilityInput
+
+	PrecisionPolicy           string // "round" or "quarantine"; empty uses the default
+	UndeclaredParameterPolicy string // "drop", "exclude" or "quarantine"; empty uses the default
+	MinSamplingIntervalSecs   *int   // minimum spacing between one device's readings; nil means none
 }
 
 type ParameterInput struct {
@@ -19,6 +23,7 @@ type ParameterInput struct {
 	MinRange  *float64
 	MaxRange  *float64
 	Precision *int
+	Required  bool
 }
 
 type RegionInput struct {
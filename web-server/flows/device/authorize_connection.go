@@ -0,0 +1,48 @@
+package device
+
+import (
+	"context"
+
+	deviceops "rootstock/web-server/ops/device"
+	"rootstock/web-server/ops/pure"
+)
+
+// AuthorizeConnectionFlow decides whether a device presenting a chain-valid certificate may
+// open a broker session, from its registry status and current certificate serial.
+type AuthorizeConnectionFlow struct {
+	deviceOps *deviceops.Ops
+}
+
+// NewAuthorizeConnectionFlow creates the flow with its required ops.
+func NewAuthorizeConnectionFlow(deviceOps *deviceops.Ops) *AuthorizeConnectionFlow {
+	return &AuthorizeConnectionFlow{deviceOps: deviceOps}
+}
+
+// Run looks the device up and applies pure.CheckDeviceConnection. A lookup failure is returned
+// as an error; callers should refuse the connection.
+func (f *AuthorizeConnectionFlow) Run(ctx context.Context, input AuthorizeConnectionInput) (*ConnectionDecision, error) {
+	d, err := f.deviceOps.GetDevice(ctx, input.DeviceID)
+	if err != nil {
+		return nil, err
+	}
+	result := pure.CheckDeviceConnection(pure.DeviceConnectionInput{
+		DeviceStatus:     d.Status,
+		RegisteredSerial: d.CertSerial,
+		PresentedSerial:  input.CertSerial,
+	})
+	return &ConnectionDecision{Action: result.Action, Reason: result.Reason, Gateway: d.Gateway}, nil
+}
+
+// RunListChildren returns the devices enrolled through a gateway, whose topics a gateway
+// session may use as far as each child's status allows.
+func (f *AuthorizeConnectionFlow) RunListChildren(ctx context.Context, gatewayID string) ([]GatewayChild, error) {
+	children, err := f.deviceOps.ListGatewayChildren(ctx, gatewayID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]GatewayChild, len(children))
+	for i, c := range children {
+		out[i] = GatewayChild(c)
+	}
+	return out, nil
+}
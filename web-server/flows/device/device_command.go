@@ -0,0 +1,242 @@
+package device
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	deviceops "rootstock/web-server/ops/device"
+	mqttops "rootstock/web-server/ops/mqtt"
+	"rootstock/web-server/ops/pure"
+)
+
+// ErrInvalidCommand is returned when a command or its parameters are refused.
+var ErrInvalidCommand = errors.New("invalid device command")
+
+// ErrCommandTarget is returned unless exactly one of a device and a campaign is named.
+var ErrCommandTarget = errors.New("name exactly one of device_id and campaign_id")
+
+// ErrCommandNotPermitted is returned when the caller neither owns the device nor runs a
+// campaign it is enrolled in, or does not run the campaign named.
+var ErrCommandNotPermitted = errors.New("caller may not command this device")
+
+// Command history page sizes.
+const (
+	defaultCommandHistory = 100
+	maxCommandHistory     = 500
+)
+
+// DeviceCommandFlow sends commands to devices on rootstock/{id}/command and records what
+// they report on rootstock/{id}/command/result. A device may be commanded by its owner or
+// by the researcher running a live campaign it is enrolled in; a campaign's researcher may
+// command all of its devices at once. Every command is kept as an audit trail.
+type DeviceCommandFlow struct {
+	deviceOps  *deviceops.Ops
+	mqttOps    *mqttops.Ops
+	defaultTTL time.Duration
+	maxTTL     time.Duration
+}
+
+// NewDeviceCommandFlow creates the flow with its required ops and expiry bounds.
+func NewDeviceCommandFlow(deviceOps *deviceops.Ops, mqttOps *mqttops.Ops, settings CommandSettings) *DeviceCommandFlow {
+	return &DeviceCommandFlow{
+		deviceOps:  deviceOps,
+		mqttOps:    mqttOps,
+		defaultTTL: time.Duration(settings.DefaultTTLMinutes) * time.Minute,
+		maxTTL:     time.Duration(settings.MaxTTLMinutes) * time.Minute,
+	}
+}
+
+// commandParams is the params object of a command payload.
+type commandParams struct {
+	IntervalSeconds *int     `json:"interval_seconds,omitempty"`
+	Sensors         []string `json:"sensors,omitempty"`
+}
+
+// RunSend validates a command, checks the caller may send it, records it for each target
+// device and publishes it. A publish failure is logged, not returned: the command stays
+// pending and is resent when the device next subscribes, until it expires.
+func (f *DeviceCommandFlow) RunSend(ctx context.Context, input SendCommandInput) (*CommandBatch, error) {
+	if (input.DeviceID == "") == (input.CampaignID == "") {
+		return nil, ErrCommandTarget
+	}
+	check := pure.ValidateCommand(pure.CommandRequest{
+		Command:         input.Command,
+		IntervalSeconds: input.IntervalSeconds,
+		Sensors:         input.Sensors,
+		TTL:             time.Duration(input.TTLSeconds) * time.Second,
+		DefaultTTL:      f.defaultTTL,
+		MaxTTL:          f.maxTTL,
+	})
+	if !check.Valid {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCommand, check.Reason)
+	}
+
+	now := time.Now()
+	targets, err := f.targets(ctx, input.IssuerID, input.DeviceID, input.CampaignID, now)
+	if err != nil {
+		return nil, err
+	}
+	if len(targets) == 0 {
+		return &CommandBatch{}, nil
+	}
+
+	params, err := json.Marshal(commandParams{IntervalSeconds: input.IntervalSeconds, Sensors: input.Sensors})
+	if err != nil {
+		return nil, fmt.Errorf("marshal command params: %w", err)
+	}
+	var campaignID *string
+	if input.CampaignID != "" {
+		campaignID = &input.CampaignID
+	}
+	commands, err := f.deviceOps.CreateCommands(ctx, deviceops.CreateCommandsInput{
+		DeviceIDs:  targets,
+		CampaignID: campaignID,
+		Command:    input.Command,
+		Params:     params,
+		IssuedBy:   input.IssuerID,
+		ExpiresAt:  now.Add(check.TTL),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	batch := &CommandBatch{Commands: make([]DeviceCommand, len(commands))}
+	for i, c := range commands {
+		batch.BatchID = c.BatchID
+		batch.Commands[i] = fromOpsDeviceCommand(c)
+		if err := f.publish(ctx, c); err != nil {
+			slog.WarnContext(ctx, "failed to publish device command", "device_id", c.DeviceID, "command_id", c.ID, "error", err)
+			continue
+		}
+		batch.Published++
+	}
+	return batch, nil
+}
+
+// RunList returns command history for a device or a campaign, newest first. The caller
+// must be allowed to command the device or campaign.
+func (f *DeviceCommandFlow) RunList(ctx context.Context, input ListCommandsInput) ([]DeviceCommand, error) {
+	if (input.DeviceID == "") == (input.CampaignID == "") {
+		return nil, ErrCommandTarget
+	}
+	if _, err := f.targets(ctx, input.IssuerID, input.DeviceID, input.CampaignID, time.Now()); err != nil {
+		return nil, err
+	}
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = defaultCommandHistory
+	}
+	limit = min(limit, maxCommandHistory)
+	commands, err := f.deviceOps.ListCommands(ctx, deviceops.ListCommandsInput{
+		DeviceID:   input.DeviceID,
+		CampaignID: input.CampaignID,
+		Limit:      limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]DeviceCommand, len(commands))
+	for i, c := range commands {
+		out[i] = fromOpsDeviceCommand(c)
+	}
+	return out, nil
+}
+
+// RunResend republishes the device's unacknowledged, unexpired commands, oldest first.
+// Call when the device subscribes to its command topic. Returns how many were sent.
+func (f *DeviceCommandFlow) RunResend(ctx context.Context, deviceID string) (int, error) {
+	commands, err := f.deviceOps.ListPendingCommands(ctx, deviceID, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	for i, c := range commands {
+		if err := f.publish(ctx, c); err != nil {
+			return i, err
+		}
+	}
+	return len(commands), nil
+}
+
+// RunResult records a device's acknowledgement or outcome of one of its commands.
+// Reports on another device's command, an expired command or a finished one are refused.
+func (f *DeviceCommandFlow) RunResult(ctx context.Context, input CommandResultInput) (*CommandReportResult, error) {
+	check := pure.ValidateCommandResult(input.Status, input.Error)
+	if !check.Valid {
+		return &CommandReportResult{Reason: check.Reason}, nil
+	}
+	if input.CommandID == "" {
+		return &CommandReportResult{Reason: "command_id is required"}, nil
+	}
+
+	var errMsg *string
+	if input.Error != "" {
+		errMsg = &input.Error
+	}
+	var result []byte
+	if len(input.Result) > 0 {
+		result = input.Result
+	}
+	applied, err := f.deviceOps.RecordCommandResult(ctx, deviceops.RecordCommandResultInput{
+		DeviceID:  input.DeviceID,
+		CommandID: input.CommandID,
+		Status:    input.Status,
+		Result:    result,
+		Error:     errMsg,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !applied {
+		return &CommandReportResult{Reason: "unknown, expired or finished command"}, nil
+	}
+	return &CommandReportResult{Accepted: true}, nil
+}
+
+// targets returns the devices the issuer asked for, or ErrCommandNotPermitted.
+func (f *DeviceCommandFlow) targets(ctx context.Context, issuerID, deviceID, campaignID string, at time.Time) ([]string, error) {
+	targets, err := f.deviceOps.ListCommandTargets(ctx, deviceops.CommandTargetsInput{
+		IssuerID:   issuerID,
+		DeviceID:   deviceID,
+		CampaignID: campaignID,
+		At:         at,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !targets.Permitted {
+		return nil, ErrCommandNotPermitted
+	}
+	return targets.DeviceIDs, nil
+}
+
+func (f *DeviceCommandFlow) publish(ctx context.Context, c deviceops.DeviceCommand) error {
+	payload, err := json.Marshal(DeviceCommandPayload{
+		CommandID:   c.ID,
+		Command:     c.Command,
+		Params:      c.Params,
+		IssuedAt:    c.IssuedAt.UTC(),
+		ExpiresAt:   c.ExpiresAt.UTC(),
+		ResultTopic: fmt.Sprintf("%s/%s/command/result", mqttops.TopicPrefix, c.DeviceID),
+	})
+	if err != nil {
+		return fmt.Errorf("marshal device command: %w", err)
+	}
+	return f.mqttOps.SendDeviceCommand(ctx, mqttops.SendDeviceCommandInput{
+		DeviceID: c.DeviceID,
+		Payload:  payload,
+	})
+}
+
+func fromOpsDeviceCommand(c deviceops.DeviceCommand) DeviceCommand {
+	return DeviceCommand{
+		ID: c.ID, DeviceID: c.DeviceID, CampaignID: c.CampaignID, BatchID: c.BatchID,
+		Command: c.Command, Params: c.Params, IssuedBy: c.IssuedBy,
+		IssuedAt: c.IssuedAt, ExpiresAt: c.ExpiresAt, Status: c.Status,
+		AckedAt: c.AckedAt, CompletedAt: c.CompletedAt, Result: c.Result, Error: c.Error,
+	}
+}
@@ -0,0 +1,202 @@
+package device
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	campaignops "rootstock/web-server/ops/campaign"
+	deviceops "rootstock/web-server/ops/device"
+	mqttops "rootstock/web-server/ops/mqtt"
+	"rootstock/web-server/ops/pure"
+)
+
+// DeviceConfigFlow keeps each device's campaign configuration current. The config lists
+// every live campaign the device is enrolled in, with its parameters and units, sampling
+// interval, window, regions and topics. It is stored versioned and published retained on
+// rootstock/{id}/config; the version only moves when the content does. Devices acknowledge
+// the version they applied on rootstock/{id}/config/ack, so devices running stale config
+// can be found.
+type DeviceConfigFlow struct {
+	pusher configPusher
+}
+
+// NewDeviceConfigFlow creates the flow with its required ops.
+func NewDeviceConfigFlow(deviceOps *deviceops.Ops, campaignOps *campaignops.Ops, mqttOps *mqttops.Ops) *DeviceConfigFlow {
+	return &DeviceConfigFlow{pusher: configPusher{deviceOps: deviceOps, campaignOps: campaignOps, mqttOps: mqttOps}}
+}
+
+// RunPush rebuilds the device's config and publishes it if it changed or the device has
+// not acknowledged it yet. Call when what the device should run changes, and when it
+// connects: the retained copy does not survive a broker restart.
+func (f *DeviceConfigFlow) RunPush(ctx context.Context, deviceID string) (*DeviceConfigStatus, error) {
+	return f.pusher.push(ctx, deviceID)
+}
+
+// RunPushCampaign re-pushes config to every device enrolled in or configured for a
+// campaign, after the campaign changed. Per-device failures are logged; returns how many
+// devices were sent a config.
+func (f *DeviceConfigFlow) RunPushCampaign(ctx context.Context, campaignID string) (int, error) {
+	deviceIDs, err := f.pusher.deviceOps.ListCampaignDevices(ctx, campaignID)
+	if err != nil {
+		return 0, err
+	}
+	return f.pushAll(ctx, deviceIDs), nil
+}
+
+// RunPushEnded re-pushes config to devices still configured for a campaign that ended,
+// was cancelled, or that they were withdrawn from. Run periodically.
+func (f *DeviceConfigFlow) RunPushEnded(ctx context.Context) (int, error) {
+	deviceIDs, err := f.pusher.deviceOps.ListEndedConfigDevices(ctx, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	return f.pushAll(ctx, deviceIDs), nil
+}
+
+// RunAck records that a device applied a config version. Reports whether the ack was
+// accepted; acks for versions never pushed or already superseded by a later ack are not.
+func (f *DeviceConfigFlow) RunAck(ctx context.Context, input AckConfigInput) (bool, error) {
+	if input.Version <= 0 {
+		return false, nil
+	}
+	return f.pusher.deviceOps.AckDeviceConfig(ctx, input.DeviceID, input.Version)
+}
+
+func (f *DeviceConfigFlow) pushAll(ctx context.Context, deviceIDs []string) int {
+	pushed := 0
+	for _, id := range deviceIDs {
+		status, err := f.pusher.push(ctx, id)
+		if err != nil {
+			slog.WarnContext(ctx, "failed to push device config", "device_id", id, "error", err)
+			continue
+		}
+		if status.Published {
+			pushed++
+		}
+	}
+	return pushed
+}
+
+// configPusher builds, stores and publishes device configs. Shared by the flows that
+// change what a device should be running.
+type configPusher struct {
+	deviceOps   *deviceops.Ops
+	campaignOps *campaignops.Ops
+	mqttOps     *mqttops.Ops
+}
+
+func (p configPusher) push(ctx context.Context, deviceID string) (*DeviceConfigStatus, error) {
+	campaignIDs, err := p.deviceOps.ListConfigCampaigns(ctx, deviceID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	campaigns := make([]CampaignConfigPayload, 0, len(campaignIDs))
+	for _, id := range campaignIDs {
+		rules, err := p.campaignOps.GetCampaignRules(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("get campaign %s rules: %w", id, err)
+		}
+		campaigns = append(campaigns, campaignConfig(deviceID, rules))
+	}
+	body, err := json.Marshal(campaigns)
+	if err != nil {
+		return nil, fmt.Errorf("marshal device config: %w", err)
+	}
+	digest := sha256.Sum256(body)
+
+	cfg, err := p.deviceOps.SaveDeviceConfig(ctx, deviceops.SaveDeviceConfigInput{
+		DeviceID:    deviceID,
+		Digest:      hex.EncodeToString(digest[:]),
+		CampaignIDs: campaignIDs,
+		Campaigns:   body,
+	})
+	if err != nil {
+		return nil, err
+	}
+	p.setActiveCampaigns(ctx, deviceID, campaignIDs)
+
+	status := &DeviceConfigStatus{
+		DeviceID:     deviceID,
+		Version:      cfg.Version,
+		PushedAt:     cfg.PushedAt,
+		AckedVersion: cfg.AckedVersion,
+	}
+	if cfg.Changed || !acked(cfg) {
+		if err := p.publish(ctx, cfg); err != nil {
+			return nil, err
+		}
+		status.Published = true
+	}
+	return status, nil
+}
+
+// setActiveCampaigns records the device's campaigns as desired shadow state. A failure is
+// logged: the config itself has been stored and the next push corrects the shadow.
+func (p configPusher) setActiveCampaigns(ctx context.Context, deviceID string, campaignIDs []string) {
+	active := append([]string{}, campaignIDs...)
+	shadows := shadowPublisher{deviceOps: p.deviceOps, mqttOps: p.mqttOps}
+	if _, err := shadows.setDesired(ctx, deviceID, pure.ShadowState{ActiveCampaigns: active}); err != nil {
+		slog.WarnContext(ctx, "failed to set desired active campaigns", "device_id", deviceID, "error", err)
+	}
+}
+
+func (p configPusher) publish(ctx context.Context, cfg *deviceops.DeviceConfig) error {
+	var campaigns []CampaignConfigPayload
+	if err := json.Unmarshal(cfg.Campaigns, &campaigns); err != nil {
+		return fmt.Errorf("decode stored device config: %w", err)
+	}
+	payload, err := json.Marshal(DeviceConfigPayload{
+		Version:   cfg.Version,
+		IssuedAt:  cfg.PushedAt.UTC(),
+		AckTopic:  fmt.Sprintf("%s/%s/config/ack", mqttops.TopicPrefix, cfg.DeviceID),
+		Campaigns: campaigns,
+	})
+	if err != nil {
+		return fmt.Errorf("marshal device config: %w", err)
+	}
+	return p.mqttOps.PushDeviceConfig(ctx, mqttops.PushDeviceConfigInput{
+		DeviceID: cfg.DeviceID,
+		Payload:  payload,
+	})
+}
+
+// campaignConfig is the device's view of a campaign. Parameters and regions are sorted so
+// the same campaign always digests the same.
+func campaignConfig(deviceID string, rules *campaignops.CampaignRules) CampaignConfigPayload {
+	c := CampaignConfigPayload{
+		CampaignID:              rules.CampaignID,
+		PublishTopic:            fmt.Sprintf("%s/%s/data/%s", mqttops.TopicPrefix, deviceID, rules.CampaignID),
+		BatchTopic:              fmt.Sprintf("%s/%s/data-batch/%s", mqttops.TopicPrefix, deviceID, rules.CampaignID),
+		SamplingIntervalSeconds: rules.MinSamplingIntervalSecs,
+		WindowStart:             rules.WindowStart,
+		WindowEnd:               rules.WindowEnd,
+		Parameters:              make([]ParameterConfigPayload, len(rules.Parameters)),
+	}
+	for i, p := range rules.Parameters {
+		c.Parameters[i] = ParameterConfigPayload{
+			Name: p.Name, Unit: p.Unit, MinRange: p.MinRange, MaxRange: p.MaxRange,
+			Precision: p.Precision, Required: p.Required,
+		}
+	}
+	sort.Slice(c.Parameters, func(i, j int) bool { return c.Parameters[i].Name < c.Parameters[j].Name })
+
+	regions := make([]string, len(rules.Regions))
+	for i, r := range rules.Regions {
+		regions[i] = r.GeoJSON
+	}
+	sort.Strings(regions)
+	for _, r := range regions {
+		c.Regions = append(c.Regions, json.RawMessage(r))
+	}
+	return c
+}
+
+func acked(cfg *deviceops.DeviceConfig) bool {
+	return cfg.AckedVersion != nil && *cfg.AckedVersion >= cfg.Version
+}
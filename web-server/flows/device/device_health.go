@@ -0,0 +1,136 @@
+package device
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	deviceops "rootstock/web-server/ops/device"
+	enrollmentops "rootstock/web-server/ops/enrollment"
+	"rootstock/web-server/ops/pure"
+)
+
+// DeviceHealthFlow stores device heartbeats from rootstock/{id}/health and tells the owner
+// when a device needs attention: low battery, failing sensors, or no heartbeat for a while.
+// Each alert notifies once and stays raised until the condition clears.
+type DeviceHealthFlow struct {
+	deviceOps     *deviceops.Ops
+	enrollmentOps *enrollmentops.Ops
+	lowBattery    float64
+	silentAfter   time.Duration
+}
+
+// NewDeviceHealthFlow creates the flow with its required ops and alert thresholds.
+func NewDeviceHealthFlow(deviceOps *deviceops.Ops, enrollmentOps *enrollmentops.Ops, settings HealthSettings) *DeviceHealthFlow {
+	return &DeviceHealthFlow{
+		deviceOps:     deviceOps,
+		enrollmentOps: enrollmentOps,
+		lowBattery:    settings.LowBatteryPercent,
+		silentAfter:   time.Duration(settings.SilentHours) * time.Hour,
+	}
+}
+
+// RunReport validates and stores a heartbeat, then raises or clears the device's alerts.
+// An implausible report is not stored: Accepted is false and Reason says why.
+// Notification failures are logged, not returned; the heartbeat is already stored.
+func (f *DeviceHealthFlow) RunReport(ctx context.Context, input ReportHealthInput) (*HealthReportResult, error) {
+	check := pure.ValidateHealthReport(pure.HealthReport{
+		BatteryPercent:  input.BatteryPercent,
+		RSSIDbm:         input.RSSIDbm,
+		UptimeSeconds:   input.UptimeSeconds,
+		FreeMemoryBytes: input.FreeMemoryBytes,
+		SensorFaults:    input.SensorFaults,
+	})
+	if !check.Valid {
+		return &HealthReportResult{Reason: check.Reason}, nil
+	}
+
+	reportedAt := input.ReportedAt
+	if reportedAt.IsZero() {
+		reportedAt = time.Now()
+	}
+	state, err := f.deviceOps.RecordHealth(ctx, deviceops.RecordHealthInput{
+		DeviceID:        input.DeviceID,
+		ReportedAt:      reportedAt,
+		BatteryPercent:  input.BatteryPercent,
+		RSSIDbm:         input.RSSIDbm,
+		UptimeSeconds:   input.UptimeSeconds,
+		FreeMemoryBytes: input.FreeMemoryBytes,
+		SensorFaults:    input.SensorFaults,
+		FirmwareBuild:   input.FirmwareBuild,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("record device health: %w", err)
+	}
+
+	alerts := pure.CheckHealthAlerts(pure.HealthAlertInput{
+		BatteryPercent:    input.BatteryPercent,
+		SensorFaults:      input.SensorFaults,
+		Active:            state.ActiveAlerts,
+		LowBatteryPercent: f.lowBattery,
+	})
+	result := &HealthReportResult{Accepted: true, Raised: alerts.Raise, Cleared: alerts.Clear}
+	if len(alerts.Raise) == 0 && len(alerts.Clear) == 0 {
+		return result, nil
+	}
+	if err := f.deviceOps.SetHealthAlerts(ctx, deviceops.SetHealthAlertsInput{
+		DeviceID: input.DeviceID,
+		Raise:    alerts.Raise,
+		Clear:    alerts.Clear,
+	}); err != nil {
+		return nil, fmt.Errorf("set health alerts: %w", err)
+	}
+
+	for _, alert := range alerts.Raise {
+		var message string
+		switch alert {
+		case pure.HealthAlertLowBattery:
+			message = fmt.Sprintf("Device %s's battery is at %.0f%%. Charge or replace it to keep readings coming.", input.DeviceID, *input.BatteryPercent)
+		case pure.HealthAlertSensorFault:
+			message = fmt.Sprintf("Device %s reports a fault on %s. Readings from the affected sensors may be missing or wrong.", input.DeviceID, strings.Join(input.SensorFaults, ", "))
+		}
+		f.notify(ctx, state.OwnerID, input.DeviceID, alert, message)
+	}
+	return result, nil
+}
+
+// RunCheckSilent raises the silent alert for active devices with no heartbeat for the
+// configured number of hours and notifies their owners. Returns how many were raised.
+// A zero SilentHours disables the check.
+func (f *DeviceHealthFlow) RunCheckSilent(ctx context.Context) (int, error) {
+	if f.silentAfter <= 0 {
+		return 0, nil
+	}
+	devices, err := f.deviceOps.ListSilentDevices(ctx, time.Now().Add(-f.silentAfter))
+	if err != nil {
+		return 0, fmt.Errorf("list silent devices: %w", err)
+	}
+
+	raised := 0
+	for _, d := range devices {
+		if err := f.deviceOps.SetHealthAlerts(ctx, deviceops.SetHealthAlertsInput{
+			DeviceID: d.DeviceID,
+			Raise:    []string{pure.HealthAlertSilent},
+		}); err != nil {
+			slog.WarnContext(ctx, "failed to raise silent device alert", "device_id", d.DeviceID, "error", err)
+			continue
+		}
+		raised++
+		f.notify(ctx, d.OwnerID, d.DeviceID, pure.HealthAlertSilent,
+			fmt.Sprintf("Device %s has not checked in since %s. Check its power and network connection.", d.DeviceID, d.LastHeartbeat.UTC().Format(time.RFC1123)))
+	}
+	return raised, nil
+}
+
+// notify tells the owner about a newly raised alert. Best-effort.
+func (f *DeviceHealthFlow) notify(ctx context.Context, ownerID, deviceID, alert, message string) {
+	if err := f.enrollmentOps.CreateNotification(ctx, enrollmentops.CreateNotificationInput{
+		UserID:  ownerID,
+		Type:    "device_" + alert,
+		Message: message,
+	}); err != nil {
+		slog.WarnContext(ctx, "failed to notify owner of device health alert", "device_id", deviceID, "alert", alert, "error", err)
+	}
+}
@@ -0,0 +1,46 @@
+package device
+
+import (
+	"context"
+
+	deviceops "rootstock/web-server/ops/device"
+)
+
+// DeviceSessionFlow records broker sessions: when a device connected and disconnected,
+// from where, with which certificate and why it left. A device is online while it has
+// an open session.
+type DeviceSessionFlow struct {
+	deviceOps *deviceops.Ops
+}
+
+// NewDeviceSessionFlow creates the flow with its required ops.
+func NewDeviceSessionFlow(deviceOps *deviceops.Ops) *DeviceSessionFlow {
+	return &DeviceSessionFlow{deviceOps: deviceOps}
+}
+
+// RunOpen records an authenticated connection and returns the session ID to close it with.
+func (f *DeviceSessionFlow) RunOpen(ctx context.Context, input OpenSessionInput) (string, error) {
+	return f.deviceOps.OpenSession(ctx, deviceops.OpenSessionInput{
+		DeviceID:    input.DeviceID,
+		ConnectedAt: input.ConnectedAt,
+		RemoteAddr:  input.RemoteAddr,
+		CertSerial:  input.CertSerial,
+	})
+}
+
+// RunClose records the end of a session.
+func (f *DeviceSessionFlow) RunClose(ctx context.Context, input CloseSessionInput) error {
+	return f.deviceOps.CloseSession(ctx, deviceops.CloseSessionInput{
+		ID:             input.ID,
+		DisconnectedAt: input.DisconnectedAt,
+		Reason:         input.Reason,
+		Graceful:       input.Graceful,
+		WillPublished:  input.WillPublished,
+	})
+}
+
+// RunCloseStale closes sessions left open by a broker that stopped without seeing its
+// clients leave, so devices are not shown online forever. Call before the broker serves.
+func (f *DeviceSessionFlow) RunCloseStale(ctx context.Context, reason string) (int64, error) {
+	return f.deviceOps.CloseOpenSessions(ctx, reason)
+}
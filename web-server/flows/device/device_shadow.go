@@ -0,0 +1,197 @@
+package device
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	deviceops "rootstock/web-server/ops/device"
+	mqttops "rootstock/web-server/ops/mqtt"
+	"rootstock/web-server/ops/pure"
+)
+
+// ErrInvalidShadow is returned when desired shadow state is refused.
+var ErrInvalidShadow = errors.New("invalid device shadow")
+
+// DeviceShadowFlow keeps each device's shadow: the state it should run (desired) and the
+// state it says it runs (reported). Devices report on rootstock/{id}/shadow/update, fetch
+// the whole shadow with rootstock/{id}/shadow/get, and are sent what they have yet to
+// apply on rootstock/{id}/shadow/delta. Owners and campaign researchers set the desired
+// sampling interval and sensors; active campaigns follow enrollments. Shadow access
+// follows command access.
+type DeviceShadowFlow struct {
+	shadows shadowPublisher
+}
+
+// NewDeviceShadowFlow creates the flow with its required ops.
+func NewDeviceShadowFlow(deviceOps *deviceops.Ops, mqttOps *mqttops.Ops) *DeviceShadowFlow {
+	return &DeviceShadowFlow{shadows: shadowPublisher{deviceOps: deviceOps, mqttOps: mqttOps}}
+}
+
+// RunGet answers a device's shadow/get with its full shadow. A device with no shadow yet
+// is sent an empty one.
+func (f *DeviceShadowFlow) RunGet(ctx context.Context, deviceID string) error {
+	shadow, err := f.shadows.get(ctx, deviceID)
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(ShadowDocumentPayload{
+		Version:   shadow.Version,
+		Desired:   shadowStateJSON(shadow.Desired),
+		Reported:  shadowStateJSON(shadow.Reported),
+		Delta:     shadowStateJSON(shadow.Delta),
+		Timestamp: time.Now().UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("marshal shadow document: %w", err)
+	}
+	return f.shadows.mqttOps.PublishShadowDocument(ctx, mqttops.PublishShadowInput{
+		DeviceID: deviceID,
+		Payload:  payload,
+	})
+}
+
+// RunReport stores the state a device reports. An implausible report is not stored:
+// Accepted is false and Reason says why. When the report changed the shadow and the device
+// still differs from what is desired, the delta is sent back.
+func (f *DeviceShadowFlow) RunReport(ctx context.Context, input ReportShadowInput) (*ShadowReportResult, error) {
+	state := pure.ShadowState(input.Reported)
+	if check := pure.ValidateShadowState(state); !check.Valid {
+		return &ShadowReportResult{Reason: check.Reason}, nil
+	}
+	saved, err := f.shadows.deviceOps.UpdateShadowReported(ctx, deviceops.UpdateShadowInput{
+		DeviceID: input.DeviceID,
+		State:    deviceops.ShadowState(pure.NormalizeShadowState(state)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	shadow := fromOpsDeviceShadow(saved)
+	if saved.Changed {
+		f.shadows.sendDelta(ctx, shadow)
+	}
+	return &ShadowReportResult{Accepted: true, Version: shadow.Version, Delta: shadow.Delta}, nil
+}
+
+// RunSetDesired sets the sampling interval and sensors a device should run, for a caller
+// allowed to command it, and sends the device what changed.
+func (f *DeviceShadowFlow) RunSetDesired(ctx context.Context, input SetShadowDesiredInput) (*DeviceShadow, error) {
+	if err := f.authorize(ctx, input.IssuerID, input.DeviceID); err != nil {
+		return nil, err
+	}
+	return f.shadows.setDesired(ctx, input.DeviceID, pure.ShadowState{
+		SamplingIntervalSeconds: input.SamplingIntervalSeconds,
+		EnabledSensors:          input.EnabledSensors,
+	})
+}
+
+// RunView returns a device's shadow for a caller allowed to command it.
+func (f *DeviceShadowFlow) RunView(ctx context.Context, issuerID, deviceID string) (*DeviceShadow, error) {
+	if err := f.authorize(ctx, issuerID, deviceID); err != nil {
+		return nil, err
+	}
+	return f.shadows.get(ctx, deviceID)
+}
+
+func (f *DeviceShadowFlow) authorize(ctx context.Context, issuerID, deviceID string) error {
+	targets, err := f.shadows.deviceOps.ListCommandTargets(ctx, deviceops.CommandTargetsInput{
+		IssuerID: issuerID,
+		DeviceID: deviceID,
+		At:       time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	if !targets.Permitted {
+		return ErrCommandNotPermitted
+	}
+	return nil
+}
+
+// shadowPublisher stores desired state and sends deltas. Shared by the flows that change
+// what a device should be running.
+type shadowPublisher struct {
+	deviceOps *deviceops.Ops
+	mqttOps   *mqttops.Ops
+}
+
+func (p shadowPublisher) get(ctx context.Context, deviceID string) (*DeviceShadow, error) {
+	shadow, err := p.deviceOps.GetShadow(ctx, deviceID)
+	if err != nil {
+		return nil, err
+	}
+	if shadow == nil {
+		return &DeviceShadow{DeviceID: deviceID}, nil
+	}
+	return fromOpsDeviceShadow(shadow), nil
+}
+
+// setDesired validates and merges desired state, sending the delta if it changed.
+// A failed send is logged; the device picks the delta up with its next shadow/get.
+func (p shadowPublisher) setDesired(ctx context.Context, deviceID string, state pure.ShadowState) (*DeviceShadow, error) {
+	if check := pure.ValidateShadowState(state); !check.Valid {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidShadow, check.Reason)
+	}
+	saved, err := p.deviceOps.UpdateShadowDesired(ctx, deviceops.UpdateShadowInput{
+		DeviceID: deviceID,
+		State:    deviceops.ShadowState(pure.NormalizeShadowState(state)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	shadow := fromOpsDeviceShadow(saved)
+	if saved.Changed {
+		p.sendDelta(ctx, shadow)
+	}
+	return shadow, nil
+}
+
+func (p shadowPublisher) sendDelta(ctx context.Context, shadow *DeviceShadow) {
+	if pure.ShadowState(shadow.Delta).IsEmpty() {
+		return
+	}
+	payload, err := json.Marshal(ShadowDeltaPayload{
+		Version:   shadow.Version,
+		State:     shadowStateJSON(shadow.Delta),
+		Timestamp: time.Now().UTC(),
+	})
+	if err == nil {
+		err = p.mqttOps.PublishShadowDelta(ctx, mqttops.PublishShadowInput{DeviceID: shadow.DeviceID, Payload: payload})
+	}
+	if err != nil {
+		slog.WarnContext(ctx, "failed to send shadow delta", "device_id", shadow.DeviceID, "error", err)
+	}
+}
+
+func fromOpsDeviceShadow(s *deviceops.DeviceShadow) *DeviceShadow {
+	return &DeviceShadow{
+		DeviceID:          s.DeviceID,
+		Version:           s.Version,
+		Desired:           ShadowState(s.Desired),
+		Reported:          ShadowState(s.Reported),
+		Delta:             ShadowState(pure.ShadowDelta(pure.ShadowState(s.Desired), pure.ShadowState(s.Reported))),
+		DesiredUpdatedAt:  s.DesiredUpdatedAt,
+		ReportedUpdatedAt: s.ReportedUpdatedAt,
+	}
+}
+
+// shadowStateJSON is a shadow state as devices see it: only the fields that are set.
+func shadowStateJSON(s ShadowState) map[string]any {
+	out := map[string]any{}
+	if s.SamplingIntervalSeconds != nil {
+		out["sampling_interval_seconds"] = *s.SamplingIntervalSeconds
+	}
+	if s.EnabledSensors != nil {
+		out["enabled_sensors"] = s.EnabledSensors
+	}
+	if s.ActiveCampaigns != nil {
+		out["active_campaigns"] = s.ActiveCampaigns
+	}
+	if s.FirmwareVersion != nil {
+		out["firmware_version"] = *s.FirmwareVersion
+	}
+	return out
+}
@@ -1,6 +1,9 @@
 package device
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // Device is the device record returned by device flows.
 type Device struct {
@@ -12,6 +15,8 @@ type Device struct {
 	Tier            int
 	Sensors         []string
 	CertSerial      *string
+	Gateway         bool
+	GatewayID       *string // gateway the device was enrolled through
 	CreatedAt       time.Time
 }
 
@@ -43,7 +48,242 @@ type EnrollInCampaignResult struct {
 	Reason   string
 }
 
-// DeviceConfigPayload is the config pushed to a device after enrollment.
+// DeviceConfigPayload is the retained config on rootstock/{id}/config: every campaign the
+// device is enrolled in that is still running. Devices acknowledge Version on AckTopic.
 type DeviceConfigPayload struct {
-	CampaignID string `json:"campaign_id"`
+	Version   int64                   `json:"version"`
+	IssuedAt  time.Time               `json:"issued_at"`
+	AckTopic  string                  `json:"ack_topic"`
+	Campaigns []CampaignConfigPayload `json:"campaigns"`
+}
+
+// CampaignConfigPayload is one campaign's section of a device config.
+type CampaignConfigPayload struct {
+	CampaignID              string                   `json:"campaign_id"`
+	PublishTopic            string                   `json:"publish_topic"`
+	BatchTopic              string                   `json:"batch_topic"`
+	SamplingIntervalSeconds *int                     `json:"sampling_interval_seconds,omitempty"` // minimum spacing between readings
+	WindowStart             *time.Time               `json:"window_start,omitempty"`
+	WindowEnd               *time.Time               `json:"window_end,omitempty"`
+	Regions                 []json.RawMessage        `json:"regions,omitempty"` // GeoJSON
+	Parameters              []ParameterConfigPayload `json:"parameters"`
+}
+
+// ParameterConfigPayload is a parameter the campaign collects and the unit to report it in.
+type ParameterConfigPayload struct {
+	Name      string   `json:"name"`
+	Unit      string   `json:"unit"`
+	MinRange  *float64 `json:"min_range,omitempty"`
+	MaxRange  *float64 `json:"max_range,omitempty"`
+	Precision *int     `json:"precision,omitempty"`
+	Required  bool     `json:"required,omitempty"`
+}
+
+// DeviceConfigStatus is the config version pushed to a device and the last one it acknowledged.
+type DeviceConfigStatus struct {
+	DeviceID     string
+	Version      int64
+	PushedAt     time.Time
+	AckedVersion *int64
+	Published    bool // the config was sent to the device this time
+}
+
+// ConnectionDecision is the result of AuthorizeConnectionFlow.
+type ConnectionDecision struct {
+	Action  string // pure.ConnectAllow, pure.ConnectRestricted or pure.ConnectDeny
+	Reason  string
+	Gateway bool // the device is registered as a gateway
+}
+
+// CRL is a DER-encoded CRL signed by the device CA.
+type CRL struct {
+	DER        []byte
+	ThisUpdate time.Time
+	NextUpdate time.Time
+}
+
+// OCSPResponse is a DER-encoded OCSP response signed by the device CA.
+type OCSPResponse struct {
+	DER        []byte
+	Status     string // good, revoked or unknown
+	NextUpdate time.Time
+}
+
+// HealthReportResult is the outcome of DeviceHealthFlow.RunReport.
+type HealthReportResult struct {
+	Accepted bool
+	Reason   string   // why the report was refused
+	Raised   []string // alerts newly raised, pure.HealthAlert* names
+	Cleared  []string
+}
+
+// DeviceCommand is one command sent to a device, with what the device reported back.
+type DeviceCommand struct {
+	ID          string
+	DeviceID    string
+	CampaignID  *string // set when sent to a campaign's devices
+	BatchID     string
+	Command     string
+	Params      json.RawMessage
+	IssuedBy    string
+	IssuedAt    time.Time
+	ExpiresAt   time.Time
+	Status      string // sent, acknowledged, succeeded, failed or expired
+	AckedAt     *time.Time
+	CompletedAt *time.Time
+	Result      json.RawMessage
+	Error       *string
+}
+
+// CommandBatch is the outcome of DeviceCommandFlow.RunSend.
+type CommandBatch struct {
+	BatchID   string
+	Commands  []DeviceCommand
+	Published int // commands delivered to the broker; the rest are resent when their device subscribes
+}
+
+// DeviceCommandPayload is what a device receives on rootstock/{id}/command. The device
+// acknowledges it, then reports the outcome, on the result topic.
+type DeviceCommandPayload struct {
+	CommandID   string          `json:"command_id"`
+	Command     string          `json:"command"`
+	Params      json.RawMessage `json:"params"`
+	IssuedAt    time.Time       `json:"issued_at"`
+	ExpiresAt   time.Time       `json:"expires_at"`
+	ResultTopic string          `json:"result_topic"`
+}
+
+// CommandReportResult is the outcome of DeviceCommandFlow.RunResult.
+type CommandReportResult struct {
+	Accepted bool
+	Reason   string // why the report was refused
+}
+
+// ShadowState is one side of a device shadow. A nil field is not set; an empty list is
+// set to nothing.
+type ShadowState struct {
+	SamplingIntervalSeconds *int
+	EnabledSensors          []string
+	ActiveCampaigns         []string
+	FirmwareVersion         *string
+}
+
+// DeviceShadow is the state a device should run, the state it last reported, and the
+// difference it has yet to apply.
+type DeviceShadow struct {
+	DeviceID          string
+	Version           int64
+	Desired           ShadowState
+	Reported          ShadowState
+	Delta             ShadowState // desired fields the device has not reported yet
+	DesiredUpdatedAt  *time.Time
+	ReportedUpdatedAt *time.Time
+}
+
+// ShadowReportResult is the outcome of DeviceShadowFlow.RunReport.
+type ShadowReportResult struct {
+	Accepted bool
+	Reason   string // why the report was refused
+	Version  int64
+	Delta    ShadowState
+}
+
+// ShadowDocumentPayload is a device's full shadow, sent on rootstock/{id}/shadow/get/accepted.
+// Each state holds only its set fields.
+type ShadowDocumentPayload struct {
+	Version   int64          `json:"version"`
+	Desired   map[string]any `json:"desired"`
+	Reported  map[string]any `json:"reported"`
+	Delta     map[string]any `json:"delta"`
+	Timestamp time.Time      `json:"timestamp"`
+}
+
+// ShadowDeltaPayload is what a device receives on rootstock/{id}/shadow/delta: the desired
+// fields it has not yet reported.
+type ShadowDeltaPayload struct {
+	Version   int64          `json:"version"`
+	State     map[string]any `json:"state"`
+	Timestamp time.Time      `json:"timestamp"`
+}
+
+// FirmwareRelease is a firmware build offered to a device class, with its rollout stage.
+type FirmwareRelease struct {
+	ID             string
+	Class          string
+	Version        string
+	SHA256         string
+	URL            string
+	SizeBytes      *int64
+	Notes          string
+	SecurityFix    bool // installing it reinstates a suspended device
+	RolloutPercent int
+	Cohort         []string
+	CreatedBy      string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// FirmwareRollout is a release and how many devices were sent its manifest by the change.
+type FirmwareRollout struct {
+	Release   FirmwareRelease
+	Published int
+}
+
+// FirmwareInstall is one device's progress installing a release.
+type FirmwareInstall struct {
+	ReleaseID  string
+	DeviceID   string
+	Status     string // notified, downloading, installing, installed or failed
+	Error      *string
+	NotifiedAt time.Time
+	UpdatedAt  time.Time
+}
+
+// FirmwareInstallResult is the outcome of FirmwareOTAFlow.RunInstallReport.
+type FirmwareInstallResult struct {
+	Accepted   bool
+	Reason     string // why the report was refused
+	Reinstated bool   // a suspended device installed a security fix
+}
+
+// FirmwareManifestPayload is what a device receives, retained, on rootstock/{id}/ota. The
+// device downloads the image, checks its SHA-256, installs it, and reports progress on
+// the status topic.
+type FirmwareManifestPayload struct {
+	ReleaseID   string    `json:"release_id"`
+	Version     string    `json:"version"`
+	SHA256      string    `json:"sha256"`
+	URL         string    `json:"url"`
+	SizeBytes   *int64    `json:"size_bytes,omitempty"`
+	SecurityFix bool      `json:"security_fix"`
+	StatusTopic string    `json:"status_topic"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// EnrollChildResult is the certificate issued to a device enrolled through a gateway.
+type EnrollChildResult struct {
+	DeviceID  string
+	GatewayID string
+	CertPEM   []byte
+	Serial    string
+	NotBefore time.Time
+	NotAfter  time.Time
+}
+
+// GatewayChild is a device enrolled through a gateway.
+type GatewayChild struct {
+	DeviceID string
+	Status   string
+	Class    string
+}
+
+// StationUpload is a weather-station upload translated into a reading, with the campaigns it is for.
+type StationUpload struct {
+	DeviceID        string
+	Protocol        string
+	CampaignIDs     []string // live campaigns the device is enrolled in; empty when none
+	Values          map[string]float64
+	Timestamp       time.Time
+	FirmwareVersion string
+	IgnoredFields   []string
 }
@@ -2,7 +2,6 @@ package device
 
 import (
 	"context"
-	"encoding/json"
 	"log/slog"
 	"time"
 
@@ -94,18 +93,9 @@ func (f *EnrollInCampaignFlow) Run(ctx context.Context, input EnrollInCampaignIn
 		slog.WarnContext(ctx, "failed to add graph enrollment", "device_id", input.DeviceID, "campaign_id", input.CampaignID, "error", err)
 	}
 
-	// 6. Push config to device via MQTT
-	configPayload, err := json.Marshal(DeviceConfigPayload{
-		CampaignID: input.CampaignID,
-	})
-	if err != nil {
-		return nil, err
-	}
-
-	if err := f.mqttOps.PushDeviceConfig(ctx, mqttops.PushDeviceConfigInput{
-		DeviceID: input.DeviceID,
-		Payload:  configPayload,
-	}); err != nil {
+	// 6. Push the device's updated config via MQTT
+	pusher := configPusher{deviceOps: f.deviceOps, campaignOps: f.campaignOps, mqttOps: f.mqttOps}
+	if _, err := pusher.push(ctx, input.DeviceID); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,276 @@
+package device
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	deviceops "rootstock/web-server/ops/device"
+	mqttops "rootstock/web-server/ops/mqtt"
+	"rootstock/web-server/ops/pure"
+)
+
+// ErrInvalidFirmwareRelease is returned when a release or its rollout stage is refused.
+var ErrInvalidFirmwareRelease = errors.New("invalid firmware release")
+
+// ErrFirmwareReleaseExists is returned when the class already has a release of that version.
+var ErrFirmwareReleaseExists = errors.New("firmware release already exists")
+
+// ErrFirmwareReleaseNotFound is returned when the named release does not exist.
+var ErrFirmwareReleaseNotFound = errors.New("firmware release not found")
+
+// FirmwareOTAFlow offers firmware releases to devices over the air. A release belongs to
+// one device class and is rolled out in stages: a percentage of the class, chosen by a
+// stable per-device bucket, plus a named cohort. Each device offered a release is sent a
+// retained manifest on rootstock/{id}/ota and reports progress on rootstock/{id}/ota/status.
+// Suspended devices are offered a security fix at any stage, and are reinstated once they
+// report it installed. Narrowing a rollout does not withdraw manifests already sent.
+type FirmwareOTAFlow struct {
+	deviceOps  *deviceops.Ops
+	mqttOps    *mqttops.Ops
+	shadows    shadowPublisher
+	reinstater deviceReinstater
+}
+
+// NewFirmwareOTAFlow creates the flow with its required ops.
+func NewFirmwareOTAFlow(deviceOps *deviceops.Ops, mqttOps *mqttops.Ops) *FirmwareOTAFlow {
+	return &FirmwareOTAFlow{
+		deviceOps:  deviceOps,
+		mqttOps:    mqttOps,
+		shadows:    shadowPublisher{deviceOps: deviceOps, mqttOps: mqttOps},
+		reinstater: deviceReinstater{deviceOps: deviceOps, mqttOps: mqttOps},
+	}
+}
+
+// RunCreateRelease registers a release and sends its manifest to the devices its first
+// stage covers.
+func (f *FirmwareOTAFlow) RunCreateRelease(ctx context.Context, input CreateFirmwareReleaseInput) (*FirmwareRollout, error) {
+	check := pure.ValidateFirmwareRelease(pure.FirmwareRelease{
+		Class:          input.Class,
+		Version:        input.Version,
+		SHA256:         input.SHA256,
+		URL:            input.URL,
+		RolloutPercent: input.RolloutPercent,
+		Cohort:         input.Cohort,
+	})
+	if !check.Valid {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidFirmwareRelease, check.Reason)
+	}
+	release, err := f.deviceOps.CreateFirmwareRelease(ctx, deviceops.CreateFirmwareReleaseInput(input))
+	if err != nil {
+		return nil, err
+	}
+	if release == nil {
+		return nil, fmt.Errorf("%w: %s %s", ErrFirmwareReleaseExists, input.Class, input.Version)
+	}
+	return f.rollout(ctx, release)
+}
+
+// RunSetRollout moves a release to a new stage and sends its manifest to the devices the
+// stage newly covers.
+func (f *FirmwareOTAFlow) RunSetRollout(ctx context.Context, input SetFirmwareRolloutInput) (*FirmwareRollout, error) {
+	if check := pure.ValidateFirmwareRollout(input.RolloutPercent, input.Cohort); !check.Valid {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidFirmwareRelease, check.Reason)
+	}
+	existing, err := f.deviceOps.GetFirmwareRelease(ctx, input.ReleaseID)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, fmt.Errorf("%w: %s", ErrFirmwareReleaseNotFound, input.ReleaseID)
+	}
+	release, err := f.deviceOps.SetFirmwareRollout(ctx, deviceops.SetFirmwareRolloutInput(input))
+	if err != nil {
+		return nil, err
+	}
+	return f.rollout(ctx, release)
+}
+
+// RunListReleases lists a class's releases, or every release when class is empty, newest first.
+func (f *FirmwareOTAFlow) RunListReleases(ctx context.Context, class string) ([]FirmwareRelease, error) {
+	releases, err := f.deviceOps.ListFirmwareReleases(ctx, class)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]FirmwareRelease, len(releases))
+	for i, r := range releases {
+		out[i] = FirmwareRelease(r)
+	}
+	return out, nil
+}
+
+// RunListInstalls returns each device's progress installing a release, most recent first.
+func (f *FirmwareOTAFlow) RunListInstalls(ctx context.Context, releaseID string) ([]FirmwareInstall, error) {
+	installs, err := f.deviceOps.ListFirmwareInstalls(ctx, releaseID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]FirmwareInstall, len(installs))
+	for i, in := range installs {
+		out[i] = FirmwareInstall(in)
+	}
+	return out, nil
+}
+
+// RunInstallReport records a device's progress installing a release it was sent. Once
+// installed, the release's version is recorded as the device's firmware, and a suspended
+// device that installed a security fix is reinstated.
+func (f *FirmwareOTAFlow) RunInstallReport(ctx context.Context, input FirmwareInstallReportInput) (*FirmwareInstallResult, error) {
+	if check := pure.ValidateInstallReport(input.Status, input.Error); !check.Valid {
+		return &FirmwareInstallResult{Reason: check.Reason}, nil
+	}
+	if input.ReleaseID == "" {
+		return &FirmwareInstallResult{Reason: "release_id is required"}, nil
+	}
+
+	var errMsg *string
+	if input.Error != "" {
+		errMsg = &input.Error
+	}
+	applied, err := f.deviceOps.RecordFirmwareInstall(ctx, deviceops.RecordFirmwareInstallInput{
+		DeviceID:  input.DeviceID,
+		ReleaseID: input.ReleaseID,
+		Status:    input.Status,
+		Error:     errMsg,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !applied {
+		return &FirmwareInstallResult{Reason: "release not sent to this device or already installed"}, nil
+	}
+	result := &FirmwareInstallResult{Accepted: true}
+	if input.Status != pure.InstallStatusInstalled {
+		return result, nil
+	}
+
+	release, err := f.deviceOps.GetFirmwareRelease(ctx, input.ReleaseID)
+	if err != nil {
+		return nil, err
+	}
+	if release == nil {
+		return nil, fmt.Errorf("%w: %s", ErrFirmwareReleaseNotFound, input.ReleaseID)
+	}
+	if _, err := f.deviceOps.UpdateShadowReported(ctx, deviceops.UpdateShadowInput{
+		DeviceID: input.DeviceID,
+		State:    deviceops.ShadowState{FirmwareVersion: &release.Version},
+	}); err != nil {
+		slog.WarnContext(ctx, "failed to report installed firmware in shadow", "device_id", input.DeviceID, "error", err)
+	}
+	if !release.SecurityFix {
+		return result, nil
+	}
+	device, err := f.deviceOps.GetDevice(ctx, input.DeviceID)
+	if err != nil {
+		return nil, err
+	}
+	if device.Status == "suspended" {
+		if err := f.reinstater.reinstate(ctx, input.DeviceID); err != nil {
+			return nil, fmt.Errorf("reinstate patched device: %w", err)
+		}
+		result.Reinstated = true
+	}
+	return result, nil
+}
+
+// rollout sends the release's manifest to each device of its class the current stage
+// covers that has not been sent it, or failed to install it. Devices covered by a newer
+// release of the class are left to that release, and devices already running the
+// release's version or a later one are not offered a downgrade. A failed publish is logged; the device
+// is sent the manifest again on the next rollout change.
+func (f *FirmwareOTAFlow) rollout(ctx context.Context, release *deviceops.FirmwareRelease) (*FirmwareRollout, error) {
+	candidates, err := f.deviceOps.ListFirmwareCandidates(ctx, release.ID)
+	if err != nil {
+		return nil, err
+	}
+	releases, err := f.deviceOps.ListFirmwareReleases(ctx, release.Class)
+	if err != nil {
+		return nil, err
+	}
+	var newer []deviceops.FirmwareRelease
+	for _, r := range releases {
+		if r.ID == release.ID {
+			break
+		}
+		newer = append(newer, r)
+	}
+
+	var sent []string
+	for _, c := range candidates {
+		if c.InstallStatus != nil && *c.InstallStatus != pure.InstallStatusFailed {
+			continue
+		}
+		if !offered(release, c) || superseded(newer, c) || upToDate(release, c) {
+			continue
+		}
+		if err := f.publish(ctx, release, c.DeviceID); err != nil {
+			slog.WarnContext(ctx, "failed to publish firmware manifest", "device_id", c.DeviceID, "release_id", release.ID, "error", err)
+			continue
+		}
+		sent = append(sent, c.DeviceID)
+		if _, err := f.shadows.setDesired(ctx, c.DeviceID, pure.ShadowState{FirmwareVersion: &release.Version}); err != nil {
+			slog.WarnContext(ctx, "failed to set desired firmware", "device_id", c.DeviceID, "error", err)
+		}
+	}
+	if len(sent) > 0 {
+		if err := f.deviceOps.MarkFirmwareNotified(ctx, release.ID, sent); err != nil {
+			return nil, err
+		}
+	}
+	return &FirmwareRollout{Release: FirmwareRelease(*release), Published: len(sent)}, nil
+}
+
+func (f *FirmwareOTAFlow) publish(ctx context.Context, release *deviceops.FirmwareRelease, deviceID string) error {
+	payload, err := json.Marshal(FirmwareManifestPayload{
+		ReleaseID:   release.ID,
+		Version:     release.Version,
+		SHA256:      release.SHA256,
+		URL:         release.URL,
+		SizeBytes:   release.SizeBytes,
+		SecurityFix: release.SecurityFix,
+		StatusTopic: fmt.Sprintf("%s/%s/ota/status", mqttops.TopicPrefix, deviceID),
+		Timestamp:   time.Now().UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("marshal firmware manifest: %w", err)
+	}
+	return f.mqttOps.PublishFirmwareManifest(ctx, mqttops.PublishFirmwareManifestInput{
+		DeviceID: deviceID,
+		Payload:  payload,
+	})
+}
+
+// offered reports whether the release's current stage covers the device.
+func offered(release *deviceops.FirmwareRelease, c deviceops.FirmwareCandidate) bool {
+	return pure.InRollout(release.ID, release.RolloutPercent, release.Cohort, c.DeviceID) ||
+		(release.SecurityFix && c.Status == "suspended")
+}
+
+// superseded reports whether a newer release the device is not running covers it.
+func superseded(newer []deviceops.FirmwareRelease, c deviceops.FirmwareCandidate) bool {
+	for i := range newer {
+		if newer[i].Version != c.FirmwareVersion && offered(&newer[i], c) {
+			return true
+		}
+	}
+	return false
+}
+
+// upToDate reports whether the device runs the release's version or a later one. Versions
+// that do not parse or compare (a date-based release for semantic firmware) are not
+// ordered, so the device is still offered the release.
+func upToDate(release *deviceops.FirmwareRelease, c deviceops.FirmwareCandidate) bool {
+	running, err := pure.ParseVersion(c.FirmwareVersion)
+	if err != nil {
+		return false
+	}
+	target, err := pure.ParseVersion(release.Version)
+	if err != nil {
+		return false
+	}
+	cmp, err := pure.CompareVersions(running, target)
+	return err == nil && cmp >= 0
+}
@@ -0,0 +1,97 @@
+package device
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	certops "rootstock/web-server/ops/cert"
+	deviceops "rootstock/web-server/ops/device"
+)
+
+// ErrNotGateway is returned when a device that is not an active gateway enrolls a child.
+var ErrNotGateway = errors.New("device is not an active gateway")
+
+// ErrInvalidChildCode is returned for a code that is not found, expired, already used, or not
+// for a non-gateway device of the gateway's owner.
+var ErrInvalidChildCode = errors.New("invalid child enrollment code")
+
+// ErrInvalidChildCSR is returned for a CSR that does not parse or is not self-signed.
+var ErrInvalidChildCSR = errors.New("invalid child csr")
+
+// GatewayEnrollmentFlow enrolls child devices through a gateway (an ESP32 hub, a LoRa
+// gateway) that relays for them. The child generates its own keypair and CSR; the gateway
+// submits the CSR with the child's enrollment code and hands the issued certificate back,
+// so the child's private key never leaves it. The gateway is recorded as the child's, and
+// may then publish on the child's topics.
+type GatewayEnrollmentFlow struct {
+	deviceOps *deviceops.Ops
+	certOps   *certops.Ops
+}
+
+// NewGatewayEnrollmentFlow creates the flow with its required ops.
+func NewGatewayEnrollmentFlow(deviceOps *deviceops.Ops, certOps *certops.Ops) *GatewayEnrollmentFlow {
+	return &GatewayEnrollmentFlow{deviceOps: deviceOps, certOps: certOps}
+}
+
+// Run enrolls a child device on a gateway's behalf:
+// RedeemDelegatedCode → IssueCert → RecordIssuedCert → UpdateDeviceStatus(active).
+// The child must belong to the gateway's owner and not itself be a gateway. The CSR is
+// checked before the code is redeemed, so a malformed one does not use the code up.
+func (f *GatewayEnrollmentFlow) Run(ctx context.Context, input EnrollChildInput) (*EnrollChildResult, error) {
+	gateway, err := f.deviceOps.GetDevice(ctx, input.GatewayID)
+	if err != nil {
+		return nil, err
+	}
+	if !gateway.Gateway || gateway.Status != "active" {
+		return nil, fmt.Errorf("%w: %s", ErrNotGateway, input.GatewayID)
+	}
+
+	csr, err := x509.ParseCertificateRequest(input.CSR)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidChildCSR, err)
+	}
+	if err := csr.CheckSignature(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidChildCSR, err)
+	}
+
+	code, err := f.deviceOps.RedeemDelegatedCode(ctx, deviceops.RedeemDelegatedCodeInput{
+		Code:      input.EnrollmentCode,
+		GatewayID: input.GatewayID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if code == nil {
+		return nil, ErrInvalidChildCode
+	}
+
+	issued, err := f.certOps.IssueCert(ctx, certops.IssueCertInput{
+		DeviceID: code.DeviceID,
+		CSR:      input.CSR,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := f.deviceOps.RecordIssuedCert(ctx, deviceops.RecordIssuedCertInput{
+		DeviceID:  code.DeviceID,
+		Serial:    issued.Serial,
+		NotBefore: issued.NotBefore,
+		NotAfter:  issued.NotAfter,
+	}); err != nil {
+		return nil, err
+	}
+	if err := f.deviceOps.UpdateDeviceStatus(ctx, code.DeviceID, "active"); err != nil {
+		return nil, err
+	}
+
+	return &EnrollChildResult{
+		DeviceID:  code.DeviceID,
+		GatewayID: input.GatewayID,
+		CertPEM:   issued.CertPEM,
+		Serial:    issued.Serial,
+		NotBefore: issued.NotBefore,
+		NotAfter:  issued.NotAfter,
+	}, nil
+}
@@ -35,6 +35,8 @@ func fromOpsDevice(d *deviceops.Device) *Device {
 		Tier:            d.Tier,
 		Sensors:         d.Sensors,
 		CertSerial:      d.CertSerial,
+		Gateway:         d.Gateway,
+		GatewayID:       d.GatewayID,
 		CreatedAt:       d.CreatedAt,
 	}
 }
@@ -6,10 +6,14 @@ import (
 	"testing"
 
 	"github.com/jackc/pgx/v5/pgxpool"
+	mochi "github.com/mochi-mqtt/server/v2"
+	"github.com/mochi-mqtt/server/v2/hooks/auth"
 
 	"rootstock/web-server/config"
 	deviceops "rootstock/web-server/ops/device"
+	mqttops "rootstock/web-server/ops/mqtt"
 	devicerepo "rootstock/web-server/repo/device"
+	mqttrepo "rootstock/web-server/repo/mqtt"
 	sqlmigrate "rootstock/web-server/repo/sql/migrate"
 )
 
@@ -42,6 +46,22 @@ func setupDeviceFlowTest(t *testing.T) (*deviceops.Ops, *pgxpool.Pool) {
 	return dOps, pool
 }
 
+// setupMQTTOps starts an in-process broker that accepts any client.
+func setupMQTTOps(t *testing.T) *mqttops.Ops {
+	t.Helper()
+	server := mochi.New(&mochi.Options{InlineClient: true})
+	if err := server.AddHook(new(auth.AllowHook), nil); err != nil {
+		t.Fatalf("add allow hook: %v", err)
+	}
+	go server.Serve()
+	mRepo := mqttrepo.NewRepository(server)
+	t.Cleanup(func() {
+		mRepo.Shutdown()
+		server.Close()
+	})
+	return mqttops.NewOps(mRepo)
+}
+
 func TestGetDevice(t *testing.T) {
 	dOps, _ := setupDeviceFlowTest(t)
 	ctx := context.Background()
@@ -75,7 +95,7 @@ func TestRevokeDevice(t *testing.T) {
 	})
 	dOps.UpdateDeviceStatus(ctx, created.ID, "active")
 
-	flow := NewRevokeDeviceFlow(dOps)
+	flow := NewRevokeDeviceFlow(dOps, setupMQTTOps(t))
 	if err := flow.Run(ctx, RevokeDeviceInput{DeviceID: created.ID}); err != nil {
 		t.Fatalf("Run(): %v", err)
 	}
@@ -96,7 +116,7 @@ func TestReinstateDevice(t *testing.T) {
 	dOps.UpdateDeviceStatus(ctx, created.ID, "active")
 	dOps.UpdateDeviceStatus(ctx, created.ID, "revoked")
 
-	flow := NewReinstateDeviceFlow(dOps)
+	flow := NewReinstateDeviceFlow(dOps, setupMQTTOps(t))
 	if err := flow.Run(ctx, ReinstateDeviceInput{DeviceID: created.ID}); err != nil {
 		t.Fatalf("Run(): %v", err)
 	}
@@ -1,5 +1,10 @@
 package device
 
+import (
+	"encoding/json"
+	"time"
+)
+
 // GetDeviceInput is what callers send to GetDeviceFlow.
 type GetDeviceInput struct {
 	DeviceID string
@@ -32,3 +37,156 @@ type EnrollInCampaignInput struct {
 	DeviceID   string
 	CampaignID string
 }
+
+// SetClassUnitsInput is what callers send to SetClassUnitsFlow.
+type SetClassUnitsInput struct {
+	Class string
+	Units map[string]string // sensor -> unit; any spelling pure.NormalizeUnit accepts
+}
+
+// AuthorizeConnectionInput is what callers send to AuthorizeConnectionFlow.
+type AuthorizeConnectionInput struct {
+	DeviceID   string // from mTLS cert CN
+	CertSerial string // presented certificate serial, hex
+}
+
+// CheckCertStatusInput is what callers send to CheckCertStatusFlow.
+type CheckCertStatusInput struct {
+	Request []byte // DER-encoded OCSP request
+}
+
+// OpenSessionInput is what callers send to DeviceSessionFlow.RunOpen.
+type OpenSessionInput struct {
+	DeviceID    string
+	ConnectedAt time.Time
+	RemoteAddr  string
+	CertSerial  string // hex; empty if unknown
+}
+
+// CloseSessionInput is what callers send to DeviceSessionFlow.RunClose.
+type CloseSessionInput struct {
+	ID             string
+	DisconnectedAt time.Time
+	Reason         string
+	Graceful       bool // the client sent DISCONNECT
+	WillPublished  bool // the broker published the client's last will as it dropped
+}
+
+// HealthSettings are the deployment-tunable thresholds DeviceHealthFlow alerts on.
+type HealthSettings struct {
+	LowBatteryPercent float64 // battery level that notifies the owner; 0 disables
+	SilentHours       int     // hours without a heartbeat that notify the owner; 0 disables
+}
+
+// ReportHealthInput is what callers send to DeviceHealthFlow.RunReport.
+// Metrics the device does not report are nil.
+type ReportHealthInput struct {
+	DeviceID        string // from the topic
+	ReportedAt      time.Time
+	BatteryPercent  *float64
+	RSSIDbm         *int
+	UptimeSeconds   *int64
+	FreeMemoryBytes *int64
+	SensorFaults    []string
+	FirmwareBuild   *string
+}
+
+// AckConfigInput is what callers send to DeviceConfigFlow.RunAck.
+type AckConfigInput struct {
+	DeviceID string // from the topic
+	Version  int64
+}
+
+// CommandSettings bound how long a command waits for its device.
+type CommandSettings struct {
+	DefaultTTLMinutes int
+	MaxTTLMinutes     int
+}
+
+// SendCommandInput is what callers send to DeviceCommandFlow.RunSend. Exactly one of
+// DeviceID and CampaignID is set.
+type SendCommandInput struct {
+	IssuerID        string // app user ID
+	DeviceID        string
+	CampaignID      string
+	Command         string // pure.Command* names
+	IntervalSeconds *int
+	Sensors         []string
+	TTLSeconds      int // 0 takes the default
+}
+
+// ListCommandsInput is what callers send to DeviceCommandFlow.RunList. Exactly one of
+// DeviceID and CampaignID is set.
+type ListCommandsInput struct {
+	IssuerID   string
+	DeviceID   string
+	CampaignID string
+	Limit      int // 0 takes the default
+}
+
+// CommandResultInput is what callers send to DeviceCommandFlow.RunResult.
+type CommandResultInput struct {
+	DeviceID  string // from the topic
+	CommandID string
+	Status    string // acknowledged, succeeded or failed
+	Result    json.RawMessage
+	Error     string
+}
+
+// ReportShadowInput is what callers send to DeviceShadowFlow.RunReport.
+type ReportShadowInput struct {
+	DeviceID string // from the topic
+	Reported ShadowState
+}
+
+// SetShadowDesiredInput is what callers send to DeviceShadowFlow.RunSetDesired. Active
+// campaigns follow enrollments and are not set here.
+type SetShadowDesiredInput struct {
+	IssuerID                string // app user ID
+	DeviceID                string
+	SamplingIntervalSeconds *int
+	EnabledSensors          []string // nil leaves the desired sensors as they are
+}
+
+// CreateFirmwareReleaseInput is what callers send to FirmwareOTAFlow.RunCreateRelease.
+type CreateFirmwareReleaseInput struct {
+	Class          string
+	Version        string
+	SHA256         string // hex
+	URL            string // https
+	SizeBytes      *int64
+	Notes          string
+	SecurityFix    bool
+	RolloutPercent int      // 0 offers the release to its cohort only
+	Cohort         []string // device IDs offered the release whatever the percentage
+	CreatedBy      string   // app user ID
+}
+
+// SetFirmwareRolloutInput is what callers send to FirmwareOTAFlow.RunSetRollout.
+type SetFirmwareRolloutInput struct {
+	ReleaseID      string
+	RolloutPercent int
+	Cohort         []string
+}
+
+// FirmwareInstallReportInput is what callers send to FirmwareOTAFlow.RunInstallReport.
+type FirmwareInstallReportInput struct {
+	DeviceID  string // from the topic
+	ReleaseID string
+	Status    string // downloading, installing, installed or failed
+	Error     string
+}
+
+// EnrollChildInput is what callers send to GatewayEnrollmentFlow.
+type EnrollChildInput struct {
+	GatewayID      string // from the gateway's mTLS cert CN
+	EnrollmentCode string // the child's code
+	CSR            []byte // DER-encoded PKCS#10, generated on the child
+}
+
+// StationUploadInput is what callers send to StationUploadFlow.
+type StationUploadInput struct {
+	Token      string              // from the upload URL
+	Fields     map[string][]string // query string and form fields, as sent
+	ReceivedAt time.Time
+}
@@ -5,10 +5,11 @@ import (
 
 	certops "rootstock/web-server/ops/cert"
 	deviceops "rootstock/web-server/ops/device"
+	"rootstock/web-server/ops/pure"
 )
 
 // RegisterDeviceFlow orchestrates device enrollment:
-// RedeemEnrollmentCode → IssueCert → UpdateCertSerial → UpdateDeviceStatus(active).
+// RedeemEnrollmentCode → GetDevice → IssueCert → RecordIssuedCert → UpdateDeviceStatus(active).
 type RegisterDeviceFlow struct {
 	deviceOps *deviceops.Ops
 	certOps   *certops.Ops
@@ -27,17 +28,27 @@ func (f *RegisterDeviceFlow) Run(ctx context.Context, input RegisterDeviceInput)
 		return nil, err
 	}
 
-	// 2. Issue cert (CN = code.DeviceID, CSR from device)
+	// 2. Issue cert (CN = code.DeviceID, CSR from device; OU marks a gateway)
+	device, err := f.deviceOps.GetDevice(ctx, code.DeviceID)
+	if err != nil {
+		return nil, err
+	}
 	issued, err := f.certOps.IssueCert(ctx, certops.IssueCertInput{
 		DeviceID: code.DeviceID,
 		CSR:      input.CSR,
+		Kind:     certKind(device),
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	// 3. Record cert serial on device
-	if err := f.deviceOps.UpdateCertSerial(ctx, code.DeviceID, issued.Serial); err != nil {
+	// 3. Record cert in the ledger and as the device's current cert
+	if err := f.deviceOps.RecordIssuedCert(ctx, deviceops.RecordIssuedCertInput{
+		DeviceID:  code.DeviceID,
+		Serial:    issued.Serial,
+		NotBefore: issued.NotBefore,
+		NotAfter:  issued.NotAfter,
+	}); err != nil {
 		return nil, err
 	}
 
@@ -54,3 +65,11 @@ func (f *RegisterDeviceFlow) Run(ctx context.Context, input RegisterDeviceInput)
 		NotAfter:  issued.NotAfter,
 	}, nil
 }
+
+// certKind is the kind recorded in a device's certificate: gateways' are marked as such.
+func certKind(d *deviceops.Device) string {
+	if d.Gateway {
+		return pure.CertKindGateway
+	}
+	return ""
+}
@@ -158,7 +158,7 @@ func setupCertRepo(t *testing.T) certrepo.Repository {
 		NotAfter:              time.Now().UTC().AddDate(1, 0, 0),
 		IsCA:                  true,
 		BasicConstraintsValid: true,
-		KeyUsage:              x509.KeyUsageCertSign,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
 	}
 	caDER, _ := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
 
@@ -170,7 +170,7 @@ func setupCertRepo(t *testing.T) certrepo.Repository {
 	os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600)
 
 	repo, err := certrepo.NewRepository(config.CertConfig{
-		CACertPath: certPath, CAKeyPath: keyPath, CertLifetimeDays: 90,
+		CACertPath: certPath, CAKeyPath: keyPath, CertLifetimeDays: 90, CRLValidityHours: 24, OCSPValidityMinutes: 60,
 	})
 	if err != nil {
 		t.Fatalf("NewRepository(): %v", err)
@@ -2,21 +2,46 @@ package device
 
 import (
 	"context"
+	"fmt"
+	"log/slog"
 
 	deviceops "rootstock/web-server/ops/device"
+	mqttops "rootstock/web-server/ops/mqtt"
 )
 
 // ReinstateDeviceFlow marks a revoked device as active again.
 type ReinstateDeviceFlow struct {
-	deviceOps *deviceops.Ops
+	reinstater deviceReinstater
 }
 
 // NewReinstateDeviceFlow creates the flow with its required ops.
-func NewReinstateDeviceFlow(deviceOps *deviceops.Ops) *ReinstateDeviceFlow {
-	return &ReinstateDeviceFlow{deviceOps: deviceOps}
+func NewReinstateDeviceFlow(deviceOps *deviceops.Ops, mqttOps *mqttops.Ops) *ReinstateDeviceFlow {
+	return &ReinstateDeviceFlow{reinstater: deviceReinstater{deviceOps: deviceOps, mqttOps: mqttOps}}
 }
 
-// Run reinstates a revoked device.
+// Run reinstates a revoked device and lifts the hold on its certificates. A live
+// session (a suspended device restricted to renew/cert) is dropped so the device
+// reconnects with its full topic access.
 func (f *ReinstateDeviceFlow) Run(ctx context.Context, input ReinstateDeviceInput) error {
-	return f.deviceOps.UpdateDeviceStatus(ctx, input.DeviceID, "active")
+	return f.reinstater.reinstate(ctx, input.DeviceID)
+}
+
+// deviceReinstater brings a revoked or suspended device back. Shared by the flows that
+// reinstate devices.
+type deviceReinstater struct {
+	deviceOps *deviceops.Ops
+	mqttOps   *mqttops.Ops
+}
+
+func (r deviceReinstater) reinstate(ctx context.Context, deviceID string) error {
+	if err := r.deviceOps.UpdateDeviceStatus(ctx, deviceID, "active"); err != nil {
+		return err
+	}
+	if _, err := r.deviceOps.SetCertHold(ctx, deviceID, false); err != nil {
+		return fmt.Errorf("release certificates: %w", err)
+	}
+	if _, err := r.mqttOps.DisconnectDevice(ctx, deviceID); err != nil {
+		slog.WarnContext(ctx, "disconnect reinstated device", "device_id", deviceID, "error", err)
+	}
+	return nil
 }
@@ -8,7 +8,7 @@ import (
 )
 
 // RenewCertFlow orchestrates certificate renewal:
-// IssueCert → UpdateCertSerial.
+// GetDevice → IssueCert → RecordIssuedCert, which revokes the superseded cert.
 type RenewCertFlow struct {
 	deviceOps *deviceops.Ops
 	certOps   *certops.Ops
@@ -21,17 +21,27 @@ func NewRenewCertFlow(deviceOps *deviceops.Ops, certOps *certops.Ops) *RenewCert
 
 // Run renews a device certificate. Device ID comes from mTLS cert CN.
 func (f *RenewCertFlow) Run(ctx context.Context, input RenewCertInput) (*RenewCertResult, error) {
-	// 1. Issue new cert
+	// 1. Issue new cert of the same kind
+	device, err := f.deviceOps.GetDevice(ctx, input.DeviceID)
+	if err != nil {
+		return nil, err
+	}
 	issued, err := f.certOps.IssueCert(ctx, certops.IssueCertInput{
 		DeviceID: input.DeviceID,
 		CSR:      input.CSR,
+		Kind:     certKind(device),
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	// 2. Update cert serial on device
-	if err := f.deviceOps.UpdateCertSerial(ctx, input.DeviceID, issued.Serial); err != nil {
+	// 2. Record cert in the ledger and as the device's current cert
+	if err := f.deviceOps.RecordIssuedCert(ctx, deviceops.RecordIssuedCertInput{
+		DeviceID:  input.DeviceID,
+		Serial:    issued.Serial,
+		NotBefore: issued.NotBefore,
+		NotAfter:  issued.NotAfter,
+	}); err != nil {
 		return nil, err
 	}
 
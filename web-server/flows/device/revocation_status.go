@@ -0,0 +1,132 @@
+package device
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	certops "rootstock/web-server/ops/cert"
+	deviceops "rootstock/web-server/ops/device"
+	"rootstock/web-server/ops/pure"
+)
+
+// crlRevisionCheckInterval bounds how often a cached CRL is checked against the
+// revoked set, so unauthenticated CRL fetches cost at most one lookup per interval.
+const crlRevisionCheckInterval = 10 * time.Second
+
+// GetCRLFlow signs a CRL of the revoked, unexpired certificates in the ledger. The
+// signed CRL is served from cache until its NextUpdate or until the revoked set
+// changes, so repeat fetches see the same CRL number and validity window.
+type GetCRLFlow struct {
+	deviceOps *deviceops.Ops
+	certOps   *certops.Ops
+
+	mu        sync.Mutex
+	cached    *CRL
+	revision  int64
+	checkedAt time.Time
+}
+
+// NewGetCRLFlow creates the flow with its required ops.
+func NewGetCRLFlow(deviceOps *deviceops.Ops, certOps *certops.Ops) *GetCRLFlow {
+	return &GetCRLFlow{deviceOps: deviceOps, certOps: certOps}
+}
+
+// Run returns the DER CRL, signing a new one only when the cached CRL has expired
+// or a certificate has been revoked, held or released since it was signed.
+func (f *GetCRLFlow) Run(ctx context.Context) (*CRL, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := time.Now()
+	if f.cached != nil && now.Before(f.cached.NextUpdate) {
+		if now.Sub(f.checkedAt) < crlRevisionCheckInterval {
+			return f.cached, nil
+		}
+		revision, err := f.deviceOps.GetCRLRevision(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("get crl revision: %w", err)
+		}
+		f.checkedAt = now
+		if revision == f.revision {
+			return f.cached, nil
+		}
+	}
+
+	list, err := f.deviceOps.ListRevokedCerts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list revoked certificates: %w", err)
+	}
+
+	entries := make([]certops.CRLEntry, len(list.Entries))
+	for i, e := range list.Entries {
+		entries[i] = certops.CRLEntry{
+			Serial:     e.Serial,
+			RevokedAt:  e.RevokedAt,
+			ReasonCode: pure.RevocationReasonCode(e.Reason),
+		}
+	}
+
+	crl, err := f.certOps.SignCRL(ctx, certops.SignCRLInput{Number: list.Number, Entries: entries})
+	if err != nil {
+		return nil, err
+	}
+	f.cached = &CRL{DER: crl.DER, ThisUpdate: crl.ThisUpdate, NextUpdate: crl.NextUpdate}
+	f.revision = list.Revision
+	f.checkedAt = now
+	return f.cached, nil
+}
+
+// ErrMalformedOCSPRequest is returned for an OCSP request that does not parse.
+var ErrMalformedOCSPRequest = errors.New("malformed ocsp request")
+
+// ErrUnknownIssuer is returned for an OCSP request about another CA's certificate.
+var ErrUnknownIssuer = errors.New("ocsp request for unknown issuer")
+
+// CheckCertStatusFlow answers OCSP requests from the issued-certificate ledger.
+type CheckCertStatusFlow struct {
+	deviceOps *deviceops.Ops
+	certOps   *certops.Ops
+}
+
+// NewCheckCertStatusFlow creates the flow with its required ops.
+func NewCheckCertStatusFlow(deviceOps *deviceops.Ops, certOps *certops.Ops) *CheckCertStatusFlow {
+	return &CheckCertStatusFlow{deviceOps: deviceOps, certOps: certOps}
+}
+
+// Run parses a DER OCSP request and returns the signed DER response. Serials
+// the CA never issued are reported as unknown.
+func (f *CheckCertStatusFlow) Run(ctx context.Context, input CheckCertStatusInput) (*OCSPResponse, error) {
+	req, err := f.certOps.ParseOCSPRequest(ctx, input.Request)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformedOCSPRequest, err)
+	}
+	if !req.KnownIssuer {
+		return nil, ErrUnknownIssuer
+	}
+
+	record, err := f.deviceOps.GetIssuedCert(ctx, req.Serial)
+	if err != nil {
+		return nil, fmt.Errorf("look up certificate: %w", err)
+	}
+
+	statusInput := pure.CertStatusInput{Issued: record != nil}
+	if record != nil {
+		statusInput.RevokedAt = record.RevokedAt
+		statusInput.RevocationReason = record.RevocationReason
+	}
+	status := pure.CheckCertStatus(statusInput)
+
+	resp, err := f.certOps.SignOCSPResponse(ctx, certops.OCSPResponseInput{
+		Serial:     req.Serial,
+		Status:     status.Status,
+		RevokedAt:  status.RevokedAt,
+		ReasonCode: status.ReasonCode,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &OCSPResponse{DER: resp.DER, Status: status.Status, NextUpdate: resp.NextUpdate}, nil
+}
@@ -0,0 +1,122 @@
+package device
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+
+	certops "rootstock/web-server/ops/cert"
+	deviceops "rootstock/web-server/ops/device"
+)
+
+func TestRenewalSupersedesCertInCRLAndOCSP(t *testing.T) {
+	dOps, _ := setupDeviceFlowTest(t)
+	cRepo := setupCertRepo(t)
+	t.Cleanup(cRepo.Shutdown)
+	cOps := certops.NewOps(cRepo)
+	ctx := context.Background()
+
+	device, _ := dOps.CreateDevice(ctx, deviceops.CreateDeviceInput{
+		OwnerID: "user-1", Class: "sensor", FirmwareVersion: "1.0.0", Tier: 1, Sensors: []string{"temp"},
+	})
+	code, _ := dOps.GenerateEnrollmentCode(ctx, deviceops.GenerateCodeInput{DeviceID: device.ID, Code: "CRL-TEST-001", TTL: 900})
+
+	registered, err := NewRegisterDeviceFlow(dOps, cOps).Run(ctx, RegisterDeviceInput{EnrollmentCode: code.Code, CSR: generateCSR(t)})
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	renewed, err := NewRenewCertFlow(dOps, cOps).Run(ctx, RenewCertInput{DeviceID: device.ID, CSR: generateCSR(t)})
+	if err != nil {
+		t.Fatalf("renew: %v", err)
+	}
+
+	crl, err := NewGetCRLFlow(dOps, cOps).Run(ctx)
+	if err != nil {
+		t.Fatalf("GetCRLFlow.Run(): %v", err)
+	}
+	parsed, err := x509.ParseRevocationList(crl.DER)
+	if err != nil {
+		t.Fatalf("parse crl: %v", err)
+	}
+	if len(parsed.RevokedCertificateEntries) != 1 {
+		t.Fatalf("crl entries = %d, want 1", len(parsed.RevokedCertificateEntries))
+	}
+	if got := parsed.RevokedCertificateEntries[0].SerialNumber.Text(16); got != registered.Serial {
+		t.Errorf("revoked serial = %s, want superseded %s", got, registered.Serial)
+	}
+
+	ca, _ := cOps.GetCACert(ctx)
+	caBlock, _ := pem.Decode(ca.CertPEM)
+	caCert, _ := x509.ParseCertificate(caBlock.Bytes)
+	checkStatus := NewCheckCertStatusFlow(dOps, cOps)
+	for _, tc := range []struct {
+		certPEM []byte
+		want    int
+	}{
+		{registered.CertPEM, ocsp.Revoked},
+		{renewed.CertPEM, ocsp.Good},
+	} {
+		block, _ := pem.Decode(tc.certPEM)
+		cert, _ := x509.ParseCertificate(block.Bytes)
+		reqDER, _ := ocsp.CreateRequest(cert, caCert, nil)
+
+		resp, err := checkStatus.Run(ctx, CheckCertStatusInput{Request: reqDER})
+		if err != nil {
+			t.Fatalf("CheckCertStatusFlow.Run(): %v", err)
+		}
+		got, err := ocsp.ParseResponseForCert(resp.DER, cert, caCert)
+		if err != nil {
+			t.Fatalf("parse ocsp response: %v", err)
+		}
+		if got.Status != tc.want {
+			t.Errorf("serial %x: ocsp status = %d, want %d", cert.SerialNumber, got.Status, tc.want)
+		}
+	}
+}
+
+func TestGetCRLCachedUntilRevokedSetChanges(t *testing.T) {
+	dOps, _ := setupDeviceFlowTest(t)
+	cRepo := setupCertRepo(t)
+	t.Cleanup(cRepo.Shutdown)
+	cOps := certops.NewOps(cRepo)
+	ctx := context.Background()
+
+	device, _ := dOps.CreateDevice(ctx, deviceops.CreateDeviceInput{
+		OwnerID: "user-1", Class: "sensor", FirmwareVersion: "1.0.0", Tier: 1, Sensors: []string{"temp"},
+	})
+	code, _ := dOps.GenerateEnrollmentCode(ctx, deviceops.GenerateCodeInput{DeviceID: device.ID, Code: "CRL-TEST-002", TTL: 900})
+	if _, err := NewRegisterDeviceFlow(dOps, cOps).Run(ctx, RegisterDeviceInput{EnrollmentCode: code.Code, CSR: generateCSR(t)}); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	flow := NewGetCRLFlow(dOps, cOps)
+	first, err := flow.Run(ctx)
+	if err != nil {
+		t.Fatalf("GetCRLFlow.Run(): %v", err)
+	}
+
+	// Past the check interval with nothing revoked, the signed CRL is reused.
+	flow.checkedAt = time.Now().Add(-crlRevisionCheckInterval)
+	again, _ := flow.Run(ctx)
+	if !bytes.Equal(again.DER, first.DER) {
+		t.Error("crl re-signed although the revoked set did not change")
+	}
+
+	if _, err := dOps.SetCertHold(ctx, device.ID, true); err != nil {
+		t.Fatalf("SetCertHold(): %v", err)
+	}
+	flow.checkedAt = time.Now().Add(-crlRevisionCheckInterval)
+	held, _ := flow.Run(ctx)
+	parsed, err := x509.ParseRevocationList(held.DER)
+	if err != nil {
+		t.Fatalf("parse crl: %v", err)
+	}
+	if len(parsed.RevokedCertificateEntries) != 1 {
+		t.Errorf("crl entries after hold = %d, want 1", len(parsed.RevokedCertificateEntries))
+	}
+}
@@ -2,21 +2,49 @@ package device
 
 import (
 	"context"
+	"fmt"
+	"log/slog"
 
 	deviceops "rootstock/web-server/ops/device"
+	mqttops "rootstock/web-server/ops/mqtt"
 )
 
 // RevokeDeviceFlow marks a device as revoked.
 type RevokeDeviceFlow struct {
 	deviceOps *deviceops.Ops
+	mqttOps   *mqttops.Ops
 }
 
 // NewRevokeDeviceFlow creates the flow with its required ops.
-func NewRevokeDeviceFlow(deviceOps *deviceops.Ops) *RevokeDeviceFlow {
-	return &RevokeDeviceFlow{deviceOps: deviceOps}
+func NewRevokeDeviceFlow(deviceOps *deviceops.Ops, mqttOps *mqttops.Ops) *RevokeDeviceFlow {
+	return &RevokeDeviceFlow{deviceOps: deviceOps, mqttOps: mqttOps}
 }
 
-// Run revokes a device.
+// Run revokes a device, places its certificates on hold in the CRL and drops its
+// live broker session, and that of the gateway relaying for it: the gateway's
+// subscriptions to the device's topics end, and it reconnects without the device.
+// The broker refuses the device on reconnect, so a failed disconnect is logged,
+// not returned.
 func (f *RevokeDeviceFlow) Run(ctx context.Context, input RevokeDeviceInput) error {
-	return f.deviceOps.UpdateDeviceStatus(ctx, input.DeviceID, "revoked")
+	if err := f.deviceOps.UpdateDeviceStatus(ctx, input.DeviceID, "revoked"); err != nil {
+		return err
+	}
+	if _, err := f.deviceOps.SetCertHold(ctx, input.DeviceID, true); err != nil {
+		return fmt.Errorf("hold certificates: %w", err)
+	}
+	if _, err := f.mqttOps.DisconnectDevice(ctx, input.DeviceID); err != nil {
+		slog.WarnContext(ctx, "disconnect revoked device", "device_id", input.DeviceID, "error", err)
+	}
+
+	d, err := f.deviceOps.GetDevice(ctx, input.DeviceID)
+	if err != nil {
+		slog.WarnContext(ctx, "look up revoked device's gateway", "device_id", input.DeviceID, "error", err)
+		return nil
+	}
+	if d.GatewayID != nil {
+		if _, err := f.mqttOps.DisconnectDevice(ctx, *d.GatewayID); err != nil {
+			slog.WarnContext(ctx, "disconnect revoked device's gateway", "device_id", input.DeviceID, "gateway_id", *d.GatewayID, "error", err)
+		}
+	}
+	return nil
 }
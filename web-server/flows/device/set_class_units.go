@@ -0,0 +1,40 @@
+package device
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	deviceops "rootstock/web-server/ops/device"
+	"rootstock/web-server/ops/pure"
+)
+
+// ErrInvalidUnits is returned when a declaration names a unit the unit registry does not know.
+var ErrInvalidUnits = errors.New("invalid sensor units")
+
+// SetClassUnitsFlow declares the units every device of a class reports its sensors in.
+// Devices may override their class defaults with their own declarations.
+type SetClassUnitsFlow struct {
+	deviceOps *deviceops.Ops
+}
+
+// NewSetClassUnitsFlow creates the flow with its required ops.
+func NewSetClassUnitsFlow(deviceOps *deviceops.Ops) *SetClassUnitsFlow {
+	return &SetClassUnitsFlow{deviceOps: deviceOps}
+}
+
+// Run normalizes the units to UCUM codes and replaces the class's declarations.
+// Returns the stored units.
+func (f *SetClassUnitsFlow) Run(ctx context.Context, input SetClassUnitsInput) (map[string]string, error) {
+	if input.Class == "" {
+		return nil, fmt.Errorf("%w: device class is required", ErrInvalidUnits)
+	}
+	units, err := pure.NormalizeUnits(input.Units)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidUnits, err)
+	}
+	if err := f.deviceOps.SetClassUnits(ctx, deviceops.SetClassUnitsInput{Class: input.Class, Units: units}); err != nil {
+		return nil, err
+	}
+	return units, nil
+}
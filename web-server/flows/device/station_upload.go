@@ -0,0 +1,70 @@
+package device
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	deviceops "rootstock/web-server/ops/device"
+	"rootstock/web-server/ops/pure"
+)
+
+// ErrUnknownStationToken is returned for an upload URL no linked device has.
+var ErrUnknownStationToken = errors.New("unknown station token")
+
+// ErrInvalidStationUpload is returned for an upload with no readable values or a bad timestamp.
+var ErrInvalidStationUpload = errors.New("invalid station upload")
+
+// StationUploadFlow translates an upload from a linked consumer weather station into a reading
+// for each live campaign the device is enrolled in. Ingesting it is left to the caller, as for
+// readings arriving over MQTT.
+type StationUploadFlow struct {
+	deviceOps *deviceops.Ops
+}
+
+// NewStationUploadFlow creates the flow with its required ops.
+func NewStationUploadFlow(deviceOps *deviceops.Ops) *StationUploadFlow {
+	return &StationUploadFlow{deviceOps: deviceOps}
+}
+
+// Run resolves the upload URL's token to its device, maps the vendor fields to the device's
+// parameters and units, and lists the campaigns the reading is for.
+func (f *StationUploadFlow) Run(ctx context.Context, input StationUploadInput) (*StationUpload, error) {
+	link, err := f.deviceOps.ResolveStationToken(ctx, input.Token)
+	if err != nil {
+		return nil, fmt.Errorf("resolve station token: %w", err)
+	}
+	if link == nil {
+		return nil, ErrUnknownStationToken
+	}
+
+	units, err := f.deviceOps.ResolveSensorUnits(ctx, link.DeviceID)
+	if err != nil {
+		return nil, fmt.Errorf("resolve sensor units: %w", err)
+	}
+	reading, err := pure.MapStationUpload(pure.StationUploadInput{
+		Protocol:     link.Protocol,
+		Fields:       input.Fields,
+		ParameterMap: link.ParameterMap,
+		DeviceUnits:  units,
+		ReceivedAt:   input.ReceivedAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidStationUpload, err)
+	}
+
+	campaigns, err := f.deviceOps.ListConfigCampaigns(ctx, link.DeviceID, input.ReceivedAt)
+	if err != nil {
+		return nil, fmt.Errorf("list campaigns: %w", err)
+	}
+
+	return &StationUpload{
+		DeviceID:        link.DeviceID,
+		Protocol:        link.Protocol,
+		CampaignIDs:     campaigns,
+		Values:          reading.Values,
+		Timestamp:       reading.Timestamp,
+		FirmwareVersion: reading.FirmwareVersion,
+		IgnoredFields:   reading.Ignored,
+	}, nil
+}
@@ -0,0 +1,65 @@
+package device
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	deviceops "rootstock/web-server/ops/device"
+)
+
+func TestStationUpload(t *testing.T) {
+	dOps, _ := setupDeviceFlowTest(t)
+	ctx := context.Background()
+
+	created, err := dOps.CreateDevice(ctx, deviceops.CreateDeviceInput{
+		OwnerID: "user-1", Class: "weather-station", FirmwareVersion: "1.0.0", Tier: 1,
+		Sensors: []string{"temperature", "humidity"}, SensorUnits: map[string]string{"air_temp": "Cel"},
+	})
+	if err != nil {
+		t.Fatalf("CreateDevice(): %v", err)
+	}
+	link, err := dOps.LinkStation(ctx, deviceops.LinkStationInput{
+		DeviceID: created.ID, Protocol: "wunderground", ParameterMap: map[string]string{"temperature": "air_temp"},
+	})
+	if err != nil {
+		t.Fatalf("LinkStation(): %v", err)
+	}
+
+	flow := NewStationUploadFlow(dOps)
+	received := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	got, err := flow.Run(ctx, StationUploadInput{
+		Token:      link.Token,
+		Fields:     map[string][]string{"tempf": {"212"}, "humidity": {"40"}, "lightning": {"3"}, "softwaretype": {"WS-2902"}},
+		ReceivedAt: received,
+	})
+	if err != nil {
+		t.Fatalf("Run(): %v", err)
+	}
+	if got.DeviceID != created.ID || got.Protocol != "wunderground" || got.FirmwareVersion != "WS-2902" {
+		t.Errorf("upload = %+v, want wunderground upload from %s", got, created.ID)
+	}
+	// Converted to the unit the device declares for the renamed parameter
+	if got.Values["air_temp"] != 100 || got.Values["humidity"] != 40 {
+		t.Errorf("Values = %v, want air_temp 100 and humidity 40", got.Values)
+	}
+	if !reflect.DeepEqual(got.IgnoredFields, []string{"lightning"}) {
+		t.Errorf("IgnoredFields = %v, want [lightning]", got.IgnoredFields)
+	}
+	if !got.Timestamp.Equal(received) {
+		t.Errorf("Timestamp = %v, want %v", got.Timestamp, received)
+	}
+	if len(got.CampaignIDs) != 0 {
+		t.Errorf("CampaignIDs = %v, want none for an unenrolled device", got.CampaignIDs)
+	}
+
+	if _, err := flow.Run(ctx, StationUploadInput{Token: link.Token, Fields: map[string][]string{"tempf": {"-9999"}}, ReceivedAt: received}); !errors.Is(err, ErrInvalidStationUpload) {
+		t.Errorf("Run(no readings) error = %v, want ErrInvalidStationUpload", err)
+	}
+	if _, err := flow.Run(ctx, StationUploadInput{Token: "not-a-token", Fields: map[string][]string{"tempf": {"70"}}, ReceivedAt: received}); !errors.Is(err, ErrUnknownStationToken) {
+		t.Errorf("Run(unknown token) error = %v, want ErrUnknownStationToken", err)
+	}
+}
@@ -0,0 +1,113 @@
+package reading
+
+import (
+	"context"
+	"errors"
+
+	dlops "rootstock/web-server/ops/deadletter"
+)
+
+// Dead-letter error classes.
+const (
+	DeadLetterMalformedPayload = "malformed_payload"
+	DeadLetterNotEnrolled      = "not_enrolled"
+	DeadLetterIngestError      = "ingest_error"
+)
+
+// ErrPurgeUnfiltered is returned when a purge names no filter.
+var ErrPurgeUnfiltered = errors.New("purge requires ids, error_class or received_before")
+
+// DeadLetterFlow keeps telemetry that could not be ingested and lets operators
+// inspect, replay or purge it. Replay itself resubmits the payload for ingestion, which
+// this flow cannot call; the caller does that and then marks the letter replayed here.
+type DeadLetterFlow struct {
+	deadLetterOps *dlops.Ops
+}
+
+// NewDeadLetterFlow creates the flow with its required ops.
+func NewDeadLetterFlow(deadLetterOps *dlops.Ops) *DeadLetterFlow {
+	return &DeadLetterFlow{deadLetterOps: deadLetterOps}
+}
+
+// RunRecord stores a failed message with its raw payload.
+func (f *DeadLetterFlow) RunRecord(ctx context.Context, input RecordDeadLetterInput) (*DeadLetter, error) {
+	result, err := f.deadLetterOps.RecordDeadLetter(ctx, dlops.RecordDeadLetterInput{
+		Topic:        input.Topic,
+		DeviceID:     input.DeviceID,
+		CampaignID:   input.CampaignID,
+		Payload:      input.Payload,
+		ErrorClass:   input.ErrorClass,
+		ErrorMessage: input.ErrorMessage,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return fromOpsDeadLetter(result), nil
+}
+
+// RunList returns dead letters, newest first.
+func (f *DeadLetterFlow) RunList(ctx context.Context, input ListDeadLettersInput) ([]DeadLetter, error) {
+	results, err := f.deadLetterOps.ListDeadLetters(ctx, dlops.ListDeadLettersInput{
+		ErrorClass: input.ErrorClass,
+		DeviceID:   input.DeviceID,
+		Status:     input.Status,
+		Limit:      input.Limit,
+		Offset:     input.Offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]DeadLetter, len(results))
+	for i := range results {
+		out[i] = *fromOpsDeadLetter(&results[i])
+	}
+	return out, nil
+}
+
+// RunGet returns a single dead letter including its payload.
+func (f *DeadLetterFlow) RunGet(ctx context.Context, id string) (*DeadLetter, error) {
+	result, err := f.deadLetterOps.GetDeadLetter(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return fromOpsDeadLetter(result), nil
+}
+
+// RunMarkReplayed records that a dead letter's payload went through ingestion again.
+// Call it only once ingestion has processed the payload; a replay that fails is
+// dead-lettered again as a new letter.
+func (f *DeadLetterFlow) RunMarkReplayed(ctx context.Context, id string) (*DeadLetter, error) {
+	result, err := f.deadLetterOps.MarkDeadLetterReplayed(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return fromOpsDeadLetter(result), nil
+}
+
+// RunPurge deletes matching dead letters and returns how many were removed.
+func (f *DeadLetterFlow) RunPurge(ctx context.Context, input PurgeDeadLettersInput) (int64, error) {
+	if len(input.IDs) == 0 && input.ErrorClass == "" && input.ReceivedBefore == nil {
+		return 0, ErrPurgeUnfiltered
+	}
+	return f.deadLetterOps.PurgeDeadLetters(ctx, dlops.PurgeDeadLettersInput{
+		IDs:            input.IDs,
+		ErrorClass:     input.ErrorClass,
+		ReceivedBefore: input.ReceivedBefore,
+	})
+}
+
+func fromOpsDeadLetter(d *dlops.DeadLetter) *DeadLetter {
+	return &DeadLetter{
+		ID:             d.ID,
+		Topic:          d.Topic,
+		DeviceID:       d.DeviceID,
+		CampaignID:     d.CampaignID,
+		Payload:        d.Payload,
+		ErrorClass:     d.ErrorClass,
+		ErrorMessage:   d.ErrorMessage,
+		ReceivedAt:     d.ReceivedAt,
+		Status:         d.Status,
+		ReplayCount:    d.ReplayCount,
+		LastReplayedAt: d.LastReplayedAt,
+	}
+}
@@ -10,6 +10,9 @@ type ReadingValue struct {
 	Value            float64
 	Status           string
 	QuarantineReason *string
+	Unit             *string  // unit Value was converted to; nil when stored as sent
+	OriginalValue    *float64 // value as the device sent it, before unit conversion
+	OriginalUnit     *string
 }
 
 // Reading is the reading record returned by IngestReadingFlow.
@@ -22,9 +25,24 @@ type Reading struct {
 	Geolocation      *string
 	FirmwareVersion  string
 	CertSerial       string
+	MessageID        string
 	IngestedAt       time.Time
-	Status           string
+	Status           string // accepted | quarantined | rejected | duplicate
 	QuarantineReason *string
+	RejectReason     *string // set when Status is "rejected"; the reading was not persisted
+	FailedCheck      string  // ingestion gate check that rejected or quarantined the reading
+	FeedbackCode     string  // device-safe outcome code, see pure.FeedbackFor
+
+	MissingParameters []string // required campaign parameters the reading did not carry
+}
+
+// BatchResult is the result of IngestReadingFlow.RunBatch.
+type BatchResult struct {
+	Readings    []Reading // one per input reading, in input order
+	Accepted    int
+	Quarantined int
+	Rejected    int
+	Duplicates  int
 }
 
 // ExportDataResult is the result of ExportDataFlow.
@@ -42,4 +60,27 @@ type ExportedReading struct {
 	FirmwareVersion string
 	IngestedAt      time.Time
 	Status          string
+	Conversions     map[string]ValueConversion // parameter -> original value and unit, for converted values
+}
+
+// ValueConversion is the provenance of a value converted to the campaign unit at ingestion.
+type ValueConversion struct {
+	OriginalValue float64
+	OriginalUnit  string
+	Unit          string
+}
+
+// DeadLetter is a telemetry message that failed ingestion.
+type DeadLetter struct {
+	ID             string
+	Topic          string
+	DeviceID       string
+	CampaignID     *string
+	Payload        []byte
+	ErrorClass     string
+	ErrorMessage   string
+	ReceivedAt     time.Time
+	Status         string
+	ReplayCount    int
+	LastReplayedAt *time.Time
 }
@@ -34,8 +34,18 @@ func (f *ExportDataFlow) Run(ctx context.Context, input ExportDataInput) (*Expor
 	pseudoInput := make([]pure.PseudonymizableReading, len(readings))
 	for i, r := range readings {
 		valuesMap := make(map[string]float64, len(r.Values))
+		var conversions map[string]pure.UnitConversion
 		for _, rv := range r.Values {
+			if rv.Status == "excluded" {
+				continue // undeclared parameter kept out of exports by campaign policy
+			}
 			valuesMap[rv.ParameterName] = rv.Value
+			if rv.OriginalValue != nil && rv.OriginalUnit != nil && rv.Unit != nil {
+				if conversions == nil {
+					conversions = make(map[string]pure.UnitConversion)
+				}
+				conversions[rv.ParameterName] = pure.UnitConversion{OriginalValue: *rv.OriginalValue, OriginalUnit: *rv.OriginalUnit, Unit: *rv.Unit}
+			}
 		}
 		pseudoInput[i] = pure.PseudonymizableReading{
 			DeviceID:        r.DeviceID,
@@ -46,6 +56,7 @@ func (f *ExportDataFlow) Run(ctx context.Context, input ExportDataInput) (*Expor
 			FirmwareVersion: r.FirmwareVersion,
 			IngestedAt:      r.IngestedAt,
 			Status:          r.Status,
+			Conversions:     conversions,
 		}
 	}
 
@@ -68,6 +79,12 @@ func (f *ExportDataFlow) Run(ctx context.Context, input ExportDataInput) (*Expor
 			IngestedAt:      p.IngestedAt,
 			Status:          p.Status,
 		}
+		for name, c := range p.Conversions {
+			if exported[i].Conversions == nil {
+				exported[i].Conversions = make(map[string]ValueConversion, len(p.Conversions))
+			}
+			exported[i].Conversions[name] = ValueConversion{OriginalValue: c.OriginalValue, OriginalUnit: c.OriginalUnit, Unit: c.Unit}
+		}
 	}
 
 	return &ExportDataResult{Readings: exported}, nil
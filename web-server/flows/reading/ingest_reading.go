@@ -2,149 +2,670 @@ package reading
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
+	"time"
 
 	campaignops "rootstock/web-server/ops/campaign"
+	deviceops "rootstock/web-server/ops/device"
+	enrollmentops "rootstock/web-server/ops/enrollment"
 	graphops "rootstock/web-server/ops/graph"
 	"rootstock/web-server/ops/pure"
 	readingops "rootstock/web-server/ops/reading"
 )
 
-// IngestReadingFlow orchestrates reading ingestion: validate then persist.
+// IngestReadingFlow orchestrates reading ingestion: gate, validate, then persist.
+// Handles single readings and store-and-forward batches.
 type IngestReadingFlow struct {
-	campaignOps *campaignops.Ops
-	readingOps  *readingops.Ops
-	graphOps    *graphops.Ops
+	campaignOps   *campaignops.Ops
+	readingOps    *readingops.Ops
+	graphOps      *graphops.Ops
+	deviceOps     *deviceops.Ops
+	enrollmentOps *enrollmentops.Ops
+	nearDupWindow time.Duration
+	regionBuffer  float64
+	driftLimit    float64
+	maxFuture     time.Duration
+	maxBacklog    time.Duration
+	skewAlert     time.Duration
 }
 
-// NewIngestReadingFlow creates the flow with its required ops.
-func NewIngestReadingFlow(campaignOps *campaignops.Ops, readingOps *readingops.Ops, graphOps *graphops.Ops) *IngestReadingFlow {
-	return &IngestReadingFlow{campaignOps: campaignOps, readingOps: readingOps, graphOps: graphOps}
+// NewIngestReadingFlow creates the flow with its required ops and ingestion limits.
+func NewIngestReadingFlow(campaignOps *campaignops.Ops, readingOps *readingops.Ops, graphOps *graphops.Ops, deviceOps *deviceops.Ops, enrollmentOps *enrollmentops.Ops, settings IngestSettings) *IngestReadingFlow {
+	return &IngestReadingFlow{
+		campaignOps:   campaignOps,
+		readingOps:    readingOps,
+		graphOps:      graphOps,
+		deviceOps:     deviceOps,
+		enrollmentOps: enrollmentOps,
+		nearDupWindow: time.Duration(settings.NearDuplicateWindowMs) * time.Millisecond,
+		regionBuffer:  settings.RegionBufferMeters,
+		driftLimit:    settings.LocationDriftMeters,
+		maxFuture:     time.Duration(settings.MaxFutureDriftSeconds) * time.Second,
+		maxBacklog:    time.Duration(settings.MaxBacklogHours) * time.Hour,
+		skewAlert:     time.Duration(settings.ClockSkewAlertSeconds) * time.Second,
+	}
 }
 
-// Run validates a reading against campaign rules, persists it, and quarantines invalid values.
+// Run checks device status and campaign enrollment, validates a reading against campaign rules,
+// and persists it with invalid values quarantined. Readings that fail the gate are either rejected
+// (Status "rejected", nothing persisted) or persisted and quarantined whole; FailedCheck names the check.
+// A repeat of an ingested reading is not persisted again: Status is "duplicate" and ID is the original's.
 func (f *IngestReadingFlow) Run(ctx context.Context, input IngestReadingInput) (*Reading, error) {
-	// 1. Get campaign validation rules
-	rules, err := f.campaignOps.GetCampaignRules(ctx, input.CampaignID)
+	// 0. Gate: device must be active and enrolled in the campaign
+	gate, enrollment, err := f.checkGate(ctx, input.DeviceID, input.CampaignID)
 	if err != nil {
 		return nil, err
 	}
+	if gate.Action == pure.GateReject {
+		return rejectedReading(input, gate), nil
+	}
 
-	// 2. Validate the reading (pure op — no I/O)
-	var paramRules []pure.ParameterRule
-	for _, p := range rules.Parameters {
-		paramRules = append(paramRules, pure.ParameterRule{
-			Name:     p.Name,
-			MinRange: p.MinRange,
-			MaxRange: p.MaxRange,
-		})
+	// 1. Get campaign validation rules and the device's sensor units
+	var rules ingestRules
+	if gate.Action == pure.GateAccept {
+		if rules, err = f.getRules(ctx, input.CampaignID, input.DeviceID); err != nil {
+			return nil, err
+		}
+	}
+
+	// 1b. Idempotency: acknowledge redeliveries without inserting again
+	dups, accepted, err := f.checkDuplicates(ctx, input.DeviceID, input.CampaignID, []IngestReadingInput{input}, rules.minInterval)
+	if err != nil {
+		return nil, err
+	}
+	if dups[0].Kind == pure.DuplicateExact {
+		return duplicateReading(input, dups[0].MatchID), nil
+	}
+
+	// 2. Decide reading and value statuses, then persist in one write
+	sampling := pure.CheckSamplingInterval(input.Timestamp, accepted, rules.minInterval)
+	a := f.assess(ctx, input, gate, rules, dups[0].DuplicateResult, sampling)
+	opsReading, err := f.readingOps.PersistReading(ctx, a.persist)
+	if err != nil {
+		return nil, err
 	}
-	validationResult := pure.ValidateReading(
-		pure.ReadingInput{
-			Values:    input.Values,
-			Timestamp: input.Timestamp,
-		},
-		pure.ValidationRules{
-			Parameters:  paramRules,
-			WindowStart: rules.WindowStart,
-			WindowEnd:   rules.WindowEnd,
-		},
-	)
 
-	// 3. Persist the reading with all values
-	opsInput := toOpsReadingInput(input)
-	opsReading, err := f.readingOps.PersistReading(ctx, opsInput)
+	rd := fromOpsReading(opsReading)
+	rd.FailedCheck = a.failedCheck
+	rd.FeedbackCode = a.feedbackCode
+	rd.MissingParameters = a.missing
+
+	// 3. Flag the enrollment if the device has moved away from its deployment point, and
+	// track the device clock against ours (live readings only: batches are backlog by design)
+	if gate.Action == pure.GateAccept {
+		f.flagLocationDrift(ctx, enrollment, []IngestReadingInput{input})
+		f.trackClockSkew(ctx, input.DeviceID, input.Timestamp, rules.validation.ReceivedAt)
+	}
+	return rd, nil
+}
+
+// RunBatch ingests a store-and-forward upload: the gate and campaign rules are checked once,
+// each reading is validated on its own, and all persisted readings are written in one bulk insert.
+// Repeats of ingested readings, or of earlier readings in the same upload, are not persisted.
+// The result has one entry per input reading, in input order.
+func (f *IngestReadingFlow) RunBatch(ctx context.Context, input IngestBatchInput) (*BatchResult, error) {
+	readings := make([]IngestReadingInput, len(input.Readings))
+	for i, r := range input.Readings {
+		r.DeviceID = input.DeviceID
+		r.CampaignID = input.CampaignID
+		readings[i] = r
+	}
+
+	result := &BatchResult{Readings: make([]Reading, len(readings))}
+	if len(readings) == 0 {
+		return result, nil
+	}
+
+	gate, enrollment, err := f.checkGate(ctx, input.DeviceID, input.CampaignID)
 	if err != nil {
 		return nil, err
 	}
+	if gate.Action == pure.GateReject {
+		for i, r := range readings {
+			result.Readings[i] = *rejectedReading(r, gate)
+		}
+		result.Rejected = len(readings)
+		return result, nil
+	}
 
-	// 4. If timestamp invalid, quarantine the whole reading
-	if !validationResult.Valid && len(validationResult.PerParameter) == 0 {
-		if err := f.readingOps.QuarantineReading(ctx, opsReading.ID, validationResult.Reason); err != nil {
+	var rules ingestRules
+	if gate.Action == pure.GateAccept {
+		if rules, err = f.getRules(ctx, input.CampaignID, input.DeviceID); err != nil {
 			return nil, err
 		}
-		opsReading.Status = "quarantined"
-		opsReading.QuarantineReason = &validationResult.Reason
 	}
 
-	// 5. Quarantine individual values that failed validation
-	failedParams := make(map[string]string) // name -> reason
+	dups, accepted, err := f.checkDuplicates(ctx, input.DeviceID, input.CampaignID, readings, rules.minInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	var assessed []assessment
+	var persistInputs []readingops.PersistReadingInput
+	var persistedIdx []int // input index of each persisted reading
+	for i, r := range readings {
+		if dups[i].Kind == pure.DuplicateExact {
+			continue
+		}
+		sampling := pure.CheckSamplingInterval(r.Timestamp, accepted, rules.minInterval)
+		a := f.assess(ctx, r, gate, rules, dups[i].DuplicateResult, sampling)
+		if a.persist.Status != "quarantined" {
+			accepted = append(accepted, r.Timestamp)
+		}
+		assessed = append(assessed, a)
+		persistInputs = append(persistInputs, a.persist)
+		persistedIdx = append(persistedIdx, i)
+	}
+
+	persisted, err := f.readingOps.PersistReadings(ctx, persistInputs)
+	if err != nil {
+		return nil, err
+	}
+
+	for j := range persisted {
+		rd := fromOpsReading(&persisted[j])
+		rd.FailedCheck = assessed[j].failedCheck
+		rd.FeedbackCode = assessed[j].feedbackCode
+		rd.MissingParameters = assessed[j].missing
+		result.Readings[persistedIdx[j]] = *rd
+		if rd.Status == "quarantined" {
+			result.Quarantined++
+		} else {
+			result.Accepted++
+		}
+	}
+
+	// Duplicates point at the reading they repeat; earlier items in this upload now have IDs.
+	for i, d := range dups {
+		if d.Kind != pure.DuplicateExact {
+			continue
+		}
+		matchID := d.MatchID
+		if d.earlierIndex >= 0 {
+			matchID = result.Readings[d.earlierIndex].ID
+		}
+		result.Readings[i] = *duplicateReading(readings[i], matchID)
+		result.Duplicates++
+	}
+
+	if gate.Action == pure.GateAccept {
+		f.flagLocationDrift(ctx, enrollment, readings)
+	}
+	return result, nil
+}
+
+// assessment is the outcome of checking one reading before it is persisted.
+type assessment struct {
+	persist      readingops.PersistReadingInput
+	failedCheck  string
+	feedbackCode string
+	missing      []string // required parameters the reading did not carry
+}
+
+// ingestRules are the campaign rules and device unit declarations a reading is assessed against.
+type ingestRules struct {
+	validation    pure.ValidationRules
+	campaignUnits map[string]string // parameter -> unit the campaign stores
+	deviceUnits   map[string]string // sensor -> unit the device reports in
+	minInterval   time.Duration     // campaign minimum sampling interval; 0 means none
+}
+
+// assess applies the gate outcome, unit conversion, duplicate and sampling interval checks,
+// campaign validation and anomaly detection to a reading and returns the persist input with
+// reading and value statuses already decided.
+func (f *IngestReadingFlow) assess(ctx context.Context, input IngestReadingInput, gate pure.IngestionGateResult, rules ingestRules, dup pure.DuplicateResult, sampling pure.SamplingResult) assessment {
+	a := assessment{persist: toOpsReadingInput(input), feedbackCode: pure.FeedbackAccepted}
+
+	// Gate quarantine: keep the data for review, skip validation
+	if gate.Action == pure.GateQuarantine {
+		a.persist.Status = "quarantined"
+		a.persist.QuarantineReason = gate.Reason
+		a.failedCheck = gate.Check
+		a.feedbackCode = gate.Code
+		return a
+	}
+
+	// Convert values to campaign units; keep what the device sent for provenance.
+	// A value whose unit cannot be converted is quarantined and not validated, so a
+	// required parameter in an unconvertible unit also counts as missing.
+	conversion := pure.ConvertReadingUnits(input.Values, rules.deviceUnits, rules.campaignUnits)
+	for i := range a.persist.Values {
+		v := &a.persist.Values[i]
+		if reason, failed := conversion.Failures[v.ParameterName]; failed {
+			v.Status = "quarantined"
+			v.QuarantineReason = reason
+			v.OriginalUnit = rules.deviceUnits[v.ParameterName]
+			a.feedbackCode = pure.FeedbackUnitMismatch
+			continue
+		}
+		if c, converted := conversion.Conversions[v.ParameterName]; converted {
+			original := c.OriginalValue
+			v.Value = conversion.Values[v.ParameterName]
+			v.Unit = c.Unit
+			v.OriginalValue = &original
+			v.OriginalUnit = c.OriginalUnit
+		}
+	}
+
+	// Near-duplicate: keep it for review, but out of baselines and scores
+	if dup.Kind == pure.DuplicateNear {
+		reason := "possible duplicate: " + dup.Reason
+		a.persist.Status = "quarantined"
+		a.persist.QuarantineReason = reason
+		for i := range a.persist.Values {
+			a.persist.Values[i].Status = "quarantined"
+			a.persist.Values[i].QuarantineReason = reason
+		}
+		a.feedbackCode = pure.FeedbackPossibleDuplicate
+		return a
+	}
+
+	// Sampling faster than the campaign collects: same treatment, so extra volume earns nothing
+	if !sampling.OK {
+		a.persist.Status = "quarantined"
+		a.persist.QuarantineReason = sampling.Reason
+		for i := range a.persist.Values {
+			a.persist.Values[i].Status = "quarantined"
+			a.persist.Values[i].QuarantineReason = sampling.Reason
+		}
+		a.feedbackCode = pure.FeedbackSamplingTooFrequent
+		return a
+	}
+
+	// A location the region check cannot read is held for review rather than let through
+	var location *pure.GeoPoint
+	if input.Geolocation != "" {
+		p, err := pure.ParseGeoPoint(input.Geolocation)
+		if err != nil && len(rules.validation.Regions) > 0 {
+			a.persist.Status = "quarantined"
+			a.persist.QuarantineReason = err.Error()
+			a.feedbackCode = pure.FeedbackInvalidLocation
+			return a
+		}
+		location = p
+	}
+
+	// Validate the reading (pure op — no I/O)
+	validationResult := pure.ValidateReading(
+		pure.ReadingInput{
+			Values:      conversion.Values,
+			Timestamp:   input.Timestamp,
+			Geolocation: location,
+		},
+		rules.validation,
+	)
+
+	// If timestamp or location invalid, or required parameters are missing, quarantine the whole reading
+	if !validationResult.Valid && (len(validationResult.PerParameter) == 0 || len(validationResult.Missing) > 0) {
+		a.feedbackCode = validationResult.Code
+		a.persist.Status = "quarantined"
+		a.persist.QuarantineReason = validationResult.Reason
+		a.missing = validationResult.Missing
+	}
+
+	// Apply per-value outcomes: drop or exclude undeclared values, store rounded values,
+	// and quarantine values that failed validation
+	outcomes := make(map[string]pure.ParameterValidation, len(validationResult.PerParameter))
 	for _, pv := range validationResult.PerParameter {
-		if !pv.Valid {
-			failedParams[pv.Name] = pv.Reason
+		outcomes[pv.Name] = pv
+		if !pv.Valid && a.feedbackCode == pure.FeedbackAccepted {
+			a.feedbackCode = pv.Code
 		}
 	}
-	for i := range opsReading.Values {
-		if reason, failed := failedParams[opsReading.Values[i].ParameterName]; failed {
-			if err := f.readingOps.QuarantineReadingValue(ctx, opsReading.Values[i].ID, reason); err != nil {
-				return nil, err
-			}
-			opsReading.Values[i].Status = "quarantined"
-			opsReading.Values[i].QuarantineReason = &reason
+	values := a.persist.Values[:0]
+	for _, v := range a.persist.Values {
+		pv, ok := outcomes[v.ParameterName]
+		if !ok {
+			values = append(values, v)
+			continue
 		}
+		if pv.Drop {
+			continue
+		}
+		v.Value = pv.Value
+		switch {
+		case !pv.Valid:
+			v.Status = "quarantined"
+			v.QuarantineReason = pv.Reason
+		case pv.Excluded:
+			v.Status = "excluded"
+			v.QuarantineReason = pv.Reason
+		}
+		values = append(values, v)
 	}
+	a.persist.Values = values
 
-	// 6. If all values are quarantined, quarantine the reading itself
-	if len(opsReading.Values) > 0 {
-		allQuarantined := true
-		for _, v := range opsReading.Values {
-			if v.Status != "quarantined" {
-				allQuarantined = false
-				break
-			}
+	// If all counted values are quarantined, quarantine the reading itself
+	counted := 0
+	allQuarantined := true
+	for _, v := range values {
+		if v.Status == "excluded" {
+			continue
 		}
+		counted++
+		if v.Status != "quarantined" {
+			allQuarantined = false
+			break
+		}
+	}
+	if counted > 0 {
 		if allQuarantined {
-			reason := "all parameter values quarantined"
-			if err := f.readingOps.QuarantineReading(ctx, opsReading.ID, reason); err != nil {
-				return nil, err
-			}
-			opsReading.Status = "quarantined"
-			opsReading.QuarantineReason = &reason
+			a.persist.Status = "quarantined"
+			a.persist.QuarantineReason = "all parameter values quarantined"
 		}
 	}
 
-	// 7. Anomaly detection for accepted values (best-effort, per parameter)
-	if opsReading.Status == "accepted" {
-		for paramName, value := range input.Values {
-			if _, failed := failedParams[paramName]; failed {
-				continue
-			}
+	// Anomaly detection for accepted values (best-effort, per parameter)
+	if a.persist.Status == "quarantined" {
+		return a
+	}
+	for i := range values {
+		if values[i].Status == "quarantined" || values[i].Status == "excluded" {
+			continue
+		}
+		paramName := values[i].ParameterName
 
-			// Update rolling baseline
-			if _, err := f.graphOps.UpdateBaseline(ctx, graphops.UpdateBaselineInput{
-				CampaignRef:   input.CampaignID,
-				ParameterName: paramName,
-				Value:         value,
-			}); err != nil {
-				slog.WarnContext(ctx, "failed to update baseline", "campaign_id", input.CampaignID, "parameter", paramName, "error", err)
-			}
+		// Update rolling baseline
+		if _, err := f.graphOps.UpdateBaseline(ctx, graphops.UpdateBaselineInput{
+			CampaignRef:   input.CampaignID,
+			ParameterName: paramName,
+			Value:         values[i].Value,
+		}); err != nil {
+			slog.WarnContext(ctx, "failed to update baseline", "campaign_id", input.CampaignID, "parameter", paramName, "error", err)
+		}
 
-			// Check for anomaly
-			anomaly, err := f.graphOps.CheckAnomaly(ctx, graphops.CheckAnomalyInput{
-				CampaignRef:   input.CampaignID,
-				ParameterName: paramName,
-				Value:         value,
-			})
-			if err != nil {
-				slog.WarnContext(ctx, "failed to check anomaly", "campaign_id", input.CampaignID, "parameter", paramName, "error", err)
-			} else if anomaly != nil {
-				// Find the reading value and quarantine it
-				for i := range opsReading.Values {
-					if opsReading.Values[i].ParameterName == paramName {
-						if err := f.readingOps.QuarantineReadingValue(ctx, opsReading.Values[i].ID, anomaly.Reason); err != nil {
-							slog.WarnContext(ctx, "failed to quarantine anomaly value", "reading_value_id", opsReading.Values[i].ID, "error", err)
-						} else {
-							opsReading.Values[i].Status = "quarantined"
-							opsReading.Values[i].QuarantineReason = &anomaly.Reason
-						}
-						break
-					}
-				}
+		// Check for anomaly
+		anomaly, err := f.graphOps.CheckAnomaly(ctx, graphops.CheckAnomalyInput{
+			CampaignRef:   input.CampaignID,
+			ParameterName: paramName,
+			Value:         values[i].Value,
+		})
+		if err != nil {
+			slog.WarnContext(ctx, "failed to check anomaly", "campaign_id", input.CampaignID, "parameter", paramName, "error", err)
+		} else if anomaly != nil {
+			values[i].Status = "quarantined"
+			values[i].QuarantineReason = anomaly.Reason
+			if a.feedbackCode == pure.FeedbackAccepted {
+				a.feedbackCode = pure.FeedbackAnomalousValue
 			}
 		}
 	}
+	return a
+}
+
+// duplicateCheck is a duplicate result with matches against earlier readings of the same
+// batch resolved to their input index.
+type duplicateCheck struct {
+	pure.DuplicateResult
+	earlierIndex int // input index of the matched reading in this batch; -1 otherwise
+}
+
+// checkDuplicates compares each reading with the device's stored readings around the same
+// time and with earlier readings in the same batch. Exact repeats within the batch are not
+// themselves candidates, so every match points at a reading that is or will be persisted.
+// It also returns the timestamps of stored readings that were not quarantined within
+// minInterval of the batch, for the sampling interval check.
+func (f *IngestReadingFlow) checkDuplicates(ctx context.Context, deviceID, campaignID string, readings []IngestReadingInput, minInterval time.Duration) ([]duplicateCheck, []time.Time, error) {
+	since, until := readings[0].Timestamp, readings[0].Timestamp
+	var messageIDs []string
+	for _, r := range readings {
+		if r.Timestamp.Before(since) {
+			since = r.Timestamp
+		}
+		if r.Timestamp.After(until) {
+			until = r.Timestamp
+		}
+		if r.MessageID != "" {
+			messageIDs = append(messageIDs, r.MessageID)
+		}
+	}
+
+	lookaround := f.nearDupWindow
+	if minInterval > lookaround {
+		lookaround = minInterval
+	}
+	existing, err := f.readingOps.FindDuplicateCandidates(ctx, readingops.DuplicateCandidatesInput{
+		DeviceID:   deviceID,
+		CampaignID: campaignID,
+		MessageIDs: messageIDs,
+		Since:      since.Add(-lookaround),
+		Until:      until.Add(lookaround),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	candidates := make([]pure.ReadingFingerprint, 0, len(existing)+len(readings))
+	var accepted []time.Time
+	for _, e := range existing {
+		candidates = append(candidates, toFingerprint(e))
+		if e.Status != "quarantined" {
+			accepted = append(accepted, e.Timestamp)
+		}
+	}
+	stored := len(candidates)
+	var batchIdx []int // input index of each batch candidate
 
-	return fromOpsReading(opsReading), nil
+	out := make([]duplicateCheck, len(readings))
+	for i, r := range readings {
+		fp := pure.ReadingFingerprint{MessageID: r.MessageID, Timestamp: r.Timestamp, Values: r.Values}
+		res := pure.CheckDuplicate(fp, candidates, f.nearDupWindow)
+		out[i] = duplicateCheck{DuplicateResult: res, earlierIndex: -1}
+		if res.MatchIndex >= stored {
+			out[i].earlierIndex = batchIdx[res.MatchIndex-stored]
+		}
+		if res.Kind != pure.DuplicateExact {
+			candidates = append(candidates, fp)
+			batchIdx = append(batchIdx, i)
+		}
+	}
+	return out, accepted, nil
+}
+
+func toFingerprint(r readingops.Reading) pure.ReadingFingerprint {
+	fp := pure.ReadingFingerprint{ID: r.ID, Timestamp: r.Timestamp, Values: make(map[string]float64, len(r.Values))}
+	if r.MessageID != nil {
+		fp.MessageID = *r.MessageID
+	}
+	// Compare what the device sent: stored values may have been converted to campaign units
+	for _, v := range r.Values {
+		fp.Values[v.ParameterName] = v.Value
+		if v.OriginalValue != nil {
+			fp.Values[v.ParameterName] = *v.OriginalValue
+		}
+	}
+	return fp
+}
+
+// getRules loads campaign rules in the shape the pure validator expects and, when the campaign
+// declares parameter units, the units the device reports in.
+func (f *IngestReadingFlow) getRules(ctx context.Context, campaignID, deviceID string) (ingestRules, error) {
+	rules, err := f.campaignOps.GetCampaignRules(ctx, campaignID)
+	if err != nil {
+		return ingestRules{}, err
+	}
+
+	var paramRules []pure.ParameterRule
+	campaignUnits := make(map[string]string)
+	for _, p := range rules.Parameters {
+		if p.Unit != "" {
+			campaignUnits[p.Name] = p.Unit
+		}
+		paramRules = append(paramRules, pure.ParameterRule{
+			Name:      p.Name,
+			MinRange:  p.MinRange,
+			MaxRange:  p.MaxRange,
+			Precision: p.Precision,
+			Required:  p.Required,
+		})
+	}
+	var regions []pure.GeoRegion
+	for _, r := range rules.Regions {
+		region, err := pure.ParseGeoRegion(r.GeoJSON)
+		if err != nil {
+			slog.WarnContext(ctx, "skipping unreadable campaign region", "campaign_id", campaignID, "error", err)
+			continue
+		}
+		regions = append(regions, region)
+	}
+
+	var minInterval time.Duration
+	if rules.MinSamplingIntervalSecs != nil {
+		minInterval = time.Duration(*rules.MinSamplingIntervalSecs) * time.Second
+	}
+
+	var deviceUnits map[string]string
+	if len(campaignUnits) > 0 {
+		if deviceUnits, err = f.deviceOps.ResolveSensorUnits(ctx, deviceID); err != nil {
+			return ingestRules{}, err
+		}
+	}
+
+	return ingestRules{
+		validation: pure.ValidationRules{
+			Parameters:         paramRules,
+			WindowStart:        rules.WindowStart,
+			WindowEnd:          rules.WindowEnd,
+			Regions:            regions,
+			RegionBufferMeters: f.regionBuffer,
+			PrecisionPolicy:    rules.PrecisionPolicy,
+			UndeclaredPolicy:   rules.UndeclaredParameterPolicy,
+			ReceivedAt:         time.Now().UTC(),
+			MaxFutureDrift:     f.maxFuture,
+			MaxBacklog:         f.maxBacklog,
+		},
+		campaignUnits: campaignUnits,
+		deviceUnits:   deviceUnits,
+		minInterval:   minInterval,
+	}, nil
+}
+
+// trackClockSkew folds a live reading's timestamp offset into the device's clock skew estimate
+// and tells the owner once when the estimate goes past the alert limit. Best-effort.
+func (f *IngestReadingFlow) trackClockSkew(ctx context.Context, deviceID string, timestamp, receivedAt time.Time) {
+	if receivedAt.IsZero() {
+		return
+	}
+	skew, err := f.deviceOps.RecordClockSkew(ctx, deviceops.RecordClockSkewInput{
+		DeviceID:   deviceID,
+		ObservedMs: timestamp.Sub(receivedAt).Milliseconds(),
+		Smoothing:  pure.ClockSkewSmoothing,
+	})
+	if err != nil {
+		slog.WarnContext(ctx, "failed to record clock skew", "device_id", deviceID, "error", err)
+		return
+	}
+
+	check := pure.CheckClockSkew(skew.EstimateMs, f.skewAlert)
+	switch {
+	case check.Skewed && skew.FlaggedAt == nil:
+		if err := f.deviceOps.SetClockSkewFlag(ctx, deviceID, true); err != nil {
+			slog.WarnContext(ctx, "failed to flag clock skew", "device_id", deviceID, "error", err)
+			return
+		}
+		direction := "ahead of"
+		if check.Skew < 0 {
+			direction = "behind"
+		}
+		if err := f.enrollmentOps.CreateNotification(ctx, enrollmentops.CreateNotificationInput{
+			UserID:  skew.OwnerID,
+			Type:    "device_clock_skew",
+			Message: fmt.Sprintf("Device %s's clock is about %s %s the correct time. Readings with wrong timestamps may be held for review; please sync the device clock.", deviceID, check.Skew.Abs().Round(time.Second), direction),
+		}); err != nil {
+			slog.WarnContext(ctx, "failed to notify owner of clock skew", "device_id", deviceID, "error", err)
+		}
+	case !check.Skewed && skew.FlaggedAt != nil:
+		if err := f.deviceOps.SetClockSkewFlag(ctx, deviceID, false); err != nil {
+			slog.WarnContext(ctx, "failed to clear clock skew flag", "device_id", deviceID, "error", err)
+		}
+	}
+}
+
+// flagLocationDrift compares reported locations with the enrollment's deployment point and flags
+// the enrollment for review at the first one too far away. Readings are not affected (FR-124).
+func (f *IngestReadingFlow) flagLocationDrift(ctx context.Context, enrollment *enrollmentops.Enrollment, readings []IngestReadingInput) {
+	if f.driftLimit <= 0 || enrollment == nil || enrollment.DeploymentLocation == nil || enrollment.LocationFlaggedAt != nil {
+		return
+	}
+	deployed, err := pure.ParseGeoPoint(*enrollment.DeploymentLocation)
+	if err != nil {
+		return
+	}
+
+	for _, r := range readings {
+		if r.Geolocation == "" {
+			continue
+		}
+		reported, err := pure.ParseGeoPoint(r.Geolocation)
+		if err != nil {
+			continue
+		}
+		drift := pure.CheckLocationDrift(*reported, *deployed, f.driftLimit)
+		if !drift.Drifted {
+			continue
+		}
+		reason := fmt.Sprintf("reported location %.0f m from deployment point", drift.DistanceMeters)
+		if err := f.enrollmentOps.FlagLocation(ctx, enrollmentops.FlagLocationInput{
+			EnrollmentID: enrollment.ID,
+			Reason:       reason,
+		}); err != nil {
+			slog.WarnContext(ctx, "failed to flag location drift", "enrollment_id", enrollment.ID, "error", err)
+			return
+		}
+		slog.InfoContext(ctx, "enrollment flagged for location drift", "enrollment_id", enrollment.ID, "device_id", r.DeviceID, "reason", reason)
+		return
+	}
+}
+
+// checkGate loads device and enrollment state and applies the pure ingestion gate.
+// The enrollment is returned for later checks; it is nil when the device is not enrolled.
+func (f *IngestReadingFlow) checkGate(ctx context.Context, deviceID, campaignID string) (pure.IngestionGateResult, *enrollmentops.Enrollment, error) {
+	device, err := f.deviceOps.GetDevice(ctx, deviceID)
+	if err != nil {
+		return pure.IngestionGateResult{}, nil, err
+	}
+	enrollment, err := f.enrollmentOps.GetByDeviceCampaign(ctx, deviceID, campaignID)
+	if err != nil {
+		return pure.IngestionGateResult{}, nil, err
+	}
+
+	gateInput := pure.IngestionGateInput{DeviceStatus: device.Status}
+	if enrollment != nil {
+		gateInput.EnrollmentStatus = enrollment.Status
+	}
+	return pure.CheckIngestionGate(gateInput), enrollment, nil
+}
+
+// duplicateReading describes a repeat of an already ingested reading; nothing was persisted.
+func duplicateReading(input IngestReadingInput, originalID string) *Reading {
+	return &Reading{
+		ID:              originalID,
+		DeviceID:        input.DeviceID,
+		CampaignID:      input.CampaignID,
+		Timestamp:       input.Timestamp,
+		FirmwareVersion: input.FirmwareVersion,
+		CertSerial:      input.CertSerial,
+		MessageID:       input.MessageID,
+		Status:          "duplicate",
+		FeedbackCode:    pure.FeedbackDuplicate,
+	}
+}
+
+// rejectedReading describes a reading that failed the gate and was not persisted.
+func rejectedReading(input IngestReadingInput, gate pure.IngestionGateResult) *Reading {
+	reason := gate.Reason
+	return &Reading{
+		DeviceID:        input.DeviceID,
+		CampaignID:      input.CampaignID,
+		Timestamp:       input.Timestamp,
+		FirmwareVersion: input.FirmwareVersion,
+		CertSerial:      input.CertSerial,
+		Status:          "rejected",
+		RejectReason:    &reason,
+		FailedCheck:     gate.Check,
+		FeedbackCode:    gate.Code,
+	}
 }
 
 func toOpsReadingInput(in IngestReadingInput) readingops.PersistReadingInput {
@@ -163,6 +684,7 @@ func toOpsReadingInput(in IngestReadingInput) readingops.PersistReadingInput {
 		Geolocation:     in.Geolocation,
 		FirmwareVersion: in.FirmwareVersion,
 		CertSerial:      in.CertSerial,
+		MessageID:       in.MessageID,
 	}
 }
 
@@ -179,6 +701,9 @@ func fromOpsReading(r *readingops.Reading) *Reading {
 		Status:           r.Status,
 		QuarantineReason: r.QuarantineReason,
 	}
+	if r.MessageID != nil {
+		rd.MessageID = *r.MessageID
+	}
 	for _, rv := range r.Values {
 		rd.Values = append(rd.Values, ReadingValue{
 			ID:               rv.ID,
@@ -187,6 +712,9 @@ func fromOpsReading(r *readingops.Reading) *Reading {
 			Value:            rv.Value,
 			Status:           rv.Status,
 			QuarantineReason: rv.QuarantineReason,
+			Unit:             rv.Unit,
+			OriginalValue:    rv.OriginalValue,
+			OriginalUnit:     rv.OriginalUnit,
 		})
 	}
 	return rd
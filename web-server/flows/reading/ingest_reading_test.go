@@ -10,10 +10,15 @@ import (
 	"github.com/oklog/ulid/v2"
 
 	campaignops "rootstock/web-server/ops/campaign"
+	deviceops "rootstock/web-server/ops/device"
+	enrollmentops "rootstock/web-server/ops/enrollment"
 	graphops "rootstock/web-server/ops/graph"
+	"rootstock/web-server/ops/pure"
 	readingops "rootstock/web-server/ops/reading"
 	"rootstock/web-server/config"
 	campaignrepo "rootstock/web-server/repo/campaign"
+	devicerepo "rootstock/web-server/repo/device"
+	enrollmentrepo "rootstock/web-server/repo/enrollment"
 	graphrepo "rootstock/web-server/repo/graph"
 	readingrepo "rootstock/web-server/repo/reading"
 	sqlmigrate "rootstock/web-server/repo/sql/migrate"
@@ -37,13 +42,18 @@ func setupIngestTest(t *testing.T) (*IngestReadingFlow, *pgxpool.Pool) {
 	}
 
 	ctx := context.Background()
-	pool.Exec(ctx, "TRUNCATE reading_values, readings, devices, campaigns CASCADE")
+	pool.Exec(ctx, "TRUNCATE reading_values, readings, campaign_enrollments, devices, campaigns CASCADE")
+	pool.Exec(ctx, `INSERT INTO app_users (id, idp_id, user_type) VALUES ('user-1', 'idp-user-1', 'scitizen') ON CONFLICT DO NOTHING`)
 
 	cRepo := campaignrepo.NewRepository(pool)
 	rRepo := readingrepo.NewRepository(pool)
+	dRepo := devicerepo.NewRepository(pool)
+	eRepo := enrollmentrepo.NewRepository(pool)
 
 	cOps := campaignops.NewOps(cRepo)
 	rOps := readingops.NewOps(rRepo)
+	dOps := deviceops.NewOps(dRepo)
+	eOps := enrollmentops.NewOps(eRepo)
 
 	gRepo, err := graphrepo.NewDgraphRepository("dgraph-alpha:9080")
 	if err != nil {
@@ -51,11 +61,20 @@ func setupIngestTest(t *testing.T) (*IngestReadingFlow, *pgxpool.Pool) {
 	}
 	gOps := graphops.NewOps(gRepo)
 
-	flow := NewIngestReadingFlow(cOps, rOps, gOps)
+	flow := NewIngestReadingFlow(cOps, rOps, gOps, dOps, eOps, IngestSettings{
+		NearDuplicateWindowMs: 2000,
+		RegionBufferMeters:    50,
+		LocationDriftMeters:   500,
+		MaxFutureDriftSeconds: 300,
+		MaxBacklogHours:       720,
+		ClockSkewAlertSeconds: 120,
+	})
 
 	t.Cleanup(func() {
 		cRepo.Shutdown()
 		rRepo.Shutdown()
+		dRepo.Shutdown()
+		eRepo.Shutdown()
 		gRepo.Shutdown()
 		pool.Close()
 	})
@@ -63,6 +82,26 @@ func setupIngestTest(t *testing.T) (*IngestReadingFlow, *pgxpool.Pool) {
 	return flow, pool
 }
 
+// insertEnrolledDevice creates a device with the given status and a campaign enrollment with the given status.
+func insertEnrolledDevice(t *testing.T, pool *pgxpool.Pool, campaignID, deviceStatus, enrollmentStatus string) string {
+	t.Helper()
+	ctx := context.Background()
+	deviceID := ulid.Make().String()
+	if _, err := pool.Exec(ctx,
+		`INSERT INTO devices (id, owner_id, class, firmware_version, tier, sensors, status)
+		 VALUES ($1, 'user-1', 'sensor', '1.0.0', 1, '{temp}', $2)`, deviceID, deviceStatus); err != nil {
+		t.Fatalf("insert device: %v", err)
+	}
+	if enrollmentStatus != "" {
+		if _, err := pool.Exec(ctx,
+			`INSERT INTO campaign_enrollments (id, device_id, campaign_id, scitizen_id, status)
+			 VALUES ($1, $2, $3, 'user-1', $4)`, ulid.Make().String(), deviceID, campaignID, enrollmentStatus); err != nil {
+			t.Fatalf("insert enrollment: %v", err)
+		}
+	}
+	return deviceID
+}
+
 func TestIngestValidReading(t *testing.T) {
 	flow, pool := setupIngestTest(t)
 	ctx := context.Background()
@@ -86,10 +125,7 @@ func TestIngestValidReading(t *testing.T) {
 		t.Fatalf("create campaign: %v", err)
 	}
 
-	deviceID := ulid.Make().String()
-	pool.Exec(ctx,
-		`INSERT INTO devices (id, owner_id, class, firmware_version, tier, sensors, status)
-		 VALUES ($1, 'user-1', 'sensor', '1.0.0', 1, '{temp}', 'active')`, deviceID)
+	deviceID := insertEnrolledDevice(t, pool, campaign.ID, "active", "active")
 
 	rd, err := flow.Run(ctx, IngestReadingInput{
 		DeviceID:        deviceID,
@@ -127,10 +163,7 @@ func TestIngestOutOfRangeReading(t *testing.T) {
 		Parameters:  []campaignrepo.ParameterInput{{Name: "temp", Unit: "celsius", MinRange: &min, MaxRange: &max}},
 	})
 
-	deviceID := ulid.Make().String()
-	pool.Exec(ctx,
-		`INSERT INTO devices (id, owner_id, class, firmware_version, tier, sensors, status)
-		 VALUES ($1, 'user-1', 'sensor', '1.0.0', 1, '{temp}', 'active')`, deviceID)
+	deviceID := insertEnrolledDevice(t, pool, campaign.ID, "active", "active")
 
 	rd, err := flow.Run(ctx, IngestReadingInput{
 		DeviceID:        deviceID,
@@ -168,10 +201,7 @@ func TestIngestOutsideWindowReading(t *testing.T) {
 		WindowEnd:   &end,
 	})
 
-	deviceID := ulid.Make().String()
-	pool.Exec(ctx,
-		`INSERT INTO devices (id, owner_id, class, firmware_version, tier, sensors, status)
-		 VALUES ($1, 'user-1', 'sensor', '1.0.0', 1, '{temp}', 'active')`, deviceID)
+	deviceID := insertEnrolledDevice(t, pool, campaign.ID, "active", "active")
 
 	rd, err := flow.Run(ctx, IngestReadingInput{
 		DeviceID:        deviceID,
@@ -188,3 +218,625 @@ func TestIngestOutsideWindowReading(t *testing.T) {
 		t.Errorf("status = %q, want quarantined", rd.Status)
 	}
 }
+
+func createGateTestCampaign(t *testing.T, pool *pgxpool.Pool) string {
+	t.Helper()
+	now := time.Now().UTC()
+	start := now.Add(-1 * time.Hour)
+	end := now.Add(1 * time.Hour)
+
+	cRepo := campaignrepo.NewRepository(pool)
+	defer cRepo.Shutdown()
+	campaign, err := cRepo.Create(context.Background(), campaignrepo.CreateCampaignInput{
+		OrgID:       "org-1",
+		CreatedBy:   "user-1",
+		WindowStart: &start,
+		WindowEnd:   &end,
+	})
+	if err != nil {
+		t.Fatalf("create campaign: %v", err)
+	}
+	return campaign.ID
+}
+
+func TestIngestRejectsUnenrolledDevice(t *testing.T) {
+	flow, pool := setupIngestTest(t)
+	ctx := context.Background()
+	campaignID := createGateTestCampaign(t, pool)
+	deviceID := insertEnrolledDevice(t, pool, campaignID, "active", "")
+
+	rd, err := flow.Run(ctx, IngestReadingInput{
+		DeviceID: deviceID, CampaignID: campaignID, Values: map[string]float64{"temp": 20},
+		Timestamp: time.Now().UTC(), FirmwareVersion: "1.0.0", CertSerial: "serial-1",
+	})
+	if err != nil {
+		t.Fatalf("Run(): %v", err)
+	}
+	if rd.Status != "rejected" {
+		t.Errorf("status = %q, want rejected", rd.Status)
+	}
+	if rd.FailedCheck != "enrollment" {
+		t.Errorf("failed check = %q, want enrollment", rd.FailedCheck)
+	}
+
+	var count int
+	pool.QueryRow(ctx, `SELECT count(*) FROM readings WHERE device_id = $1`, deviceID).Scan(&count)
+	if count != 0 {
+		t.Errorf("persisted readings = %d, want 0", count)
+	}
+}
+
+func TestIngestRejectsWithdrawnEnrollment(t *testing.T) {
+	flow, pool := setupIngestTest(t)
+	ctx := context.Background()
+	campaignID := createGateTestCampaign(t, pool)
+	deviceID := insertEnrolledDevice(t, pool, campaignID, "active", "withdrawn")
+
+	rd, err := flow.Run(ctx, IngestReadingInput{
+		DeviceID: deviceID, CampaignID: campaignID, Values: map[string]float64{"temp": 20},
+		Timestamp: time.Now().UTC(), FirmwareVersion: "1.0.0", CertSerial: "serial-1",
+	})
+	if err != nil {
+		t.Fatalf("Run(): %v", err)
+	}
+	if rd.Status != "rejected" || rd.FailedCheck != "enrollment" {
+		t.Errorf("status/check = %q/%q, want rejected/enrollment", rd.Status, rd.FailedCheck)
+	}
+}
+
+func TestIngestRejectsRevokedDevice(t *testing.T) {
+	flow, pool := setupIngestTest(t)
+	ctx := context.Background()
+	campaignID := createGateTestCampaign(t, pool)
+	deviceID := insertEnrolledDevice(t, pool, campaignID, "revoked", "active")
+
+	rd, err := flow.Run(ctx, IngestReadingInput{
+		DeviceID: deviceID, CampaignID: campaignID, Values: map[string]float64{"temp": 20},
+		Timestamp: time.Now().UTC(), FirmwareVersion: "1.0.0", CertSerial: "serial-1",
+	})
+	if err != nil {
+		t.Fatalf("Run(): %v", err)
+	}
+	if rd.Status != "rejected" || rd.FailedCheck != "device_status" {
+		t.Errorf("status/check = %q/%q, want rejected/device_status", rd.Status, rd.FailedCheck)
+	}
+}
+
+func TestIngestQuarantinesSuspendedDevice(t *testing.T) {
+	flow, pool := setupIngestTest(t)
+	ctx := context.Background()
+	campaignID := createGateTestCampaign(t, pool)
+	deviceID := insertEnrolledDevice(t, pool, campaignID, "suspended", "active")
+
+	rd, err := flow.Run(ctx, IngestReadingInput{
+		DeviceID: deviceID, CampaignID: campaignID, Values: map[string]float64{"temp": 20},
+		Timestamp: time.Now().UTC(), FirmwareVersion: "1.0.0", CertSerial: "serial-1",
+	})
+	if err != nil {
+		t.Fatalf("Run(): %v", err)
+	}
+	if rd.Status != "quarantined" || rd.FailedCheck != "device_status" {
+		t.Errorf("status/check = %q/%q, want quarantined/device_status", rd.Status, rd.FailedCheck)
+	}
+	if rd.QuarantineReason == nil || *rd.QuarantineReason != "device suspended" {
+		t.Errorf("quarantine_reason = %v, want %q", rd.QuarantineReason, "device suspended")
+	}
+}
+
+func TestIngestBatchValidatesEachReading(t *testing.T) {
+	flow, pool := setupIngestTest(t)
+	ctx := context.Background()
+
+	now := time.Now().UTC()
+	start := now.Add(-1 * time.Hour)
+	end := now.Add(1 * time.Hour)
+	min := 0.0
+	max := 100.0
+
+	cRepo := campaignrepo.NewRepository(pool)
+	defer cRepo.Shutdown()
+	campaign, err := cRepo.Create(ctx, campaignrepo.CreateCampaignInput{
+		OrgID:       "org-1",
+		CreatedBy:   "user-1",
+		WindowStart: &start,
+		WindowEnd:   &end,
+		Parameters:  []campaignrepo.ParameterInput{{Name: "temp", Unit: "celsius", MinRange: &min, MaxRange: &max}},
+	})
+	if err != nil {
+		t.Fatalf("create campaign: %v", err)
+	}
+	deviceID := insertEnrolledDevice(t, pool, campaign.ID, "active", "active")
+
+	result, err := flow.RunBatch(ctx, IngestBatchInput{
+		DeviceID:   deviceID,
+		CampaignID: campaign.ID,
+		Readings: []IngestReadingInput{
+			{Values: map[string]float64{"temp": 21}, Timestamp: now.Add(-30 * time.Minute), FirmwareVersion: "1.0.0", CertSerial: "serial-1"},
+			{Values: map[string]float64{"temp": 150}, Timestamp: now.Add(-20 * time.Minute), FirmwareVersion: "1.0.0", CertSerial: "serial-1"},
+			{Values: map[string]float64{"temp": 22}, Timestamp: now.Add(-2 * time.Hour), FirmwareVersion: "1.0.0", CertSerial: "serial-1"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("RunBatch(): %v", err)
+	}
+	if len(result.Readings) != 3 {
+		t.Fatalf("results = %d, want 3", len(result.Readings))
+	}
+	if result.Readings[0].Status != "accepted" {
+		t.Errorf("reading 0 status = %q, want accepted", result.Readings[0].Status)
+	}
+	if result.Readings[1].Status != "quarantined" {
+		t.Errorf("reading 1 status = %q, want quarantined", result.Readings[1].Status)
+	}
+	if result.Readings[2].Status != "quarantined" {
+		t.Errorf("reading 2 status = %q, want quarantined", result.Readings[2].Status)
+	}
+	if result.Accepted != 1 || result.Quarantined != 2 {
+		t.Errorf("accepted/quarantined = %d/%d, want 1/2", result.Accepted, result.Quarantined)
+	}
+
+	var count int
+	pool.QueryRow(ctx, `SELECT count(*) FROM readings WHERE device_id = $1`, deviceID).Scan(&count)
+	if count != 3 {
+		t.Errorf("persisted readings = %d, want 3", count)
+	}
+}
+
+func TestIngestBatchRejectsUnenrolledDevice(t *testing.T) {
+	flow, pool := setupIngestTest(t)
+	ctx := context.Background()
+	campaignID := createGateTestCampaign(t, pool)
+	deviceID := insertEnrolledDevice(t, pool, campaignID, "active", "")
+
+	result, err := flow.RunBatch(ctx, IngestBatchInput{
+		DeviceID:   deviceID,
+		CampaignID: campaignID,
+		Readings: []IngestReadingInput{
+			{Values: map[string]float64{"temp": 20}, Timestamp: time.Now().UTC(), FirmwareVersion: "1.0.0", CertSerial: "serial-1"},
+			{Values: map[string]float64{"temp": 21}, Timestamp: time.Now().UTC(), FirmwareVersion: "1.0.0", CertSerial: "serial-1"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("RunBatch(): %v", err)
+	}
+	if result.Rejected != 2 {
+		t.Errorf("rejected = %d, want 2", result.Rejected)
+	}
+
+	var count int
+	pool.QueryRow(ctx, `SELECT count(*) FROM readings WHERE device_id = $1`, deviceID).Scan(&count)
+	if count != 0 {
+		t.Errorf("persisted readings = %d, want 0", count)
+	}
+}
+
+func TestIngestAcknowledgesRedeliveredMessage(t *testing.T) {
+	flow, pool := setupIngestTest(t)
+	ctx := context.Background()
+	campaignID := createGateTestCampaign(t, pool)
+	deviceID := insertEnrolledDevice(t, pool, campaignID, "active", "active")
+
+	input := IngestReadingInput{
+		DeviceID: deviceID, CampaignID: campaignID, MessageID: "msg-1", Values: map[string]float64{"temp": 20},
+		Timestamp: time.Now().UTC(), FirmwareVersion: "1.0.0", CertSerial: "serial-1",
+	}
+	first, err := flow.Run(ctx, input)
+	if err != nil {
+		t.Fatalf("Run(): %v", err)
+	}
+	second, err := flow.Run(ctx, input)
+	if err != nil {
+		t.Fatalf("Run() redelivery: %v", err)
+	}
+	if second.Status != "duplicate" {
+		t.Errorf("status = %q, want duplicate", second.Status)
+	}
+	if second.ID != first.ID {
+		t.Errorf("duplicate ID = %q, want original %q", second.ID, first.ID)
+	}
+
+	var count int
+	pool.QueryRow(ctx, `SELECT count(*) FROM readings WHERE device_id = $1`, deviceID).Scan(&count)
+	if count != 1 {
+		t.Errorf("persisted readings = %d, want 1", count)
+	}
+}
+
+func TestIngestFlagsNearDuplicate(t *testing.T) {
+	flow, pool := setupIngestTest(t)
+	ctx := context.Background()
+	campaignID := createGateTestCampaign(t, pool)
+	deviceID := insertEnrolledDevice(t, pool, campaignID, "active", "active")
+
+	now := time.Now().UTC()
+	if _, err := flow.Run(ctx, IngestReadingInput{
+		DeviceID: deviceID, CampaignID: campaignID, Values: map[string]float64{"temp": 20},
+		Timestamp: now, FirmwareVersion: "1.0.0", CertSerial: "serial-1",
+	}); err != nil {
+		t.Fatalf("Run(): %v", err)
+	}
+
+	rd, err := flow.Run(ctx, IngestReadingInput{
+		DeviceID: deviceID, CampaignID: campaignID, Values: map[string]float64{"temp": 20},
+		Timestamp: now.Add(500 * time.Millisecond), FirmwareVersion: "1.0.0", CertSerial: "serial-1",
+	})
+	if err != nil {
+		t.Fatalf("Run(): %v", err)
+	}
+	if rd.Status != "quarantined" {
+		t.Errorf("status = %q, want quarantined", rd.Status)
+	}
+	if rd.FeedbackCode != "possible_duplicate" {
+		t.Errorf("feedback code = %q, want possible_duplicate", rd.FeedbackCode)
+	}
+}
+
+func TestIngestBatchSkipsRepeatedReadings(t *testing.T) {
+	flow, pool := setupIngestTest(t)
+	ctx := context.Background()
+	campaignID := createGateTestCampaign(t, pool)
+	deviceID := insertEnrolledDevice(t, pool, campaignID, "active", "active")
+
+	now := time.Now().UTC()
+	reading := IngestReadingInput{Values: map[string]float64{"temp": 20}, Timestamp: now, FirmwareVersion: "1.0.0", CertSerial: "serial-1"}
+	result, err := flow.RunBatch(ctx, IngestBatchInput{
+		DeviceID:   deviceID,
+		CampaignID: campaignID,
+		Readings:   []IngestReadingInput{reading, reading},
+	})
+	if err != nil {
+		t.Fatalf("RunBatch(): %v", err)
+	}
+	if result.Duplicates != 1 {
+		t.Errorf("duplicates = %d, want 1", result.Duplicates)
+	}
+	if result.Readings[1].ID != result.Readings[0].ID {
+		t.Errorf("duplicate ID = %q, want %q", result.Readings[1].ID, result.Readings[0].ID)
+	}
+
+	// Re-uploading the same batch inserts nothing.
+	again, err := flow.RunBatch(ctx, IngestBatchInput{
+		DeviceID:   deviceID,
+		CampaignID: campaignID,
+		Readings:   []IngestReadingInput{reading},
+	})
+	if err != nil {
+		t.Fatalf("RunBatch() again: %v", err)
+	}
+	if again.Duplicates != 1 {
+		t.Errorf("re-upload duplicates = %d, want 1", again.Duplicates)
+	}
+
+	var count int
+	pool.QueryRow(ctx, `SELECT count(*) FROM readings WHERE device_id = $1`, deviceID).Scan(&count)
+	if count != 1 {
+		t.Errorf("persisted readings = %d, want 1", count)
+	}
+}
+
+func TestIngestQuarantinesReadingOutsideRegion(t *testing.T) {
+	flow, pool := setupIngestTest(t)
+	ctx := context.Background()
+
+	cRepo := campaignrepo.NewRepository(pool)
+	defer cRepo.Shutdown()
+	campaign, err := cRepo.Create(ctx, campaignrepo.CreateCampaignInput{
+		OrgID:     "org-1",
+		CreatedBy: "user-1",
+		Regions: []campaignrepo.RegionInput{
+			{GeoJSON: `{"type":"Polygon","coordinates":[[[-74,40.7],[-73.9,40.7],[-73.9,40.8],[-74,40.8],[-74,40.7]]]}`},
+		},
+	})
+	if err != nil {
+		t.Fatalf("create campaign: %v", err)
+	}
+	deviceID := insertEnrolledDevice(t, pool, campaign.ID, "active", "active")
+
+	inside, err := flow.Run(ctx, IngestReadingInput{
+		DeviceID: deviceID, CampaignID: campaign.ID, Values: map[string]float64{"temp": 20},
+		Timestamp: time.Now().UTC(), Geolocation: `{"type":"Point","coordinates":[-73.95,40.75]}`,
+	})
+	if err != nil {
+		t.Fatalf("Run() inside: %v", err)
+	}
+	if inside.Status != "accepted" {
+		t.Errorf("inside status = %q, want accepted", inside.Status)
+	}
+
+	outside, err := flow.Run(ctx, IngestReadingInput{
+		DeviceID: deviceID, CampaignID: campaign.ID, Values: map[string]float64{"temp": 20},
+		Timestamp: time.Now().UTC().Add(time.Minute), Geolocation: `{"type":"Point","coordinates":[-73.5,40.75]}`,
+	})
+	if err != nil {
+		t.Fatalf("Run() outside: %v", err)
+	}
+	if outside.Status != "quarantined" {
+		t.Errorf("outside status = %q, want quarantined", outside.Status)
+	}
+	if outside.FeedbackCode != pure.FeedbackOutsideRegion {
+		t.Errorf("feedback = %q, want %q", outside.FeedbackCode, pure.FeedbackOutsideRegion)
+	}
+}
+
+func TestIngestAppliesParameterPolicies(t *testing.T) {
+	flow, pool := setupIngestTest(t)
+	ctx := context.Background()
+
+	cRepo := campaignrepo.NewRepository(pool)
+	defer cRepo.Shutdown()
+	precision := 1
+	campaign, err := cRepo.Create(ctx, campaignrepo.CreateCampaignInput{
+		OrgID:     "org-1",
+		CreatedBy: "user-1",
+		Parameters: []campaignrepo.ParameterInput{
+			{Name: "temp", Unit: "C", Precision: &precision, Required: true},
+			{Name: "humidity", Unit: "%"},
+		},
+		UndeclaredParameterPolicy: pure.UndeclaredExclude,
+	})
+	if err != nil {
+		t.Fatalf("create campaign: %v", err)
+	}
+	deviceID := insertEnrolledDevice(t, pool, campaign.ID, "active", "active")
+
+	rd, err := flow.Run(ctx, IngestReadingInput{
+		DeviceID: deviceID, CampaignID: campaign.ID,
+		Values:    map[string]float64{"temp": 21.36, "pressure": 1013},
+		Timestamp: time.Now().UTC(),
+	})
+	if err != nil {
+		t.Fatalf("Run(): %v", err)
+	}
+	if rd.Status != "accepted" {
+		t.Fatalf("status = %q, want accepted", rd.Status)
+	}
+	for _, v := range rd.Values {
+		switch v.ParameterName {
+		case "temp":
+			if v.Value != 21.4 {
+				t.Errorf("temp = %v, want rounded 21.4", v.Value)
+			}
+		case "pressure":
+			if v.Status != "excluded" {
+				t.Errorf("pressure status = %q, want excluded", v.Status)
+			}
+		}
+	}
+
+	missing, err := flow.Run(ctx, IngestReadingInput{
+		DeviceID: deviceID, CampaignID: campaign.ID,
+		Values:    map[string]float64{"humidity": 40},
+		Timestamp: time.Now().UTC().Add(time.Minute),
+	})
+	if err != nil {
+		t.Fatalf("Run() missing: %v", err)
+	}
+	if missing.Status != "quarantined" || missing.FeedbackCode != pure.FeedbackMissingParameters {
+		t.Errorf("missing = %q/%q, want quarantined/%s", missing.Status, missing.FeedbackCode, pure.FeedbackMissingParameters)
+	}
+	if len(missing.MissingParameters) != 1 || missing.MissingParameters[0] != "temp" {
+		t.Errorf("missing parameters = %v, want [temp]", missing.MissingParameters)
+	}
+}
+
+func TestIngestConvertsDeviceUnits(t *testing.T) {
+	flow, pool := setupIngestTest(t)
+	ctx := context.Background()
+
+	cRepo := campaignrepo.NewRepository(pool)
+	defer cRepo.Shutdown()
+	maxTemp := 50.0
+	campaign, err := cRepo.Create(ctx, campaignrepo.CreateCampaignInput{
+		OrgID:     "org-1",
+		CreatedBy: "user-1",
+		Parameters: []campaignrepo.ParameterInput{
+			{Name: "temp", Unit: "Cel", MaxRange: &maxTemp},
+			{Name: "pressure", Unit: "hPa"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("create campaign: %v", err)
+	}
+	deviceID := insertEnrolledDevice(t, pool, campaign.ID, "active", "active")
+	if _, err := pool.Exec(ctx, `UPDATE devices SET sensor_units = '{"temp":"[degF]","pressure":"Cel"}' WHERE id = $1`, deviceID); err != nil {
+		t.Fatalf("set sensor units: %v", err)
+	}
+
+	// 104F is 40C: inside the 50C limit only once converted.
+	rd, err := flow.Run(ctx, IngestReadingInput{
+		DeviceID: deviceID, CampaignID: campaign.ID,
+		Values:    map[string]float64{"temp": 104, "pressure": 1013},
+		Timestamp: time.Now().UTC(),
+	})
+	if err != nil {
+		t.Fatalf("Run(): %v", err)
+	}
+	if rd.FeedbackCode != pure.FeedbackUnitMismatch {
+		t.Errorf("feedback = %q, want %q", rd.FeedbackCode, pure.FeedbackUnitMismatch)
+	}
+	for _, v := range rd.Values {
+		switch v.ParameterName {
+		case "temp":
+			if v.Status != "accepted" || v.Value < 39.99 || v.Value > 40.01 {
+				t.Errorf("temp = %v (%s), want accepted 40", v.Value, v.Status)
+			}
+			if v.OriginalValue == nil || *v.OriginalValue != 104 || v.OriginalUnit == nil || *v.OriginalUnit != "[degF]" {
+				t.Errorf("temp provenance = %v %v, want 104 [degF]", v.OriginalValue, v.OriginalUnit)
+			}
+		case "pressure":
+			if v.Status != "quarantined" {
+				t.Errorf("pressure status = %q, want quarantined for incompatible unit", v.Status)
+			}
+		}
+	}
+}
+
+func TestIngestFlagsLocationDrift(t *testing.T) {
+	flow, pool := setupIngestTest(t)
+	ctx := context.Background()
+
+	cRepo := campaignrepo.NewRepository(pool)
+	defer cRepo.Shutdown()
+	campaign, err := cRepo.Create(ctx, campaignrepo.CreateCampaignInput{OrgID: "org-1", CreatedBy: "user-1"})
+	if err != nil {
+		t.Fatalf("create campaign: %v", err)
+	}
+	deviceID := insertEnrolledDevice(t, pool, campaign.ID, "active", "active")
+	if _, err := pool.Exec(ctx,
+		`UPDATE campaign_enrollments SET deployment_location = '{"type":"Point","coordinates":[-73.95,40.75]}'
+		 WHERE device_id = $1`, deviceID); err != nil {
+		t.Fatalf("set deployment location: %v", err)
+	}
+
+	// ~1.7 km east of the deployment point: flagged, but the reading is still accepted.
+	rd, err := flow.Run(ctx, IngestReadingInput{
+		DeviceID: deviceID, CampaignID: campaign.ID, Values: map[string]float64{"temp": 20},
+		Timestamp: time.Now().UTC(), Geolocation: `{"type":"Point","coordinates":[-73.93,40.75]}`,
+	})
+	if err != nil {
+		t.Fatalf("Run(): %v", err)
+	}
+	if rd.Status != "accepted" {
+		t.Errorf("status = %q, want accepted", rd.Status)
+	}
+
+	var flaggedAt *time.Time
+	var reason *string
+	if err := pool.QueryRow(ctx,
+		`SELECT location_flagged_at, location_flag_reason FROM campaign_enrollments WHERE device_id = $1`, deviceID,
+	).Scan(&flaggedAt, &reason); err != nil {
+		t.Fatalf("query enrollment: %v", err)
+	}
+	if flaggedAt == nil || reason == nil {
+		t.Error("expected enrollment to be flagged for location drift")
+	}
+}
+
+func TestIngestQuarantinesImplausibleTimestamps(t *testing.T) {
+	flow, pool := setupIngestTest(t)
+	ctx := context.Background()
+
+	cRepo := campaignrepo.NewRepository(pool)
+	defer cRepo.Shutdown()
+	campaign, err := cRepo.Create(ctx, campaignrepo.CreateCampaignInput{OrgID: "org-1", CreatedBy: "user-1"})
+	if err != nil {
+		t.Fatalf("create campaign: %v", err)
+	}
+	deviceID := insertEnrolledDevice(t, pool, campaign.ID, "active", "active")
+
+	tests := []struct {
+		name      string
+		timestamp time.Time
+		want      string
+	}{
+		{"years ahead", time.Now().UTC().AddDate(3, 0, 0), pure.FeedbackClockAhead},
+		{"epoch", time.Unix(0, 0).UTC(), pure.FeedbackBacklogTooOld},
+	}
+	for _, tt := range tests {
+		rd, err := flow.Run(ctx, IngestReadingInput{
+			DeviceID: deviceID, CampaignID: campaign.ID,
+			Values: map[string]float64{"temp": 20}, Timestamp: tt.timestamp,
+		})
+		if err != nil {
+			t.Fatalf("%s: Run(): %v", tt.name, err)
+		}
+		if rd.Status != "quarantined" || rd.FeedbackCode != tt.want {
+			t.Errorf("%s: status = %q, feedback = %q; want quarantined, %q", tt.name, rd.Status, rd.FeedbackCode, tt.want)
+		}
+	}
+}
+
+func TestIngestTracksClockSkew(t *testing.T) {
+	flow, pool := setupIngestTest(t)
+	ctx := context.Background()
+
+	cRepo := campaignrepo.NewRepository(pool)
+	defer cRepo.Shutdown()
+	campaign, err := cRepo.Create(ctx, campaignrepo.CreateCampaignInput{OrgID: "org-1", CreatedBy: "user-1"})
+	if err != nil {
+		t.Fatalf("create campaign: %v", err)
+	}
+	deviceID := insertEnrolledDevice(t, pool, campaign.ID, "active", "active")
+
+	// Four minutes fast: inside the drift limit, so accepted, but past the alert limit.
+	rd, err := flow.Run(ctx, IngestReadingInput{
+		DeviceID: deviceID, CampaignID: campaign.ID,
+		Values: map[string]float64{"temp": 20}, Timestamp: time.Now().UTC().Add(4 * time.Minute),
+	})
+	if err != nil {
+		t.Fatalf("Run(): %v", err)
+	}
+	if rd.Status != "accepted" {
+		t.Errorf("status = %q, want accepted", rd.Status)
+	}
+
+	var skewMs *int64
+	var flaggedAt *time.Time
+	if err := pool.QueryRow(ctx,
+		`SELECT clock_skew_ms, clock_skew_flagged_at FROM devices WHERE id = $1`, deviceID,
+	).Scan(&skewMs, &flaggedAt); err != nil {
+		t.Fatalf("query device: %v", err)
+	}
+	if skewMs == nil || *skewMs < 200_000 {
+		t.Errorf("clock_skew_ms = %v, want about 240000", skewMs)
+	}
+	if flaggedAt == nil {
+		t.Error("expected device to be flagged for clock skew")
+	}
+}
+
+func TestIngestQuarantinesReadingsInsideSamplingInterval(t *testing.T) {
+	flow, pool := setupIngestTest(t)
+	ctx := context.Background()
+
+	cRepo := campaignrepo.NewRepository(pool)
+	defer cRepo.Shutdown()
+	interval := 60
+	campaign, err := cRepo.Create(ctx, campaignrepo.CreateCampaignInput{
+		OrgID: "org-1", CreatedBy: "user-1", MinSamplingIntervalSecs: &interval,
+	})
+	if err != nil {
+		t.Fatalf("create campaign: %v", err)
+	}
+	deviceID := insertEnrolledDevice(t, pool, campaign.ID, "active", "active")
+
+	now := time.Now().UTC()
+	first, err := flow.Run(ctx, IngestReadingInput{
+		DeviceID: deviceID, CampaignID: campaign.ID, Values: map[string]float64{"temp": 20}, Timestamp: now.Add(-2 * time.Minute),
+	})
+	if err != nil {
+		t.Fatalf("Run(first): %v", err)
+	}
+	if first.Status != "accepted" {
+		t.Fatalf("first status = %q, want accepted", first.Status)
+	}
+
+	// 30s later: too soon
+	second, err := flow.Run(ctx, IngestReadingInput{
+		DeviceID: deviceID, CampaignID: campaign.ID, Values: map[string]float64{"temp": 21}, Timestamp: now.Add(-90 * time.Second),
+	})
+	if err != nil {
+		t.Fatalf("Run(second): %v", err)
+	}
+	if second.Status != "quarantined" || second.FeedbackCode != pure.FeedbackSamplingTooFrequent {
+		t.Errorf("second = %q/%q, want quarantined/%q", second.Status, second.FeedbackCode, pure.FeedbackSamplingTooFrequent)
+	}
+
+	// A batch: the first item is a full interval after the accepted reading, the second only 10s after that
+	result, err := flow.RunBatch(ctx, IngestBatchInput{
+		DeviceID: deviceID, CampaignID: campaign.ID,
+		Readings: []IngestReadingInput{
+			{Values: map[string]float64{"temp": 22}, Timestamp: now.Add(-time.Minute)},
+			{Values: map[string]float64{"temp": 23}, Timestamp: now.Add(-50 * time.Second)},
+		},
+	})
+	if err != nil {
+		t.Fatalf("RunBatch(): %v", err)
+	}
+	if result.Accepted != 1 || result.Quarantined != 1 {
+		t.Errorf("batch accepted=%d quarantined=%d, want 1 and 1", result.Accepted, result.Quarantined)
+	}
+	if result.Readings[1].FeedbackCode != pure.FeedbackSamplingTooFrequent {
+		t.Errorf("batch item 1 feedback = %q, want %q", result.Readings[1].FeedbackCode, pure.FeedbackSamplingTooFrequent)
+	}
+}
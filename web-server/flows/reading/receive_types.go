@@ -11,6 +11,25 @@ type IngestReadingInput struct {
 	Geolocation     string
 	FirmwareVersion string
 	CertSerial      string
+	MessageID       string // optional device-supplied ID; repeats are acknowledged, not re-ingested
+}
+
+// IngestSettings are the deployment-tunable limits IngestReadingFlow applies.
+type IngestSettings struct {
+	NearDuplicateWindowMs int     // identical values from one device closer than this are possible duplicates
+	RegionBufferMeters    float64 // tolerance outside campaign regions before a reading is quarantined
+	LocationDriftMeters   float64 // distance from the deployment point that flags an enrollment; 0 disables
+	MaxFutureDriftSeconds int     // timestamps further ahead of ingestion are quarantined; 0 disables
+	MaxBacklogHours       int     // timestamps older than this at ingestion are quarantined; 0 disables
+	ClockSkewAlertSeconds int     // device clock skew estimate that notifies the owner; 0 disables
+}
+
+// IngestBatchInput is what callers send to IngestReadingFlow.RunBatch.
+// DeviceID and CampaignID apply to every reading; those fields on the items are ignored.
+type IngestBatchInput struct {
+	DeviceID   string
+	CampaignID string
+	Readings   []IngestReadingInput
 }
 
 // ExportDataInput is what callers send to ExportDataFlow.
@@ -20,3 +39,30 @@ type ExportDataInput struct {
 	Limit      int
 	Offset     int
 }
+
+// RecordDeadLetterInput is what callers send to DeadLetterFlow.RunRecord.
+type RecordDeadLetterInput struct {
+	Topic        string
+	DeviceID     string
+	CampaignID   string
+	Payload      []byte
+	ErrorClass   string
+	ErrorMessage string
+}
+
+// ListDeadLettersInput is what callers send to DeadLetterFlow.RunList.
+type ListDeadLettersInput struct {
+	ErrorClass string
+	DeviceID   string
+	Status     string
+	Limit      int
+	Offset     int
+}
+
+// PurgeDeadLettersInput is what callers send to DeadLetterFlow.RunPurge.
+// At least one filter must be set.
+type PurgeDeadLettersInput struct {
+	IDs            []string
+	ErrorClass     string
+	ReceivedBefore *time.Time
+}
@@ -30,6 +30,7 @@ func (f *DeviceManagementFlow) RunList(ctx context.Context, ownerID string) ([]D
 			ID: r.ID, Status: r.Status, Class: r.Class,
 			FirmwareVersion: r.FirmwareVersion, Tier: r.Tier, Sensors: r.Sensors,
 			ActiveEnrollments: r.ActiveEnrollments, LastSeen: r.LastSeen,
+			Health: fromOpsDeviceHealth(r.Health),
 		}
 	}
 	return out, nil
@@ -53,11 +54,34 @@ func (f *DeviceManagementFlow) RunDetail(ctx context.Context, deviceID string) (
 	for i, c := range result.ConnectionHistory {
 		connHistory[i] = ConnectionEvent{EventType: c.EventType, Timestamp: c.Timestamp, Reason: c.Reason}
 	}
+	sessions := make([]DeviceSession, len(result.Sessions))
+	for i, s := range result.Sessions {
+		sessions[i] = DeviceSession{
+			ConnectedAt: s.ConnectedAt, DisconnectedAt: s.DisconnectedAt, DurationSeconds: s.DurationSeconds,
+			RemoteAddr: s.RemoteAddr, CertSerial: s.CertSerial, DisconnectReason: s.DisconnectReason, Graceful: s.Graceful,
+			WillPublished: s.WillPublished,
+		}
+	}
 
 	return &DeviceDetail{
 		ID: result.ID, OwnerID: result.OwnerID, Status: result.Status,
 		Class: result.Class, FirmwareVersion: result.FirmwareVersion,
 		Tier: result.Tier, Sensors: result.Sensors, CertSerial: result.CertSerial,
 		CreatedAt: result.CreatedAt, Enrollments: enrollments, ConnectionHistory: connHistory,
+		ClockSkewMs: result.ClockSkewMs, ClockSkewFlaggedAt: result.ClockSkewFlaggedAt,
+		Online: result.Online, Sessions: sessions, Health: fromOpsDeviceHealth(result.Health),
+		ConfigVersion: result.ConfigVersion, ConfigAckedVersion: result.ConfigAckedVersion,
 	}, nil
 }
+
+func fromOpsDeviceHealth(h *scitizenops.DeviceHealth) *DeviceHealth {
+	if h == nil {
+		return nil
+	}
+	return &DeviceHealth{
+		ReportedAt: h.ReportedAt, ReceivedAt: h.ReceivedAt,
+		BatteryPercent: h.BatteryPercent, RSSIDbm: h.RSSIDbm, UptimeSeconds: h.UptimeSeconds,
+		FreeMemoryBytes: h.FreeMemoryBytes, SensorFaults: h.SensorFaults, FirmwareBuild: h.FirmwareBuild,
+		Alerts: h.Alerts,
+	}
+}
@@ -0,0 +1,181 @@
+package scitizen
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	deviceops "rootstock/web-server/ops/device"
+	"rootstock/web-server/ops/pure"
+	scitizenops "rootstock/web-server/ops/scitizen"
+)
+
+// ErrNotDeviceOwner is returned when a scitizen acts on a device they do not own.
+var ErrNotDeviceOwner = errors.New("device is not owned by caller")
+
+// ErrDeviceNotPending is returned when a code is requested for a device that has already enrolled.
+var ErrDeviceNotPending = errors.New("device is not pending enrollment")
+
+// ErrInvalidSensorUnits is returned when a device declares a unit the unit registry does not know.
+var ErrInvalidSensorUnits = errors.New("invalid sensor units")
+
+// ErrInvalidDeviceRegistration is returned when a registration is missing a required field.
+var ErrInvalidDeviceRegistration = errors.New("invalid device registration")
+
+// ErrDeviceNotFound is returned when a scitizen acts on a device that does not exist.
+var ErrDeviceNotFound = errors.New("device not found")
+
+// DeviceRegistrationFlow lets a scitizen declare a device and manage its enrollment codes.
+// CreateDevice → GenerateEnrollmentCode; the code is later redeemed at /enroll.
+// Implements FR-013, FR-016
+type DeviceRegistrationFlow struct {
+	deviceOps   *deviceops.Ops
+	scitizenOps *scitizenops.Ops
+	codeTTL     int // seconds
+}
+
+// NewDeviceRegistrationFlow creates the flow with its required ops.
+func NewDeviceRegistrationFlow(deviceOps *deviceops.Ops, scitizenOps *scitizenops.Ops, codeTTLMinutes int) *DeviceRegistrationFlow {
+	return &DeviceRegistrationFlow{deviceOps: deviceOps, scitizenOps: scitizenOps, codeTTL: codeTTLMinutes * 60}
+}
+
+// RunRegister creates a pending device owned by the scitizen and issues its first enrollment code.
+func (f *DeviceRegistrationFlow) RunRegister(ctx context.Context, input RegisterDeviceInput) (*DeviceRegistration, error) {
+	if input.Class == "" {
+		return nil, fmt.Errorf("%w: device class is required", ErrInvalidDeviceRegistration)
+	}
+	if input.FirmwareVersion == "" {
+		return nil, fmt.Errorf("%w: firmware version is required", ErrInvalidDeviceRegistration)
+	}
+	if input.Tier != 1 && input.Tier != 2 {
+		return nil, fmt.Errorf("%w: tier must be 1 or 2", ErrInvalidDeviceRegistration)
+	}
+	if len(input.Sensors) == 0 {
+		return nil, fmt.Errorf("%w: at least one sensor is required", ErrInvalidDeviceRegistration)
+	}
+	units, err := pure.NormalizeUnits(input.SensorUnits)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSensorUnits, err)
+	}
+
+	device, err := f.deviceOps.CreateDevice(ctx, deviceops.CreateDeviceInput{
+		OwnerID:         input.OwnerID,
+		Class:           input.Class,
+		FirmwareVersion: input.FirmwareVersion,
+		Tier:            input.Tier,
+		Sensors:         input.Sensors,
+		SensorUnits:     units,
+		Gateway:         input.Gateway,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create device: %w", err)
+	}
+
+	code, err := f.deviceOps.GenerateEnrollmentCode(ctx, deviceops.GenerateCodeInput{
+		DeviceID: device.ID,
+		TTL:      f.codeTTL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("generate enrollment code: %w", err)
+	}
+
+	// Update onboarding state (best-effort)
+	t := true
+	_ = f.scitizenOps.UpdateOnboarding(ctx, scitizenops.UpdateOnboardingInput{
+		UserID:           input.OwnerID,
+		DeviceRegistered: &t,
+	})
+
+	return &DeviceRegistration{
+		DeviceID:  device.ID,
+		Status:    device.Status,
+		Code:      code.Code,
+		ExpiresAt: code.ExpiresAt,
+	}, nil
+}
+
+// RunListCodes returns the scitizen's unused, unexpired enrollment codes.
+func (f *DeviceRegistrationFlow) RunListCodes(ctx context.Context, ownerID string) ([]PendingEnrollmentCode, error) {
+	codes, err := f.deviceOps.ListPendingEnrollmentCodes(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]PendingEnrollmentCode, len(codes))
+	for i, c := range codes {
+		out[i] = PendingEnrollmentCode{DeviceID: c.DeviceID, Code: c.Code, ExpiresAt: c.ExpiresAt}
+	}
+	return out, nil
+}
+
+// RunRegenerateCode expires any pending codes for the device and issues a fresh one.
+func (f *DeviceRegistrationFlow) RunRegenerateCode(ctx context.Context, input EnrollmentCodeInput) (*PendingEnrollmentCode, error) {
+	if err := f.checkPendingOwnedDevice(ctx, input); err != nil {
+		return nil, err
+	}
+
+	code, err := f.deviceOps.RegenerateEnrollmentCode(ctx, deviceops.GenerateCodeInput{
+		DeviceID: input.DeviceID,
+		TTL:      f.codeTTL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("regenerate enrollment code: %w", err)
+	}
+
+	return &PendingEnrollmentCode{DeviceID: code.DeviceID, Code: code.Code, ExpiresAt: code.ExpiresAt}, nil
+}
+
+// RunExpireCode invalidates the device's pending enrollment codes. Returns the number expired.
+func (f *DeviceRegistrationFlow) RunExpireCode(ctx context.Context, input EnrollmentCodeInput) (int, error) {
+	device, err := getDevice(ctx, f.deviceOps, input.DeviceID)
+	if err != nil {
+		return 0, err
+	}
+	if device.OwnerID != input.OwnerID {
+		return 0, ErrNotDeviceOwner
+	}
+	return f.deviceOps.ExpireEnrollmentCodes(ctx, input.DeviceID)
+}
+
+// RunSetSensorUnits replaces the units the device declares for its sensors and returns the
+// units ingestion will now assume for each sensor, class defaults included.
+func (f *DeviceRegistrationFlow) RunSetSensorUnits(ctx context.Context, input SetSensorUnitsInput) (map[string]string, error) {
+	device, err := getDevice(ctx, f.deviceOps, input.DeviceID)
+	if err != nil {
+		return nil, err
+	}
+	if device.OwnerID != input.OwnerID {
+		return nil, ErrNotDeviceOwner
+	}
+	units, err := pure.NormalizeUnits(input.Units)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSensorUnits, err)
+	}
+	if err := f.deviceOps.SetSensorUnits(ctx, input.DeviceID, units); err != nil {
+		return nil, err
+	}
+	return f.deviceOps.ResolveSensorUnits(ctx, input.DeviceID)
+}
+
+func (f *DeviceRegistrationFlow) checkPendingOwnedDevice(ctx context.Context, input EnrollmentCodeInput) error {
+	device, err := getDevice(ctx, f.deviceOps, input.DeviceID)
+	if err != nil {
+		return err
+	}
+	if device.OwnerID != input.OwnerID {
+		return ErrNotDeviceOwner
+	}
+	if device.Status != "pending" {
+		return ErrDeviceNotPending
+	}
+	return nil
+}
+
+// getDevice looks up a device, reporting a missing one as ErrDeviceNotFound.
+func getDevice(ctx context.Context, deviceOps *deviceops.Ops, id string) (*deviceops.Device, error) {
+	device, err := deviceOps.GetDevice(ctx, id)
+	if errors.Is(err, deviceops.ErrDeviceNotFound) {
+		return nil, fmt.Errorf("%w: %s", ErrDeviceNotFound, id)
+	}
+	return device, err
+}
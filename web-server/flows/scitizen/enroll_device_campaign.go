@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	enrollmentops "rootstock/web-server/ops/enrollment"
+	"rootstock/web-server/ops/pure"
 	scitizenops "rootstock/web-server/ops/scitizen"
 )
 
@@ -14,11 +15,13 @@ import (
 type EnrollDeviceCampaignFlow struct {
 	scitizenOps   *scitizenops.Ops
 	enrollmentOps *enrollmentops.Ops
+	regionBuffer  float64
 }
 
-// NewEnrollDeviceCampaignFlow creates the flow with its required ops.
-func NewEnrollDeviceCampaignFlow(scitizenOps *scitizenops.Ops, enrollmentOps *enrollmentops.Ops) *EnrollDeviceCampaignFlow {
-	return &EnrollDeviceCampaignFlow{scitizenOps: scitizenOps, enrollmentOps: enrollmentOps}
+// NewEnrollDeviceCampaignFlow creates the flow with its required ops. Deployment locations
+// more than regionBufferMeters outside the campaign regions are flagged for review.
+func NewEnrollDeviceCampaignFlow(scitizenOps *scitizenops.Ops, enrollmentOps *enrollmentops.Ops, regionBufferMeters float64) *EnrollDeviceCampaignFlow {
+	return &EnrollDeviceCampaignFlow{scitizenOps: scitizenOps, enrollmentOps: enrollmentOps, regionBuffer: regionBufferMeters}
 }
 
 // Run enrolls a device in a campaign after eligibility check and consent capture.
@@ -64,18 +67,45 @@ func (f *EnrollDeviceCampaignFlow) Run(ctx context.Context, input EnrollDeviceIn
 		}
 	}
 
+	// Check the deployment location against campaign regions; outside is flagged, not refused (FR-124)
+	var locationFlag string
+	if input.DeploymentLocation != "" {
+		point, err := pure.ParseGeoPoint(input.DeploymentLocation)
+		if err != nil {
+			return &EnrollResult{Enrolled: false, Reason: "deployment location must be a GeoJSON Point"}, nil
+		}
+		var regions []pure.GeoRegion
+		for _, r := range detail.Regions {
+			if region, err := pure.ParseGeoRegion(r.GeoJSON); err == nil {
+				regions = append(regions, region)
+			}
+		}
+		if check := pure.CheckRegions(*point, regions, f.regionBuffer); !check.Inside {
+			locationFlag = fmt.Sprintf("deployment location %.0f m outside campaign regions", check.DistanceMeters)
+		}
+	}
+
 	// Enroll with consent
 	enrollment, err := f.enrollmentOps.Enroll(ctx, enrollmentops.EnrollInput{
-		DeviceID:       input.DeviceID,
-		CampaignID:     input.CampaignID,
-		ScitizenID:     input.ScitizenID,
-		ConsentVersion: input.ConsentVersion,
-		ConsentScope:   input.ConsentScope,
+		DeviceID:           input.DeviceID,
+		CampaignID:         input.CampaignID,
+		ScitizenID:         input.ScitizenID,
+		ConsentVersion:     input.ConsentVersion,
+		ConsentScope:       input.ConsentScope,
+		DeploymentLocation: input.DeploymentLocation,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("enroll device: %w", err)
 	}
 
+	// Flag for researcher review (best-effort)
+	if locationFlag != "" {
+		_ = f.enrollmentOps.FlagLocation(ctx, enrollmentops.FlagLocationInput{
+			EnrollmentID: enrollment.ID,
+			Reason:       locationFlag,
+		})
+	}
+
 	// Update onboarding state (best-effort)
 	t := true
 	_ = f.scitizenOps.UpdateOnboarding(ctx, scitizenops.UpdateOnboardingInput{
@@ -84,8 +114,9 @@ func (f *EnrollDeviceCampaignFlow) Run(ctx context.Context, input EnrollDeviceIn
 	})
 
 	return &EnrollResult{
-		Enrolled:     true,
-		Reason:       "enrolled successfully",
-		EnrollmentID: enrollment.ID,
+		Enrolled:        true,
+		Reason:          "enrolled successfully",
+		EnrollmentID:    enrollment.ID,
+		LocationFlagged: locationFlag != "",
 	}, nil
 }
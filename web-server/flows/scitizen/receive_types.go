@@ -25,11 +25,12 @@ type SearchInput struct {
 
 // EnrollDeviceInput enrolls a device in a campaign.
 type EnrollDeviceInput struct {
-	ScitizenID     string
-	DeviceID       string
-	CampaignID     string
-	ConsentVersion string
-	ConsentScope   string
+	ScitizenID         string
+	DeviceID           string
+	CampaignID         string
+	ConsentVersion     string
+	ConsentScope       string
+	DeploymentLocation string // GeoJSON Point where the device is placed; optional
 }
 
 // GetNotificationsInput filters notifications.
@@ -39,3 +40,41 @@ type GetNotificationsInput struct {
 	Limit      int
 	Offset     int
 }
+
+// RegisterDeviceInput declares a new device owned by a scitizen.
+type RegisterDeviceInput struct {
+	OwnerID         string
+	Class           string
+	FirmwareVersion string
+	Tier            int
+	Sensors         []string
+	SensorUnits     map[string]string // sensor -> unit; overrides the device class defaults
+	Gateway         bool              // enrolls and relays for child devices
+}
+
+// SetSensorUnitsInput replaces the units a scitizen's device declares for its sensors.
+type SetSensorUnitsInput struct {
+	OwnerID  string
+	DeviceID string
+	Units    map[string]string
+}
+
+// EnrollmentCodeInput identifies a device's enrollment codes on behalf of its owner.
+type EnrollmentCodeInput struct {
+	OwnerID  string
+	DeviceID string
+}
+
+// LinkStationInput links a scitizen's device to a consumer weather-station upload protocol.
+type LinkStationInput struct {
+	OwnerID      string
+	DeviceID     string
+	Protocol     string            // wunderground, ecowitt or ambient
+	ParameterMap map[string]string // standard parameter -> campaign parameter, e.g. temperature -> air_temp
+}
+
+// UnlinkStationInput removes a scitizen's device's station link.
+type UnlinkStationInput struct {
+	OwnerID  string
+	DeviceID string
+}
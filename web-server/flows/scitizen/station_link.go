@@ -0,0 +1,109 @@
+package scitizen
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	deviceops "rootstock/web-server/ops/device"
+	"rootstock/web-server/ops/pure"
+)
+
+// ErrInvalidStationLink is returned for an unknown protocol or a parameter map the protocol cannot satisfy.
+var ErrInvalidStationLink = errors.New("invalid station link")
+
+// ErrDeviceNotLinkable is returned when a revoked or suspended device is linked as a station.
+var ErrDeviceNotLinkable = errors.New("device cannot be linked as a station")
+
+// StationLinkFlow lets a scitizen point an existing consumer weather station at Rootstock.
+// Linking mints the device's upload URL; the station's own firmware uploads to it in its
+// vendor's protocol, so the device never enrolls for a certificate.
+type StationLinkFlow struct {
+	deviceOps     *deviceops.Ops
+	uploadBaseURL string
+}
+
+// NewStationLinkFlow creates the flow with its required ops. Upload URLs are served under publicBaseURL.
+func NewStationLinkFlow(deviceOps *deviceops.Ops, publicBaseURL string) *StationLinkFlow {
+	return &StationLinkFlow{deviceOps: deviceOps, uploadBaseURL: strings.TrimRight(publicBaseURL, "/") + "/station/"}
+}
+
+// RunLink links the scitizen's device to a station protocol and returns its upload URL, which
+// is shown only this once; linking again replaces the URL. The units the protocol reports are
+// declared for parameters the device has no unit for, so ingestion converts them. A pending
+// device becomes active, as enrollment would make it, and its enrollment codes are expired.
+func (f *StationLinkFlow) RunLink(ctx context.Context, input LinkStationInput) (*LinkedStation, error) {
+	if err := pure.ValidateStationParameterMap(input.Protocol, input.ParameterMap); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidStationLink, err)
+	}
+	device, err := getDevice(ctx, f.deviceOps, input.DeviceID)
+	if err != nil {
+		return nil, err
+	}
+	if device.OwnerID != input.OwnerID {
+		return nil, ErrNotDeviceOwner
+	}
+	if device.Status != "pending" && device.Status != "active" {
+		return nil, fmt.Errorf("%w: device is %s", ErrDeviceNotLinkable, device.Status)
+	}
+
+	resolved, err := f.deviceOps.ResolveSensorUnits(ctx, input.DeviceID)
+	if err != nil {
+		return nil, fmt.Errorf("resolve sensor units: %w", err)
+	}
+	units := make(map[string]string, len(device.SensorUnits))
+	for param, unit := range device.SensorUnits {
+		units[param] = unit
+	}
+	declared := false
+	for param, unit := range pure.StationParameters(input.Protocol, input.ParameterMap) {
+		if _, ok := resolved[param]; !ok {
+			units[param] = unit
+			declared = true
+		}
+	}
+	if declared {
+		if err := f.deviceOps.SetSensorUnits(ctx, input.DeviceID, units); err != nil {
+			return nil, fmt.Errorf("declare station units: %w", err)
+		}
+	}
+
+	link, err := f.deviceOps.LinkStation(ctx, deviceops.LinkStationInput{
+		DeviceID:     input.DeviceID,
+		Protocol:     input.Protocol,
+		ParameterMap: input.ParameterMap,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("link station: %w", err)
+	}
+
+	if device.Status == "pending" {
+		if err := f.deviceOps.UpdateDeviceStatus(ctx, input.DeviceID, "active"); err != nil {
+			return nil, fmt.Errorf("activate device: %w", err)
+		}
+		if _, err := f.deviceOps.ExpireEnrollmentCodes(ctx, input.DeviceID); err != nil {
+			return nil, fmt.Errorf("expire enrollment codes: %w", err)
+		}
+	}
+
+	return &LinkedStation{
+		DeviceID:  link.DeviceID,
+		Protocol:  link.Protocol,
+		UploadURL: f.uploadBaseURL + link.Token,
+		CreatedAt: link.CreatedAt,
+	}, nil
+}
+
+// RunUnlink removes the scitizen's device's station link, so its upload URL stops working.
+// Returns false when the device was not linked.
+func (f *StationLinkFlow) RunUnlink(ctx context.Context, input UnlinkStationInput) (bool, error) {
+	device, err := getDevice(ctx, f.deviceOps, input.DeviceID)
+	if err != nil {
+		return false, err
+	}
+	if device.OwnerID != input.OwnerID {
+		return false, ErrNotDeviceOwner
+	}
+	return f.deviceOps.UnlinkStation(ctx, input.DeviceID)
+}
@@ -17,7 +17,17 @@ func NewWithdrawEnrollmentFlow(enrollmentOps *enrollmentops.Ops) *WithdrawEnroll
 	return &WithdrawEnrollmentFlow{enrollmentOps: enrollmentOps}
 }
 
-// Run withdraws a device enrollment by enrollment ID.
-func (f *WithdrawEnrollmentFlow) Run(ctx context.Context, enrollmentID string) error {
-	return f.enrollmentOps.Withdraw(ctx, enrollmentID)
+// Run withdraws a device enrollment by enrollment ID and returns the withdrawn enrollment.
+func (f *WithdrawEnrollmentFlow) Run(ctx context.Context, enrollmentID string) (*Enrollment, error) {
+	if err := f.enrollmentOps.Withdraw(ctx, enrollmentID); err != nil {
+		return nil, err
+	}
+	e, err := f.enrollmentOps.GetByID(ctx, enrollmentID)
+	if err != nil {
+		return nil, err
+	}
+	return &Enrollment{
+		ID: e.ID, DeviceID: e.DeviceID, CampaignID: e.CampaignID,
+		Status: e.Status, EnrolledAt: e.EnrolledAt,
+	}, nil
 }
@@ -1,8 +1,21 @@
 package security
 
+import "time"
+
 // SecurityResponseResult is the outcome of a security response action.
 type SecurityResponseResult struct {
 	SuspendedCount      int
 	QuarantinedReadings int64
 	NotifiedScitizens   int
 }
+
+// RateLimitFlag is a device flagged for repeatedly exceeding ingestion rate limits.
+type RateLimitFlag struct {
+	DeviceID        string
+	OwnerID         string
+	Class           string
+	FirmwareVersion string
+	Status          string
+	Violations      int64
+	FlaggedAt       time.Time
+}
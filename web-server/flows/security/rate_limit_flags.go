@@ -0,0 +1,65 @@
+package security
+
+import (
+	"context"
+	"log/slog"
+
+	deviceops "rootstock/web-server/ops/device"
+)
+
+// RateLimitFlagFlow records devices that keep exceeding ingestion rate limits and lets
+// administrators review and clear them. Flagging does not suspend a device: that stays
+// a decision for the security response (SuspendByClass, revocation).
+type RateLimitFlagFlow struct {
+	deviceOps *deviceops.Ops
+}
+
+// NewRateLimitFlagFlow creates the flow with its required ops.
+func NewRateLimitFlagFlow(deviceOps *deviceops.Ops) *RateLimitFlagFlow {
+	return &RateLimitFlagFlow{deviceOps: deviceOps}
+}
+
+// RunFlag adds to a device's violation count and flags it for review.
+func (f *RateLimitFlagFlow) RunFlag(ctx context.Context, input FlagRateLimitInput) (*RateLimitFlag, error) {
+	result, err := f.deviceOps.FlagRateLimitViolator(ctx, deviceops.FlagRateLimitInput{
+		DeviceID:   input.DeviceID,
+		Violations: input.Violations,
+	})
+	if err != nil {
+		return nil, err
+	}
+	slog.WarnContext(ctx, "device flagged for repeated rate limit violations",
+		"device_id", result.DeviceID, "violations", result.Violations, "flagged_at", result.FlaggedAt)
+	flag := fromOpsRateLimitFlag(*result)
+	return &flag, nil
+}
+
+// RunList returns flagged devices, most recently flagged first.
+func (f *RateLimitFlagFlow) RunList(ctx context.Context) ([]RateLimitFlag, error) {
+	results, err := f.deviceOps.ListRateLimitFlagged(ctx)
+	if err != nil {
+		return nil, err
+	}
+	flags := make([]RateLimitFlag, len(results))
+	for i, r := range results {
+		flags[i] = fromOpsRateLimitFlag(r)
+	}
+	return flags, nil
+}
+
+// RunClear removes a device's flag once it has been dealt with.
+func (f *RateLimitFlagFlow) RunClear(ctx context.Context, deviceID string) error {
+	return f.deviceOps.ClearRateLimitFlag(ctx, deviceID)
+}
+
+func fromOpsRateLimitFlag(r deviceops.RateLimitFlag) RateLimitFlag {
+	return RateLimitFlag{
+		DeviceID:        r.DeviceID,
+		OwnerID:         r.OwnerID,
+		Class:           r.Class,
+		FirmwareVersion: r.FirmwareVersion,
+		Status:          r.Status,
+		Violations:      r.Violations,
+		FlaggedAt:       r.FlaggedAt,
+	}
+}
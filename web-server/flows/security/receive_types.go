@@ -5,9 +5,18 @@ import "time"
 // SecurityResponseInput is what callers send to SecurityResponseFlow.Run.
 type SecurityResponseInput struct {
 	Class       string
-	FirmwareMin string
-	FirmwareMax string
-	WindowStart time.Time
-	WindowEnd   time.Time
-	Reason      string
+	FirmwareMin string // inclusive; ignored when FirmwareConstraint is set
+	FirmwareMax string // inclusive; ignored when FirmwareConstraint is set
+	// FirmwareConstraint selects affected firmware with a version constraint,
+	// e.g. ">=2.1.0 <3, !=2.4.1". Empty means the FirmwareMin-FirmwareMax range.
+	FirmwareConstraint string
+	WindowStart        time.Time
+	WindowEnd          time.Time
+	Reason             string
+}
+
+// FlagRateLimitInput is what callers send to RateLimitFlagFlow.RunFlag.
+type FlagRateLimitInput struct {
+	DeviceID   string
+	Violations int64 // violations since the device was last reported
 }
@@ -2,47 +2,91 @@ package security
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"strings"
 
 	deviceops "rootstock/web-server/ops/device"
+	mqttops "rootstock/web-server/ops/mqtt"
 	notificationops "rootstock/web-server/ops/notification"
+	"rootstock/web-server/ops/pure"
 	readingops "rootstock/web-server/ops/reading"
 )
 
+// ErrInvalidFirmwareConstraint is returned when the affected firmware range does not parse.
+var ErrInvalidFirmwareConstraint = errors.New("invalid firmware constraint")
+
 // SecurityResponseFlow suspends vulnerable devices by class/firmware,
 // quarantines readings from the vulnerability window, and notifies affected scitizens.
 type SecurityResponseFlow struct {
 	deviceOps       *deviceops.Ops
 	readingOps      *readingops.Ops
 	notificationOps *notificationops.Ops
+	mqttOps         *mqttops.Ops
 }
 
 // NewSecurityResponseFlow creates the flow with its required ops.
-func NewSecurityResponseFlow(deviceOps *deviceops.Ops, readingOps *readingops.Ops, notificationOps *notificationops.Ops) *SecurityResponseFlow {
+func NewSecurityResponseFlow(deviceOps *deviceops.Ops, readingOps *readingops.Ops, notificationOps *notificationops.Ops, mqttOps *mqttops.Ops) *SecurityResponseFlow {
 	return &SecurityResponseFlow{
 		deviceOps:       deviceOps,
 		readingOps:      readingOps,
 		notificationOps: notificationOps,
+		mqttOps:         mqttOps,
 	}
 }
 
 // Run executes the security response: suspend, quarantine, notify.
 func (f *SecurityResponseFlow) Run(ctx context.Context, input SecurityResponseInput) (*SecurityResponseResult, error) {
-	// 1. Query affected devices by class and firmware range.
-	devices, err := f.deviceOps.QueryDevicesByClass(ctx, deviceops.QueryByClassInput{
-		Class:          input.Class,
-		FirmwareMinGte: input.FirmwareMin,
-		FirmwareMaxLte: input.FirmwareMax,
+	// 1. Query the class and keep the devices whose firmware is affected. Versions are
+	// compared as versions, so 1.10.0 is above 1.9.0; firmware that does not parse as a
+	// version cannot be placed in the range and is left alone.
+	affected := affectedFirmware(input)
+	constraint, err := pure.ParseVersionConstraint(affected)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFirmwareConstraint, err)
+	}
+	classDevices, err := f.deviceOps.QueryDevicesByClass(ctx, deviceops.QueryByClassInput{
+		Class: input.Class,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("query devices by class: %w", err)
 	}
+	var devices []deviceops.Device
+	for _, d := range classDevices {
+		if affected == "" {
+			devices = append(devices, d)
+			continue
+		}
+		v, err := pure.ParseVersion(d.FirmwareVersion)
+		if err != nil {
+			slog.WarnContext(ctx, "skip device with unrecognised firmware version", "device_id", d.ID, "firmware_version", d.FirmwareVersion)
+			continue
+		}
+		if constraint.Allows(v) {
+			devices = append(devices, d)
+		}
+	}
 
-	// 2. Suspend each device.
+	// 2. Suspend each device and drop its live session; it reconnects restricted to renew/cert.
+	// A gateway relaying for a suspended device drops too, so its subscriptions to the
+	// device's topics end and it reconnects relaying only what a suspended device may use.
+	gateways := make(map[string]bool)
 	for _, d := range devices {
 		if err := f.deviceOps.UpdateDeviceStatus(ctx, d.ID, "suspended"); err != nil {
 			return nil, fmt.Errorf("suspend device %s: %w", d.ID, err)
 		}
+		if _, err := f.mqttOps.DisconnectDevice(ctx, d.ID); err != nil {
+			slog.WarnContext(ctx, "disconnect suspended device", "device_id", d.ID, "error", err)
+		}
+		if d.GatewayID != nil {
+			gateways[*d.GatewayID] = true
+		}
+	}
+	for gatewayID := range gateways {
+		if _, err := f.mqttOps.DisconnectDevice(ctx, gatewayID); err != nil {
+			slog.WarnContext(ctx, "disconnect suspended devices' gateway", "gateway_id", gatewayID, "error", err)
+		}
 	}
 
 	// 3. Collect device IDs and quarantine readings from the vulnerability window.
@@ -75,7 +119,7 @@ func (f *SecurityResponseFlow) Run(ctx context.Context, input SecurityResponseIn
 		recipients = append(recipients, notificationops.Recipient{
 			ID:      d.OwnerID,
 			Subject: "Device suspended due to security vulnerability",
-			Body:    fmt.Sprintf("Your device (class %s) has been suspended: %s. Affected firmware range: %s–%s.", input.Class, input.Reason, input.FirmwareMin, input.FirmwareMax),
+			Body:    fmt.Sprintf("Your device (class %s) has been suspended: %s. Affected firmware: %s.", input.Class, input.Reason, affectedDescription(affected)),
 		})
 	}
 
@@ -93,3 +137,26 @@ func (f *SecurityResponseFlow) Run(ctx context.Context, input SecurityResponseIn
 		NotifiedScitizens:   len(recipients),
 	}, nil
 }
+
+// affectedFirmware is the version constraint naming the affected firmware: the
+// input's constraint, or else its inclusive min-max range.
+func affectedFirmware(input SecurityResponseInput) string {
+	if input.FirmwareConstraint != "" {
+		return input.FirmwareConstraint
+	}
+	var terms []string
+	if input.FirmwareMin != "" {
+		terms = append(terms, ">="+input.FirmwareMin)
+	}
+	if input.FirmwareMax != "" {
+		terms = append(terms, "<="+input.FirmwareMax)
+	}
+	return strings.Join(terms, " ")
+}
+
+func affectedDescription(affected string) string {
+	if affected == "" {
+		return "all versions"
+	}
+	return affected
+}
@@ -2,18 +2,23 @@ package security
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
+	mochi "github.com/mochi-mqtt/server/v2"
+	"github.com/mochi-mqtt/server/v2/hooks/auth"
 	"github.com/oklog/ulid/v2"
 
 	"rootstock/web-server/config"
 	deviceops "rootstock/web-server/ops/device"
+	mqttops "rootstock/web-server/ops/mqtt"
 	notificationops "rootstock/web-server/ops/notification"
 	readingops "rootstock/web-server/ops/reading"
 	devicerepo "rootstock/web-server/repo/device"
+	mqttrepo "rootstock/web-server/repo/mqtt"
 	notificationrepo "rootstock/web-server/repo/notification"
 	readingrepo "rootstock/web-server/repo/reading"
 	sqlmigrate "rootstock/web-server/repo/sql/migrate"
@@ -43,16 +48,26 @@ func setupSecurityResponseTest(t *testing.T) (*SecurityResponseFlow, *pgxpool.Po
 	rRepo := readingrepo.NewRepository(pool)
 	nRepo := notificationrepo.NewRepository("maildev", 1025, "noreply@rootstock.local")
 
+	broker := mochi.New(&mochi.Options{InlineClient: true})
+	if err := broker.AddHook(new(auth.AllowHook), nil); err != nil {
+		t.Fatalf("add allow hook: %v", err)
+	}
+	go broker.Serve()
+	mRepo := mqttrepo.NewRepository(broker)
+
 	dOps := deviceops.NewOps(dRepo)
 	rOps := readingops.NewOps(rRepo)
 	nOps := notificationops.NewOps(nRepo)
+	mOps := mqttops.NewOps(mRepo)
 
-	flow := NewSecurityResponseFlow(dOps, rOps, nOps)
+	flow := NewSecurityResponseFlow(dOps, rOps, nOps, mOps)
 
 	t.Cleanup(func() {
 		dRepo.Shutdown()
 		rRepo.Shutdown()
 		nRepo.Shutdown()
+		mRepo.Shutdown()
+		broker.Close()
 		pool.Close()
 	})
 
@@ -209,3 +224,39 @@ func TestSecurityResponseDeduplicatesOwners(t *testing.T) {
 		t.Errorf("NotifiedScitizens = %d, want 1", result.NotifiedScitizens)
 	}
 }
+
+func TestSecurityResponseComparesVersions(t *testing.T) {
+	flow, pool := setupSecurityResponseTest(t)
+	ctx := context.Background()
+
+	affected := insertDevice(t, pool, "sci-5@test.local", "tier1", "1.10.0")
+	excluded := insertDevice(t, pool, "sci-6@test.local", "tier1", "2.4.1")
+	old := insertDevice(t, pool, "sci-7@test.local", "tier1", "1.9.0")
+
+	result, err := flow.Run(ctx, SecurityResponseInput{
+		Class:              "tier1",
+		FirmwareConstraint: ">=1.10.0 <3, !=2.4.1",
+		WindowStart:        time.Now().Add(-24 * time.Hour),
+		WindowEnd:          time.Now(),
+		Reason:             "test vulnerability",
+	})
+	if err != nil {
+		t.Fatalf("Run(): %v", err)
+	}
+	if result.SuspendedCount != 1 {
+		t.Errorf("SuspendedCount = %d, want 1", result.SuspendedCount)
+	}
+
+	for id, want := range map[string]string{affected: "suspended", excluded: "active", old: "active"} {
+		var status string
+		pool.QueryRow(ctx, "SELECT status FROM devices WHERE id = $1", id).Scan(&status)
+		if status != want {
+			t.Errorf("device %s status = %q, want %q", id, status, want)
+		}
+	}
+
+	_, err = flow.Run(ctx, SecurityResponseInput{Class: "tier1", FirmwareConstraint: ">=", Reason: "test"})
+	if !errors.Is(err, ErrInvalidFirmwareConstraint) {
+		t.Errorf("Run(invalid constraint) = %v, want ErrInvalidFirmwareConstraint", err)
+	}
+}
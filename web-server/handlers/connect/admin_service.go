@@ -2,25 +2,82 @@ package connect
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"connectrpc.com/connect"
 
+	"rootstock/web-server/auth"
+	deviceflows "rootstock/web-server/flows/device"
+	readingflows "rootstock/web-server/flows/reading"
 	securityflows "rootstock/web-server/flows/security"
+	userflows "rootstock/web-server/flows/user"
 	rootstockv1 "rootstock/web-server/proto/rootstock/v1"
 )
 
+// ErrDeadLetterNotReplayable is returned for a dead letter whose topic is not a telemetry topic.
+var ErrDeadLetterNotReplayable = errors.New("dead letter is not on a replayable topic")
+
+// DeadLetterReplayer resubmits a dead letter's payload for ingestion and waits for the outcome.
+type DeadLetterReplayer interface {
+	ReplayDeadLetter(ctx context.Context, dl *readingflows.DeadLetter) (DeadLetterReplay, error)
+}
+
+// DeadLetterReplay is how ingestion answered a replayed dead letter.
+type DeadLetterReplay struct {
+	Processed bool   // ingestion ran; false when it was too busy to take the payload
+	Status    string // the acknowledgement a device would have had: accepted | quarantined | duplicate | rejected | error
+	Code      string
+	Reason    string
+}
+
 // AdminServiceHandler implements the AdminService Connect RPC interface.
 type AdminServiceHandler struct {
 	securityResponse *securityflows.SecurityResponseFlow
+	deadLetters      *readingflows.DeadLetterFlow
+	replayer         DeadLetterReplayer
+	classUnits       *deviceflows.SetClassUnitsFlow
+	rateLimitFlags   *securityflows.RateLimitFlagFlow
+	firmwareOTA      *deviceflows.FirmwareOTAFlow
+	getUser          *userflows.GetUserFlow
 }
 
 // NewAdminServiceHandler creates the handler with all required flows.
-func NewAdminServiceHandler(securityResponse *securityflows.SecurityResponseFlow) *AdminServiceHandler {
+func NewAdminServiceHandler(
+	securityResponse *securityflows.SecurityResponseFlow,
+	deadLetters *readingflows.DeadLetterFlow,
+	replayer DeadLetterReplayer,
+	classUnits *deviceflows.SetClassUnitsFlow,
+	rateLimitFlags *securityflows.RateLimitFlagFlow,
+	firmwareOTA *deviceflows.FirmwareOTAFlow,
+	getUser *userflows.GetUserFlow,
+) *AdminServiceHandler {
 	return &AdminServiceHandler{
 		securityResponse: securityResponse,
+		deadLetters:      deadLetters,
+		replayer:         replayer,
+		classUnits:       classUnits,
+		rateLimitFlags:   rateLimitFlags,
+		firmwareOTA:      firmwareOTA,
+		getUser:          getUser,
+	}
+}
+
+// resolveUserID extracts the IdP user ID from context and resolves the app user ID.
+func (h *AdminServiceHandler) resolveUserID(ctx context.Context) (string, error) {
+	idpID, ok := auth.SubjectFromContext(ctx)
+	if !ok || idpID == "" {
+		return "", connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("no authenticated subject"))
+	}
+	user, err := h.getUser.Run(ctx, idpID)
+	if err != nil {
+		return "", connect.NewError(connect.CodeInternal, fmt.Errorf("resolve user: %w", err))
+	}
+	if user == nil {
+		return "", connect.NewError(connect.CodeNotFound, fmt.Errorf("user not found"))
 	}
+	return user.ID, nil
 }
 
 func (h *AdminServiceHandler) SuspendByClass(
@@ -37,13 +94,17 @@ func (h *AdminServiceHandler) SuspendByClass(
 	}
 
 	result, err := h.securityResponse.Run(ctx, securityflows.SecurityResponseInput{
-		Class:       req.Msg.GetDeviceClass(),
-		FirmwareMin: req.Msg.GetFirmwareMin(),
-		FirmwareMax: req.Msg.GetFirmwareMax(),
-		WindowStart: windowStart,
-		WindowEnd:   windowEnd,
-		Reason:      req.Msg.GetReason(),
+		Class:              req.Msg.GetDeviceClass(),
+		FirmwareMin:        req.Msg.GetFirmwareMin(),
+		FirmwareMax:        req.Msg.GetFirmwareMax(),
+		FirmwareConstraint: req.Msg.GetFirmwareConstraint(),
+		WindowStart:        windowStart,
+		WindowEnd:          windowEnd,
+		Reason:             req.Msg.GetReason(),
 	})
+	if errors.Is(err, securityflows.ErrInvalidFirmwareConstraint) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -54,3 +115,291 @@ func (h *AdminServiceHandler) SuspendByClass(
 		NotifiedScitizens:   int32(result.NotifiedScitizens),
 	}), nil
 }
+
+func (h *AdminServiceHandler) ListDeadLetters(
+	ctx context.Context,
+	req *connect.Request[rootstockv1.ListDeadLettersRequest],
+) (*connect.Response[rootstockv1.ListDeadLettersResponse], error) {
+	results, err := h.deadLetters.RunList(ctx, readingflows.ListDeadLettersInput{
+		ErrorClass: req.Msg.GetErrorClass(),
+		DeviceID:   req.Msg.GetDeviceId(),
+		Status:     req.Msg.GetStatus(),
+		Limit:      int(req.Msg.GetLimit()),
+		Offset:     int(req.Msg.GetOffset()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*rootstockv1.DeadLetterProto, len(results))
+	for i := range results {
+		out[i] = deadLetterToProto(&results[i])
+	}
+	return connect.NewResponse(&rootstockv1.ListDeadLettersResponse{DeadLetters: out}), nil
+}
+
+func (h *AdminServiceHandler) GetDeadLetter(
+	ctx context.Context,
+	req *connect.Request[rootstockv1.GetDeadLetterRequest],
+) (*connect.Response[rootstockv1.GetDeadLetterResponse], error) {
+	result, err := h.deadLetters.RunGet(ctx, req.Msg.GetId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+	return connect.NewResponse(&rootstockv1.GetDeadLetterResponse{
+		DeadLetter: deadLetterToProto(result),
+		Payload:    result.Payload,
+	}), nil
+}
+
+// ReplayDeadLetter sends a dead letter's payload through ingestion again and answers with
+// the outcome. The letter is marked replayed once ingestion has processed it; a replay that
+// fails again is dead-lettered as a new letter. A replay ingestion was too busy to take
+// leaves the letter pending, so it can be replayed later.
+func (h *AdminServiceHandler) ReplayDeadLetter(
+	ctx context.Context,
+	req *connect.Request[rootstockv1.ReplayDeadLetterRequest],
+) (*connect.Response[rootstockv1.ReplayDeadLetterResponse], error) {
+	letter, err := h.deadLetters.RunGet(ctx, req.Msg.GetId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+
+	replay, err := h.replayer.ReplayDeadLetter(ctx, letter)
+	switch {
+	case errors.Is(err, ErrDeadLetterNotReplayable):
+		return nil, connect.NewError(connect.CodeFailedPrecondition, err)
+	case errors.Is(err, context.DeadlineExceeded):
+		return nil, connect.NewError(connect.CodeDeadlineExceeded, fmt.Errorf("replay outcome not known yet; the letter is still pending"))
+	case err != nil:
+		return nil, err
+	}
+
+	if replay.Processed {
+		if letter, err = h.deadLetters.RunMarkReplayed(ctx, letter.ID); err != nil {
+			return nil, err
+		}
+	}
+	return connect.NewResponse(&rootstockv1.ReplayDeadLetterResponse{
+		DeadLetter: deadLetterToProto(letter),
+		Status:     replay.Status,
+		Code:       replay.Code,
+		Reason:     replay.Reason,
+	}), nil
+}
+
+func (h *AdminServiceHandler) PurgeDeadLetters(
+	ctx context.Context,
+	req *connect.Request[rootstockv1.PurgeDeadLettersRequest],
+) (*connect.Response[rootstockv1.PurgeDeadLettersResponse], error) {
+	input := readingflows.PurgeDeadLettersInput{
+		IDs:        req.Msg.GetIds(),
+		ErrorClass: req.Msg.GetErrorClass(),
+	}
+	if rb := req.Msg.GetReceivedBefore(); rb != "" {
+		t, err := time.Parse(time.RFC3339, rb)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("parse received_before: %w", err))
+		}
+		input.ReceivedBefore = &t
+	}
+
+	purged, err := h.deadLetters.RunPurge(ctx, input)
+	if err != nil {
+		if errors.Is(err, readingflows.ErrPurgeUnfiltered) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		return nil, err
+	}
+	return connect.NewResponse(&rootstockv1.PurgeDeadLettersResponse{Purged: purged}), nil
+}
+
+func deadLetterToProto(d *readingflows.DeadLetter) *rootstockv1.DeadLetterProto {
+	p := &rootstockv1.DeadLetterProto{
+		Id:           d.ID,
+		Topic:        d.Topic,
+		DeviceId:     d.DeviceID,
+		CampaignId:   d.CampaignID,
+		ErrorClass:   d.ErrorClass,
+		ErrorMessage: d.ErrorMessage,
+		ReceivedAt:   d.ReceivedAt.Format(time.RFC3339),
+		Status:       d.Status,
+		ReplayCount:  int32(d.ReplayCount),
+		PayloadSize:  int32(len(d.Payload)),
+	}
+	if d.LastReplayedAt != nil {
+		s := d.LastReplayedAt.Format(time.RFC3339)
+		p.LastReplayedAt = &s
+	}
+	return p
+}
+
+func (h *AdminServiceHandler) SetDeviceClassUnits(
+	ctx context.Context,
+	req *connect.Request[rootstockv1.SetDeviceClassUnitsRequest],
+) (*connect.Response[rootstockv1.SetDeviceClassUnitsResponse], error) {
+	units, err := h.classUnits.Run(ctx, deviceflows.SetClassUnitsInput{
+		Class: req.Msg.GetDeviceClass(),
+		Units: req.Msg.GetSensorUnits(),
+	})
+	if err != nil {
+		if errors.Is(err, deviceflows.ErrInvalidUnits) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		return nil, err
+	}
+
+	return connect.NewResponse(&rootstockv1.SetDeviceClassUnitsResponse{
+		SensorUnits: units,
+	}), nil
+}
+
+func (h *AdminServiceHandler) ListRateLimitFlaggedDevices(
+	ctx context.Context,
+	req *connect.Request[rootstockv1.ListRateLimitFlaggedDevicesRequest],
+) (*connect.Response[rootstockv1.ListRateLimitFlaggedDevicesResponse], error) {
+	results, err := h.rateLimitFlags.RunList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*rootstockv1.RateLimitFlagProto, len(results))
+	for i, f := range results {
+		out[i] = &rootstockv1.RateLimitFlagProto{
+			DeviceId:        f.DeviceID,
+			OwnerId:         f.OwnerID,
+			DeviceClass:     f.Class,
+			FirmwareVersion: f.FirmwareVersion,
+			Status:          f.Status,
+			Violations:      f.Violations,
+			FlaggedAt:       f.FlaggedAt.Format(time.RFC3339),
+		}
+	}
+	return connect.NewResponse(&rootstockv1.ListRateLimitFlaggedDevicesResponse{Devices: out}), nil
+}
+
+func (h *AdminServiceHandler) ClearRateLimitFlag(
+	ctx context.Context,
+	req *connect.Request[rootstockv1.ClearRateLimitFlagRequest],
+) (*connect.Response[rootstockv1.ClearRateLimitFlagResponse], error) {
+	if err := h.rateLimitFlags.RunClear(ctx, req.Msg.GetDeviceId()); err != nil {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+	return connect.NewResponse(&rootstockv1.ClearRateLimitFlagResponse{}), nil
+}
+
+func (h *AdminServiceHandler) CreateFirmwareRelease(
+	ctx context.Context,
+	req *connect.Request[rootstockv1.CreateFirmwareReleaseRequest],
+) (*connect.Response[rootstockv1.CreateFirmwareReleaseResponse], error) {
+	userID, err := h.resolveUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rollout, err := h.firmwareOTA.RunCreateRelease(ctx, deviceflows.CreateFirmwareReleaseInput{
+		Class:          req.Msg.GetDeviceClass(),
+		Version:        req.Msg.GetVersion(),
+		SHA256:         req.Msg.GetSha256(),
+		URL:            req.Msg.GetUrl(),
+		SizeBytes:      req.Msg.SizeBytes,
+		Notes:          req.Msg.GetNotes(),
+		SecurityFix:    req.Msg.GetSecurityFix(),
+		RolloutPercent: int(req.Msg.GetRolloutPercent()),
+		Cohort:         req.Msg.GetCohort(),
+		CreatedBy:      userID,
+	})
+	if err != nil {
+		return nil, firmwareError(err)
+	}
+	return connect.NewResponse(&rootstockv1.CreateFirmwareReleaseResponse{
+		Release:   firmwareReleaseToProto(&rollout.Release),
+		Published: int32(rollout.Published),
+	}), nil
+}
+
+func (h *AdminServiceHandler) ListFirmwareReleases(
+	ctx context.Context,
+	req *connect.Request[rootstockv1.ListFirmwareReleasesRequest],
+) (*connect.Response[rootstockv1.ListFirmwareReleasesResponse], error) {
+	results, err := h.firmwareOTA.RunListReleases(ctx, req.Msg.GetDeviceClass())
+	if err != nil {
+		return nil, err
+	}
+	releases := make([]*rootstockv1.FirmwareReleaseProto, len(results))
+	for i := range results {
+		releases[i] = firmwareReleaseToProto(&results[i])
+	}
+	return connect.NewResponse(&rootstockv1.ListFirmwareReleasesResponse{Releases: releases}), nil
+}
+
+func (h *AdminServiceHandler) SetFirmwareRollout(
+	ctx context.Context,
+	req *connect.Request[rootstockv1.SetFirmwareRolloutRequest],
+) (*connect.Response[rootstockv1.SetFirmwareRolloutResponse], error) {
+	rollout, err := h.firmwareOTA.RunSetRollout(ctx, deviceflows.SetFirmwareRolloutInput{
+		ReleaseID:      req.Msg.GetReleaseId(),
+		RolloutPercent: int(req.Msg.GetRolloutPercent()),
+		Cohort:         req.Msg.GetCohort(),
+	})
+	if err != nil {
+		return nil, firmwareError(err)
+	}
+	return connect.NewResponse(&rootstockv1.SetFirmwareRolloutResponse{
+		Release:   firmwareReleaseToProto(&rollout.Release),
+		Published: int32(rollout.Published),
+	}), nil
+}
+
+func (h *AdminServiceHandler) ListFirmwareInstalls(
+	ctx context.Context,
+	req *connect.Request[rootstockv1.ListFirmwareInstallsRequest],
+) (*connect.Response[rootstockv1.ListFirmwareInstallsResponse], error) {
+	results, err := h.firmwareOTA.RunListInstalls(ctx, req.Msg.GetReleaseId())
+	if err != nil {
+		return nil, err
+	}
+	installs := make([]*rootstockv1.FirmwareInstallProto, len(results))
+	for i, in := range results {
+		installs[i] = &rootstockv1.FirmwareInstallProto{
+			ReleaseId:  in.ReleaseID,
+			DeviceId:   in.DeviceID,
+			Status:     in.Status,
+			Error:      in.Error,
+			NotifiedAt: in.NotifiedAt.Format(time.RFC3339),
+			UpdatedAt:  in.UpdatedAt.Format(time.RFC3339),
+		}
+	}
+	return connect.NewResponse(&rootstockv1.ListFirmwareInstallsResponse{Installs: installs}), nil
+}
+
+// firmwareError maps firmware release validation and lookup errors to Connect codes.
+func firmwareError(err error) error {
+	switch {
+	case errors.Is(err, deviceflows.ErrInvalidFirmwareRelease):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, deviceflows.ErrFirmwareReleaseExists):
+		return connect.NewError(connect.CodeAlreadyExists, err)
+	case errors.Is(err, deviceflows.ErrFirmwareReleaseNotFound):
+		return connect.NewError(connect.CodeNotFound, err)
+	default:
+		return err
+	}
+}
+
+func firmwareReleaseToProto(r *deviceflows.FirmwareRelease) *rootstockv1.FirmwareReleaseProto {
+	return &rootstockv1.FirmwareReleaseProto{
+		Id:             r.ID,
+		DeviceClass:    r.Class,
+		Version:        r.Version,
+		Sha256:         r.SHA256,
+		Url:            r.URL,
+		SizeBytes:      r.SizeBytes,
+		Notes:          r.Notes,
+		SecurityFix:    r.SecurityFix,
+		RolloutPercent: int32(r.RolloutPercent),
+		Cohort:         r.Cohort,
+		CreatedBy:      r.CreatedBy,
+		CreatedAt:      r.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:      r.UpdatedAt.Format(time.RFC3339),
+	}
+}
@@ -2,12 +2,14 @@ package connect
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"time"
 
 	"connectrpc.com/connect"
 
 	campaignflows "rootstock/web-server/flows/campaign"
+	deviceflows "rootstock/web-server/flows/device"
 	readingflows "rootstock/web-server/flows/reading"
 	rootstockv1 "rootstock/web-server/proto/rootstock/v1"
 )
@@ -19,6 +21,7 @@ type CampaignServiceHandler struct {
 	browseCampaigns   *campaignflows.BrowseCampaignsFlow
 	campaignDashboard *campaignflows.DashboardFlow
 	exportData        *readingflows.ExportDataFlow
+	deviceConfig      *deviceflows.DeviceConfigFlow
 	hmacSecret        string
 }
 
@@ -29,6 +32,7 @@ func NewCampaignServiceHandler(
 	browseCampaigns *campaignflows.BrowseCampaignsFlow,
 	campaignDashboard *campaignflows.DashboardFlow,
 	exportData *readingflows.ExportDataFlow,
+	deviceConfig *deviceflows.DeviceConfigFlow,
 	hmacSecret string,
 ) *CampaignServiceHandler {
 	return &CampaignServiceHandler{
@@ -37,6 +41,7 @@ func NewCampaignServiceHandler(
 		browseCampaigns:   browseCampaigns,
 		campaignDashboard: campaignDashboard,
 		exportData:        exportData,
+		deviceConfig:      deviceConfig,
 		hmacSecret:        hmacSecret,
 	}
 }
@@ -52,6 +57,13 @@ func (h *CampaignServiceHandler) CreateCampaign(
 		CreatedBy:   msg.GetCreatedBy(),
 		WindowStart: parseOptionalTime(msg.WindowStart),
 		WindowEnd:   parseOptionalTime(msg.WindowEnd),
+
+		PrecisionPolicy:           msg.GetPrecisionPolicy(),
+		UndeclaredParameterPolicy: msg.GetUndeclaredParameterPolicy(),
+	}
+	if msg.MinSamplingIntervalSeconds != nil {
+		v := int(msg.GetMinSamplingIntervalSeconds())
+		input.MinSamplingIntervalSecs = &v
 	}
 
 	for _, p := range msg.GetParameters() {
@@ -60,6 +72,7 @@ func (h *CampaignServiceHandler) CreateCampaign(
 			Unit:     p.GetUnit(),
 			MinRange: p.MinRange,
 			MaxRange: p.MaxRange,
+			Required: p.GetRequired(),
 		}
 		if p.Precision != nil {
 			v := int(p.GetPrecision())
@@ -80,6 +93,10 @@ func (h *CampaignServiceHandler) CreateCampaign(
 	}
 
 	result, err := h.createCampaign.Run(ctx, input)
+	if errors.Is(err, campaignflows.ErrInvalidParameterPolicy) || errors.Is(err, campaignflows.ErrInvalidSamplingInterval) ||
+		errors.Is(err, campaignflows.ErrInvalidFirmwareRequirement) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
 	if err != nil {
 		slog.ErrorContext(ctx, "create campaign failed", "error", err)
 		return nil, connect.NewError(connect.CodeInternal, err)
@@ -94,9 +111,14 @@ func (h *CampaignServiceHandler) PublishCampaign(
 	ctx context.Context,
 	req *connect.Request[rootstockv1.PublishCampaignRequest],
 ) (*connect.Response[rootstockv1.PublishCampaignResponse], error) {
-	if err := h.publishCampaign.Run(ctx, req.Msg.GetCampaignId()); err != nil {
+	campaignID := req.Msg.GetCampaignId()
+	if err := h.publishCampaign.Run(ctx, campaignID); err != nil {
 		return nil, err
 	}
+	// Devices already enrolled pick up the campaign now that it is live (best-effort)
+	if _, err := h.deviceConfig.RunPushCampaign(ctx, campaignID); err != nil {
+		slog.WarnContext(ctx, "push campaign device config failed", "campaign_id", campaignID, "error", err)
+	}
 	return connect.NewResponse(&rootstockv1.PublishCampaignResponse{}), nil
 }
 
@@ -154,14 +176,27 @@ func (h *CampaignServiceHandler) GetCampaignDashboard(
 
 	for _, db := range dashboard.DeviceBreakdown {
 		entry := &rootstockv1.DeviceBreakdownProto{
-			PseudoDeviceId: db.PseudoDeviceID,
-			DeviceClass:    db.DeviceClass,
-			AcceptanceRate: db.AcceptanceRate,
-			ReadingCount:   int32(db.ReadingCount),
+			PseudoDeviceId:  db.PseudoDeviceID,
+			DeviceClass:     db.DeviceClass,
+			AcceptanceRate:  db.AcceptanceRate,
+			ReadingCount:    int32(db.ReadingCount),
+			Online:          db.Online,
+			LastConnectedAt: db.LastConnectedAt,
+			BatteryPercent:  db.BatteryPercent,
+			LastHeartbeatAt: db.LastHeartbeatAt,
+			HealthAlerts:    db.HealthAlerts,
+
+			ConfigVersion:      db.ConfigVersion,
+			ConfigAckedVersion: db.ConfigAckedVersion,
+			ConfigStale:        configStale(db.ConfigVersion, db.ConfigAckedVersion),
 		}
 		if db.LastSeen != nil {
 			entry.LastSeen = *db.LastSeen
 		}
+		if db.RSSIDbm != nil {
+			rssi := int32(*db.RSSIDbm)
+			entry.RssiDbm = &rssi
+		}
 		resp.DeviceBreakdown = append(resp.DeviceBreakdown, entry)
 	}
 
@@ -211,6 +246,16 @@ func (h *CampaignServiceHandler) ExportCampaignData(
 		if r.Geolocation != nil {
 			readings[i].Geolocation = r.Geolocation
 		}
+		for name, c := range r.Conversions {
+			if readings[i].Conversions == nil {
+				readings[i].Conversions = make(map[string]*rootstockv1.ValueConversionProto, len(r.Conversions))
+			}
+			readings[i].Conversions[name] = &rootstockv1.ValueConversionProto{
+				OriginalValue: c.OriginalValue,
+				OriginalUnit:  c.OriginalUnit,
+				Unit:          c.Unit,
+			}
+		}
 	}
 
 	return connect.NewResponse(&rootstockv1.ExportCampaignDataResponse{
@@ -237,6 +282,11 @@ func campaignToProto(c *campaignflows.Campaign) *rootstockv1.CampaignProto {
 	return proto
 }
 
+// configStale reports whether a device has been pushed a config version it has not acknowledged.
+func configStale(version, acked *int64) bool {
+	return version != nil && (acked == nil || *acked < *version)
+}
+
 func parseOptionalTime(s *string) *time.Time {
 	if s == nil {
 		return nil
@@ -2,11 +2,15 @@ package connect
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	"connectrpc.com/connect"
 
+	"rootstock/web-server/auth"
 	deviceflows "rootstock/web-server/flows/device"
+	userflows "rootstock/web-server/flows/user"
 	rootstockv1 "rootstock/web-server/proto/rootstock/v1"
 )
 
@@ -16,6 +20,9 @@ type DeviceServiceHandler struct {
 	revokeDevice      *deviceflows.RevokeDeviceFlow
 	reinstateDevice   *deviceflows.ReinstateDeviceFlow
 	enrollInCampaign  *deviceflows.EnrollInCampaignFlow
+	deviceCommand     *deviceflows.DeviceCommandFlow
+	deviceShadow      *deviceflows.DeviceShadowFlow
+	getUser           *userflows.GetUserFlow
 }
 
 // NewDeviceServiceHandler creates the handler with all required flows.
@@ -24,15 +31,37 @@ func NewDeviceServiceHandler(
 	revokeDevice *deviceflows.RevokeDeviceFlow,
 	reinstateDevice *deviceflows.ReinstateDeviceFlow,
 	enrollInCampaign *deviceflows.EnrollInCampaignFlow,
+	deviceCommand *deviceflows.DeviceCommandFlow,
+	deviceShadow *deviceflows.DeviceShadowFlow,
+	getUser *userflows.GetUserFlow,
 ) *DeviceServiceHandler {
 	return &DeviceServiceHandler{
 		getDevice:        getDevice,
 		revokeDevice:     revokeDevice,
 		reinstateDevice:  reinstateDevice,
 		enrollInCampaign: enrollInCampaign,
+		deviceCommand:    deviceCommand,
+		deviceShadow:     deviceShadow,
+		getUser:          getUser,
 	}
 }
 
+// resolveUserID extracts the IdP user ID from context and resolves the app user ID.
+func (h *DeviceServiceHandler) resolveUserID(ctx context.Context) (string, error) {
+	idpID, ok := auth.SubjectFromContext(ctx)
+	if !ok || idpID == "" {
+		return "", connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("no authenticated subject"))
+	}
+	user, err := h.getUser.Run(ctx, idpID)
+	if err != nil {
+		return "", connect.NewError(connect.CodeInternal, fmt.Errorf("resolve user: %w", err))
+	}
+	if user == nil {
+		return "", connect.NewError(connect.CodeNotFound, fmt.Errorf("user not found"))
+	}
+	return user.ID, nil
+}
+
 func (h *DeviceServiceHandler) GetDevice(
 	ctx context.Context,
 	req *connect.Request[rootstockv1.GetDeviceRequest],
@@ -90,6 +119,203 @@ func (h *DeviceServiceHandler) EnrollInCampaign(
 	}), nil
 }
 
+func (h *DeviceServiceHandler) SendDeviceCommand(
+	ctx context.Context,
+	req *connect.Request[rootstockv1.SendDeviceCommandRequest],
+) (*connect.Response[rootstockv1.SendDeviceCommandResponse], error) {
+	userID, err := h.resolveUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	input := deviceflows.SendCommandInput{
+		IssuerID:   userID,
+		DeviceID:   req.Msg.GetDeviceId(),
+		CampaignID: req.Msg.GetCampaignId(),
+		Command:    req.Msg.GetCommand(),
+		Sensors:    req.Msg.GetSensors(),
+		TTLSeconds: int(req.Msg.GetTtlSeconds()),
+	}
+	if req.Msg.IntervalSeconds != nil {
+		interval := int(req.Msg.GetIntervalSeconds())
+		input.IntervalSeconds = &interval
+	}
+	batch, err := h.deviceCommand.RunSend(ctx, input)
+	if err != nil {
+		return nil, deviceCommandError(err)
+	}
+
+	commands := make([]*rootstockv1.DeviceCommandProto, len(batch.Commands))
+	for i := range batch.Commands {
+		commands[i] = deviceCommandToProto(&batch.Commands[i])
+	}
+	return connect.NewResponse(&rootstockv1.SendDeviceCommandResponse{
+		BatchId:   batch.BatchID,
+		Commands:  commands,
+		Published: int32(batch.Published),
+	}), nil
+}
+
+func (h *DeviceServiceHandler) ListDeviceCommands(
+	ctx context.Context,
+	req *connect.Request[rootstockv1.ListDeviceCommandsRequest],
+) (*connect.Response[rootstockv1.ListDeviceCommandsResponse], error) {
+	userID, err := h.resolveUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := h.deviceCommand.RunList(ctx, deviceflows.ListCommandsInput{
+		IssuerID:   userID,
+		DeviceID:   req.Msg.GetDeviceId(),
+		CampaignID: req.Msg.GetCampaignId(),
+		Limit:      int(req.Msg.GetLimit()),
+	})
+	if err != nil {
+		return nil, deviceCommandError(err)
+	}
+
+	commands := make([]*rootstockv1.DeviceCommandProto, len(results))
+	for i := range results {
+		commands[i] = deviceCommandToProto(&results[i])
+	}
+	return connect.NewResponse(&rootstockv1.ListDeviceCommandsResponse{
+		Commands: commands,
+	}), nil
+}
+
+func (h *DeviceServiceHandler) GetDeviceShadow(
+	ctx context.Context,
+	req *connect.Request[rootstockv1.GetDeviceShadowRequest],
+) (*connect.Response[rootstockv1.GetDeviceShadowResponse], error) {
+	userID, err := h.resolveUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	shadow, err := h.deviceShadow.RunView(ctx, userID, req.Msg.GetDeviceId())
+	if err != nil {
+		return nil, deviceShadowError(err)
+	}
+	return connect.NewResponse(&rootstockv1.GetDeviceShadowResponse{
+		Shadow: deviceShadowToProto(shadow),
+	}), nil
+}
+
+func (h *DeviceServiceHandler) UpdateDeviceShadow(
+	ctx context.Context,
+	req *connect.Request[rootstockv1.UpdateDeviceShadowRequest],
+) (*connect.Response[rootstockv1.UpdateDeviceShadowResponse], error) {
+	userID, err := h.resolveUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	input := deviceflows.SetShadowDesiredInput{
+		IssuerID: userID,
+		DeviceID: req.Msg.GetDeviceId(),
+	}
+	if req.Msg.SamplingIntervalSeconds != nil {
+		v := int(req.Msg.GetSamplingIntervalSeconds())
+		input.SamplingIntervalSeconds = &v
+	}
+	if req.Msg.EnabledSensors != nil {
+		input.EnabledSensors = append([]string{}, req.Msg.GetEnabledSensors().GetValues()...)
+	}
+	shadow, err := h.deviceShadow.RunSetDesired(ctx, input)
+	if err != nil {
+		return nil, deviceShadowError(err)
+	}
+	return connect.NewResponse(&rootstockv1.UpdateDeviceShadowResponse{
+		Shadow: deviceShadowToProto(shadow),
+	}), nil
+}
+
+// deviceCommandError maps command validation and access errors to Connect codes.
+func deviceCommandError(err error) error {
+	switch {
+	case errors.Is(err, deviceflows.ErrCommandNotPermitted):
+		return connect.NewError(connect.CodePermissionDenied, err)
+	case errors.Is(err, deviceflows.ErrInvalidCommand), errors.Is(err, deviceflows.ErrCommandTarget):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	default:
+		return connect.NewError(connect.CodeInternal, err)
+	}
+}
+
+// deviceShadowError maps shadow validation and access errors to Connect codes. Shadow
+// access follows command access.
+func deviceShadowError(err error) error {
+	switch {
+	case errors.Is(err, deviceflows.ErrCommandNotPermitted):
+		return connect.NewError(connect.CodePermissionDenied, err)
+	case errors.Is(err, deviceflows.ErrInvalidShadow):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	default:
+		return connect.NewError(connect.CodeInternal, err)
+	}
+}
+
+func deviceCommandToProto(c *deviceflows.DeviceCommand) *rootstockv1.DeviceCommandProto {
+	proto := &rootstockv1.DeviceCommandProto{
+		Id:         c.ID,
+		DeviceId:   c.DeviceID,
+		CampaignId: c.CampaignID,
+		BatchId:    c.BatchID,
+		Command:    c.Command,
+		Params:     c.Params,
+		IssuedBy:   c.IssuedBy,
+		IssuedAt:   c.IssuedAt.Format(time.RFC3339),
+		ExpiresAt:  c.ExpiresAt.Format(time.RFC3339),
+		Status:     c.Status,
+		Result:     c.Result,
+		Error:      c.Error,
+	}
+	if c.AckedAt != nil {
+		s := c.AckedAt.Format(time.RFC3339)
+		proto.AckedAt = &s
+	}
+	if c.CompletedAt != nil {
+		s := c.CompletedAt.Format(time.RFC3339)
+		proto.CompletedAt = &s
+	}
+	return proto
+}
+
+func deviceShadowToProto(s *deviceflows.DeviceShadow) *rootstockv1.DeviceShadowProto {
+	proto := &rootstockv1.DeviceShadowProto{
+		DeviceId: s.DeviceID,
+		Version:  s.Version,
+		Desired:  shadowStateToProto(s.Desired),
+		Reported: shadowStateToProto(s.Reported),
+		Delta:    shadowStateToProto(s.Delta),
+	}
+	if s.DesiredUpdatedAt != nil {
+		t := s.DesiredUpdatedAt.Format(time.RFC3339)
+		proto.DesiredUpdatedAt = &t
+	}
+	if s.ReportedUpdatedAt != nil {
+		t := s.ReportedUpdatedAt.Format(time.RFC3339)
+		proto.ReportedUpdatedAt = &t
+	}
+	return proto
+}
+
+func shadowStateToProto(s deviceflows.ShadowState) *rootstockv1.ShadowStateProto {
+	proto := &rootstockv1.ShadowStateProto{FirmwareVersion: s.FirmwareVersion}
+	if s.SamplingIntervalSeconds != nil {
+		v := int32(*s.SamplingIntervalSeconds)
+		proto.SamplingIntervalSeconds = &v
+	}
+	if s.EnabledSensors != nil {
+		proto.EnabledSensors = &rootstockv1.StringListProto{Values: s.EnabledSensors}
+	}
+	if s.ActiveCampaigns != nil {
+		proto.ActiveCampaigns = &rootstockv1.StringListProto{Values: s.ActiveCampaigns}
+	}
+	return proto
+}
+
 func deviceToProto(d *deviceflows.Device) *rootstockv1.DeviceProto {
 	proto := &rootstockv1.DeviceProto{
 		Id:              d.ID,
@@ -101,6 +327,8 @@ func deviceToProto(d *deviceflows.Device) *rootstockv1.DeviceProto {
 		Sensors:         d.Sensors,
 		CertSerial:      d.CertSerial,
 		CreatedAt:       d.CreatedAt.Format(time.RFC3339),
+		Gateway:         d.Gateway,
+		GatewayId:       d.GatewayID,
 	}
 	return proto
 }
@@ -2,12 +2,15 @@ package connect
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
 	"time"
 
 	"connectrpc.com/connect"
 
 	"rootstock/web-server/auth"
+	deviceflows "rootstock/web-server/flows/device"
 	scitizenflows "rootstock/web-server/flows/scitizen"
 	scoreflows "rootstock/web-server/flows/score"
 	userflows "rootstock/web-server/flows/user"
@@ -30,6 +33,9 @@ type ScitizenServiceHandler struct {
 	notifications      *scitizenflows.NotificationFlow
 	campaignProgress   *scitizenflows.CampaignProgressFlow
 	getLeaderboard     *scoreflows.GetLeaderboardFlow
+	deviceRegistration *scitizenflows.DeviceRegistrationFlow
+	stationLink        *scitizenflows.StationLinkFlow
+	deviceConfig       *deviceflows.DeviceConfigFlow
 }
 
 // NewScitizenServiceHandler creates the handler with all required flows.
@@ -47,6 +53,9 @@ func NewScitizenServiceHandler(
 	notifications *scitizenflows.NotificationFlow,
 	campaignProgress *scitizenflows.CampaignProgressFlow,
 	getLeaderboard *scoreflows.GetLeaderboardFlow,
+	deviceRegistration *scitizenflows.DeviceRegistrationFlow,
+	stationLink *scitizenflows.StationLinkFlow,
+	deviceConfig *deviceflows.DeviceConfigFlow,
 ) *ScitizenServiceHandler {
 	return &ScitizenServiceHandler{
 		getUser:            getUser,
@@ -62,6 +71,9 @@ func NewScitizenServiceHandler(
 		notifications:      notifications,
 		campaignProgress:   campaignProgress,
 		getLeaderboard:     getLeaderboard,
+		deviceRegistration: deviceRegistration,
+		stationLink:        stationLink,
+		deviceConfig:       deviceConfig,
 	}
 }
 
@@ -277,20 +289,25 @@ func (h *ScitizenServiceHandler) EnrollDevice(
 	consent := msg.GetConsent()
 
 	result, err := h.enrollDevice.Run(ctx, scitizenflows.EnrollDeviceInput{
-		ScitizenID:     userID,
-		DeviceID:       msg.GetDeviceId(),
-		CampaignID:     msg.GetCampaignId(),
-		ConsentVersion: consent.GetVersion(),
-		ConsentScope:   consent.GetScope(),
+		ScitizenID:         userID,
+		DeviceID:           msg.GetDeviceId(),
+		CampaignID:         msg.GetCampaignId(),
+		ConsentVersion:     consent.GetVersion(),
+		ConsentScope:       consent.GetScope(),
+		DeploymentLocation: msg.GetDeploymentLocation(),
 	})
 	if err != nil {
 		return nil, err
 	}
+	if result.Enrolled {
+		h.pushDeviceConfig(ctx, msg.GetDeviceId())
+	}
 
 	return connect.NewResponse(&rootstockv1.EnrollDeviceResponse{
-		Enrolled:     result.Enrolled,
-		Reason:       result.Reason,
-		EnrollmentId: result.EnrollmentID,
+		Enrolled:        result.Enrolled,
+		Reason:          result.Reason,
+		EnrollmentId:    result.EnrollmentID,
+		LocationFlagged: result.LocationFlagged,
 	}), nil
 }
 
@@ -298,12 +315,22 @@ func (h *ScitizenServiceHandler) WithdrawEnrollment(
 	ctx context.Context,
 	req *connect.Request[rootstockv1.WithdrawEnrollmentRequest],
 ) (*connect.Response[rootstockv1.WithdrawEnrollmentResponse], error) {
-	if err := h.withdrawEnrollment.Run(ctx, req.Msg.GetEnrollmentId()); err != nil {
+	enrollment, err := h.withdrawEnrollment.Run(ctx, req.Msg.GetEnrollmentId())
+	if err != nil {
 		return nil, err
 	}
+	h.pushDeviceConfig(ctx, enrollment.DeviceID)
 	return connect.NewResponse(&rootstockv1.WithdrawEnrollmentResponse{}), nil
 }
 
+// pushDeviceConfig sends the device its updated campaign config. Best-effort: the device
+// is brought up to date when it next subscribes to its config topic.
+func (h *ScitizenServiceHandler) pushDeviceConfig(ctx context.Context, deviceID string) {
+	if _, err := h.deviceConfig.RunPush(ctx, deviceID); err != nil {
+		slog.WarnContext(ctx, "push device config failed", "device_id", deviceID, "error", err)
+	}
+}
+
 func (h *ScitizenServiceHandler) GetDevices(
 	ctx context.Context,
 	req *connect.Request[rootstockv1.GetDevicesRequest],
@@ -328,6 +355,7 @@ func (h *ScitizenServiceHandler) GetDevices(
 			Tier:              int32(d.Tier),
 			Sensors:           d.Sensors,
 			ActiveEnrollments: int32(d.ActiveEnrollments),
+			Health:            deviceHealthToProto(d.Health),
 		}
 		if d.LastSeen != nil {
 			s := d.LastSeen.Format(time.RFC3339)
@@ -369,6 +397,23 @@ func (h *ScitizenServiceHandler) GetDeviceDetail(
 		}
 	}
 
+	sessions := make([]*rootstockv1.DeviceSessionProto, len(result.Sessions))
+	for i, s := range result.Sessions {
+		sessions[i] = &rootstockv1.DeviceSessionProto{
+			ConnectedAt:      s.ConnectedAt.Format(time.RFC3339),
+			DurationSeconds:  s.DurationSeconds,
+			RemoteAddr:       s.RemoteAddr,
+			CertSerial:       s.CertSerial,
+			DisconnectReason: s.DisconnectReason,
+			Graceful:         s.Graceful,
+			WillPublished:    s.WillPublished,
+		}
+		if s.DisconnectedAt != nil {
+			t := s.DisconnectedAt.Format(time.RFC3339)
+			sessions[i].DisconnectedAt = &t
+		}
+	}
+
 	device := &rootstockv1.DeviceProto{
 		Id:              result.ID,
 		OwnerId:         result.OwnerID,
@@ -385,6 +430,15 @@ func (h *ScitizenServiceHandler) GetDeviceDetail(
 		Device:            device,
 		Enrollments:       enrollments,
 		ConnectionHistory: connHistory,
+		ClockSkewMs:       result.ClockSkewMs,
+		ClockSkewFlagged:  result.ClockSkewFlaggedAt != nil,
+		Online:            result.Online,
+		Sessions:          sessions,
+		Health:            deviceHealthToProto(result.Health),
+
+		ConfigVersion:      result.ConfigVersion,
+		ConfigAckedVersion: result.ConfigAckedVersion,
+		ConfigStale:        configStale(result.ConfigVersion, result.ConfigAckedVersion),
 	}), nil
 }
 
@@ -566,3 +620,226 @@ func campaignSummaryToProto(c *scitizenflows.CampaignSummary) *rootstockv1.Campa
 	}
 	return p
 }
+
+func (h *ScitizenServiceHandler) RegisterDevice(
+	ctx context.Context,
+	req *connect.Request[rootstockv1.RegisterDeviceRequest],
+) (*connect.Response[rootstockv1.RegisterDeviceResponse], error) {
+	userID, err := h.resolveUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := req.Msg
+	result, err := h.deviceRegistration.RunRegister(ctx, scitizenflows.RegisterDeviceInput{
+		OwnerID:         userID,
+		Class:           msg.GetClass(),
+		FirmwareVersion: msg.GetFirmwareVersion(),
+		Tier:            int(msg.GetTier()),
+		Sensors:         msg.GetSensors(),
+		SensorUnits:     msg.GetSensorUnits(),
+		Gateway:         msg.GetGateway(),
+	})
+	if err != nil {
+		return nil, deviceRegistrationError(err)
+	}
+
+	return connect.NewResponse(&rootstockv1.RegisterDeviceResponse{
+		DeviceId: result.DeviceID,
+		Status:   result.Status,
+		EnrollmentCode: &rootstockv1.EnrollmentCodeProto{
+			DeviceId:  result.DeviceID,
+			Code:      result.Code,
+			ExpiresAt: result.ExpiresAt.Format(time.RFC3339),
+		},
+	}), nil
+}
+
+func (h *ScitizenServiceHandler) ListEnrollmentCodes(
+	ctx context.Context,
+	req *connect.Request[rootstockv1.ListEnrollmentCodesRequest],
+) (*connect.Response[rootstockv1.ListEnrollmentCodesResponse], error) {
+	userID, err := h.resolveUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	codes, err := h.deviceRegistration.RunListCodes(ctx, userID)
+	if err != nil {
+		return nil, deviceRegistrationError(err)
+	}
+
+	protos := make([]*rootstockv1.EnrollmentCodeProto, len(codes))
+	for i, c := range codes {
+		protos[i] = enrollmentCodeToProto(&c)
+	}
+
+	return connect.NewResponse(&rootstockv1.ListEnrollmentCodesResponse{
+		Codes: protos,
+	}), nil
+}
+
+func (h *ScitizenServiceHandler) RegenerateEnrollmentCode(
+	ctx context.Context,
+	req *connect.Request[rootstockv1.RegenerateEnrollmentCodeRequest],
+) (*connect.Response[rootstockv1.RegenerateEnrollmentCodeResponse], error) {
+	userID, err := h.resolveUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := h.deviceRegistration.RunRegenerateCode(ctx, scitizenflows.EnrollmentCodeInput{
+		OwnerID:  userID,
+		DeviceID: req.Msg.GetDeviceId(),
+	})
+	if err != nil {
+		return nil, deviceRegistrationError(err)
+	}
+
+	return connect.NewResponse(&rootstockv1.RegenerateEnrollmentCodeResponse{
+		EnrollmentCode: enrollmentCodeToProto(code),
+	}), nil
+}
+
+func (h *ScitizenServiceHandler) ExpireEnrollmentCode(
+	ctx context.Context,
+	req *connect.Request[rootstockv1.ExpireEnrollmentCodeRequest],
+) (*connect.Response[rootstockv1.ExpireEnrollmentCodeResponse], error) {
+	userID, err := h.resolveUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	expired, err := h.deviceRegistration.RunExpireCode(ctx, scitizenflows.EnrollmentCodeInput{
+		OwnerID:  userID,
+		DeviceID: req.Msg.GetDeviceId(),
+	})
+	if err != nil {
+		return nil, deviceRegistrationError(err)
+	}
+
+	return connect.NewResponse(&rootstockv1.ExpireEnrollmentCodeResponse{
+		Expired: int32(expired),
+	}), nil
+}
+
+func (h *ScitizenServiceHandler) SetDeviceSensorUnits(
+	ctx context.Context,
+	req *connect.Request[rootstockv1.SetDeviceSensorUnitsRequest],
+) (*connect.Response[rootstockv1.SetDeviceSensorUnitsResponse], error) {
+	userID, err := h.resolveUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	units, err := h.deviceRegistration.RunSetSensorUnits(ctx, scitizenflows.SetSensorUnitsInput{
+		OwnerID:  userID,
+		DeviceID: req.Msg.GetDeviceId(),
+		Units:    req.Msg.GetSensorUnits(),
+	})
+	if err != nil {
+		return nil, deviceRegistrationError(err)
+	}
+
+	return connect.NewResponse(&rootstockv1.SetDeviceSensorUnitsResponse{
+		EffectiveUnits: units,
+	}), nil
+}
+
+func (h *ScitizenServiceHandler) LinkStation(
+	ctx context.Context,
+	req *connect.Request[rootstockv1.LinkStationRequest],
+) (*connect.Response[rootstockv1.LinkStationResponse], error) {
+	userID, err := h.resolveUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	station, err := h.stationLink.RunLink(ctx, scitizenflows.LinkStationInput{
+		OwnerID:      userID,
+		DeviceID:     req.Msg.GetDeviceId(),
+		Protocol:     req.Msg.GetProtocol(),
+		ParameterMap: req.Msg.GetParameterMap(),
+	})
+	if err != nil {
+		return nil, deviceRegistrationError(err)
+	}
+
+	return connect.NewResponse(&rootstockv1.LinkStationResponse{
+		DeviceId:  station.DeviceID,
+		Protocol:  station.Protocol,
+		UploadUrl: station.UploadURL,
+		CreatedAt: station.CreatedAt.Format(time.RFC3339),
+	}), nil
+}
+
+func (h *ScitizenServiceHandler) UnlinkStation(
+	ctx context.Context,
+	req *connect.Request[rootstockv1.UnlinkStationRequest],
+) (*connect.Response[rootstockv1.UnlinkStationResponse], error) {
+	userID, err := h.resolveUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	unlinked, err := h.stationLink.RunUnlink(ctx, scitizenflows.UnlinkStationInput{
+		OwnerID:  userID,
+		DeviceID: req.Msg.GetDeviceId(),
+	})
+	if err != nil {
+		return nil, deviceRegistrationError(err)
+	}
+
+	return connect.NewResponse(&rootstockv1.UnlinkStationResponse{
+		Unlinked: unlinked,
+	}), nil
+}
+
+func enrollmentCodeToProto(c *scitizenflows.PendingEnrollmentCode) *rootstockv1.EnrollmentCodeProto {
+	return &rootstockv1.EnrollmentCodeProto{
+		DeviceId:  c.DeviceID,
+		Code:      c.Code,
+		ExpiresAt: c.ExpiresAt.Format(time.RFC3339),
+	}
+}
+
+func deviceHealthToProto(h *scitizenflows.DeviceHealth) *rootstockv1.DeviceHealthProto {
+	if h == nil {
+		return nil
+	}
+	p := &rootstockv1.DeviceHealthProto{
+		ReportedAt:      h.ReportedAt.Format(time.RFC3339),
+		ReceivedAt:      h.ReceivedAt.Format(time.RFC3339),
+		BatteryPercent:  h.BatteryPercent,
+		UptimeSeconds:   h.UptimeSeconds,
+		FreeMemoryBytes: h.FreeMemoryBytes,
+		SensorFaults:    h.SensorFaults,
+		FirmwareBuild:   h.FirmwareBuild,
+		Alerts:          h.Alerts,
+	}
+	if h.RSSIDbm != nil {
+		rssi := int32(*h.RSSIDbm)
+		p.RssiDbm = &rssi
+	}
+	return p
+}
+
+// deviceRegistrationError maps device registration, ownership and state errors to Connect
+// codes; anything else is an internal error.
+func deviceRegistrationError(err error) error {
+	switch {
+	case errors.Is(err, scitizenflows.ErrDeviceNotFound):
+		return connect.NewError(connect.CodeNotFound, err)
+	case errors.Is(err, scitizenflows.ErrNotDeviceOwner):
+		return connect.NewError(connect.CodePermissionDenied, err)
+	case errors.Is(err, scitizenflows.ErrDeviceNotPending),
+		errors.Is(err, scitizenflows.ErrDeviceNotLinkable):
+		return connect.NewError(connect.CodeFailedPrecondition, err)
+	case errors.Is(err, scitizenflows.ErrInvalidSensorUnits),
+		errors.Is(err, scitizenflows.ErrInvalidStationLink),
+		errors.Is(err, scitizenflows.ErrInvalidDeviceRegistration):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	default:
+		return connect.NewError(connect.CodeInternal, err)
+	}
+}
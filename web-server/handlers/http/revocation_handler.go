@@ -0,0 +1,117 @@
+package http
+
+import (
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+
+	deviceflows "rootstock/web-server/flows/device"
+)
+
+// maxOCSPRequestBytes bounds POSTed OCSP requests; a single-certificate request is a few hundred bytes.
+const maxOCSPRequestBytes = 8 << 10
+
+// RevocationHandler serves the device CA's CRL and OCSP responder on the RPC port.
+// Public, like /ca — relays and gateways verifying device certs have no session.
+type RevocationHandler struct {
+	getCRL          *deviceflows.GetCRLFlow
+	checkCertStatus *deviceflows.CheckCertStatusFlow
+}
+
+// NewRevocationHandler creates the handler with required flows.
+func NewRevocationHandler(
+	getCRL *deviceflows.GetCRLFlow,
+	checkCertStatus *deviceflows.CheckCertStatusFlow,
+) *RevocationHandler {
+	return &RevocationHandler{
+		getCRL:          getCRL,
+		checkCertStatus: checkCertStatus,
+	}
+}
+
+// GetCRL handles GET /crl — returns the signed CRL as DER, or PEM with ?format=pem.
+func (h *RevocationHandler) GetCRL(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	crl, err := h.getCRL.Run(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Last-Modified", crl.ThisUpdate.UTC().Format(http.TimeFormat))
+	w.Header().Set("Expires", crl.NextUpdate.UTC().Format(http.TimeFormat))
+	if r.URL.Query().Get("format") == "pem" {
+		w.Header().Set("Content-Type", "application/x-pem-file")
+		w.Write(pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: crl.DER}))
+		return
+	}
+	w.Header().Set("Content-Type", "application/pkix-crl")
+	w.Write(crl.DER)
+}
+
+// OCSP handles POST /ocsp and GET /ocsp/{base64 request} (RFC 6960 appendix A).
+// Failures are answered with OCSP error responses, not HTTP errors.
+func (h *RevocationHandler) OCSP(w http.ResponseWriter, r *http.Request) {
+	var der []byte
+	switch r.Method {
+	case http.MethodPost:
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxOCSPRequestBytes))
+		if err != nil {
+			writeOCSP(w, ocsp.MalformedRequestErrorResponse, time.Time{})
+			return
+		}
+		der = body
+	case http.MethodGet:
+		decoded, err := decodeOCSPPath(strings.TrimPrefix(r.URL.Path, "/ocsp/"))
+		if err != nil {
+			writeOCSP(w, ocsp.MalformedRequestErrorResponse, time.Time{})
+			return
+		}
+		der = decoded
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	resp, err := h.checkCertStatus.Run(r.Context(), deviceflows.CheckCertStatusInput{Request: der})
+	if err != nil {
+		switch {
+		case errors.Is(err, deviceflows.ErrUnknownIssuer):
+			writeOCSP(w, ocsp.UnauthorizedErrorResponse, time.Time{})
+		case errors.Is(err, deviceflows.ErrMalformedOCSPRequest):
+			writeOCSP(w, ocsp.MalformedRequestErrorResponse, time.Time{})
+		default:
+			writeOCSP(w, ocsp.InternalErrorErrorResponse, time.Time{})
+		}
+		return
+	}
+	writeOCSP(w, resp.DER, resp.NextUpdate)
+}
+
+// decodeOCSPPath decodes the base64 request of a GET; clients may URL-escape it.
+func decodeOCSPPath(path string) ([]byte, error) {
+	if path == "" {
+		return nil, fmt.Errorf("empty ocsp request")
+	}
+	path = strings.NewReplacer("%2B", "+", "%2F", "/", "%3D", "=", "%2b", "+", "%2f", "/", "%3d", "=").Replace(path)
+	return base64.StdEncoding.DecodeString(path)
+}
+
+func writeOCSP(w http.ResponseWriter, der []byte, nextUpdate time.Time) {
+	w.Header().Set("Content-Type", "application/ocsp-response")
+	if !nextUpdate.IsZero() {
+		w.Header().Set("Expires", nextUpdate.UTC().Format(http.TimeFormat))
+	}
+	w.Write(der)
+}
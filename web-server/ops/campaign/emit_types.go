@@ -20,6 +20,10 @@ type CampaignRules struct {
 	Regions     []Region
 	WindowStart *time.Time
 	WindowEnd   *time.Time
+
+	PrecisionPolicy           string
+	UndeclaredParameterPolicy string
+	MinSamplingIntervalSecs   *int
 }
 
 type Parameter struct {
@@ -28,6 +32,7 @@ type Parameter struct {
 	MinRange  *float64
 	MaxRange  *float64
 	Precision *int
+	Required  bool
 }
 
 type Region struct {
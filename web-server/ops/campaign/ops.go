@@ -84,6 +84,7 @@ func toRepoCreateInput(in CreateCampaignInput) campaignrepo.CreateCampaignInput
 			MinRange:  p.MinRange,
 			MaxRange:  p.MaxRange,
 			Precision: p.Precision,
+			Required:  p.Required,
 		}
 	}
 	regions := make([]campaignrepo.RegionInput, len(in.Regions))
@@ -107,6 +108,10 @@ func toRepoCreateInput(in CreateCampaignInput) campaignrepo.CreateCampaignInput
 		Parameters:  params,
 		Regions:     regions,
 		Eligibility: elig,
+
+		PrecisionPolicy:           in.PrecisionPolicy,
+		UndeclaredParameterPolicy: in.UndeclaredParameterPolicy,
+		MinSamplingIntervalSecs:   in.MinSamplingIntervalSecs,
 	}
 }
 
@@ -141,6 +146,7 @@ func fromRepoRules(r *campaignrepo.CampaignRules) *CampaignRules {
 			MinRange:  p.MinRange,
 			MaxRange:  p.MaxRange,
 			Precision: p.Precision,
+			Required:  p.Required,
 		}
 	}
 	regions := make([]Region, len(r.Regions))
@@ -153,5 +159,9 @@ func fromRepoRules(r *campaignrepo.CampaignRules) *CampaignRules {
 		Regions:     regions,
 		WindowStart: r.WindowStart,
 		WindowEnd:   r.WindowEnd,
+
+		PrecisionPolicy:           r.PrecisionPolicy,
+		UndeclaredParameterPolicy: r.UndeclaredParameterPolicy,
+		MinSamplingIntervalSecs:   r.MinSamplingIntervalSecs,
 	}
 }
@@ -14,3 +14,22 @@ type IssuedCert struct {
 type CACert struct {
 	CertPEM []byte
 }
+
+// CRL is a DER-encoded CRL signed by the CA.
+type CRL struct {
+	DER        []byte
+	ThisUpdate time.Time
+	NextUpdate time.Time
+}
+
+// OCSPRequest is a parsed OCSP request for a certificate issued by this CA.
+type OCSPRequest struct {
+	Serial      string // hex-encoded
+	KnownIssuer bool   // false when the request names another CA as issuer
+}
+
+// OCSPResponse is a DER-encoded OCSP response signed by the CA.
+type OCSPResponse struct {
+	DER        []byte
+	NextUpdate time.Time
+}
@@ -34,10 +34,47 @@ func (o *Ops) GetCACert(ctx context.Context) (*CACert, error) {
 	return &CACert{CertPEM: result.CertPEM}, nil
 }
 
+// SignCRL signs a CRL with the CA key.
+func (o *Ops) SignCRL(ctx context.Context, input SignCRLInput) (*CRL, error) {
+	entries := make([]certrepo.CRLEntry, len(input.Entries))
+	for i, e := range input.Entries {
+		entries[i] = certrepo.CRLEntry{Serial: e.Serial, RevokedAt: e.RevokedAt, ReasonCode: e.ReasonCode}
+	}
+	result, err := o.repo.SignCRL(ctx, certrepo.SignCRLInput{Number: input.Number, Entries: entries})
+	if err != nil {
+		return nil, err
+	}
+	return &CRL{DER: result.DER, ThisUpdate: result.ThisUpdate, NextUpdate: result.NextUpdate}, nil
+}
+
+// ParseOCSPRequest parses a DER OCSP request addressed to this CA.
+func (o *Ops) ParseOCSPRequest(ctx context.Context, der []byte) (*OCSPRequest, error) {
+	result, err := o.repo.ParseOCSPRequest(ctx, der)
+	if err != nil {
+		return nil, err
+	}
+	return &OCSPRequest{Serial: result.Serial, KnownIssuer: result.KnownIssuer}, nil
+}
+
+// SignOCSPResponse signs an OCSP response for one certificate with the CA key.
+func (o *Ops) SignOCSPResponse(ctx context.Context, input OCSPResponseInput) (*OCSPResponse, error) {
+	result, err := o.repo.SignOCSPResponse(ctx, certrepo.OCSPResponseInput{
+		Serial:     input.Serial,
+		Status:     input.Status,
+		RevokedAt:  input.RevokedAt,
+		ReasonCode: input.ReasonCode,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &OCSPResponse{DER: result.DER, NextUpdate: result.NextUpdate}, nil
+}
+
 func toRepoIssueCertInput(in IssueCertInput) certrepo.IssueCertInput {
 	return certrepo.IssueCertInput{
 		DeviceID: in.DeviceID,
 		CSR:      in.CSR,
+		Kind:     in.Kind,
 	}
 }
 
@@ -1,7 +1,31 @@
 package cert
 
+import "time"
+
 // IssueCertInput is what callers send to IssueCert.
 type IssueCertInput struct {
 	DeviceID string
 	CSR      []byte // DER-encoded PKCS#10
+	Kind     string // pure.CertKindGateway, or empty for a device
+}
+
+// CRLEntry is one revoked certificate to list in a CRL.
+type CRLEntry struct {
+	Serial     string // hex-encoded
+	RevokedAt  time.Time
+	ReasonCode int // RFC 5280 CRLReason
+}
+
+// SignCRLInput is what callers send to SignCRL.
+type SignCRLInput struct {
+	Number  int64
+	Entries []CRLEntry
+}
+
+// OCSPResponseInput is what callers send to SignOCSPResponse.
+type OCSPResponseInput struct {
+	Serial     string // hex-encoded
+	Status     string // pure.CertStatusGood, CertStatusRevoked or CertStatusUnknown
+	RevokedAt  time.Time
+	ReasonCode int
 }
@@ -0,0 +1,18 @@
+package deadletter
+
+import "time"
+
+// DeadLetter is a telemetry message that could not be ingested.
+type DeadLetter struct {
+	ID             string
+	Topic          string
+	DeviceID       string
+	CampaignID     *string
+	Payload        []byte
+	ErrorClass     string
+	ErrorMessage   string
+	ReceivedAt     time.Time
+	Status         string
+	ReplayCount    int
+	LastReplayedAt *time.Time
+}
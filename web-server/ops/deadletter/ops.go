@@ -0,0 +1,95 @@
+package deadletter
+
+import (
+	"context"
+
+	dlrepo "rootstock/web-server/repo/deadletter"
+)
+
+// Ops holds dead-letter operations. Each method is one op.
+type Ops struct {
+	repo dlrepo.Repository
+}
+
+// NewOps creates dead-letter ops backed by the given repository.
+func NewOps(repo dlrepo.Repository) *Ops {
+	return &Ops{repo: repo}
+}
+
+// RecordDeadLetter stores a telemetry message that failed ingestion.
+func (o *Ops) RecordDeadLetter(ctx context.Context, input RecordDeadLetterInput) (*DeadLetter, error) {
+	result, err := o.repo.Record(ctx, dlrepo.RecordInput{
+		Topic:        input.Topic,
+		DeviceID:     input.DeviceID,
+		CampaignID:   input.CampaignID,
+		Payload:      input.Payload,
+		ErrorClass:   input.ErrorClass,
+		ErrorMessage: input.ErrorMessage,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return fromRepoDeadLetter(result), nil
+}
+
+// ListDeadLetters returns dead letters, newest first.
+func (o *Ops) ListDeadLetters(ctx context.Context, input ListDeadLettersInput) ([]DeadLetter, error) {
+	results, err := o.repo.List(ctx, dlrepo.ListInput{
+		ErrorClass: input.ErrorClass,
+		DeviceID:   input.DeviceID,
+		Status:     input.Status,
+		Limit:      input.Limit,
+		Offset:     input.Offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]DeadLetter, len(results))
+	for i := range results {
+		out[i] = *fromRepoDeadLetter(&results[i])
+	}
+	return out, nil
+}
+
+// GetDeadLetter returns a single dead letter including its raw payload.
+func (o *Ops) GetDeadLetter(ctx context.Context, id string) (*DeadLetter, error) {
+	result, err := o.repo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return fromRepoDeadLetter(result), nil
+}
+
+// MarkDeadLetterReplayed records that a dead letter was resubmitted for ingestion.
+func (o *Ops) MarkDeadLetterReplayed(ctx context.Context, id string) (*DeadLetter, error) {
+	result, err := o.repo.MarkReplayed(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return fromRepoDeadLetter(result), nil
+}
+
+// PurgeDeadLetters deletes matching dead letters and returns how many were removed.
+func (o *Ops) PurgeDeadLetters(ctx context.Context, input PurgeDeadLettersInput) (int64, error) {
+	return o.repo.Purge(ctx, dlrepo.PurgeInput{
+		IDs:            input.IDs,
+		ErrorClass:     input.ErrorClass,
+		ReceivedBefore: input.ReceivedBefore,
+	})
+}
+
+func fromRepoDeadLetter(d *dlrepo.DeadLetter) *DeadLetter {
+	return &DeadLetter{
+		ID:             d.ID,
+		Topic:          d.Topic,
+		DeviceID:       d.DeviceID,
+		CampaignID:     d.CampaignID,
+		Payload:        d.Payload,
+		ErrorClass:     d.ErrorClass,
+		ErrorMessage:   d.ErrorMessage,
+		ReceivedAt:     d.ReceivedAt,
+		Status:         d.Status,
+		ReplayCount:    d.ReplayCount,
+		LastReplayedAt: d.LastReplayedAt,
+	}
+}
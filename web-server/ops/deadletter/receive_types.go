@@ -0,0 +1,29 @@
+package deadletter
+
+import "time"
+
+// RecordDeadLetterInput is what callers send to RecordDeadLetter.
+type RecordDeadLetterInput struct {
+	Topic        string
+	DeviceID     string
+	CampaignID   string
+	Payload      []byte
+	ErrorClass   string
+	ErrorMessage string
+}
+
+// ListDeadLettersInput filters ListDeadLetters. Empty fields match everything.
+type ListDeadLettersInput struct {
+	ErrorClass string
+	DeviceID   string
+	Status     string
+	Limit      int
+	Offset     int
+}
+
+// PurgeDeadLettersInput selects dead letters to delete. Filters are ANDed.
+type PurgeDeadLettersInput struct {
+	IDs            []string
+	ErrorClass     string
+	ReceivedBefore *time.Time
+}
@@ -11,7 +11,10 @@ type Device struct {
 	FirmwareVersion string
 	Tier            int
 	Sensors         []string
+	SensorUnits     map[string]string // sensor -> UCUM unit, as declared for this device
 	CertSerial      *string
+	Gateway         bool
+	GatewayID       *string
 	CreatedAt       time.Time
 }
 
@@ -30,3 +33,174 @@ type EnrollmentCode struct {
 	ExpiresAt time.Time
 	Used      bool
 }
+
+// ClockSkew is a device's smoothed clock skew estimate.
+type ClockSkew struct {
+	DeviceID   string
+	OwnerID    string
+	EstimateMs int64 // device clock minus server clock
+	Samples    int
+	UpdatedAt  time.Time
+	FlaggedAt  *time.Time
+}
+
+// RateLimitFlag is a device flagged for repeatedly exceeding ingestion rate limits.
+type RateLimitFlag struct {
+	DeviceID        string
+	OwnerID         string
+	Class           string
+	FirmwareVersion string
+	Status          string
+	Violations      int64
+	FlaggedAt       time.Time
+}
+
+// IssuedCertRecord is a certificate in the issued-certificate ledger.
+type IssuedCertRecord struct {
+	Serial           string
+	DeviceID         string
+	NotBefore        *time.Time // nil for certificates issued before the ledger existed
+	NotAfter         *time.Time
+	IssuedAt         time.Time
+	RevokedAt        *time.Time
+	RevocationReason string // pure.RevocationSuperseded or pure.RevocationCertificateHold; empty while good
+}
+
+// RevokedCert is one entry of the certificate revocation list.
+type RevokedCert struct {
+	Serial    string
+	RevokedAt time.Time
+	Reason    string
+}
+
+// RevocationList is the set of revoked, unexpired certificates and the CRL number to sign it under.
+// Revision identifies the state of the revoked set the entries were read at.
+type RevocationList struct {
+	Number   int64
+	Revision int64
+	Entries  []RevokedCert
+}
+
+// HealthState is a device's owner and currently raised health alerts.
+type HealthState struct {
+	DeviceID     string
+	OwnerID      string
+	ActiveAlerts []string
+}
+
+// SilentDevice is an active device that has stopped sending heartbeats.
+type SilentDevice struct {
+	DeviceID      string
+	OwnerID       string
+	LastHeartbeat time.Time
+}
+
+// DeviceConfig is the campaign configuration last pushed to a device.
+type DeviceConfig struct {
+	DeviceID     string
+	Version      int64
+	Digest       string
+	CampaignIDs  []string
+	Campaigns    []byte // JSON
+	PushedAt     time.Time
+	AckedVersion *int64
+	AckedAt      *time.Time
+	Changed      bool // the save moved the version
+}
+
+// DeviceCommand is one command sent to a device and what the device reported back.
+type DeviceCommand struct {
+	ID          string
+	DeviceID    string
+	CampaignID  *string
+	BatchID     string
+	Command     string
+	Params      []byte // JSON
+	IssuedBy    string
+	IssuedAt    time.Time
+	ExpiresAt   time.Time
+	Status      string // pure.CommandStatus* names
+	AckedAt     *time.Time
+	CompletedAt *time.Time
+	Result      []byte // JSON
+	Error       *string
+}
+
+// CommandTargets are the devices an issuer asked to command and whether they may.
+type CommandTargets struct {
+	Permitted bool
+	DeviceIDs []string
+}
+
+// ShadowState is one side of a device shadow. A nil field is not set; an empty list is
+// set to nothing.
+type ShadowState struct {
+	SamplingIntervalSeconds *int
+	EnabledSensors          []string
+	ActiveCampaigns         []string
+	FirmwareVersion         *string
+}
+
+// DeviceShadow is the state a device should run and the state it last reported.
+type DeviceShadow struct {
+	DeviceID          string
+	Version           int64
+	Desired           ShadowState
+	Reported          ShadowState
+	DesiredUpdatedAt  *time.Time
+	ReportedUpdatedAt *time.Time
+	Changed           bool // the update moved the version
+}
+
+// FirmwareRelease is a firmware build offered to a device class, with its rollout stage.
+type FirmwareRelease struct {
+	ID             string
+	Class          string
+	Version        string
+	SHA256         string
+	URL            string
+	SizeBytes      *int64
+	Notes          string
+	SecurityFix    bool
+	RolloutPercent int
+	Cohort         []string
+	CreatedBy      string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// FirmwareCandidate is a device of a release's class not yet running its version.
+type FirmwareCandidate struct {
+	DeviceID        string
+	OwnerID         string
+	Status          string
+	FirmwareVersion string
+	InstallStatus   *string // nil until the device is sent the release
+}
+
+// FirmwareInstall is one device's progress installing a release.
+type FirmwareInstall struct {
+	ReleaseID  string
+	DeviceID   string
+	Status     string
+	Error      *string
+	NotifiedAt time.Time
+	UpdatedAt  time.Time
+}
+
+// GatewayChild is a device enrolled through a gateway.
+type GatewayChild struct {
+	DeviceID string
+	Status   string
+	Class    string
+}
+
+// StationLink is a device that uploads as a consumer weather station.
+type StationLink struct {
+	DeviceID     string
+	Protocol     string
+	ParameterMap map[string]string
+	Token        string // set only by LinkStation; the upload URL's secret
+	CreatedAt    time.Time
+	LastUploadAt *time.Time
+}
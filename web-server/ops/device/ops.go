@@ -2,10 +2,20 @@ package device
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"time"
 
 	devicerepo "rootstock/web-server/repo/device"
 )
 
+// ErrDeviceNotFound is returned by GetDevice when no device has the ID.
+var ErrDeviceNotFound = devicerepo.ErrDeviceNotFound
+
 // Ops holds device operations. Each method is one op.
 type Ops struct {
 	repo devicerepo.Repository
@@ -17,8 +27,16 @@ func NewOps(repo devicerepo.Repository) *Ops {
 }
 
 // GenerateEnrollmentCode generates a one-time enrollment code for a device.
+// A random code is minted when input.Code is empty.
 // Op #11: FR-013
 func (o *Ops) GenerateEnrollmentCode(ctx context.Context, input GenerateCodeInput) (*EnrollmentCode, error) {
+	if input.Code == "" {
+		code, err := newEnrollmentCode()
+		if err != nil {
+			return nil, err
+		}
+		input.Code = code
+	}
 	result, err := o.repo.GenerateEnrollmentCode(ctx, toRepoGenerateCodeInput(input))
 	if err != nil {
 		return nil, err
@@ -36,6 +54,42 @@ func (o *Ops) RedeemEnrollmentCode(ctx context.Context, code string) (*Enrollmen
 	return fromRepoEnrollmentCode(result), nil
 }
 
+// ListPendingEnrollmentCodes returns unused, unexpired codes for devices owned by ownerID.
+func (o *Ops) ListPendingEnrollmentCodes(ctx context.Context, ownerID string) ([]EnrollmentCode, error) {
+	results, err := o.repo.ListPendingEnrollmentCodes(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]EnrollmentCode, len(results))
+	for i, r := range results {
+		out[i] = *fromRepoEnrollmentCode(&r)
+	}
+	return out, nil
+}
+
+// ExpireEnrollmentCodes invalidates every pending code for a device.
+// Returns the number of codes expired.
+func (o *Ops) ExpireEnrollmentCodes(ctx context.Context, deviceID string) (int, error) {
+	return o.repo.ExpireEnrollmentCodes(ctx, deviceID)
+}
+
+// RegenerateEnrollmentCode replaces a device's pending codes with a new one, atomically.
+// Generates the code if not provided.
+func (o *Ops) RegenerateEnrollmentCode(ctx context.Context, input GenerateCodeInput) (*EnrollmentCode, error) {
+	if input.Code == "" {
+		code, err := newEnrollmentCode()
+		if err != nil {
+			return nil, err
+		}
+		input.Code = code
+	}
+	result, err := o.repo.RegenerateEnrollmentCode(ctx, toRepoGenerateCodeInput(input))
+	if err != nil {
+		return nil, err
+	}
+	return fromRepoEnrollmentCode(result), nil
+}
+
 // CreateDevice creates a device registry entry.
 // Op #13: FR-016
 func (o *Ops) CreateDevice(ctx context.Context, input CreateDeviceInput) (*Device, error) {
@@ -103,6 +157,563 @@ func (o *Ops) UpdateCertSerial(ctx context.Context, id string, serial string) er
 	return o.repo.UpdateCertSerial(ctx, id, serial)
 }
 
+// SetSensorUnits replaces the units a device declares for its sensors.
+func (o *Ops) SetSensorUnits(ctx context.Context, id string, units map[string]string) error {
+	return o.repo.SetSensorUnits(ctx, id, units)
+}
+
+// SetClassUnits replaces the default sensor units for a device class.
+func (o *Ops) SetClassUnits(ctx context.Context, input SetClassUnitsInput) error {
+	return o.repo.SetClassUnits(ctx, devicerepo.SetClassUnitsInput{Class: input.Class, Units: input.Units})
+}
+
+// GetClassUnits returns the default sensor units for a device class.
+func (o *Ops) GetClassUnits(ctx context.Context, class string) (map[string]string, error) {
+	return o.repo.GetClassUnits(ctx, class)
+}
+
+// ResolveSensorUnits returns the unit each of a device's sensors reports in:
+// the device's own declaration, else its class default.
+func (o *Ops) ResolveSensorUnits(ctx context.Context, id string) (map[string]string, error) {
+	return o.repo.ResolveSensorUnits(ctx, id)
+}
+
+// RecordClockSkew folds a clock offset observation into the device's skew estimate.
+func (o *Ops) RecordClockSkew(ctx context.Context, input RecordClockSkewInput) (*ClockSkew, error) {
+	result, err := o.repo.RecordClockSkew(ctx, devicerepo.RecordClockSkewInput{
+		DeviceID:   input.DeviceID,
+		ObservedMs: input.ObservedMs,
+		Smoothing:  input.Smoothing,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &ClockSkew{
+		DeviceID:   result.DeviceID,
+		OwnerID:    result.OwnerID,
+		EstimateMs: result.EstimateMs,
+		Samples:    result.Samples,
+		UpdatedAt:  result.UpdatedAt,
+		FlaggedAt:  result.FlaggedAt,
+	}, nil
+}
+
+// SetClockSkewFlag marks or clears a device as having a wrong clock.
+func (o *Ops) SetClockSkewFlag(ctx context.Context, id string, flagged bool) error {
+	return o.repo.SetClockSkewFlag(ctx, id, flagged)
+}
+
+// FlagRateLimitViolator adds to a device's rate limit violation count and flags it for security review.
+func (o *Ops) FlagRateLimitViolator(ctx context.Context, input FlagRateLimitInput) (*RateLimitFlag, error) {
+	result, err := o.repo.FlagRateLimitViolator(ctx, devicerepo.FlagRateLimitInput{
+		DeviceID:   input.DeviceID,
+		Violations: input.Violations,
+	})
+	if err != nil {
+		return nil, err
+	}
+	flag := fromRepoRateLimitFlag(*result)
+	return &flag, nil
+}
+
+// ClearRateLimitFlag removes a device's rate limit flag; its violation count is kept.
+func (o *Ops) ClearRateLimitFlag(ctx context.Context, id string) error {
+	return o.repo.ClearRateLimitFlag(ctx, id)
+}
+
+// ListRateLimitFlagged returns devices flagged for rate limit violations, most recent first.
+func (o *Ops) ListRateLimitFlagged(ctx context.Context) ([]RateLimitFlag, error) {
+	results, err := o.repo.ListRateLimitFlagged(ctx)
+	if err != nil {
+		return nil, err
+	}
+	flags := make([]RateLimitFlag, len(results))
+	for i, r := range results {
+		flags[i] = fromRepoRateLimitFlag(r)
+	}
+	return flags, nil
+}
+
+// RecordIssuedCert adds a newly issued certificate to the ledger and makes it the device's
+// current certificate. Certificates it replaces are revoked as superseded.
+func (o *Ops) RecordIssuedCert(ctx context.Context, input RecordIssuedCertInput) error {
+	return o.repo.RecordIssuedCert(ctx, devicerepo.RecordIssuedCertInput{
+		DeviceID:  input.DeviceID,
+		Serial:    input.Serial,
+		NotBefore: input.NotBefore,
+		NotAfter:  input.NotAfter,
+	})
+}
+
+// SetCertHold places a device's certificates on hold, or lifts the hold.
+// Returns the number of certificates changed.
+func (o *Ops) SetCertHold(ctx context.Context, id string, held bool) (int64, error) {
+	return o.repo.SetCertHold(ctx, id, held)
+}
+
+// ListRevokedCerts returns the revoked, unexpired certificates and the next CRL number.
+func (o *Ops) ListRevokedCerts(ctx context.Context) (*RevocationList, error) {
+	result, err := o.repo.ListRevokedCerts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]RevokedCert, len(result.Entries))
+	for i, e := range result.Entries {
+		entries[i] = RevokedCert{Serial: e.Serial, RevokedAt: e.RevokedAt, Reason: e.Reason}
+	}
+	return &RevocationList{Number: result.Number, Revision: result.Revision, Entries: entries}, nil
+}
+
+// GetCRLRevision returns the revision of the revoked set, which changes whenever a
+// certificate is revoked, held or released.
+func (o *Ops) GetCRLRevision(ctx context.Context) (int64, error) {
+	return o.repo.GetCRLRevision(ctx)
+}
+
+// GetIssuedCert looks a certificate up in the ledger by hex serial.
+// Returns nil when the serial was never issued.
+func (o *Ops) GetIssuedCert(ctx context.Context, serial string) (*IssuedCertRecord, error) {
+	result, err := o.repo.GetIssuedCert(ctx, serial)
+	if err != nil || result == nil {
+		return nil, err
+	}
+	return &IssuedCertRecord{
+		Serial:           result.Serial,
+		DeviceID:         result.DeviceID,
+		NotBefore:        result.NotBefore,
+		NotAfter:         result.NotAfter,
+		IssuedAt:         result.IssuedAt,
+		RevokedAt:        result.RevokedAt,
+		RevocationReason: result.RevocationReason,
+	}, nil
+}
+
+// OpenSession records a device's broker connection and returns the session ID.
+func (o *Ops) OpenSession(ctx context.Context, input OpenSessionInput) (string, error) {
+	return o.repo.OpenSession(ctx, devicerepo.OpenSessionInput{
+		DeviceID:    input.DeviceID,
+		ConnectedAt: input.ConnectedAt,
+		RemoteAddr:  input.RemoteAddr,
+		CertSerial:  input.CertSerial,
+	})
+}
+
+// CloseSession records the end of a broker session.
+func (o *Ops) CloseSession(ctx context.Context, input CloseSessionInput) error {
+	return o.repo.CloseSession(ctx, devicerepo.CloseSessionInput{
+		ID:             input.ID,
+		DisconnectedAt: input.DisconnectedAt,
+		Reason:         input.Reason,
+		Graceful:       input.Graceful,
+		WillPublished:  input.WillPublished,
+	})
+}
+
+// CloseOpenSessions closes every session still marked open. Returns the number closed.
+func (o *Ops) CloseOpenSessions(ctx context.Context, reason string) (int64, error) {
+	return o.repo.CloseOpenSessions(ctx, reason)
+}
+
+// RecordHealth stores a heartbeat and returns the device's owner and active health alerts.
+func (o *Ops) RecordHealth(ctx context.Context, input RecordHealthInput) (*HealthState, error) {
+	result, err := o.repo.RecordHealth(ctx, devicerepo.RecordHealthInput{
+		DeviceID:        input.DeviceID,
+		ReportedAt:      input.ReportedAt,
+		BatteryPercent:  input.BatteryPercent,
+		RSSIDbm:         input.RSSIDbm,
+		UptimeSeconds:   input.UptimeSeconds,
+		FreeMemoryBytes: input.FreeMemoryBytes,
+		SensorFaults:    input.SensorFaults,
+		FirmwareBuild:   input.FirmwareBuild,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &HealthState{DeviceID: result.DeviceID, OwnerID: result.OwnerID, ActiveAlerts: result.ActiveAlerts}, nil
+}
+
+// SetHealthAlerts raises and clears a device's health alerts.
+func (o *Ops) SetHealthAlerts(ctx context.Context, input SetHealthAlertsInput) error {
+	return o.repo.SetHealthAlerts(ctx, devicerepo.SetHealthAlertsInput{
+		DeviceID: input.DeviceID,
+		Raise:    input.Raise,
+		Clear:    input.Clear,
+	})
+}
+
+// ListSilentDevices returns active devices whose last heartbeat is older than before and
+// that are not yet flagged silent.
+func (o *Ops) ListSilentDevices(ctx context.Context, before time.Time) ([]SilentDevice, error) {
+	results, err := o.repo.ListSilentDevices(ctx, before)
+	if err != nil {
+		return nil, err
+	}
+	devices := make([]SilentDevice, len(results))
+	for i, r := range results {
+		devices[i] = SilentDevice{DeviceID: r.DeviceID, OwnerID: r.OwnerID, LastHeartbeat: r.LastHeartbeat}
+	}
+	return devices, nil
+}
+
+// ListConfigCampaigns returns the live campaigns a device is enrolled in at the given time.
+func (o *Ops) ListConfigCampaigns(ctx context.Context, deviceID string, at time.Time) ([]string, error) {
+	return o.repo.ListConfigCampaigns(ctx, deviceID, at)
+}
+
+// ListCampaignDevices returns devices enrolled in a campaign or configured for it.
+func (o *Ops) ListCampaignDevices(ctx context.Context, campaignID string) ([]string, error) {
+	return o.repo.ListCampaignDevices(ctx, campaignID)
+}
+
+// ListEndedConfigDevices returns devices whose pushed config lists a campaign they should
+// no longer run.
+func (o *Ops) ListEndedConfigDevices(ctx context.Context, at time.Time) ([]string, error) {
+	return o.repo.ListEndedConfigDevices(ctx, at)
+}
+
+// SaveDeviceConfig stores a device config, moving its version when the digest changed.
+func (o *Ops) SaveDeviceConfig(ctx context.Context, input SaveDeviceConfigInput) (*DeviceConfig, error) {
+	result, err := o.repo.SaveDeviceConfig(ctx, devicerepo.SaveDeviceConfigInput{
+		DeviceID:    input.DeviceID,
+		Digest:      input.Digest,
+		CampaignIDs: input.CampaignIDs,
+		Campaigns:   input.Campaigns,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return fromRepoDeviceConfig(result), nil
+}
+
+// GetDeviceConfig returns the config last pushed to a device, or nil if none was.
+func (o *Ops) GetDeviceConfig(ctx context.Context, deviceID string) (*DeviceConfig, error) {
+	result, err := o.repo.GetDeviceConfig(ctx, deviceID)
+	if err != nil || result == nil {
+		return nil, err
+	}
+	return fromRepoDeviceConfig(result), nil
+}
+
+// AckDeviceConfig records that a device applied a config version. Reports whether the
+// ack was newer than what was on record.
+func (o *Ops) AckDeviceConfig(ctx context.Context, deviceID string, version int64) (bool, error) {
+	return o.repo.AckDeviceConfig(ctx, deviceID, version)
+}
+
+// ListCommandTargets resolves the devices an issuer asked to command and whether the
+// issuer may command them.
+func (o *Ops) ListCommandTargets(ctx context.Context, input CommandTargetsInput) (*CommandTargets, error) {
+	result, err := o.repo.ListCommandTargets(ctx, devicerepo.CommandTargetsInput{
+		IssuerID:   input.IssuerID,
+		DeviceID:   input.DeviceID,
+		CampaignID: input.CampaignID,
+		At:         input.At,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &CommandTargets{Permitted: result.Permitted, DeviceIDs: result.DeviceIDs}, nil
+}
+
+// CreateCommands records a command about to be sent to each device, as one batch.
+func (o *Ops) CreateCommands(ctx context.Context, input CreateCommandsInput) ([]DeviceCommand, error) {
+	results, err := o.repo.CreateCommands(ctx, devicerepo.CreateCommandsInput{
+		DeviceIDs:  input.DeviceIDs,
+		CampaignID: input.CampaignID,
+		Command:    input.Command,
+		Params:     input.Params,
+		IssuedBy:   input.IssuedBy,
+		ExpiresAt:  input.ExpiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return fromRepoDeviceCommands(results), nil
+}
+
+// ListCommands returns command history for a device or campaign, newest first.
+func (o *Ops) ListCommands(ctx context.Context, input ListCommandsInput) ([]DeviceCommand, error) {
+	results, err := o.repo.ListCommands(ctx, devicerepo.ListCommandsInput{
+		DeviceID:   input.DeviceID,
+		CampaignID: input.CampaignID,
+		Limit:      input.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return fromRepoDeviceCommands(results), nil
+}
+
+// ListPendingCommands returns the device's unacknowledged, unexpired commands, oldest first.
+func (o *Ops) ListPendingCommands(ctx context.Context, deviceID string, at time.Time) ([]DeviceCommand, error) {
+	results, err := o.repo.ListPendingCommands(ctx, deviceID, at)
+	if err != nil {
+		return nil, err
+	}
+	return fromRepoDeviceCommands(results), nil
+}
+
+// RecordCommandResult applies a device's report on a command. Reports whether it was
+// applied; reports on expired, finished or unknown commands are not.
+func (o *Ops) RecordCommandResult(ctx context.Context, input RecordCommandResultInput) (bool, error) {
+	return o.repo.RecordCommandResult(ctx, devicerepo.RecordCommandResultInput{
+		DeviceID:  input.DeviceID,
+		CommandID: input.CommandID,
+		Status:    input.Status,
+		Result:    input.Result,
+		Error:     input.Error,
+	})
+}
+
+// GetShadow returns the device's shadow, or nil if it has none yet.
+func (o *Ops) GetShadow(ctx context.Context, deviceID string) (*DeviceShadow, error) {
+	result, err := o.repo.GetShadow(ctx, deviceID)
+	if err != nil || result == nil {
+		return nil, err
+	}
+	return fromRepoDeviceShadow(result), nil
+}
+
+// UpdateShadowDesired merges state into the shadow's desired side.
+func (o *Ops) UpdateShadowDesired(ctx context.Context, input UpdateShadowInput) (*DeviceShadow, error) {
+	result, err := o.repo.UpdateShadowDesired(ctx, devicerepo.UpdateShadowInput{
+		DeviceID: input.DeviceID,
+		State:    devicerepo.ShadowState(input.State),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return fromRepoDeviceShadow(result), nil
+}
+
+// UpdateShadowReported merges state into the shadow's reported side.
+func (o *Ops) UpdateShadowReported(ctx context.Context, input UpdateShadowInput) (*DeviceShadow, error) {
+	result, err := o.repo.UpdateShadowReported(ctx, devicerepo.UpdateShadowInput{
+		DeviceID: input.DeviceID,
+		State:    devicerepo.ShadowState(input.State),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return fromRepoDeviceShadow(result), nil
+}
+
+// CreateFirmwareRelease registers a firmware build for a device class. Returns nil if the
+// class already has a release of that version.
+func (o *Ops) CreateFirmwareRelease(ctx context.Context, input CreateFirmwareReleaseInput) (*FirmwareRelease, error) {
+	result, err := o.repo.CreateFirmwareRelease(ctx, devicerepo.CreateFirmwareReleaseInput(input))
+	if err != nil || result == nil {
+		return nil, err
+	}
+	release := FirmwareRelease(*result)
+	return &release, nil
+}
+
+// GetFirmwareRelease returns a release, or nil if there is none.
+func (o *Ops) GetFirmwareRelease(ctx context.Context, id string) (*FirmwareRelease, error) {
+	result, err := o.repo.GetFirmwareRelease(ctx, id)
+	if err != nil || result == nil {
+		return nil, err
+	}
+	release := FirmwareRelease(*result)
+	return &release, nil
+}
+
+// ListFirmwareReleases lists a class's releases, or all when class is empty, newest first.
+func (o *Ops) ListFirmwareReleases(ctx context.Context, class string) ([]FirmwareRelease, error) {
+	results, err := o.repo.ListFirmwareReleases(ctx, class)
+	if err != nil {
+		return nil, err
+	}
+	releases := make([]FirmwareRelease, len(results))
+	for i, r := range results {
+		releases[i] = FirmwareRelease(r)
+	}
+	return releases, nil
+}
+
+// SetFirmwareRollout moves a release to a new rollout stage.
+func (o *Ops) SetFirmwareRollout(ctx context.Context, input SetFirmwareRolloutInput) (*FirmwareRelease, error) {
+	result, err := o.repo.SetFirmwareRollout(ctx, devicerepo.SetFirmwareRolloutInput(input))
+	if err != nil {
+		return nil, err
+	}
+	release := FirmwareRelease(*result)
+	return &release, nil
+}
+
+// ListFirmwareCandidates lists the active and suspended devices of a release's class not
+// running its version.
+func (o *Ops) ListFirmwareCandidates(ctx context.Context, releaseID string) ([]FirmwareCandidate, error) {
+	results, err := o.repo.ListFirmwareCandidates(ctx, releaseID)
+	if err != nil {
+		return nil, err
+	}
+	candidates := make([]FirmwareCandidate, len(results))
+	for i, c := range results {
+		candidates[i] = FirmwareCandidate(c)
+	}
+	return candidates, nil
+}
+
+// MarkFirmwareNotified records that devices were sent a release.
+func (o *Ops) MarkFirmwareNotified(ctx context.Context, releaseID string, deviceIDs []string) error {
+	return o.repo.MarkFirmwareNotified(ctx, releaseID, deviceIDs)
+}
+
+// RecordFirmwareInstall applies a device's install report. Reports false if refused.
+func (o *Ops) RecordFirmwareInstall(ctx context.Context, input RecordFirmwareInstallInput) (bool, error) {
+	return o.repo.RecordFirmwareInstall(ctx, devicerepo.RecordFirmwareInstallInput(input))
+}
+
+// ListFirmwareInstalls lists each device's progress installing a release.
+func (o *Ops) ListFirmwareInstalls(ctx context.Context, releaseID string) ([]FirmwareInstall, error) {
+	results, err := o.repo.ListFirmwareInstalls(ctx, releaseID)
+	if err != nil {
+		return nil, err
+	}
+	installs := make([]FirmwareInstall, len(results))
+	for i, r := range results {
+		installs[i] = FirmwareInstall(r)
+	}
+	return installs, nil
+}
+
+// RedeemDelegatedCode marks a code as used on behalf of a gateway of the same owner,
+// recording the gateway as the device's. Returns nil when the code is not found, expired,
+// already used, or not for a non-gateway device of the gateway's owner.
+func (o *Ops) RedeemDelegatedCode(ctx context.Context, input RedeemDelegatedCodeInput) (*EnrollmentCode, error) {
+	result, err := o.repo.RedeemDelegatedCode(ctx, devicerepo.RedeemDelegatedCodeInput(input))
+	if err != nil || result == nil {
+		return nil, err
+	}
+	return fromRepoEnrollmentCode(result), nil
+}
+
+// ListGatewayChildren returns the devices enrolled through a gateway.
+func (o *Ops) ListGatewayChildren(ctx context.Context, gatewayID string) ([]GatewayChild, error) {
+	results, err := o.repo.ListGatewayChildren(ctx, gatewayID)
+	if err != nil {
+		return nil, err
+	}
+	children := make([]GatewayChild, len(results))
+	for i, r := range results {
+		children[i] = GatewayChild(r)
+	}
+	return children, nil
+}
+
+// LinkStation links a device to a weather-station upload protocol under a newly minted token,
+// replacing any earlier link. The token is returned once, in the result; only its hash is kept.
+func (o *Ops) LinkStation(ctx context.Context, input LinkStationInput) (*StationLink, error) {
+	token, err := newStationToken()
+	if err != nil {
+		return nil, err
+	}
+	result, err := o.repo.SaveStationLink(ctx, devicerepo.SaveStationLinkInput{
+		DeviceID:     input.DeviceID,
+		Protocol:     input.Protocol,
+		TokenHash:    hashStationToken(token),
+		ParameterMap: input.ParameterMap,
+	})
+	if err != nil {
+		return nil, err
+	}
+	link := fromRepoStationLink(result)
+	link.Token = token
+	return link, nil
+}
+
+// ResolveStationToken returns the link an upload URL's token belongs to, recording the
+// upload. Returns nil, nil for an unknown token.
+func (o *Ops) ResolveStationToken(ctx context.Context, token string) (*StationLink, error) {
+	result, err := o.repo.ResolveStationToken(ctx, hashStationToken(token))
+	if err != nil || result == nil {
+		return nil, err
+	}
+	return fromRepoStationLink(result), nil
+}
+
+// UnlinkStation removes a device's station link. Returns false when it had none.
+func (o *Ops) UnlinkStation(ctx context.Context, deviceID string) (bool, error) {
+	return o.repo.DeleteStationLink(ctx, deviceID)
+}
+
+func fromRepoStationLink(r *devicerepo.StationLink) *StationLink {
+	return &StationLink{
+		DeviceID:     r.DeviceID,
+		Protocol:     r.Protocol,
+		ParameterMap: r.ParameterMap,
+		CreatedAt:    r.CreatedAt,
+		LastUploadAt: r.LastUploadAt,
+	}
+}
+
+func fromRepoDeviceShadow(r *devicerepo.DeviceShadow) *DeviceShadow {
+	return &DeviceShadow{
+		DeviceID:          r.DeviceID,
+		Version:           r.Version,
+		Desired:           ShadowState(r.Desired),
+		Reported:          ShadowState(r.Reported),
+		DesiredUpdatedAt:  r.DesiredUpdatedAt,
+		ReportedUpdatedAt: r.ReportedUpdatedAt,
+		Changed:           r.Changed,
+	}
+}
+
+func fromRepoDeviceCommand(r *devicerepo.DeviceCommand) *DeviceCommand {
+	return &DeviceCommand{
+		ID:          r.ID,
+		DeviceID:    r.DeviceID,
+		CampaignID:  r.CampaignID,
+		BatchID:     r.BatchID,
+		Command:     r.Command,
+		Params:      r.Params,
+		IssuedBy:    r.IssuedBy,
+		IssuedAt:    r.IssuedAt,
+		ExpiresAt:   r.ExpiresAt,
+		Status:      r.Status,
+		AckedAt:     r.AckedAt,
+		CompletedAt: r.CompletedAt,
+		Result:      r.Result,
+		Error:       r.Error,
+	}
+}
+
+func fromRepoDeviceCommands(rs []devicerepo.DeviceCommand) []DeviceCommand {
+	out := make([]DeviceCommand, len(rs))
+	for i := range rs {
+		out[i] = *fromRepoDeviceCommand(&rs[i])
+	}
+	return out
+}
+
+func fromRepoDeviceConfig(r *devicerepo.DeviceConfig) *DeviceConfig {
+	return &DeviceConfig{
+		DeviceID:     r.DeviceID,
+		Version:      r.Version,
+		Digest:       r.Digest,
+		CampaignIDs:  r.CampaignIDs,
+		Campaigns:    r.Campaigns,
+		PushedAt:     r.PushedAt,
+		AckedVersion: r.AckedVersion,
+		AckedAt:      r.AckedAt,
+		Changed:      r.Changed,
+	}
+}
+
+func fromRepoRateLimitFlag(r devicerepo.RateLimitFlag) RateLimitFlag {
+	return RateLimitFlag{
+		DeviceID:        r.DeviceID,
+		OwnerID:         r.OwnerID,
+		Class:           r.Class,
+		FirmwareVersion: r.FirmwareVersion,
+		Status:          r.Status,
+		Violations:      r.Violations,
+		FlaggedAt:       r.FlaggedAt,
+	}
+}
+
 func toRepoCreateDeviceInput(in CreateDeviceInput) devicerepo.CreateDeviceInput {
 	return devicerepo.CreateDeviceInput{
 		OwnerID:         in.OwnerID,
@@ -110,14 +721,14 @@ func toRepoCreateDeviceInput(in CreateDeviceInput) devicerepo.CreateDeviceInput
 		FirmwareVersion: in.FirmwareVersion,
 		Tier:            in.Tier,
 		Sensors:         in.Sensors,
+		SensorUnits:     in.SensorUnits,
+		Gateway:         in.Gateway,
 	}
 }
 
 func toRepoQueryByClassInput(in QueryByClassInput) devicerepo.QueryByClassInput {
 	return devicerepo.QueryByClassInput{
-		Class:          in.Class,
-		FirmwareMinGte: in.FirmwareMinGte,
-		FirmwareMaxLte: in.FirmwareMaxLte,
+		Class: in.Class,
 	}
 }
 
@@ -138,7 +749,10 @@ func fromRepoDevice(r *devicerepo.Device) *Device {
 		FirmwareVersion: r.FirmwareVersion,
 		Tier:            r.Tier,
 		Sensors:         r.Sensors,
+		SensorUnits:     r.SensorUnits,
 		CertSerial:      r.CertSerial,
+		Gateway:         r.Gateway,
+		GatewayID:       r.GatewayID,
 		CreatedAt:       r.CreatedAt,
 	}
 }
@@ -151,3 +765,38 @@ func fromRepoEnrollmentCode(r *devicerepo.EnrollmentCode) *EnrollmentCode {
 		Used:      r.Used,
 	}
 }
+
+// enrollmentCodeAlphabet omits ambiguous characters (0/O, 1/I/L) per FR-013.
+const enrollmentCodeAlphabet = "23456789ABCDEFGHJKMNPQRSTUVWXYZ"
+
+const enrollmentCodeLength = 8
+
+func newEnrollmentCode() (string, error) {
+	alphabetLen := big.NewInt(int64(len(enrollmentCodeAlphabet)))
+	buf := make([]byte, enrollmentCodeLength)
+	for i := range buf {
+		n, err := rand.Int(rand.Reader, alphabetLen)
+		if err != nil {
+			return "", fmt.Errorf("generate enrollment code: %w", err)
+		}
+		buf[i] = enrollmentCodeAlphabet[n.Int64()]
+	}
+	return string(buf), nil
+}
+
+// stationTokenBytes is the entropy of a station upload token. Stations put it in a URL path,
+// so it is base64url without padding.
+const stationTokenBytes = 24
+
+func newStationToken() (string, error) {
+	buf := make([]byte, stationTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate station token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func hashStationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -3,6 +3,7 @@ package device
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -106,6 +107,54 @@ func TestEnrollmentCodeLifecycle(t *testing.T) {
 	}
 }
 
+func TestGenerateEnrollmentCodeMintsCode(t *testing.T) {
+	ops, _ := setupTest(t)
+	ctx := context.Background()
+
+	d, _ := ops.CreateDevice(ctx, CreateDeviceInput{
+		OwnerID: "user-1", Class: "sensor", FirmwareVersion: "1.0.0", Tier: 1, Sensors: []string{"temp"},
+	})
+
+	code, err := ops.GenerateEnrollmentCode(ctx, GenerateCodeInput{DeviceID: d.ID, TTL: 900})
+	if err != nil {
+		t.Fatalf("GenerateEnrollmentCode(): %v", err)
+	}
+	if len(code.Code) != enrollmentCodeLength {
+		t.Errorf("code length = %d, want %d", len(code.Code), enrollmentCodeLength)
+	}
+	if strings.ContainsAny(code.Code, "0O1IL") {
+		t.Errorf("code %q contains ambiguous characters", code.Code)
+	}
+}
+
+func TestLinkStationMintsToken(t *testing.T) {
+	ops, _ := setupTest(t)
+	ctx := context.Background()
+
+	d, _ := ops.CreateDevice(ctx, CreateDeviceInput{
+		OwnerID: "user-1", Class: "weather-station", FirmwareVersion: "1.0.0", Tier: 1, Sensors: []string{"temperature"},
+	})
+
+	link, err := ops.LinkStation(ctx, LinkStationInput{DeviceID: d.ID, Protocol: "ambient"})
+	if err != nil {
+		t.Fatalf("LinkStation(): %v", err)
+	}
+	if link.Token == "" || strings.ContainsAny(link.Token, "/+=") {
+		t.Errorf("token %q is not URL-path safe", link.Token)
+	}
+
+	got, err := ops.ResolveStationToken(ctx, link.Token)
+	if err != nil {
+		t.Fatalf("ResolveStationToken(): %v", err)
+	}
+	if got == nil || got.DeviceID != d.ID || got.Token != "" {
+		t.Errorf("resolved = %+v, want link for %s without its token", got, d.ID)
+	}
+	if got, _ := ops.ResolveStationToken(ctx, "not-a-token"); got != nil {
+		t.Errorf("ResolveStationToken(unknown) = %+v, want nil", got)
+	}
+}
+
 func TestGetCapabilitiesAndQuery(t *testing.T) {
 	ops, _ := setupTest(t)
 	ctx := context.Background()
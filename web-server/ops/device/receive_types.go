@@ -1,5 +1,7 @@
 package device
 
+import "time"
+
 // CreateDeviceInput is what callers send to CreateDevice.
 type CreateDeviceInput struct {
 	OwnerID         string
@@ -7,13 +9,13 @@ type CreateDeviceInput struct {
 	FirmwareVersion string
 	Tier            int
 	Sensors         []string
+	SensorUnits     map[string]string
+	Gateway         bool
 }
 
 // QueryByClassInput is what callers send to QueryDevicesByClass.
 type QueryByClassInput struct {
-	Class          string
-	FirmwareMinGte string
-	FirmwareMaxLte string
+	Class string
 }
 
 // GenerateCodeInput is what callers send to GenerateEnrollmentCode.
@@ -22,3 +24,157 @@ type GenerateCodeInput struct {
 	Code     string
 	TTL      int
 }
+
+// SetClassUnitsInput is what callers send to SetClassUnits.
+type SetClassUnitsInput struct {
+	Class string
+	Units map[string]string // sensor -> UCUM unit; replaces the class's current units
+}
+
+// RecordClockSkewInput is what callers send to RecordClockSkew.
+type RecordClockSkewInput struct {
+	DeviceID   string
+	ObservedMs int64   // reading timestamp minus arrival time, for a live reading
+	Smoothing  float64 // weight of this observation, 0-1
+}
+
+// FlagRateLimitInput is what callers send to FlagRateLimitViolator.
+type FlagRateLimitInput struct {
+	DeviceID   string
+	Violations int64 // violations since the device was last reported
+}
+
+// RecordIssuedCertInput is what callers send to RecordIssuedCert.
+type RecordIssuedCertInput struct {
+	DeviceID  string
+	Serial    string // hex-encoded
+	NotBefore time.Time
+	NotAfter  time.Time
+}
+
+// OpenSessionInput is what callers send to OpenSession.
+type OpenSessionInput struct {
+	DeviceID    string
+	ConnectedAt time.Time
+	RemoteAddr  string
+	CertSerial  string // hex-encoded; empty if unknown
+}
+
+// CloseSessionInput is what callers send to CloseSession.
+type CloseSessionInput struct {
+	ID             string
+	DisconnectedAt time.Time
+	Reason         string
+	Graceful       bool // the client sent DISCONNECT
+	WillPublished  bool // the broker published the client's last will as it dropped
+}
+
+// RecordHealthInput is what callers send to RecordHealth.
+type RecordHealthInput struct {
+	DeviceID        string
+	ReportedAt      time.Time
+	BatteryPercent  *float64
+	RSSIDbm         *int
+	UptimeSeconds   *int64
+	FreeMemoryBytes *int64
+	SensorFaults    []string
+	FirmwareBuild   *string
+}
+
+// SetHealthAlertsInput is what callers send to SetHealthAlerts.
+type SetHealthAlertsInput struct {
+	DeviceID string
+	Raise    []string // pure.HealthAlert* names
+	Clear    []string
+}
+
+// SaveDeviceConfigInput is what callers send to SaveDeviceConfig.
+type SaveDeviceConfigInput struct {
+	DeviceID    string
+	Digest      string
+	CampaignIDs []string
+	Campaigns   []byte // JSON
+}
+
+// CommandTargetsInput is what callers send to ListCommandTargets: one device or one
+// campaign's devices.
+type CommandTargetsInput struct {
+	IssuerID   string
+	DeviceID   string
+	CampaignID string
+	At         time.Time
+}
+
+// CreateCommandsInput is what callers send to CreateCommands.
+type CreateCommandsInput struct {
+	DeviceIDs  []string
+	CampaignID *string // set when sent to a campaign's devices
+	Command    string
+	Params     []byte // JSON
+	IssuedBy   string
+	ExpiresAt  time.Time
+}
+
+// ListCommandsInput is what callers send to ListCommands.
+type ListCommandsInput struct {
+	DeviceID   string
+	CampaignID string
+	Limit      int
+}
+
+// RecordCommandResultInput is what callers send to RecordCommandResult.
+type RecordCommandResultInput struct {
+	DeviceID  string
+	CommandID string
+	Status    string
+	Result    []byte // JSON
+	Error     *string
+}
+
+// UpdateShadowInput is what callers send to UpdateShadowDesired and UpdateShadowReported.
+type UpdateShadowInput struct {
+	DeviceID string
+	State    ShadowState // nil fields are left as they are
+}
+
+// CreateFirmwareReleaseInput is what callers send to CreateFirmwareRelease.
+type CreateFirmwareReleaseInput struct {
+	Class          string
+	Version        string
+	SHA256         string
+	URL            string
+	SizeBytes      *int64
+	Notes          string
+	SecurityFix    bool
+	RolloutPercent int
+	Cohort         []string
+	CreatedBy      string
+}
+
+// SetFirmwareRolloutInput is what callers send to SetFirmwareRollout.
+type SetFirmwareRolloutInput struct {
+	ReleaseID      string
+	RolloutPercent int
+	Cohort         []string
+}
+
+// RecordFirmwareInstallInput is what callers send to RecordFirmwareInstall.
+type RecordFirmwareInstallInput struct {
+	DeviceID  string
+	ReleaseID string
+	Status    string
+	Error     *string
+}
+
+// RedeemDelegatedCodeInput is what callers send to RedeemDelegatedCode.
+type RedeemDelegatedCodeInput struct {
+	Code      string
+	GatewayID string
+}
+
+// LinkStationInput is what callers send to LinkStation.
+type LinkStationInput struct {
+	DeviceID     string
+	Protocol     string
+	ParameterMap map[string]string // standard parameter name -> campaign parameter name
+}
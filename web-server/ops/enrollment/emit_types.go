@@ -4,13 +4,16 @@ import "time"
 
 // Enrollment is the enrollment record returned by ops.
 type Enrollment struct {
-	ID          string
-	DeviceID    string
-	CampaignID  string
-	ScitizenID  string
-	Status      string
-	EnrolledAt  time.Time
-	WithdrawnAt *time.Time
+	ID                 string
+	DeviceID           string
+	CampaignID         string
+	ScitizenID         string
+	Status             string
+	EnrolledAt         time.Time
+	WithdrawnAt        *time.Time
+	DeploymentLocation *string // GeoJSON Point
+	LocationFlaggedAt  *time.Time
+	LocationFlagReason *string
 }
 
 // NotificationPreference is a per-type notification preference.
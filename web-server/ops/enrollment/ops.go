@@ -20,11 +20,12 @@ func NewOps(repo enrollmentrepo.Repository) *Ops {
 // Enroll creates a campaign enrollment with consent record.
 func (o *Ops) Enroll(ctx context.Context, input EnrollInput) (*Enrollment, error) {
 	result, err := o.repo.Enroll(ctx, enrollmentrepo.EnrollInput{
-		DeviceID:       input.DeviceID,
-		CampaignID:     input.CampaignID,
-		ScitizenID:     input.ScitizenID,
-		ConsentVersion: input.ConsentVersion,
-		ConsentScope:   input.ConsentScope,
+		DeviceID:           input.DeviceID,
+		CampaignID:         input.CampaignID,
+		ScitizenID:         input.ScitizenID,
+		ConsentVersion:     input.ConsentVersion,
+		ConsentScope:       input.ConsentScope,
+		DeploymentLocation: input.DeploymentLocation,
 	})
 	if err != nil {
 		return nil, err
@@ -58,6 +59,15 @@ func (o *Ops) GetByDeviceCampaign(ctx context.Context, deviceID, campaignID stri
 	return fromRepoEnrollment(result), nil
 }
 
+// FlagLocation marks an enrollment for review because the device's reported
+// location does not match its deployment (FR-124).
+func (o *Ops) FlagLocation(ctx context.Context, input FlagLocationInput) error {
+	return o.repo.FlagLocation(ctx, enrollmentrepo.FlagLocationInput{
+		EnrollmentID: input.EnrollmentID,
+		Reason:       input.Reason,
+	})
+}
+
 // MarkRead marks notifications as read.
 func (o *Ops) MarkRead(ctx context.Context, userID string, ids []string) (int, error) {
 	return o.repo.MarkRead(ctx, userID, ids)
@@ -97,12 +107,15 @@ func (o *Ops) UpdatePreferences(ctx context.Context, userID string, prefs []Noti
 
 func fromRepoEnrollment(r *enrollmentrepo.Enrollment) *Enrollment {
 	return &Enrollment{
-		ID:          r.ID,
-		DeviceID:    r.DeviceID,
-		CampaignID:  r.CampaignID,
-		ScitizenID:  r.ScitizenID,
-		Status:      r.Status,
-		EnrolledAt:  r.EnrolledAt,
-		WithdrawnAt: r.WithdrawnAt,
+		ID:                 r.ID,
+		DeviceID:           r.DeviceID,
+		CampaignID:         r.CampaignID,
+		ScitizenID:         r.ScitizenID,
+		Status:             r.Status,
+		EnrolledAt:         r.EnrolledAt,
+		WithdrawnAt:        r.WithdrawnAt,
+		DeploymentLocation: r.DeploymentLocation,
+		LocationFlaggedAt:  r.LocationFlaggedAt,
+		LocationFlagReason: r.LocationFlagReason,
 	}
 }
@@ -2,11 +2,18 @@ package enrollment
 
 // EnrollInput is what callers send to Enroll.
 type EnrollInput struct {
-	DeviceID       string
-	CampaignID     string
-	ScitizenID     string
-	ConsentVersion string
-	ConsentScope   string
+	DeviceID           string
+	CampaignID         string
+	ScitizenID         string
+	ConsentVersion     string
+	ConsentScope       string
+	DeploymentLocation string // GeoJSON Point; empty when not given
+}
+
+// FlagLocationInput is what callers send to FlagLocation.
+type FlagLocationInput struct {
+	EnrollmentID string
+	Reason       string
 }
 
 // CreateNotificationInput creates a notification.
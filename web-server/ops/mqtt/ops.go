@@ -2,10 +2,14 @@ package mqtt
 
 import (
 	"context"
+	"fmt"
 
 	mqttrepo "rootstock/web-server/repo/mqtt"
 )
 
+// TopicPrefix is the root namespace for all Rootstock MQTT topics.
+const TopicPrefix = mqttrepo.TopicPrefix
+
 // Ops holds MQTT operations. Each method is one op.
 type Ops struct {
 	repo mqttrepo.Repository
@@ -23,3 +27,60 @@ func (o *Ops) PushDeviceConfig(ctx context.Context, input PushDeviceConfigInput)
 		Payload:  input.Payload,
 	})
 }
+
+// SendDeviceCommand publishes a command payload to a device's command topic at QoS 1,
+// not retained: a device that was offline is sent its pending commands when it subscribes.
+func (o *Ops) SendDeviceCommand(ctx context.Context, input SendDeviceCommandInput) error {
+	return o.repo.PublishToDevice(ctx, mqttrepo.PublishInput{
+		Topic:   fmt.Sprintf("%s/%s/command", TopicPrefix, input.DeviceID),
+		Payload: input.Payload,
+		QoS:     1,
+	})
+}
+
+// PublishShadowDelta publishes the desired state a device has not yet reported to
+// rootstock/{id}/shadow/delta at QoS 1, not retained.
+func (o *Ops) PublishShadowDelta(ctx context.Context, input PublishShadowInput) error {
+	return o.repo.PublishToDevice(ctx, mqttrepo.PublishInput{
+		Topic:   fmt.Sprintf("%s/%s/shadow/delta", TopicPrefix, input.DeviceID),
+		Payload: input.Payload,
+		QoS:     1,
+	})
+}
+
+// PublishShadowDocument answers a device's shadow/get with its full shadow on
+// rootstock/{id}/shadow/get/accepted.
+func (o *Ops) PublishShadowDocument(ctx context.Context, input PublishShadowInput) error {
+	return o.repo.PublishToDevice(ctx, mqttrepo.PublishInput{
+		Topic:   fmt.Sprintf("%s/%s/shadow/get/accepted", TopicPrefix, input.DeviceID),
+		Payload: input.Payload,
+		QoS:     1,
+	})
+}
+
+// PublishFirmwareManifest publishes a firmware update manifest to rootstock/{id}/ota at
+// QoS 1, retained, so a device that was offline is sent it when it subscribes.
+func (o *Ops) PublishFirmwareManifest(ctx context.Context, input PublishFirmwareManifestInput) error {
+	return o.repo.PublishToDevice(ctx, mqttrepo.PublishInput{
+		Topic:   fmt.Sprintf("%s/%s/ota", TopicPrefix, input.DeviceID),
+		Payload: input.Payload,
+		QoS:     1,
+		Retain:  true,
+	})
+}
+
+// DisconnectDevice ends a device's live broker session so it has to authenticate again.
+// Reports whether a session was open.
+func (o *Ops) DisconnectDevice(ctx context.Context, deviceID string) (bool, error) {
+	return o.repo.DisconnectDevice(ctx, deviceID)
+}
+
+// Republish publishes a payload back onto its original topic so it flows through
+// the broker's normal subscription path again.
+func (o *Ops) Republish(ctx context.Context, input RepublishInput) error {
+	return o.repo.PublishToDevice(ctx, mqttrepo.PublishInput{
+		Topic:   input.Topic,
+		Payload: input.Payload,
+		QoS:     1,
+	})
+}
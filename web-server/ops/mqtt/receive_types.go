@@ -5,3 +5,27 @@ type PushDeviceConfigInput struct {
 	DeviceID string
 	Payload  []byte
 }
+
+// SendDeviceCommandInput is what callers send to SendDeviceCommand.
+type SendDeviceCommandInput struct {
+	DeviceID string
+	Payload  []byte
+}
+
+// PublishShadowInput is what callers send to PublishShadowDelta and PublishShadowDocument.
+type PublishShadowInput struct {
+	DeviceID string
+	Payload  []byte
+}
+
+// PublishFirmwareManifestInput is what callers send to PublishFirmwareManifest.
+type PublishFirmwareManifestInput struct {
+	DeviceID string
+	Payload  []byte
+}
+
+// RepublishInput is what callers send to Republish.
+type RepublishInput struct {
+	Topic   string
+	Payload []byte
+}
@@ -0,0 +1,64 @@
+package pure
+
+import "time"
+
+// Revocation reasons recorded in the issued-certificate ledger.
+const (
+	RevocationSuperseded      = "superseded"       // replaced by a renewal
+	RevocationCertificateHold = "certificate_hold" // device revoked; lifted on reinstatement
+)
+
+// CRLReason codes from RFC 5280 5.3.1.
+const (
+	CRLReasonUnspecified     = 0
+	CRLReasonSuperseded      = 4
+	CRLReasonCertificateHold = 6
+)
+
+// Certificate statuses reported over OCSP.
+const (
+	CertStatusGood    = "good"
+	CertStatusRevoked = "revoked"
+	CertStatusUnknown = "unknown" // never issued by this CA
+)
+
+// CertStatusInput is a ledger record to report on. Issued is false when the serial is not in the ledger.
+type CertStatusInput struct {
+	Issued           bool
+	RevokedAt        *time.Time
+	RevocationReason string
+}
+
+// CertStatusResult is the status to report for a certificate.
+type CertStatusResult struct {
+	Status     string
+	RevokedAt  time.Time // set when revoked
+	ReasonCode int       // CRLReason, set when revoked
+}
+
+// RevocationReasonCode is a pure function: ledger revocation reason -> RFC 5280 CRLReason code.
+func RevocationReasonCode(reason string) int {
+	switch reason {
+	case RevocationSuperseded:
+		return CRLReasonSuperseded
+	case RevocationCertificateHold:
+		return CRLReasonCertificateHold
+	default:
+		return CRLReasonUnspecified
+	}
+}
+
+// CheckCertStatus is a pure function: ledger record -> good, revoked or unknown.
+func CheckCertStatus(input CertStatusInput) CertStatusResult {
+	if !input.Issued {
+		return CertStatusResult{Status: CertStatusUnknown}
+	}
+	if input.RevokedAt == nil {
+		return CertStatusResult{Status: CertStatusGood}
+	}
+	return CertStatusResult{
+		Status:     CertStatusRevoked,
+		RevokedAt:  *input.RevokedAt,
+		ReasonCode: RevocationReasonCode(input.RevocationReason),
+	}
+}
@@ -0,0 +1,39 @@
+package pure
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRevocationReasonCode(t *testing.T) {
+	tests := []struct {
+		reason string
+		want   int
+	}{
+		{RevocationSuperseded, CRLReasonSuperseded},
+		{RevocationCertificateHold, CRLReasonCertificateHold},
+		{"", CRLReasonUnspecified},
+		{"something_else", CRLReasonUnspecified},
+	}
+	for _, tt := range tests {
+		if got := RevocationReasonCode(tt.reason); got != tt.want {
+			t.Errorf("RevocationReasonCode(%q) = %d, want %d", tt.reason, got, tt.want)
+		}
+	}
+}
+
+func TestCheckCertStatus(t *testing.T) {
+	revokedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	if got := CheckCertStatus(CertStatusInput{}); got.Status != CertStatusUnknown {
+		t.Errorf("unissued serial: status = %q, want unknown", got.Status)
+	}
+	if got := CheckCertStatus(CertStatusInput{Issued: true}); got.Status != CertStatusGood {
+		t.Errorf("unrevoked cert: status = %q, want good", got.Status)
+	}
+
+	got := CheckCertStatus(CertStatusInput{Issued: true, RevokedAt: &revokedAt, RevocationReason: RevocationSuperseded})
+	if got.Status != CertStatusRevoked || !got.RevokedAt.Equal(revokedAt) || got.ReasonCode != CRLReasonSuperseded {
+		t.Errorf("superseded cert: got %+v", got)
+	}
+}
@@ -0,0 +1,51 @@
+package pure
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// ClockSkewSmoothing is the weight a new observation gets in a device's skew estimate.
+const ClockSkewSmoothing = 0.2
+
+// TimestampLagResult is the outcome of checking a reading timestamp against when it arrived.
+type TimestampLagResult struct {
+	OK     bool
+	Lag    time.Duration // receivedAt - timestamp; negative when the timestamp is in the future
+	Code   string        // feedback code when not OK
+	Reason string
+}
+
+// ClockSkewResult is the outcome of comparing a device's skew estimate with the owner-alert limit.
+type ClockSkewResult struct {
+	Skewed bool
+	Skew   time.Duration // device clock minus server clock
+}
+
+// CheckTimestampLag is a pure function: (timestamp, received at, limits) -> within limits?
+// A timestamp more than maxFutureDrift after receivedAt means the device clock is ahead; one more
+// than maxBacklog before it is too old to accept even from store-and-forward. A zero limit
+// disables that side of the check, and a zero receivedAt disables both.
+func CheckTimestampLag(timestamp, receivedAt time.Time, maxFutureDrift, maxBacklog time.Duration) TimestampLagResult {
+	if receivedAt.IsZero() {
+		return TimestampLagResult{OK: true}
+	}
+	lag := receivedAt.Sub(timestamp)
+	if maxFutureDrift > 0 && -lag > maxFutureDrift {
+		return TimestampLagResult{Lag: lag, Code: FeedbackClockAhead,
+			Reason: fmt.Sprintf("timestamp %s is %s ahead of ingestion, limit %s", timestamp.Format(time.RFC3339), (-lag).Round(time.Second), maxFutureDrift)}
+	}
+	if maxBacklog > 0 && lag > maxBacklog {
+		return TimestampLagResult{Lag: lag, Code: FeedbackBacklogTooOld,
+			Reason: fmt.Sprintf("timestamp %s is %s before ingestion, backlog limit %s", timestamp.Format(time.RFC3339), lag.Round(time.Second), maxBacklog)}
+	}
+	return TimestampLagResult{OK: true, Lag: lag}
+}
+
+// CheckClockSkew is a pure function: (skew estimate, limit) -> is the device clock wrong?
+// A limit of zero or less disables the check.
+func CheckClockSkew(estimateMs int64, limit time.Duration) ClockSkewResult {
+	skew := time.Duration(estimateMs) * time.Millisecond
+	return ClockSkewResult{Skewed: limit > 0 && math.Abs(float64(skew)) > float64(limit), Skew: skew}
+}
@@ -0,0 +1,66 @@
+package pure
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCheckTimestampLag(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		timestamp time.Time
+		ok        bool
+		code      string
+	}{
+		{"on time", now.Add(-2 * time.Second), true, ""},
+		{"slightly ahead", now.Add(30 * time.Second), true, ""},
+		{"far ahead", now.Add(2 * time.Hour), false, FeedbackClockAhead},
+		{"recent backlog", now.Add(-48 * time.Hour), true, ""},
+		{"epoch", time.Unix(0, 0).UTC(), false, FeedbackBacklogTooOld},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CheckTimestampLag(tt.timestamp, now, 5*time.Minute, 30*24*time.Hour)
+			if got.OK != tt.ok || got.Code != tt.code {
+				t.Errorf("got ok=%v code=%q, want ok=%v code=%q (%s)", got.OK, got.Code, tt.ok, tt.code, got.Reason)
+			}
+		})
+	}
+}
+
+func TestCheckTimestampLagDisabled(t *testing.T) {
+	now := time.Now().UTC()
+	if !CheckTimestampLag(now.Add(24*time.Hour), now, 0, 0).OK {
+		t.Error("zero limits should disable the check")
+	}
+	if !CheckTimestampLag(time.Unix(0, 0), time.Time{}, time.Minute, time.Hour).OK {
+		t.Error("zero received time should disable the check")
+	}
+}
+
+func TestCheckClockSkew(t *testing.T) {
+	if r := CheckClockSkew(-90_000, time.Minute); !r.Skewed || r.Skew != -90*time.Second {
+		t.Errorf("behind clock = %+v, want skewed by -90s", r)
+	}
+	if CheckClockSkew(20_000, time.Minute).Skewed {
+		t.Error("20s skew should be within a one-minute limit")
+	}
+	if CheckClockSkew(1_000_000, 0).Skewed {
+		t.Error("zero limit should disable the check")
+	}
+}
+
+func TestValidateReadingClockAhead(t *testing.T) {
+	now := time.Now().UTC()
+	result := ValidateReading(ReadingInput{
+		Values:    map[string]float64{"temp": 20},
+		Timestamp: now.Add(365 * 24 * time.Hour),
+	}, ValidationRules{ReceivedAt: now, MaxFutureDrift: 5 * time.Minute})
+	if result.Valid || result.Code != FeedbackClockAhead {
+		t.Errorf("result = %+v, want clock_ahead", result)
+	}
+	if len(result.PerParameter) != 0 {
+		t.Error("clock check should apply to the whole reading")
+	}
+}
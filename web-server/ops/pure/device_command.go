@@ -0,0 +1,121 @@
+package pure
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Commands a device accepts on rootstock/{id}/command.
+const (
+	CommandSetSamplingInterval = "set_sampling_interval" // interval_seconds
+	CommandRecalibrate         = "recalibrate"           // sensors; none means all
+	CommandReboot              = "reboot"
+	CommandRenewCertificate    = "renew_certificate" // the device renews over rootstock/{id}/renew
+)
+
+// Command statuses. A command is sent, then acknowledged by the device when it is
+// received, then succeeded or failed once carried out. A command still unacknowledged
+// at its expiry is expired; the device must not act on it.
+const (
+	CommandStatusSent         = "sent"
+	CommandStatusAcknowledged = "acknowledged"
+	CommandStatusSucceeded    = "succeeded"
+	CommandStatusFailed       = "failed"
+	CommandStatusExpired      = "expired"
+)
+
+// Limits on command parameters. The sampling interval bounds also apply to device shadows.
+const (
+	MinSamplingIntervalSeconds = 1
+	MaxSamplingIntervalSeconds = 86400
+	MaxCommandSensors          = 32
+	MaxCommandResultError      = 512
+)
+
+// CommandRequest is a command as asked for by a researcher or device owner.
+type CommandRequest struct {
+	Command         string
+	IntervalSeconds *int          // set_sampling_interval
+	Sensors         []string      // recalibrate
+	TTL             time.Duration // 0 takes DefaultTTL
+	DefaultTTL      time.Duration
+	MaxTTL          time.Duration
+}
+
+// CommandCheck is the outcome of validating a command request.
+type CommandCheck struct {
+	Valid  bool
+	Reason string
+	TTL    time.Duration // how long the device has to pick the command up
+}
+
+// ValidateCommand is a pure function: command request -> valid? and for how long.
+// Parameters that do not belong to the command are refused rather than ignored, so a
+// caller cannot believe it sent something the device will never see.
+func ValidateCommand(r CommandRequest) CommandCheck {
+	ttl := r.TTL
+	if ttl == 0 {
+		ttl = r.DefaultTTL
+	}
+	switch {
+	case ttl < 0:
+		return CommandCheck{Reason: "ttl is negative"}
+	case r.MaxTTL > 0 && ttl > r.MaxTTL:
+		return CommandCheck{Reason: fmt.Sprintf("ttl %s exceeds limit %s", ttl, r.MaxTTL)}
+	case ttl == 0:
+		return CommandCheck{Reason: "ttl is required"}
+	}
+
+	switch r.Command {
+	case CommandSetSamplingInterval:
+		if r.IntervalSeconds == nil {
+			return CommandCheck{Reason: "set_sampling_interval requires interval_seconds"}
+		}
+		if s := *r.IntervalSeconds; s < MinSamplingIntervalSeconds || s > MaxSamplingIntervalSeconds {
+			return CommandCheck{Reason: fmt.Sprintf("interval_seconds %d outside %d-%d", s, MinSamplingIntervalSeconds, MaxSamplingIntervalSeconds)}
+		}
+	case CommandRecalibrate:
+		if len(r.Sensors) > MaxCommandSensors {
+			return CommandCheck{Reason: fmt.Sprintf("%d sensors, limit %d", len(r.Sensors), MaxCommandSensors)}
+		}
+		if slices.Contains(r.Sensors, "") {
+			return CommandCheck{Reason: "sensor name is empty"}
+		}
+	case CommandReboot, CommandRenewCertificate:
+	default:
+		return CommandCheck{Reason: fmt.Sprintf("unknown command %q", r.Command)}
+	}
+
+	if r.IntervalSeconds != nil && r.Command != CommandSetSamplingInterval {
+		return CommandCheck{Reason: fmt.Sprintf("%s does not take interval_seconds", r.Command)}
+	}
+	if len(r.Sensors) > 0 && r.Command != CommandRecalibrate {
+		return CommandCheck{Reason: fmt.Sprintf("%s does not take sensors", r.Command)}
+	}
+	return CommandCheck{Valid: true, TTL: ttl}
+}
+
+// CommandResultCheck is the outcome of validating a device's report on a command.
+type CommandResultCheck struct {
+	Valid  bool
+	Reason string
+}
+
+// ValidateCommandResult is a pure function: device report -> acceptable?
+// A device reports acknowledged, succeeded or failed; a failure must say why.
+func ValidateCommandResult(status, errMsg string) CommandResultCheck {
+	switch status {
+	case CommandStatusAcknowledged, CommandStatusSucceeded:
+	case CommandStatusFailed:
+		if errMsg == "" {
+			return CommandResultCheck{Reason: "failed requires error"}
+		}
+	default:
+		return CommandResultCheck{Reason: fmt.Sprintf("status %q is not reported by devices", status)}
+	}
+	if len(errMsg) > MaxCommandResultError {
+		return CommandResultCheck{Reason: fmt.Sprintf("error longer than %d characters", MaxCommandResultError)}
+	}
+	return CommandResultCheck{Valid: true}
+}
@@ -0,0 +1,79 @@
+package pure
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     CommandRequest
+		valid   bool
+		wantTTL time.Duration
+	}{
+		{"reboot takes default ttl", CommandRequest{Command: CommandReboot}, true, time.Hour},
+		{"renew certificate", CommandRequest{Command: CommandRenewCertificate, TTL: 10 * time.Minute}, true, 10 * time.Minute},
+		{"set interval", CommandRequest{Command: CommandSetSamplingInterval, IntervalSeconds: ptr(300)}, true, time.Hour},
+		{"set interval missing", CommandRequest{Command: CommandSetSamplingInterval}, false, 0},
+		{"set interval zero", CommandRequest{Command: CommandSetSamplingInterval, IntervalSeconds: ptr(0)}, false, 0},
+		{"set interval too long", CommandRequest{Command: CommandSetSamplingInterval, IntervalSeconds: ptr(MaxSamplingIntervalSeconds + 1)}, false, 0},
+		{"recalibrate all", CommandRequest{Command: CommandRecalibrate}, true, time.Hour},
+		{"recalibrate named", CommandRequest{Command: CommandRecalibrate, Sensors: []string{"pm25", "co2"}}, true, time.Hour},
+		{"recalibrate empty name", CommandRequest{Command: CommandRecalibrate, Sensors: []string{""}}, false, 0},
+		{"recalibrate too many", CommandRequest{Command: CommandRecalibrate, Sensors: make([]string, MaxCommandSensors+1)}, false, 0},
+		{"reboot with interval", CommandRequest{Command: CommandReboot, IntervalSeconds: ptr(60)}, false, 0},
+		{"reboot with sensors", CommandRequest{Command: CommandReboot, Sensors: []string{"pm25"}}, false, 0},
+		{"unknown command", CommandRequest{Command: "self_destruct"}, false, 0},
+		{"empty command", CommandRequest{}, false, 0},
+		{"ttl at limit", CommandRequest{Command: CommandReboot, TTL: 24 * time.Hour}, true, 24 * time.Hour},
+		{"ttl over limit", CommandRequest{Command: CommandReboot, TTL: 25 * time.Hour}, false, 0},
+		{"negative ttl", CommandRequest{Command: CommandReboot, TTL: -time.Minute}, false, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.req.DefaultTTL = time.Hour
+			tt.req.MaxTTL = 24 * time.Hour
+			got := ValidateCommand(tt.req)
+			if got.Valid != tt.valid {
+				t.Fatalf("Valid = %v, want %v (%s)", got.Valid, tt.valid, got.Reason)
+			}
+			if got.TTL != tt.wantTTL {
+				t.Errorf("TTL = %s, want %s", got.TTL, tt.wantTTL)
+			}
+		})
+	}
+}
+
+func TestValidateCommandNoDefaultTTL(t *testing.T) {
+	if got := ValidateCommand(CommandRequest{Command: CommandReboot}); got.Valid {
+		t.Error("command without ttl or default accepted")
+	}
+}
+
+func TestValidateCommandResult(t *testing.T) {
+	tests := []struct {
+		name   string
+		status string
+		errMsg string
+		valid  bool
+	}{
+		{"acknowledged", CommandStatusAcknowledged, "", true},
+		{"succeeded", CommandStatusSucceeded, "", true},
+		{"failed with error", CommandStatusFailed, "sensor busy", true},
+		{"failed without error", CommandStatusFailed, "", false},
+		{"sent", CommandStatusSent, "", false},
+		{"expired", CommandStatusExpired, "", false},
+		{"unknown", "done", "", false},
+		{"error too long", CommandStatusFailed, strings.Repeat("x", MaxCommandResultError+1), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ValidateCommandResult(tt.status, tt.errMsg)
+			if got.Valid != tt.valid {
+				t.Errorf("Valid = %v, want %v (%s)", got.Valid, tt.valid, got.Reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package pure
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Broker connection actions for a device presenting a chain-valid certificate.
+const (
+	ConnectAllow      = "allow"
+	ConnectRestricted = "restricted" // maintenance topics only: certificate renewal
+	ConnectDeny       = "deny"
+)
+
+// DeviceConnectionInput is the registry state and presented certificate at connect time.
+type DeviceConnectionInput struct {
+	DeviceStatus     string  // devices.status
+	RegisteredSerial *string // devices.cert_serial, hex; nil before a certificate is issued
+	PresentedSerial  string  // serial of the certificate on the TLS connection, hex
+}
+
+// DeviceConnectionResult says whether a device may open a broker session.
+type DeviceConnectionResult struct {
+	Action string // allow | restricted | deny
+	Reason string
+}
+
+// CheckDeviceConnection is a pure function: (device status, registered serial, presented serial) -> allow/restrict/deny.
+// Only the certificate most recently issued to the device is accepted, so one superseded by renewal
+// is refused even while it is still chain-valid. Suspended devices may connect to renew their
+// certificate but not to publish; revoked, pending and unknown devices are refused.
+func CheckDeviceConnection(input DeviceConnectionInput) DeviceConnectionResult {
+	if input.RegisteredSerial == nil || !sameSerial(*input.RegisteredSerial, input.PresentedSerial) {
+		return DeviceConnectionResult{Action: ConnectDeny, Reason: fmt.Sprintf("certificate %s is not the device's current certificate", input.PresentedSerial)}
+	}
+	switch input.DeviceStatus {
+	case "active":
+		return DeviceConnectionResult{Action: ConnectAllow}
+	case "suspended":
+		return DeviceConnectionResult{Action: ConnectRestricted, Reason: "device suspended"}
+	case "revoked":
+		return DeviceConnectionResult{Action: ConnectDeny, Reason: "device revoked"}
+	case "pending":
+		return DeviceConnectionResult{Action: ConnectDeny, Reason: "device not activated"}
+	default:
+		return DeviceConnectionResult{Action: ConnectDeny, Reason: "device status unknown"}
+	}
+}
+
+// sameSerial compares hex serials ignoring case and leading zeros.
+func sameSerial(a, b string) bool {
+	a = strings.TrimLeft(strings.ToLower(strings.TrimSpace(a)), "0")
+	b = strings.TrimLeft(strings.ToLower(strings.TrimSpace(b)), "0")
+	return a != "" && a == b
+}
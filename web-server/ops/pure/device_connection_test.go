@@ -0,0 +1,37 @@
+package pure
+
+import "testing"
+
+func TestCheckDeviceConnection(t *testing.T) {
+	serial := "1a2b3c"
+	tests := []struct {
+		name      string
+		status    string
+		serial    *string
+		presented string
+		want      string
+	}{
+		{"active, current cert", "active", &serial, "1a2b3c", ConnectAllow},
+		{"serial case and padding ignored", "active", &serial, "001A2B3C", ConnectAllow},
+		{"superseded cert", "active", &serial, "ffee", ConnectDeny},
+		{"no cert on record", "active", nil, "1a2b3c", ConnectDeny},
+		{"suspended", "suspended", &serial, "1a2b3c", ConnectRestricted},
+		{"revoked", "revoked", &serial, "1a2b3c", ConnectDeny},
+		{"pending", "pending", &serial, "1a2b3c", ConnectDeny},
+		{"unknown status", "mystery", &serial, "1a2b3c", ConnectDeny},
+	}
+	for _, tt := range tests {
+		got := CheckDeviceConnection(DeviceConnectionInput{DeviceStatus: tt.status, RegisteredSerial: tt.serial, PresentedSerial: tt.presented})
+		if got.Action != tt.want {
+			t.Errorf("%s: action = %q, want %q (%s)", tt.name, got.Action, tt.want, got.Reason)
+		}
+	}
+}
+
+func TestCheckDeviceConnection_EmptySerialNeverMatches(t *testing.T) {
+	empty := ""
+	got := CheckDeviceConnection(DeviceConnectionInput{DeviceStatus: "active", RegisteredSerial: &empty, PresentedSerial: ""})
+	if got.Action != ConnectDeny {
+		t.Errorf("action = %q, want deny for empty serials", got.Action)
+	}
+}
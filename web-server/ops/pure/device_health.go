@@ -0,0 +1,109 @@
+package pure
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Device health alerts raised to the owner. Each is raised once and stays active until
+// the condition clears.
+const (
+	HealthAlertLowBattery  = "low_battery"
+	HealthAlertSensorFault = "sensor_fault"
+	HealthAlertSilent      = "silent" // no heartbeat for the configured number of hours
+)
+
+// HealthBatteryHysteresis is how far above the low-battery threshold the battery must
+// recover before the alert clears, so a level hovering at the threshold does not
+// notify the owner on every heartbeat.
+const HealthBatteryHysteresis = 5.0
+
+// Limits on a health report's free-form fields.
+const (
+	MaxHealthSensorFaults = 32
+	MaxHealthFaultLength  = 64
+)
+
+// HealthReport is a device heartbeat. Every metric is optional; a device reports what it can.
+type HealthReport struct {
+	BatteryPercent  *float64
+	RSSIDbm         *int
+	UptimeSeconds   *int64
+	FreeMemoryBytes *int64
+	SensorFaults    []string // sensors currently failing, by name
+}
+
+// HealthReportResult is the outcome of validating a heartbeat.
+type HealthReportResult struct {
+	Valid  bool
+	Reason string
+}
+
+// HealthAlertInput is a heartbeat and the alerts already active for the device.
+type HealthAlertInput struct {
+	BatteryPercent    *float64
+	SensorFaults      []string
+	Active            []string // alerts currently raised
+	LowBatteryPercent float64  // threshold; 0 or less disables the battery alert
+}
+
+// HealthAlertResult lists alerts to raise (newly true) and to clear (no longer true).
+type HealthAlertResult struct {
+	Raise []string
+	Clear []string
+}
+
+// ValidateHealthReport is a pure function: heartbeat -> plausible?
+// Out-of-range metrics mean broken firmware, so the whole report is refused.
+func ValidateHealthReport(r HealthReport) HealthReportResult {
+	switch {
+	case r.BatteryPercent != nil && (*r.BatteryPercent < 0 || *r.BatteryPercent > 100):
+		return HealthReportResult{Reason: fmt.Sprintf("battery_percent %g outside 0-100", *r.BatteryPercent)}
+	case r.RSSIDbm != nil && (*r.RSSIDbm < -150 || *r.RSSIDbm > 0):
+		return HealthReportResult{Reason: fmt.Sprintf("rssi_dbm %d outside -150-0", *r.RSSIDbm)}
+	case r.UptimeSeconds != nil && *r.UptimeSeconds < 0:
+		return HealthReportResult{Reason: "uptime_seconds is negative"}
+	case r.FreeMemoryBytes != nil && *r.FreeMemoryBytes < 0:
+		return HealthReportResult{Reason: "free_memory_bytes is negative"}
+	case len(r.SensorFaults) > MaxHealthSensorFaults:
+		return HealthReportResult{Reason: fmt.Sprintf("%d sensor faults, limit %d", len(r.SensorFaults), MaxHealthSensorFaults)}
+	}
+	for _, f := range r.SensorFaults {
+		if f == "" || len(f) > MaxHealthFaultLength {
+			return HealthReportResult{Reason: fmt.Sprintf("sensor fault name must be 1-%d characters", MaxHealthFaultLength)}
+		}
+	}
+	return HealthReportResult{Valid: true}
+}
+
+// CheckHealthAlerts is a pure function: (heartbeat, active alerts, threshold) -> alerts to raise and clear.
+// A heartbeat always clears the silent alert. A report without a battery level leaves the
+// battery alert as it is.
+func CheckHealthAlerts(input HealthAlertInput) HealthAlertResult {
+	var result HealthAlertResult
+	active := func(alert string) bool { return slices.Contains(input.Active, alert) }
+
+	if active(HealthAlertSilent) {
+		result.Clear = append(result.Clear, HealthAlertSilent)
+	}
+
+	if input.BatteryPercent != nil {
+		low := input.LowBatteryPercent > 0 && *input.BatteryPercent < input.LowBatteryPercent
+		recovered := input.LowBatteryPercent <= 0 || *input.BatteryPercent >= input.LowBatteryPercent+HealthBatteryHysteresis
+		switch {
+		case low && !active(HealthAlertLowBattery):
+			result.Raise = append(result.Raise, HealthAlertLowBattery)
+		case recovered && active(HealthAlertLowBattery):
+			result.Clear = append(result.Clear, HealthAlertLowBattery)
+		}
+	}
+
+	faulty := len(input.SensorFaults) > 0
+	switch {
+	case faulty && !active(HealthAlertSensorFault):
+		result.Raise = append(result.Raise, HealthAlertSensorFault)
+	case !faulty && active(HealthAlertSensorFault):
+		result.Clear = append(result.Clear, HealthAlertSensorFault)
+	}
+	return result
+}
@@ -0,0 +1,65 @@
+package pure
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func ptr[T any](v T) *T { return &v }
+
+func TestValidateHealthReport(t *testing.T) {
+	tests := []struct {
+		name   string
+		report HealthReport
+		valid  bool
+	}{
+		{"empty", HealthReport{}, true},
+		{"typical", HealthReport{BatteryPercent: ptr(81.5), RSSIDbm: ptr(-67), UptimeSeconds: ptr(int64(3600)), FreeMemoryBytes: ptr(int64(40960))}, true},
+		{"battery over 100", HealthReport{BatteryPercent: ptr(104.0)}, false},
+		{"battery negative", HealthReport{BatteryPercent: ptr(-1.0)}, false},
+		{"positive rssi", HealthReport{RSSIDbm: ptr(12)}, false},
+		{"negative uptime", HealthReport{UptimeSeconds: ptr(int64(-5))}, false},
+		{"negative memory", HealthReport{FreeMemoryBytes: ptr(int64(-1))}, false},
+		{"named faults", HealthReport{SensorFaults: []string{"pm25", "humidity"}}, true},
+		{"empty fault name", HealthReport{SensorFaults: []string{""}}, false},
+		{"long fault name", HealthReport{SensorFaults: []string{strings.Repeat("x", MaxHealthFaultLength+1)}}, false},
+		{"too many faults", HealthReport{SensorFaults: make([]string, MaxHealthSensorFaults+1)}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ValidateHealthReport(tt.report)
+			if got.Valid != tt.valid {
+				t.Errorf("Valid = %v, want %v (%s)", got.Valid, tt.valid, got.Reason)
+			}
+		})
+	}
+}
+
+func TestCheckHealthAlerts(t *testing.T) {
+	tests := []struct {
+		name  string
+		input HealthAlertInput
+		raise []string
+		clear []string
+	}{
+		{"healthy", HealthAlertInput{BatteryPercent: ptr(90.0), LowBatteryPercent: 15}, nil, nil},
+		{"battery drops low", HealthAlertInput{BatteryPercent: ptr(12.0), LowBatteryPercent: 15}, []string{HealthAlertLowBattery}, nil},
+		{"still low, already raised", HealthAlertInput{BatteryPercent: ptr(10.0), LowBatteryPercent: 15, Active: []string{HealthAlertLowBattery}}, nil, nil},
+		{"within hysteresis", HealthAlertInput{BatteryPercent: ptr(17.0), LowBatteryPercent: 15, Active: []string{HealthAlertLowBattery}}, nil, nil},
+		{"recharged", HealthAlertInput{BatteryPercent: ptr(60.0), LowBatteryPercent: 15, Active: []string{HealthAlertLowBattery}}, nil, []string{HealthAlertLowBattery}},
+		{"no battery reading", HealthAlertInput{LowBatteryPercent: 15, Active: []string{HealthAlertLowBattery}}, nil, nil},
+		{"battery alert disabled", HealthAlertInput{BatteryPercent: ptr(3.0)}, nil, nil},
+		{"sensor fails", HealthAlertInput{SensorFaults: []string{"pm25"}}, []string{HealthAlertSensorFault}, nil},
+		{"sensor recovers", HealthAlertInput{Active: []string{HealthAlertSensorFault}}, nil, []string{HealthAlertSensorFault}},
+		{"heartbeat after silence", HealthAlertInput{Active: []string{HealthAlertSilent}}, nil, []string{HealthAlertSilent}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CheckHealthAlerts(tt.input)
+			if !slices.Equal(got.Raise, tt.raise) || !slices.Equal(got.Clear, tt.clear) {
+				t.Errorf("got raise=%v clear=%v, want raise=%v clear=%v", got.Raise, got.Clear, tt.raise, tt.clear)
+			}
+		})
+	}
+}
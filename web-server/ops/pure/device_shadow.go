@@ -0,0 +1,103 @@
+package pure
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Limits on a device shadow's list and text fields.
+const (
+	MaxShadowSensors        = 32
+	MaxShadowCampaigns      = 64
+	MaxShadowNameLength     = 64
+	MaxShadowFirmwareLength = 64
+)
+
+// ShadowState is one side of a device shadow, desired or reported. A nil field is not
+// set; an empty list is set to nothing.
+type ShadowState struct {
+	SamplingIntervalSeconds *int
+	EnabledSensors          []string
+	ActiveCampaigns         []string
+	FirmwareVersion         *string
+}
+
+// ShadowCheck is the outcome of validating a shadow state.
+type ShadowCheck struct {
+	Valid  bool
+	Reason string
+}
+
+// ValidateShadowState is a pure function: shadow state -> plausible?
+func ValidateShadowState(s ShadowState) ShadowCheck {
+	if s.SamplingIntervalSeconds != nil {
+		if v := *s.SamplingIntervalSeconds; v < MinSamplingIntervalSeconds || v > MaxSamplingIntervalSeconds {
+			return ShadowCheck{Reason: fmt.Sprintf("sampling_interval_seconds %d outside %d-%d", v, MinSamplingIntervalSeconds, MaxSamplingIntervalSeconds)}
+		}
+	}
+	if len(s.EnabledSensors) > MaxShadowSensors {
+		return ShadowCheck{Reason: fmt.Sprintf("%d enabled sensors, limit %d", len(s.EnabledSensors), MaxShadowSensors)}
+	}
+	if len(s.ActiveCampaigns) > MaxShadowCampaigns {
+		return ShadowCheck{Reason: fmt.Sprintf("%d active campaigns, limit %d", len(s.ActiveCampaigns), MaxShadowCampaigns)}
+	}
+	for _, names := range [][]string{s.EnabledSensors, s.ActiveCampaigns} {
+		for _, n := range names {
+			if n == "" || len(n) > MaxShadowNameLength {
+				return ShadowCheck{Reason: fmt.Sprintf("names must be 1-%d characters", MaxShadowNameLength)}
+			}
+		}
+	}
+	if s.FirmwareVersion != nil && (*s.FirmwareVersion == "" || len(*s.FirmwareVersion) > MaxShadowFirmwareLength) {
+		return ShadowCheck{Reason: fmt.Sprintf("firmware_version must be 1-%d characters", MaxShadowFirmwareLength)}
+	}
+	return ShadowCheck{Valid: true}
+}
+
+// NormalizeShadowState is a pure function: shadow state -> the same state with its lists
+// sorted and deduplicated, so equal states store and compare equal.
+func NormalizeShadowState(s ShadowState) ShadowState {
+	s.EnabledSensors = normalizeNames(s.EnabledSensors)
+	s.ActiveCampaigns = normalizeNames(s.ActiveCampaigns)
+	return s
+}
+
+// ShadowDelta is a pure function: (desired, reported) -> the desired fields the device has
+// not yet reported. An empty delta means the device has converged. Lists compare as sets.
+func ShadowDelta(desired, reported ShadowState) ShadowState {
+	var d ShadowState
+	if desired.SamplingIntervalSeconds != nil &&
+		(reported.SamplingIntervalSeconds == nil || *reported.SamplingIntervalSeconds != *desired.SamplingIntervalSeconds) {
+		d.SamplingIntervalSeconds = desired.SamplingIntervalSeconds
+	}
+	if desired.EnabledSensors != nil && !sameNames(desired.EnabledSensors, reported.EnabledSensors) {
+		d.EnabledSensors = desired.EnabledSensors
+	}
+	if desired.ActiveCampaigns != nil && !sameNames(desired.ActiveCampaigns, reported.ActiveCampaigns) {
+		d.ActiveCampaigns = desired.ActiveCampaigns
+	}
+	if desired.FirmwareVersion != nil &&
+		(reported.FirmwareVersion == nil || *reported.FirmwareVersion != *desired.FirmwareVersion) {
+		d.FirmwareVersion = desired.FirmwareVersion
+	}
+	return d
+}
+
+// IsEmpty reports whether no field of the state is set.
+func (s ShadowState) IsEmpty() bool {
+	return s.SamplingIntervalSeconds == nil && s.EnabledSensors == nil && s.ActiveCampaigns == nil && s.FirmwareVersion == nil
+}
+
+func normalizeNames(names []string) []string {
+	if names == nil {
+		return nil
+	}
+	out := slices.Clone(names)
+	slices.Sort(out)
+	return slices.Compact(out)
+}
+
+// sameNames compares a desired list with a reported one; a list never reported differs.
+func sameNames(want, got []string) bool {
+	return got != nil && slices.Equal(normalizeNames(want), normalizeNames(got))
+}
@@ -0,0 +1,79 @@
+package pure
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestValidateShadowState(t *testing.T) {
+	tests := []struct {
+		name  string
+		state ShadowState
+		valid bool
+	}{
+		{"empty", ShadowState{}, true},
+		{"typical", ShadowState{SamplingIntervalSeconds: ptr(60), EnabledSensors: []string{"pm25", "temp"}, FirmwareVersion: ptr("2.1.0")}, true},
+		{"no sensors enabled", ShadowState{EnabledSensors: []string{}}, true},
+		{"interval zero", ShadowState{SamplingIntervalSeconds: ptr(0)}, false},
+		{"interval too long", ShadowState{SamplingIntervalSeconds: ptr(MaxSamplingIntervalSeconds + 1)}, false},
+		{"empty sensor name", ShadowState{EnabledSensors: []string{""}}, false},
+		{"long campaign id", ShadowState{ActiveCampaigns: []string{strings.Repeat("c", MaxShadowNameLength+1)}}, false},
+		{"too many sensors", ShadowState{EnabledSensors: make([]string, MaxShadowSensors+1)}, false},
+		{"empty firmware", ShadowState{FirmwareVersion: ptr("")}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ValidateShadowState(tt.state)
+			if got.Valid != tt.valid {
+				t.Errorf("Valid = %v, want %v (%s)", got.Valid, tt.valid, got.Reason)
+			}
+		})
+	}
+}
+
+func TestNormalizeShadowState(t *testing.T) {
+	got := NormalizeShadowState(ShadowState{EnabledSensors: []string{"temp", "pm25", "temp"}, ActiveCampaigns: []string{}})
+	if !slices.Equal(got.EnabledSensors, []string{"pm25", "temp"}) {
+		t.Errorf("EnabledSensors = %v, want [pm25 temp]", got.EnabledSensors)
+	}
+	if got.ActiveCampaigns == nil || len(got.ActiveCampaigns) != 0 {
+		t.Errorf("ActiveCampaigns = %#v, want set and empty", got.ActiveCampaigns)
+	}
+	if NormalizeShadowState(ShadowState{}).EnabledSensors != nil {
+		t.Error("unset list became set")
+	}
+}
+
+func TestShadowDelta(t *testing.T) {
+	tests := []struct {
+		name     string
+		desired  ShadowState
+		reported ShadowState
+		want     ShadowState
+	}{
+		{"nothing desired", ShadowState{}, ShadowState{SamplingIntervalSeconds: ptr(60)}, ShadowState{}},
+		{"converged", ShadowState{SamplingIntervalSeconds: ptr(60), FirmwareVersion: ptr("2.0")}, ShadowState{SamplingIntervalSeconds: ptr(60), FirmwareVersion: ptr("2.0")}, ShadowState{}},
+		{"interval differs", ShadowState{SamplingIntervalSeconds: ptr(30)}, ShadowState{SamplingIntervalSeconds: ptr(60)}, ShadowState{SamplingIntervalSeconds: ptr(30)}},
+		{"interval never reported", ShadowState{SamplingIntervalSeconds: ptr(30)}, ShadowState{}, ShadowState{SamplingIntervalSeconds: ptr(30)}},
+		{"sensors same set in another order", ShadowState{EnabledSensors: []string{"a", "b"}}, ShadowState{EnabledSensors: []string{"b", "a"}}, ShadowState{}},
+		{"sensors differ", ShadowState{EnabledSensors: []string{"a"}}, ShadowState{EnabledSensors: []string{"a", "b"}}, ShadowState{EnabledSensors: []string{"a"}}},
+		{"no campaigns desired, none reported", ShadowState{ActiveCampaigns: []string{}}, ShadowState{}, ShadowState{ActiveCampaigns: []string{}}},
+		{"no campaigns desired, none running", ShadowState{ActiveCampaigns: []string{}}, ShadowState{ActiveCampaigns: []string{}}, ShadowState{}},
+		{"firmware differs", ShadowState{FirmwareVersion: ptr("2.1")}, ShadowState{FirmwareVersion: ptr("2.0")}, ShadowState{FirmwareVersion: ptr("2.1")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ShadowDelta(tt.desired, tt.reported)
+			if got.IsEmpty() != tt.want.IsEmpty() {
+				t.Fatalf("IsEmpty = %v, want %v (%+v)", got.IsEmpty(), tt.want.IsEmpty(), got)
+			}
+			if (got.SamplingIntervalSeconds == nil) != (tt.want.SamplingIntervalSeconds == nil) ||
+				(got.FirmwareVersion == nil) != (tt.want.FirmwareVersion == nil) ||
+				!slices.Equal(got.EnabledSensors, tt.want.EnabledSensors) ||
+				(got.ActiveCampaigns == nil) != (tt.want.ActiveCampaigns == nil) {
+				t.Errorf("ShadowDelta() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
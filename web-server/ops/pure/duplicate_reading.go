@@ -0,0 +1,78 @@
+package pure
+
+import (
+	"fmt"
+	"time"
+)
+
+// Duplicate kinds.
+const (
+	DuplicateNone  = ""
+	DuplicateExact = "exact" // same message ID, or same (device, campaign, timestamp) when no ID is sent
+	DuplicateNear  = "near"  // identical values within the near-duplicate window
+)
+
+// ReadingFingerprint is the identity of a reading for duplicate detection.
+// Candidates passed to CheckDuplicate are assumed to share the device and campaign.
+type ReadingFingerprint struct {
+	ID        string // "" for readings not yet persisted
+	MessageID string // device-supplied; "" if the device does not send one
+	Timestamp time.Time
+	Values    map[string]float64
+}
+
+// DuplicateResult says whether a reading repeats one already seen.
+type DuplicateResult struct {
+	Kind       string // DuplicateNone | DuplicateExact | DuplicateNear
+	MatchIndex int    // index into candidates; -1 when Kind is DuplicateNone
+	MatchID    string // ID of the matched reading; "" if it is not persisted yet
+	Reason     string
+}
+
+// CheckDuplicate is a pure function: (reading, earlier readings, window) -> exact/near/none.
+// When the reading carries a message ID only that ID decides an exact match; otherwise the
+// natural key (device, campaign, timestamp) does. Timestamps compare at the microsecond
+// precision Postgres stores. Exact matches take priority over near matches.
+func CheckDuplicate(reading ReadingFingerprint, candidates []ReadingFingerprint, nearWindow time.Duration) DuplicateResult {
+	ts := reading.Timestamp.Truncate(time.Microsecond)
+
+	for i, c := range candidates {
+		if reading.MessageID != "" {
+			if c.MessageID == reading.MessageID {
+				return DuplicateResult{Kind: DuplicateExact, MatchIndex: i, MatchID: c.ID, Reason: "message ID already ingested"}
+			}
+			continue
+		}
+		if c.Timestamp.Truncate(time.Microsecond).Equal(ts) {
+			return DuplicateResult{Kind: DuplicateExact, MatchIndex: i, MatchID: c.ID, Reason: "reading with same timestamp already ingested"}
+		}
+	}
+
+	for i, c := range candidates {
+		delta := c.Timestamp.Truncate(time.Microsecond).Sub(ts)
+		if delta < 0 {
+			delta = -delta
+		}
+		if delta <= nearWindow && sameValues(reading.Values, c.Values) {
+			reason := fmt.Sprintf("identical values %s from an earlier reading in the same upload", delta)
+			if c.ID != "" {
+				reason = fmt.Sprintf("identical values %s from reading %s", delta, c.ID)
+			}
+			return DuplicateResult{Kind: DuplicateNear, MatchIndex: i, MatchID: c.ID, Reason: reason}
+		}
+	}
+
+	return DuplicateResult{Kind: DuplicateNone, MatchIndex: -1}
+}
+
+func sameValues(a, b map[string]float64) bool {
+	if len(a) == 0 || len(a) != len(b) {
+		return false
+	}
+	for name, v := range a {
+		if other, ok := b[name]; !ok || other != v {
+			return false
+		}
+	}
+	return true
+}
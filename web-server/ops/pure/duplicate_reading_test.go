@@ -0,0 +1,67 @@
+package pure
+
+import (
+	"testing"
+	"time"
+)
+
+var dupBase = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func TestCheckDuplicateMessageID(t *testing.T) {
+	existing := []ReadingFingerprint{{ID: "r1", MessageID: "m-1", Timestamp: dupBase, Values: map[string]float64{"temp": 20}}}
+	result := CheckDuplicate(ReadingFingerprint{MessageID: "m-1", Timestamp: dupBase.Add(time.Hour), Values: map[string]float64{"temp": 25}}, existing, time.Second)
+	if result.Kind != DuplicateExact {
+		t.Errorf("kind = %q, want %q", result.Kind, DuplicateExact)
+	}
+	if result.MatchID != "r1" {
+		t.Errorf("match = %q, want r1", result.MatchID)
+	}
+}
+
+func TestCheckDuplicateDifferentMessageIDSameTimestamp(t *testing.T) {
+	existing := []ReadingFingerprint{{ID: "r1", MessageID: "m-1", Timestamp: dupBase, Values: map[string]float64{"temp": 20}}}
+	result := CheckDuplicate(ReadingFingerprint{MessageID: "m-2", Timestamp: dupBase, Values: map[string]float64{"temp": 21}}, existing, time.Second)
+	if result.Kind != DuplicateNone {
+		t.Errorf("kind = %q, want none", result.Kind)
+	}
+}
+
+func TestCheckDuplicateNaturalKey(t *testing.T) {
+	existing := []ReadingFingerprint{{ID: "r1", Timestamp: dupBase, Values: map[string]float64{"temp": 20}}}
+	// Postgres keeps microseconds; a retry carrying nanoseconds is still the same reading.
+	result := CheckDuplicate(ReadingFingerprint{Timestamp: dupBase.Add(300 * time.Nanosecond), Values: map[string]float64{"temp": 20}}, existing, time.Second)
+	if result.Kind != DuplicateExact {
+		t.Errorf("kind = %q, want %q", result.Kind, DuplicateExact)
+	}
+}
+
+func TestCheckDuplicateNearIdenticalValues(t *testing.T) {
+	existing := []ReadingFingerprint{{ID: "r1", Timestamp: dupBase, Values: map[string]float64{"temp": 20, "humidity": 41}}}
+	result := CheckDuplicate(ReadingFingerprint{Timestamp: dupBase.Add(500 * time.Millisecond), Values: map[string]float64{"temp": 20, "humidity": 41}}, existing, time.Second)
+	if result.Kind != DuplicateNear {
+		t.Errorf("kind = %q, want %q", result.Kind, DuplicateNear)
+	}
+}
+
+func TestCheckDuplicateNearDifferentValues(t *testing.T) {
+	existing := []ReadingFingerprint{{ID: "r1", Timestamp: dupBase, Values: map[string]float64{"temp": 20}}}
+	result := CheckDuplicate(ReadingFingerprint{Timestamp: dupBase.Add(500 * time.Millisecond), Values: map[string]float64{"temp": 20.1}}, existing, time.Second)
+	if result.Kind != DuplicateNone {
+		t.Errorf("kind = %q, want none", result.Kind)
+	}
+}
+
+func TestCheckDuplicateOutsideWindow(t *testing.T) {
+	existing := []ReadingFingerprint{{ID: "r1", Timestamp: dupBase, Values: map[string]float64{"temp": 20}}}
+	result := CheckDuplicate(ReadingFingerprint{Timestamp: dupBase.Add(5 * time.Second), Values: map[string]float64{"temp": 20}}, existing, time.Second)
+	if result.Kind != DuplicateNone {
+		t.Errorf("kind = %q, want none", result.Kind)
+	}
+}
+
+func TestCheckDuplicateNoCandidates(t *testing.T) {
+	result := CheckDuplicate(ReadingFingerprint{Timestamp: dupBase, Values: map[string]float64{"temp": 20}}, nil, time.Second)
+	if result.Kind != DuplicateNone {
+		t.Errorf("kind = %q, want none", result.Kind)
+	}
+}
@@ -0,0 +1,112 @@
+package pure
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"slices"
+)
+
+// Firmware install statuses. The server records notified when it publishes a manifest;
+// the device then reports downloading, installing, and installed or failed.
+const (
+	InstallStatusNotified    = "notified"
+	InstallStatusDownloading = "downloading"
+	InstallStatusInstalling  = "installing"
+	InstallStatusInstalled   = "installed"
+	InstallStatusFailed      = "failed"
+)
+
+// Limits on firmware releases and install reports.
+const (
+	MaxFirmwareVersionLength = 64
+	MaxFirmwareCohort        = 1000
+	MaxInstallError          = 512
+)
+
+// FirmwareRelease is a firmware build offered to one device class.
+type FirmwareRelease struct {
+	Class          string
+	Version        string
+	SHA256         string // hex digest of the image
+	URL            string
+	RolloutPercent int
+	Cohort         []string // device IDs offered the release whatever the percentage
+}
+
+// FirmwareReleaseCheck is the outcome of validating a firmware release or its rollout.
+type FirmwareReleaseCheck struct {
+	Valid  bool
+	Reason string
+}
+
+// ValidateFirmwareRelease is a pure function: release -> valid?
+// Images are fetched over HTTPS and verified against their SHA-256 by the device.
+func ValidateFirmwareRelease(r FirmwareRelease) FirmwareReleaseCheck {
+	if r.Class == "" {
+		return FirmwareReleaseCheck{Reason: "class is required"}
+	}
+	if r.Version == "" || len(r.Version) > MaxFirmwareVersionLength {
+		return FirmwareReleaseCheck{Reason: fmt.Sprintf("version must be 1-%d characters", MaxFirmwareVersionLength)}
+	}
+	if b, err := hex.DecodeString(r.SHA256); err != nil || len(b) != sha256.Size {
+		return FirmwareReleaseCheck{Reason: "sha256 must be 64 hex characters"}
+	}
+	if u, err := url.Parse(r.URL); err != nil || u.Scheme != "https" || u.Host == "" {
+		return FirmwareReleaseCheck{Reason: "url must be an https URL"}
+	}
+	return ValidateFirmwareRollout(r.RolloutPercent, r.Cohort)
+}
+
+// ValidateFirmwareRollout is a pure function: rollout stage -> valid?
+func ValidateFirmwareRollout(percent int, cohort []string) FirmwareReleaseCheck {
+	if percent < 0 || percent > 100 {
+		return FirmwareReleaseCheck{Reason: fmt.Sprintf("rollout_percent %d outside 0-100", percent)}
+	}
+	if len(cohort) > MaxFirmwareCohort {
+		return FirmwareReleaseCheck{Reason: fmt.Sprintf("%d cohort devices, limit %d", len(cohort), MaxFirmwareCohort)}
+	}
+	if slices.Contains(cohort, "") {
+		return FirmwareReleaseCheck{Reason: "cohort device ID is empty"}
+	}
+	return FirmwareReleaseCheck{Valid: true}
+}
+
+// RolloutBucket is a pure function: (release, device) -> 0-99. Each release spreads its
+// devices evenly over the buckets, and a device keeps its bucket as the rollout widens,
+// so a device offered a release at 10% is still offered it at 50%.
+func RolloutBucket(releaseID, deviceID string) int {
+	sum := sha256.Sum256([]byte(releaseID + "/" + deviceID))
+	return int(binary.BigEndian.Uint64(sum[:8]) % 100)
+}
+
+// InRollout is a pure function: (release stage, device) -> offered the release?
+func InRollout(releaseID string, percent int, cohort []string, deviceID string) bool {
+	return slices.Contains(cohort, deviceID) || RolloutBucket(releaseID, deviceID) < percent
+}
+
+// InstallReportCheck is the outcome of validating a device's install report.
+type InstallReportCheck struct {
+	Valid  bool
+	Reason string
+}
+
+// ValidateInstallReport is a pure function: device install report -> acceptable?
+// A device reports downloading, installing, installed or failed; a failure must say why.
+func ValidateInstallReport(status, errMsg string) InstallReportCheck {
+	switch status {
+	case InstallStatusDownloading, InstallStatusInstalling, InstallStatusInstalled:
+	case InstallStatusFailed:
+		if errMsg == "" {
+			return InstallReportCheck{Reason: "failed requires error"}
+		}
+	default:
+		return InstallReportCheck{Reason: fmt.Sprintf("status %q is not reported by devices", status)}
+	}
+	if len(errMsg) > MaxInstallError {
+		return InstallReportCheck{Reason: fmt.Sprintf("error longer than %d characters", MaxInstallError)}
+	}
+	return InstallReportCheck{Valid: true}
+}
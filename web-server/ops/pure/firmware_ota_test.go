@@ -0,0 +1,115 @@
+package pure
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestValidateFirmwareRelease(t *testing.T) {
+	valid := FirmwareRelease{
+		Class:   "air-quality",
+		Version: "2.1.0",
+		SHA256:  strings.Repeat("ab", 32),
+		URL:     "https://firmware.example.org/aq-2.1.0.bin",
+	}
+	tests := []struct {
+		name   string
+		modify func(r *FirmwareRelease)
+		valid  bool
+	}{
+		{"valid", func(r *FirmwareRelease) {}, true},
+		{"staged with cohort", func(r *FirmwareRelease) { r.RolloutPercent = 10; r.Cohort = []string{"dev-1"} }, true},
+		{"full rollout", func(r *FirmwareRelease) { r.RolloutPercent = 100 }, true},
+		{"no class", func(r *FirmwareRelease) { r.Class = "" }, false},
+		{"no version", func(r *FirmwareRelease) { r.Version = "" }, false},
+		{"version too long", func(r *FirmwareRelease) { r.Version = strings.Repeat("1", MaxFirmwareVersionLength+1) }, false},
+		{"short digest", func(r *FirmwareRelease) { r.SHA256 = "abcd" }, false},
+		{"digest not hex", func(r *FirmwareRelease) { r.SHA256 = strings.Repeat("zz", 32) }, false},
+		{"plain http", func(r *FirmwareRelease) { r.URL = "http://firmware.example.org/aq.bin" }, false},
+		{"no host", func(r *FirmwareRelease) { r.URL = "https:///aq.bin" }, false},
+		{"negative percent", func(r *FirmwareRelease) { r.RolloutPercent = -1 }, false},
+		{"percent over 100", func(r *FirmwareRelease) { r.RolloutPercent = 101 }, false},
+		{"empty cohort ID", func(r *FirmwareRelease) { r.Cohort = []string{""} }, false},
+		{"cohort too large", func(r *FirmwareRelease) { r.Cohort = make([]string, MaxFirmwareCohort+1) }, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := valid
+			tt.modify(&r)
+			got := ValidateFirmwareRelease(r)
+			if got.Valid != tt.valid {
+				t.Errorf("Valid = %v, want %v (%s)", got.Valid, tt.valid, got.Reason)
+			}
+		})
+	}
+}
+
+func TestRolloutBucket(t *testing.T) {
+	counts := make([]int, 100)
+	for i := range 10000 {
+		b := RolloutBucket("release-1", fmt.Sprintf("device-%d", i))
+		if b < 0 || b > 99 {
+			t.Fatalf("bucket %d outside 0-99", b)
+		}
+		counts[b]++
+	}
+	for b, n := range counts {
+		if n < 50 || n > 150 {
+			t.Errorf("bucket %d has %d of 10000 devices, want about 100", b, n)
+		}
+	}
+	if RolloutBucket("release-1", "device-1") != RolloutBucket("release-1", "device-1") {
+		t.Error("bucket should be stable")
+	}
+}
+
+func TestInRollout(t *testing.T) {
+	bucket := RolloutBucket("release-1", "device-1")
+	tests := []struct {
+		name    string
+		percent int
+		cohort  []string
+		want    bool
+	}{
+		{"stage off", 0, nil, false},
+		{"full rollout", 100, nil, true},
+		{"below bucket", bucket, nil, false},
+		{"above bucket", bucket + 1, nil, true},
+		{"in cohort", 0, []string{"device-1"}, true},
+		{"other cohort", 0, []string{"device-2"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := InRollout("release-1", tt.percent, tt.cohort, "device-1"); got != tt.want {
+				t.Errorf("InRollout(%d%%, %v) = %v, want %v (bucket %d)", tt.percent, tt.cohort, got, tt.want, bucket)
+			}
+		})
+	}
+}
+
+func TestValidateInstallReport(t *testing.T) {
+	tests := []struct {
+		name   string
+		status string
+		errMsg string
+		valid  bool
+	}{
+		{"downloading", InstallStatusDownloading, "", true},
+		{"installing", InstallStatusInstalling, "", true},
+		{"installed", InstallStatusInstalled, "", true},
+		{"failed with error", InstallStatusFailed, "checksum mismatch", true},
+		{"failed without error", InstallStatusFailed, "", false},
+		{"notified", InstallStatusNotified, "", false},
+		{"unknown", "done", "", false},
+		{"error too long", InstallStatusFailed, strings.Repeat("x", MaxInstallError+1), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ValidateInstallReport(tt.status, tt.errMsg)
+			if got.Valid != tt.valid {
+				t.Errorf("Valid = %v, want %v (%s)", got.Valid, tt.valid, got.Reason)
+			}
+		})
+	}
+}
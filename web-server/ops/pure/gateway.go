@@ -0,0 +1,33 @@
+package pure
+
+// CertKindGateway marks a gateway's certificate. It is recorded as the certificate's
+// subject OU, alongside the gateway's device ID as CN.
+const CertKindGateway = "gateway"
+
+// SuspendedTopicAllowed is a pure function: device subtopic -> usable by a suspended device?
+// A suspended device may renew its certificate and install the firmware that reinstates it.
+func SuspendedTopicAllowed(subtopic string) bool {
+	switch subtopic {
+	case "renew", "cert", "ota", "ota/status":
+		return true
+	}
+	return false
+}
+
+// GatewayChildTopicAllowed is a pure function: (child status, subtopic) -> may the child's
+// gateway use the subtopic on its behalf? A gateway relays for active children as they
+// would themselves, and for suspended children only as far as SuspendedTopicAllowed.
+// It never relays renew or cert: a certificate is only issued for a key the child holds,
+// so a child renews over its own session.
+func GatewayChildTopicAllowed(childStatus, subtopic string) bool {
+	if subtopic == "renew" || subtopic == "cert" {
+		return false
+	}
+	switch childStatus {
+	case "active":
+		return true
+	case "suspended":
+		return SuspendedTopicAllowed(subtopic)
+	}
+	return false
+}
@@ -0,0 +1,30 @@
+package pure
+
+import "testing"
+
+func TestGatewayChildTopicAllowed(t *testing.T) {
+	tests := []struct {
+		status   string
+		subtopic string
+		want     bool
+	}{
+		{"active", "data", true},
+		{"active", "renew", false},
+		{"active", "cert", false},
+		{"suspended", "data", false},
+		{"suspended", "renew", false},
+		{"suspended", "cert", false},
+		{"suspended", "ota", true},
+		{"suspended", "ota/status", true},
+		{"suspended", "shadow/update", false},
+		{"revoked", "renew", false},
+		{"pending", "data", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.status+" "+tt.subtopic, func(t *testing.T) {
+			if got := GatewayChildTopicAllowed(tt.status, tt.subtopic); got != tt.want {
+				t.Errorf("GatewayChildTopicAllowed(%q, %q) = %v, want %v", tt.status, tt.subtopic, got, tt.want)
+			}
+		})
+	}
+}
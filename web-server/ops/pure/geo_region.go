@@ -0,0 +1,287 @@
+package pure
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+const earthRadiusMeters = 6371008.8
+
+// GeoPolygon is a polygon as GeoJSON rings: ring 0 is the outer boundary, the rest are holes.
+type GeoPolygon [][]GeoPoint
+
+// GeoRegion is a parsed campaign region. Polygons cover an area; Points are sites whose
+// region is the buffer distance around them.
+type GeoRegion struct {
+	Polygons []GeoPolygon
+	Points   []GeoPoint
+}
+
+// RegionCheckResult is the outcome of checking a location against campaign regions.
+type RegionCheckResult struct {
+	Inside         bool
+	DistanceMeters float64 // distance to the nearest region; 0 when strictly inside one
+}
+
+// LocationDriftResult is the outcome of comparing a reported location with a deployment point.
+type LocationDriftResult struct {
+	Drifted        bool
+	DistanceMeters float64
+}
+
+type geoJSON struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+	Geometry    *geoJSON        `json:"geometry"`
+	Features    []geoJSON       `json:"features"`
+	Geometries  []geoJSON       `json:"geometries"`
+}
+
+// ParseGeoPoint is a pure function: GeoJSON Point (bare or as a Feature) -> GeoPoint.
+func ParseGeoPoint(raw string) (*GeoPoint, error) {
+	var g geoJSON
+	if err := json.Unmarshal([]byte(raw), &g); err != nil {
+		return nil, fmt.Errorf("parse geolocation: %w", err)
+	}
+	if g.Type == "Feature" && g.Geometry != nil {
+		g = *g.Geometry
+	}
+	if g.Type != "Point" {
+		return nil, fmt.Errorf("geolocation is a %q, want Point", g.Type)
+	}
+	var c []float64
+	if err := json.Unmarshal(g.Coordinates, &c); err != nil {
+		return nil, fmt.Errorf("parse point coordinates: %w", err)
+	}
+	p, err := toGeoPoint(c)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// ParseGeoRegion is a pure function: campaign region GeoJSON -> GeoRegion.
+// Accepts Polygon, MultiPolygon and Point geometries, and Features, FeatureCollections
+// and GeometryCollections of them.
+func ParseGeoRegion(raw string) (GeoRegion, error) {
+	var g geoJSON
+	if err := json.Unmarshal([]byte(raw), &g); err != nil {
+		return GeoRegion{}, fmt.Errorf("parse region: %w", err)
+	}
+	var region GeoRegion
+	if err := addGeometry(&region, g); err != nil {
+		return GeoRegion{}, err
+	}
+	if len(region.Polygons) == 0 && len(region.Points) == 0 {
+		return GeoRegion{}, fmt.Errorf("region has no geometry")
+	}
+	return region, nil
+}
+
+func addGeometry(region *GeoRegion, g geoJSON) error {
+	switch g.Type {
+	case "Feature":
+		if g.Geometry == nil {
+			return nil
+		}
+		return addGeometry(region, *g.Geometry)
+	case "FeatureCollection":
+		for _, f := range g.Features {
+			if err := addGeometry(region, f); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "GeometryCollection":
+		for _, sub := range g.Geometries {
+			if err := addGeometry(region, sub); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "Point":
+		var c []float64
+		if err := json.Unmarshal(g.Coordinates, &c); err != nil {
+			return fmt.Errorf("parse point coordinates: %w", err)
+		}
+		p, err := toGeoPoint(c)
+		if err != nil {
+			return err
+		}
+		region.Points = append(region.Points, p)
+		return nil
+	case "Polygon":
+		var rings [][][]float64
+		if err := json.Unmarshal(g.Coordinates, &rings); err != nil {
+			return fmt.Errorf("parse polygon coordinates: %w", err)
+		}
+		poly, err := toGeoPolygon(rings)
+		if err != nil {
+			return err
+		}
+		region.Polygons = append(region.Polygons, poly)
+		return nil
+	case "MultiPolygon":
+		var polys [][][][]float64
+		if err := json.Unmarshal(g.Coordinates, &polys); err != nil {
+			return fmt.Errorf("parse multipolygon coordinates: %w", err)
+		}
+		for _, rings := range polys {
+			poly, err := toGeoPolygon(rings)
+			if err != nil {
+				return err
+			}
+			region.Polygons = append(region.Polygons, poly)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported region geometry %q", g.Type)
+	}
+}
+
+func toGeoPoint(c []float64) (GeoPoint, error) {
+	if len(c) < 2 {
+		return GeoPoint{}, fmt.Errorf("position needs longitude and latitude")
+	}
+	p := GeoPoint{Longitude: c[0], Latitude: c[1]}
+	if p.Longitude < -180 || p.Longitude > 180 || p.Latitude < -90 || p.Latitude > 90 {
+		return GeoPoint{}, fmt.Errorf("position %v out of range", c[:2])
+	}
+	return p, nil
+}
+
+func toGeoPolygon(rings [][][]float64) (GeoPolygon, error) {
+	if len(rings) == 0 {
+		return nil, fmt.Errorf("polygon has no rings")
+	}
+	poly := make(GeoPolygon, len(rings))
+	for i, ring := range rings {
+		if len(ring) < 4 {
+			return nil, fmt.Errorf("polygon ring needs at least 4 positions, got %d", len(ring))
+		}
+		poly[i] = make([]GeoPoint, len(ring))
+		for j, c := range ring {
+			p, err := toGeoPoint(c)
+			if err != nil {
+				return nil, err
+			}
+			poly[i][j] = p
+		}
+	}
+	return poly, nil
+}
+
+// CheckRegions is a pure function: (location, regions, buffer) -> inside/outside + distance.
+// A location is inside when it lies within any polygon (outside its holes), or within
+// bufferMeters of a polygon edge or region point. No regions means no constraint.
+func CheckRegions(p GeoPoint, regions []GeoRegion, bufferMeters float64) RegionCheckResult {
+	if len(regions) == 0 {
+		return RegionCheckResult{Inside: true}
+	}
+
+	nearest := math.Inf(1)
+	for _, region := range regions {
+		for _, poly := range region.Polygons {
+			if polygonContains(poly, p) {
+				return RegionCheckResult{Inside: true}
+			}
+			for _, ring := range poly {
+				if d := distanceToRing(p, ring); d < nearest {
+					nearest = d
+				}
+			}
+		}
+		for _, site := range region.Points {
+			if d := DistanceMeters(p, site); d < nearest {
+				nearest = d
+			}
+		}
+	}
+	return RegionCheckResult{Inside: nearest <= bufferMeters, DistanceMeters: nearest}
+}
+
+// CheckLocationDrift is a pure function: (reported, deployment point, max drift) -> drifted?
+// A maxDriftMeters of zero or less disables the check.
+func CheckLocationDrift(reported, deployed GeoPoint, maxDriftMeters float64) LocationDriftResult {
+	d := DistanceMeters(reported, deployed)
+	return LocationDriftResult{Drifted: maxDriftMeters > 0 && d > maxDriftMeters, DistanceMeters: d}
+}
+
+// DistanceMeters is the great-circle (haversine) distance between two points.
+func DistanceMeters(a, b GeoPoint) float64 {
+	lat1, lat2 := radians(a.Latitude), radians(b.Latitude)
+	dLat := lat2 - lat1
+	dLon := radians(b.Longitude - a.Longitude)
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusMeters * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// polygonContains reports whether p is inside the outer ring and outside every hole.
+func polygonContains(poly GeoPolygon, p GeoPoint) bool {
+	if !ringContains(poly[0], p) {
+		return false
+	}
+	for _, hole := range poly[1:] {
+		if ringContains(hole, p) {
+			return false
+		}
+	}
+	return true
+}
+
+// ringContains is an even-odd ray cast in lon/lat space.
+func ringContains(ring []GeoPoint, p GeoPoint) bool {
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		a, b := ring[i], ring[j]
+		if (a.Latitude > p.Latitude) != (b.Latitude > p.Latitude) {
+			x := (b.Longitude-a.Longitude)*(p.Latitude-a.Latitude)/(b.Latitude-a.Latitude) + a.Longitude
+			if p.Longitude < x {
+				inside = !inside
+			}
+		}
+	}
+	return inside
+}
+
+// distanceToRing is the distance from p to the nearest ring edge, measured on a local
+// equirectangular projection centred on p (accurate for buffer-sized distances).
+func distanceToRing(p GeoPoint, ring []GeoPoint) float64 {
+	nearest := math.Inf(1)
+	for i := 1; i < len(ring); i++ {
+		ax, ay := project(p, ring[i-1])
+		bx, by := project(p, ring[i])
+		if d := distanceToSegment(ax, ay, bx, by); d < nearest {
+			nearest = d
+		}
+	}
+	return nearest
+}
+
+// project maps q to metres east/north of origin.
+func project(origin, q GeoPoint) (float64, float64) {
+	dLon := q.Longitude - origin.Longitude
+	if dLon > 180 {
+		dLon -= 360
+	} else if dLon < -180 {
+		dLon += 360
+	}
+	x := radians(dLon) * math.Cos(radians(origin.Latitude)) * earthRadiusMeters
+	y := radians(q.Latitude-origin.Latitude) * earthRadiusMeters
+	return x, y
+}
+
+// distanceToSegment is the distance from the origin to segment a-b.
+func distanceToSegment(ax, ay, bx, by float64) float64 {
+	dx, dy := bx-ax, by-ay
+	t := 0.0
+	if l2 := dx*dx + dy*dy; l2 > 0 {
+		t = math.Max(0, math.Min(1, -(ax*dx+ay*dy)/l2))
+	}
+	return math.Hypot(ax+t*dx, ay+t*dy)
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
@@ -0,0 +1,189 @@
+package pure
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// Roughly Manhattan between 40.7N and 40.8N.
+const squareRegion = `{"type":"Polygon","coordinates":[[[-74.0,40.7],[-73.9,40.7],[-73.9,40.8],[-74.0,40.8],[-74.0,40.7]]]}`
+
+func mustRegion(t *testing.T, raw string) GeoRegion {
+	t.Helper()
+	r, err := ParseGeoRegion(raw)
+	if err != nil {
+		t.Fatalf("parse region: %v", err)
+	}
+	return r
+}
+
+func TestParseGeoPoint(t *testing.T) {
+	p, err := ParseGeoPoint(`{"type":"Point","coordinates":[-73.98,40.74]}`)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if p.Longitude != -73.98 || p.Latitude != 40.74 {
+		t.Errorf("point = %+v", p)
+	}
+
+	p, err = ParseGeoPoint(`{"type":"Feature","geometry":{"type":"Point","coordinates":[1,2]}}`)
+	if err != nil || p.Latitude != 2 {
+		t.Errorf("feature point = %+v, %v", p, err)
+	}
+}
+
+func TestParseGeoPointRejectsBadInput(t *testing.T) {
+	for _, raw := range []string{
+		`not json`,
+		`{"type":"Polygon","coordinates":[]}`,
+		`{"type":"Point","coordinates":[10]}`,
+		`{"type":"Point","coordinates":[200,10]}`,
+	} {
+		if _, err := ParseGeoPoint(raw); err == nil {
+			t.Errorf("expected error for %s", raw)
+		}
+	}
+}
+
+func TestParseGeoRegionTypes(t *testing.T) {
+	multi := mustRegion(t, `{"type":"MultiPolygon","coordinates":[
+		[[[0,0],[1,0],[1,1],[0,1],[0,0]]],
+		[[[5,5],[6,5],[6,6],[5,6],[5,5]]]]}`)
+	if len(multi.Polygons) != 2 {
+		t.Errorf("multipolygon polygons = %d, want 2", len(multi.Polygons))
+	}
+
+	fc := mustRegion(t, `{"type":"FeatureCollection","features":[
+		{"type":"Feature","geometry":`+squareRegion+`},
+		{"type":"Feature","geometry":{"type":"Point","coordinates":[-73.98,40.74]}}]}`)
+	if len(fc.Polygons) != 1 || len(fc.Points) != 1 {
+		t.Errorf("feature collection = %d polygons, %d points", len(fc.Polygons), len(fc.Points))
+	}
+
+	if _, err := ParseGeoRegion(`{"type":"LineString","coordinates":[[0,0],[1,1]]}`); err == nil {
+		t.Error("expected error for unsupported geometry")
+	}
+	if _, err := ParseGeoRegion(`{"type":"Polygon","coordinates":[[[0,0],[1,0],[0,0]]]}`); err == nil {
+		t.Error("expected error for degenerate ring")
+	}
+}
+
+func TestCheckRegionsInsidePolygon(t *testing.T) {
+	result := CheckRegions(GeoPoint{Longitude: -73.95, Latitude: 40.75}, []GeoRegion{mustRegion(t, squareRegion)}, 0)
+	if !result.Inside || result.DistanceMeters != 0 {
+		t.Errorf("result = %+v, want inside", result)
+	}
+}
+
+func TestCheckRegionsOutsidePolygon(t *testing.T) {
+	// ~0.01 degrees of longitude east of the boundary at 40.75N is about 840 m.
+	result := CheckRegions(GeoPoint{Longitude: -73.89, Latitude: 40.75}, []GeoRegion{mustRegion(t, squareRegion)}, 0)
+	if result.Inside {
+		t.Fatal("expected outside")
+	}
+	if math.Abs(result.DistanceMeters-843) > 10 {
+		t.Errorf("distance = %.0f m, want about 843", result.DistanceMeters)
+	}
+}
+
+func TestCheckRegionsBuffer(t *testing.T) {
+	regions := []GeoRegion{mustRegion(t, squareRegion)}
+	p := GeoPoint{Longitude: -73.89, Latitude: 40.75}
+	if !CheckRegions(p, regions, 1000).Inside {
+		t.Error("expected inside a 1000 m buffer")
+	}
+	if CheckRegions(p, regions, 500).Inside {
+		t.Error("expected outside a 500 m buffer")
+	}
+}
+
+func TestCheckRegionsHole(t *testing.T) {
+	donut := mustRegion(t, `{"type":"Polygon","coordinates":[
+		[[0,0],[10,0],[10,10],[0,10],[0,0]],
+		[[4,4],[6,4],[6,6],[4,6],[4,4]]]}`)
+	if CheckRegions(GeoPoint{Longitude: 5, Latitude: 5}, []GeoRegion{donut}, 0).Inside {
+		t.Error("point in hole should be outside")
+	}
+	if !CheckRegions(GeoPoint{Longitude: 2, Latitude: 2}, []GeoRegion{donut}, 0).Inside {
+		t.Error("point in ring should be inside")
+	}
+}
+
+func TestCheckRegionsMultiPolygonAndPointSite(t *testing.T) {
+	regions := []GeoRegion{
+		mustRegion(t, `{"type":"MultiPolygon","coordinates":[
+			[[[0,0],[1,0],[1,1],[0,1],[0,0]]],
+			[[[5,5],[6,5],[6,6],[5,6],[5,5]]]]}`),
+		mustRegion(t, `{"type":"Point","coordinates":[20,20]}`),
+	}
+	if !CheckRegions(GeoPoint{Longitude: 5.5, Latitude: 5.5}, regions, 0).Inside {
+		t.Error("expected inside second polygon")
+	}
+	if CheckRegions(GeoPoint{Longitude: 3, Latitude: 3}, regions, 0).Inside {
+		t.Error("expected outside between polygons")
+	}
+	if !CheckRegions(GeoPoint{Longitude: 20.001, Latitude: 20}, regions, 200).Inside {
+		t.Error("expected inside point site buffer")
+	}
+}
+
+func TestCheckRegionsNoRegions(t *testing.T) {
+	if !CheckRegions(GeoPoint{Longitude: 100, Latitude: -45}, nil, 0).Inside {
+		t.Error("no regions should not constrain location")
+	}
+}
+
+func TestCheckLocationDrift(t *testing.T) {
+	deployed := GeoPoint{Longitude: -73.95, Latitude: 40.75}
+	near := GeoPoint{Longitude: -73.9505, Latitude: 40.75}
+	far := GeoPoint{Longitude: -73.93, Latitude: 40.75}
+
+	if r := CheckLocationDrift(near, deployed, 100); r.Drifted {
+		t.Errorf("near point drifted: %+v", r)
+	}
+	if r := CheckLocationDrift(far, deployed, 100); !r.Drifted {
+		t.Errorf("far point not drifted: %+v", r)
+	}
+	if r := CheckLocationDrift(far, deployed, 0); r.Drifted {
+		t.Error("zero max drift should disable the check")
+	}
+}
+
+func TestDistanceMeters(t *testing.T) {
+	// One degree of latitude is about 111.2 km.
+	d := DistanceMeters(GeoPoint{Latitude: 0}, GeoPoint{Latitude: 1})
+	if math.Abs(d-111195) > 50 {
+		t.Errorf("distance = %.0f, want about 111195", d)
+	}
+}
+
+func TestValidateReadingOutsideRegion(t *testing.T) {
+	rules := ValidationRules{Regions: []GeoRegion{mustRegion(t, squareRegion)}, RegionBufferMeters: 100}
+
+	outside := ValidateReading(ReadingInput{
+		Values:      map[string]float64{"temp": 20},
+		Timestamp:   time.Now().UTC(),
+		Geolocation: &GeoPoint{Longitude: -73.89, Latitude: 40.75},
+	}, rules)
+	if outside.Valid || outside.Code != FeedbackOutsideRegion {
+		t.Errorf("result = %+v, want outside_region", outside)
+	}
+	if len(outside.PerParameter) != 0 {
+		t.Error("outside-region result should apply to the whole reading")
+	}
+
+	inside := ValidateReading(ReadingInput{
+		Values:      map[string]float64{"temp": 20},
+		Timestamp:   time.Now().UTC(),
+		Geolocation: &GeoPoint{Longitude: -73.95, Latitude: 40.75},
+	}, rules)
+	if !inside.Valid {
+		t.Errorf("expected valid inside region, got %s", inside.Reason)
+	}
+
+	noLocation := ValidateReading(ReadingInput{Values: map[string]float64{"temp": 20}, Timestamp: time.Now().UTC()}, rules)
+	if !noLocation.Valid {
+		t.Errorf("expected valid without location, got %s", noLocation.Reason)
+	}
+}
@@ -0,0 +1,56 @@
+package pure
+
+// Gate checks that can fail during ingestion.
+const (
+	GateCheckDeviceStatus = "device_status"
+	GateCheckEnrollment   = "enrollment"
+)
+
+// Gate actions.
+const (
+	GateAccept     = "accept"
+	GateQuarantine = "quarantine"
+	GateReject     = "reject"
+)
+
+// IngestionGateInput is the device and enrollment state at ingest time.
+type IngestionGateInput struct {
+	DeviceStatus     string // devices.status
+	EnrollmentStatus string // campaign_enrollments.status; "" if the device is not enrolled
+}
+
+// IngestionGateResult says whether a reading may proceed to validation.
+type IngestionGateResult struct {
+	Action string // accept | quarantine | reject
+	Check  string // which check failed; "" on accept
+	Reason string
+	Code   string // feedback code, see FeedbackFor
+}
+
+// CheckIngestionGate is a pure function: (device status, enrollment status) -> accept/quarantine/reject.
+// Suspended devices are quarantined so their data is kept for the security review;
+// every other failure is rejected outright and never persisted.
+func CheckIngestionGate(input IngestionGateInput) IngestionGateResult {
+	switch input.DeviceStatus {
+	case "active":
+	case "suspended":
+		return IngestionGateResult{Action: GateQuarantine, Check: GateCheckDeviceStatus, Reason: "device suspended", Code: FeedbackDeviceSuspended}
+	case "revoked":
+		return IngestionGateResult{Action: GateReject, Check: GateCheckDeviceStatus, Reason: "device revoked", Code: FeedbackDeviceInactive}
+	case "pending":
+		return IngestionGateResult{Action: GateReject, Check: GateCheckDeviceStatus, Reason: "device not activated", Code: FeedbackDeviceInactive}
+	default:
+		return IngestionGateResult{Action: GateReject, Check: GateCheckDeviceStatus, Reason: "device status unknown", Code: FeedbackDeviceInactive}
+	}
+
+	switch input.EnrollmentStatus {
+	case "active":
+		return IngestionGateResult{Action: GateAccept, Code: FeedbackAccepted}
+	case "":
+		return IngestionGateResult{Action: GateReject, Check: GateCheckEnrollment, Reason: "device not enrolled in campaign", Code: FeedbackNotEnrolled}
+	case "withdrawn":
+		return IngestionGateResult{Action: GateReject, Check: GateCheckEnrollment, Reason: "enrollment withdrawn", Code: FeedbackEnrollmentWithdrawn}
+	default:
+		return IngestionGateResult{Action: GateReject, Check: GateCheckEnrollment, Reason: "enrollment not active", Code: FeedbackNotEnrolled}
+	}
+}
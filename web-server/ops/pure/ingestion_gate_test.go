@@ -0,0 +1,70 @@
+package pure
+
+import "testing"
+
+func TestCheckIngestionGateActiveEnrolled(t *testing.T) {
+	result := CheckIngestionGate(IngestionGateInput{DeviceStatus: "active", EnrollmentStatus: "active"})
+	if result.Action != GateAccept {
+		t.Errorf("action = %q, want %q (%s)", result.Action, GateAccept, result.Reason)
+	}
+	if result.Check != "" {
+		t.Errorf("check = %q, want empty", result.Check)
+	}
+}
+
+func TestCheckIngestionGateSuspendedQuarantines(t *testing.T) {
+	result := CheckIngestionGate(IngestionGateInput{DeviceStatus: "suspended", EnrollmentStatus: "active"})
+	if result.Action != GateQuarantine {
+		t.Errorf("action = %q, want %q", result.Action, GateQuarantine)
+	}
+	if result.Check != GateCheckDeviceStatus {
+		t.Errorf("check = %q, want %q", result.Check, GateCheckDeviceStatus)
+	}
+}
+
+func TestCheckIngestionGateRevokedRejects(t *testing.T) {
+	result := CheckIngestionGate(IngestionGateInput{DeviceStatus: "revoked", EnrollmentStatus: "active"})
+	if result.Action != GateReject {
+		t.Errorf("action = %q, want %q", result.Action, GateReject)
+	}
+	if result.Check != GateCheckDeviceStatus {
+		t.Errorf("check = %q, want %q", result.Check, GateCheckDeviceStatus)
+	}
+}
+
+func TestCheckIngestionGatePendingRejects(t *testing.T) {
+	result := CheckIngestionGate(IngestionGateInput{DeviceStatus: "pending", EnrollmentStatus: "active"})
+	if result.Action != GateReject {
+		t.Errorf("action = %q, want %q", result.Action, GateReject)
+	}
+}
+
+func TestCheckIngestionGateDeviceCheckedFirst(t *testing.T) {
+	result := CheckIngestionGate(IngestionGateInput{DeviceStatus: "revoked", EnrollmentStatus: ""})
+	if result.Check != GateCheckDeviceStatus {
+		t.Errorf("check = %q, want %q", result.Check, GateCheckDeviceStatus)
+	}
+}
+
+func TestCheckIngestionGateNotEnrolledRejects(t *testing.T) {
+	result := CheckIngestionGate(IngestionGateInput{DeviceStatus: "active", EnrollmentStatus: ""})
+	if result.Action != GateReject {
+		t.Errorf("action = %q, want %q", result.Action, GateReject)
+	}
+	if result.Check != GateCheckEnrollment {
+		t.Errorf("check = %q, want %q", result.Check, GateCheckEnrollment)
+	}
+}
+
+func TestCheckIngestionGateWithdrawnRejects(t *testing.T) {
+	result := CheckIngestionGate(IngestionGateInput{DeviceStatus: "active", EnrollmentStatus: "withdrawn"})
+	if result.Action != GateReject {
+		t.Errorf("action = %q, want %q", result.Action, GateReject)
+	}
+	if result.Check != GateCheckEnrollment {
+		t.Errorf("check = %q, want %q", result.Check, GateCheckEnrollment)
+	}
+	if result.Reason == "device not enrolled in campaign" {
+		t.Error("withdrawn enrollment should have a distinct reason")
+	}
+}
@@ -15,7 +15,7 @@ type EligibilityCriteria struct {
 	DeviceClass     string
 	Tier            int
 	RequiredSensors []string
-	FirmwareMin     string
+	FirmwareMin     string // a minimum version or a VersionConstraint; see CheckFirmware
 }
 
 // EligibilityResult is the outcome of the eligibility check.
@@ -34,8 +34,8 @@ func MatchEligibility(caps DeviceCapabilities, criteria EligibilityCriteria) Eli
 		return EligibilityResult{Eligible: false, Reason: fmt.Sprintf("device tier %d below required %d", caps.Tier, criteria.Tier)}
 	}
 
-	if criteria.FirmwareMin != "" && caps.FirmwareVersion < criteria.FirmwareMin {
-		return EligibilityResult{Eligible: false, Reason: fmt.Sprintf("firmware %s below minimum %s", caps.FirmwareVersion, criteria.FirmwareMin)}
+	if fw := CheckFirmware(caps.FirmwareVersion, criteria.FirmwareMin); !fw.Compatible {
+		return EligibilityResult{Eligible: false, Reason: fw.Reason}
 	}
 
 	sensorSet := make(map[string]bool, len(caps.Sensors))
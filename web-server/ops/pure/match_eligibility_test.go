@@ -61,3 +61,30 @@ func TestMatchEligibilityNoFirmwareMin(t *testing.T) {
 		t.Errorf("expected eligible with no firmware min, got: %s", result.Reason)
 	}
 }
+
+func TestMatchEligibilityFirmwareVersions(t *testing.T) {
+	tests := []struct {
+		name     string
+		firmware string
+		required string
+		eligible bool
+	}{
+		{"numeric not lexical", "1.10.0", "1.9.0", true},
+		{"below minimum", "1.9.0", "1.10.0", false},
+		{"within range", "2.3.0", ">=2.1.0 <3", true},
+		{"excluded release", "2.4.1", ">=2.1.0 <3, !=2.4.1", false},
+		{"date-based", "2024.06.01", ">=2024.03.01", true},
+		{"unrecognised firmware", "unknown", "1.0.0", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := MatchEligibility(
+				DeviceCapabilities{Class: "weather-station", Tier: 1, Sensors: []string{"temp"}, FirmwareVersion: tt.firmware},
+				EligibilityCriteria{DeviceClass: "weather-station", Tier: 1, RequiredSensors: []string{"temp"}, FirmwareMin: tt.required},
+			)
+			if result.Eligible != tt.eligible {
+				t.Errorf("Eligible = %v, want %v (%s)", result.Eligible, tt.eligible, result.Reason)
+			}
+		})
+	}
+}
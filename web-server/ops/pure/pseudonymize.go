@@ -23,6 +23,7 @@ type PseudonymizableReading struct {
 	FirmwareVersion string
 	IngestedAt      time.Time
 	Status          string
+	Conversions     map[string]UnitConversion // values converted from the unit the device reported in
 }
 
 // PseudonymizedReading is a reading with the device ID replaced by an HMAC pseudonym.
@@ -35,6 +36,7 @@ type PseudonymizedReading struct {
 	FirmwareVersion string
 	IngestedAt      time.Time
 	Status          string
+	Conversions     map[string]UnitConversion // values converted from the unit the device reported in
 }
 
 // PseudonymizeExport replaces device IDs with HMAC-SHA256 pseudonyms.
@@ -53,6 +55,7 @@ func PseudonymizeExport(input PseudonymizeInput) []PseudonymizedReading {
 			FirmwareVersion: r.FirmwareVersion,
 			IngestedAt:      r.IngestedAt,
 			Status:          r.Status,
+			Conversions:     r.Conversions,
 		}
 	}
 	return out
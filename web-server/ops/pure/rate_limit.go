@@ -0,0 +1,74 @@
+package pure
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// TokenBucket is the state of one token-bucket rate limit.
+type TokenBucket struct {
+	Tokens  float64
+	Updated time.Time
+}
+
+// SamplingResult is the outcome of checking a reading against a campaign's minimum sampling interval.
+type SamplingResult struct {
+	OK     bool
+	Gap    time.Duration // distance to the nearest accepted reading; 0 when there is none
+	Reason string
+}
+
+// TakeToken is a pure function: (bucket, now, rate, burst) -> bucket after taking a token, allowed?
+// The bucket refills at rate tokens per second up to burst. A bucket never used starts full.
+// A rate or burst of zero or less disables the limit.
+func TakeToken(b TokenBucket, now time.Time, rate, burst float64) (TokenBucket, bool) {
+	if rate <= 0 || burst <= 0 {
+		return b, true
+	}
+	if b.Updated.IsZero() {
+		b.Tokens = burst
+	} else if elapsed := now.Sub(b.Updated).Seconds(); elapsed > 0 {
+		b.Tokens = math.Min(burst, b.Tokens+elapsed*rate)
+	}
+	b.Updated = now
+	if b.Tokens < 1 {
+		return b, false
+	}
+	b.Tokens--
+	return b, true
+}
+
+// BucketIdle is a pure function: (bucket, now, rate, burst) -> has the bucket refilled completely?
+// An idle bucket is indistinguishable from a new one and can be forgotten.
+func BucketIdle(b TokenBucket, now time.Time, rate, burst float64) bool {
+	if rate <= 0 {
+		return true
+	}
+	return b.Tokens+now.Sub(b.Updated).Seconds()*rate >= burst
+}
+
+// CheckSamplingInterval is a pure function: (timestamp, accepted timestamps, minimum interval) -> far enough apart?
+// accepted holds the timestamps of the device's readings already accepted in the campaign around
+// this one; order does not matter. A reading closer than minInterval to any of them samples too often.
+// A minInterval of zero or less disables the check.
+func CheckSamplingInterval(timestamp time.Time, accepted []time.Time, minInterval time.Duration) SamplingResult {
+	if minInterval <= 0 || len(accepted) == 0 {
+		return SamplingResult{OK: true}
+	}
+	nearest := time.Duration(math.MaxInt64)
+	for _, t := range accepted {
+		gap := timestamp.Sub(t)
+		if gap < 0 {
+			gap = -gap
+		}
+		if gap < nearest {
+			nearest = gap
+		}
+	}
+	if nearest < minInterval {
+		return SamplingResult{Gap: nearest,
+			Reason: fmt.Sprintf("reading %s after the previous accepted reading, campaign minimum interval %s", nearest, minInterval)}
+	}
+	return SamplingResult{OK: true, Gap: nearest}
+}
@@ -0,0 +1,89 @@
+package pure
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTakeTokenStartsFullAndEmpties(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	var b TokenBucket
+	var ok bool
+	for i := 0; i < 3; i++ {
+		if b, ok = TakeToken(b, now, 1, 3); !ok {
+			t.Fatalf("take %d: refused within burst", i)
+		}
+	}
+	if _, ok = TakeToken(b, now, 1, 3); ok {
+		t.Error("expected bucket to be empty after burst")
+	}
+}
+
+func TestTakeTokenRefills(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	b := TokenBucket{Tokens: 0, Updated: now}
+
+	if _, ok := TakeToken(b, now.Add(500*time.Millisecond), 1, 5); ok {
+		t.Error("half a token should not allow a message")
+	}
+	b, ok := TakeToken(b, now.Add(2*time.Second), 1, 5)
+	if !ok {
+		t.Fatal("expected a token after two seconds")
+	}
+	if b.Tokens < 0.99 || b.Tokens > 1.01 {
+		t.Errorf("tokens = %v, want 1 left of 2 refilled", b.Tokens)
+	}
+
+	// Refill stops at burst
+	b, _ = TakeToken(b, now.Add(time.Hour), 1, 5)
+	if b.Tokens != 4 {
+		t.Errorf("tokens = %v, want burst 5 less the one taken", b.Tokens)
+	}
+}
+
+func TestTakeTokenDisabled(t *testing.T) {
+	now := time.Now()
+	b := TokenBucket{Tokens: 0, Updated: now}
+	if _, ok := TakeToken(b, now, 0, 10); !ok {
+		t.Error("zero rate should disable the limit")
+	}
+}
+
+func TestBucketIdle(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	b := TokenBucket{Tokens: 2, Updated: now}
+	if BucketIdle(b, now.Add(time.Second), 1, 10) {
+		t.Error("bucket with 3 of 10 tokens should not be idle")
+	}
+	if !BucketIdle(b, now.Add(8*time.Second), 1, 10) {
+		t.Error("bucket refilled to burst should be idle")
+	}
+}
+
+func TestCheckSamplingInterval(t *testing.T) {
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	accepted := []time.Time{base, base.Add(10 * time.Minute)}
+
+	tests := []struct {
+		name      string
+		timestamp time.Time
+		min       time.Duration
+		wantOK    bool
+	}{
+		{"far enough after", base.Add(20 * time.Minute), 5 * time.Minute, true},
+		{"too soon after", base.Add(12 * time.Minute), 5 * time.Minute, false},
+		{"too soon before (out of order)", base.Add(8 * time.Minute), 5 * time.Minute, false},
+		{"exactly the interval", base.Add(15 * time.Minute), 5 * time.Minute, true},
+		{"disabled", base.Add(time.Second), 0, true},
+	}
+	for _, tt := range tests {
+		got := CheckSamplingInterval(tt.timestamp, accepted, tt.min)
+		if got.OK != tt.wantOK {
+			t.Errorf("%s: OK = %v, want %v (%s)", tt.name, got.OK, tt.wantOK, got.Reason)
+		}
+	}
+
+	if got := CheckSamplingInterval(base, nil, time.Minute); !got.OK {
+		t.Error("first reading should always pass")
+	}
+}
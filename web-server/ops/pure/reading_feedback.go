@@ -0,0 +1,151 @@
+package pure
+
+// Feedback codes classify why a reading was not accepted. They are safe to
+// send to devices and owners: none of them carry thresholds or algorithm detail (FR-125).
+const (
+	FeedbackAccepted               = "accepted"
+	FeedbackMalformedPayload       = "malformed_payload"
+	FeedbackBatchTooLarge          = "batch_too_large"
+	FeedbackTimestampOutsideWindow = "timestamp_outside_window"
+	FeedbackValueOutOfRange        = "value_out_of_range"
+	FeedbackExcessPrecision        = "excess_precision"
+	FeedbackUndeclaredParameter    = "undeclared_parameter"
+	FeedbackMissingParameters      = "missing_parameters"
+	FeedbackUnitMismatch           = "unit_mismatch"
+	FeedbackClockAhead             = "clock_ahead"
+	FeedbackBacklogTooOld          = "backlog_too_old"
+	FeedbackOutsideRegion          = "outside_region"
+	FeedbackInvalidLocation        = "invalid_location"
+	FeedbackAnomalousValue         = "anomalous_value"
+	FeedbackDuplicate              = "duplicate"
+	FeedbackPossibleDuplicate      = "possible_duplicate"
+	FeedbackDeviceSuspended        = "device_suspended"
+	FeedbackDeviceInactive         = "device_inactive"
+	FeedbackNotEnrolled            = "not_enrolled"
+	FeedbackEnrollmentWithdrawn    = "enrollment_withdrawn"
+	FeedbackRejected               = "rejected"
+	FeedbackServerError            = "server_error"
+	FeedbackServerBusy             = "server_busy"
+	FeedbackSamplingTooFrequent    = "sampling_too_frequent"
+	FeedbackRateLimited            = "rate_limited"
+)
+
+// ReadingFeedback is the owner-facing explanation for a feedback code.
+type ReadingFeedback struct {
+	Code    string
+	Message string
+	Action  string // suggested corrective action; "" when none is needed
+}
+
+var readingFeedback = map[string]ReadingFeedback{
+	FeedbackAccepted: {
+		Message: "Reading accepted.",
+	},
+	FeedbackMalformedPayload: {
+		Message: "Reading could not be read.",
+		Action:  "Check the device firmware is sending the documented payload format.",
+	},
+	FeedbackBatchTooLarge: {
+		Message: "Upload contains too many readings.",
+		Action:  "Split buffered readings into smaller uploads.",
+	},
+	FeedbackTimestampOutsideWindow: {
+		Message: "Reading time is outside the campaign collection period.",
+		Action:  "Check the device clock and the campaign dates.",
+	},
+	FeedbackValueOutOfRange: {
+		Message: "One or more values are outside the range this campaign expects.",
+		Action:  "Check sensor placement and calibration.",
+	},
+	FeedbackExcessPrecision: {
+		Message: "One or more values are reported more precisely than this campaign expects; they are held for review.",
+		Action:  "Check the device reports values at the precision the campaign lists.",
+	},
+	FeedbackUndeclaredParameter: {
+		Message: "Reading includes measurements this campaign does not collect; they are held for review.",
+		Action:  "Check the device only sends the parameters the campaign lists.",
+	},
+	FeedbackMissingParameters: {
+		Message: "Reading is missing measurements this campaign requires; it is held for review.",
+		Action:  "Check every sensor the campaign requires is connected and reporting.",
+	},
+	FeedbackUnitMismatch: {
+		Message: "A measurement's declared unit cannot be converted to the unit this campaign uses; it is held for review.",
+		Action:  "Check the sensor units declared for this device.",
+	},
+	FeedbackClockAhead: {
+		Message: "Reading timestamp is in the future; it is held for review.",
+		Action:  "Check the device clock is set and synchronised (NTP or GPS time).",
+	},
+	FeedbackBacklogTooOld: {
+		Message: "Reading is too old to accept; it is held for review.",
+		Action:  "Upload stored readings sooner, and check the device clock has not reset.",
+	},
+	FeedbackOutsideRegion: {
+		Message: "Reading location is outside the campaign area; it is held for review.",
+		Action:  "Check the device is placed inside the campaign area and its location fix is current.",
+	},
+	FeedbackInvalidLocation: {
+		Message: "Reading location could not be read; it is held for review.",
+		Action:  "Check the device firmware is sending location in the documented format.",
+	},
+	FeedbackAnomalousValue: {
+		Message: "One or more values differ sharply from recent readings.",
+		Action:  "Check sensor placement and calibration, or restart the device.",
+	},
+	FeedbackDuplicate: {
+		Message: "Reading was already received.",
+	},
+	FeedbackPossibleDuplicate: {
+		Message: "Reading repeats a very recent reading; it is held for review.",
+		Action:  "Check the device is not sending the same reading more than once.",
+	},
+	FeedbackDeviceSuspended: {
+		Message: "Device is suspended; readings are held for review.",
+		Action:  "Check your notifications for steps to reinstate the device.",
+	},
+	FeedbackDeviceInactive: {
+		Message: "Device is not active.",
+		Action:  "Complete device enrollment or contact support.",
+	},
+	FeedbackNotEnrolled: {
+		Message: "Device is not enrolled in this campaign.",
+		Action:  "Enroll the device in the campaign before sending readings.",
+	},
+	FeedbackEnrollmentWithdrawn: {
+		Message: "Device was withdrawn from this campaign.",
+		Action:  "Re-enroll the device to contribute again.",
+	},
+	FeedbackRejected: {
+		Message: "Reading was not accepted.",
+		Action:  "Check the device and try again later.",
+	},
+	FeedbackServerError: {
+		Message: "Reading could not be processed right now.",
+		Action:  "Keep the reading and resend it later.",
+	},
+	FeedbackServerBusy: {
+		Message: "Server is busy; reading was not stored.",
+		Action:  "Keep the reading and resend it after a short wait.",
+	},
+	FeedbackSamplingTooFrequent: {
+		Message: "Device is sampling more often than this campaign collects; the extra reading is held for review.",
+		Action:  "Check the device sampling interval against the campaign settings.",
+	},
+	FeedbackRateLimited: {
+		Message: "Device is sending too fast; reading was not stored.",
+		Action:  "Slow the device's publishing rate and resend buffered readings later.",
+	},
+}
+
+// FeedbackFor is a pure function: feedback code -> owner-facing message and action.
+// Unknown codes fall back to a generic rejection so internal detail never leaks.
+func FeedbackFor(code string) ReadingFeedback {
+	fb, ok := readingFeedback[code]
+	if !ok {
+		code = FeedbackRejected
+		fb = readingFeedback[code]
+	}
+	fb.Code = code
+	return fb
+}
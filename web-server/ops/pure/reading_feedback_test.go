@@ -0,0 +1,43 @@
+package pure
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFeedbackForKnownCode(t *testing.T) {
+	fb := FeedbackFor(FeedbackValueOutOfRange)
+	if fb.Code != FeedbackValueOutOfRange {
+		t.Errorf("code = %q, want %q", fb.Code, FeedbackValueOutOfRange)
+	}
+	if fb.Message == "" || fb.Action == "" {
+		t.Error("out-of-range feedback should carry a message and an action")
+	}
+}
+
+func TestFeedbackForUnknownCodeFallsBack(t *testing.T) {
+	fb := FeedbackFor("value 999.000000 above max range 50.000000 for temp")
+	if fb.Code != FeedbackRejected {
+		t.Errorf("code = %q, want %q", fb.Code, FeedbackRejected)
+	}
+}
+
+func TestFeedbackDoesNotDiscloseThresholds(t *testing.T) {
+	for code := range readingFeedback {
+		fb := FeedbackFor(code)
+		if strings.ContainsAny(fb.Message+fb.Action, "0123456789") {
+			t.Errorf("feedback for %q contains digits: %q / %q", code, fb.Message, fb.Action)
+		}
+	}
+}
+
+func TestValidateReadingSetsFeedbackCode(t *testing.T) {
+	max := 50.0
+	result := ValidateReading(
+		ReadingInput{Values: map[string]float64{"temp": 99}},
+		ValidationRules{Parameters: []ParameterRule{{Name: "temp", MaxRange: &max}}},
+	)
+	if result.Code != FeedbackValueOutOfRange {
+		t.Errorf("code = %q, want %q", result.Code, FeedbackValueOutOfRange)
+	}
+}
@@ -0,0 +1,269 @@
+package pure
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Consumer weather-station upload protocols a device can be linked with.
+const (
+	StationProtocolWunderground = "wunderground" // updateweatherstation GET, as Weather Underground stations send it
+	StationProtocolEcowitt      = "ecowitt"      // Ecowitt custom-server form POST
+	StationProtocolAmbient      = "ambient"      // Ambient Weather custom-server query string
+)
+
+// StationField is the parameter a vendor upload field reports, and the unit the vendor sends
+// it in (UCUM code; empty for quantities the unit registry does not cover, which are passed
+// through as sent).
+type StationField struct {
+	Parameter string
+	Unit      string
+}
+
+// The three protocols share Weather Underground's imperial field names; Ecowitt and Ambient
+// add their own for what WU lacks.
+var wundergroundFields = map[string]StationField{
+	"tempf":          {"temperature", "[degF]"},
+	"humidity":       {"humidity", "%"},
+	"dewptf":         {"dew_point", "[degF]"},
+	"baromin":        {"pressure", "[in_i'Hg]"},
+	"windspeedmph":   {"wind_speed", "[mi_i]/h"},
+	"windgustmph":    {"wind_gust", "[mi_i]/h"},
+	"winddir":        {"wind_direction", ""},
+	"rainin":         {"rain_1h", "[in_i]"},
+	"dailyrainin":    {"rain_daily", "[in_i]"},
+	"solarradiation": {"solar_radiation", ""},
+	"UV":             {"uv_index", ""},
+	"indoortempf":    {"indoor_temperature", "[degF]"},
+	"indoorhumidity": {"indoor_humidity", "%"},
+	"soiltempf":      {"soil_temperature", "[degF]"},
+	"soilmoisture":   {"soil_moisture", "%"},
+	"AqPM2.5":        {"pm2_5", "ug/m3"},
+	"AqPM10":         {"pm10", "ug/m3"},
+}
+
+var ecowittFields = map[string]StationField{
+	"tempf":          {"temperature", "[degF]"},
+	"humidity":       {"humidity", "%"},
+	"baromrelin":     {"pressure", "[in_i'Hg]"},
+	"baromabsin":     {"pressure_absolute", "[in_i'Hg]"},
+	"windspeedmph":   {"wind_speed", "[mi_i]/h"},
+	"windgustmph":    {"wind_gust", "[mi_i]/h"},
+	"winddir":        {"wind_direction", ""},
+	"hourlyrainin":   {"rain_1h", "[in_i]"},
+	"dailyrainin":    {"rain_daily", "[in_i]"},
+	"eventrainin":    {"rain_event", "[in_i]"},
+	"solarradiation": {"solar_radiation", ""},
+	"uv":             {"uv_index", ""},
+	"tempinf":        {"indoor_temperature", "[degF]"},
+	"humidityin":     {"indoor_humidity", "%"},
+	"soilmoisture1":  {"soil_moisture", "%"},
+	"tf_ch1":         {"soil_temperature", "[degF]"},
+	"pm25_ch1":       {"pm2_5", "ug/m3"},
+	"pm25_co2":       {"pm2_5", "ug/m3"},
+	"pm10_co2":       {"pm10", "ug/m3"},
+	"co2":            {"co2", "[ppm]"},
+}
+
+var ambientFields = map[string]StationField{
+	"tempf":          {"temperature", "[degF]"},
+	"humidity":       {"humidity", "%"},
+	"dewptf":         {"dew_point", "[degF]"},
+	"baromrelin":     {"pressure", "[in_i'Hg]"},
+	"baromabsin":     {"pressure_absolute", "[in_i'Hg]"},
+	"windspeedmph":   {"wind_speed", "[mi_i]/h"},
+	"windgustmph":    {"wind_gust", "[mi_i]/h"},
+	"winddir":        {"wind_direction", ""},
+	"hourlyrainin":   {"rain_1h", "[in_i]"},
+	"dailyrainin":    {"rain_daily", "[in_i]"},
+	"eventrainin":    {"rain_event", "[in_i]"},
+	"solarradiation": {"solar_radiation", ""},
+	"uv":             {"uv_index", ""},
+	"tempinf":        {"indoor_temperature", "[degF]"},
+	"humidityin":     {"indoor_humidity", "%"},
+	"soilhum1":       {"soil_moisture", "%"},
+	"soiltemp1f":     {"soil_temperature", "[degF]"},
+	"pm25":           {"pm2_5", "ug/m3"},
+	"co2":            {"co2", "[ppm]"},
+}
+
+var stationProtocols = map[string]struct {
+	fields   map[string]StationField
+	firmware []string // fields naming the station's software, first present wins
+}{
+	StationProtocolWunderground: {wundergroundFields, []string{"softwaretype"}},
+	StationProtocolEcowitt:      {ecowittFields, []string{"stationtype"}},
+	StationProtocolAmbient:      {ambientFields, []string{"stationtype"}},
+}
+
+// stationMissingValue is what WU-style stations send for a sensor with no reading.
+const stationMissingValue = -9999
+
+// stationTimeLayout is the dateutc format all three protocols use.
+const stationTimeLayout = "2006-01-02 15:04:05"
+
+// IsStationProtocol reports whether protocol is a supported upload protocol.
+func IsStationProtocol(protocol string) bool {
+	_, ok := stationProtocols[protocol]
+	return ok
+}
+
+// StationParameters is a pure function: protocol -> parameter -> the unit the protocol
+// reports it in, after renaming through parameterMap. Used to declare a linked device's units.
+func StationParameters(protocol string, parameterMap map[string]string) map[string]string {
+	p, ok := stationProtocols[protocol]
+	if !ok {
+		return nil
+	}
+	out := make(map[string]string, len(p.fields))
+	for _, f := range p.fields {
+		if f.Unit != "" {
+			out[stationParameter(f.Parameter, parameterMap)] = f.Unit
+		}
+	}
+	return out
+}
+
+// ValidateStationParameterMap is a pure function: checks a link's renames name standard
+// parameters of protocol, each to a distinct parameter.
+func ValidateStationParameterMap(protocol string, parameterMap map[string]string) error {
+	p, ok := stationProtocols[protocol]
+	if !ok {
+		return fmt.Errorf("unknown station protocol %q", protocol)
+	}
+	standard := make(map[string]bool, len(p.fields))
+	for _, f := range p.fields {
+		standard[f.Parameter] = true
+	}
+	renamed := make(map[string]string, len(parameterMap))
+	for from, to := range parameterMap {
+		if !standard[from] {
+			return fmt.Errorf("%s uploads do not report %q", protocol, from)
+		}
+		to = strings.TrimSpace(to)
+		if to == "" {
+			return fmt.Errorf("parameter %q is renamed to an empty name", from)
+		}
+		if other, dup := renamed[to]; dup {
+			return fmt.Errorf("parameters %q and %q are both renamed to %q", other, from, to)
+		}
+		renamed[to] = from
+	}
+	return nil
+}
+
+// StationUploadInput is one upload from a linked weather station.
+type StationUploadInput struct {
+	Protocol     string
+	Fields       map[string][]string // query string and form fields, as sent
+	ParameterMap map[string]string   // standard parameter name -> campaign parameter name
+	DeviceUnits  map[string]string   // parameter -> UCUM unit the device declares, after renaming
+	ReceivedAt   time.Time
+}
+
+// StationReading is a station upload translated into a reading.
+type StationReading struct {
+	Values          map[string]float64 // parameter -> value, in the device's declared unit
+	Timestamp       time.Time
+	FirmwareVersion string
+	Ignored         []string // fields not mapped or not readable, sorted
+}
+
+// MapStationUpload is a pure function: vendor upload -> reading. Each known field becomes its
+// parameter, renamed through ParameterMap, and is converted from the vendor's unit to the unit
+// the device declares for that parameter; ingestion converts on to each campaign's units.
+// A value in a unit the device does not declare is passed through in the vendor's unit.
+// Missing readings (-9999), unparseable values and unknown fields are ignored. The station's
+// dateutc is the timestamp; "now" or none means ReceivedAt.
+func MapStationUpload(input StationUploadInput) (StationReading, error) {
+	p, ok := stationProtocols[input.Protocol]
+	if !ok {
+		return StationReading{}, fmt.Errorf("unknown station protocol %q", input.Protocol)
+	}
+
+	reading := StationReading{Values: make(map[string]float64), Timestamp: input.ReceivedAt.UTC()}
+	if ts := firstField(input.Fields, "dateutc"); ts != "" && !strings.EqualFold(ts, "now") {
+		t, err := time.Parse(stationTimeLayout, ts)
+		if err != nil {
+			return StationReading{}, fmt.Errorf("dateutc %q is not %s", ts, stationTimeLayout)
+		}
+		reading.Timestamp = t
+	}
+	for _, name := range p.firmware {
+		if v := firstField(input.Fields, name); v != "" {
+			reading.FirmwareVersion = v
+			break
+		}
+	}
+
+	names := make([]string, 0, len(input.Fields))
+	for name := range input.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		field, known := p.fields[name]
+		if !known {
+			if !stationControlField(name, p.firmware) {
+				reading.Ignored = append(reading.Ignored, name)
+			}
+			continue
+		}
+		value, err := strconv.ParseFloat(strings.TrimSpace(firstField(input.Fields, name)), 64)
+		if err != nil || math.IsNaN(value) || math.IsInf(value, 0) || value == stationMissingValue {
+			reading.Ignored = append(reading.Ignored, name)
+			continue
+		}
+		param := stationParameter(field.Parameter, input.ParameterMap)
+		if to := input.DeviceUnits[param]; field.Unit != "" && to != "" {
+			if value, err = ConvertUnit(value, field.Unit, to); err != nil {
+				reading.Ignored = append(reading.Ignored, name)
+				continue
+			}
+		}
+		if _, dup := reading.Values[param]; dup {
+			// Two fields for one parameter (an Ecowitt PM2.5 sensor on two channels): first wins
+			reading.Ignored = append(reading.Ignored, name)
+			continue
+		}
+		reading.Values[param] = value
+	}
+	if len(reading.Values) == 0 {
+		return StationReading{}, fmt.Errorf("upload has no readable %s fields", input.Protocol)
+	}
+	return reading, nil
+}
+
+// stationControlField reports fields every upload carries that are not readings: credentials
+// (the tokenised URL authenticates instead), protocol flags and the station's identity.
+func stationControlField(name string, firmware []string) bool {
+	switch strings.ToLower(name) {
+	case "id", "password", "passkey", "mac", "action", "realtime", "rtfreq", "freq", "model", "dateutc":
+		return true
+	}
+	for _, f := range firmware {
+		if name == f {
+			return true
+		}
+	}
+	return false
+}
+
+func stationParameter(standard string, parameterMap map[string]string) string {
+	if name := strings.TrimSpace(parameterMap[standard]); name != "" {
+		return name
+	}
+	return standard
+}
+
+func firstField(fields map[string][]string, name string) string {
+	if v := fields[name]; len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
@@ -0,0 +1,152 @@
+package pure
+
+import (
+	"math"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMapStationUpload(t *testing.T) {
+	received := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	query := func(s string) map[string][]string {
+		v, err := url.ParseQuery(s)
+		if err != nil {
+			t.Fatalf("parse %q: %v", s, err)
+		}
+		return v
+	}
+
+	tests := []struct {
+		name         string
+		protocol     string
+		fields       string
+		parameterMap map[string]string
+		deviceUnits  map[string]string
+		wantValues   map[string]float64
+		wantTime     time.Time
+		wantFirmware string
+		wantIgnored  []string
+		wantErr      bool
+	}{
+		{
+			name:         "wunderground in vendor units",
+			protocol:     StationProtocolWunderground,
+			fields:       "ID=KXX1&PASSWORD=x&action=updateraw&dateutc=now&tempf=70.0&humidity=55&winddir=180&softwaretype=WS-2902",
+			wantValues:   map[string]float64{"temperature": 70, "humidity": 55, "wind_direction": 180},
+			wantTime:     received,
+			wantFirmware: "WS-2902",
+		},
+		{
+			name:        "converted to declared units",
+			protocol:    StationProtocolWunderground,
+			fields:      "dateutc=2026-05-01+11:55:00&tempf=212&baromin=29.92&windspeedmph=10",
+			deviceUnits: map[string]string{"temperature": "Cel", "pressure": "hPa", "wind_speed": "m/s"},
+			wantValues:  map[string]float64{"temperature": 100, "pressure": 1013.21, "wind_speed": 4.4704},
+			wantTime:    time.Date(2026, 5, 1, 11, 55, 0, 0, time.UTC),
+		},
+		{
+			name:         "ecowitt renamed through parameter map",
+			protocol:     StationProtocolEcowitt,
+			fields:       "PASSKEY=abc&stationtype=GW1000_V1.6.8&dateutc=2026-05-01+11:59:00&tempf=32&pm25_ch1=12&co2=415",
+			parameterMap: map[string]string{"temperature": "air_temp", "pm2_5": "pm25"},
+			deviceUnits:  map[string]string{"air_temp": "Cel"},
+			wantValues:   map[string]float64{"air_temp": 0, "pm25": 12, "co2": 415},
+			wantTime:     time.Date(2026, 5, 1, 11, 59, 0, 0, time.UTC),
+			wantFirmware: "GW1000_V1.6.8",
+		},
+		{
+			name:        "missing and unknown fields ignored",
+			protocol:    StationProtocolAmbient,
+			fields:      "MAC=00:11&tempf=-9999&humidity=40&uv=abc&lightning_day=3",
+			wantValues:  map[string]float64{"humidity": 40},
+			wantTime:    received,
+			wantIgnored: []string{"lightning_day", "tempf", "uv"},
+		},
+		{
+			name:        "second field for one parameter ignored",
+			protocol:    StationProtocolEcowitt,
+			fields:      "pm25_ch1=10&pm25_co2=11",
+			wantValues:  map[string]float64{"pm2_5": 10},
+			wantTime:    received,
+			wantIgnored: []string{"pm25_co2"},
+		},
+		{name: "no readings", protocol: StationProtocolWunderground, fields: "ID=KXX1&tempf=-9999", wantErr: true},
+		{name: "bad dateutc", protocol: StationProtocolWunderground, fields: "dateutc=yesterday&tempf=70", wantErr: true},
+		{name: "unknown protocol", protocol: "davis", fields: "tempf=70", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MapStationUpload(StationUploadInput{
+				Protocol:     tt.protocol,
+				Fields:       query(tt.fields),
+				ParameterMap: tt.parameterMap,
+				DeviceUnits:  tt.deviceUnits,
+				ReceivedAt:   received,
+			})
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("MapStationUpload() = %+v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("MapStationUpload() error = %v", err)
+			}
+			if len(got.Values) != len(tt.wantValues) {
+				t.Errorf("Values = %v, want %v", got.Values, tt.wantValues)
+			}
+			for param, want := range tt.wantValues {
+				if v, ok := got.Values[param]; !ok || math.Abs(v-want) > 0.01 {
+					t.Errorf("Values[%q] = %v, want %v", param, v, want)
+				}
+			}
+			if !got.Timestamp.Equal(tt.wantTime) {
+				t.Errorf("Timestamp = %v, want %v", got.Timestamp, tt.wantTime)
+			}
+			if got.FirmwareVersion != tt.wantFirmware {
+				t.Errorf("FirmwareVersion = %q, want %q", got.FirmwareVersion, tt.wantFirmware)
+			}
+			if !reflect.DeepEqual(got.Ignored, tt.wantIgnored) {
+				t.Errorf("Ignored = %v, want %v", got.Ignored, tt.wantIgnored)
+			}
+		})
+	}
+}
+
+func TestStationParameters(t *testing.T) {
+	got := StationParameters(StationProtocolWunderground, map[string]string{"temperature": "air_temp"})
+	if got["air_temp"] != "[degF]" || got["pressure"] != "[in_i'Hg]" {
+		t.Errorf("StationParameters() = %v", got)
+	}
+	if _, ok := got["wind_direction"]; ok {
+		t.Errorf("wind_direction has no registry unit, want it left undeclared")
+	}
+	if StationParameters("davis", nil) != nil {
+		t.Errorf("StationParameters(unknown) want nil")
+	}
+}
+
+func TestValidateStationParameterMap(t *testing.T) {
+	tests := []struct {
+		name     string
+		protocol string
+		m        map[string]string
+		wantErr  bool
+	}{
+		{"empty", StationProtocolEcowitt, nil, false},
+		{"rename", StationProtocolEcowitt, map[string]string{"temperature": "air_temp", "co2": "co2_ppm"}, false},
+		{"not reported by protocol", StationProtocolWunderground, map[string]string{"co2": "co2_ppm"}, true},
+		{"empty name", StationProtocolAmbient, map[string]string{"temperature": " "}, true},
+		{"two to one", StationProtocolAmbient, map[string]string{"temperature": "t", "dew_point": "t"}, true},
+		{"unknown protocol", "davis", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateStationParameterMap(tt.protocol, tt.m); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateStationParameterMap() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package pure
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Unit dimensions. Values convert only between units of the same dimension.
+const (
+	DimensionTemperature       = "temperature"
+	DimensionPressure          = "pressure"
+	DimensionSpeed             = "speed"
+	DimensionMassConcentration = "mass_concentration"
+	DimensionRatio             = "ratio" // dimensionless: ppm, ppb, percent
+	DimensionLength            = "length"
+)
+
+// unitDef converts a unit to its dimension's base unit: base = value*scale + offset.
+type unitDef struct {
+	dimension string
+	scale     float64
+	offset    float64
+}
+
+// unitRegistry is keyed by UCUM case-sensitive code.
+var unitRegistry = map[string]unitDef{
+	// temperature, base K
+	"K":      {DimensionTemperature, 1, 0},
+	"Cel":    {DimensionTemperature, 1, 273.15},
+	"[degF]": {DimensionTemperature, 5.0 / 9.0, 273.15 - 32*5.0/9.0},
+
+	// pressure, base Pa
+	"Pa":        {DimensionPressure, 1, 0},
+	"hPa":       {DimensionPressure, 100, 0},
+	"kPa":       {DimensionPressure, 1000, 0},
+	"mbar":      {DimensionPressure, 100, 0},
+	"bar":       {DimensionPressure, 100000, 0},
+	"mm[Hg]":    {DimensionPressure, 133.322387415, 0},
+	"[in_i'Hg]": {DimensionPressure, 3386.388640341, 0},
+	"[psi]":     {DimensionPressure, 6894.757293168, 0},
+	"atm":       {DimensionPressure, 101325, 0},
+
+	// speed, base m/s
+	"m/s":      {DimensionSpeed, 1, 0},
+	"km/h":     {DimensionSpeed, 1000.0 / 3600.0, 0},
+	"[mi_i]/h": {DimensionSpeed, 1609.344 / 3600.0, 0},
+	"[kn_i]":   {DimensionSpeed, 1852.0 / 3600.0, 0},
+	"[ft_i]/s": {DimensionSpeed, 0.3048, 0},
+
+	// mass concentration, base ug/m3
+	"ug/m3": {DimensionMassConcentration, 1, 0},
+	"mg/m3": {DimensionMassConcentration, 1000, 0},
+	"g/m3":  {DimensionMassConcentration, 1e6, 0},
+
+	// ratio, base 1
+	"1":     {DimensionRatio, 1, 0},
+	"%":     {DimensionRatio, 1e-2, 0},
+	"[ppm]": {DimensionRatio, 1e-6, 0},
+	"[ppb]": {DimensionRatio, 1e-9, 0},
+
+	// length (precipitation, snow depth), base m
+	"m":      {DimensionLength, 1, 0},
+	"cm":     {DimensionLength, 1e-2, 0},
+	"mm":     {DimensionLength, 1e-3, 0},
+	"[in_i]": {DimensionLength, 0.0254, 0},
+}
+
+// unitAliases maps common spellings, lower-cased, to UCUM codes.
+var unitAliases = map[string]string{
+	"c": "Cel", "°c": "Cel", "degc": "Cel", "celsius": "Cel",
+	"f": "[degF]", "°f": "[degF]", "degf": "[degF]", "fahrenheit": "[degF]",
+	"k": "K", "kelvin": "K",
+
+	"pa": "Pa", "hpa": "hPa", "kpa": "kPa", "mb": "mbar", "millibar": "mbar",
+	"mmhg": "mm[Hg]", "inhg": "[in_i'Hg]", "psi": "[psi]",
+
+	"mps": "m/s", "kph": "km/h", "kmh": "km/h", "mph": "[mi_i]/h",
+	"kn": "[kn_i]", "kt": "[kn_i]", "kts": "[kn_i]", "knot": "[kn_i]", "knots": "[kn_i]",
+	"ft/s": "[ft_i]/s", "fps": "[ft_i]/s",
+
+	"µg/m3": "ug/m3", "μg/m3": "ug/m3", "ug/m³": "ug/m3", "µg/m³": "ug/m3", "μg/m³": "ug/m3",
+	"mg/m³": "mg/m3",
+
+	"ppm": "[ppm]", "ppb": "[ppb]", "percent": "%",
+
+	"in": "[in_i]", "inch": "[in_i]", "inches": "[in_i]",
+}
+
+// UnitConversion records how a stored value was derived from what the device sent.
+type UnitConversion struct {
+	OriginalValue float64
+	OriginalUnit  string // UCUM code
+	Unit          string // UCUM code of the stored value
+}
+
+// UnitConversionResult is the outcome of converting a reading's values to campaign units.
+type UnitConversionResult struct {
+	Values      map[string]float64        // converted values; unconvertible values are absent
+	Conversions map[string]UnitConversion // only values whose unit changed
+	Failures    map[string]string         // parameter -> reason, for values that could not be converted
+}
+
+// NormalizeUnit is a pure function: unit spelling -> UCUM code.
+// Reports false when the unit is not in the registry.
+func NormalizeUnit(unit string) (string, bool) {
+	u := strings.TrimSpace(unit)
+	if _, ok := unitRegistry[u]; ok {
+		return u, true
+	}
+	if code, ok := unitAliases[strings.ToLower(u)]; ok {
+		return code, true
+	}
+	return "", false
+}
+
+// ConvertUnit is a pure function: (value, from, to) -> value in to.
+// Both units may be any spelling NormalizeUnit accepts.
+func ConvertUnit(value float64, from, to string) (float64, error) {
+	fromCode, ok := NormalizeUnit(from)
+	if !ok {
+		return 0, fmt.Errorf("unknown unit %q", from)
+	}
+	toCode, ok := NormalizeUnit(to)
+	if !ok {
+		return 0, fmt.Errorf("unknown unit %q", to)
+	}
+	if fromCode == toCode {
+		return value, nil
+	}
+	f, t := unitRegistry[fromCode], unitRegistry[toCode]
+	if f.dimension != t.dimension {
+		return 0, fmt.Errorf("cannot convert %s (%s) to %s (%s)", fromCode, f.dimension, toCode, t.dimension)
+	}
+	base := value*f.scale + f.offset
+	return (base - t.offset) / t.scale, nil
+}
+
+// ConvertReadingUnits is a pure function: (values, device units, campaign units) -> values in
+// campaign units. A value is left as sent when the device declares no unit for it, the campaign
+// declares none, or the two spell the same unit. A value whose declared unit cannot be converted
+// to the campaign's is reported in Failures.
+func ConvertReadingUnits(values map[string]float64, deviceUnits, campaignUnits map[string]string) UnitConversionResult {
+	result := UnitConversionResult{Values: make(map[string]float64, len(values))}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		value := values[name]
+		from, to := deviceUnits[name], campaignUnits[name]
+		if from == "" || to == "" || strings.TrimSpace(from) == strings.TrimSpace(to) {
+			result.Values[name] = value
+			continue
+		}
+		converted, err := ConvertUnit(value, from, to)
+		if err != nil {
+			if result.Failures == nil {
+				result.Failures = make(map[string]string)
+			}
+			result.Failures[name] = fmt.Sprintf("unit conversion for %s: %v", name, err)
+			continue
+		}
+		result.Values[name] = converted
+		fromCode, _ := NormalizeUnit(from)
+		toCode, _ := NormalizeUnit(to)
+		if fromCode == toCode {
+			continue
+		}
+		if result.Conversions == nil {
+			result.Conversions = make(map[string]UnitConversion)
+		}
+		result.Conversions[name] = UnitConversion{OriginalValue: value, OriginalUnit: fromCode, Unit: toCode}
+	}
+	return result
+}
+
+// NormalizeUnits is a pure function: sensor -> unit spelling declarations -> sensor -> UCUM code.
+// Fails on the first unit not in the registry, in sensor name order.
+func NormalizeUnits(units map[string]string) (map[string]string, error) {
+	names := make([]string, 0, len(units))
+	for name := range units {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	out := make(map[string]string, len(units))
+	for _, name := range names {
+		if strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("unit declared for an unnamed sensor")
+		}
+		code, ok := NormalizeUnit(units[name])
+		if !ok {
+			return nil, fmt.Errorf("unknown unit %q for sensor %s", units[name], name)
+		}
+		out[name] = code
+	}
+	return out, nil
+}
@@ -0,0 +1,119 @@
+package pure
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNormalizeUnit(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+		ok   bool
+	}{
+		{"Cel", "Cel", true},
+		{"°C", "Cel", true},
+		{"degF", "[degF]", true},
+		{" hPa ", "hPa", true},
+		{"inHg", "[in_i'Hg]", true},
+		{"MPH", "[mi_i]/h", true},
+		{"µg/m³", "ug/m3", true},
+		{"ppm", "[ppm]", true},
+		{"furlongs", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := NormalizeUnit(tt.in)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("NormalizeUnit(%q) = %q, %v; want %q, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestConvertUnit(t *testing.T) {
+	tests := []struct {
+		value    float64
+		from, to string
+		want     float64
+	}{
+		{212, "[degF]", "Cel", 100},
+		{-40, "F", "C", -40},
+		{0, "Cel", "K", 273.15},
+		{29.92, "inHg", "hPa", 1013.2},
+		{1013.25, "hPa", "kPa", 101.325},
+		{36, "km/h", "m/s", 10},
+		{10, "knots", "m/s", 5.144},
+		{1, "mg/m3", "ug/m3", 1000},
+		{1, "%", "ppm", 10000},
+		{1, "in", "mm", 25.4},
+	}
+	for _, tt := range tests {
+		got, err := ConvertUnit(tt.value, tt.from, tt.to)
+		if err != nil {
+			t.Errorf("ConvertUnit(%v, %s, %s): %v", tt.value, tt.from, tt.to, err)
+			continue
+		}
+		if math.Abs(got-tt.want) > 0.01 {
+			t.Errorf("ConvertUnit(%v, %s, %s) = %v, want %v", tt.value, tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestConvertUnitRejectsIncompatible(t *testing.T) {
+	if _, err := ConvertUnit(1, "Cel", "hPa"); err == nil {
+		t.Error("expected error converting temperature to pressure")
+	}
+	if _, err := ConvertUnit(1, "ppm", "ug/m3"); err == nil {
+		t.Error("expected error converting ratio to mass concentration")
+	}
+	if _, err := ConvertUnit(1, "furlongs", "m"); err == nil {
+		t.Error("expected error for unknown unit")
+	}
+}
+
+func TestConvertReadingUnits(t *testing.T) {
+	result := ConvertReadingUnits(
+		map[string]float64{"temp": 68, "pressure": 1013, "count": 4, "pm25": 12},
+		map[string]string{"temp": "[degF]", "pressure": "hPa", "pm25": "ppm"},
+		map[string]string{"temp": "Cel", "pressure": "hPa", "count": "count", "pm25": "ug/m3"},
+	)
+
+	if math.Abs(result.Values["temp"]-20) > 0.001 {
+		t.Errorf("temp = %v, want 20", result.Values["temp"])
+	}
+	conv, ok := result.Conversions["temp"]
+	if !ok || conv.OriginalValue != 68 || conv.OriginalUnit != "[degF]" || conv.Unit != "Cel" {
+		t.Errorf("temp conversion = %+v", conv)
+	}
+	if result.Values["pressure"] != 1013 || result.Values["count"] != 4 {
+		t.Errorf("unchanged values = %v", result.Values)
+	}
+	if _, ok := result.Conversions["pressure"]; ok {
+		t.Error("same unit should not record a conversion")
+	}
+	if _, ok := result.Failures["pm25"]; !ok {
+		t.Error("expected pm25 conversion failure")
+	}
+	if _, ok := result.Values["pm25"]; ok {
+		t.Error("failed value should not be in Values")
+	}
+}
+
+func TestConvertReadingUnitsWithoutDeclarations(t *testing.T) {
+	result := ConvertReadingUnits(map[string]float64{"temp": 20}, nil, map[string]string{"temp": "Cel"})
+	if result.Values["temp"] != 20 || len(result.Conversions) != 0 || len(result.Failures) != 0 {
+		t.Errorf("result = %+v, want value unchanged", result)
+	}
+}
+
+func TestNormalizeUnits(t *testing.T) {
+	got, err := NormalizeUnits(map[string]string{"temp": "°F", "pressure": "inHg"})
+	if err != nil {
+		t.Fatalf("NormalizeUnits: %v", err)
+	}
+	if got["temp"] != "[degF]" || got["pressure"] != "[in_i'Hg]" {
+		t.Errorf("got %v", got)
+	}
+	if _, err := NormalizeUnits(map[string]string{"temp": "furlongs"}); err == nil {
+		t.Error("expected error for unknown unit")
+	}
+}
@@ -2,9 +2,25 @@ package pure
 
 import (
 	"fmt"
+	"math"
+	"sort"
+	"strings"
 	"time"
 )
 
+// Precision policies: what happens to a value with more decimal places than its parameter declares.
+const (
+	PrecisionRound      = "round"      // round to the declared precision and accept
+	PrecisionQuarantine = "quarantine" // keep as sent and quarantine the value
+)
+
+// Undeclared-parameter policies: what happens to a value for a parameter the campaign never declared.
+const (
+	UndeclaredDrop       = "drop"       // discard the value
+	UndeclaredExclude    = "exclude"    // store it, but keep it out of exports and baselines
+	UndeclaredQuarantine = "quarantine" // store it quarantined
+)
+
 // ReadingInput is the reading data to validate. Supports multi-value readings.
 type ReadingInput struct {
 	Values      map[string]float64 // parameter name -> value
@@ -20,41 +36,70 @@ type GeoPoint struct {
 
 // ValidationRules are the campaign rules to validate against.
 type ValidationRules struct {
-	Parameters  []ParameterRule
-	WindowStart *time.Time
-	WindowEnd   *time.Time
+	Parameters         []ParameterRule
+	WindowStart        *time.Time
+	WindowEnd          *time.Time
+	Regions            []GeoRegion // empty means the campaign is not geographically scoped
+	RegionBufferMeters float64
+	PrecisionPolicy    string        // PrecisionRound (default) or PrecisionQuarantine
+	UndeclaredPolicy   string        // UndeclaredExclude (default), UndeclaredDrop or UndeclaredQuarantine
+	ReceivedAt         time.Time     // ingestion time for clock checks; zero disables them
+	MaxFutureDrift     time.Duration // 0 disables
+	MaxBacklog         time.Duration // 0 disables
 }
 
 // ParameterRule defines valid ranges for a measurement parameter.
 type ParameterRule struct {
-	Name     string
-	MinRange *float64
-	MaxRange *float64
+	Name      string
+	MinRange  *float64
+	MaxRange  *float64
+	Precision *int // decimal places; nil means any
+	Required  bool
 }
 
 // ParameterValidation is the validation result for a single parameter.
 type ParameterValidation struct {
-	Name   string
-	Valid  bool
-	Reason string
+	Name     string
+	Value    float64 // value to store; rounded when the precision policy rounds
+	Valid    bool
+	Reason   string
+	Code     string // feedback code, see FeedbackFor
+	Drop     bool   // undeclared and dropped: do not store
+	Excluded bool   // undeclared and excluded: store, but not for export
 }
 
 // ValidationResult is the outcome of reading validation.
 type ValidationResult struct {
 	Valid        bool
 	Reason       string
+	Code         string // feedback code, see FeedbackFor
 	PerParameter []ParameterValidation
+	Missing      []string // required parameters the reading did not carry
 }
 
 // ValidateReading is a pure function: (reading, rules) -> valid/invalid + reason.
-// Each parameter is validated independently. Overall Valid = all parameters pass + timestamp valid.
+// Each parameter is validated independently. Overall Valid = all parameters pass + timestamp valid
+// + location (when reported) within the campaign regions.
 func ValidateReading(input ReadingInput, rules ValidationRules) ValidationResult {
+	// Check timestamp against when the reading arrived: a wrong device clock, not a late campaign
+	if lag := CheckTimestampLag(input.Timestamp, rules.ReceivedAt, rules.MaxFutureDrift, rules.MaxBacklog); !lag.OK {
+		return ValidationResult{Valid: false, Code: lag.Code, Reason: lag.Reason}
+	}
+
 	// Check timestamp within campaign window
 	if rules.WindowStart != nil && input.Timestamp.Before(*rules.WindowStart) {
-		return ValidationResult{Valid: false, Reason: fmt.Sprintf("timestamp %s before campaign window start %s", input.Timestamp.Format(time.RFC3339), rules.WindowStart.Format(time.RFC3339))}
+		return ValidationResult{Valid: false, Code: FeedbackTimestampOutsideWindow, Reason: fmt.Sprintf("timestamp %s before campaign window start %s", input.Timestamp.Format(time.RFC3339), rules.WindowStart.Format(time.RFC3339))}
 	}
 	if rules.WindowEnd != nil && input.Timestamp.After(*rules.WindowEnd) {
-		return ValidationResult{Valid: false, Reason: fmt.Sprintf("timestamp %s after campaign window end %s", input.Timestamp.Format(time.RFC3339), rules.WindowEnd.Format(time.RFC3339))}
+		return ValidationResult{Valid: false, Code: FeedbackTimestampOutsideWindow, Reason: fmt.Sprintf("timestamp %s after campaign window end %s", input.Timestamp.Format(time.RFC3339), rules.WindowEnd.Format(time.RFC3339))}
+	}
+
+	// Check location against campaign regions
+	if input.Geolocation != nil && len(rules.Regions) > 0 {
+		region := CheckRegions(*input.Geolocation, rules.Regions, rules.RegionBufferMeters)
+		if !region.Inside {
+			return ValidationResult{Valid: false, Code: FeedbackOutsideRegion, Reason: fmt.Sprintf("location %.6f,%.6f is %.0f m outside campaign regions", input.Geolocation.Latitude, input.Geolocation.Longitude, region.DistanceMeters)}
+		}
 	}
 
 	// Build a rules lookup by parameter name
@@ -63,31 +108,42 @@ func ValidateReading(input ReadingInput, rules ValidationRules) ValidationResult
 		rulesByName[p.Name] = p
 	}
 
+	names := make([]string, 0, len(input.Values))
+	for name := range input.Values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
 	allValid := true
 	var perParam []ParameterValidation
 
-	for name, value := range input.Values {
-		pv := ParameterValidation{Name: name, Valid: true, Reason: "valid"}
-		if rule, ok := rulesByName[name]; ok {
-			if rule.MinRange != nil && value < *rule.MinRange {
-				pv.Valid = false
-				pv.Reason = fmt.Sprintf("value %f below min range %f for %s", value, *rule.MinRange, name)
-				allValid = false
-			}
-			if pv.Valid && rule.MaxRange != nil && value > *rule.MaxRange {
-				pv.Valid = false
-				pv.Reason = fmt.Sprintf("value %f above max range %f for %s", value, *rule.MaxRange, name)
-				allValid = false
-			}
+	for _, name := range names {
+		pv := validateParameter(name, input.Values[name], rulesByName, rules)
+		if !pv.Valid {
+			allValid = false
 		}
 		perParam = append(perParam, pv)
 	}
 
+	var missing []string
+	for _, p := range rules.Parameters {
+		if _, ok := input.Values[p.Name]; p.Required && !ok {
+			missing = append(missing, p.Name)
+		}
+	}
+	sort.Strings(missing)
+
 	reason := "valid"
-	if !allValid {
+	code := FeedbackAccepted
+	if len(missing) > 0 {
+		allValid = false
+		reason = "missing required parameters: " + strings.Join(missing, ", ")
+		code = FeedbackMissingParameters
+	} else if !allValid {
 		for _, pv := range perParam {
 			if !pv.Valid {
 				reason = pv.Reason
+				code = pv.Code
 				break
 			}
 		}
@@ -96,6 +152,70 @@ func ValidateReading(input ReadingInput, rules ValidationRules) ValidationResult
 	return ValidationResult{
 		Valid:        allValid,
 		Reason:       reason,
+		Code:         code,
 		PerParameter: perParam,
+		Missing:      missing,
+	}
+}
+
+// validateParameter applies the undeclared-parameter policy, then precision, then range.
+// Campaigns that declare no parameters accept any.
+func validateParameter(name string, value float64, rulesByName map[string]ParameterRule, rules ValidationRules) ParameterValidation {
+	pv := ParameterValidation{Name: name, Value: value, Valid: true, Reason: "valid", Code: FeedbackAccepted}
+
+	rule, declared := rulesByName[name]
+	if !declared {
+		if len(rules.Parameters) == 0 {
+			return pv
+		}
+		switch rules.UndeclaredPolicy {
+		case UndeclaredDrop:
+			pv.Drop = true
+			pv.Reason = fmt.Sprintf("parameter %s not declared by campaign; dropped", name)
+		case UndeclaredQuarantine:
+			pv.Valid = false
+			pv.Reason = fmt.Sprintf("parameter %s not declared by campaign", name)
+			pv.Code = FeedbackUndeclaredParameter
+		default:
+			pv.Excluded = true
+			pv.Reason = fmt.Sprintf("parameter %s not declared by campaign; excluded from export", name)
+		}
+		return pv
+	}
+
+	if rule.Precision != nil {
+		rounded := RoundToPrecision(value, *rule.Precision)
+		if rounded != value {
+			if rules.PrecisionPolicy == PrecisionQuarantine {
+				pv.Valid = false
+				pv.Reason = fmt.Sprintf("value %v for %s exceeds declared precision of %d decimal places", value, name, *rule.Precision)
+				pv.Code = FeedbackExcessPrecision
+				return pv
+			}
+			pv.Value = rounded
+		}
+	}
+
+	if rule.MinRange != nil && pv.Value < *rule.MinRange {
+		pv.Valid = false
+		pv.Reason = fmt.Sprintf("value %f below min range %f for %s", pv.Value, *rule.MinRange, name)
+		pv.Code = FeedbackValueOutOfRange
+	}
+	if pv.Valid && rule.MaxRange != nil && pv.Value > *rule.MaxRange {
+		pv.Valid = false
+		pv.Reason = fmt.Sprintf("value %f above max range %f for %s", pv.Value, *rule.MaxRange, name)
+		pv.Code = FeedbackValueOutOfRange
+	}
+	return pv
+}
+
+// RoundToPrecision rounds v half away from zero to the given number of decimal places.
+// Values already at that precision, give or take float noise, come back unchanged.
+func RoundToPrecision(v float64, places int) float64 {
+	scale := math.Pow(10, float64(places))
+	rounded := math.Round(v*scale) / scale
+	if math.Abs(rounded-v) <= 1e-9*math.Max(1, math.Abs(v)) {
+		return v
 	}
+	return rounded
 }
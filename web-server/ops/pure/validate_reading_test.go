@@ -1,6 +1,7 @@
 package pure
 
 import (
+	"math"
 	"testing"
 	"time"
 )
@@ -151,3 +152,130 @@ func TestValidateReadingMultiValuePartialFail(t *testing.T) {
 		t.Error("expected temp to be invalid")
 	}
 }
+
+func TestValidateReadingRoundsExcessPrecision(t *testing.T) {
+	one := 1
+	result := ValidateReading(
+		ReadingInput{Values: map[string]float64{"temp": 23.46}, Timestamp: time.Now().UTC()},
+		ValidationRules{Parameters: []ParameterRule{{Name: "temp", Precision: &one}}},
+	)
+	if !result.Valid {
+		t.Fatalf("expected valid, got: %s", result.Reason)
+	}
+	if result.PerParameter[0].Value != 23.5 {
+		t.Errorf("value = %v, want 23.5", result.PerParameter[0].Value)
+	}
+}
+
+func TestValidateReadingQuarantinesExcessPrecision(t *testing.T) {
+	one := 1
+	result := ValidateReading(
+		ReadingInput{Values: map[string]float64{"temp": 23.46, "humidity": 40.1}, Timestamp: time.Now().UTC()},
+		ValidationRules{
+			PrecisionPolicy: PrecisionQuarantine,
+			Parameters:      []ParameterRule{{Name: "temp", Precision: &one}, {Name: "humidity", Precision: &one}},
+		},
+	)
+	if result.Valid || result.Code != FeedbackExcessPrecision {
+		t.Fatalf("result = %+v, want excess_precision", result)
+	}
+	for _, pv := range result.PerParameter {
+		if pv.Name == "humidity" && !pv.Valid {
+			t.Error("humidity is at declared precision and should be valid")
+		}
+		if pv.Name == "temp" && pv.Value != 23.46 {
+			t.Errorf("quarantined value should be kept as sent, got %v", pv.Value)
+		}
+	}
+}
+
+func TestValidateReadingPrecisionToleratesFloatNoise(t *testing.T) {
+	two := 2
+	result := ValidateReading(
+		ReadingInput{Values: map[string]float64{"temp": 0.1 + 0.2}, Timestamp: time.Now().UTC()},
+		ValidationRules{PrecisionPolicy: PrecisionQuarantine, Parameters: []ParameterRule{{Name: "temp", Precision: &two}}},
+	)
+	if !result.Valid {
+		t.Errorf("expected valid, got: %s", result.Reason)
+	}
+}
+
+func TestValidateReadingUndeclaredPolicies(t *testing.T) {
+	declared := []ParameterRule{{Name: "temp"}}
+	input := ReadingInput{Values: map[string]float64{"temp": 20, "voltage": 3.3}, Timestamp: time.Now().UTC()}
+
+	tests := []struct {
+		policy       string
+		wantValid    bool
+		wantDrop     bool
+		wantExcluded bool
+	}{
+		{"", true, false, true},
+		{UndeclaredExclude, true, false, true},
+		{UndeclaredDrop, true, true, false},
+		{UndeclaredQuarantine, false, false, false},
+	}
+	for _, tt := range tests {
+		result := ValidateReading(input, ValidationRules{Parameters: declared, UndeclaredPolicy: tt.policy})
+		if result.Valid != tt.wantValid {
+			t.Errorf("policy %q: valid = %v, want %v", tt.policy, result.Valid, tt.wantValid)
+		}
+		for _, pv := range result.PerParameter {
+			if pv.Name != "voltage" {
+				continue
+			}
+			if pv.Drop != tt.wantDrop || pv.Excluded != tt.wantExcluded {
+				t.Errorf("policy %q: drop=%v excluded=%v, want %v/%v", tt.policy, pv.Drop, pv.Excluded, tt.wantDrop, tt.wantExcluded)
+			}
+			if !tt.wantValid && pv.Code != FeedbackUndeclaredParameter {
+				t.Errorf("policy %q: code = %q", tt.policy, pv.Code)
+			}
+		}
+	}
+}
+
+func TestValidateReadingNoDeclaredParametersAcceptsAny(t *testing.T) {
+	result := ValidateReading(
+		ReadingInput{Values: map[string]float64{"voltage": 3.3}, Timestamp: time.Now().UTC()},
+		ValidationRules{UndeclaredPolicy: UndeclaredQuarantine},
+	)
+	if !result.Valid || result.PerParameter[0].Excluded {
+		t.Errorf("campaign without declared parameters should accept any, got %+v", result)
+	}
+}
+
+func TestValidateReadingMissingRequired(t *testing.T) {
+	result := ValidateReading(
+		ReadingInput{Values: map[string]float64{"temp": 20}, Timestamp: time.Now().UTC()},
+		ValidationRules{Parameters: []ParameterRule{
+			{Name: "temp", Required: true},
+			{Name: "pm25", Required: true},
+			{Name: "humidity"},
+		}},
+	)
+	if result.Valid || result.Code != FeedbackMissingParameters {
+		t.Fatalf("result = %+v, want missing_parameters", result)
+	}
+	if len(result.Missing) != 1 || result.Missing[0] != "pm25" {
+		t.Errorf("missing = %v, want [pm25]", result.Missing)
+	}
+}
+
+func TestRoundToPrecision(t *testing.T) {
+	tests := []struct {
+		v      float64
+		places int
+		want   float64
+	}{
+		{23.45, 1, 23.5},
+		{-23.45, 1, -23.5},
+		{23.4, 1, 23.4},
+		{1234, -2, 1200},
+		{0.125, 2, 0.13},
+	}
+	for _, tt := range tests {
+		if got := RoundToPrecision(tt.v, tt.places); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("RoundToPrecision(%v, %d) = %v, want %v", tt.v, tt.places, got, tt.want)
+		}
+	}
+}
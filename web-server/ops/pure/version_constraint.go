@@ -0,0 +1,299 @@
+package pure
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Version is a parsed firmware version, either semantic (MAJOR[.MINOR[.PATCH]][-pre][+build],
+// with an optional leading "v") or vendor date-based (YYYY.MM.DD, YYYY-MM-DD or YYYYMMDD,
+// with an optional trailing build number). Versions of different kinds do not compare.
+type Version struct {
+	Date       bool
+	Parts      []int    // major, minor, patch; or year, month, day, build
+	Prerelease []string // semantic versions only
+	given      int      // parts written out, for ~ and ^ ranges
+}
+
+var (
+	dateVersionRE   = regexp.MustCompile(`^(\d{4})([.-]?)(\d{2})([.-]?)(\d{2})(?:[.-](\d+))?$`)
+	semanticRE      = regexp.MustCompile(`^(\d+)(?:\.(\d+))?(?:\.(\d+))?(?:-([0-9A-Za-z.-]+))?(?:\+[0-9A-Za-z.-]+)?$`)
+	constraintOpsRE = regexp.MustCompile(`^(>=|<=|!=|==|>|<|=|~|\^)?(.*)$`)
+)
+
+// ParseVersion is a pure function: version string -> Version.
+func ParseVersion(s string) (Version, error) {
+	s = strings.TrimSpace(s)
+	if m := dateVersionRE.FindStringSubmatch(s); m != nil && m[2] == m[4] {
+		year, _ := strconv.Atoi(m[1])
+		month, _ := strconv.Atoi(m[3])
+		day, _ := strconv.Atoi(m[5])
+		if year >= 1970 && month >= 1 && month <= 12 && day >= 1 && day <= 31 {
+			build := 0
+			if m[6] != "" {
+				var err error
+				if build, err = strconv.Atoi(m[6]); err != nil {
+					return Version{}, fmt.Errorf("version %q: build number out of range", s)
+				}
+			}
+			return Version{Date: true, Parts: []int{year, month, day, build}, given: 4}, nil
+		}
+	}
+
+	m := semanticRE.FindStringSubmatch(strings.TrimPrefix(strings.TrimPrefix(s, "v"), "V"))
+	if m == nil {
+		return Version{}, fmt.Errorf("%q is not a semantic or date-based version", s)
+	}
+	v := Version{Parts: make([]int, 3)}
+	for i, part := range m[1:4] {
+		if part == "" {
+			break
+		}
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return Version{}, fmt.Errorf("version %q: component out of range", s)
+		}
+		v.Parts[i] = n
+		v.given++
+	}
+	if m[4] != "" {
+		v.Prerelease = strings.Split(m[4], ".")
+		for _, id := range v.Prerelease {
+			if id == "" {
+				return Version{}, fmt.Errorf("version %q: empty prerelease identifier", s)
+			}
+		}
+	}
+	return v, nil
+}
+
+// CompareVersions is a pure function: (a, b) -> -1, 0 or 1. A prerelease sorts below its
+// release, and build metadata is ignored, as semver specifies. Versions of different
+// kinds cannot be compared.
+func CompareVersions(a, b Version) (int, error) {
+	if a.Date != b.Date {
+		return 0, fmt.Errorf("cannot compare a date-based version with a semantic one")
+	}
+	for i := range max(len(a.Parts), len(b.Parts)) {
+		if c := compareInts(partAt(a.Parts, i), partAt(b.Parts, i)); c != 0 {
+			return c, nil
+		}
+	}
+	return comparePrerelease(a.Prerelease, b.Prerelease), nil
+}
+
+// VersionConstraint is a parsed version requirement: alternatives separated by "||", each
+// a list of comparisons separated by spaces or commas, all of which must hold, e.g.
+// ">=2.1.0 <3, !=2.4.1 || >=4". Comparisons are =, ==, !=, >, >=, <, <= and a bare version
+// (exact match); ~1.2 allows patch updates and ^1.2 minor ones. Missing components are 0.
+// The empty constraint allows every version.
+type VersionConstraint struct {
+	alternatives [][]versionTerm
+}
+
+type versionTerm struct {
+	op string
+	v  Version
+}
+
+// ParseVersionConstraint is a pure function: constraint string -> VersionConstraint.
+func ParseVersionConstraint(s string) (VersionConstraint, error) {
+	var c VersionConstraint
+	if strings.TrimSpace(s) == "" {
+		return c, nil
+	}
+	for _, alt := range strings.Split(s, "||") {
+		tokens := strings.Fields(strings.ReplaceAll(alt, ",", " "))
+		if len(tokens) == 0 {
+			return VersionConstraint{}, fmt.Errorf("constraint %q has an empty alternative", s)
+		}
+		var terms []versionTerm
+		for i := 0; i < len(tokens); i++ {
+			token := tokens[i]
+			// An operator written apart from its version, as in "< 3"
+			if constraintOpsRE.FindStringSubmatch(token)[2] == "" && i+1 < len(tokens) {
+				i++
+				token += tokens[i]
+			}
+			parsed, err := parseTerm(token)
+			if err != nil {
+				return VersionConstraint{}, fmt.Errorf("constraint %q: %w", s, err)
+			}
+			terms = append(terms, parsed...)
+		}
+		c.alternatives = append(c.alternatives, terms)
+	}
+	return c, nil
+}
+
+// Allows reports whether v satisfies the constraint. A comparison with a version of the
+// other kind never holds.
+func (c VersionConstraint) Allows(v Version) bool {
+	if len(c.alternatives) == 0 {
+		return true
+	}
+	for _, terms := range c.alternatives {
+		if allHold(terms, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// FirmwareCheck is the outcome of checking a device's firmware against a requirement.
+type FirmwareCheck struct {
+	Compatible bool
+	Reason     string
+}
+
+// CheckFirmware is a pure function: (firmware version, requirement) -> compatible/reason.
+// A requirement that is a bare version is a minimum, so "1.2.0" means ">=1.2.0"; anything
+// else is a VersionConstraint. An empty requirement accepts any firmware.
+func CheckFirmware(version, requirement string) FirmwareCheck {
+	constraint, err := ParseFirmwareRequirement(requirement)
+	if err != nil {
+		return FirmwareCheck{Reason: fmt.Sprintf("invalid firmware requirement: %v", err)}
+	}
+	if len(constraint.alternatives) == 0 {
+		return FirmwareCheck{Compatible: true}
+	}
+	v, err := ParseVersion(version)
+	if err != nil {
+		return FirmwareCheck{Reason: fmt.Sprintf("firmware %q is not a recognised version; %s is required", version, requirement)}
+	}
+	if !constraint.Allows(v) {
+		return FirmwareCheck{Reason: fmt.Sprintf("firmware %s does not meet required %s; update the device firmware", version, requirement)}
+	}
+	return FirmwareCheck{Compatible: true}
+}
+
+// ParseFirmwareRequirement is a pure function: requirement string -> VersionConstraint,
+// reading a bare version as a minimum. See CheckFirmware.
+func ParseFirmwareRequirement(requirement string) (VersionConstraint, error) {
+	if _, err := ParseVersion(requirement); err == nil {
+		requirement = ">=" + strings.TrimSpace(requirement)
+	}
+	return ParseVersionConstraint(requirement)
+}
+
+// parseTerm expands one comparison; ~ and ^ become a pair of bounds.
+func parseTerm(token string) ([]versionTerm, error) {
+	m := constraintOpsRE.FindStringSubmatch(token)
+	op, rest := m[1], m[2]
+	v, err := ParseVersion(rest)
+	if err != nil {
+		return nil, err
+	}
+	switch op {
+	case "", "==":
+		op = "="
+	case "~", "^":
+		if v.Date {
+			return nil, fmt.Errorf("%s applies to semantic versions only", op)
+		}
+		return []versionTerm{{op: ">=", v: v}, {op: "<", v: rangeCeiling(op, v)}}, nil
+	}
+	return []versionTerm{{op: op, v: v}}, nil
+}
+
+// rangeCeiling is the exclusive upper bound of a ~ or ^ range.
+func rangeCeiling(op string, v Version) Version {
+	// The component to bump: ~ keeps all but the last given part (at least major.minor
+	// when given); ^ keeps everything up to the first non-zero part.
+	bump := 0
+	if op == "~" {
+		if v.given >= 2 {
+			bump = 1
+		}
+	} else {
+		for bump < v.given-1 && v.Parts[bump] == 0 {
+			bump++
+		}
+	}
+	parts := make([]int, len(v.Parts))
+	copy(parts, v.Parts[:bump])
+	parts[bump] = v.Parts[bump] + 1
+	// A bare upper bound would admit its own prereleases (2.0.0-rc.1 < 2.0.0); the lowest
+	// prerelease keeps them out.
+	return Version{Parts: parts, Prerelease: []string{"0"}, given: 3}
+}
+
+func allHold(terms []versionTerm, v Version) bool {
+	for _, t := range terms {
+		c, err := CompareVersions(v, t.v)
+		if err != nil {
+			return false
+		}
+		var ok bool
+		switch t.op {
+		case "=":
+			ok = c == 0
+		case "!=":
+			ok = c != 0
+		case ">":
+			ok = c > 0
+		case ">=":
+			ok = c >= 0
+		case "<":
+			ok = c < 0
+		case "<=":
+			ok = c <= 0
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func partAt(parts []int, i int) int {
+	if i < len(parts) {
+		return parts[i]
+	}
+	return 0
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// comparePrerelease orders prerelease identifiers as semver does: none sorts highest,
+// numeric identifiers compare numerically and below alphanumeric ones, and a longer list
+// wins a tie.
+func comparePrerelease(a, b []string) int {
+	switch {
+	case len(a) == 0 && len(b) == 0:
+		return 0
+	case len(a) == 0:
+		return 1
+	case len(b) == 0:
+		return -1
+	}
+	for i := range min(len(a), len(b)) {
+		an, aErr := strconv.Atoi(a[i])
+		bn, bErr := strconv.Atoi(b[i])
+		var c int
+		switch {
+		case aErr == nil && bErr == nil:
+			c = compareInts(an, bn)
+		case aErr == nil:
+			c = -1
+		case bErr == nil:
+			c = 1
+		default:
+			c = strings.Compare(a[i], b[i])
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return compareInts(len(a), len(b))
+}
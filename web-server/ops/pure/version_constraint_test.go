@@ -0,0 +1,205 @@
+package pure
+
+import "testing"
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		in    string
+		date  bool
+		parts []int
+		pre   []string
+		ok    bool
+	}{
+		{"1.2.3", false, []int{1, 2, 3}, nil, true},
+		{"v1.2.3", false, []int{1, 2, 3}, nil, true},
+		{"1.2", false, []int{1, 2, 0}, nil, true},
+		{"3", false, []int{3, 0, 0}, nil, true},
+		{"1.2.3-rc.1", false, []int{1, 2, 3}, []string{"rc", "1"}, true},
+		{"1.2.3+build.7", false, []int{1, 2, 3}, nil, true},
+		{"1.2.3-beta+exp.sha", false, []int{1, 2, 3}, []string{"beta"}, true},
+		{"2024.03.15", true, []int{2024, 3, 15, 0}, nil, true},
+		{"2024-03-15", true, []int{2024, 3, 15, 0}, nil, true},
+		{"20240315", true, []int{2024, 3, 15, 0}, nil, true},
+		{"2024.03.15.2", true, []int{2024, 3, 15, 2}, nil, true},
+		{"2024.1.5", false, []int{2024, 1, 5}, nil, true},
+		{"2024.13.01", false, []int{2024, 13, 1}, nil, true},
+		{"2024/03/15", false, nil, nil, false},
+		{"", false, nil, nil, false},
+		{"latest", false, nil, nil, false},
+		{"1.2.3.4", false, nil, nil, false},
+		{"1.2.3-", false, nil, nil, false},
+		{"1.2.3-rc..1", false, nil, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			v, err := ParseVersion(tt.in)
+			if (err == nil) != tt.ok {
+				t.Fatalf("ParseVersion(%q) error = %v, want ok %v", tt.in, err, tt.ok)
+			}
+			if !tt.ok {
+				return
+			}
+			if v.Date != tt.date {
+				t.Errorf("Date = %v, want %v", v.Date, tt.date)
+			}
+			if len(v.Parts) != len(tt.parts) {
+				t.Fatalf("Parts = %v, want %v", v.Parts, tt.parts)
+			}
+			for i := range tt.parts {
+				if v.Parts[i] != tt.parts[i] {
+					t.Errorf("Parts = %v, want %v", v.Parts, tt.parts)
+					break
+				}
+			}
+			if len(v.Prerelease) != len(tt.pre) {
+				t.Errorf("Prerelease = %v, want %v", v.Prerelease, tt.pre)
+			}
+		})
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+		ok   bool
+	}{
+		{"1.10.0", "1.9.0", 1, true},
+		{"1.2.3", "1.2.3", 0, true},
+		{"1.2", "1.2.0", 0, true},
+		{"v2.0.0", "2.0.0", 0, true},
+		{"1.2.3+a", "1.2.3+b", 0, true},
+		{"1.0.0-rc.1", "1.0.0", -1, true},
+		{"1.0.0-alpha", "1.0.0-alpha.1", -1, true},
+		{"1.0.0-alpha.1", "1.0.0-alpha.beta", -1, true},
+		{"1.0.0-beta.2", "1.0.0-beta.11", -1, true},
+		{"1.0.0-beta", "1.0.0-alpha", 1, true},
+		{"2024.03.15", "2024-03-15", 0, true},
+		{"2024.10.01", "2024.09.30", 1, true},
+		{"2024.03.15", "2024.03.15.1", -1, true},
+		{"2024.03.15", "1.0.0", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+" vs "+tt.b, func(t *testing.T) {
+			a, err := ParseVersion(tt.a)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b, err := ParseVersion(tt.b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := CompareVersions(a, b)
+			if (err == nil) != tt.ok {
+				t.Fatalf("error = %v, want ok %v", err, tt.ok)
+			}
+			if got != tt.want {
+				t.Errorf("CompareVersions(%s, %s) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVersionConstraintAllows(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    string
+		want       bool
+	}{
+		{"", "0.0.1", true},
+		{">=2.1.0 <3, !=2.4.1", "2.1.0", true},
+		{">=2.1.0 <3, !=2.4.1", "2.10.0", true},
+		{">=2.1.0 <3, !=2.4.1", "2.4.1", false},
+		{">=2.1.0 <3, !=2.4.1", "2.0.9", false},
+		{">=2.1.0 <3, !=2.4.1", "3.0.0", false},
+		{">=2.1.0 <3, !=2.4.1", "3.0.0-rc.1", true},
+		{">= 2.1.0, < 3", "2.5.0", true},
+		{"1.2.3", "1.2.3", true},
+		{"=1.2.3", "1.2.4", false},
+		{"==1.2", "1.2.0", true},
+		{">1.2.3", "1.2.3", false},
+		{"<=1.2.3", "1.2.3", true},
+		{"<1.0 || >=2.0", "1.5.0", false},
+		{"<1.0 || >=2.0", "2.0.0", true},
+		{"~1.2.3", "1.2.9", true},
+		{"~1.2.3", "1.3.0", false},
+		{"~1.2", "1.2.0", true},
+		{"~1", "1.9.0", true},
+		{"~1", "2.0.0", false},
+		{"^1.2.3", "1.9.0", true},
+		{"^1.2.3", "2.0.0", false},
+		{"^1.2.3", "2.0.0-rc.1", false},
+		{"^0.2.3", "0.2.9", true},
+		{"^0.2.3", "0.3.0", false},
+		{"^0.0.3", "0.0.4", false},
+		{">=2024.01.01 <2025.01.01", "2024.06.30", true},
+		{">=2024.01.01 <2025.01.01", "2025-01-01", false},
+		{">=2024.01.01", "1.0.0", false},
+		{">=1.0.0", "2024.06.30", false},
+		{"!=1.0.0", "2024.06.30", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.constraint+" allows "+tt.version, func(t *testing.T) {
+			c, err := ParseVersionConstraint(tt.constraint)
+			if err != nil {
+				t.Fatal(err)
+			}
+			v, err := ParseVersion(tt.version)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := c.Allows(v); got != tt.want {
+				t.Errorf("Allows = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseVersionConstraintInvalid(t *testing.T) {
+	for _, s := range []string{
+		">=",
+		">=1.0 ||",
+		"|| <2",
+		">=x.y",
+		"=>1.0",
+		"~2024.01.01",
+		"^2024.01.01",
+		">=1.0 <",
+	} {
+		t.Run(s, func(t *testing.T) {
+			if _, err := ParseVersionConstraint(s); err == nil {
+				t.Errorf("ParseVersionConstraint(%q) accepted", s)
+			}
+		})
+	}
+}
+
+func TestCheckFirmware(t *testing.T) {
+	tests := []struct {
+		name        string
+		version     string
+		requirement string
+		compatible  bool
+	}{
+		{"no requirement", "anything", "", true},
+		{"bare version is a minimum", "1.10.0", "1.9.0", true},
+		{"below bare minimum", "1.9.0", "1.10.0", false},
+		{"range", "2.3.0", ">=2.1.0 <3, !=2.4.1", true},
+		{"excluded", "2.4.1", ">=2.1.0 <3, !=2.4.1", false},
+		{"date minimum", "2024.06.01", "2024.01.15", true},
+		{"kind mismatch", "2024.06.01", "1.0.0", false},
+		{"unparseable firmware", "unknown", "1.0.0", false},
+		{"invalid requirement", "1.0.0", ">=", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CheckFirmware(tt.version, tt.requirement)
+			if got.Compatible != tt.compatible {
+				t.Errorf("Compatible = %v, want %v (%s)", got.Compatible, tt.compatible, got.Reason)
+			}
+			if !got.Compatible && got.Reason == "" {
+				t.Error("incompatible without a reason")
+			}
+		})
+	}
+}
@@ -26,6 +26,44 @@ func (o *Ops) PersistReading(ctx context.Context, input PersistReadingInput) (*R
 	return fromRepoReading(result), nil
 }
 
+// PersistReadings writes a batch of readings in one transaction.
+// Used for store-and-forward uploads; statuses are decided before persistence.
+func (o *Ops) PersistReadings(ctx context.Context, inputs []PersistReadingInput) ([]Reading, error) {
+	repoInputs := make([]readingrepo.PersistReadingInput, len(inputs))
+	for i, in := range inputs {
+		repoInputs[i] = toRepoPersistInput(in)
+	}
+	results, err := o.repo.PersistBatch(ctx, repoInputs)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Reading, len(results))
+	for i, r := range results {
+		out[i] = *fromRepoReading(&r)
+	}
+	return out, nil
+}
+
+// FindDuplicateCandidates reads a device's readings that share a message ID or fall
+// within a time range, for duplicate detection before persistence.
+func (o *Ops) FindDuplicateCandidates(ctx context.Context, input DuplicateCandidatesInput) ([]Reading, error) {
+	results, err := o.repo.FindDuplicateCandidates(ctx, readingrepo.DuplicateCandidatesInput{
+		DeviceID:   input.DeviceID,
+		CampaignID: input.CampaignID,
+		MessageIDs: input.MessageIDs,
+		Since:      input.Since,
+		Until:      input.Until,
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Reading, len(results))
+	for i, r := range results {
+		out[i] = *fromRepoReading(&r)
+	}
+	return out, nil
+}
+
 // QuarantineReading flags a reading as quarantined.
 // Op #21: FR-025
 func (o *Ops) QuarantineReading(ctx context.Context, id string, reason string) error {
@@ -88,11 +126,20 @@ func (o *Ops) GetCampaignDeviceBreakdown(ctx context.Context, campaignID string,
 	out := make([]DeviceBreakdown, len(results))
 	for i, r := range results {
 		out[i] = DeviceBreakdown{
-			PseudoDeviceID: r.PseudoDeviceID,
-			DeviceClass:    r.DeviceClass,
-			AcceptanceRate: r.AcceptanceRate,
-			ReadingCount:   r.ReadingCount,
-			LastSeen:       r.LastSeen,
+			PseudoDeviceID:  r.PseudoDeviceID,
+			DeviceClass:     r.DeviceClass,
+			AcceptanceRate:  r.AcceptanceRate,
+			ReadingCount:    r.ReadingCount,
+			LastSeen:        r.LastSeen,
+			Online:          r.Online,
+			LastConnectedAt: r.LastConnectedAt,
+			BatteryPercent:  r.BatteryPercent,
+			RSSIDbm:         r.RSSIDbm,
+			LastHeartbeatAt: r.LastHeartbeatAt,
+			HealthAlerts:    r.HealthAlerts,
+
+			ConfigVersion:      r.ConfigVersion,
+			ConfigAckedVersion: r.ConfigAckedVersion,
 		}
 	}
 	return out, nil
@@ -142,18 +189,26 @@ func toRepoPersistInput(in PersistReadingInput) readingrepo.PersistReadingInput
 	values := make([]readingrepo.ReadingValueInput, len(in.Values))
 	for i, v := range in.Values {
 		values[i] = readingrepo.ReadingValueInput{
-			ParameterName: v.ParameterName,
-			Value:         v.Value,
+			ParameterName:    v.ParameterName,
+			Value:            v.Value,
+			Status:           v.Status,
+			QuarantineReason: v.QuarantineReason,
+			Unit:             v.Unit,
+			OriginalValue:    v.OriginalValue,
+			OriginalUnit:     v.OriginalUnit,
 		}
 	}
 	return readingrepo.PersistReadingInput{
-		DeviceID:        in.DeviceID,
-		CampaignID:      in.CampaignID,
-		Values:          values,
-		Timestamp:       in.Timestamp,
-		Geolocation:     in.Geolocation,
-		FirmwareVersion: in.FirmwareVersion,
-		CertSerial:      in.CertSerial,
+		DeviceID:         in.DeviceID,
+		CampaignID:       in.CampaignID,
+		Values:           values,
+		Timestamp:        in.Timestamp,
+		Geolocation:      in.Geolocation,
+		FirmwareVersion:  in.FirmwareVersion,
+		CertSerial:       in.CertSerial,
+		Status:           in.Status,
+		QuarantineReason: in.QuarantineReason,
+		MessageID:        in.MessageID,
 	}
 }
 
@@ -191,6 +246,7 @@ func fromRepoReading(r *readingrepo.Reading) *Reading {
 		IngestedAt:       r.IngestedAt,
 		Status:           r.Status,
 		QuarantineReason: r.QuarantineReason,
+		MessageID:        r.MessageID,
 	}
 	for _, rv := range r.Values {
 		rd.Values = append(rd.Values, ReadingValue{
@@ -200,6 +256,9 @@ func fromRepoReading(r *readingrepo.Reading) *Reading {
 			Value:            rv.Value,
 			Status:           rv.Status,
 			QuarantineReason: rv.QuarantineReason,
+			Unit:             rv.Unit,
+			OriginalValue:    rv.OriginalValue,
+			OriginalUnit:     rv.OriginalUnit,
 		})
 	}
 	return rd
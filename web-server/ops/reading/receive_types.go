@@ -4,19 +4,38 @@ import "time"
 
 // ReadingValueInput is a single parameter measurement to persist.
 type ReadingValueInput struct {
-	ParameterName string
-	Value         float64
+	ParameterName    string
+	Value            float64
+	Status           string // accepted | quarantined | excluded; "" means accepted
+	QuarantineReason string
+
+	// Set when Value was converted from the unit the device reported in.
+	Unit          string
+	OriginalValue *float64
+	OriginalUnit  string
 }
 
 // PersistReadingInput is what callers send to PersistReading.
 type PersistReadingInput struct {
-	DeviceID        string
-	CampaignID      string
-	Values          []ReadingValueInput
-	Timestamp       time.Time
-	Geolocation     string
-	FirmwareVersion string
-	CertSerial      string
+	DeviceID         string
+	CampaignID       string
+	Values           []ReadingValueInput
+	Timestamp        time.Time
+	Geolocation      string
+	FirmwareVersion  string
+	CertSerial       string
+	Status           string // accepted | quarantined; "" means accepted
+	QuarantineReason string
+	MessageID        string
+}
+
+// DuplicateCandidatesInput is what callers send to FindDuplicateCandidates.
+type DuplicateCandidatesInput struct {
+	DeviceID   string
+	CampaignID string
+	MessageIDs []string
+	Since      time.Time
+	Until      time.Time
 }
 
 // QueryReadingsInput is what callers send to QueryReadings.
@@ -106,21 +106,29 @@ type DeviceSummary struct {
 	Sensors           []string
 	ActiveEnrollments int
 	LastSeen          *time.Time
+	Health            *DeviceHealth // nil until the device sends a heartbeat
 }
 
 // DeviceDetail is full device info.
 type DeviceDetail struct {
-	ID                string
-	OwnerID           string
-	Status            string
-	Class             string
-	FirmwareVersion   string
-	Tier              int
-	Sensors           []string
-	CertSerial        *string
-	CreatedAt         time.Time
-	Enrollments       []Enrollment
-	ConnectionHistory []ConnectionEvent
+	ID                 string
+	OwnerID            string
+	Status             string
+	Class              string
+	FirmwareVersion    string
+	Tier               int
+	Sensors            []string
+	CertSerial         *string
+	CreatedAt          time.Time
+	Enrollments        []Enrollment
+	ConnectionHistory  []ConnectionEvent
+	ClockSkewMs        *int64     // smoothed device clock minus server time
+	ClockSkewFlaggedAt *time.Time // set while the owner has been told the clock is wrong
+	Online             bool       // the device has an open broker session
+	Sessions           []DeviceSession
+	Health             *DeviceHealth // nil until the device sends a heartbeat
+	ConfigVersion      *int64        // campaign config last pushed; nil if none
+	ConfigAckedVersion *int64        // config the device last acknowledged
 }
 
 // ConnectionEvent is a device connection history entry.
@@ -130,6 +138,31 @@ type ConnectionEvent struct {
 	Reason    *string
 }
 
+// DeviceHealth is a device's latest heartbeat and the health alerts raised for it.
+type DeviceHealth struct {
+	ReportedAt      time.Time // device clock
+	ReceivedAt      time.Time
+	BatteryPercent  *float64
+	RSSIDbm         *int
+	UptimeSeconds   *int64
+	FreeMemoryBytes *int64
+	SensorFaults    []string
+	FirmwareBuild   *string
+	Alerts          []string // low_battery, sensor_fault, silent
+}
+
+// DeviceSession is one broker session.
+type DeviceSession struct {
+	ConnectedAt      time.Time
+	DisconnectedAt   *time.Time // nil while connected
+	DurationSeconds  int64      // so far, for an open session
+	RemoteAddr       string
+	CertSerial       *string
+	DisconnectReason *string
+	Graceful         *bool // the device sent DISCONNECT
+	WillPublished    *bool // the broker published the device's last will as it dropped
+}
+
 // Notification is an in-app notification record.
 type Notification struct {
 	ID           string
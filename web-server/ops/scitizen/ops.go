@@ -142,6 +142,7 @@ func (o *Ops) GetDevices(ctx context.Context, ownerID string) ([]DeviceSummary,
 			Sensors:           r.Sensors,
 			ActiveEnrollments: r.ActiveEnrollments,
 			LastSeen:          r.LastSeen,
+			Health:            fromRepoDeviceHealth(r.Health),
 		}
 	}
 	return out, nil
@@ -261,6 +262,18 @@ func fromRepoCampaignDetail(r *scitizenrepo.CampaignDetail) *CampaignDetail {
 	}
 }
 
+func fromRepoDeviceHealth(r *scitizenrepo.DeviceHealth) *DeviceHealth {
+	if r == nil {
+		return nil
+	}
+	return &DeviceHealth{
+		ReportedAt: r.ReportedAt, ReceivedAt: r.ReceivedAt,
+		BatteryPercent: r.BatteryPercent, RSSIDbm: r.RSSIDbm, UptimeSeconds: r.UptimeSeconds,
+		FreeMemoryBytes: r.FreeMemoryBytes, SensorFaults: r.SensorFaults, FirmwareBuild: r.FirmwareBuild,
+		Alerts: r.Alerts,
+	}
+}
+
 func fromRepoDeviceDetail(r *scitizenrepo.DeviceDetail) *DeviceDetail {
 	enrollments := make([]Enrollment, len(r.Enrollments))
 	for i, e := range r.Enrollments {
@@ -273,10 +286,21 @@ func fromRepoDeviceDetail(r *scitizenrepo.DeviceDetail) *DeviceDetail {
 	for i, c := range r.ConnectionHistory {
 		connHistory[i] = ConnectionEvent{EventType: c.EventType, Timestamp: c.Timestamp, Reason: c.Reason}
 	}
+	sessions := make([]DeviceSession, len(r.Sessions))
+	for i, s := range r.Sessions {
+		sessions[i] = DeviceSession{
+			ConnectedAt: s.ConnectedAt, DisconnectedAt: s.DisconnectedAt, DurationSeconds: s.DurationSeconds,
+			RemoteAddr: s.RemoteAddr, CertSerial: s.CertSerial, DisconnectReason: s.DisconnectReason, Graceful: s.Graceful,
+			WillPublished: s.WillPublished,
+		}
+	}
 	return &DeviceDetail{
 		ID: r.ID, OwnerID: r.OwnerID, Status: r.Status, Class: r.Class,
 		FirmwareVersion: r.FirmwareVersion, Tier: r.Tier, Sensors: r.Sensors,
 		CertSerial: r.CertSerial, CreatedAt: r.CreatedAt,
 		Enrollments: enrollments, ConnectionHistory: connHistory,
+		ClockSkewMs: r.ClockSkewMs, ClockSkewFlaggedAt: r.ClockSkewFlaggedAt,
+		Online: r.Online, Sessions: sessions, Health: fromRepoDeviceHealth(r.Health),
+		ConfigVersion: r.ConfigVersion, ConfigAckedVersion: r.ConfigAckedVersion,
 	}
 }
@@ -108,6 +108,7 @@ type ParameterProto struct {
 	MinRange      *float64               `protobuf:"fixed64,3,opt,name=min_range,json=minRange,proto3,oneof" json:"min_range,omitempty"`
 	MaxRange      *float64               `protobuf:"fixed64,4,opt,name=max_range,json=maxRange,proto3,oneof" json:"max_range,omitempty"`
 	Precision     *int32                 `protobuf:"varint,5,opt,name=precision,proto3,oneof" json:"precision,omitempty"`
+	Required      bool                   `protobuf:"varint,6,opt,name=required,proto3" json:"required,omitempty"` // reading is quarantined when this parameter is absent
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -177,6 +178,13 @@ func (x *ParameterProto) GetPrecision() int32 {
 	return 0
 }
 
+func (x *ParameterProto) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
 type RegionProto struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	GeoJson       string                 `protobuf:"bytes,1,opt,name=geo_json,json=geoJson,proto3" json:"geo_json,omitempty"`
@@ -382,16 +390,19 @@ func (x *CampaignProto) GetCreatedAt() string {
 }
 
 type CreateCampaignRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
-	CreatedBy     string                 `protobuf:"bytes,2,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
-	WindowStart   *string                `protobuf:"bytes,3,opt,name=window_start,json=windowStart,proto3,oneof" json:"window_start,omitempty"`
-	WindowEnd     *string                `protobuf:"bytes,4,opt,name=window_end,json=windowEnd,proto3,oneof" json:"window_end,omitempty"`
-	Parameters    []*ParameterProto      `protobuf:"bytes,5,rep,name=parameters,proto3" json:"parameters,omitempty"`
-	Regions       []*RegionProto         `protobuf:"bytes,6,rep,name=regions,proto3" json:"regions,omitempty"`
-	Eligibility   []*EligibilityProto    `protobuf:"bytes,7,rep,name=eligibility,proto3" json:"eligibility,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state                      protoimpl.MessageState `protogen:"open.v1"`
+	OrgId                      string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	CreatedBy                  string                 `protobuf:"bytes,2,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	WindowStart                *string                `protobuf:"bytes,3,opt,name=window_start,json=windowStart,proto3,oneof" json:"window_start,omitempty"`
+	WindowEnd                  *string                `protobuf:"bytes,4,opt,name=window_end,json=windowEnd,proto3,oneof" json:"window_end,omitempty"`
+	Parameters                 []*ParameterProto      `protobuf:"bytes,5,rep,name=parameters,proto3" json:"parameters,omitempty"`
+	Regions                    []*RegionProto         `protobuf:"bytes,6,rep,name=regions,proto3" json:"regions,omitempty"`
+	Eligibility                []*EligibilityProto    `protobuf:"bytes,7,rep,name=eligibility,proto3" json:"eligibility,omitempty"`
+	PrecisionPolicy            string                 `protobuf:"bytes,8,opt,name=precision_policy,json=precisionPolicy,proto3" json:"precision_policy,omitempty"`                                              // "round" (default) or "quarantine"
+	UndeclaredParameterPolicy  string                 `protobuf:"bytes,9,opt,name=undeclared_parameter_policy,json=undeclaredParameterPolicy,proto3" json:"undeclared_parameter_policy,omitempty"`              // "drop", "exclude" (default) or "quarantine"
+	MinSamplingIntervalSeconds *int32                 `protobuf:"varint,10,opt,name=min_sampling_interval_seconds,json=minSamplingIntervalSeconds,proto3,oneof" json:"min_sampling_interval_seconds,omitempty"` // closer readings from one device are quarantined
+	unknownFields              protoimpl.UnknownFields
+	sizeCache                  protoimpl.SizeCache
 }
 
 func (x *CreateCampaignRequest) Reset() {
@@ -473,6 +484,27 @@ func (x *CreateCampaignRequest) GetEligibility() []*EligibilityProto {
 	return nil
 }
 
+func (x *CreateCampaignRequest) GetPrecisionPolicy() string {
+	if x != nil {
+		return x.PrecisionPolicy
+	}
+	return ""
+}
+
+func (x *CreateCampaignRequest) GetUndeclaredParameterPolicy() string {
+	if x != nil {
+		return x.UndeclaredParameterPolicy
+	}
+	return ""
+}
+
+func (x *CreateCampaignRequest) GetMinSamplingIntervalSeconds() int32 {
+	if x != nil && x.MinSamplingIntervalSeconds != nil {
+		return *x.MinSamplingIntervalSeconds
+	}
+	return 0
+}
+
 type CreateCampaignResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Campaign      *CampaignProto         `protobuf:"bytes,1,opt,name=campaign,proto3" json:"campaign,omitempty"`
@@ -822,14 +854,23 @@ func (x *ParameterQualityProto) GetQuarantinedCount() int32 {
 }
 
 type DeviceBreakdownProto struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	PseudoDeviceId string                 `protobuf:"bytes,1,opt,name=pseudo_device_id,json=pseudoDeviceId,proto3" json:"pseudo_device_id,omitempty"`
-	DeviceClass    string                 `protobuf:"bytes,2,opt,name=device_class,json=deviceClass,proto3" json:"device_class,omitempty"`
-	AcceptanceRate float64                `protobuf:"fixed64,3,opt,name=acceptance_rate,json=acceptanceRate,proto3" json:"acceptance_rate,omitempty"`
-	ReadingCount   int32                  `protobuf:"varint,4,opt,name=reading_count,json=readingCount,proto3" json:"reading_count,omitempty"`
-	LastSeen       string                 `protobuf:"bytes,5,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	PseudoDeviceId     string                 `protobuf:"bytes,1,opt,name=pseudo_device_id,json=pseudoDeviceId,proto3" json:"pseudo_device_id,omitempty"`
+	DeviceClass        string                 `protobuf:"bytes,2,opt,name=device_class,json=deviceClass,proto3" json:"device_class,omitempty"`
+	AcceptanceRate     float64                `protobuf:"fixed64,3,opt,name=acceptance_rate,json=acceptanceRate,proto3" json:"acceptance_rate,omitempty"`
+	ReadingCount       int32                  `protobuf:"varint,4,opt,name=reading_count,json=readingCount,proto3" json:"reading_count,omitempty"`
+	LastSeen           string                 `protobuf:"bytes,5,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
+	Online             bool                   `protobuf:"varint,6,opt,name=online,proto3" json:"online,omitempty"`                                                 // has an open broker session
+	LastConnectedAt    *string                `protobuf:"bytes,7,opt,name=last_connected_at,json=lastConnectedAt,proto3,oneof" json:"last_connected_at,omitempty"` // start of the latest broker session
+	BatteryPercent     *float64               `protobuf:"fixed64,8,opt,name=battery_percent,json=batteryPercent,proto3,oneof" json:"battery_percent,omitempty"`    // from the latest heartbeat
+	RssiDbm            *int32                 `protobuf:"varint,9,opt,name=rssi_dbm,json=rssiDbm,proto3,oneof" json:"rssi_dbm,omitempty"`
+	LastHeartbeatAt    *string                `protobuf:"bytes,10,opt,name=last_heartbeat_at,json=lastHeartbeatAt,proto3,oneof" json:"last_heartbeat_at,omitempty"`
+	HealthAlerts       []string               `protobuf:"bytes,11,rep,name=health_alerts,json=healthAlerts,proto3" json:"health_alerts,omitempty"`                            // low_battery, sensor_fault, silent
+	ConfigVersion      *int64                 `protobuf:"varint,12,opt,name=config_version,json=configVersion,proto3,oneof" json:"config_version,omitempty"`                  // config last pushed to the device
+	ConfigAckedVersion *int64                 `protobuf:"varint,13,opt,name=config_acked_version,json=configAckedVersion,proto3,oneof" json:"config_acked_version,omitempty"` // config the device last acknowledged
+	ConfigStale        bool                   `protobuf:"varint,14,opt,name=config_stale,json=configStale,proto3" json:"config_stale,omitempty"`                              // the device has not acknowledged its current config
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *DeviceBreakdownProto) Reset() {
@@ -897,6 +938,69 @@ func (x *DeviceBreakdownProto) GetLastSeen() string {
 	return ""
 }
 
+func (x *DeviceBreakdownProto) GetOnline() bool {
+	if x != nil {
+		return x.Online
+	}
+	return false
+}
+
+func (x *DeviceBreakdownProto) GetLastConnectedAt() string {
+	if x != nil && x.LastConnectedAt != nil {
+		return *x.LastConnectedAt
+	}
+	return ""
+}
+
+func (x *DeviceBreakdownProto) GetBatteryPercent() float64 {
+	if x != nil && x.BatteryPercent != nil {
+		return *x.BatteryPercent
+	}
+	return 0
+}
+
+func (x *DeviceBreakdownProto) GetRssiDbm() int32 {
+	if x != nil && x.RssiDbm != nil {
+		return *x.RssiDbm
+	}
+	return 0
+}
+
+func (x *DeviceBreakdownProto) GetLastHeartbeatAt() string {
+	if x != nil && x.LastHeartbeatAt != nil {
+		return *x.LastHeartbeatAt
+	}
+	return ""
+}
+
+func (x *DeviceBreakdownProto) GetHealthAlerts() []string {
+	if x != nil {
+		return x.HealthAlerts
+	}
+	return nil
+}
+
+func (x *DeviceBreakdownProto) GetConfigVersion() int64 {
+	if x != nil && x.ConfigVersion != nil {
+		return *x.ConfigVersion
+	}
+	return 0
+}
+
+func (x *DeviceBreakdownProto) GetConfigAckedVersion() int64 {
+	if x != nil && x.ConfigAckedVersion != nil {
+		return *x.ConfigAckedVersion
+	}
+	return 0
+}
+
+func (x *DeviceBreakdownProto) GetConfigStale() bool {
+	if x != nil {
+		return x.ConfigStale
+	}
+	return false
+}
+
 type EnrollmentFunnelProto struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Enrolled      int32                  `protobuf:"varint,1,opt,name=enrolled,proto3" json:"enrolled,omitempty"`
@@ -1102,15 +1206,16 @@ func (x *GetCampaignDashboardResponse) GetTemporalCoverage() []*TemporalBucketPr
 }
 
 type ExportedReadingProto struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	PseudoDeviceId  string                 `protobuf:"bytes,1,opt,name=pseudo_device_id,json=pseudoDeviceId,proto3" json:"pseudo_device_id,omitempty"`
-	CampaignId      string                 `protobuf:"bytes,2,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
-	Values          map[string]float64     `protobuf:"bytes,3,rep,name=values,proto3" json:"values,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
-	Timestamp       string                 `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	Geolocation     *string                `protobuf:"bytes,5,opt,name=geolocation,proto3,oneof" json:"geolocation,omitempty"`
-	FirmwareVersion string                 `protobuf:"bytes,6,opt,name=firmware_version,json=firmwareVersion,proto3" json:"firmware_version,omitempty"`
-	IngestedAt      string                 `protobuf:"bytes,7,opt,name=ingested_at,json=ingestedAt,proto3" json:"ingested_at,omitempty"`
-	Status          string                 `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
+	state           protoimpl.MessageState           `protogen:"open.v1"`
+	PseudoDeviceId  string                           `protobuf:"bytes,1,opt,name=pseudo_device_id,json=pseudoDeviceId,proto3" json:"pseudo_device_id,omitempty"`
+	CampaignId      string                           `protobuf:"bytes,2,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	Values          map[string]float64               `protobuf:"bytes,3,rep,name=values,proto3" json:"values,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	Timestamp       string                           `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Geolocation     *string                          `protobuf:"bytes,5,opt,name=geolocation,proto3,oneof" json:"geolocation,omitempty"`
+	FirmwareVersion string                           `protobuf:"bytes,6,opt,name=firmware_version,json=firmwareVersion,proto3" json:"firmware_version,omitempty"`
+	IngestedAt      string                           `protobuf:"bytes,7,opt,name=ingested_at,json=ingestedAt,proto3" json:"ingested_at,omitempty"`
+	Status          string                           `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
+	Conversions     map[string]*ValueConversionProto `protobuf:"bytes,9,rep,name=conversions,proto3" json:"conversions,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // values converted to the campaign unit at ingestion
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
@@ -1201,6 +1306,74 @@ func (x *ExportedReadingProto) GetStatus() string {
 	return ""
 }
 
+func (x *ExportedReadingProto) GetConversions() map[string]*ValueConversionProto {
+	if x != nil {
+		return x.Conversions
+	}
+	return nil
+}
+
+// ValueConversionProto is the provenance of a value converted at ingestion. Units are UCUM codes.
+type ValueConversionProto struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OriginalValue float64                `protobuf:"fixed64,1,opt,name=original_value,json=originalValue,proto3" json:"original_value,omitempty"`
+	OriginalUnit  string                 `protobuf:"bytes,2,opt,name=original_unit,json=originalUnit,proto3" json:"original_unit,omitempty"`
+	Unit          string                 `protobuf:"bytes,3,opt,name=unit,proto3" json:"unit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValueConversionProto) Reset() {
+	*x = ValueConversionProto{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValueConversionProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValueConversionProto) ProtoMessage() {}
+
+func (x *ValueConversionProto) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValueConversionProto.ProtoReflect.Descriptor instead.
+func (*ValueConversionProto) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ValueConversionProto) GetOriginalValue() float64 {
+	if x != nil {
+		return x.OriginalValue
+	}
+	return 0
+}
+
+func (x *ValueConversionProto) GetOriginalUnit() string {
+	if x != nil {
+		return x.OriginalUnit
+	}
+	return ""
+}
+
+func (x *ValueConversionProto) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
 type ExportCampaignDataRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	CampaignId    string                 `protobuf:"bytes,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
@@ -1212,7 +1385,7 @@ type ExportCampaignDataRequest struct {
 
 func (x *ExportCampaignDataRequest) Reset() {
 	*x = ExportCampaignDataRequest{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[19]
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1224,7 +1397,7 @@ func (x *ExportCampaignDataRequest) String() string {
 func (*ExportCampaignDataRequest) ProtoMessage() {}
 
 func (x *ExportCampaignDataRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[19]
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1237,7 +1410,7 @@ func (x *ExportCampaignDataRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExportCampaignDataRequest.ProtoReflect.Descriptor instead.
 func (*ExportCampaignDataRequest) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{19}
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *ExportCampaignDataRequest) GetCampaignId() string {
@@ -1270,7 +1443,7 @@ type ExportCampaignDataResponse struct {
 
 func (x *ExportCampaignDataResponse) Reset() {
 	*x = ExportCampaignDataResponse{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[20]
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1282,7 +1455,7 @@ func (x *ExportCampaignDataResponse) String() string {
 func (*ExportCampaignDataResponse) ProtoMessage() {}
 
 func (x *ExportCampaignDataResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[20]
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1295,7 +1468,7 @@ func (x *ExportCampaignDataResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExportCampaignDataResponse.ProtoReflect.Descriptor instead.
 func (*ExportCampaignDataResponse) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{20}
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *ExportCampaignDataResponse) GetReadings() []*ExportedReadingProto {
@@ -1314,7 +1487,7 @@ type CreateOrgRequest struct {
 
 func (x *CreateOrgRequest) Reset() {
 	*x = CreateOrgRequest{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[21]
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1326,7 +1499,7 @@ func (x *CreateOrgRequest) String() string {
 func (*CreateOrgRequest) ProtoMessage() {}
 
 func (x *CreateOrgRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[21]
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1339,7 +1512,7 @@ func (x *CreateOrgRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateOrgRequest.ProtoReflect.Descriptor instead.
 func (*CreateOrgRequest) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{21}
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *CreateOrgRequest) GetName() string {
@@ -1359,7 +1532,7 @@ type CreateOrgResponse struct {
 
 func (x *CreateOrgResponse) Reset() {
 	*x = CreateOrgResponse{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[22]
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1371,7 +1544,7 @@ func (x *CreateOrgResponse) String() string {
 func (*CreateOrgResponse) ProtoMessage() {}
 
 func (x *CreateOrgResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[22]
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1384,7 +1557,7 @@ func (x *CreateOrgResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateOrgResponse.ProtoReflect.Descriptor instead.
 func (*CreateOrgResponse) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{22}
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *CreateOrgResponse) GetOrgId() string {
@@ -1411,7 +1584,7 @@ type NestOrgRequest struct {
 
 func (x *NestOrgRequest) Reset() {
 	*x = NestOrgRequest{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[23]
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1423,7 +1596,7 @@ func (x *NestOrgRequest) String() string {
 func (*NestOrgRequest) ProtoMessage() {}
 
 func (x *NestOrgRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[23]
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1436,7 +1609,7 @@ func (x *NestOrgRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NestOrgRequest.ProtoReflect.Descriptor instead.
 func (*NestOrgRequest) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{23}
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *NestOrgRequest) GetName() string {
@@ -1463,7 +1636,7 @@ type NestOrgResponse struct {
 
 func (x *NestOrgResponse) Reset() {
 	*x = NestOrgResponse{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[24]
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1475,7 +1648,7 @@ func (x *NestOrgResponse) String() string {
 func (*NestOrgResponse) ProtoMessage() {}
 
 func (x *NestOrgResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[24]
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1488,7 +1661,7 @@ func (x *NestOrgResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NestOrgResponse.ProtoReflect.Descriptor instead.
 func (*NestOrgResponse) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{24}
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *NestOrgResponse) GetOrgId() string {
@@ -1516,7 +1689,7 @@ type DefineRoleRequest struct {
 
 func (x *DefineRoleRequest) Reset() {
 	*x = DefineRoleRequest{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[25]
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1528,7 +1701,7 @@ func (x *DefineRoleRequest) String() string {
 func (*DefineRoleRequest) ProtoMessage() {}
 
 func (x *DefineRoleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[25]
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1541,7 +1714,7 @@ func (x *DefineRoleRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DefineRoleRequest.ProtoReflect.Descriptor instead.
 func (*DefineRoleRequest) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{25}
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *DefineRoleRequest) GetProjectId() string {
@@ -1576,7 +1749,7 @@ type DefineRoleResponse struct {
 
 func (x *DefineRoleResponse) Reset() {
 	*x = DefineRoleResponse{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[26]
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1588,7 +1761,7 @@ func (x *DefineRoleResponse) String() string {
 func (*DefineRoleResponse) ProtoMessage() {}
 
 func (x *DefineRoleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[26]
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1601,7 +1774,7 @@ func (x *DefineRoleResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DefineRoleResponse.ProtoReflect.Descriptor instead.
 func (*DefineRoleResponse) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{26}
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *DefineRoleResponse) GetProjectId() string {
@@ -1636,7 +1809,7 @@ type AssignRoleRequest struct {
 
 func (x *AssignRoleRequest) Reset() {
 	*x = AssignRoleRequest{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[27]
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1648,7 +1821,7 @@ func (x *AssignRoleRequest) String() string {
 func (*AssignRoleRequest) ProtoMessage() {}
 
 func (x *AssignRoleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[27]
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1661,7 +1834,7 @@ func (x *AssignRoleRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AssignRoleRequest.ProtoReflect.Descriptor instead.
 func (*AssignRoleRequest) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{27}
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *AssignRoleRequest) GetUserId() string {
@@ -1697,7 +1870,7 @@ type AssignRoleResponse struct {
 
 func (x *AssignRoleResponse) Reset() {
 	*x = AssignRoleResponse{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[28]
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1709,7 +1882,7 @@ func (x *AssignRoleResponse) String() string {
 func (*AssignRoleResponse) ProtoMessage() {}
 
 func (x *AssignRoleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[28]
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1722,7 +1895,7 @@ func (x *AssignRoleResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AssignRoleResponse.ProtoReflect.Descriptor instead.
 func (*AssignRoleResponse) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{28}
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *AssignRoleResponse) GetUserGrantId() string {
@@ -1765,7 +1938,7 @@ type InviteUserRequest struct {
 
 func (x *InviteUserRequest) Reset() {
 	*x = InviteUserRequest{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[29]
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1777,7 +1950,7 @@ func (x *InviteUserRequest) String() string {
 func (*InviteUserRequest) ProtoMessage() {}
 
 func (x *InviteUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[29]
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1790,7 +1963,7 @@ func (x *InviteUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InviteUserRequest.ProtoReflect.Descriptor instead.
 func (*InviteUserRequest) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{29}
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *InviteUserRequest) GetOrgId() string {
@@ -1831,7 +2004,7 @@ type InviteUserResponse struct {
 
 func (x *InviteUserResponse) Reset() {
 	*x = InviteUserResponse{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[30]
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1843,7 +2016,7 @@ func (x *InviteUserResponse) String() string {
 func (*InviteUserResponse) ProtoMessage() {}
 
 func (x *InviteUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[30]
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1856,7 +2029,7 @@ func (x *InviteUserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InviteUserResponse.ProtoReflect.Descriptor instead.
 func (*InviteUserResponse) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{30}
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *InviteUserResponse) GetUserId() string {
@@ -1884,7 +2057,7 @@ type BadgeProto struct {
 
 func (x *BadgeProto) Reset() {
 	*x = BadgeProto{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[31]
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1896,7 +2069,7 @@ func (x *BadgeProto) String() string {
 func (*BadgeProto) ProtoMessage() {}
 
 func (x *BadgeProto) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[31]
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1909,7 +2082,7 @@ func (x *BadgeProto) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BadgeProto.ProtoReflect.Descriptor instead.
 func (*BadgeProto) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{31}
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *BadgeProto) GetId() string {
@@ -1942,7 +2115,7 @@ type GetContributionRequest struct {
 
 func (x *GetContributionRequest) Reset() {
 	*x = GetContributionRequest{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[32]
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1954,7 +2127,7 @@ func (x *GetContributionRequest) String() string {
 func (*GetContributionRequest) ProtoMessage() {}
 
 func (x *GetContributionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[32]
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1967,7 +2140,7 @@ func (x *GetContributionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetContributionRequest.ProtoReflect.Descriptor instead.
 func (*GetContributionRequest) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{32}
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *GetContributionRequest) GetScitizenId() string {
@@ -1993,7 +2166,7 @@ type GetContributionResponse struct {
 
 func (x *GetContributionResponse) Reset() {
 	*x = GetContributionResponse{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[33]
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2005,7 +2178,7 @@ func (x *GetContributionResponse) String() string {
 func (*GetContributionResponse) ProtoMessage() {}
 
 func (x *GetContributionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[33]
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2018,7 +2191,7 @@ func (x *GetContributionResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetContributionResponse.ProtoReflect.Descriptor instead.
 func (*GetContributionResponse) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{33}
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *GetContributionResponse) GetScitizenId() string {
@@ -2088,13 +2261,15 @@ type DeviceProto struct {
 	Sensors         []string               `protobuf:"bytes,7,rep,name=sensors,proto3" json:"sensors,omitempty"`
 	CertSerial      *string                `protobuf:"bytes,8,opt,name=cert_serial,json=certSerial,proto3,oneof" json:"cert_serial,omitempty"`
 	CreatedAt       string                 `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Gateway         bool                   `protobuf:"varint,10,opt,name=gateway,proto3" json:"gateway,omitempty"`                           // enrolls and relays for child devices
+	GatewayId       *string                `protobuf:"bytes,11,opt,name=gateway_id,json=gatewayId,proto3,oneof" json:"gateway_id,omitempty"` // gateway the device was enrolled through
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
 
 func (x *DeviceProto) Reset() {
 	*x = DeviceProto{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[34]
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2106,7 +2281,7 @@ func (x *DeviceProto) String() string {
 func (*DeviceProto) ProtoMessage() {}
 
 func (x *DeviceProto) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[34]
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2119,7 +2294,7 @@ func (x *DeviceProto) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeviceProto.ProtoReflect.Descriptor instead.
 func (*DeviceProto) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{34}
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *DeviceProto) GetId() string {
@@ -2185,6 +2360,20 @@ func (x *DeviceProto) GetCreatedAt() string {
 	return ""
 }
 
+func (x *DeviceProto) GetGateway() bool {
+	if x != nil {
+		return x.Gateway
+	}
+	return false
+}
+
+func (x *DeviceProto) GetGatewayId() string {
+	if x != nil && x.GatewayId != nil {
+		return *x.GatewayId
+	}
+	return ""
+}
+
 type GetDeviceRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	DeviceId      string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
@@ -2194,7 +2383,7 @@ type GetDeviceRequest struct {
 
 func (x *GetDeviceRequest) Reset() {
 	*x = GetDeviceRequest{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[35]
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2206,7 +2395,7 @@ func (x *GetDeviceRequest) String() string {
 func (*GetDeviceRequest) ProtoMessage() {}
 
 func (x *GetDeviceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[35]
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2219,7 +2408,7 @@ func (x *GetDeviceRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetDeviceRequest.ProtoReflect.Descriptor instead.
 func (*GetDeviceRequest) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{35}
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *GetDeviceRequest) GetDeviceId() string {
@@ -2238,7 +2427,7 @@ type GetDeviceResponse struct {
 
 func (x *GetDeviceResponse) Reset() {
 	*x = GetDeviceResponse{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[36]
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2250,7 +2439,7 @@ func (x *GetDeviceResponse) String() string {
 func (*GetDeviceResponse) ProtoMessage() {}
 
 func (x *GetDeviceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[36]
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2263,7 +2452,7 @@ func (x *GetDeviceResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetDeviceResponse.ProtoReflect.Descriptor instead.
 func (*GetDeviceResponse) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{36}
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *GetDeviceResponse) GetDevice() *DeviceProto {
@@ -2282,7 +2471,7 @@ type RevokeDeviceRequest struct {
 
 func (x *RevokeDeviceRequest) Reset() {
 	*x = RevokeDeviceRequest{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[37]
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2294,7 +2483,7 @@ func (x *RevokeDeviceRequest) String() string {
 func (*RevokeDeviceRequest) ProtoMessage() {}
 
 func (x *RevokeDeviceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[37]
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2307,7 +2496,7 @@ func (x *RevokeDeviceRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RevokeDeviceRequest.ProtoReflect.Descriptor instead.
 func (*RevokeDeviceRequest) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{37}
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *RevokeDeviceRequest) GetDeviceId() string {
@@ -2325,7 +2514,7 @@ type RevokeDeviceResponse struct {
 
 func (x *RevokeDeviceResponse) Reset() {
 	*x = RevokeDeviceResponse{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[38]
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2337,7 +2526,7 @@ func (x *RevokeDeviceResponse) String() string {
 func (*RevokeDeviceResponse) ProtoMessage() {}
 
 func (x *RevokeDeviceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[38]
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2350,7 +2539,7 @@ func (x *RevokeDeviceResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RevokeDeviceResponse.ProtoReflect.Descriptor instead.
 func (*RevokeDeviceResponse) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{38}
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{39}
 }
 
 type ReinstateDeviceRequest struct {
@@ -2362,7 +2551,7 @@ type ReinstateDeviceRequest struct {
 
 func (x *ReinstateDeviceRequest) Reset() {
 	*x = ReinstateDeviceRequest{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[39]
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2374,7 +2563,7 @@ func (x *ReinstateDeviceRequest) String() string {
 func (*ReinstateDeviceRequest) ProtoMessage() {}
 
 func (x *ReinstateDeviceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[39]
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2387,7 +2576,7 @@ func (x *ReinstateDeviceRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReinstateDeviceRequest.ProtoReflect.Descriptor instead.
 func (*ReinstateDeviceRequest) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{39}
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{40}
 }
 
 func (x *ReinstateDeviceRequest) GetDeviceId() string {
@@ -2405,7 +2594,7 @@ type ReinstateDeviceResponse struct {
 
 func (x *ReinstateDeviceResponse) Reset() {
 	*x = ReinstateDeviceResponse{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[40]
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2417,7 +2606,7 @@ func (x *ReinstateDeviceResponse) String() string {
 func (*ReinstateDeviceResponse) ProtoMessage() {}
 
 func (x *ReinstateDeviceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[40]
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2430,7 +2619,7 @@ func (x *ReinstateDeviceResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReinstateDeviceResponse.ProtoReflect.Descriptor instead.
 func (*ReinstateDeviceResponse) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{40}
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{41}
 }
 
 type EnrollInCampaignRequest struct {
@@ -2443,7 +2632,7 @@ type EnrollInCampaignRequest struct {
 
 func (x *EnrollInCampaignRequest) Reset() {
 	*x = EnrollInCampaignRequest{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[41]
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2455,7 +2644,7 @@ func (x *EnrollInCampaignRequest) String() string {
 func (*EnrollInCampaignRequest) ProtoMessage() {}
 
 func (x *EnrollInCampaignRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[41]
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2468,7 +2657,7 @@ func (x *EnrollInCampaignRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EnrollInCampaignRequest.ProtoReflect.Descriptor instead.
 func (*EnrollInCampaignRequest) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{41}
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{42}
 }
 
 func (x *EnrollInCampaignRequest) GetDeviceId() string {
@@ -2495,7 +2684,7 @@ type EnrollInCampaignResponse struct {
 
 func (x *EnrollInCampaignResponse) Reset() {
 	*x = EnrollInCampaignResponse{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[42]
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2507,7 +2696,7 @@ func (x *EnrollInCampaignResponse) String() string {
 func (*EnrollInCampaignResponse) ProtoMessage() {}
 
 func (x *EnrollInCampaignResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[42]
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2520,7 +2709,7 @@ func (x *EnrollInCampaignResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EnrollInCampaignResponse.ProtoReflect.Descriptor instead.
 func (*EnrollInCampaignResponse) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{42}
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{43}
 }
 
 func (x *EnrollInCampaignResponse) GetEnrolled() bool {
@@ -2537,31 +2726,43 @@ func (x *EnrollInCampaignResponse) GetReason() string {
 	return ""
 }
 
-type UserProto struct {
+// A command sent to a device on rootstock/{id}/command. status is sent, acknowledged,
+// succeeded, failed, or expired when the device did not acknowledge it in time.
+type DeviceCommandProto struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	UserType      string                 `protobuf:"bytes,2,opt,name=user_type,json=userType,proto3" json:"user_type,omitempty"`
-	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	DeviceId      string                 `protobuf:"bytes,2,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	CampaignId    *string                `protobuf:"bytes,3,opt,name=campaign_id,json=campaignId,proto3,oneof" json:"campaign_id,omitempty"`
+	BatchId       string                 `protobuf:"bytes,4,opt,name=batch_id,json=batchId,proto3" json:"batch_id,omitempty"`
+	Command       string                 `protobuf:"bytes,5,opt,name=command,proto3" json:"command,omitempty"`
+	Params        []byte                 `protobuf:"bytes,6,opt,name=params,proto3" json:"params,omitempty"` // JSON
+	IssuedBy      string                 `protobuf:"bytes,7,opt,name=issued_by,json=issuedBy,proto3" json:"issued_by,omitempty"`
+	IssuedAt      string                 `protobuf:"bytes,8,opt,name=issued_at,json=issuedAt,proto3" json:"issued_at,omitempty"`
+	ExpiresAt     string                 `protobuf:"bytes,9,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Status        string                 `protobuf:"bytes,10,opt,name=status,proto3" json:"status,omitempty"`
+	AckedAt       *string                `protobuf:"bytes,11,opt,name=acked_at,json=ackedAt,proto3,oneof" json:"acked_at,omitempty"`
+	CompletedAt   *string                `protobuf:"bytes,12,opt,name=completed_at,json=completedAt,proto3,oneof" json:"completed_at,omitempty"`
+	Result        []byte                 `protobuf:"bytes,13,opt,name=result,proto3" json:"result,omitempty"` // JSON reported by the device, if any
+	Error         *string                `protobuf:"bytes,14,opt,name=error,proto3,oneof" json:"error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UserProto) Reset() {
-	*x = UserProto{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[43]
+func (x *DeviceCommandProto) Reset() {
+	*x = DeviceCommandProto{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UserProto) String() string {
+func (x *DeviceCommandProto) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UserProto) ProtoMessage() {}
+func (*DeviceCommandProto) ProtoMessage() {}
 
-func (x *UserProto) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[43]
+func (x *DeviceCommandProto) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2572,104 +2773,137 @@ func (x *UserProto) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UserProto.ProtoReflect.Descriptor instead.
-func (*UserProto) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{43}
+// Deprecated: Use DeviceCommandProto.ProtoReflect.Descriptor instead.
+func (*DeviceCommandProto) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{44}
 }
 
-func (x *UserProto) GetId() string {
+func (x *DeviceCommandProto) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-func (x *UserProto) GetUserType() string {
+func (x *DeviceCommandProto) GetDeviceId() string {
 	if x != nil {
-		return x.UserType
+		return x.DeviceId
 	}
 	return ""
 }
 
-func (x *UserProto) GetStatus() string {
+func (x *DeviceCommandProto) GetCampaignId() string {
+	if x != nil && x.CampaignId != nil {
+		return *x.CampaignId
+	}
+	return ""
+}
+
+func (x *DeviceCommandProto) GetBatchId() string {
 	if x != nil {
-		return x.Status
+		return x.BatchId
 	}
 	return ""
 }
 
-func (x *UserProto) GetCreatedAt() string {
+func (x *DeviceCommandProto) GetCommand() string {
 	if x != nil {
-		return x.CreatedAt
+		return x.Command
 	}
 	return ""
 }
 
-type RegisterUserRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserType      string                 `protobuf:"bytes,1,opt,name=user_type,json=userType,proto3" json:"user_type,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *DeviceCommandProto) GetParams() []byte {
+	if x != nil {
+		return x.Params
+	}
+	return nil
 }
 
-func (x *RegisterUserRequest) Reset() {
-	*x = RegisterUserRequest{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[44]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *DeviceCommandProto) GetIssuedBy() string {
+	if x != nil {
+		return x.IssuedBy
+	}
+	return ""
 }
 
-func (x *RegisterUserRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *DeviceCommandProto) GetIssuedAt() string {
+	if x != nil {
+		return x.IssuedAt
+	}
+	return ""
 }
 
-func (*RegisterUserRequest) ProtoMessage() {}
+func (x *DeviceCommandProto) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
 
-func (x *RegisterUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[44]
+func (x *DeviceCommandProto) GetStatus() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Status
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use RegisterUserRequest.ProtoReflect.Descriptor instead.
-func (*RegisterUserRequest) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{44}
+func (x *DeviceCommandProto) GetAckedAt() string {
+	if x != nil && x.AckedAt != nil {
+		return *x.AckedAt
+	}
+	return ""
 }
 
-func (x *RegisterUserRequest) GetUserType() string {
+func (x *DeviceCommandProto) GetCompletedAt() string {
+	if x != nil && x.CompletedAt != nil {
+		return *x.CompletedAt
+	}
+	return ""
+}
+
+func (x *DeviceCommandProto) GetResult() []byte {
 	if x != nil {
-		return x.UserType
+		return x.Result
+	}
+	return nil
+}
+
+func (x *DeviceCommandProto) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
 	}
 	return ""
 }
 
-type RegisterUserResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	User          *UserProto             `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// Set exactly one of device_id and campaign_id. command is set_sampling_interval
+// (interval_seconds), recalibrate (sensors; none means all), reboot or renew_certificate.
+type SendDeviceCommandRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId        string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	CampaignId      string                 `protobuf:"bytes,2,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	Command         string                 `protobuf:"bytes,3,opt,name=command,proto3" json:"command,omitempty"`
+	IntervalSeconds *int32                 `protobuf:"varint,4,opt,name=interval_seconds,json=intervalSeconds,proto3,oneof" json:"interval_seconds,omitempty"`
+	Sensors         []string               `protobuf:"bytes,5,rep,name=sensors,proto3" json:"sensors,omitempty"`
+	TtlSeconds      int32                  `protobuf:"varint,6,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"` // 0 takes the server default
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *RegisterUserResponse) Reset() {
-	*x = RegisterUserResponse{}
+func (x *SendDeviceCommandRequest) Reset() {
+	*x = SendDeviceCommandRequest{}
 	mi := &file_rootstock_v1_rootstock_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RegisterUserResponse) String() string {
+func (x *SendDeviceCommandRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RegisterUserResponse) ProtoMessage() {}
+func (*SendDeviceCommandRequest) ProtoMessage() {}
 
-func (x *RegisterUserResponse) ProtoReflect() protoreflect.Message {
+func (x *SendDeviceCommandRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_rootstock_v1_rootstock_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2681,38 +2915,76 @@ func (x *RegisterUserResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RegisterUserResponse.ProtoReflect.Descriptor instead.
-func (*RegisterUserResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use SendDeviceCommandRequest.ProtoReflect.Descriptor instead.
+func (*SendDeviceCommandRequest) Descriptor() ([]byte, []int) {
 	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{45}
 }
 
-func (x *RegisterUserResponse) GetUser() *UserProto {
+func (x *SendDeviceCommandRequest) GetDeviceId() string {
 	if x != nil {
-		return x.User
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *SendDeviceCommandRequest) GetCampaignId() string {
+	if x != nil {
+		return x.CampaignId
+	}
+	return ""
+}
+
+func (x *SendDeviceCommandRequest) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *SendDeviceCommandRequest) GetIntervalSeconds() int32 {
+	if x != nil && x.IntervalSeconds != nil {
+		return *x.IntervalSeconds
+	}
+	return 0
+}
+
+func (x *SendDeviceCommandRequest) GetSensors() []string {
+	if x != nil {
+		return x.Sensors
 	}
 	return nil
 }
 
-type GetMeRequest struct {
+func (x *SendDeviceCommandRequest) GetTtlSeconds() int32 {
+	if x != nil {
+		return x.TtlSeconds
+	}
+	return 0
+}
+
+type SendDeviceCommandResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	BatchId       string                 `protobuf:"bytes,1,opt,name=batch_id,json=batchId,proto3" json:"batch_id,omitempty"`
+	Commands      []*DeviceCommandProto  `protobuf:"bytes,2,rep,name=commands,proto3" json:"commands,omitempty"`
+	Published     int32                  `protobuf:"varint,3,opt,name=published,proto3" json:"published,omitempty"` // the rest are sent when their device next subscribes
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetMeRequest) Reset() {
-	*x = GetMeRequest{}
+func (x *SendDeviceCommandResponse) Reset() {
+	*x = SendDeviceCommandResponse{}
 	mi := &file_rootstock_v1_rootstock_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetMeRequest) String() string {
+func (x *SendDeviceCommandResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetMeRequest) ProtoMessage() {}
+func (*SendDeviceCommandResponse) ProtoMessage() {}
 
-func (x *GetMeRequest) ProtoReflect() protoreflect.Message {
+func (x *SendDeviceCommandResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_rootstock_v1_rootstock_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2724,32 +2996,56 @@ func (x *GetMeRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetMeRequest.ProtoReflect.Descriptor instead.
-func (*GetMeRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use SendDeviceCommandResponse.ProtoReflect.Descriptor instead.
+func (*SendDeviceCommandResponse) Descriptor() ([]byte, []int) {
 	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{46}
 }
 
-type GetMeResponse struct {
+func (x *SendDeviceCommandResponse) GetBatchId() string {
+	if x != nil {
+		return x.BatchId
+	}
+	return ""
+}
+
+func (x *SendDeviceCommandResponse) GetCommands() []*DeviceCommandProto {
+	if x != nil {
+		return x.Commands
+	}
+	return nil
+}
+
+func (x *SendDeviceCommandResponse) GetPublished() int32 {
+	if x != nil {
+		return x.Published
+	}
+	return 0
+}
+
+// Set exactly one of device_id and campaign_id.
+type ListDeviceCommandsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	User          *UserProto             `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	DeviceId      string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	CampaignId    string                 `protobuf:"bytes,2,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetMeResponse) Reset() {
-	*x = GetMeResponse{}
+func (x *ListDeviceCommandsRequest) Reset() {
+	*x = ListDeviceCommandsRequest{}
 	mi := &file_rootstock_v1_rootstock_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetMeResponse) String() string {
+func (x *ListDeviceCommandsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetMeResponse) ProtoMessage() {}
+func (*ListDeviceCommandsRequest) ProtoMessage() {}
 
-func (x *GetMeResponse) ProtoReflect() protoreflect.Message {
+func (x *ListDeviceCommandsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_rootstock_v1_rootstock_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2761,40 +3057,53 @@ func (x *GetMeResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetMeResponse.ProtoReflect.Descriptor instead.
-func (*GetMeResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListDeviceCommandsRequest.ProtoReflect.Descriptor instead.
+func (*ListDeviceCommandsRequest) Descriptor() ([]byte, []int) {
 	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{47}
 }
 
-func (x *GetMeResponse) GetUser() *UserProto {
+func (x *ListDeviceCommandsRequest) GetDeviceId() string {
 	if x != nil {
-		return x.User
+		return x.DeviceId
 	}
-	return nil
+	return ""
 }
 
-type LoginRequest struct {
+func (x *ListDeviceCommandsRequest) GetCampaignId() string {
+	if x != nil {
+		return x.CampaignId
+	}
+	return ""
+}
+
+func (x *ListDeviceCommandsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListDeviceCommandsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
-	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	Commands      []*DeviceCommandProto  `protobuf:"bytes,1,rep,name=commands,proto3" json:"commands,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LoginRequest) Reset() {
-	*x = LoginRequest{}
+func (x *ListDeviceCommandsResponse) Reset() {
+	*x = ListDeviceCommandsResponse{}
 	mi := &file_rootstock_v1_rootstock_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LoginRequest) String() string {
+func (x *ListDeviceCommandsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LoginRequest) ProtoMessage() {}
+func (*ListDeviceCommandsResponse) ProtoMessage() {}
 
-func (x *LoginRequest) ProtoReflect() protoreflect.Message {
+func (x *ListDeviceCommandsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_rootstock_v1_rootstock_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2806,48 +3115,40 @@ func (x *LoginRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LoginRequest.ProtoReflect.Descriptor instead.
-func (*LoginRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListDeviceCommandsResponse.ProtoReflect.Descriptor instead.
+func (*ListDeviceCommandsResponse) Descriptor() ([]byte, []int) {
 	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{48}
 }
 
-func (x *LoginRequest) GetEmail() string {
-	if x != nil {
-		return x.Email
-	}
-	return ""
-}
-
-func (x *LoginRequest) GetPassword() string {
+func (x *ListDeviceCommandsResponse) GetCommands() []*DeviceCommandProto {
 	if x != nil {
-		return x.Password
+		return x.Commands
 	}
-	return ""
+	return nil
 }
 
-type LoginResponse struct {
+// A list that may be unset, as distinct from set to nothing.
+type StringListProto struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
-	SessionToken  string                 `protobuf:"bytes,2,opt,name=session_token,json=sessionToken,proto3" json:"session_token,omitempty"`
-	User          *UserProto             `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
+	Values        []string               `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LoginResponse) Reset() {
-	*x = LoginResponse{}
+func (x *StringListProto) Reset() {
+	*x = StringListProto{}
 	mi := &file_rootstock_v1_rootstock_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LoginResponse) String() string {
+func (x *StringListProto) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LoginResponse) ProtoMessage() {}
+func (*StringListProto) ProtoMessage() {}
 
-func (x *LoginResponse) ProtoReflect() protoreflect.Message {
+func (x *StringListProto) ProtoReflect() protoreflect.Message {
 	mi := &file_rootstock_v1_rootstock_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2859,52 +3160,43 @@ func (x *LoginResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LoginResponse.ProtoReflect.Descriptor instead.
-func (*LoginResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use StringListProto.ProtoReflect.Descriptor instead.
+func (*StringListProto) Descriptor() ([]byte, []int) {
 	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{49}
 }
 
-func (x *LoginResponse) GetSessionId() string {
-	if x != nil {
-		return x.SessionId
-	}
-	return ""
-}
-
-func (x *LoginResponse) GetSessionToken() string {
-	if x != nil {
-		return x.SessionToken
-	}
-	return ""
-}
-
-func (x *LoginResponse) GetUser() *UserProto {
+func (x *StringListProto) GetValues() []string {
 	if x != nil {
-		return x.User
+		return x.Values
 	}
 	return nil
 }
 
-type LogoutRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// One side of a device shadow. Unset fields have not been desired or reported.
+type ShadowStateProto struct {
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	SamplingIntervalSeconds *int32                 `protobuf:"varint,1,opt,name=sampling_interval_seconds,json=samplingIntervalSeconds,proto3,oneof" json:"sampling_interval_seconds,omitempty"`
+	EnabledSensors          *StringListProto       `protobuf:"bytes,2,opt,name=enabled_sensors,json=enabledSensors,proto3" json:"enabled_sensors,omitempty"`
+	ActiveCampaigns         *StringListProto       `protobuf:"bytes,3,opt,name=active_campaigns,json=activeCampaigns,proto3" json:"active_campaigns,omitempty"`
+	FirmwareVersion         *string                `protobuf:"bytes,4,opt,name=firmware_version,json=firmwareVersion,proto3,oneof" json:"firmware_version,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
 }
 
-func (x *LogoutRequest) Reset() {
-	*x = LogoutRequest{}
+func (x *ShadowStateProto) Reset() {
+	*x = ShadowStateProto{}
 	mi := &file_rootstock_v1_rootstock_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LogoutRequest) String() string {
+func (x *ShadowStateProto) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LogoutRequest) ProtoMessage() {}
+func (*ShadowStateProto) ProtoMessage() {}
 
-func (x *LogoutRequest) ProtoReflect() protoreflect.Message {
+func (x *ShadowStateProto) ProtoReflect() protoreflect.Message {
 	mi := &file_rootstock_v1_rootstock_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2916,31 +3208,68 @@ func (x *LogoutRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LogoutRequest.ProtoReflect.Descriptor instead.
-func (*LogoutRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ShadowStateProto.ProtoReflect.Descriptor instead.
+func (*ShadowStateProto) Descriptor() ([]byte, []int) {
 	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{50}
 }
 
-type LogoutResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *ShadowStateProto) GetSamplingIntervalSeconds() int32 {
+	if x != nil && x.SamplingIntervalSeconds != nil {
+		return *x.SamplingIntervalSeconds
+	}
+	return 0
 }
 
-func (x *LogoutResponse) Reset() {
-	*x = LogoutResponse{}
+func (x *ShadowStateProto) GetEnabledSensors() *StringListProto {
+	if x != nil {
+		return x.EnabledSensors
+	}
+	return nil
+}
+
+func (x *ShadowStateProto) GetActiveCampaigns() *StringListProto {
+	if x != nil {
+		return x.ActiveCampaigns
+	}
+	return nil
+}
+
+func (x *ShadowStateProto) GetFirmwareVersion() string {
+	if x != nil && x.FirmwareVersion != nil {
+		return *x.FirmwareVersion
+	}
+	return ""
+}
+
+// What a device should run (desired), what it last reported running (reported), and the
+// desired fields it has yet to apply (delta). Devices sync it on rootstock/{id}/shadow/*.
+type DeviceShadowProto struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId          string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	Version           int64                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Desired           *ShadowStateProto      `protobuf:"bytes,3,opt,name=desired,proto3" json:"desired,omitempty"`
+	Reported          *ShadowStateProto      `protobuf:"bytes,4,opt,name=reported,proto3" json:"reported,omitempty"`
+	Delta             *ShadowStateProto      `protobuf:"bytes,5,opt,name=delta,proto3" json:"delta,omitempty"`
+	DesiredUpdatedAt  *string                `protobuf:"bytes,6,opt,name=desired_updated_at,json=desiredUpdatedAt,proto3,oneof" json:"desired_updated_at,omitempty"`
+	ReportedUpdatedAt *string                `protobuf:"bytes,7,opt,name=reported_updated_at,json=reportedUpdatedAt,proto3,oneof" json:"reported_updated_at,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *DeviceShadowProto) Reset() {
+	*x = DeviceShadowProto{}
 	mi := &file_rootstock_v1_rootstock_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LogoutResponse) String() string {
+func (x *DeviceShadowProto) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LogoutResponse) ProtoMessage() {}
+func (*DeviceShadowProto) ProtoMessage() {}
 
-func (x *LogoutResponse) ProtoReflect() protoreflect.Message {
+func (x *DeviceShadowProto) ProtoReflect() protoreflect.Message {
 	mi := &file_rootstock_v1_rootstock_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2952,36 +3281,81 @@ func (x *LogoutResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LogoutResponse.ProtoReflect.Descriptor instead.
-func (*LogoutResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use DeviceShadowProto.ProtoReflect.Descriptor instead.
+func (*DeviceShadowProto) Descriptor() ([]byte, []int) {
 	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{51}
 }
 
-type RegisterResearcherRequest struct {
+func (x *DeviceShadowProto) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *DeviceShadowProto) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *DeviceShadowProto) GetDesired() *ShadowStateProto {
+	if x != nil {
+		return x.Desired
+	}
+	return nil
+}
+
+func (x *DeviceShadowProto) GetReported() *ShadowStateProto {
+	if x != nil {
+		return x.Reported
+	}
+	return nil
+}
+
+func (x *DeviceShadowProto) GetDelta() *ShadowStateProto {
+	if x != nil {
+		return x.Delta
+	}
+	return nil
+}
+
+func (x *DeviceShadowProto) GetDesiredUpdatedAt() string {
+	if x != nil && x.DesiredUpdatedAt != nil {
+		return *x.DesiredUpdatedAt
+	}
+	return ""
+}
+
+func (x *DeviceShadowProto) GetReportedUpdatedAt() string {
+	if x != nil && x.ReportedUpdatedAt != nil {
+		return *x.ReportedUpdatedAt
+	}
+	return ""
+}
+
+type GetDeviceShadowRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
-	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
-	GivenName     string                 `protobuf:"bytes,3,opt,name=given_name,json=givenName,proto3" json:"given_name,omitempty"`
-	FamilyName    string                 `protobuf:"bytes,4,opt,name=family_name,json=familyName,proto3" json:"family_name,omitempty"`
-	UserType      string                 `protobuf:"bytes,5,opt,name=user_type,json=userType,proto3" json:"user_type,omitempty"`
+	DeviceId      string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RegisterResearcherRequest) Reset() {
-	*x = RegisterResearcherRequest{}
+func (x *GetDeviceShadowRequest) Reset() {
+	*x = GetDeviceShadowRequest{}
 	mi := &file_rootstock_v1_rootstock_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RegisterResearcherRequest) String() string {
+func (x *GetDeviceShadowRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RegisterResearcherRequest) ProtoMessage() {}
+func (*GetDeviceShadowRequest) ProtoMessage() {}
 
-func (x *RegisterResearcherRequest) ProtoReflect() protoreflect.Message {
+func (x *GetDeviceShadowRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_rootstock_v1_rootstock_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2993,68 +3367,39 @@ func (x *RegisterResearcherRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RegisterResearcherRequest.ProtoReflect.Descriptor instead.
-func (*RegisterResearcherRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetDeviceShadowRequest.ProtoReflect.Descriptor instead.
+func (*GetDeviceShadowRequest) Descriptor() ([]byte, []int) {
 	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{52}
 }
 
-func (x *RegisterResearcherRequest) GetEmail() string {
-	if x != nil {
-		return x.Email
-	}
-	return ""
-}
-
-func (x *RegisterResearcherRequest) GetPassword() string {
-	if x != nil {
-		return x.Password
-	}
-	return ""
-}
-
-func (x *RegisterResearcherRequest) GetGivenName() string {
-	if x != nil {
-		return x.GivenName
-	}
-	return ""
-}
-
-func (x *RegisterResearcherRequest) GetFamilyName() string {
-	if x != nil {
-		return x.FamilyName
-	}
-	return ""
-}
-
-func (x *RegisterResearcherRequest) GetUserType() string {
+func (x *GetDeviceShadowRequest) GetDeviceId() string {
 	if x != nil {
-		return x.UserType
+		return x.DeviceId
 	}
 	return ""
 }
 
-type RegisterResearcherResponse struct {
-	state                 protoimpl.MessageState `protogen:"open.v1"`
-	UserId                string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	EmailVerificationSent bool                   `protobuf:"varint,2,opt,name=email_verification_sent,json=emailVerificationSent,proto3" json:"email_verification_sent,omitempty"`
-	unknownFields         protoimpl.UnknownFields
-	sizeCache             protoimpl.SizeCache
+type GetDeviceShadowResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Shadow        *DeviceShadowProto     `protobuf:"bytes,1,opt,name=shadow,proto3" json:"shadow,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RegisterResearcherResponse) Reset() {
-	*x = RegisterResearcherResponse{}
+func (x *GetDeviceShadowResponse) Reset() {
+	*x = GetDeviceShadowResponse{}
 	mi := &file_rootstock_v1_rootstock_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RegisterResearcherResponse) String() string {
+func (x *GetDeviceShadowResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RegisterResearcherResponse) ProtoMessage() {}
+func (*GetDeviceShadowResponse) ProtoMessage() {}
 
-func (x *RegisterResearcherResponse) ProtoReflect() protoreflect.Message {
+func (x *GetDeviceShadowResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_rootstock_v1_rootstock_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3066,47 +3411,43 @@ func (x *RegisterResearcherResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RegisterResearcherResponse.ProtoReflect.Descriptor instead.
-func (*RegisterResearcherResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetDeviceShadowResponse.ProtoReflect.Descriptor instead.
+func (*GetDeviceShadowResponse) Descriptor() ([]byte, []int) {
 	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{53}
 }
 
-func (x *RegisterResearcherResponse) GetUserId() string {
-	if x != nil {
-		return x.UserId
-	}
-	return ""
-}
-
-func (x *RegisterResearcherResponse) GetEmailVerificationSent() bool {
+func (x *GetDeviceShadowResponse) GetShadow() *DeviceShadowProto {
 	if x != nil {
-		return x.EmailVerificationSent
+		return x.Shadow
 	}
-	return false
+	return nil
 }
 
-type VerifyEmailRequest struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	UserId           string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	VerificationCode string                 `protobuf:"bytes,2,opt,name=verification_code,json=verificationCode,proto3" json:"verification_code,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+// Unset fields keep their desired value. Active campaigns follow enrollments and firmware
+// follows releases, so neither is set here.
+type UpdateDeviceShadowRequest struct {
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId                string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	SamplingIntervalSeconds *int32                 `protobuf:"varint,2,opt,name=sampling_interval_seconds,json=samplingIntervalSeconds,proto3,oneof" json:"sampling_interval_seconds,omitempty"`
+	EnabledSensors          *StringListProto       `protobuf:"bytes,3,opt,name=enabled_sensors,json=enabledSensors,proto3" json:"enabled_sensors,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
 }
 
-func (x *VerifyEmailRequest) Reset() {
-	*x = VerifyEmailRequest{}
+func (x *UpdateDeviceShadowRequest) Reset() {
+	*x = UpdateDeviceShadowRequest{}
 	mi := &file_rootstock_v1_rootstock_proto_msgTypes[54]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *VerifyEmailRequest) String() string {
+func (x *UpdateDeviceShadowRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VerifyEmailRequest) ProtoMessage() {}
+func (*UpdateDeviceShadowRequest) ProtoMessage() {}
 
-func (x *VerifyEmailRequest) ProtoReflect() protoreflect.Message {
+func (x *UpdateDeviceShadowRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_rootstock_v1_rootstock_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3118,46 +3459,53 @@ func (x *VerifyEmailRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VerifyEmailRequest.ProtoReflect.Descriptor instead.
-func (*VerifyEmailRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use UpdateDeviceShadowRequest.ProtoReflect.Descriptor instead.
+func (*UpdateDeviceShadowRequest) Descriptor() ([]byte, []int) {
 	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{54}
 }
 
-func (x *VerifyEmailRequest) GetUserId() string {
+func (x *UpdateDeviceShadowRequest) GetDeviceId() string {
 	if x != nil {
-		return x.UserId
+		return x.DeviceId
 	}
 	return ""
 }
 
-func (x *VerifyEmailRequest) GetVerificationCode() string {
+func (x *UpdateDeviceShadowRequest) GetSamplingIntervalSeconds() int32 {
+	if x != nil && x.SamplingIntervalSeconds != nil {
+		return *x.SamplingIntervalSeconds
+	}
+	return 0
+}
+
+func (x *UpdateDeviceShadowRequest) GetEnabledSensors() *StringListProto {
 	if x != nil {
-		return x.VerificationCode
+		return x.EnabledSensors
 	}
-	return ""
+	return nil
 }
 
-type VerifyEmailResponse struct {
+type UpdateDeviceShadowResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Verified      bool                   `protobuf:"varint,1,opt,name=verified,proto3" json:"verified,omitempty"`
+	Shadow        *DeviceShadowProto     `protobuf:"bytes,1,opt,name=shadow,proto3" json:"shadow,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *VerifyEmailResponse) Reset() {
-	*x = VerifyEmailResponse{}
+func (x *UpdateDeviceShadowResponse) Reset() {
+	*x = UpdateDeviceShadowResponse{}
 	mi := &file_rootstock_v1_rootstock_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *VerifyEmailResponse) String() string {
+func (x *UpdateDeviceShadowResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VerifyEmailResponse) ProtoMessage() {}
+func (*UpdateDeviceShadowResponse) ProtoMessage() {}
 
-func (x *VerifyEmailResponse) ProtoReflect() protoreflect.Message {
+func (x *UpdateDeviceShadowResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_rootstock_v1_rootstock_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3169,39 +3517,42 @@ func (x *VerifyEmailResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VerifyEmailResponse.ProtoReflect.Descriptor instead.
-func (*VerifyEmailResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use UpdateDeviceShadowResponse.ProtoReflect.Descriptor instead.
+func (*UpdateDeviceShadowResponse) Descriptor() ([]byte, []int) {
 	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{55}
 }
 
-func (x *VerifyEmailResponse) GetVerified() bool {
+func (x *UpdateDeviceShadowResponse) GetShadow() *DeviceShadowProto {
 	if x != nil {
-		return x.Verified
+		return x.Shadow
 	}
-	return false
+	return nil
 }
 
-type UpdateUserTypeRequest struct {
+type UserProto struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserType      string                 `protobuf:"bytes,1,opt,name=user_type,json=userType,proto3" json:"user_type,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserType      string                 `protobuf:"bytes,2,opt,name=user_type,json=userType,proto3" json:"user_type,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateUserTypeRequest) Reset() {
-	*x = UpdateUserTypeRequest{}
+func (x *UserProto) Reset() {
+	*x = UserProto{}
 	mi := &file_rootstock_v1_rootstock_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateUserTypeRequest) String() string {
+func (x *UserProto) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateUserTypeRequest) ProtoMessage() {}
+func (*UserProto) ProtoMessage() {}
 
-func (x *UpdateUserTypeRequest) ProtoReflect() protoreflect.Message {
+func (x *UserProto) ProtoReflect() protoreflect.Message {
 	mi := &file_rootstock_v1_rootstock_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3213,39 +3564,60 @@ func (x *UpdateUserTypeRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateUserTypeRequest.ProtoReflect.Descriptor instead.
-func (*UpdateUserTypeRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use UserProto.ProtoReflect.Descriptor instead.
+func (*UserProto) Descriptor() ([]byte, []int) {
 	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{56}
 }
 
-func (x *UpdateUserTypeRequest) GetUserType() string {
+func (x *UserProto) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UserProto) GetUserType() string {
 	if x != nil {
 		return x.UserType
 	}
 	return ""
 }
 
-type UpdateUserTypeResponse struct {
+func (x *UserProto) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *UserProto) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type RegisterUserRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	User          *UserProto             `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	UserType      string                 `protobuf:"bytes,1,opt,name=user_type,json=userType,proto3" json:"user_type,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateUserTypeResponse) Reset() {
-	*x = UpdateUserTypeResponse{}
+func (x *RegisterUserRequest) Reset() {
+	*x = RegisterUserRequest{}
 	mi := &file_rootstock_v1_rootstock_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateUserTypeResponse) String() string {
+func (x *RegisterUserRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateUserTypeResponse) ProtoMessage() {}
+func (*RegisterUserRequest) ProtoMessage() {}
 
-func (x *UpdateUserTypeResponse) ProtoReflect() protoreflect.Message {
+func (x *RegisterUserRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_rootstock_v1_rootstock_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3257,43 +3629,39 @@ func (x *UpdateUserTypeResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateUserTypeResponse.ProtoReflect.Descriptor instead.
-func (*UpdateUserTypeResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use RegisterUserRequest.ProtoReflect.Descriptor instead.
+func (*RegisterUserRequest) Descriptor() ([]byte, []int) {
 	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{57}
 }
 
-func (x *UpdateUserTypeResponse) GetUser() *UserProto {
+func (x *RegisterUserRequest) GetUserType() string {
 	if x != nil {
-		return x.User
+		return x.UserType
 	}
-	return nil
+	return ""
 }
 
-type RegisterScitizenRequest struct {
+type RegisterUserResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
-	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
-	GivenName     string                 `protobuf:"bytes,3,opt,name=given_name,json=givenName,proto3" json:"given_name,omitempty"`
-	FamilyName    string                 `protobuf:"bytes,4,opt,name=family_name,json=familyName,proto3" json:"family_name,omitempty"`
-	TosAccepted   bool                   `protobuf:"varint,5,opt,name=tos_accepted,json=tosAccepted,proto3" json:"tos_accepted,omitempty"`
+	User          *UserProto             `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RegisterScitizenRequest) Reset() {
-	*x = RegisterScitizenRequest{}
+func (x *RegisterUserResponse) Reset() {
+	*x = RegisterUserResponse{}
 	mi := &file_rootstock_v1_rootstock_proto_msgTypes[58]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RegisterScitizenRequest) String() string {
+func (x *RegisterUserResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RegisterScitizenRequest) ProtoMessage() {}
+func (*RegisterUserResponse) ProtoMessage() {}
 
-func (x *RegisterScitizenRequest) ProtoReflect() protoreflect.Message {
+func (x *RegisterUserResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_rootstock_v1_rootstock_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3305,68 +3673,38 @@ func (x *RegisterScitizenRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RegisterScitizenRequest.ProtoReflect.Descriptor instead.
-func (*RegisterScitizenRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use RegisterUserResponse.ProtoReflect.Descriptor instead.
+func (*RegisterUserResponse) Descriptor() ([]byte, []int) {
 	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{58}
 }
 
-func (x *RegisterScitizenRequest) GetEmail() string {
-	if x != nil {
-		return x.Email
-	}
-	return ""
-}
-
-func (x *RegisterScitizenRequest) GetPassword() string {
-	if x != nil {
-		return x.Password
-	}
-	return ""
-}
-
-func (x *RegisterScitizenRequest) GetGivenName() string {
-	if x != nil {
-		return x.GivenName
-	}
-	return ""
-}
-
-func (x *RegisterScitizenRequest) GetFamilyName() string {
-	if x != nil {
-		return x.FamilyName
-	}
-	return ""
-}
-
-func (x *RegisterScitizenRequest) GetTosAccepted() bool {
+func (x *RegisterUserResponse) GetUser() *UserProto {
 	if x != nil {
-		return x.TosAccepted
+		return x.User
 	}
-	return false
+	return nil
 }
 
-type RegisterScitizenResponse struct {
-	state                 protoimpl.MessageState `protogen:"open.v1"`
-	UserId                string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	EmailVerificationSent bool                   `protobuf:"varint,2,opt,name=email_verification_sent,json=emailVerificationSent,proto3" json:"email_verification_sent,omitempty"`
-	unknownFields         protoimpl.UnknownFields
-	sizeCache             protoimpl.SizeCache
+type GetMeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RegisterScitizenResponse) Reset() {
-	*x = RegisterScitizenResponse{}
+func (x *GetMeRequest) Reset() {
+	*x = GetMeRequest{}
 	mi := &file_rootstock_v1_rootstock_proto_msgTypes[59]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RegisterScitizenResponse) String() string {
+func (x *GetMeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RegisterScitizenResponse) ProtoMessage() {}
+func (*GetMeRequest) ProtoMessage() {}
 
-func (x *RegisterScitizenResponse) ProtoReflect() protoreflect.Message {
+func (x *GetMeRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_rootstock_v1_rootstock_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3378,49 +3716,32 @@ func (x *RegisterScitizenResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RegisterScitizenResponse.ProtoReflect.Descriptor instead.
-func (*RegisterScitizenResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetMeRequest.ProtoReflect.Descriptor instead.
+func (*GetMeRequest) Descriptor() ([]byte, []int) {
 	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{59}
 }
 
-func (x *RegisterScitizenResponse) GetUserId() string {
-	if x != nil {
-		return x.UserId
-	}
-	return ""
-}
-
-func (x *RegisterScitizenResponse) GetEmailVerificationSent() bool {
-	if x != nil {
-		return x.EmailVerificationSent
-	}
-	return false
-}
-
-type OnboardingStateProto struct {
-	state                 protoimpl.MessageState `protogen:"open.v1"`
-	DeviceRegistered      bool                   `protobuf:"varint,1,opt,name=device_registered,json=deviceRegistered,proto3" json:"device_registered,omitempty"`
-	CampaignEnrolled      bool                   `protobuf:"varint,2,opt,name=campaign_enrolled,json=campaignEnrolled,proto3" json:"campaign_enrolled,omitempty"`
-	FirstReadingSubmitted bool                   `protobuf:"varint,3,opt,name=first_reading_submitted,json=firstReadingSubmitted,proto3" json:"first_reading_submitted,omitempty"`
-	TosAccepted           bool                   `protobuf:"varint,4,opt,name=tos_accepted,json=tosAccepted,proto3" json:"tos_accepted,omitempty"`
-	unknownFields         protoimpl.UnknownFields
-	sizeCache             protoimpl.SizeCache
+type GetMeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *UserProto             `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *OnboardingStateProto) Reset() {
-	*x = OnboardingStateProto{}
+func (x *GetMeResponse) Reset() {
+	*x = GetMeResponse{}
 	mi := &file_rootstock_v1_rootstock_proto_msgTypes[60]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OnboardingStateProto) String() string {
+func (x *GetMeResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OnboardingStateProto) ProtoMessage() {}
+func (*GetMeResponse) ProtoMessage() {}
 
-func (x *OnboardingStateProto) ProtoReflect() protoreflect.Message {
+func (x *GetMeResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_rootstock_v1_rootstock_proto_msgTypes[60]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3432,59 +3753,40 @@ func (x *OnboardingStateProto) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OnboardingStateProto.ProtoReflect.Descriptor instead.
-func (*OnboardingStateProto) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetMeResponse.ProtoReflect.Descriptor instead.
+func (*GetMeResponse) Descriptor() ([]byte, []int) {
 	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{60}
 }
 
-func (x *OnboardingStateProto) GetDeviceRegistered() bool {
-	if x != nil {
-		return x.DeviceRegistered
-	}
-	return false
-}
-
-func (x *OnboardingStateProto) GetCampaignEnrolled() bool {
-	if x != nil {
-		return x.CampaignEnrolled
-	}
-	return false
-}
-
-func (x *OnboardingStateProto) GetFirstReadingSubmitted() bool {
-	if x != nil {
-		return x.FirstReadingSubmitted
-	}
-	return false
-}
-
-func (x *OnboardingStateProto) GetTosAccepted() bool {
+func (x *GetMeResponse) GetUser() *UserProto {
 	if x != nil {
-		return x.TosAccepted
+		return x.User
 	}
-	return false
+	return nil
 }
 
-type GetOnboardingStateRequest struct {
+type LoginRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetOnboardingStateRequest) Reset() {
-	*x = GetOnboardingStateRequest{}
+func (x *LoginRequest) Reset() {
+	*x = LoginRequest{}
 	mi := &file_rootstock_v1_rootstock_proto_msgTypes[61]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetOnboardingStateRequest) String() string {
+func (x *LoginRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOnboardingStateRequest) ProtoMessage() {}
+func (*LoginRequest) ProtoMessage() {}
 
-func (x *GetOnboardingStateRequest) ProtoReflect() protoreflect.Message {
+func (x *LoginRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_rootstock_v1_rootstock_proto_msgTypes[61]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3496,32 +3798,48 @@ func (x *GetOnboardingStateRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOnboardingStateRequest.ProtoReflect.Descriptor instead.
-func (*GetOnboardingStateRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use LoginRequest.ProtoReflect.Descriptor instead.
+func (*LoginRequest) Descriptor() ([]byte, []int) {
 	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{61}
 }
 
-type GetOnboardingStateResponse struct {
+func (x *LoginRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *LoginRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type LoginResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	State         *OnboardingStateProto  `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	SessionToken  string                 `protobuf:"bytes,2,opt,name=session_token,json=sessionToken,proto3" json:"session_token,omitempty"`
+	User          *UserProto             `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetOnboardingStateResponse) Reset() {
-	*x = GetOnboardingStateResponse{}
+func (x *LoginResponse) Reset() {
+	*x = LoginResponse{}
 	mi := &file_rootstock_v1_rootstock_proto_msgTypes[62]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetOnboardingStateResponse) String() string {
+func (x *LoginResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOnboardingStateResponse) ProtoMessage() {}
+func (*LoginResponse) ProtoMessage() {}
 
-func (x *GetOnboardingStateResponse) ProtoReflect() protoreflect.Message {
+func (x *LoginResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_rootstock_v1_rootstock_proto_msgTypes[62]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3533,43 +3851,52 @@ func (x *GetOnboardingStateResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOnboardingStateResponse.ProtoReflect.Descriptor instead.
-func (*GetOnboardingStateResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use LoginResponse.ProtoReflect.Descriptor instead.
+func (*LoginResponse) Descriptor() ([]byte, []int) {
 	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{62}
 }
 
-func (x *GetOnboardingStateResponse) GetState() *OnboardingStateProto {
+func (x *LoginResponse) GetSessionId() string {
 	if x != nil {
-		return x.State
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *LoginResponse) GetSessionToken() string {
+	if x != nil {
+		return x.SessionToken
+	}
+	return ""
+}
+
+func (x *LoginResponse) GetUser() *UserProto {
+	if x != nil {
+		return x.User
 	}
 	return nil
 }
 
-type EnrollmentProto struct {
+type LogoutRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	DeviceId      string                 `protobuf:"bytes,2,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
-	CampaignId    string                 `protobuf:"bytes,3,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
-	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
-	EnrolledAt    string                 `protobuf:"bytes,5,opt,name=enrolled_at,json=enrolledAt,proto3" json:"enrolled_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *EnrollmentProto) Reset() {
-	*x = EnrollmentProto{}
+func (x *LogoutRequest) Reset() {
+	*x = LogoutRequest{}
 	mi := &file_rootstock_v1_rootstock_proto_msgTypes[63]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *EnrollmentProto) String() string {
+func (x *LogoutRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EnrollmentProto) ProtoMessage() {}
+func (*LogoutRequest) ProtoMessage() {}
 
-func (x *EnrollmentProto) ProtoReflect() protoreflect.Message {
+func (x *LogoutRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_rootstock_v1_rootstock_proto_msgTypes[63]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3581,67 +3908,3290 @@ func (x *EnrollmentProto) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EnrollmentProto.ProtoReflect.Descriptor instead.
-func (*EnrollmentProto) Descriptor() ([]byte, []int) {
+// Deprecated: Use LogoutRequest.ProtoReflect.Descriptor instead.
+func (*LogoutRequest) Descriptor() ([]byte, []int) {
 	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{63}
 }
 
-func (x *EnrollmentProto) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
+type LogoutResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *EnrollmentProto) GetDeviceId() string {
-	if x != nil {
-		return x.DeviceId
-	}
-	return ""
+func (x *LogoutResponse) Reset() {
+	*x = LogoutResponse{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *EnrollmentProto) GetCampaignId() string {
-	if x != nil {
-		return x.CampaignId
-	}
-	return ""
+func (x *LogoutResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *EnrollmentProto) GetStatus() string {
+func (*LogoutResponse) ProtoMessage() {}
+
+func (x *LogoutResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogoutResponse.ProtoReflect.Descriptor instead.
+func (*LogoutResponse) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{64}
+}
+
+type RegisterResearcherRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	GivenName     string                 `protobuf:"bytes,3,opt,name=given_name,json=givenName,proto3" json:"given_name,omitempty"`
+	FamilyName    string                 `protobuf:"bytes,4,opt,name=family_name,json=familyName,proto3" json:"family_name,omitempty"`
+	UserType      string                 `protobuf:"bytes,5,opt,name=user_type,json=userType,proto3" json:"user_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterResearcherRequest) Reset() {
+	*x = RegisterResearcherRequest{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterResearcherRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterResearcherRequest) ProtoMessage() {}
+
+func (x *RegisterResearcherRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterResearcherRequest.ProtoReflect.Descriptor instead.
+func (*RegisterResearcherRequest) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *RegisterResearcherRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *RegisterResearcherRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *RegisterResearcherRequest) GetGivenName() string {
+	if x != nil {
+		return x.GivenName
+	}
+	return ""
+}
+
+func (x *RegisterResearcherRequest) GetFamilyName() string {
+	if x != nil {
+		return x.FamilyName
+	}
+	return ""
+}
+
+func (x *RegisterResearcherRequest) GetUserType() string {
+	if x != nil {
+		return x.UserType
+	}
+	return ""
+}
+
+type RegisterResearcherResponse struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	UserId                string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	EmailVerificationSent bool                   `protobuf:"varint,2,opt,name=email_verification_sent,json=emailVerificationSent,proto3" json:"email_verification_sent,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *RegisterResearcherResponse) Reset() {
+	*x = RegisterResearcherResponse{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterResearcherResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterResearcherResponse) ProtoMessage() {}
+
+func (x *RegisterResearcherResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterResearcherResponse.ProtoReflect.Descriptor instead.
+func (*RegisterResearcherResponse) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *RegisterResearcherResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RegisterResearcherResponse) GetEmailVerificationSent() bool {
+	if x != nil {
+		return x.EmailVerificationSent
+	}
+	return false
+}
+
+type VerifyEmailRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	UserId           string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	VerificationCode string                 `protobuf:"bytes,2,opt,name=verification_code,json=verificationCode,proto3" json:"verification_code,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *VerifyEmailRequest) Reset() {
+	*x = VerifyEmailRequest{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyEmailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyEmailRequest) ProtoMessage() {}
+
+func (x *VerifyEmailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyEmailRequest.ProtoReflect.Descriptor instead.
+func (*VerifyEmailRequest) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *VerifyEmailRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *VerifyEmailRequest) GetVerificationCode() string {
+	if x != nil {
+		return x.VerificationCode
+	}
+	return ""
+}
+
+type VerifyEmailResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Verified      bool                   `protobuf:"varint,1,opt,name=verified,proto3" json:"verified,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyEmailResponse) Reset() {
+	*x = VerifyEmailResponse{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyEmailResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyEmailResponse) ProtoMessage() {}
+
+func (x *VerifyEmailResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyEmailResponse.ProtoReflect.Descriptor instead.
+func (*VerifyEmailResponse) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *VerifyEmailResponse) GetVerified() bool {
+	if x != nil {
+		return x.Verified
+	}
+	return false
+}
+
+type UpdateUserTypeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserType      string                 `protobuf:"bytes,1,opt,name=user_type,json=userType,proto3" json:"user_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateUserTypeRequest) Reset() {
+	*x = UpdateUserTypeRequest{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateUserTypeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateUserTypeRequest) ProtoMessage() {}
+
+func (x *UpdateUserTypeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateUserTypeRequest.ProtoReflect.Descriptor instead.
+func (*UpdateUserTypeRequest) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *UpdateUserTypeRequest) GetUserType() string {
+	if x != nil {
+		return x.UserType
+	}
+	return ""
+}
+
+type UpdateUserTypeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *UserProto             `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateUserTypeResponse) Reset() {
+	*x = UpdateUserTypeResponse{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateUserTypeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateUserTypeResponse) ProtoMessage() {}
+
+func (x *UpdateUserTypeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateUserTypeResponse.ProtoReflect.Descriptor instead.
+func (*UpdateUserTypeResponse) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *UpdateUserTypeResponse) GetUser() *UserProto {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type RegisterScitizenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	GivenName     string                 `protobuf:"bytes,3,opt,name=given_name,json=givenName,proto3" json:"given_name,omitempty"`
+	FamilyName    string                 `protobuf:"bytes,4,opt,name=family_name,json=familyName,proto3" json:"family_name,omitempty"`
+	TosAccepted   bool                   `protobuf:"varint,5,opt,name=tos_accepted,json=tosAccepted,proto3" json:"tos_accepted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterScitizenRequest) Reset() {
+	*x = RegisterScitizenRequest{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterScitizenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterScitizenRequest) ProtoMessage() {}
+
+func (x *RegisterScitizenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterScitizenRequest.ProtoReflect.Descriptor instead.
+func (*RegisterScitizenRequest) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *RegisterScitizenRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *RegisterScitizenRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *RegisterScitizenRequest) GetGivenName() string {
+	if x != nil {
+		return x.GivenName
+	}
+	return ""
+}
+
+func (x *RegisterScitizenRequest) GetFamilyName() string {
+	if x != nil {
+		return x.FamilyName
+	}
+	return ""
+}
+
+func (x *RegisterScitizenRequest) GetTosAccepted() bool {
+	if x != nil {
+		return x.TosAccepted
+	}
+	return false
+}
+
+type RegisterScitizenResponse struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	UserId                string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	EmailVerificationSent bool                   `protobuf:"varint,2,opt,name=email_verification_sent,json=emailVerificationSent,proto3" json:"email_verification_sent,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *RegisterScitizenResponse) Reset() {
+	*x = RegisterScitizenResponse{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterScitizenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterScitizenResponse) ProtoMessage() {}
+
+func (x *RegisterScitizenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterScitizenResponse.ProtoReflect.Descriptor instead.
+func (*RegisterScitizenResponse) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *RegisterScitizenResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RegisterScitizenResponse) GetEmailVerificationSent() bool {
+	if x != nil {
+		return x.EmailVerificationSent
+	}
+	return false
+}
+
+type OnboardingStateProto struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	DeviceRegistered      bool                   `protobuf:"varint,1,opt,name=device_registered,json=deviceRegistered,proto3" json:"device_registered,omitempty"`
+	CampaignEnrolled      bool                   `protobuf:"varint,2,opt,name=campaign_enrolled,json=campaignEnrolled,proto3" json:"campaign_enrolled,omitempty"`
+	FirstReadingSubmitted bool                   `protobuf:"varint,3,opt,name=first_reading_submitted,json=firstReadingSubmitted,proto3" json:"first_reading_submitted,omitempty"`
+	TosAccepted           bool                   `protobuf:"varint,4,opt,name=tos_accepted,json=tosAccepted,proto3" json:"tos_accepted,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *OnboardingStateProto) Reset() {
+	*x = OnboardingStateProto{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OnboardingStateProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OnboardingStateProto) ProtoMessage() {}
+
+func (x *OnboardingStateProto) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OnboardingStateProto.ProtoReflect.Descriptor instead.
+func (*OnboardingStateProto) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *OnboardingStateProto) GetDeviceRegistered() bool {
+	if x != nil {
+		return x.DeviceRegistered
+	}
+	return false
+}
+
+func (x *OnboardingStateProto) GetCampaignEnrolled() bool {
+	if x != nil {
+		return x.CampaignEnrolled
+	}
+	return false
+}
+
+func (x *OnboardingStateProto) GetFirstReadingSubmitted() bool {
+	if x != nil {
+		return x.FirstReadingSubmitted
+	}
+	return false
+}
+
+func (x *OnboardingStateProto) GetTosAccepted() bool {
+	if x != nil {
+		return x.TosAccepted
+	}
+	return false
+}
+
+type GetOnboardingStateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOnboardingStateRequest) Reset() {
+	*x = GetOnboardingStateRequest{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOnboardingStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOnboardingStateRequest) ProtoMessage() {}
+
+func (x *GetOnboardingStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOnboardingStateRequest.ProtoReflect.Descriptor instead.
+func (*GetOnboardingStateRequest) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{74}
+}
+
+type GetOnboardingStateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	State         *OnboardingStateProto  `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOnboardingStateResponse) Reset() {
+	*x = GetOnboardingStateResponse{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOnboardingStateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOnboardingStateResponse) ProtoMessage() {}
+
+func (x *GetOnboardingStateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOnboardingStateResponse.ProtoReflect.Descriptor instead.
+func (*GetOnboardingStateResponse) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *GetOnboardingStateResponse) GetState() *OnboardingStateProto {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+type EnrollmentProto struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DeviceId      string                 `protobuf:"bytes,2,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	CampaignId    string                 `protobuf:"bytes,3,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	EnrolledAt    string                 `protobuf:"bytes,5,opt,name=enrolled_at,json=enrolledAt,proto3" json:"enrolled_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnrollmentProto) Reset() {
+	*x = EnrollmentProto{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollmentProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollmentProto) ProtoMessage() {}
+
+func (x *EnrollmentProto) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollmentProto.ProtoReflect.Descriptor instead.
+func (*EnrollmentProto) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *EnrollmentProto) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *EnrollmentProto) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *EnrollmentProto) GetCampaignId() string {
+	if x != nil {
+		return x.CampaignId
+	}
+	return ""
+}
+
+func (x *EnrollmentProto) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *EnrollmentProto) GetEnrolledAt() string {
+	if x != nil {
+		return x.EnrolledAt
+	}
+	return ""
+}
+
+type GetDashboardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDashboardRequest) Reset() {
+	*x = GetDashboardRequest{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDashboardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDashboardRequest) ProtoMessage() {}
+
+func (x *GetDashboardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDashboardRequest.ProtoReflect.Descriptor instead.
+func (*GetDashboardRequest) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{77}
+}
+
+type GetDashboardResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ActiveEnrollments int32                  `protobuf:"varint,1,opt,name=active_enrollments,json=activeEnrollments,proto3" json:"active_enrollments,omitempty"`
+	TotalReadings     int32                  `protobuf:"varint,2,opt,name=total_readings,json=totalReadings,proto3" json:"total_readings,omitempty"`
+	AcceptedReadings  int32                  `protobuf:"varint,3,opt,name=accepted_readings,json=acceptedReadings,proto3" json:"accepted_readings,omitempty"`
+	ContributionScore float64                `protobuf:"fixed64,4,opt,name=contribution_score,json=contributionScore,proto3" json:"contribution_score,omitempty"`
+	Badges            []*BadgeProto          `protobuf:"bytes,5,rep,name=badges,proto3" json:"badges,omitempty"`
+	Enrollments       []*EnrollmentProto     `protobuf:"bytes,6,rep,name=enrollments,proto3" json:"enrollments,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GetDashboardResponse) Reset() {
+	*x = GetDashboardResponse{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDashboardResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDashboardResponse) ProtoMessage() {}
+
+func (x *GetDashboardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDashboardResponse.ProtoReflect.Descriptor instead.
+func (*GetDashboardResponse) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *GetDashboardResponse) GetActiveEnrollments() int32 {
+	if x != nil {
+		return x.ActiveEnrollments
+	}
+	return 0
+}
+
+func (x *GetDashboardResponse) GetTotalReadings() int32 {
+	if x != nil {
+		return x.TotalReadings
+	}
+	return 0
+}
+
+func (x *GetDashboardResponse) GetAcceptedReadings() int32 {
+	if x != nil {
+		return x.AcceptedReadings
+	}
+	return 0
+}
+
+func (x *GetDashboardResponse) GetContributionScore() float64 {
+	if x != nil {
+		return x.ContributionScore
+	}
+	return 0
+}
+
+func (x *GetDashboardResponse) GetBadges() []*BadgeProto {
+	if x != nil {
+		return x.Badges
+	}
+	return nil
+}
+
+func (x *GetDashboardResponse) GetEnrollments() []*EnrollmentProto {
+	if x != nil {
+		return x.Enrollments
+	}
+	return nil
+}
+
+type BrowsePublishedCampaignsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Longitude     *float64               `protobuf:"fixed64,1,opt,name=longitude,proto3,oneof" json:"longitude,omitempty"`
+	Latitude      *float64               `protobuf:"fixed64,2,opt,name=latitude,proto3,oneof" json:"latitude,omitempty"`
+	RadiusKm      *float64               `protobuf:"fixed64,3,opt,name=radius_km,json=radiusKm,proto3,oneof" json:"radius_km,omitempty"`
+	SensorType    *string                `protobuf:"bytes,4,opt,name=sensor_type,json=sensorType,proto3,oneof" json:"sensor_type,omitempty"`
+	Limit         int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,6,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BrowsePublishedCampaignsRequest) Reset() {
+	*x = BrowsePublishedCampaignsRequest{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BrowsePublishedCampaignsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BrowsePublishedCampaignsRequest) ProtoMessage() {}
+
+func (x *BrowsePublishedCampaignsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BrowsePublishedCampaignsRequest.ProtoReflect.Descriptor instead.
+func (*BrowsePublishedCampaignsRequest) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *BrowsePublishedCampaignsRequest) GetLongitude() float64 {
+	if x != nil && x.Longitude != nil {
+		return *x.Longitude
+	}
+	return 0
+}
+
+func (x *BrowsePublishedCampaignsRequest) GetLatitude() float64 {
+	if x != nil && x.Latitude != nil {
+		return *x.Latitude
+	}
+	return 0
+}
+
+func (x *BrowsePublishedCampaignsRequest) GetRadiusKm() float64 {
+	if x != nil && x.RadiusKm != nil {
+		return *x.RadiusKm
+	}
+	return 0
+}
+
+func (x *BrowsePublishedCampaignsRequest) GetSensorType() string {
+	if x != nil && x.SensorType != nil {
+		return *x.SensorType
+	}
+	return ""
+}
+
+func (x *BrowsePublishedCampaignsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *BrowsePublishedCampaignsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type CampaignSummaryProto struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status          string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	WindowStart     *string                `protobuf:"bytes,3,opt,name=window_start,json=windowStart,proto3,oneof" json:"window_start,omitempty"`
+	WindowEnd       *string                `protobuf:"bytes,4,opt,name=window_end,json=windowEnd,proto3,oneof" json:"window_end,omitempty"`
+	EnrollmentCount int32                  `protobuf:"varint,5,opt,name=enrollment_count,json=enrollmentCount,proto3" json:"enrollment_count,omitempty"`
+	RequiredSensors []string               `protobuf:"bytes,6,rep,name=required_sensors,json=requiredSensors,proto3" json:"required_sensors,omitempty"`
+	CreatedAt       string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CampaignSummaryProto) Reset() {
+	*x = CampaignSummaryProto{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CampaignSummaryProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CampaignSummaryProto) ProtoMessage() {}
+
+func (x *CampaignSummaryProto) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CampaignSummaryProto.ProtoReflect.Descriptor instead.
+func (*CampaignSummaryProto) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *CampaignSummaryProto) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CampaignSummaryProto) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CampaignSummaryProto) GetWindowStart() string {
+	if x != nil && x.WindowStart != nil {
+		return *x.WindowStart
+	}
+	return ""
+}
+
+func (x *CampaignSummaryProto) GetWindowEnd() string {
+	if x != nil && x.WindowEnd != nil {
+		return *x.WindowEnd
+	}
+	return ""
+}
+
+func (x *CampaignSummaryProto) GetEnrollmentCount() int32 {
+	if x != nil {
+		return x.EnrollmentCount
+	}
+	return 0
+}
+
+func (x *CampaignSummaryProto) GetRequiredSensors() []string {
+	if x != nil {
+		return x.RequiredSensors
+	}
+	return nil
+}
+
+func (x *CampaignSummaryProto) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type BrowsePublishedCampaignsResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Campaigns     []*CampaignSummaryProto `protobuf:"bytes,1,rep,name=campaigns,proto3" json:"campaigns,omitempty"`
+	Total         int32                   `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BrowsePublishedCampaignsResponse) Reset() {
+	*x = BrowsePublishedCampaignsResponse{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BrowsePublishedCampaignsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BrowsePublishedCampaignsResponse) ProtoMessage() {}
+
+func (x *BrowsePublishedCampaignsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BrowsePublishedCampaignsResponse.ProtoReflect.Descriptor instead.
+func (*BrowsePublishedCampaignsResponse) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *BrowsePublishedCampaignsResponse) GetCampaigns() []*CampaignSummaryProto {
+	if x != nil {
+		return x.Campaigns
+	}
+	return nil
+}
+
+func (x *BrowsePublishedCampaignsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type GetCampaignDetailRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CampaignId    string                 `protobuf:"bytes,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCampaignDetailRequest) Reset() {
+	*x = GetCampaignDetailRequest{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCampaignDetailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCampaignDetailRequest) ProtoMessage() {}
+
+func (x *GetCampaignDetailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCampaignDetailRequest.ProtoReflect.Descriptor instead.
+func (*GetCampaignDetailRequest) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *GetCampaignDetailRequest) GetCampaignId() string {
+	if x != nil {
+		return x.CampaignId
+	}
+	return ""
+}
+
+type GetCampaignDetailResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	CampaignId      string                 `protobuf:"bytes,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	Status          string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	WindowStart     *string                `protobuf:"bytes,3,opt,name=window_start,json=windowStart,proto3,oneof" json:"window_start,omitempty"`
+	WindowEnd       *string                `protobuf:"bytes,4,opt,name=window_end,json=windowEnd,proto3,oneof" json:"window_end,omitempty"`
+	Parameters      []*ParameterProto      `protobuf:"bytes,5,rep,name=parameters,proto3" json:"parameters,omitempty"`
+	Regions         []*RegionProto         `protobuf:"bytes,6,rep,name=regions,proto3" json:"regions,omitempty"`
+	Eligibility     []*EligibilityProto    `protobuf:"bytes,7,rep,name=eligibility,proto3" json:"eligibility,omitempty"`
+	EnrollmentCount int32                  `protobuf:"varint,8,opt,name=enrollment_count,json=enrollmentCount,proto3" json:"enrollment_count,omitempty"`
+	ProgressPercent float64                `protobuf:"fixed64,9,opt,name=progress_percent,json=progressPercent,proto3" json:"progress_percent,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetCampaignDetailResponse) Reset() {
+	*x = GetCampaignDetailResponse{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCampaignDetailResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCampaignDetailResponse) ProtoMessage() {}
+
+func (x *GetCampaignDetailResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCampaignDetailResponse.ProtoReflect.Descriptor instead.
+func (*GetCampaignDetailResponse) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *GetCampaignDetailResponse) GetCampaignId() string {
+	if x != nil {
+		return x.CampaignId
+	}
+	return ""
+}
+
+func (x *GetCampaignDetailResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *GetCampaignDetailResponse) GetWindowStart() string {
+	if x != nil && x.WindowStart != nil {
+		return *x.WindowStart
+	}
+	return ""
+}
+
+func (x *GetCampaignDetailResponse) GetWindowEnd() string {
+	if x != nil && x.WindowEnd != nil {
+		return *x.WindowEnd
+	}
+	return ""
+}
+
+func (x *GetCampaignDetailResponse) GetParameters() []*ParameterProto {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+func (x *GetCampaignDetailResponse) GetRegions() []*RegionProto {
+	if x != nil {
+		return x.Regions
+	}
+	return nil
+}
+
+func (x *GetCampaignDetailResponse) GetEligibility() []*EligibilityProto {
+	if x != nil {
+		return x.Eligibility
+	}
+	return nil
+}
+
+func (x *GetCampaignDetailResponse) GetEnrollmentCount() int32 {
+	if x != nil {
+		return x.EnrollmentCount
+	}
+	return 0
+}
+
+func (x *GetCampaignDetailResponse) GetProgressPercent() float64 {
+	if x != nil {
+		return x.ProgressPercent
+	}
+	return 0
+}
+
+type SearchCampaignsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchCampaignsRequest) Reset() {
+	*x = SearchCampaignsRequest{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchCampaignsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchCampaignsRequest) ProtoMessage() {}
+
+func (x *SearchCampaignsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchCampaignsRequest.ProtoReflect.Descriptor instead.
+func (*SearchCampaignsRequest) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *SearchCampaignsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchCampaignsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SearchCampaignsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type SearchCampaignsResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Campaigns     []*CampaignSummaryProto `protobuf:"bytes,1,rep,name=campaigns,proto3" json:"campaigns,omitempty"`
+	Total         int32                   `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchCampaignsResponse) Reset() {
+	*x = SearchCampaignsResponse{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchCampaignsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchCampaignsResponse) ProtoMessage() {}
+
+func (x *SearchCampaignsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchCampaignsResponse.ProtoReflect.Descriptor instead.
+func (*SearchCampaignsResponse) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *SearchCampaignsResponse) GetCampaigns() []*CampaignSummaryProto {
+	if x != nil {
+		return x.Campaigns
+	}
+	return nil
+}
+
+func (x *SearchCampaignsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type ConsentProto struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       string                 `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	Scope         string                 `protobuf:"bytes,2,opt,name=scope,proto3" json:"scope,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConsentProto) Reset() {
+	*x = ConsentProto{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConsentProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsentProto) ProtoMessage() {}
+
+func (x *ConsentProto) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsentProto.ProtoReflect.Descriptor instead.
+func (*ConsentProto) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *ConsentProto) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *ConsentProto) GetScope() string {
+	if x != nil {
+		return x.Scope
+	}
+	return ""
+}
+
+type EnrollDeviceRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId           string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	CampaignId         string                 `protobuf:"bytes,2,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	Consent            *ConsentProto          `protobuf:"bytes,3,opt,name=consent,proto3" json:"consent,omitempty"`
+	DeploymentLocation string                 `protobuf:"bytes,4,opt,name=deployment_location,json=deploymentLocation,proto3" json:"deployment_location,omitempty"` // GeoJSON Point where the device is placed; optional
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *EnrollDeviceRequest) Reset() {
+	*x = EnrollDeviceRequest{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollDeviceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollDeviceRequest) ProtoMessage() {}
+
+func (x *EnrollDeviceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollDeviceRequest.ProtoReflect.Descriptor instead.
+func (*EnrollDeviceRequest) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *EnrollDeviceRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *EnrollDeviceRequest) GetCampaignId() string {
+	if x != nil {
+		return x.CampaignId
+	}
+	return ""
+}
+
+func (x *EnrollDeviceRequest) GetConsent() *ConsentProto {
+	if x != nil {
+		return x.Consent
+	}
+	return nil
+}
+
+func (x *EnrollDeviceRequest) GetDeploymentLocation() string {
+	if x != nil {
+		return x.DeploymentLocation
+	}
+	return ""
+}
+
+type EnrollDeviceResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Enrolled        bool                   `protobuf:"varint,1,opt,name=enrolled,proto3" json:"enrolled,omitempty"`
+	Reason          string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	EnrollmentId    string                 `protobuf:"bytes,3,opt,name=enrollment_id,json=enrollmentId,proto3" json:"enrollment_id,omitempty"`
+	LocationFlagged bool                   `protobuf:"varint,4,opt,name=location_flagged,json=locationFlagged,proto3" json:"location_flagged,omitempty"` // deployment location is outside the campaign regions and held for review
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *EnrollDeviceResponse) Reset() {
+	*x = EnrollDeviceResponse{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollDeviceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollDeviceResponse) ProtoMessage() {}
+
+func (x *EnrollDeviceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollDeviceResponse.ProtoReflect.Descriptor instead.
+func (*EnrollDeviceResponse) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *EnrollDeviceResponse) GetEnrolled() bool {
+	if x != nil {
+		return x.Enrolled
+	}
+	return false
+}
+
+func (x *EnrollDeviceResponse) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *EnrollDeviceResponse) GetEnrollmentId() string {
+	if x != nil {
+		return x.EnrollmentId
+	}
+	return ""
+}
+
+func (x *EnrollDeviceResponse) GetLocationFlagged() bool {
+	if x != nil {
+		return x.LocationFlagged
+	}
+	return false
+}
+
+type WithdrawEnrollmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EnrollmentId  string                 `protobuf:"bytes,1,opt,name=enrollment_id,json=enrollmentId,proto3" json:"enrollment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WithdrawEnrollmentRequest) Reset() {
+	*x = WithdrawEnrollmentRequest{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WithdrawEnrollmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithdrawEnrollmentRequest) ProtoMessage() {}
+
+func (x *WithdrawEnrollmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithdrawEnrollmentRequest.ProtoReflect.Descriptor instead.
+func (*WithdrawEnrollmentRequest) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *WithdrawEnrollmentRequest) GetEnrollmentId() string {
+	if x != nil {
+		return x.EnrollmentId
+	}
+	return ""
+}
+
+type WithdrawEnrollmentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WithdrawEnrollmentResponse) Reset() {
+	*x = WithdrawEnrollmentResponse{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WithdrawEnrollmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithdrawEnrollmentResponse) ProtoMessage() {}
+
+func (x *WithdrawEnrollmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[90]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithdrawEnrollmentResponse.ProtoReflect.Descriptor instead.
+func (*WithdrawEnrollmentResponse) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{90}
+}
+
+type DeviceSummaryProto struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status            string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Class             string                 `protobuf:"bytes,3,opt,name=class,proto3" json:"class,omitempty"`
+	FirmwareVersion   string                 `protobuf:"bytes,4,opt,name=firmware_version,json=firmwareVersion,proto3" json:"firmware_version,omitempty"`
+	Tier              int32                  `protobuf:"varint,5,opt,name=tier,proto3" json:"tier,omitempty"`
+	Sensors           []string               `protobuf:"bytes,6,rep,name=sensors,proto3" json:"sensors,omitempty"`
+	ActiveEnrollments int32                  `protobuf:"varint,7,opt,name=active_enrollments,json=activeEnrollments,proto3" json:"active_enrollments,omitempty"`
+	LastSeen          *string                `protobuf:"bytes,8,opt,name=last_seen,json=lastSeen,proto3,oneof" json:"last_seen,omitempty"`
+	Health            *DeviceHealthProto     `protobuf:"bytes,9,opt,name=health,proto3,oneof" json:"health,omitempty"` // unset until the device sends a heartbeat
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *DeviceSummaryProto) Reset() {
+	*x = DeviceSummaryProto{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeviceSummaryProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeviceSummaryProto) ProtoMessage() {}
+
+func (x *DeviceSummaryProto) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeviceSummaryProto.ProtoReflect.Descriptor instead.
+func (*DeviceSummaryProto) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *DeviceSummaryProto) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeviceSummaryProto) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *DeviceSummaryProto) GetClass() string {
+	if x != nil {
+		return x.Class
+	}
+	return ""
+}
+
+func (x *DeviceSummaryProto) GetFirmwareVersion() string {
+	if x != nil {
+		return x.FirmwareVersion
+	}
+	return ""
+}
+
+func (x *DeviceSummaryProto) GetTier() int32 {
+	if x != nil {
+		return x.Tier
+	}
+	return 0
+}
+
+func (x *DeviceSummaryProto) GetSensors() []string {
+	if x != nil {
+		return x.Sensors
+	}
+	return nil
+}
+
+func (x *DeviceSummaryProto) GetActiveEnrollments() int32 {
+	if x != nil {
+		return x.ActiveEnrollments
+	}
+	return 0
+}
+
+func (x *DeviceSummaryProto) GetLastSeen() string {
+	if x != nil && x.LastSeen != nil {
+		return *x.LastSeen
+	}
+	return ""
+}
+
+func (x *DeviceSummaryProto) GetHealth() *DeviceHealthProto {
+	if x != nil {
+		return x.Health
+	}
+	return nil
+}
+
+type GetDevicesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDevicesRequest) Reset() {
+	*x = GetDevicesRequest{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[92]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDevicesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDevicesRequest) ProtoMessage() {}
+
+func (x *GetDevicesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[92]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDevicesRequest.ProtoReflect.Descriptor instead.
+func (*GetDevicesRequest) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{92}
+}
+
+type GetDevicesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Devices       []*DeviceSummaryProto  `protobuf:"bytes,1,rep,name=devices,proto3" json:"devices,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDevicesResponse) Reset() {
+	*x = GetDevicesResponse{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDevicesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDevicesResponse) ProtoMessage() {}
+
+func (x *GetDevicesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[93]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDevicesResponse.ProtoReflect.Descriptor instead.
+func (*GetDevicesResponse) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *GetDevicesResponse) GetDevices() []*DeviceSummaryProto {
+	if x != nil {
+		return x.Devices
+	}
+	return nil
+}
+
+type ConnectionEventProto struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventType     string                 `protobuf:"bytes,1,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	Timestamp     string                 `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Reason        *string                `protobuf:"bytes,3,opt,name=reason,proto3,oneof" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConnectionEventProto) Reset() {
+	*x = ConnectionEventProto{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[94]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConnectionEventProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConnectionEventProto) ProtoMessage() {}
+
+func (x *ConnectionEventProto) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[94]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConnectionEventProto.ProtoReflect.Descriptor instead.
+func (*ConnectionEventProto) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{94}
+}
+
+func (x *ConnectionEventProto) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *ConnectionEventProto) GetTimestamp() string {
+	if x != nil {
+		return x.Timestamp
+	}
+	return ""
+}
+
+func (x *ConnectionEventProto) GetReason() string {
+	if x != nil && x.Reason != nil {
+		return *x.Reason
+	}
+	return ""
+}
+
+type GetDeviceDetailRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId      string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDeviceDetailRequest) Reset() {
+	*x = GetDeviceDetailRequest{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[95]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDeviceDetailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeviceDetailRequest) ProtoMessage() {}
+
+func (x *GetDeviceDetailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[95]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeviceDetailRequest.ProtoReflect.Descriptor instead.
+func (*GetDeviceDetailRequest) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{95}
+}
+
+func (x *GetDeviceDetailRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+type GetDeviceDetailResponse struct {
+	state              protoimpl.MessageState  `protogen:"open.v1"`
+	Device             *DeviceProto            `protobuf:"bytes,1,opt,name=device,proto3" json:"device,omitempty"`
+	Enrollments        []*EnrollmentProto      `protobuf:"bytes,2,rep,name=enrollments,proto3" json:"enrollments,omitempty"`
+	ConnectionHistory  []*ConnectionEventProto `protobuf:"bytes,3,rep,name=connection_history,json=connectionHistory,proto3" json:"connection_history,omitempty"`
+	ClockSkewMs        *int64                  `protobuf:"varint,4,opt,name=clock_skew_ms,json=clockSkewMs,proto3,oneof" json:"clock_skew_ms,omitempty"`                       // smoothed device clock minus server time; unset until a live reading arrives
+	ClockSkewFlagged   bool                    `protobuf:"varint,5,opt,name=clock_skew_flagged,json=clockSkewFlagged,proto3" json:"clock_skew_flagged,omitempty"`              // the skew is past the alert limit and the owner has been notified
+	Online             bool                    `protobuf:"varint,6,opt,name=online,proto3" json:"online,omitempty"`                                                            // the device has an open broker session
+	Sessions           []*DeviceSessionProto   `protobuf:"bytes,7,rep,name=sessions,proto3" json:"sessions,omitempty"`                                                         // newest first, most recent 50
+	Health             *DeviceHealthProto      `protobuf:"bytes,8,opt,name=health,proto3,oneof" json:"health,omitempty"`                                                       // unset until the device sends a heartbeat
+	ConfigVersion      *int64                  `protobuf:"varint,9,opt,name=config_version,json=configVersion,proto3,oneof" json:"config_version,omitempty"`                   // campaign config last pushed to the device
+	ConfigAckedVersion *int64                  `protobuf:"varint,10,opt,name=config_acked_version,json=configAckedVersion,proto3,oneof" json:"config_acked_version,omitempty"` // config the device last acknowledged
+	ConfigStale        bool                    `protobuf:"varint,11,opt,name=config_stale,json=configStale,proto3" json:"config_stale,omitempty"`                              // the device has not acknowledged its current config
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *GetDeviceDetailResponse) Reset() {
+	*x = GetDeviceDetailResponse{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[96]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDeviceDetailResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeviceDetailResponse) ProtoMessage() {}
+
+func (x *GetDeviceDetailResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[96]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeviceDetailResponse.ProtoReflect.Descriptor instead.
+func (*GetDeviceDetailResponse) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{96}
+}
+
+func (x *GetDeviceDetailResponse) GetDevice() *DeviceProto {
+	if x != nil {
+		return x.Device
+	}
+	return nil
+}
+
+func (x *GetDeviceDetailResponse) GetEnrollments() []*EnrollmentProto {
+	if x != nil {
+		return x.Enrollments
+	}
+	return nil
+}
+
+func (x *GetDeviceDetailResponse) GetConnectionHistory() []*ConnectionEventProto {
+	if x != nil {
+		return x.ConnectionHistory
+	}
+	return nil
+}
+
+func (x *GetDeviceDetailResponse) GetClockSkewMs() int64 {
+	if x != nil && x.ClockSkewMs != nil {
+		return *x.ClockSkewMs
+	}
+	return 0
+}
+
+func (x *GetDeviceDetailResponse) GetClockSkewFlagged() bool {
+	if x != nil {
+		return x.ClockSkewFlagged
+	}
+	return false
+}
+
+func (x *GetDeviceDetailResponse) GetOnline() bool {
+	if x != nil {
+		return x.Online
+	}
+	return false
+}
+
+func (x *GetDeviceDetailResponse) GetSessions() []*DeviceSessionProto {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+func (x *GetDeviceDetailResponse) GetHealth() *DeviceHealthProto {
+	if x != nil {
+		return x.Health
+	}
+	return nil
+}
+
+func (x *GetDeviceDetailResponse) GetConfigVersion() int64 {
+	if x != nil && x.ConfigVersion != nil {
+		return *x.ConfigVersion
+	}
+	return 0
+}
+
+func (x *GetDeviceDetailResponse) GetConfigAckedVersion() int64 {
+	if x != nil && x.ConfigAckedVersion != nil {
+		return *x.ConfigAckedVersion
+	}
+	return 0
+}
+
+func (x *GetDeviceDetailResponse) GetConfigStale() bool {
+	if x != nil {
+		return x.ConfigStale
+	}
+	return false
+}
+
+type DeviceHealthProto struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ReportedAt      string                 `protobuf:"bytes,1,opt,name=reported_at,json=reportedAt,proto3" json:"reported_at,omitempty"` // device clock
+	ReceivedAt      string                 `protobuf:"bytes,2,opt,name=received_at,json=receivedAt,proto3" json:"received_at,omitempty"`
+	BatteryPercent  *float64               `protobuf:"fixed64,3,opt,name=battery_percent,json=batteryPercent,proto3,oneof" json:"battery_percent,omitempty"`
+	RssiDbm         *int32                 `protobuf:"varint,4,opt,name=rssi_dbm,json=rssiDbm,proto3,oneof" json:"rssi_dbm,omitempty"`
+	UptimeSeconds   *int64                 `protobuf:"varint,5,opt,name=uptime_seconds,json=uptimeSeconds,proto3,oneof" json:"uptime_seconds,omitempty"`
+	FreeMemoryBytes *int64                 `protobuf:"varint,6,opt,name=free_memory_bytes,json=freeMemoryBytes,proto3,oneof" json:"free_memory_bytes,omitempty"`
+	SensorFaults    []string               `protobuf:"bytes,7,rep,name=sensor_faults,json=sensorFaults,proto3" json:"sensor_faults,omitempty"`
+	FirmwareBuild   *string                `protobuf:"bytes,8,opt,name=firmware_build,json=firmwareBuild,proto3,oneof" json:"firmware_build,omitempty"`
+	Alerts          []string               `protobuf:"bytes,9,rep,name=alerts,proto3" json:"alerts,omitempty"` // open health alerts: low_battery, sensor_fault, silent
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DeviceHealthProto) Reset() {
+	*x = DeviceHealthProto{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[97]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeviceHealthProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeviceHealthProto) ProtoMessage() {}
+
+func (x *DeviceHealthProto) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[97]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeviceHealthProto.ProtoReflect.Descriptor instead.
+func (*DeviceHealthProto) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{97}
+}
+
+func (x *DeviceHealthProto) GetReportedAt() string {
+	if x != nil {
+		return x.ReportedAt
+	}
+	return ""
+}
+
+func (x *DeviceHealthProto) GetReceivedAt() string {
+	if x != nil {
+		return x.ReceivedAt
+	}
+	return ""
+}
+
+func (x *DeviceHealthProto) GetBatteryPercent() float64 {
+	if x != nil && x.BatteryPercent != nil {
+		return *x.BatteryPercent
+	}
+	return 0
+}
+
+func (x *DeviceHealthProto) GetRssiDbm() int32 {
+	if x != nil && x.RssiDbm != nil {
+		return *x.RssiDbm
+	}
+	return 0
+}
+
+func (x *DeviceHealthProto) GetUptimeSeconds() int64 {
+	if x != nil && x.UptimeSeconds != nil {
+		return *x.UptimeSeconds
+	}
+	return 0
+}
+
+func (x *DeviceHealthProto) GetFreeMemoryBytes() int64 {
+	if x != nil && x.FreeMemoryBytes != nil {
+		return *x.FreeMemoryBytes
+	}
+	return 0
+}
+
+func (x *DeviceHealthProto) GetSensorFaults() []string {
+	if x != nil {
+		return x.SensorFaults
+	}
+	return nil
+}
+
+func (x *DeviceHealthProto) GetFirmwareBuild() string {
+	if x != nil && x.FirmwareBuild != nil {
+		return *x.FirmwareBuild
+	}
+	return ""
+}
+
+func (x *DeviceHealthProto) GetAlerts() []string {
+	if x != nil {
+		return x.Alerts
+	}
+	return nil
+}
+
+type DeviceSessionProto struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ConnectedAt      string                 `protobuf:"bytes,1,opt,name=connected_at,json=connectedAt,proto3" json:"connected_at,omitempty"`
+	DisconnectedAt   *string                `protobuf:"bytes,2,opt,name=disconnected_at,json=disconnectedAt,proto3,oneof" json:"disconnected_at,omitempty"` // unset while connected
+	DurationSeconds  int64                  `protobuf:"varint,3,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"`   // so far, for an open session
+	RemoteAddr       string                 `protobuf:"bytes,4,opt,name=remote_addr,json=remoteAddr,proto3" json:"remote_addr,omitempty"`
+	CertSerial       *string                `protobuf:"bytes,5,opt,name=cert_serial,json=certSerial,proto3,oneof" json:"cert_serial,omitempty"`
+	DisconnectReason *string                `protobuf:"bytes,6,opt,name=disconnect_reason,json=disconnectReason,proto3,oneof" json:"disconnect_reason,omitempty"`
+	Graceful         *bool                  `protobuf:"varint,7,opt,name=graceful,proto3,oneof" json:"graceful,omitempty"`                                // the device sent DISCONNECT
+	WillPublished    *bool                  `protobuf:"varint,8,opt,name=will_published,json=willPublished,proto3,oneof" json:"will_published,omitempty"` // the broker published the device's last will as it dropped
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *DeviceSessionProto) Reset() {
+	*x = DeviceSessionProto{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[98]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeviceSessionProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeviceSessionProto) ProtoMessage() {}
+
+func (x *DeviceSessionProto) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[98]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeviceSessionProto.ProtoReflect.Descriptor instead.
+func (*DeviceSessionProto) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{98}
+}
+
+func (x *DeviceSessionProto) GetConnectedAt() string {
+	if x != nil {
+		return x.ConnectedAt
+	}
+	return ""
+}
+
+func (x *DeviceSessionProto) GetDisconnectedAt() string {
+	if x != nil && x.DisconnectedAt != nil {
+		return *x.DisconnectedAt
+	}
+	return ""
+}
+
+func (x *DeviceSessionProto) GetDurationSeconds() int64 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+func (x *DeviceSessionProto) GetRemoteAddr() string {
+	if x != nil {
+		return x.RemoteAddr
+	}
+	return ""
+}
+
+func (x *DeviceSessionProto) GetCertSerial() string {
+	if x != nil && x.CertSerial != nil {
+		return *x.CertSerial
+	}
+	return ""
+}
+
+func (x *DeviceSessionProto) GetDisconnectReason() string {
+	if x != nil && x.DisconnectReason != nil {
+		return *x.DisconnectReason
+	}
+	return ""
+}
+
+func (x *DeviceSessionProto) GetGraceful() bool {
+	if x != nil && x.Graceful != nil {
+		return *x.Graceful
+	}
+	return false
+}
+
+func (x *DeviceSessionProto) GetWillPublished() bool {
+	if x != nil && x.WillPublished != nil {
+		return *x.WillPublished
+	}
+	return false
+}
+
+type EnrollmentCodeProto struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId      string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	ExpiresAt     string                 `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnrollmentCodeProto) Reset() {
+	*x = EnrollmentCodeProto{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[99]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollmentCodeProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollmentCodeProto) ProtoMessage() {}
+
+func (x *EnrollmentCodeProto) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[99]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollmentCodeProto.ProtoReflect.Descriptor instead.
+func (*EnrollmentCodeProto) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{99}
+}
+
+func (x *EnrollmentCodeProto) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *EnrollmentCodeProto) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *EnrollmentCodeProto) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+type RegisterDeviceRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Class           string                 `protobuf:"bytes,1,opt,name=class,proto3" json:"class,omitempty"`
+	Tier            int32                  `protobuf:"varint,2,opt,name=tier,proto3" json:"tier,omitempty"`
+	Sensors         []string               `protobuf:"bytes,3,rep,name=sensors,proto3" json:"sensors,omitempty"`
+	FirmwareVersion string                 `protobuf:"bytes,4,opt,name=firmware_version,json=firmwareVersion,proto3" json:"firmware_version,omitempty"`
+	SensorUnits     map[string]string      `protobuf:"bytes,5,rep,name=sensor_units,json=sensorUnits,proto3" json:"sensor_units,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // sensor -> unit the device reports in, e.g. "[degF]" or "hPa"
+	Gateway         bool                   `protobuf:"varint,6,opt,name=gateway,proto3" json:"gateway,omitempty"`                                                                                                     // enrolls and relays for child devices (ESP32 hubs, LoRa gateways)
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RegisterDeviceRequest) Reset() {
+	*x = RegisterDeviceRequest{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[100]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterDeviceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterDeviceRequest) ProtoMessage() {}
+
+func (x *RegisterDeviceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[100]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterDeviceRequest.ProtoReflect.Descriptor instead.
+func (*RegisterDeviceRequest) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{100}
+}
+
+func (x *RegisterDeviceRequest) GetClass() string {
+	if x != nil {
+		return x.Class
+	}
+	return ""
+}
+
+func (x *RegisterDeviceRequest) GetTier() int32 {
+	if x != nil {
+		return x.Tier
+	}
+	return 0
+}
+
+func (x *RegisterDeviceRequest) GetSensors() []string {
+	if x != nil {
+		return x.Sensors
+	}
+	return nil
+}
+
+func (x *RegisterDeviceRequest) GetFirmwareVersion() string {
+	if x != nil {
+		return x.FirmwareVersion
+	}
+	return ""
+}
+
+func (x *RegisterDeviceRequest) GetSensorUnits() map[string]string {
+	if x != nil {
+		return x.SensorUnits
+	}
+	return nil
+}
+
+func (x *RegisterDeviceRequest) GetGateway() bool {
+	if x != nil {
+		return x.Gateway
+	}
+	return false
+}
+
+type RegisterDeviceResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId       string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	Status         string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	EnrollmentCode *EnrollmentCodeProto   `protobuf:"bytes,3,opt,name=enrollment_code,json=enrollmentCode,proto3" json:"enrollment_code,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RegisterDeviceResponse) Reset() {
+	*x = RegisterDeviceResponse{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[101]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterDeviceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterDeviceResponse) ProtoMessage() {}
+
+func (x *RegisterDeviceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[101]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterDeviceResponse.ProtoReflect.Descriptor instead.
+func (*RegisterDeviceResponse) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{101}
+}
+
+func (x *RegisterDeviceResponse) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *RegisterDeviceResponse) GetStatus() string {
 	if x != nil {
 		return x.Status
 	}
 	return ""
 }
 
-func (x *EnrollmentProto) GetEnrolledAt() string {
+func (x *RegisterDeviceResponse) GetEnrollmentCode() *EnrollmentCodeProto {
+	if x != nil {
+		return x.EnrollmentCode
+	}
+	return nil
+}
+
+type ListEnrollmentCodesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEnrollmentCodesRequest) Reset() {
+	*x = ListEnrollmentCodesRequest{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[102]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEnrollmentCodesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEnrollmentCodesRequest) ProtoMessage() {}
+
+func (x *ListEnrollmentCodesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[102]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEnrollmentCodesRequest.ProtoReflect.Descriptor instead.
+func (*ListEnrollmentCodesRequest) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{102}
+}
+
+type ListEnrollmentCodesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Codes         []*EnrollmentCodeProto `protobuf:"bytes,1,rep,name=codes,proto3" json:"codes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEnrollmentCodesResponse) Reset() {
+	*x = ListEnrollmentCodesResponse{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[103]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEnrollmentCodesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEnrollmentCodesResponse) ProtoMessage() {}
+
+func (x *ListEnrollmentCodesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[103]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEnrollmentCodesResponse.ProtoReflect.Descriptor instead.
+func (*ListEnrollmentCodesResponse) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{103}
+}
+
+func (x *ListEnrollmentCodesResponse) GetCodes() []*EnrollmentCodeProto {
+	if x != nil {
+		return x.Codes
+	}
+	return nil
+}
+
+type RegenerateEnrollmentCodeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId      string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegenerateEnrollmentCodeRequest) Reset() {
+	*x = RegenerateEnrollmentCodeRequest{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[104]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegenerateEnrollmentCodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegenerateEnrollmentCodeRequest) ProtoMessage() {}
+
+func (x *RegenerateEnrollmentCodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[104]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegenerateEnrollmentCodeRequest.ProtoReflect.Descriptor instead.
+func (*RegenerateEnrollmentCodeRequest) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{104}
+}
+
+func (x *RegenerateEnrollmentCodeRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+type RegenerateEnrollmentCodeResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	EnrollmentCode *EnrollmentCodeProto   `protobuf:"bytes,1,opt,name=enrollment_code,json=enrollmentCode,proto3" json:"enrollment_code,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RegenerateEnrollmentCodeResponse) Reset() {
+	*x = RegenerateEnrollmentCodeResponse{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[105]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegenerateEnrollmentCodeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegenerateEnrollmentCodeResponse) ProtoMessage() {}
+
+func (x *RegenerateEnrollmentCodeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[105]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegenerateEnrollmentCodeResponse.ProtoReflect.Descriptor instead.
+func (*RegenerateEnrollmentCodeResponse) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{105}
+}
+
+func (x *RegenerateEnrollmentCodeResponse) GetEnrollmentCode() *EnrollmentCodeProto {
+	if x != nil {
+		return x.EnrollmentCode
+	}
+	return nil
+}
+
+type ExpireEnrollmentCodeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId      string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExpireEnrollmentCodeRequest) Reset() {
+	*x = ExpireEnrollmentCodeRequest{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[106]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpireEnrollmentCodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpireEnrollmentCodeRequest) ProtoMessage() {}
+
+func (x *ExpireEnrollmentCodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[106]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpireEnrollmentCodeRequest.ProtoReflect.Descriptor instead.
+func (*ExpireEnrollmentCodeRequest) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{106}
+}
+
+func (x *ExpireEnrollmentCodeRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+type ExpireEnrollmentCodeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Expired       int32                  `protobuf:"varint,1,opt,name=expired,proto3" json:"expired,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExpireEnrollmentCodeResponse) Reset() {
+	*x = ExpireEnrollmentCodeResponse{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[107]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpireEnrollmentCodeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpireEnrollmentCodeResponse) ProtoMessage() {}
+
+func (x *ExpireEnrollmentCodeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[107]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpireEnrollmentCodeResponse.ProtoReflect.Descriptor instead.
+func (*ExpireEnrollmentCodeResponse) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{107}
+}
+
+func (x *ExpireEnrollmentCodeResponse) GetExpired() int32 {
+	if x != nil {
+		return x.Expired
+	}
+	return 0
+}
+
+type SetDeviceSensorUnitsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId      string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	SensorUnits   map[string]string      `protobuf:"bytes,2,rep,name=sensor_units,json=sensorUnits,proto3" json:"sensor_units,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // replaces the device's declarations
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetDeviceSensorUnitsRequest) Reset() {
+	*x = SetDeviceSensorUnitsRequest{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[108]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetDeviceSensorUnitsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetDeviceSensorUnitsRequest) ProtoMessage() {}
+
+func (x *SetDeviceSensorUnitsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[108]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetDeviceSensorUnitsRequest.ProtoReflect.Descriptor instead.
+func (*SetDeviceSensorUnitsRequest) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{108}
+}
+
+func (x *SetDeviceSensorUnitsRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *SetDeviceSensorUnitsRequest) GetSensorUnits() map[string]string {
+	if x != nil {
+		return x.SensorUnits
+	}
+	return nil
+}
+
+type SetDeviceSensorUnitsResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	EffectiveUnits map[string]string      `protobuf:"bytes,1,rep,name=effective_units,json=effectiveUnits,proto3" json:"effective_units,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // device declarations over class defaults, as UCUM codes
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SetDeviceSensorUnitsResponse) Reset() {
+	*x = SetDeviceSensorUnitsResponse{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[109]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetDeviceSensorUnitsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetDeviceSensorUnitsResponse) ProtoMessage() {}
+
+func (x *SetDeviceSensorUnitsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[109]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetDeviceSensorUnitsResponse.ProtoReflect.Descriptor instead.
+func (*SetDeviceSensorUnitsResponse) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{109}
+}
+
+func (x *SetDeviceSensorUnitsResponse) GetEffectiveUnits() map[string]string {
+	if x != nil {
+		return x.EffectiveUnits
+	}
+	return nil
+}
+
+// LinkStationRequest points an existing consumer weather station at Rootstock. The station
+// uploads to the returned URL in its vendor's protocol; no firmware change or enrollment.
+type LinkStationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId      string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	Protocol      string                 `protobuf:"bytes,2,opt,name=protocol,proto3" json:"protocol,omitempty"`                                                                                                       // wunderground, ecowitt or ambient
+	ParameterMap  map[string]string      `protobuf:"bytes,3,rep,name=parameter_map,json=parameterMap,proto3" json:"parameter_map,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // standard parameter -> campaign parameter, e.g. temperature -> air_temp
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkStationRequest) Reset() {
+	*x = LinkStationRequest{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[110]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkStationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkStationRequest) ProtoMessage() {}
+
+func (x *LinkStationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[110]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkStationRequest.ProtoReflect.Descriptor instead.
+func (*LinkStationRequest) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{110}
+}
+
+func (x *LinkStationRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *LinkStationRequest) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+func (x *LinkStationRequest) GetParameterMap() map[string]string {
+	if x != nil {
+		return x.ParameterMap
+	}
+	return nil
+}
+
+type LinkStationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId      string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	Protocol      string                 `protobuf:"bytes,2,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	UploadUrl     string                 `protobuf:"bytes,3,opt,name=upload_url,json=uploadUrl,proto3" json:"upload_url,omitempty"` // shown once; linking again replaces it
+	CreatedAt     string                 `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkStationResponse) Reset() {
+	*x = LinkStationResponse{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[111]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkStationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkStationResponse) ProtoMessage() {}
+
+func (x *LinkStationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[111]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkStationResponse.ProtoReflect.Descriptor instead.
+func (*LinkStationResponse) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{111}
+}
+
+func (x *LinkStationResponse) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *LinkStationResponse) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+func (x *LinkStationResponse) GetUploadUrl() string {
+	if x != nil {
+		return x.UploadUrl
+	}
+	return ""
+}
+
+func (x *LinkStationResponse) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type UnlinkStationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId      string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlinkStationRequest) Reset() {
+	*x = UnlinkStationRequest{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[112]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlinkStationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlinkStationRequest) ProtoMessage() {}
+
+func (x *UnlinkStationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[112]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlinkStationRequest.ProtoReflect.Descriptor instead.
+func (*UnlinkStationRequest) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{112}
+}
+
+func (x *UnlinkStationRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+type UnlinkStationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Unlinked      bool                   `protobuf:"varint,1,opt,name=unlinked,proto3" json:"unlinked,omitempty"` // false when the device was not linked
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlinkStationResponse) Reset() {
+	*x = UnlinkStationResponse{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[113]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlinkStationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlinkStationResponse) ProtoMessage() {}
+
+func (x *UnlinkStationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[113]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlinkStationResponse.ProtoReflect.Descriptor instead.
+func (*UnlinkStationResponse) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{113}
+}
+
+func (x *UnlinkStationResponse) GetUnlinked() bool {
+	if x != nil {
+		return x.Unlinked
+	}
+	return false
+}
+
+type NotificationProto struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Read          bool                   `protobuf:"varint,4,opt,name=read,proto3" json:"read,omitempty"`
+	ResourceLink  *string                `protobuf:"bytes,5,opt,name=resource_link,json=resourceLink,proto3,oneof" json:"resource_link,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotificationProto) Reset() {
+	*x = NotificationProto{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[114]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotificationProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotificationProto) ProtoMessage() {}
+
+func (x *NotificationProto) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[114]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotificationProto.ProtoReflect.Descriptor instead.
+func (*NotificationProto) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{114}
+}
+
+func (x *NotificationProto) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *NotificationProto) GetType() string {
 	if x != nil {
-		return x.EnrolledAt
+		return x.Type
+	}
+	return ""
+}
+
+func (x *NotificationProto) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *NotificationProto) GetRead() bool {
+	if x != nil {
+		return x.Read
+	}
+	return false
+}
+
+func (x *NotificationProto) GetResourceLink() string {
+	if x != nil && x.ResourceLink != nil {
+		return *x.ResourceLink
+	}
+	return ""
+}
+
+func (x *NotificationProto) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type GetNotificationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TypeFilter    *string                `protobuf:"bytes,1,opt,name=type_filter,json=typeFilter,proto3,oneof" json:"type_filter,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNotificationsRequest) Reset() {
+	*x = GetNotificationsRequest{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[115]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNotificationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNotificationsRequest) ProtoMessage() {}
+
+func (x *GetNotificationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[115]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNotificationsRequest.ProtoReflect.Descriptor instead.
+func (*GetNotificationsRequest) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{115}
+}
+
+func (x *GetNotificationsRequest) GetTypeFilter() string {
+	if x != nil && x.TypeFilter != nil {
+		return *x.TypeFilter
+	}
+	return ""
+}
+
+func (x *GetNotificationsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetNotificationsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type GetNotificationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Notifications []*NotificationProto   `protobuf:"bytes,1,rep,name=notifications,proto3" json:"notifications,omitempty"`
+	UnreadCount   int32                  `protobuf:"varint,2,opt,name=unread_count,json=unreadCount,proto3" json:"unread_count,omitempty"`
+	Total         int32                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNotificationsResponse) Reset() {
+	*x = GetNotificationsResponse{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[116]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNotificationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNotificationsResponse) ProtoMessage() {}
+
+func (x *GetNotificationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[116]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNotificationsResponse.ProtoReflect.Descriptor instead.
+func (*GetNotificationsResponse) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{116}
+}
+
+func (x *GetNotificationsResponse) GetNotifications() []*NotificationProto {
+	if x != nil {
+		return x.Notifications
+	}
+	return nil
+}
+
+func (x *GetNotificationsResponse) GetUnreadCount() int32 {
+	if x != nil {
+		return x.UnreadCount
+	}
+	return 0
+}
+
+func (x *GetNotificationsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type ReadingHistoryProto struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId      string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	CampaignId    string                 `protobuf:"bytes,2,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	TotalReadings int32                  `protobuf:"varint,3,opt,name=total_readings,json=totalReadings,proto3" json:"total_readings,omitempty"`
+	Accepted      int32                  `protobuf:"varint,4,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	Rejected      int32                  `protobuf:"varint,5,opt,name=rejected,proto3" json:"rejected,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReadingHistoryProto) Reset() {
+	*x = ReadingHistoryProto{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[117]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadingHistoryProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadingHistoryProto) ProtoMessage() {}
+
+func (x *ReadingHistoryProto) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[117]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadingHistoryProto.ProtoReflect.Descriptor instead.
+func (*ReadingHistoryProto) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{117}
+}
+
+func (x *ReadingHistoryProto) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *ReadingHistoryProto) GetCampaignId() string {
+	if x != nil {
+		return x.CampaignId
+	}
+	return ""
+}
+
+func (x *ReadingHistoryProto) GetTotalReadings() int32 {
+	if x != nil {
+		return x.TotalReadings
+	}
+	return 0
+}
+
+func (x *ReadingHistoryProto) GetAccepted() int32 {
+	if x != nil {
+		return x.Accepted
+	}
+	return 0
+}
+
+func (x *ReadingHistoryProto) GetRejected() int32 {
+	if x != nil {
+		return x.Rejected
 	}
-	return ""
+	return 0
 }
 
-type GetDashboardRequest struct {
+type GetContributionsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetDashboardRequest) Reset() {
-	*x = GetDashboardRequest{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[64]
+func (x *GetContributionsRequest) Reset() {
+	*x = GetContributionsRequest{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[118]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetDashboardRequest) String() string {
+func (x *GetContributionsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetDashboardRequest) ProtoMessage() {}
+func (*GetContributionsRequest) ProtoMessage() {}
 
-func (x *GetDashboardRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[64]
+func (x *GetContributionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[118]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3652,38 +7202,35 @@ func (x *GetDashboardRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetDashboardRequest.ProtoReflect.Descriptor instead.
-func (*GetDashboardRequest) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{64}
+// Deprecated: Use GetContributionsRequest.ProtoReflect.Descriptor instead.
+func (*GetContributionsRequest) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{118}
 }
 
-type GetDashboardResponse struct {
+type GetContributionsResponse struct {
 	state             protoimpl.MessageState `protogen:"open.v1"`
-	ActiveEnrollments int32                  `protobuf:"varint,1,opt,name=active_enrollments,json=activeEnrollments,proto3" json:"active_enrollments,omitempty"`
-	TotalReadings     int32                  `protobuf:"varint,2,opt,name=total_readings,json=totalReadings,proto3" json:"total_readings,omitempty"`
-	AcceptedReadings  int32                  `protobuf:"varint,3,opt,name=accepted_readings,json=acceptedReadings,proto3" json:"accepted_readings,omitempty"`
-	ContributionScore float64                `protobuf:"fixed64,4,opt,name=contribution_score,json=contributionScore,proto3" json:"contribution_score,omitempty"`
-	Badges            []*BadgeProto          `protobuf:"bytes,5,rep,name=badges,proto3" json:"badges,omitempty"`
-	Enrollments       []*EnrollmentProto     `protobuf:"bytes,6,rep,name=enrollments,proto3" json:"enrollments,omitempty"`
+	Histories         []*ReadingHistoryProto `protobuf:"bytes,1,rep,name=histories,proto3" json:"histories,omitempty"`
+	ContributionScore float64                `protobuf:"fixed64,2,opt,name=contribution_score,json=contributionScore,proto3" json:"contribution_score,omitempty"`
+	Badges            []*BadgeProto          `protobuf:"bytes,3,rep,name=badges,proto3" json:"badges,omitempty"`
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
 
-func (x *GetDashboardResponse) Reset() {
-	*x = GetDashboardResponse{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[65]
+func (x *GetContributionsResponse) Reset() {
+	*x = GetContributionsResponse{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[119]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetDashboardResponse) String() string {
+func (x *GetContributionsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetDashboardResponse) ProtoMessage() {}
+func (*GetContributionsResponse) ProtoMessage() {}
 
-func (x *GetDashboardResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[65]
+func (x *GetContributionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[119]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3694,80 +7241,58 @@ func (x *GetDashboardResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetDashboardResponse.ProtoReflect.Descriptor instead.
-func (*GetDashboardResponse) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{65}
-}
-
-func (x *GetDashboardResponse) GetActiveEnrollments() int32 {
-	if x != nil {
-		return x.ActiveEnrollments
-	}
-	return 0
-}
-
-func (x *GetDashboardResponse) GetTotalReadings() int32 {
-	if x != nil {
-		return x.TotalReadings
-	}
-	return 0
+// Deprecated: Use GetContributionsResponse.ProtoReflect.Descriptor instead.
+func (*GetContributionsResponse) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{119}
 }
 
-func (x *GetDashboardResponse) GetAcceptedReadings() int32 {
+func (x *GetContributionsResponse) GetHistories() []*ReadingHistoryProto {
 	if x != nil {
-		return x.AcceptedReadings
+		return x.Histories
 	}
-	return 0
+	return nil
 }
 
-func (x *GetDashboardResponse) GetContributionScore() float64 {
+func (x *GetContributionsResponse) GetContributionScore() float64 {
 	if x != nil {
 		return x.ContributionScore
 	}
 	return 0
 }
 
-func (x *GetDashboardResponse) GetBadges() []*BadgeProto {
+func (x *GetContributionsResponse) GetBadges() []*BadgeProto {
 	if x != nil {
 		return x.Badges
 	}
 	return nil
 }
 
-func (x *GetDashboardResponse) GetEnrollments() []*EnrollmentProto {
-	if x != nil {
-		return x.Enrollments
-	}
-	return nil
-}
-
-type BrowsePublishedCampaignsRequest struct {
+type LeaderboardEntryProto struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Longitude     *float64               `protobuf:"fixed64,1,opt,name=longitude,proto3,oneof" json:"longitude,omitempty"`
-	Latitude      *float64               `protobuf:"fixed64,2,opt,name=latitude,proto3,oneof" json:"latitude,omitempty"`
-	RadiusKm      *float64               `protobuf:"fixed64,3,opt,name=radius_km,json=radiusKm,proto3,oneof" json:"radius_km,omitempty"`
-	SensorType    *string                `protobuf:"bytes,4,opt,name=sensor_type,json=sensorType,proto3,oneof" json:"sensor_type,omitempty"`
-	Limit         int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset        int32                  `protobuf:"varint,6,opt,name=offset,proto3" json:"offset,omitempty"`
+	Rank          int32                  `protobuf:"varint,1,opt,name=rank,proto3" json:"rank,omitempty"`
+	ScitizenId    string                 `protobuf:"bytes,2,opt,name=scitizen_id,json=scitizenId,proto3" json:"scitizen_id,omitempty"`
+	Score         float64                `protobuf:"fixed64,3,opt,name=score,proto3" json:"score,omitempty"`
+	BadgeCount    int32                  `protobuf:"varint,4,opt,name=badge_count,json=badgeCount,proto3" json:"badge_count,omitempty"`
+	CampaignCount int32                  `protobuf:"varint,5,opt,name=campaign_count,json=campaignCount,proto3" json:"campaign_count,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BrowsePublishedCampaignsRequest) Reset() {
-	*x = BrowsePublishedCampaignsRequest{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[66]
+func (x *LeaderboardEntryProto) Reset() {
+	*x = LeaderboardEntryProto{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[120]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BrowsePublishedCampaignsRequest) String() string {
+func (x *LeaderboardEntryProto) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BrowsePublishedCampaignsRequest) ProtoMessage() {}
+func (*LeaderboardEntryProto) ProtoMessage() {}
 
-func (x *BrowsePublishedCampaignsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[66]
+func (x *LeaderboardEntryProto) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[120]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3778,81 +7303,71 @@ func (x *BrowsePublishedCampaignsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BrowsePublishedCampaignsRequest.ProtoReflect.Descriptor instead.
-func (*BrowsePublishedCampaignsRequest) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{66}
+// Deprecated: Use LeaderboardEntryProto.ProtoReflect.Descriptor instead.
+func (*LeaderboardEntryProto) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{120}
 }
 
-func (x *BrowsePublishedCampaignsRequest) GetLongitude() float64 {
-	if x != nil && x.Longitude != nil {
-		return *x.Longitude
+func (x *LeaderboardEntryProto) GetRank() int32 {
+	if x != nil {
+		return x.Rank
 	}
 	return 0
 }
 
-func (x *BrowsePublishedCampaignsRequest) GetLatitude() float64 {
-	if x != nil && x.Latitude != nil {
-		return *x.Latitude
+func (x *LeaderboardEntryProto) GetScitizenId() string {
+	if x != nil {
+		return x.ScitizenId
 	}
-	return 0
+	return ""
 }
 
-func (x *BrowsePublishedCampaignsRequest) GetRadiusKm() float64 {
-	if x != nil && x.RadiusKm != nil {
-		return *x.RadiusKm
+func (x *LeaderboardEntryProto) GetScore() float64 {
+	if x != nil {
+		return x.Score
 	}
 	return 0
 }
 
-func (x *BrowsePublishedCampaignsRequest) GetSensorType() string {
-	if x != nil && x.SensorType != nil {
-		return *x.SensorType
-	}
-	return ""
-}
-
-func (x *BrowsePublishedCampaignsRequest) GetLimit() int32 {
+func (x *LeaderboardEntryProto) GetBadgeCount() int32 {
 	if x != nil {
-		return x.Limit
+		return x.BadgeCount
 	}
 	return 0
 }
 
-func (x *BrowsePublishedCampaignsRequest) GetOffset() int32 {
+func (x *LeaderboardEntryProto) GetCampaignCount() int32 {
 	if x != nil {
-		return x.Offset
+		return x.CampaignCount
 	}
 	return 0
 }
 
-type CampaignSummaryProto struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Status          string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
-	WindowStart     *string                `protobuf:"bytes,3,opt,name=window_start,json=windowStart,proto3,oneof" json:"window_start,omitempty"`
-	WindowEnd       *string                `protobuf:"bytes,4,opt,name=window_end,json=windowEnd,proto3,oneof" json:"window_end,omitempty"`
-	EnrollmentCount int32                  `protobuf:"varint,5,opt,name=enrollment_count,json=enrollmentCount,proto3" json:"enrollment_count,omitempty"`
-	RequiredSensors []string               `protobuf:"bytes,6,rep,name=required_sensors,json=requiredSensors,proto3" json:"required_sensors,omitempty"`
-	CreatedAt       string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+type GetLeaderboardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CampaignId    *string                `protobuf:"bytes,1,opt,name=campaign_id,json=campaignId,proto3,oneof" json:"campaign_id,omitempty"`
+	TimePeriod    *string                `protobuf:"bytes,2,opt,name=time_period,json=timePeriod,proto3,oneof" json:"time_period,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CampaignSummaryProto) Reset() {
-	*x = CampaignSummaryProto{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[67]
+func (x *GetLeaderboardRequest) Reset() {
+	*x = GetLeaderboardRequest{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[121]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CampaignSummaryProto) String() string {
+func (x *GetLeaderboardRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CampaignSummaryProto) ProtoMessage() {}
+func (*GetLeaderboardRequest) ProtoMessage() {}
 
-func (x *CampaignSummaryProto) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[67]
+func (x *GetLeaderboardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[121]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3863,83 +7378,123 @@ func (x *CampaignSummaryProto) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CampaignSummaryProto.ProtoReflect.Descriptor instead.
-func (*CampaignSummaryProto) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{67}
+// Deprecated: Use GetLeaderboardRequest.ProtoReflect.Descriptor instead.
+func (*GetLeaderboardRequest) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{121}
 }
 
-func (x *CampaignSummaryProto) GetId() string {
-	if x != nil {
-		return x.Id
+func (x *GetLeaderboardRequest) GetCampaignId() string {
+	if x != nil && x.CampaignId != nil {
+		return *x.CampaignId
 	}
 	return ""
 }
 
-func (x *CampaignSummaryProto) GetStatus() string {
-	if x != nil {
-		return x.Status
+func (x *GetLeaderboardRequest) GetTimePeriod() string {
+	if x != nil && x.TimePeriod != nil {
+		return *x.TimePeriod
 	}
 	return ""
 }
 
-func (x *CampaignSummaryProto) GetWindowStart() string {
-	if x != nil && x.WindowStart != nil {
-		return *x.WindowStart
+func (x *GetLeaderboardRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
 	}
-	return ""
+	return 0
 }
 
-func (x *CampaignSummaryProto) GetWindowEnd() string {
-	if x != nil && x.WindowEnd != nil {
-		return *x.WindowEnd
+func (x *GetLeaderboardRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
 	}
-	return ""
+	return 0
 }
 
-func (x *CampaignSummaryProto) GetEnrollmentCount() int32 {
+type GetLeaderboardResponse struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Entries       []*LeaderboardEntryProto `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	Total         int32                    `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Requester     *LeaderboardEntryProto   `protobuf:"bytes,3,opt,name=requester,proto3,oneof" json:"requester,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLeaderboardResponse) Reset() {
+	*x = GetLeaderboardResponse{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[122]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLeaderboardResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLeaderboardResponse) ProtoMessage() {}
+
+func (x *GetLeaderboardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[122]
 	if x != nil {
-		return x.EnrollmentCount
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *CampaignSummaryProto) GetRequiredSensors() []string {
+// Deprecated: Use GetLeaderboardResponse.ProtoReflect.Descriptor instead.
+func (*GetLeaderboardResponse) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{122}
+}
+
+func (x *GetLeaderboardResponse) GetEntries() []*LeaderboardEntryProto {
 	if x != nil {
-		return x.RequiredSensors
+		return x.Entries
 	}
 	return nil
 }
 
-func (x *CampaignSummaryProto) GetCreatedAt() string {
+func (x *GetLeaderboardResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *GetLeaderboardResponse) GetRequester() *LeaderboardEntryProto {
 	if x != nil {
-		return x.CreatedAt
+		return x.Requester
 	}
-	return ""
+	return nil
 }
 
-type BrowsePublishedCampaignsResponse struct {
-	state         protoimpl.MessageState  `protogen:"open.v1"`
-	Campaigns     []*CampaignSummaryProto `protobuf:"bytes,1,rep,name=campaigns,proto3" json:"campaigns,omitempty"`
-	Total         int32                   `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+type ListNotificationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TypeFilter    *string                `protobuf:"bytes,1,opt,name=type_filter,json=typeFilter,proto3,oneof" json:"type_filter,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BrowsePublishedCampaignsResponse) Reset() {
-	*x = BrowsePublishedCampaignsResponse{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[68]
+func (x *ListNotificationsRequest) Reset() {
+	*x = ListNotificationsRequest{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[123]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BrowsePublishedCampaignsResponse) String() string {
+func (x *ListNotificationsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BrowsePublishedCampaignsResponse) ProtoMessage() {}
+func (*ListNotificationsRequest) ProtoMessage() {}
 
-func (x *BrowsePublishedCampaignsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[68]
+func (x *ListNotificationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[123]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3950,47 +7505,56 @@ func (x *BrowsePublishedCampaignsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BrowsePublishedCampaignsResponse.ProtoReflect.Descriptor instead.
-func (*BrowsePublishedCampaignsResponse) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{68}
+// Deprecated: Use ListNotificationsRequest.ProtoReflect.Descriptor instead.
+func (*ListNotificationsRequest) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{123}
 }
 
-func (x *BrowsePublishedCampaignsResponse) GetCampaigns() []*CampaignSummaryProto {
+func (x *ListNotificationsRequest) GetTypeFilter() string {
+	if x != nil && x.TypeFilter != nil {
+		return *x.TypeFilter
+	}
+	return ""
+}
+
+func (x *ListNotificationsRequest) GetLimit() int32 {
 	if x != nil {
-		return x.Campaigns
+		return x.Limit
 	}
-	return nil
+	return 0
 }
 
-func (x *BrowsePublishedCampaignsResponse) GetTotal() int32 {
+func (x *ListNotificationsRequest) GetOffset() int32 {
 	if x != nil {
-		return x.Total
+		return x.Offset
 	}
 	return 0
 }
 
-type GetCampaignDetailRequest struct {
+type ListNotificationsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	CampaignId    string                 `protobuf:"bytes,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	Notifications []*NotificationProto   `protobuf:"bytes,1,rep,name=notifications,proto3" json:"notifications,omitempty"`
+	UnreadCount   int32                  `protobuf:"varint,2,opt,name=unread_count,json=unreadCount,proto3" json:"unread_count,omitempty"`
+	Total         int32                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetCampaignDetailRequest) Reset() {
-	*x = GetCampaignDetailRequest{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[69]
+func (x *ListNotificationsResponse) Reset() {
+	*x = ListNotificationsResponse{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[124]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetCampaignDetailRequest) String() string {
+func (x *ListNotificationsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetCampaignDetailRequest) ProtoMessage() {}
+func (*ListNotificationsResponse) ProtoMessage() {}
 
-func (x *GetCampaignDetailRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[69]
+func (x *ListNotificationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[124]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4001,48 +7565,54 @@ func (x *GetCampaignDetailRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetCampaignDetailRequest.ProtoReflect.Descriptor instead.
-func (*GetCampaignDetailRequest) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{69}
+// Deprecated: Use ListNotificationsResponse.ProtoReflect.Descriptor instead.
+func (*ListNotificationsResponse) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{124}
 }
 
-func (x *GetCampaignDetailRequest) GetCampaignId() string {
+func (x *ListNotificationsResponse) GetNotifications() []*NotificationProto {
 	if x != nil {
-		return x.CampaignId
+		return x.Notifications
 	}
-	return ""
+	return nil
 }
 
-type GetCampaignDetailResponse struct {
+func (x *ListNotificationsResponse) GetUnreadCount() int32 {
+	if x != nil {
+		return x.UnreadCount
+	}
+	return 0
+}
+
+func (x *ListNotificationsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type MarkReadRequest struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
-	CampaignId      string                 `protobuf:"bytes,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
-	Status          string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
-	WindowStart     *string                `protobuf:"bytes,3,opt,name=window_start,json=windowStart,proto3,oneof" json:"window_start,omitempty"`
-	WindowEnd       *string                `protobuf:"bytes,4,opt,name=window_end,json=windowEnd,proto3,oneof" json:"window_end,omitempty"`
-	Parameters      []*ParameterProto      `protobuf:"bytes,5,rep,name=parameters,proto3" json:"parameters,omitempty"`
-	Regions         []*RegionProto         `protobuf:"bytes,6,rep,name=regions,proto3" json:"regions,omitempty"`
-	Eligibility     []*EligibilityProto    `protobuf:"bytes,7,rep,name=eligibility,proto3" json:"eligibility,omitempty"`
-	EnrollmentCount int32                  `protobuf:"varint,8,opt,name=enrollment_count,json=enrollmentCount,proto3" json:"enrollment_count,omitempty"`
-	ProgressPercent float64                `protobuf:"fixed64,9,opt,name=progress_percent,json=progressPercent,proto3" json:"progress_percent,omitempty"`
+	NotificationIds []string               `protobuf:"bytes,1,rep,name=notification_ids,json=notificationIds,proto3" json:"notification_ids,omitempty"`
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
 
-func (x *GetCampaignDetailResponse) Reset() {
-	*x = GetCampaignDetailResponse{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[70]
+func (x *MarkReadRequest) Reset() {
+	*x = MarkReadRequest{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[125]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetCampaignDetailResponse) String() string {
+func (x *MarkReadRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetCampaignDetailResponse) ProtoMessage() {}
+func (*MarkReadRequest) ProtoMessage() {}
 
-func (x *GetCampaignDetailResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[70]
+func (x *MarkReadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[125]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4053,98 +7623,86 @@ func (x *GetCampaignDetailResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetCampaignDetailResponse.ProtoReflect.Descriptor instead.
-func (*GetCampaignDetailResponse) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{70}
-}
-
-func (x *GetCampaignDetailResponse) GetCampaignId() string {
-	if x != nil {
-		return x.CampaignId
-	}
-	return ""
+// Deprecated: Use MarkReadRequest.ProtoReflect.Descriptor instead.
+func (*MarkReadRequest) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{125}
 }
 
-func (x *GetCampaignDetailResponse) GetStatus() string {
+func (x *MarkReadRequest) GetNotificationIds() []string {
 	if x != nil {
-		return x.Status
+		return x.NotificationIds
 	}
-	return ""
+	return nil
 }
 
-func (x *GetCampaignDetailResponse) GetWindowStart() string {
-	if x != nil && x.WindowStart != nil {
-		return *x.WindowStart
-	}
-	return ""
+type MarkReadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MarkedCount   int32                  `protobuf:"varint,1,opt,name=marked_count,json=markedCount,proto3" json:"marked_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetCampaignDetailResponse) GetWindowEnd() string {
-	if x != nil && x.WindowEnd != nil {
-		return *x.WindowEnd
-	}
-	return ""
+func (x *MarkReadResponse) Reset() {
+	*x = MarkReadResponse{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[126]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetCampaignDetailResponse) GetParameters() []*ParameterProto {
-	if x != nil {
-		return x.Parameters
-	}
-	return nil
+func (x *MarkReadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *GetCampaignDetailResponse) GetRegions() []*RegionProto {
-	if x != nil {
-		return x.Regions
-	}
-	return nil
-}
+func (*MarkReadResponse) ProtoMessage() {}
 
-func (x *GetCampaignDetailResponse) GetEligibility() []*EligibilityProto {
+func (x *MarkReadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[126]
 	if x != nil {
-		return x.Eligibility
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *GetCampaignDetailResponse) GetEnrollmentCount() int32 {
-	if x != nil {
-		return x.EnrollmentCount
-	}
-	return 0
+// Deprecated: Use MarkReadResponse.ProtoReflect.Descriptor instead.
+func (*MarkReadResponse) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{126}
 }
 
-func (x *GetCampaignDetailResponse) GetProgressPercent() float64 {
+func (x *MarkReadResponse) GetMarkedCount() int32 {
 	if x != nil {
-		return x.ProgressPercent
+		return x.MarkedCount
 	}
 	return 0
 }
 
-type SearchCampaignsRequest struct {
+type NotificationPreferenceProto struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
-	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	InApp         bool                   `protobuf:"varint,2,opt,name=in_app,json=inApp,proto3" json:"in_app,omitempty"`
+	Email         bool                   `protobuf:"varint,3,opt,name=email,proto3" json:"email,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SearchCampaignsRequest) Reset() {
-	*x = SearchCampaignsRequest{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[71]
+func (x *NotificationPreferenceProto) Reset() {
+	*x = NotificationPreferenceProto{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[127]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SearchCampaignsRequest) String() string {
+func (x *NotificationPreferenceProto) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SearchCampaignsRequest) ProtoMessage() {}
+func (*NotificationPreferenceProto) ProtoMessage() {}
 
-func (x *SearchCampaignsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[71]
+func (x *NotificationPreferenceProto) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[127]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4155,55 +7713,90 @@ func (x *SearchCampaignsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SearchCampaignsRequest.ProtoReflect.Descriptor instead.
-func (*SearchCampaignsRequest) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{71}
+// Deprecated: Use NotificationPreferenceProto.ProtoReflect.Descriptor instead.
+func (*NotificationPreferenceProto) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{127}
 }
 
-func (x *SearchCampaignsRequest) GetQuery() string {
+func (x *NotificationPreferenceProto) GetType() string {
 	if x != nil {
-		return x.Query
+		return x.Type
 	}
 	return ""
 }
 
-func (x *SearchCampaignsRequest) GetLimit() int32 {
+func (x *NotificationPreferenceProto) GetInApp() bool {
 	if x != nil {
-		return x.Limit
+		return x.InApp
 	}
-	return 0
+	return false
 }
 
-func (x *SearchCampaignsRequest) GetOffset() int32 {
+func (x *NotificationPreferenceProto) GetEmail() bool {
 	if x != nil {
-		return x.Offset
+		return x.Email
 	}
-	return 0
+	return false
+}
+
+type GetPreferencesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPreferencesRequest) Reset() {
+	*x = GetPreferencesRequest{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[128]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPreferencesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPreferencesRequest) ProtoMessage() {}
+
+func (x *GetPreferencesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[128]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-type SearchCampaignsResponse struct {
-	state         protoimpl.MessageState  `protogen:"open.v1"`
-	Campaigns     []*CampaignSummaryProto `protobuf:"bytes,1,rep,name=campaigns,proto3" json:"campaigns,omitempty"`
-	Total         int32                   `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+// Deprecated: Use GetPreferencesRequest.ProtoReflect.Descriptor instead.
+func (*GetPreferencesRequest) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{128}
+}
+
+type GetPreferencesResponse struct {
+	state         protoimpl.MessageState         `protogen:"open.v1"`
+	Preferences   []*NotificationPreferenceProto `protobuf:"bytes,1,rep,name=preferences,proto3" json:"preferences,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SearchCampaignsResponse) Reset() {
-	*x = SearchCampaignsResponse{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[72]
+func (x *GetPreferencesResponse) Reset() {
+	*x = GetPreferencesResponse{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[129]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SearchCampaignsResponse) String() string {
+func (x *GetPreferencesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SearchCampaignsResponse) ProtoMessage() {}
+func (*GetPreferencesResponse) ProtoMessage() {}
 
-func (x *SearchCampaignsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[72]
+func (x *GetPreferencesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[129]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4214,48 +7807,40 @@ func (x *SearchCampaignsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SearchCampaignsResponse.ProtoReflect.Descriptor instead.
-func (*SearchCampaignsResponse) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{72}
+// Deprecated: Use GetPreferencesResponse.ProtoReflect.Descriptor instead.
+func (*GetPreferencesResponse) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{129}
 }
 
-func (x *SearchCampaignsResponse) GetCampaigns() []*CampaignSummaryProto {
+func (x *GetPreferencesResponse) GetPreferences() []*NotificationPreferenceProto {
 	if x != nil {
-		return x.Campaigns
+		return x.Preferences
 	}
 	return nil
 }
 
-func (x *SearchCampaignsResponse) GetTotal() int32 {
-	if x != nil {
-		return x.Total
-	}
-	return 0
-}
-
-type ConsentProto struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Version       string                 `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
-	Scope         string                 `protobuf:"bytes,2,opt,name=scope,proto3" json:"scope,omitempty"`
+type UpdatePreferencesRequest struct {
+	state         protoimpl.MessageState         `protogen:"open.v1"`
+	Preferences   []*NotificationPreferenceProto `protobuf:"bytes,1,rep,name=preferences,proto3" json:"preferences,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ConsentProto) Reset() {
-	*x = ConsentProto{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[73]
+func (x *UpdatePreferencesRequest) Reset() {
+	*x = UpdatePreferencesRequest{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[130]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ConsentProto) String() string {
+func (x *UpdatePreferencesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ConsentProto) ProtoMessage() {}
+func (*UpdatePreferencesRequest) ProtoMessage() {}
 
-func (x *ConsentProto) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[73]
+func (x *UpdatePreferencesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[130]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4266,49 +7851,39 @@ func (x *ConsentProto) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ConsentProto.ProtoReflect.Descriptor instead.
-func (*ConsentProto) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{73}
-}
-
-func (x *ConsentProto) GetVersion() string {
-	if x != nil {
-		return x.Version
-	}
-	return ""
+// Deprecated: Use UpdatePreferencesRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePreferencesRequest) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{130}
 }
 
-func (x *ConsentProto) GetScope() string {
+func (x *UpdatePreferencesRequest) GetPreferences() []*NotificationPreferenceProto {
 	if x != nil {
-		return x.Scope
+		return x.Preferences
 	}
-	return ""
+	return nil
 }
 
-type EnrollDeviceRequest struct {
+type UpdatePreferencesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	DeviceId      string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
-	CampaignId    string                 `protobuf:"bytes,2,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
-	Consent       *ConsentProto          `protobuf:"bytes,3,opt,name=consent,proto3" json:"consent,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *EnrollDeviceRequest) Reset() {
-	*x = EnrollDeviceRequest{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[74]
+func (x *UpdatePreferencesResponse) Reset() {
+	*x = UpdatePreferencesResponse{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[131]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *EnrollDeviceRequest) String() string {
+func (x *UpdatePreferencesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EnrollDeviceRequest) ProtoMessage() {}
+func (*UpdatePreferencesResponse) ProtoMessage() {}
 
-func (x *EnrollDeviceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[74]
+func (x *UpdatePreferencesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[131]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4319,56 +7894,41 @@ func (x *EnrollDeviceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EnrollDeviceRequest.ProtoReflect.Descriptor instead.
-func (*EnrollDeviceRequest) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{74}
-}
-
-func (x *EnrollDeviceRequest) GetDeviceId() string {
-	if x != nil {
-		return x.DeviceId
-	}
-	return ""
-}
-
-func (x *EnrollDeviceRequest) GetCampaignId() string {
-	if x != nil {
-		return x.CampaignId
-	}
-	return ""
-}
-
-func (x *EnrollDeviceRequest) GetConsent() *ConsentProto {
-	if x != nil {
-		return x.Consent
-	}
-	return nil
+// Deprecated: Use UpdatePreferencesResponse.ProtoReflect.Descriptor instead.
+func (*UpdatePreferencesResponse) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{131}
 }
 
-type EnrollDeviceResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Enrolled      bool                   `protobuf:"varint,1,opt,name=enrolled,proto3" json:"enrolled,omitempty"`
-	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
-	EnrollmentId  string                 `protobuf:"bytes,3,opt,name=enrollment_id,json=enrollmentId,proto3" json:"enrollment_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type SuspendByClassRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	DeviceClass string                 `protobuf:"bytes,1,opt,name=device_class,json=deviceClass,proto3" json:"device_class,omitempty"`
+	FirmwareMin string                 `protobuf:"bytes,2,opt,name=firmware_min,json=firmwareMin,proto3" json:"firmware_min,omitempty"`
+	FirmwareMax string                 `protobuf:"bytes,3,opt,name=firmware_max,json=firmwareMax,proto3" json:"firmware_max,omitempty"`
+	WindowStart string                 `protobuf:"bytes,4,opt,name=window_start,json=windowStart,proto3" json:"window_start,omitempty"`
+	WindowEnd   string                 `protobuf:"bytes,5,opt,name=window_end,json=windowEnd,proto3" json:"window_end,omitempty"`
+	Reason      string                 `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	// Version constraint selecting affected firmware, e.g. ">=2.1.0 <3, !=2.4.1".
+	// Overrides firmware_min/firmware_max when set.
+	FirmwareConstraint string `protobuf:"bytes,7,opt,name=firmware_constraint,json=firmwareConstraint,proto3" json:"firmware_constraint,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
-func (x *EnrollDeviceResponse) Reset() {
-	*x = EnrollDeviceResponse{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[75]
+func (x *SuspendByClassRequest) Reset() {
+	*x = SuspendByClassRequest{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[132]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *EnrollDeviceResponse) String() string {
+func (x *SuspendByClassRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EnrollDeviceResponse) ProtoMessage() {}
+func (*SuspendByClassRequest) ProtoMessage() {}
 
-func (x *EnrollDeviceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[75]
+func (x *SuspendByClassRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[132]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4379,97 +7939,84 @@ func (x *EnrollDeviceResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EnrollDeviceResponse.ProtoReflect.Descriptor instead.
-func (*EnrollDeviceResponse) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{75}
+// Deprecated: Use SuspendByClassRequest.ProtoReflect.Descriptor instead.
+func (*SuspendByClassRequest) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{132}
 }
 
-func (x *EnrollDeviceResponse) GetEnrolled() bool {
+func (x *SuspendByClassRequest) GetDeviceClass() string {
 	if x != nil {
-		return x.Enrolled
+		return x.DeviceClass
 	}
-	return false
+	return ""
 }
 
-func (x *EnrollDeviceResponse) GetReason() string {
+func (x *SuspendByClassRequest) GetFirmwareMin() string {
 	if x != nil {
-		return x.Reason
+		return x.FirmwareMin
 	}
 	return ""
 }
 
-func (x *EnrollDeviceResponse) GetEnrollmentId() string {
+func (x *SuspendByClassRequest) GetFirmwareMax() string {
 	if x != nil {
-		return x.EnrollmentId
+		return x.FirmwareMax
 	}
 	return ""
 }
 
-type WithdrawEnrollmentRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	EnrollmentId  string                 `protobuf:"bytes,1,opt,name=enrollment_id,json=enrollmentId,proto3" json:"enrollment_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *WithdrawEnrollmentRequest) Reset() {
-	*x = WithdrawEnrollmentRequest{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[76]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *WithdrawEnrollmentRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *SuspendByClassRequest) GetWindowStart() string {
+	if x != nil {
+		return x.WindowStart
+	}
+	return ""
 }
 
-func (*WithdrawEnrollmentRequest) ProtoMessage() {}
-
-func (x *WithdrawEnrollmentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[76]
+func (x *SuspendByClassRequest) GetWindowEnd() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.WindowEnd
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use WithdrawEnrollmentRequest.ProtoReflect.Descriptor instead.
-func (*WithdrawEnrollmentRequest) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{76}
+func (x *SuspendByClassRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
 }
 
-func (x *WithdrawEnrollmentRequest) GetEnrollmentId() string {
+func (x *SuspendByClassRequest) GetFirmwareConstraint() string {
 	if x != nil {
-		return x.EnrollmentId
+		return x.FirmwareConstraint
 	}
 	return ""
 }
 
-type WithdrawEnrollmentResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type SuspendByClassResponse struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	SuspendedCount      int32                  `protobuf:"varint,1,opt,name=suspended_count,json=suspendedCount,proto3" json:"suspended_count,omitempty"`
+	QuarantinedReadings int64                  `protobuf:"varint,2,opt,name=quarantined_readings,json=quarantinedReadings,proto3" json:"quarantined_readings,omitempty"`
+	NotifiedScitizens   int32                  `protobuf:"varint,3,opt,name=notified_scitizens,json=notifiedScitizens,proto3" json:"notified_scitizens,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
 }
 
-func (x *WithdrawEnrollmentResponse) Reset() {
-	*x = WithdrawEnrollmentResponse{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[77]
+func (x *SuspendByClassResponse) Reset() {
+	*x = SuspendByClassResponse{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[133]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *WithdrawEnrollmentResponse) String() string {
+func (x *SuspendByClassResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WithdrawEnrollmentResponse) ProtoMessage() {}
+func (*SuspendByClassResponse) ProtoMessage() {}
 
-func (x *WithdrawEnrollmentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[77]
+func (x *SuspendByClassResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[133]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4480,40 +8027,64 @@ func (x *WithdrawEnrollmentResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WithdrawEnrollmentResponse.ProtoReflect.Descriptor instead.
-func (*WithdrawEnrollmentResponse) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{77}
+// Deprecated: Use SuspendByClassResponse.ProtoReflect.Descriptor instead.
+func (*SuspendByClassResponse) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{133}
 }
 
-type DeviceSummaryProto struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Status            string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
-	Class             string                 `protobuf:"bytes,3,opt,name=class,proto3" json:"class,omitempty"`
-	FirmwareVersion   string                 `protobuf:"bytes,4,opt,name=firmware_version,json=firmwareVersion,proto3" json:"firmware_version,omitempty"`
-	Tier              int32                  `protobuf:"varint,5,opt,name=tier,proto3" json:"tier,omitempty"`
-	Sensors           []string               `protobuf:"bytes,6,rep,name=sensors,proto3" json:"sensors,omitempty"`
-	ActiveEnrollments int32                  `protobuf:"varint,7,opt,name=active_enrollments,json=activeEnrollments,proto3" json:"active_enrollments,omitempty"`
-	LastSeen          *string                `protobuf:"bytes,8,opt,name=last_seen,json=lastSeen,proto3,oneof" json:"last_seen,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+func (x *SuspendByClassResponse) GetSuspendedCount() int32 {
+	if x != nil {
+		return x.SuspendedCount
+	}
+	return 0
+}
+
+func (x *SuspendByClassResponse) GetQuarantinedReadings() int64 {
+	if x != nil {
+		return x.QuarantinedReadings
+	}
+	return 0
+}
+
+func (x *SuspendByClassResponse) GetNotifiedScitizens() int32 {
+	if x != nil {
+		return x.NotifiedScitizens
+	}
+	return 0
 }
 
-func (x *DeviceSummaryProto) Reset() {
-	*x = DeviceSummaryProto{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[78]
+type DeadLetterProto struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Topic          string                 `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`
+	DeviceId       string                 `protobuf:"bytes,3,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	CampaignId     *string                `protobuf:"bytes,4,opt,name=campaign_id,json=campaignId,proto3,oneof" json:"campaign_id,omitempty"`
+	ErrorClass     string                 `protobuf:"bytes,5,opt,name=error_class,json=errorClass,proto3" json:"error_class,omitempty"`
+	ErrorMessage   string                 `protobuf:"bytes,6,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	ReceivedAt     string                 `protobuf:"bytes,7,opt,name=received_at,json=receivedAt,proto3" json:"received_at,omitempty"`
+	Status         string                 `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
+	ReplayCount    int32                  `protobuf:"varint,9,opt,name=replay_count,json=replayCount,proto3" json:"replay_count,omitempty"`
+	LastReplayedAt *string                `protobuf:"bytes,10,opt,name=last_replayed_at,json=lastReplayedAt,proto3,oneof" json:"last_replayed_at,omitempty"`
+	PayloadSize    int32                  `protobuf:"varint,11,opt,name=payload_size,json=payloadSize,proto3" json:"payload_size,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DeadLetterProto) Reset() {
+	*x = DeadLetterProto{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[134]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeviceSummaryProto) String() string {
+func (x *DeadLetterProto) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeviceSummaryProto) ProtoMessage() {}
+func (*DeadLetterProto) ProtoMessage() {}
 
-func (x *DeviceSummaryProto) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[78]
+func (x *DeadLetterProto) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[134]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4524,125 +8095,114 @@ func (x *DeviceSummaryProto) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeviceSummaryProto.ProtoReflect.Descriptor instead.
-func (*DeviceSummaryProto) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{78}
+// Deprecated: Use DeadLetterProto.ProtoReflect.Descriptor instead.
+func (*DeadLetterProto) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{134}
 }
 
-func (x *DeviceSummaryProto) GetId() string {
+func (x *DeadLetterProto) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-func (x *DeviceSummaryProto) GetStatus() string {
+func (x *DeadLetterProto) GetTopic() string {
 	if x != nil {
-		return x.Status
+		return x.Topic
 	}
 	return ""
 }
 
-func (x *DeviceSummaryProto) GetClass() string {
+func (x *DeadLetterProto) GetDeviceId() string {
 	if x != nil {
-		return x.Class
+		return x.DeviceId
 	}
 	return ""
 }
 
-func (x *DeviceSummaryProto) GetFirmwareVersion() string {
-	if x != nil {
-		return x.FirmwareVersion
+func (x *DeadLetterProto) GetCampaignId() string {
+	if x != nil && x.CampaignId != nil {
+		return *x.CampaignId
 	}
 	return ""
 }
 
-func (x *DeviceSummaryProto) GetTier() int32 {
+func (x *DeadLetterProto) GetErrorClass() string {
 	if x != nil {
-		return x.Tier
+		return x.ErrorClass
 	}
-	return 0
+	return ""
 }
 
-func (x *DeviceSummaryProto) GetSensors() []string {
+func (x *DeadLetterProto) GetErrorMessage() string {
 	if x != nil {
-		return x.Sensors
+		return x.ErrorMessage
 	}
-	return nil
+	return ""
 }
 
-func (x *DeviceSummaryProto) GetActiveEnrollments() int32 {
+func (x *DeadLetterProto) GetReceivedAt() string {
 	if x != nil {
-		return x.ActiveEnrollments
+		return x.ReceivedAt
 	}
-	return 0
+	return ""
 }
 
-func (x *DeviceSummaryProto) GetLastSeen() string {
-	if x != nil && x.LastSeen != nil {
-		return *x.LastSeen
+func (x *DeadLetterProto) GetStatus() string {
+	if x != nil {
+		return x.Status
 	}
 	return ""
 }
 
-type GetDevicesRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *GetDevicesRequest) Reset() {
-	*x = GetDevicesRequest{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[79]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *DeadLetterProto) GetReplayCount() int32 {
+	if x != nil {
+		return x.ReplayCount
+	}
+	return 0
 }
 
-func (x *GetDevicesRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *DeadLetterProto) GetLastReplayedAt() string {
+	if x != nil && x.LastReplayedAt != nil {
+		return *x.LastReplayedAt
+	}
+	return ""
 }
 
-func (*GetDevicesRequest) ProtoMessage() {}
-
-func (x *GetDevicesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[79]
+func (x *DeadLetterProto) GetPayloadSize() int32 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.PayloadSize
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use GetDevicesRequest.ProtoReflect.Descriptor instead.
-func (*GetDevicesRequest) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{79}
+	return 0
 }
 
-type GetDevicesResponse struct {
+type ListDeadLettersRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Devices       []*DeviceSummaryProto  `protobuf:"bytes,1,rep,name=devices,proto3" json:"devices,omitempty"`
+	ErrorClass    string                 `protobuf:"bytes,1,opt,name=error_class,json=errorClass,proto3" json:"error_class,omitempty"`
+	DeviceId      string                 `protobuf:"bytes,2,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,5,opt,name=offset,proto3" json:"offset,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetDevicesResponse) Reset() {
-	*x = GetDevicesResponse{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[80]
+func (x *ListDeadLettersRequest) Reset() {
+	*x = ListDeadLettersRequest{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[135]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetDevicesResponse) String() string {
+func (x *ListDeadLettersRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetDevicesResponse) ProtoMessage() {}
+func (*ListDeadLettersRequest) ProtoMessage() {}
 
-func (x *GetDevicesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[80]
+func (x *ListDeadLettersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[135]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4653,100 +8213,68 @@ func (x *GetDevicesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetDevicesResponse.ProtoReflect.Descriptor instead.
-func (*GetDevicesResponse) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{80}
+// Deprecated: Use ListDeadLettersRequest.ProtoReflect.Descriptor instead.
+func (*ListDeadLettersRequest) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{135}
 }
 
-func (x *GetDevicesResponse) GetDevices() []*DeviceSummaryProto {
+func (x *ListDeadLettersRequest) GetErrorClass() string {
 	if x != nil {
-		return x.Devices
+		return x.ErrorClass
 	}
-	return nil
-}
-
-type ConnectionEventProto struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	EventType     string                 `protobuf:"bytes,1,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
-	Timestamp     string                 `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	Reason        *string                `protobuf:"bytes,3,opt,name=reason,proto3,oneof" json:"reason,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *ConnectionEventProto) Reset() {
-	*x = ConnectionEventProto{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[81]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *ConnectionEventProto) String() string {
-	return protoimpl.X.MessageStringOf(x)
+	return ""
 }
 
-func (*ConnectionEventProto) ProtoMessage() {}
-
-func (x *ConnectionEventProto) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[81]
+func (x *ListDeadLettersRequest) GetDeviceId() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.DeviceId
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use ConnectionEventProto.ProtoReflect.Descriptor instead.
-func (*ConnectionEventProto) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{81}
+	return ""
 }
 
-func (x *ConnectionEventProto) GetEventType() string {
+func (x *ListDeadLettersRequest) GetStatus() string {
 	if x != nil {
-		return x.EventType
+		return x.Status
 	}
 	return ""
 }
 
-func (x *ConnectionEventProto) GetTimestamp() string {
+func (x *ListDeadLettersRequest) GetLimit() int32 {
 	if x != nil {
-		return x.Timestamp
+		return x.Limit
 	}
-	return ""
+	return 0
 }
 
-func (x *ConnectionEventProto) GetReason() string {
-	if x != nil && x.Reason != nil {
-		return *x.Reason
+func (x *ListDeadLettersRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
 	}
-	return ""
+	return 0
 }
 
-type GetDeviceDetailRequest struct {
+type ListDeadLettersResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	DeviceId      string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	DeadLetters   []*DeadLetterProto     `protobuf:"bytes,1,rep,name=dead_letters,json=deadLetters,proto3" json:"dead_letters,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetDeviceDetailRequest) Reset() {
-	*x = GetDeviceDetailRequest{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[82]
+func (x *ListDeadLettersResponse) Reset() {
+	*x = ListDeadLettersResponse{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[136]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetDeviceDetailRequest) String() string {
+func (x *ListDeadLettersResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetDeviceDetailRequest) ProtoMessage() {}
+func (*ListDeadLettersResponse) ProtoMessage() {}
 
-func (x *GetDeviceDetailRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[82]
+func (x *ListDeadLettersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[136]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4757,42 +8285,40 @@ func (x *GetDeviceDetailRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetDeviceDetailRequest.ProtoReflect.Descriptor instead.
-func (*GetDeviceDetailRequest) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{82}
+// Deprecated: Use ListDeadLettersResponse.ProtoReflect.Descriptor instead.
+func (*ListDeadLettersResponse) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{136}
 }
 
-func (x *GetDeviceDetailRequest) GetDeviceId() string {
+func (x *ListDeadLettersResponse) GetDeadLetters() []*DeadLetterProto {
 	if x != nil {
-		return x.DeviceId
+		return x.DeadLetters
 	}
-	return ""
+	return nil
 }
 
-type GetDeviceDetailResponse struct {
-	state             protoimpl.MessageState  `protogen:"open.v1"`
-	Device            *DeviceProto            `protobuf:"bytes,1,opt,name=device,proto3" json:"device,omitempty"`
-	Enrollments       []*EnrollmentProto      `protobuf:"bytes,2,rep,name=enrollments,proto3" json:"enrollments,omitempty"`
-	ConnectionHistory []*ConnectionEventProto `protobuf:"bytes,3,rep,name=connection_history,json=connectionHistory,proto3" json:"connection_history,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+type GetDeadLetterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetDeviceDetailResponse) Reset() {
-	*x = GetDeviceDetailResponse{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[83]
+func (x *GetDeadLetterRequest) Reset() {
+	*x = GetDeadLetterRequest{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[137]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetDeviceDetailResponse) String() string {
+func (x *GetDeadLetterRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetDeviceDetailResponse) ProtoMessage() {}
+func (*GetDeadLetterRequest) ProtoMessage() {}
 
-func (x *GetDeviceDetailResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[83]
+func (x *GetDeadLetterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[137]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4803,59 +8329,41 @@ func (x *GetDeviceDetailResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetDeviceDetailResponse.ProtoReflect.Descriptor instead.
-func (*GetDeviceDetailResponse) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{83}
-}
-
-func (x *GetDeviceDetailResponse) GetDevice() *DeviceProto {
-	if x != nil {
-		return x.Device
-	}
-	return nil
-}
-
-func (x *GetDeviceDetailResponse) GetEnrollments() []*EnrollmentProto {
-	if x != nil {
-		return x.Enrollments
-	}
-	return nil
+// Deprecated: Use GetDeadLetterRequest.ProtoReflect.Descriptor instead.
+func (*GetDeadLetterRequest) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{137}
 }
 
-func (x *GetDeviceDetailResponse) GetConnectionHistory() []*ConnectionEventProto {
+func (x *GetDeadLetterRequest) GetId() string {
 	if x != nil {
-		return x.ConnectionHistory
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-type NotificationProto struct {
+type GetDeadLetterResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
-	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
-	Read          bool                   `protobuf:"varint,4,opt,name=read,proto3" json:"read,omitempty"`
-	ResourceLink  *string                `protobuf:"bytes,5,opt,name=resource_link,json=resourceLink,proto3,oneof" json:"resource_link,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	DeadLetter    *DeadLetterProto       `protobuf:"bytes,1,opt,name=dead_letter,json=deadLetter,proto3" json:"dead_letter,omitempty"`
+	Payload       []byte                 `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *NotificationProto) Reset() {
-	*x = NotificationProto{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[84]
+func (x *GetDeadLetterResponse) Reset() {
+	*x = GetDeadLetterResponse{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[138]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *NotificationProto) String() string {
+func (x *GetDeadLetterResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*NotificationProto) ProtoMessage() {}
+func (*GetDeadLetterResponse) ProtoMessage() {}
 
-func (x *NotificationProto) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[84]
+func (x *GetDeadLetterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[138]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4866,77 +8374,47 @@ func (x *NotificationProto) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use NotificationProto.ProtoReflect.Descriptor instead.
-func (*NotificationProto) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{84}
-}
-
-func (x *NotificationProto) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
-}
-
-func (x *NotificationProto) GetType() string {
-	if x != nil {
-		return x.Type
-	}
-	return ""
-}
-
-func (x *NotificationProto) GetMessage() string {
-	if x != nil {
-		return x.Message
-	}
-	return ""
+// Deprecated: Use GetDeadLetterResponse.ProtoReflect.Descriptor instead.
+func (*GetDeadLetterResponse) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{138}
 }
 
-func (x *NotificationProto) GetRead() bool {
+func (x *GetDeadLetterResponse) GetDeadLetter() *DeadLetterProto {
 	if x != nil {
-		return x.Read
-	}
-	return false
-}
-
-func (x *NotificationProto) GetResourceLink() string {
-	if x != nil && x.ResourceLink != nil {
-		return *x.ResourceLink
+		return x.DeadLetter
 	}
-	return ""
+	return nil
 }
 
-func (x *NotificationProto) GetCreatedAt() string {
+func (x *GetDeadLetterResponse) GetPayload() []byte {
 	if x != nil {
-		return x.CreatedAt
+		return x.Payload
 	}
-	return ""
+	return nil
 }
 
-type GetNotificationsRequest struct {
+type ReplayDeadLetterRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	TypeFilter    *string                `protobuf:"bytes,1,opt,name=type_filter,json=typeFilter,proto3,oneof" json:"type_filter,omitempty"`
-	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetNotificationsRequest) Reset() {
-	*x = GetNotificationsRequest{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[85]
+func (x *ReplayDeadLetterRequest) Reset() {
+	*x = ReplayDeadLetterRequest{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[139]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetNotificationsRequest) String() string {
+func (x *ReplayDeadLetterRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetNotificationsRequest) ProtoMessage() {}
+func (*ReplayDeadLetterRequest) ProtoMessage() {}
 
-func (x *GetNotificationsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[85]
+func (x *ReplayDeadLetterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[139]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4947,56 +8425,43 @@ func (x *GetNotificationsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetNotificationsRequest.ProtoReflect.Descriptor instead.
-func (*GetNotificationsRequest) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{85}
-}
-
-func (x *GetNotificationsRequest) GetTypeFilter() string {
-	if x != nil && x.TypeFilter != nil {
-		return *x.TypeFilter
-	}
-	return ""
-}
-
-func (x *GetNotificationsRequest) GetLimit() int32 {
-	if x != nil {
-		return x.Limit
-	}
-	return 0
+// Deprecated: Use ReplayDeadLetterRequest.ProtoReflect.Descriptor instead.
+func (*ReplayDeadLetterRequest) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{139}
 }
 
-func (x *GetNotificationsRequest) GetOffset() int32 {
+func (x *ReplayDeadLetterRequest) GetId() string {
 	if x != nil {
-		return x.Offset
+		return x.Id
 	}
-	return 0
+	return ""
 }
 
-type GetNotificationsResponse struct {
+type ReplayDeadLetterResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Notifications []*NotificationProto   `protobuf:"bytes,1,rep,name=notifications,proto3" json:"notifications,omitempty"`
-	UnreadCount   int32                  `protobuf:"varint,2,opt,name=unread_count,json=unreadCount,proto3" json:"unread_count,omitempty"`
-	Total         int32                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	DeadLetter    *DeadLetterProto       `protobuf:"bytes,1,opt,name=dead_letter,json=deadLetter,proto3" json:"dead_letter,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"` // ingestion outcome: accepted | quarantined | duplicate | rejected | error
+	Code          string                 `protobuf:"bytes,3,opt,name=code,proto3" json:"code,omitempty"`
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetNotificationsResponse) Reset() {
-	*x = GetNotificationsResponse{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[86]
+func (x *ReplayDeadLetterResponse) Reset() {
+	*x = ReplayDeadLetterResponse{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[140]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetNotificationsResponse) String() string {
+func (x *ReplayDeadLetterResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetNotificationsResponse) ProtoMessage() {}
+func (*ReplayDeadLetterResponse) ProtoMessage() {}
 
-func (x *GetNotificationsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[86]
+func (x *ReplayDeadLetterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[140]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5007,58 +8472,63 @@ func (x *GetNotificationsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetNotificationsResponse.ProtoReflect.Descriptor instead.
-func (*GetNotificationsResponse) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{86}
+// Deprecated: Use ReplayDeadLetterResponse.ProtoReflect.Descriptor instead.
+func (*ReplayDeadLetterResponse) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{140}
 }
 
-func (x *GetNotificationsResponse) GetNotifications() []*NotificationProto {
+func (x *ReplayDeadLetterResponse) GetDeadLetter() *DeadLetterProto {
 	if x != nil {
-		return x.Notifications
+		return x.DeadLetter
 	}
 	return nil
 }
 
-func (x *GetNotificationsResponse) GetUnreadCount() int32 {
+func (x *ReplayDeadLetterResponse) GetStatus() string {
 	if x != nil {
-		return x.UnreadCount
+		return x.Status
 	}
-	return 0
+	return ""
 }
 
-func (x *GetNotificationsResponse) GetTotal() int32 {
+func (x *ReplayDeadLetterResponse) GetCode() string {
 	if x != nil {
-		return x.Total
+		return x.Code
 	}
-	return 0
+	return ""
 }
 
-type ReadingHistoryProto struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	DeviceId      string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
-	CampaignId    string                 `protobuf:"bytes,2,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
-	TotalReadings int32                  `protobuf:"varint,3,opt,name=total_readings,json=totalReadings,proto3" json:"total_readings,omitempty"`
-	Accepted      int32                  `protobuf:"varint,4,opt,name=accepted,proto3" json:"accepted,omitempty"`
-	Rejected      int32                  `protobuf:"varint,5,opt,name=rejected,proto3" json:"rejected,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *ReplayDeadLetterResponse) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
 }
 
-func (x *ReadingHistoryProto) Reset() {
-	*x = ReadingHistoryProto{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[87]
+type PurgeDeadLettersRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Ids            []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	ErrorClass     string                 `protobuf:"bytes,2,opt,name=error_class,json=errorClass,proto3" json:"error_class,omitempty"`
+	ReceivedBefore string                 `protobuf:"bytes,3,opt,name=received_before,json=receivedBefore,proto3" json:"received_before,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PurgeDeadLettersRequest) Reset() {
+	*x = PurgeDeadLettersRequest{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[141]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReadingHistoryProto) String() string {
+func (x *PurgeDeadLettersRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReadingHistoryProto) ProtoMessage() {}
+func (*PurgeDeadLettersRequest) ProtoMessage() {}
 
-func (x *ReadingHistoryProto) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[87]
+func (x *PurgeDeadLettersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[141]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5069,67 +8539,54 @@ func (x *ReadingHistoryProto) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReadingHistoryProto.ProtoReflect.Descriptor instead.
-func (*ReadingHistoryProto) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{87}
+// Deprecated: Use PurgeDeadLettersRequest.ProtoReflect.Descriptor instead.
+func (*PurgeDeadLettersRequest) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{141}
 }
 
-func (x *ReadingHistoryProto) GetDeviceId() string {
+func (x *PurgeDeadLettersRequest) GetIds() []string {
 	if x != nil {
-		return x.DeviceId
+		return x.Ids
 	}
-	return ""
+	return nil
 }
 
-func (x *ReadingHistoryProto) GetCampaignId() string {
+func (x *PurgeDeadLettersRequest) GetErrorClass() string {
 	if x != nil {
-		return x.CampaignId
+		return x.ErrorClass
 	}
 	return ""
 }
 
-func (x *ReadingHistoryProto) GetTotalReadings() int32 {
-	if x != nil {
-		return x.TotalReadings
-	}
-	return 0
-}
-
-func (x *ReadingHistoryProto) GetAccepted() int32 {
-	if x != nil {
-		return x.Accepted
-	}
-	return 0
-}
-
-func (x *ReadingHistoryProto) GetRejected() int32 {
+func (x *PurgeDeadLettersRequest) GetReceivedBefore() string {
 	if x != nil {
-		return x.Rejected
+		return x.ReceivedBefore
 	}
-	return 0
+	return ""
 }
 
-type GetContributionsRequest struct {
+type PurgeDeadLettersResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Purged        int64                  `protobuf:"varint,1,opt,name=purged,proto3" json:"purged,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetContributionsRequest) Reset() {
-	*x = GetContributionsRequest{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[88]
+func (x *PurgeDeadLettersResponse) Reset() {
+	*x = PurgeDeadLettersResponse{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[142]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetContributionsRequest) String() string {
+func (x *PurgeDeadLettersResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetContributionsRequest) ProtoMessage() {}
+func (*PurgeDeadLettersResponse) ProtoMessage() {}
 
-func (x *GetContributionsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[88]
+func (x *PurgeDeadLettersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[142]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5140,35 +8597,41 @@ func (x *GetContributionsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetContributionsRequest.ProtoReflect.Descriptor instead.
-func (*GetContributionsRequest) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{88}
+// Deprecated: Use PurgeDeadLettersResponse.ProtoReflect.Descriptor instead.
+func (*PurgeDeadLettersResponse) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{142}
 }
 
-type GetContributionsResponse struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	Histories         []*ReadingHistoryProto `protobuf:"bytes,1,rep,name=histories,proto3" json:"histories,omitempty"`
-	ContributionScore float64                `protobuf:"fixed64,2,opt,name=contribution_score,json=contributionScore,proto3" json:"contribution_score,omitempty"`
-	Badges            []*BadgeProto          `protobuf:"bytes,3,rep,name=badges,proto3" json:"badges,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+func (x *PurgeDeadLettersResponse) GetPurged() int64 {
+	if x != nil {
+		return x.Purged
+	}
+	return 0
 }
 
-func (x *GetContributionsResponse) Reset() {
-	*x = GetContributionsResponse{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[89]
+type SetDeviceClassUnitsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeviceClass   string                 `protobuf:"bytes,1,opt,name=device_class,json=deviceClass,proto3" json:"device_class,omitempty"`
+	SensorUnits   map[string]string      `protobuf:"bytes,2,rep,name=sensor_units,json=sensorUnits,proto3" json:"sensor_units,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // replaces the class's declarations
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetDeviceClassUnitsRequest) Reset() {
+	*x = SetDeviceClassUnitsRequest{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[143]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetContributionsResponse) String() string {
+func (x *SetDeviceClassUnitsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetContributionsResponse) ProtoMessage() {}
+func (*SetDeviceClassUnitsRequest) ProtoMessage() {}
 
-func (x *GetContributionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[89]
+func (x *SetDeviceClassUnitsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[143]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5179,58 +8642,47 @@ func (x *GetContributionsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetContributionsResponse.ProtoReflect.Descriptor instead.
-func (*GetContributionsResponse) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{89}
-}
-
-func (x *GetContributionsResponse) GetHistories() []*ReadingHistoryProto {
-	if x != nil {
-		return x.Histories
-	}
-	return nil
+// Deprecated: Use SetDeviceClassUnitsRequest.ProtoReflect.Descriptor instead.
+func (*SetDeviceClassUnitsRequest) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{143}
 }
 
-func (x *GetContributionsResponse) GetContributionScore() float64 {
+func (x *SetDeviceClassUnitsRequest) GetDeviceClass() string {
 	if x != nil {
-		return x.ContributionScore
+		return x.DeviceClass
 	}
-	return 0
+	return ""
 }
 
-func (x *GetContributionsResponse) GetBadges() []*BadgeProto {
+func (x *SetDeviceClassUnitsRequest) GetSensorUnits() map[string]string {
 	if x != nil {
-		return x.Badges
+		return x.SensorUnits
 	}
 	return nil
 }
 
-type LeaderboardEntryProto struct {
+type SetDeviceClassUnitsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Rank          int32                  `protobuf:"varint,1,opt,name=rank,proto3" json:"rank,omitempty"`
-	ScitizenId    string                 `protobuf:"bytes,2,opt,name=scitizen_id,json=scitizenId,proto3" json:"scitizen_id,omitempty"`
-	Score         float64                `protobuf:"fixed64,3,opt,name=score,proto3" json:"score,omitempty"`
-	BadgeCount    int32                  `protobuf:"varint,4,opt,name=badge_count,json=badgeCount,proto3" json:"badge_count,omitempty"`
-	CampaignCount int32                  `protobuf:"varint,5,opt,name=campaign_count,json=campaignCount,proto3" json:"campaign_count,omitempty"`
+	SensorUnits   map[string]string      `protobuf:"bytes,1,rep,name=sensor_units,json=sensorUnits,proto3" json:"sensor_units,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // as stored, UCUM codes
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LeaderboardEntryProto) Reset() {
-	*x = LeaderboardEntryProto{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[90]
+func (x *SetDeviceClassUnitsResponse) Reset() {
+	*x = SetDeviceClassUnitsResponse{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[144]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LeaderboardEntryProto) String() string {
+func (x *SetDeviceClassUnitsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LeaderboardEntryProto) ProtoMessage() {}
+func (*SetDeviceClassUnitsResponse) ProtoMessage() {}
 
-func (x *LeaderboardEntryProto) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[90]
+func (x *SetDeviceClassUnitsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[144]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5241,71 +8693,46 @@ func (x *LeaderboardEntryProto) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LeaderboardEntryProto.ProtoReflect.Descriptor instead.
-func (*LeaderboardEntryProto) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{90}
-}
-
-func (x *LeaderboardEntryProto) GetRank() int32 {
-	if x != nil {
-		return x.Rank
-	}
-	return 0
-}
-
-func (x *LeaderboardEntryProto) GetScitizenId() string {
-	if x != nil {
-		return x.ScitizenId
-	}
-	return ""
-}
-
-func (x *LeaderboardEntryProto) GetScore() float64 {
-	if x != nil {
-		return x.Score
-	}
-	return 0
-}
-
-func (x *LeaderboardEntryProto) GetBadgeCount() int32 {
-	if x != nil {
-		return x.BadgeCount
-	}
-	return 0
+// Deprecated: Use SetDeviceClassUnitsResponse.ProtoReflect.Descriptor instead.
+func (*SetDeviceClassUnitsResponse) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{144}
 }
 
-func (x *LeaderboardEntryProto) GetCampaignCount() int32 {
+func (x *SetDeviceClassUnitsResponse) GetSensorUnits() map[string]string {
 	if x != nil {
-		return x.CampaignCount
+		return x.SensorUnits
 	}
-	return 0
+	return nil
 }
 
-type GetLeaderboardRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	CampaignId    *string                `protobuf:"bytes,1,opt,name=campaign_id,json=campaignId,proto3,oneof" json:"campaign_id,omitempty"`
-	TimePeriod    *string                `protobuf:"bytes,2,opt,name=time_period,json=timePeriod,proto3,oneof" json:"time_period,omitempty"`
-	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset        int32                  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type RateLimitFlagProto struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId        string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	OwnerId         string                 `protobuf:"bytes,2,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	DeviceClass     string                 `protobuf:"bytes,3,opt,name=device_class,json=deviceClass,proto3" json:"device_class,omitempty"`
+	FirmwareVersion string                 `protobuf:"bytes,4,opt,name=firmware_version,json=firmwareVersion,proto3" json:"firmware_version,omitempty"`
+	Status          string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	Violations      int64                  `protobuf:"varint,6,opt,name=violations,proto3" json:"violations,omitempty"` // messages dropped or quarantined for rate, all time
+	FlaggedAt       string                 `protobuf:"bytes,7,opt,name=flagged_at,json=flaggedAt,proto3" json:"flagged_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *GetLeaderboardRequest) Reset() {
-	*x = GetLeaderboardRequest{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[91]
+func (x *RateLimitFlagProto) Reset() {
+	*x = RateLimitFlagProto{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[145]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetLeaderboardRequest) String() string {
+func (x *RateLimitFlagProto) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetLeaderboardRequest) ProtoMessage() {}
+func (*RateLimitFlagProto) ProtoMessage() {}
 
-func (x *GetLeaderboardRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[91]
+func (x *RateLimitFlagProto) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[145]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5316,63 +8743,81 @@ func (x *GetLeaderboardRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetLeaderboardRequest.ProtoReflect.Descriptor instead.
-func (*GetLeaderboardRequest) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{91}
+// Deprecated: Use RateLimitFlagProto.ProtoReflect.Descriptor instead.
+func (*RateLimitFlagProto) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{145}
 }
 
-func (x *GetLeaderboardRequest) GetCampaignId() string {
-	if x != nil && x.CampaignId != nil {
-		return *x.CampaignId
+func (x *RateLimitFlagProto) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
 	}
 	return ""
 }
 
-func (x *GetLeaderboardRequest) GetTimePeriod() string {
-	if x != nil && x.TimePeriod != nil {
-		return *x.TimePeriod
+func (x *RateLimitFlagProto) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *RateLimitFlagProto) GetDeviceClass() string {
+	if x != nil {
+		return x.DeviceClass
+	}
+	return ""
+}
+
+func (x *RateLimitFlagProto) GetFirmwareVersion() string {
+	if x != nil {
+		return x.FirmwareVersion
+	}
+	return ""
+}
+
+func (x *RateLimitFlagProto) GetStatus() string {
+	if x != nil {
+		return x.Status
 	}
 	return ""
 }
 
-func (x *GetLeaderboardRequest) GetLimit() int32 {
+func (x *RateLimitFlagProto) GetViolations() int64 {
 	if x != nil {
-		return x.Limit
+		return x.Violations
 	}
 	return 0
 }
 
-func (x *GetLeaderboardRequest) GetOffset() int32 {
+func (x *RateLimitFlagProto) GetFlaggedAt() string {
 	if x != nil {
-		return x.Offset
+		return x.FlaggedAt
 	}
-	return 0
+	return ""
 }
 
-type GetLeaderboardResponse struct {
-	state         protoimpl.MessageState   `protogen:"open.v1"`
-	Entries       []*LeaderboardEntryProto `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
-	Total         int32                    `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
-	Requester     *LeaderboardEntryProto   `protobuf:"bytes,3,opt,name=requester,proto3,oneof" json:"requester,omitempty"`
+type ListRateLimitFlaggedDevicesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetLeaderboardResponse) Reset() {
-	*x = GetLeaderboardResponse{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[92]
+func (x *ListRateLimitFlaggedDevicesRequest) Reset() {
+	*x = ListRateLimitFlaggedDevicesRequest{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[146]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetLeaderboardResponse) String() string {
+func (x *ListRateLimitFlaggedDevicesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetLeaderboardResponse) ProtoMessage() {}
+func (*ListRateLimitFlaggedDevicesRequest) ProtoMessage() {}
 
-func (x *GetLeaderboardResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[92]
+func (x *ListRateLimitFlaggedDevicesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[146]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5383,56 +8828,77 @@ func (x *GetLeaderboardResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetLeaderboardResponse.ProtoReflect.Descriptor instead.
-func (*GetLeaderboardResponse) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{92}
+// Deprecated: Use ListRateLimitFlaggedDevicesRequest.ProtoReflect.Descriptor instead.
+func (*ListRateLimitFlaggedDevicesRequest) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{146}
 }
 
-func (x *GetLeaderboardResponse) GetEntries() []*LeaderboardEntryProto {
-	if x != nil {
-		return x.Entries
-	}
-	return nil
+type ListRateLimitFlaggedDevicesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Devices       []*RateLimitFlagProto  `protobuf:"bytes,1,rep,name=devices,proto3" json:"devices,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetLeaderboardResponse) GetTotal() int32 {
+func (x *ListRateLimitFlaggedDevicesResponse) Reset() {
+	*x = ListRateLimitFlaggedDevicesResponse{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[147]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRateLimitFlaggedDevicesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRateLimitFlaggedDevicesResponse) ProtoMessage() {}
+
+func (x *ListRateLimitFlaggedDevicesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[147]
 	if x != nil {
-		return x.Total
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *GetLeaderboardResponse) GetRequester() *LeaderboardEntryProto {
+// Deprecated: Use ListRateLimitFlaggedDevicesResponse.ProtoReflect.Descriptor instead.
+func (*ListRateLimitFlaggedDevicesResponse) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{147}
+}
+
+func (x *ListRateLimitFlaggedDevicesResponse) GetDevices() []*RateLimitFlagProto {
 	if x != nil {
-		return x.Requester
+		return x.Devices
 	}
 	return nil
 }
 
-type ListNotificationsRequest struct {
+type ClearRateLimitFlagRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	TypeFilter    *string                `protobuf:"bytes,1,opt,name=type_filter,json=typeFilter,proto3,oneof" json:"type_filter,omitempty"`
-	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	DeviceId      string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListNotificationsRequest) Reset() {
-	*x = ListNotificationsRequest{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[93]
+func (x *ClearRateLimitFlagRequest) Reset() {
+	*x = ClearRateLimitFlagRequest{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[148]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListNotificationsRequest) String() string {
+func (x *ClearRateLimitFlagRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListNotificationsRequest) ProtoMessage() {}
+func (*ClearRateLimitFlagRequest) ProtoMessage() {}
 
-func (x *ListNotificationsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[93]
+func (x *ClearRateLimitFlagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[148]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5443,56 +8909,91 @@ func (x *ListNotificationsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListNotificationsRequest.ProtoReflect.Descriptor instead.
-func (*ListNotificationsRequest) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{93}
+// Deprecated: Use ClearRateLimitFlagRequest.ProtoReflect.Descriptor instead.
+func (*ClearRateLimitFlagRequest) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{148}
 }
 
-func (x *ListNotificationsRequest) GetTypeFilter() string {
-	if x != nil && x.TypeFilter != nil {
-		return *x.TypeFilter
+func (x *ClearRateLimitFlagRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
 	}
 	return ""
 }
 
-func (x *ListNotificationsRequest) GetLimit() int32 {
-	if x != nil {
-		return x.Limit
-	}
-	return 0
+type ClearRateLimitFlagResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListNotificationsRequest) GetOffset() int32 {
+func (x *ClearRateLimitFlagResponse) Reset() {
+	*x = ClearRateLimitFlagResponse{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[149]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClearRateLimitFlagResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearRateLimitFlagResponse) ProtoMessage() {}
+
+func (x *ClearRateLimitFlagResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[149]
 	if x != nil {
-		return x.Offset
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-type ListNotificationsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Notifications []*NotificationProto   `protobuf:"bytes,1,rep,name=notifications,proto3" json:"notifications,omitempty"`
-	UnreadCount   int32                  `protobuf:"varint,2,opt,name=unread_count,json=unreadCount,proto3" json:"unread_count,omitempty"`
-	Total         int32                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// Deprecated: Use ClearRateLimitFlagResponse.ProtoReflect.Descriptor instead.
+func (*ClearRateLimitFlagResponse) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{149}
 }
 
-func (x *ListNotificationsResponse) Reset() {
-	*x = ListNotificationsResponse{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[94]
+// A firmware build offered to one device class over rootstock/{id}/ota. The rollout stage
+// covers devices whose bucket is below rollout_percent, plus the cohort's device IDs.
+// Suspended devices are offered a security fix at any stage and reinstated once it is installed.
+type FirmwareReleaseProto struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DeviceClass    string                 `protobuf:"bytes,2,opt,name=device_class,json=deviceClass,proto3" json:"device_class,omitempty"`
+	Version        string                 `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	Sha256         string                 `protobuf:"bytes,4,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	Url            string                 `protobuf:"bytes,5,opt,name=url,proto3" json:"url,omitempty"`
+	SizeBytes      *int64                 `protobuf:"varint,6,opt,name=size_bytes,json=sizeBytes,proto3,oneof" json:"size_bytes,omitempty"`
+	Notes          string                 `protobuf:"bytes,7,opt,name=notes,proto3" json:"notes,omitempty"`
+	SecurityFix    bool                   `protobuf:"varint,8,opt,name=security_fix,json=securityFix,proto3" json:"security_fix,omitempty"`
+	RolloutPercent int32                  `protobuf:"varint,9,opt,name=rollout_percent,json=rolloutPercent,proto3" json:"rollout_percent,omitempty"`
+	Cohort         []string               `protobuf:"bytes,10,rep,name=cohort,proto3" json:"cohort,omitempty"`
+	CreatedBy      string                 `protobuf:"bytes,11,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedAt      string                 `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      string                 `protobuf:"bytes,13,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *FirmwareReleaseProto) Reset() {
+	*x = FirmwareReleaseProto{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[150]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListNotificationsResponse) String() string {
+func (x *FirmwareReleaseProto) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListNotificationsResponse) ProtoMessage() {}
+func (*FirmwareReleaseProto) ProtoMessage() {}
 
-func (x *ListNotificationsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[94]
+func (x *FirmwareReleaseProto) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[150]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5503,98 +9004,130 @@ func (x *ListNotificationsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListNotificationsResponse.ProtoReflect.Descriptor instead.
-func (*ListNotificationsResponse) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{94}
+// Deprecated: Use FirmwareReleaseProto.ProtoReflect.Descriptor instead.
+func (*FirmwareReleaseProto) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{150}
 }
 
-func (x *ListNotificationsResponse) GetNotifications() []*NotificationProto {
+func (x *FirmwareReleaseProto) GetId() string {
 	if x != nil {
-		return x.Notifications
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-func (x *ListNotificationsResponse) GetUnreadCount() int32 {
+func (x *FirmwareReleaseProto) GetDeviceClass() string {
 	if x != nil {
-		return x.UnreadCount
+		return x.DeviceClass
 	}
-	return 0
+	return ""
 }
 
-func (x *ListNotificationsResponse) GetTotal() int32 {
+func (x *FirmwareReleaseProto) GetVersion() string {
 	if x != nil {
-		return x.Total
+		return x.Version
 	}
-	return 0
+	return ""
 }
 
-type MarkReadRequest struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	NotificationIds []string               `protobuf:"bytes,1,rep,name=notification_ids,json=notificationIds,proto3" json:"notification_ids,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+func (x *FirmwareReleaseProto) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
 }
 
-func (x *MarkReadRequest) Reset() {
-	*x = MarkReadRequest{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[95]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *FirmwareReleaseProto) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
 }
 
-func (x *MarkReadRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *FirmwareReleaseProto) GetSizeBytes() int64 {
+	if x != nil && x.SizeBytes != nil {
+		return *x.SizeBytes
+	}
+	return 0
 }
 
-func (*MarkReadRequest) ProtoMessage() {}
+func (x *FirmwareReleaseProto) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
 
-func (x *MarkReadRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[95]
+func (x *FirmwareReleaseProto) GetSecurityFix() bool {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.SecurityFix
 	}
-	return mi.MessageOf(x)
+	return false
 }
 
-// Deprecated: Use MarkReadRequest.ProtoReflect.Descriptor instead.
-func (*MarkReadRequest) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{95}
+func (x *FirmwareReleaseProto) GetRolloutPercent() int32 {
+	if x != nil {
+		return x.RolloutPercent
+	}
+	return 0
 }
 
-func (x *MarkReadRequest) GetNotificationIds() []string {
+func (x *FirmwareReleaseProto) GetCohort() []string {
 	if x != nil {
-		return x.NotificationIds
+		return x.Cohort
 	}
 	return nil
 }
 
-type MarkReadResponse struct {
+func (x *FirmwareReleaseProto) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *FirmwareReleaseProto) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *FirmwareReleaseProto) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+// status is notified, downloading, installing, installed or failed.
+type FirmwareInstallProto struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	MarkedCount   int32                  `protobuf:"varint,1,opt,name=marked_count,json=markedCount,proto3" json:"marked_count,omitempty"`
+	ReleaseId     string                 `protobuf:"bytes,1,opt,name=release_id,json=releaseId,proto3" json:"release_id,omitempty"`
+	DeviceId      string                 `protobuf:"bytes,2,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Error         *string                `protobuf:"bytes,4,opt,name=error,proto3,oneof" json:"error,omitempty"`
+	NotifiedAt    string                 `protobuf:"bytes,5,opt,name=notified_at,json=notifiedAt,proto3" json:"notified_at,omitempty"`
+	UpdatedAt     string                 `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MarkReadResponse) Reset() {
-	*x = MarkReadResponse{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[96]
+func (x *FirmwareInstallProto) Reset() {
+	*x = FirmwareInstallProto{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[151]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MarkReadResponse) String() string {
+func (x *FirmwareInstallProto) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MarkReadResponse) ProtoMessage() {}
+func (*FirmwareInstallProto) ProtoMessage() {}
 
-func (x *MarkReadResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[96]
+func (x *FirmwareInstallProto) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[151]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5605,42 +9138,83 @@ func (x *MarkReadResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MarkReadResponse.ProtoReflect.Descriptor instead.
-func (*MarkReadResponse) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{96}
+// Deprecated: Use FirmwareInstallProto.ProtoReflect.Descriptor instead.
+func (*FirmwareInstallProto) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{151}
 }
 
-func (x *MarkReadResponse) GetMarkedCount() int32 {
+func (x *FirmwareInstallProto) GetReleaseId() string {
 	if x != nil {
-		return x.MarkedCount
+		return x.ReleaseId
 	}
-	return 0
+	return ""
 }
 
-type NotificationPreferenceProto struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
-	InApp         bool                   `protobuf:"varint,2,opt,name=in_app,json=inApp,proto3" json:"in_app,omitempty"`
-	Email         bool                   `protobuf:"varint,3,opt,name=email,proto3" json:"email,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *FirmwareInstallProto) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
 }
 
-func (x *NotificationPreferenceProto) Reset() {
-	*x = NotificationPreferenceProto{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[97]
+func (x *FirmwareInstallProto) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *FirmwareInstallProto) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+func (x *FirmwareInstallProto) GetNotifiedAt() string {
+	if x != nil {
+		return x.NotifiedAt
+	}
+	return ""
+}
+
+func (x *FirmwareInstallProto) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+type CreateFirmwareReleaseRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	DeviceClass    string                 `protobuf:"bytes,1,opt,name=device_class,json=deviceClass,proto3" json:"device_class,omitempty"`
+	Version        string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	Sha256         string                 `protobuf:"bytes,3,opt,name=sha256,proto3" json:"sha256,omitempty"` // hex digest of the image
+	Url            string                 `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`       // https
+	SizeBytes      *int64                 `protobuf:"varint,5,opt,name=size_bytes,json=sizeBytes,proto3,oneof" json:"size_bytes,omitempty"`
+	Notes          string                 `protobuf:"bytes,6,opt,name=notes,proto3" json:"notes,omitempty"`
+	SecurityFix    bool                   `protobuf:"varint,7,opt,name=security_fix,json=securityFix,proto3" json:"security_fix,omitempty"`
+	RolloutPercent int32                  `protobuf:"varint,8,opt,name=rollout_percent,json=rolloutPercent,proto3" json:"rollout_percent,omitempty"`
+	Cohort         []string               `protobuf:"bytes,9,rep,name=cohort,proto3" json:"cohort,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateFirmwareReleaseRequest) Reset() {
+	*x = CreateFirmwareReleaseRequest{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[152]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *NotificationPreferenceProto) String() string {
+func (x *CreateFirmwareReleaseRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*NotificationPreferenceProto) ProtoMessage() {}
+func (*CreateFirmwareReleaseRequest) ProtoMessage() {}
 
-func (x *NotificationPreferenceProto) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[97]
+func (x *CreateFirmwareReleaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[152]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5651,53 +9225,97 @@ func (x *NotificationPreferenceProto) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use NotificationPreferenceProto.ProtoReflect.Descriptor instead.
-func (*NotificationPreferenceProto) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{97}
+// Deprecated: Use CreateFirmwareReleaseRequest.ProtoReflect.Descriptor instead.
+func (*CreateFirmwareReleaseRequest) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{152}
 }
 
-func (x *NotificationPreferenceProto) GetType() string {
+func (x *CreateFirmwareReleaseRequest) GetDeviceClass() string {
 	if x != nil {
-		return x.Type
+		return x.DeviceClass
 	}
 	return ""
 }
 
-func (x *NotificationPreferenceProto) GetInApp() bool {
+func (x *CreateFirmwareReleaseRequest) GetVersion() string {
 	if x != nil {
-		return x.InApp
+		return x.Version
 	}
-	return false
+	return ""
 }
 
-func (x *NotificationPreferenceProto) GetEmail() bool {
+func (x *CreateFirmwareReleaseRequest) GetSha256() string {
 	if x != nil {
-		return x.Email
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *CreateFirmwareReleaseRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *CreateFirmwareReleaseRequest) GetSizeBytes() int64 {
+	if x != nil && x.SizeBytes != nil {
+		return *x.SizeBytes
+	}
+	return 0
+}
+
+func (x *CreateFirmwareReleaseRequest) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+func (x *CreateFirmwareReleaseRequest) GetSecurityFix() bool {
+	if x != nil {
+		return x.SecurityFix
 	}
 	return false
 }
 
-type GetPreferencesRequest struct {
+func (x *CreateFirmwareReleaseRequest) GetRolloutPercent() int32 {
+	if x != nil {
+		return x.RolloutPercent
+	}
+	return 0
+}
+
+func (x *CreateFirmwareReleaseRequest) GetCohort() []string {
+	if x != nil {
+		return x.Cohort
+	}
+	return nil
+}
+
+type CreateFirmwareReleaseResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Release       *FirmwareReleaseProto  `protobuf:"bytes,1,opt,name=release,proto3" json:"release,omitempty"`
+	Published     int32                  `protobuf:"varint,2,opt,name=published,proto3" json:"published,omitempty"` // devices sent the manifest
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetPreferencesRequest) Reset() {
-	*x = GetPreferencesRequest{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[98]
+func (x *CreateFirmwareReleaseResponse) Reset() {
+	*x = CreateFirmwareReleaseResponse{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[153]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetPreferencesRequest) String() string {
+func (x *CreateFirmwareReleaseResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetPreferencesRequest) ProtoMessage() {}
+func (*CreateFirmwareReleaseResponse) ProtoMessage() {}
 
-func (x *GetPreferencesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[98]
+func (x *CreateFirmwareReleaseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[153]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5708,33 +9326,47 @@ func (x *GetPreferencesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetPreferencesRequest.ProtoReflect.Descriptor instead.
-func (*GetPreferencesRequest) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{98}
+// Deprecated: Use CreateFirmwareReleaseResponse.ProtoReflect.Descriptor instead.
+func (*CreateFirmwareReleaseResponse) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{153}
 }
 
-type GetPreferencesResponse struct {
-	state         protoimpl.MessageState         `protogen:"open.v1"`
-	Preferences   []*NotificationPreferenceProto `protobuf:"bytes,1,rep,name=preferences,proto3" json:"preferences,omitempty"`
+func (x *CreateFirmwareReleaseResponse) GetRelease() *FirmwareReleaseProto {
+	if x != nil {
+		return x.Release
+	}
+	return nil
+}
+
+func (x *CreateFirmwareReleaseResponse) GetPublished() int32 {
+	if x != nil {
+		return x.Published
+	}
+	return 0
+}
+
+type ListFirmwareReleasesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeviceClass   string                 `protobuf:"bytes,1,opt,name=device_class,json=deviceClass,proto3" json:"device_class,omitempty"` // empty lists every class
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetPreferencesResponse) Reset() {
-	*x = GetPreferencesResponse{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[99]
+func (x *ListFirmwareReleasesRequest) Reset() {
+	*x = ListFirmwareReleasesRequest{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[154]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetPreferencesResponse) String() string {
+func (x *ListFirmwareReleasesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetPreferencesResponse) ProtoMessage() {}
+func (*ListFirmwareReleasesRequest) ProtoMessage() {}
 
-func (x *GetPreferencesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[99]
+func (x *ListFirmwareReleasesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[154]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5745,40 +9377,40 @@ func (x *GetPreferencesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetPreferencesResponse.ProtoReflect.Descriptor instead.
-func (*GetPreferencesResponse) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{99}
+// Deprecated: Use ListFirmwareReleasesRequest.ProtoReflect.Descriptor instead.
+func (*ListFirmwareReleasesRequest) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{154}
 }
 
-func (x *GetPreferencesResponse) GetPreferences() []*NotificationPreferenceProto {
+func (x *ListFirmwareReleasesRequest) GetDeviceClass() string {
 	if x != nil {
-		return x.Preferences
+		return x.DeviceClass
 	}
-	return nil
+	return ""
 }
 
-type UpdatePreferencesRequest struct {
-	state         protoimpl.MessageState         `protogen:"open.v1"`
-	Preferences   []*NotificationPreferenceProto `protobuf:"bytes,1,rep,name=preferences,proto3" json:"preferences,omitempty"`
+type ListFirmwareReleasesResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Releases      []*FirmwareReleaseProto `protobuf:"bytes,1,rep,name=releases,proto3" json:"releases,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdatePreferencesRequest) Reset() {
-	*x = UpdatePreferencesRequest{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[100]
+func (x *ListFirmwareReleasesResponse) Reset() {
+	*x = ListFirmwareReleasesResponse{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[155]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdatePreferencesRequest) String() string {
+func (x *ListFirmwareReleasesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdatePreferencesRequest) ProtoMessage() {}
+func (*ListFirmwareReleasesResponse) ProtoMessage() {}
 
-func (x *UpdatePreferencesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[100]
+func (x *ListFirmwareReleasesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[155]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5789,39 +9421,42 @@ func (x *UpdatePreferencesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdatePreferencesRequest.ProtoReflect.Descriptor instead.
-func (*UpdatePreferencesRequest) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{100}
+// Deprecated: Use ListFirmwareReleasesResponse.ProtoReflect.Descriptor instead.
+func (*ListFirmwareReleasesResponse) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{155}
 }
 
-func (x *UpdatePreferencesRequest) GetPreferences() []*NotificationPreferenceProto {
+func (x *ListFirmwareReleasesResponse) GetReleases() []*FirmwareReleaseProto {
 	if x != nil {
-		return x.Preferences
+		return x.Releases
 	}
 	return nil
 }
 
-type UpdatePreferencesResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type SetFirmwareRolloutRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ReleaseId      string                 `protobuf:"bytes,1,opt,name=release_id,json=releaseId,proto3" json:"release_id,omitempty"`
+	RolloutPercent int32                  `protobuf:"varint,2,opt,name=rollout_percent,json=rolloutPercent,proto3" json:"rollout_percent,omitempty"`
+	Cohort         []string               `protobuf:"bytes,3,rep,name=cohort,proto3" json:"cohort,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *UpdatePreferencesResponse) Reset() {
-	*x = UpdatePreferencesResponse{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[101]
+func (x *SetFirmwareRolloutRequest) Reset() {
+	*x = SetFirmwareRolloutRequest{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[156]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdatePreferencesResponse) String() string {
+func (x *SetFirmwareRolloutRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdatePreferencesResponse) ProtoMessage() {}
+func (*SetFirmwareRolloutRequest) ProtoMessage() {}
 
-func (x *UpdatePreferencesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[101]
+func (x *SetFirmwareRolloutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[156]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5832,38 +9467,55 @@ func (x *UpdatePreferencesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdatePreferencesResponse.ProtoReflect.Descriptor instead.
-func (*UpdatePreferencesResponse) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{101}
+// Deprecated: Use SetFirmwareRolloutRequest.ProtoReflect.Descriptor instead.
+func (*SetFirmwareRolloutRequest) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{156}
 }
 
-type SuspendByClassRequest struct {
+func (x *SetFirmwareRolloutRequest) GetReleaseId() string {
+	if x != nil {
+		return x.ReleaseId
+	}
+	return ""
+}
+
+func (x *SetFirmwareRolloutRequest) GetRolloutPercent() int32 {
+	if x != nil {
+		return x.RolloutPercent
+	}
+	return 0
+}
+
+func (x *SetFirmwareRolloutRequest) GetCohort() []string {
+	if x != nil {
+		return x.Cohort
+	}
+	return nil
+}
+
+type SetFirmwareRolloutResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	DeviceClass   string                 `protobuf:"bytes,1,opt,name=device_class,json=deviceClass,proto3" json:"device_class,omitempty"`
-	FirmwareMin   string                 `protobuf:"bytes,2,opt,name=firmware_min,json=firmwareMin,proto3" json:"firmware_min,omitempty"`
-	FirmwareMax   string                 `protobuf:"bytes,3,opt,name=firmware_max,json=firmwareMax,proto3" json:"firmware_max,omitempty"`
-	WindowStart   string                 `protobuf:"bytes,4,opt,name=window_start,json=windowStart,proto3" json:"window_start,omitempty"`
-	WindowEnd     string                 `protobuf:"bytes,5,opt,name=window_end,json=windowEnd,proto3" json:"window_end,omitempty"`
-	Reason        string                 `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	Release       *FirmwareReleaseProto  `protobuf:"bytes,1,opt,name=release,proto3" json:"release,omitempty"`
+	Published     int32                  `protobuf:"varint,2,opt,name=published,proto3" json:"published,omitempty"` // devices newly sent the manifest
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SuspendByClassRequest) Reset() {
-	*x = SuspendByClassRequest{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[102]
+func (x *SetFirmwareRolloutResponse) Reset() {
+	*x = SetFirmwareRolloutResponse{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[157]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SuspendByClassRequest) String() string {
+func (x *SetFirmwareRolloutResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SuspendByClassRequest) ProtoMessage() {}
+func (*SetFirmwareRolloutResponse) ProtoMessage() {}
 
-func (x *SuspendByClassRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[102]
+func (x *SetFirmwareRolloutResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[157]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5874,77 +9526,91 @@ func (x *SuspendByClassRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SuspendByClassRequest.ProtoReflect.Descriptor instead.
-func (*SuspendByClassRequest) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{102}
+// Deprecated: Use SetFirmwareRolloutResponse.ProtoReflect.Descriptor instead.
+func (*SetFirmwareRolloutResponse) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{157}
 }
 
-func (x *SuspendByClassRequest) GetDeviceClass() string {
+func (x *SetFirmwareRolloutResponse) GetRelease() *FirmwareReleaseProto {
 	if x != nil {
-		return x.DeviceClass
+		return x.Release
 	}
-	return ""
+	return nil
 }
 
-func (x *SuspendByClassRequest) GetFirmwareMin() string {
+func (x *SetFirmwareRolloutResponse) GetPublished() int32 {
 	if x != nil {
-		return x.FirmwareMin
+		return x.Published
 	}
-	return ""
+	return 0
 }
 
-func (x *SuspendByClassRequest) GetFirmwareMax() string {
-	if x != nil {
-		return x.FirmwareMax
-	}
-	return ""
+type ListFirmwareInstallsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReleaseId     string                 `protobuf:"bytes,1,opt,name=release_id,json=releaseId,proto3" json:"release_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SuspendByClassRequest) GetWindowStart() string {
-	if x != nil {
-		return x.WindowStart
-	}
-	return ""
+func (x *ListFirmwareInstallsRequest) Reset() {
+	*x = ListFirmwareInstallsRequest{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[158]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *SuspendByClassRequest) GetWindowEnd() string {
+func (x *ListFirmwareInstallsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFirmwareInstallsRequest) ProtoMessage() {}
+
+func (x *ListFirmwareInstallsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[158]
 	if x != nil {
-		return x.WindowEnd
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *SuspendByClassRequest) GetReason() string {
+// Deprecated: Use ListFirmwareInstallsRequest.ProtoReflect.Descriptor instead.
+func (*ListFirmwareInstallsRequest) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{158}
+}
+
+func (x *ListFirmwareInstallsRequest) GetReleaseId() string {
 	if x != nil {
-		return x.Reason
+		return x.ReleaseId
 	}
 	return ""
 }
 
-type SuspendByClassResponse struct {
-	state               protoimpl.MessageState `protogen:"open.v1"`
-	SuspendedCount      int32                  `protobuf:"varint,1,opt,name=suspended_count,json=suspendedCount,proto3" json:"suspended_count,omitempty"`
-	QuarantinedReadings int64                  `protobuf:"varint,2,opt,name=quarantined_readings,json=quarantinedReadings,proto3" json:"quarantined_readings,omitempty"`
-	NotifiedScitizens   int32                  `protobuf:"varint,3,opt,name=notified_scitizens,json=notifiedScitizens,proto3" json:"notified_scitizens,omitempty"`
-	unknownFields       protoimpl.UnknownFields
-	sizeCache           protoimpl.SizeCache
+type ListFirmwareInstallsResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Installs      []*FirmwareInstallProto `protobuf:"bytes,1,rep,name=installs,proto3" json:"installs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SuspendByClassResponse) Reset() {
-	*x = SuspendByClassResponse{}
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[103]
+func (x *ListFirmwareInstallsResponse) Reset() {
+	*x = ListFirmwareInstallsResponse{}
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[159]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SuspendByClassResponse) String() string {
+func (x *ListFirmwareInstallsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SuspendByClassResponse) ProtoMessage() {}
+func (*ListFirmwareInstallsResponse) ProtoMessage() {}
 
-func (x *SuspendByClassResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rootstock_v1_rootstock_proto_msgTypes[103]
+func (x *ListFirmwareInstallsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rootstock_v1_rootstock_proto_msgTypes[159]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5955,30 +9621,16 @@ func (x *SuspendByClassResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SuspendByClassResponse.ProtoReflect.Descriptor instead.
-func (*SuspendByClassResponse) Descriptor() ([]byte, []int) {
-	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{103}
-}
-
-func (x *SuspendByClassResponse) GetSuspendedCount() int32 {
-	if x != nil {
-		return x.SuspendedCount
-	}
-	return 0
-}
-
-func (x *SuspendByClassResponse) GetQuarantinedReadings() int64 {
-	if x != nil {
-		return x.QuarantinedReadings
-	}
-	return 0
+// Deprecated: Use ListFirmwareInstallsResponse.ProtoReflect.Descriptor instead.
+func (*ListFirmwareInstallsResponse) Descriptor() ([]byte, []int) {
+	return file_rootstock_v1_rootstock_proto_rawDescGZIP(), []int{159}
 }
 
-func (x *SuspendByClassResponse) GetNotifiedScitizens() int32 {
+func (x *ListFirmwareInstallsResponse) GetInstalls() []*FirmwareInstallProto {
 	if x != nil {
-		return x.NotifiedScitizens
+		return x.Installs
 	}
-	return 0
+	return nil
 }
 
 var File_rootstock_v1_rootstock_proto protoreflect.FileDescriptor
@@ -5988,13 +9640,14 @@ const file_rootstock_v1_rootstock_proto_rawDesc = "" +
 	"\x1crootstock/v1/rootstock.proto\x12\frootstock.v1\"\x0e\n" +
 	"\fCheckRequest\"'\n" +
 	"\rCheckResponse\x12\x16\n" +
-	"\x06status\x18\x01 \x01(\tR\x06status\"\xc9\x01\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\"\xe5\x01\n" +
 	"\x0eParameterProto\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
 	"\x04unit\x18\x02 \x01(\tR\x04unit\x12 \n" +
 	"\tmin_range\x18\x03 \x01(\x01H\x00R\bminRange\x88\x01\x01\x12 \n" +
 	"\tmax_range\x18\x04 \x01(\x01H\x01R\bmaxRange\x88\x01\x01\x12!\n" +
-	"\tprecision\x18\x05 \x01(\x05H\x02R\tprecision\x88\x01\x01B\f\n" +
+	"\tprecision\x18\x05 \x01(\x05H\x02R\tprecision\x88\x01\x01\x12\x1a\n" +
+	"\brequired\x18\x06 \x01(\bR\brequiredB\f\n" +
 	"\n" +
 	"_min_rangeB\f\n" +
 	"\n" +
@@ -6020,7 +9673,7 @@ const file_rootstock_v1_rootstock_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\a \x01(\tR\tcreatedAtB\x0f\n" +
 	"\r_window_startB\r\n" +
-	"\v_window_end\"\xee\x02\n" +
+	"\v_window_end\"\xc3\x04\n" +
 	"\x15CreateCampaignRequest\x12\x15\n" +
 	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x1d\n" +
 	"\n" +
@@ -6032,9 +9685,14 @@ const file_rootstock_v1_rootstock_proto_rawDesc = "" +
 	"parameters\x18\x05 \x03(\v2\x1c.rootstock.v1.ParameterProtoR\n" +
 	"parameters\x123\n" +
 	"\aregions\x18\x06 \x03(\v2\x19.rootstock.v1.RegionProtoR\aregions\x12@\n" +
-	"\veligibility\x18\a \x03(\v2\x1e.rootstock.v1.EligibilityProtoR\veligibilityB\x0f\n" +
+	"\veligibility\x18\a \x03(\v2\x1e.rootstock.v1.EligibilityProtoR\veligibility\x12)\n" +
+	"\x10precision_policy\x18\b \x01(\tR\x0fprecisionPolicy\x12>\n" +
+	"\x1bundeclared_parameter_policy\x18\t \x01(\tR\x19undeclaredParameterPolicy\x12F\n" +
+	"\x1dmin_sampling_interval_seconds\x18\n" +
+	" \x01(\x05H\x02R\x1aminSamplingIntervalSeconds\x88\x01\x01B\x0f\n" +
 	"\r_window_startB\r\n" +
-	"\v_window_end\"Q\n" +
+	"\v_window_endB \n" +
+	"\x1e_min_sampling_interval_seconds\"Q\n" +
 	"\x16CreateCampaignResponse\x127\n" +
 	"\bcampaign\x18\x01 \x01(\v2\x1b.rootstock.v1.CampaignProtoR\bcampaign\"9\n" +
 	"\x16PublishCampaignRequest\x12\x1f\n" +
@@ -6060,13 +9718,29 @@ const file_rootstock_v1_rootstock_proto_rawDesc = "" +
 	"\x15ParameterQualityProto\x12%\n" +
 	"\x0eparameter_name\x18\x01 \x01(\tR\rparameterName\x12%\n" +
 	"\x0eaccepted_count\x18\x02 \x01(\x05R\racceptedCount\x12+\n" +
-	"\x11quarantined_count\x18\x03 \x01(\x05R\x10quarantinedCount\"\xce\x01\n" +
+	"\x11quarantined_count\x18\x03 \x01(\x05R\x10quarantinedCount\"\xba\x05\n" +
 	"\x14DeviceBreakdownProto\x12(\n" +
 	"\x10pseudo_device_id\x18\x01 \x01(\tR\x0epseudoDeviceId\x12!\n" +
 	"\fdevice_class\x18\x02 \x01(\tR\vdeviceClass\x12'\n" +
 	"\x0facceptance_rate\x18\x03 \x01(\x01R\x0eacceptanceRate\x12#\n" +
 	"\rreading_count\x18\x04 \x01(\x05R\freadingCount\x12\x1b\n" +
-	"\tlast_seen\x18\x05 \x01(\tR\blastSeen\"o\n" +
+	"\tlast_seen\x18\x05 \x01(\tR\blastSeen\x12\x16\n" +
+	"\x06online\x18\x06 \x01(\bR\x06online\x12/\n" +
+	"\x11last_connected_at\x18\a \x01(\tH\x00R\x0flastConnectedAt\x88\x01\x01\x12,\n" +
+	"\x0fbattery_percent\x18\b \x01(\x01H\x01R\x0ebatteryPercent\x88\x01\x01\x12\x1e\n" +
+	"\brssi_dbm\x18\t \x01(\x05H\x02R\arssiDbm\x88\x01\x01\x12/\n" +
+	"\x11last_heartbeat_at\x18\n" +
+	" \x01(\tH\x03R\x0flastHeartbeatAt\x88\x01\x01\x12#\n" +
+	"\rhealth_alerts\x18\v \x03(\tR\fhealthAlerts\x12*\n" +
+	"\x0econfig_version\x18\f \x01(\x03H\x04R\rconfigVersion\x88\x01\x01\x125\n" +
+	"\x14config_acked_version\x18\r \x01(\x03H\x05R\x12configAckedVersion\x88\x01\x01\x12!\n" +
+	"\fconfig_stale\x18\x0e \x01(\bR\vconfigStaleB\x14\n" +
+	"\x12_last_connected_atB\x12\n" +
+	"\x10_battery_percentB\v\n" +
+	"\t_rssi_dbmB\x14\n" +
+	"\x12_last_heartbeat_atB\x11\n" +
+	"\x0f_config_versionB\x17\n" +
+	"\x15_config_acked_version\"o\n" +
 	"\x15EnrollmentFunnelProto\x12\x1a\n" +
 	"\benrolled\x18\x01 \x01(\x05R\benrolled\x12\x16\n" +
 	"\x06active\x18\x02 \x01(\x05R\x06active\x12\"\n" +
@@ -6082,7 +9756,7 @@ const file_rootstock_v1_rootstock_proto_rawDesc = "" +
 	"\x11parameter_quality\x18\x04 \x03(\v2#.rootstock.v1.ParameterQualityProtoR\x10parameterQuality\x12M\n" +
 	"\x10device_breakdown\x18\x05 \x03(\v2\".rootstock.v1.DeviceBreakdownProtoR\x0fdeviceBreakdown\x12P\n" +
 	"\x11enrollment_funnel\x18\x06 \x01(\v2#.rootstock.v1.EnrollmentFunnelProtoR\x10enrollmentFunnel\x12N\n" +
-	"\x11temporal_coverage\x18\a \x03(\v2!.rootstock.v1.TemporalBucketProtoR\x10temporalCoverage\"\x9d\x03\n" +
+	"\x11temporal_coverage\x18\a \x03(\v2!.rootstock.v1.TemporalBucketProtoR\x10temporalCoverage\"\xd8\x04\n" +
 	"\x14ExportedReadingProto\x12(\n" +
 	"\x10pseudo_device_id\x18\x01 \x01(\tR\x0epseudoDeviceId\x12\x1f\n" +
 	"\vcampaign_id\x18\x02 \x01(\tR\n" +
@@ -6093,11 +9767,19 @@ const file_rootstock_v1_rootstock_proto_rawDesc = "" +
 	"\x10firmware_version\x18\x06 \x01(\tR\x0ffirmwareVersion\x12\x1f\n" +
 	"\vingested_at\x18\a \x01(\tR\n" +
 	"ingestedAt\x12\x16\n" +
-	"\x06status\x18\b \x01(\tR\x06status\x1a9\n" +
+	"\x06status\x18\b \x01(\tR\x06status\x12U\n" +
+	"\vconversions\x18\t \x03(\v23.rootstock.v1.ExportedReadingProto.ConversionsEntryR\vconversions\x1a9\n" +
 	"\vValuesEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01B\x0e\n" +
-	"\f_geolocation\"j\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\x1ab\n" +
+	"\x10ConversionsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x128\n" +
+	"\x05value\x18\x02 \x01(\v2\".rootstock.v1.ValueConversionProtoR\x05value:\x028\x01B\x0e\n" +
+	"\f_geolocation\"v\n" +
+	"\x14ValueConversionProto\x12%\n" +
+	"\x0eoriginal_value\x18\x01 \x01(\x01R\roriginalValue\x12#\n" +
+	"\roriginal_unit\x18\x02 \x01(\tR\foriginalUnit\x12\x12\n" +
+	"\x04unit\x18\x03 \x01(\tR\x04unit\"j\n" +
 	"\x19ExportCampaignDataRequest\x12\x1f\n" +
 	"\vcampaign_id\x18\x01 \x01(\tR\n" +
 	"campaignId\x12\x14\n" +
@@ -6168,7 +9850,7 @@ const file_rootstock_v1_rootstock_proto_rawDesc = "" +
 	"\x05total\x18\x06 \x01(\x01R\x05total\x12\x1d\n" +
 	"\n" +
 	"updated_at\x18\a \x01(\tR\tupdatedAt\x120\n" +
-	"\x06badges\x18\b \x03(\v2\x18.rootstock.v1.BadgeProtoR\x06badges\"\x94\x02\n" +
+	"\x06badges\x18\b \x03(\v2\x18.rootstock.v1.BadgeProtoR\x06badges\"\xe1\x02\n" +
 	"\vDeviceProto\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
 	"\bowner_id\x18\x02 \x01(\tR\aownerId\x12\x16\n" +
@@ -6180,8 +9862,13 @@ const file_rootstock_v1_rootstock_proto_rawDesc = "" +
 	"\vcert_serial\x18\b \x01(\tH\x00R\n" +
 	"certSerial\x88\x01\x01\x12\x1d\n" +
 	"\n" +
-	"created_at\x18\t \x01(\tR\tcreatedAtB\x0e\n" +
-	"\f_cert_serial\"/\n" +
+	"created_at\x18\t \x01(\tR\tcreatedAt\x12\x18\n" +
+	"\agateway\x18\n" +
+	" \x01(\bR\agateway\x12\"\n" +
+	"\n" +
+	"gateway_id\x18\v \x01(\tH\x01R\tgatewayId\x88\x01\x01B\x0e\n" +
+	"\f_cert_serialB\r\n" +
+	"\v_gateway_id\"/\n" +
 	"\x10GetDeviceRequest\x12\x1b\n" +
 	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\"F\n" +
 	"\x11GetDeviceResponse\x121\n" +
@@ -6198,7 +9885,80 @@ const file_rootstock_v1_rootstock_proto_rawDesc = "" +
 	"campaignId\"N\n" +
 	"\x18EnrollInCampaignResponse\x12\x1a\n" +
 	"\benrolled\x18\x01 \x01(\bR\benrolled\x12\x16\n" +
-	"\x06reason\x18\x02 \x01(\tR\x06reason\"o\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"\xd8\x03\n" +
+	"\x12DeviceCommandProto\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tdevice_id\x18\x02 \x01(\tR\bdeviceId\x12$\n" +
+	"\vcampaign_id\x18\x03 \x01(\tH\x00R\n" +
+	"campaignId\x88\x01\x01\x12\x19\n" +
+	"\bbatch_id\x18\x04 \x01(\tR\abatchId\x12\x18\n" +
+	"\acommand\x18\x05 \x01(\tR\acommand\x12\x16\n" +
+	"\x06params\x18\x06 \x01(\fR\x06params\x12\x1b\n" +
+	"\tissued_by\x18\a \x01(\tR\bissuedBy\x12\x1b\n" +
+	"\tissued_at\x18\b \x01(\tR\bissuedAt\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\t \x01(\tR\texpiresAt\x12\x16\n" +
+	"\x06status\x18\n" +
+	" \x01(\tR\x06status\x12\x1e\n" +
+	"\backed_at\x18\v \x01(\tH\x01R\aackedAt\x88\x01\x01\x12&\n" +
+	"\fcompleted_at\x18\f \x01(\tH\x02R\vcompletedAt\x88\x01\x01\x12\x16\n" +
+	"\x06result\x18\r \x01(\fR\x06result\x12\x19\n" +
+	"\x05error\x18\x0e \x01(\tH\x03R\x05error\x88\x01\x01B\x0e\n" +
+	"\f_campaign_idB\v\n" +
+	"\t_acked_atB\x0f\n" +
+	"\r_completed_atB\b\n" +
+	"\x06_error\"\xf2\x01\n" +
+	"\x18SendDeviceCommandRequest\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\x12\x1f\n" +
+	"\vcampaign_id\x18\x02 \x01(\tR\n" +
+	"campaignId\x12\x18\n" +
+	"\acommand\x18\x03 \x01(\tR\acommand\x12.\n" +
+	"\x10interval_seconds\x18\x04 \x01(\x05H\x00R\x0fintervalSeconds\x88\x01\x01\x12\x18\n" +
+	"\asensors\x18\x05 \x03(\tR\asensors\x12\x1f\n" +
+	"\vttl_seconds\x18\x06 \x01(\x05R\n" +
+	"ttlSecondsB\x13\n" +
+	"\x11_interval_seconds\"\x92\x01\n" +
+	"\x19SendDeviceCommandResponse\x12\x19\n" +
+	"\bbatch_id\x18\x01 \x01(\tR\abatchId\x12<\n" +
+	"\bcommands\x18\x02 \x03(\v2 .rootstock.v1.DeviceCommandProtoR\bcommands\x12\x1c\n" +
+	"\tpublished\x18\x03 \x01(\x05R\tpublished\"o\n" +
+	"\x19ListDeviceCommandsRequest\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\x12\x1f\n" +
+	"\vcampaign_id\x18\x02 \x01(\tR\n" +
+	"campaignId\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"Z\n" +
+	"\x1aListDeviceCommandsResponse\x12<\n" +
+	"\bcommands\x18\x01 \x03(\v2 .rootstock.v1.DeviceCommandProtoR\bcommands\")\n" +
+	"\x0fStringListProto\x12\x16\n" +
+	"\x06values\x18\x01 \x03(\tR\x06values\"\xc8\x02\n" +
+	"\x10ShadowStateProto\x12?\n" +
+	"\x19sampling_interval_seconds\x18\x01 \x01(\x05H\x00R\x17samplingIntervalSeconds\x88\x01\x01\x12F\n" +
+	"\x0fenabled_sensors\x18\x02 \x01(\v2\x1d.rootstock.v1.StringListProtoR\x0eenabledSensors\x12H\n" +
+	"\x10active_campaigns\x18\x03 \x01(\v2\x1d.rootstock.v1.StringListProtoR\x0factiveCampaigns\x12.\n" +
+	"\x10firmware_version\x18\x04 \x01(\tH\x01R\x0ffirmwareVersion\x88\x01\x01B\x1c\n" +
+	"\x1a_sampling_interval_secondsB\x13\n" +
+	"\x11_firmware_version\"\x8d\x03\n" +
+	"\x11DeviceShadowProto\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x03R\aversion\x128\n" +
+	"\adesired\x18\x03 \x01(\v2\x1e.rootstock.v1.ShadowStateProtoR\adesired\x12:\n" +
+	"\breported\x18\x04 \x01(\v2\x1e.rootstock.v1.ShadowStateProtoR\breported\x124\n" +
+	"\x05delta\x18\x05 \x01(\v2\x1e.rootstock.v1.ShadowStateProtoR\x05delta\x121\n" +
+	"\x12desired_updated_at\x18\x06 \x01(\tH\x00R\x10desiredUpdatedAt\x88\x01\x01\x123\n" +
+	"\x13reported_updated_at\x18\a \x01(\tH\x01R\x11reportedUpdatedAt\x88\x01\x01B\x15\n" +
+	"\x13_desired_updated_atB\x16\n" +
+	"\x14_reported_updated_at\"5\n" +
+	"\x16GetDeviceShadowRequest\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\"R\n" +
+	"\x17GetDeviceShadowResponse\x127\n" +
+	"\x06shadow\x18\x01 \x01(\v2\x1f.rootstock.v1.DeviceShadowProtoR\x06shadow\"\xdf\x01\n" +
+	"\x19UpdateDeviceShadowRequest\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\x12?\n" +
+	"\x19sampling_interval_seconds\x18\x02 \x01(\x05H\x00R\x17samplingIntervalSeconds\x88\x01\x01\x12F\n" +
+	"\x0fenabled_sensors\x18\x03 \x01(\v2\x1d.rootstock.v1.StringListProtoR\x0eenabledSensorsB\x1c\n" +
+	"\x1a_sampling_interval_seconds\"U\n" +
+	"\x1aUpdateDeviceShadowResponse\x127\n" +
+	"\x06shadow\x18\x01 \x01(\v2\x1f.rootstock.v1.DeviceShadowProtoR\x06shadow\"o\n" +
 	"\tUserProto\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
 	"\tuser_type\x18\x02 \x01(\tR\buserType\x12\x16\n" +
@@ -6334,19 +10094,21 @@ const file_rootstock_v1_rootstock_proto_rawDesc = "" +
 	"\x05total\x18\x02 \x01(\x05R\x05total\">\n" +
 	"\fConsentProto\x12\x18\n" +
 	"\aversion\x18\x01 \x01(\tR\aversion\x12\x14\n" +
-	"\x05scope\x18\x02 \x01(\tR\x05scope\"\x89\x01\n" +
+	"\x05scope\x18\x02 \x01(\tR\x05scope\"\xba\x01\n" +
 	"\x13EnrollDeviceRequest\x12\x1b\n" +
 	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\x12\x1f\n" +
 	"\vcampaign_id\x18\x02 \x01(\tR\n" +
 	"campaignId\x124\n" +
-	"\aconsent\x18\x03 \x01(\v2\x1a.rootstock.v1.ConsentProtoR\aconsent\"o\n" +
+	"\aconsent\x18\x03 \x01(\v2\x1a.rootstock.v1.ConsentProtoR\aconsent\x12/\n" +
+	"\x13deployment_location\x18\x04 \x01(\tR\x12deploymentLocation\"\x9a\x01\n" +
 	"\x14EnrollDeviceResponse\x12\x1a\n" +
 	"\benrolled\x18\x01 \x01(\bR\benrolled\x12\x16\n" +
 	"\x06reason\x18\x02 \x01(\tR\x06reason\x12#\n" +
-	"\renrollment_id\x18\x03 \x01(\tR\fenrollmentId\"@\n" +
+	"\renrollment_id\x18\x03 \x01(\tR\fenrollmentId\x12)\n" +
+	"\x10location_flagged\x18\x04 \x01(\bR\x0flocationFlagged\"@\n" +
 	"\x19WithdrawEnrollmentRequest\x12#\n" +
 	"\renrollment_id\x18\x01 \x01(\tR\fenrollmentId\"\x1c\n" +
-	"\x1aWithdrawEnrollmentResponse\"\x8a\x02\n" +
+	"\x1aWithdrawEnrollmentResponse\"\xd3\x02\n" +
 	"\x12DeviceSummaryProto\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
 	"\x06status\x18\x02 \x01(\tR\x06status\x12\x14\n" +
@@ -6355,9 +10117,11 @@ const file_rootstock_v1_rootstock_proto_rawDesc = "" +
 	"\x04tier\x18\x05 \x01(\x05R\x04tier\x12\x18\n" +
 	"\asensors\x18\x06 \x03(\tR\asensors\x12-\n" +
 	"\x12active_enrollments\x18\a \x01(\x05R\x11activeEnrollments\x12 \n" +
-	"\tlast_seen\x18\b \x01(\tH\x00R\blastSeen\x88\x01\x01B\f\n" +
+	"\tlast_seen\x18\b \x01(\tH\x00R\blastSeen\x88\x01\x01\x12<\n" +
+	"\x06health\x18\t \x01(\v2\x1f.rootstock.v1.DeviceHealthProtoH\x01R\x06health\x88\x01\x01B\f\n" +
 	"\n" +
-	"_last_seen\"\x13\n" +
+	"_last_seenB\t\n" +
+	"\a_health\"\x13\n" +
 	"\x11GetDevicesRequest\"P\n" +
 	"\x12GetDevicesResponse\x12:\n" +
 	"\adevices\x18\x01 \x03(\v2 .rootstock.v1.DeviceSummaryProtoR\adevices\"{\n" +
@@ -6368,11 +10132,116 @@ const file_rootstock_v1_rootstock_proto_rawDesc = "" +
 	"\x06reason\x18\x03 \x01(\tH\x00R\x06reason\x88\x01\x01B\t\n" +
 	"\a_reason\"5\n" +
 	"\x16GetDeviceDetailRequest\x12\x1b\n" +
-	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\"\xe0\x01\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\"\x9a\x05\n" +
 	"\x17GetDeviceDetailResponse\x121\n" +
 	"\x06device\x18\x01 \x01(\v2\x19.rootstock.v1.DeviceProtoR\x06device\x12?\n" +
 	"\venrollments\x18\x02 \x03(\v2\x1d.rootstock.v1.EnrollmentProtoR\venrollments\x12Q\n" +
-	"\x12connection_history\x18\x03 \x03(\v2\".rootstock.v1.ConnectionEventProtoR\x11connectionHistory\"\xc0\x01\n" +
+	"\x12connection_history\x18\x03 \x03(\v2\".rootstock.v1.ConnectionEventProtoR\x11connectionHistory\x12'\n" +
+	"\rclock_skew_ms\x18\x04 \x01(\x03H\x00R\vclockSkewMs\x88\x01\x01\x12,\n" +
+	"\x12clock_skew_flagged\x18\x05 \x01(\bR\x10clockSkewFlagged\x12\x16\n" +
+	"\x06online\x18\x06 \x01(\bR\x06online\x12<\n" +
+	"\bsessions\x18\a \x03(\v2 .rootstock.v1.DeviceSessionProtoR\bsessions\x12<\n" +
+	"\x06health\x18\b \x01(\v2\x1f.rootstock.v1.DeviceHealthProtoH\x01R\x06health\x88\x01\x01\x12*\n" +
+	"\x0econfig_version\x18\t \x01(\x03H\x02R\rconfigVersion\x88\x01\x01\x125\n" +
+	"\x14config_acked_version\x18\n" +
+	" \x01(\x03H\x03R\x12configAckedVersion\x88\x01\x01\x12!\n" +
+	"\fconfig_stale\x18\v \x01(\bR\vconfigStaleB\x10\n" +
+	"\x0e_clock_skew_msB\t\n" +
+	"\a_healthB\x11\n" +
+	"\x0f_config_versionB\x17\n" +
+	"\x15_config_acked_version\"\xc6\x03\n" +
+	"\x11DeviceHealthProto\x12\x1f\n" +
+	"\vreported_at\x18\x01 \x01(\tR\n" +
+	"reportedAt\x12\x1f\n" +
+	"\vreceived_at\x18\x02 \x01(\tR\n" +
+	"receivedAt\x12,\n" +
+	"\x0fbattery_percent\x18\x03 \x01(\x01H\x00R\x0ebatteryPercent\x88\x01\x01\x12\x1e\n" +
+	"\brssi_dbm\x18\x04 \x01(\x05H\x01R\arssiDbm\x88\x01\x01\x12*\n" +
+	"\x0euptime_seconds\x18\x05 \x01(\x03H\x02R\ruptimeSeconds\x88\x01\x01\x12/\n" +
+	"\x11free_memory_bytes\x18\x06 \x01(\x03H\x03R\x0ffreeMemoryBytes\x88\x01\x01\x12#\n" +
+	"\rsensor_faults\x18\a \x03(\tR\fsensorFaults\x12*\n" +
+	"\x0efirmware_build\x18\b \x01(\tH\x04R\rfirmwareBuild\x88\x01\x01\x12\x16\n" +
+	"\x06alerts\x18\t \x03(\tR\x06alertsB\x12\n" +
+	"\x10_battery_percentB\v\n" +
+	"\t_rssi_dbmB\x11\n" +
+	"\x0f_uptime_secondsB\x14\n" +
+	"\x12_free_memory_bytesB\x11\n" +
+	"\x0f_firmware_build\"\xb0\x03\n" +
+	"\x12DeviceSessionProto\x12!\n" +
+	"\fconnected_at\x18\x01 \x01(\tR\vconnectedAt\x12,\n" +
+	"\x0fdisconnected_at\x18\x02 \x01(\tH\x00R\x0edisconnectedAt\x88\x01\x01\x12)\n" +
+	"\x10duration_seconds\x18\x03 \x01(\x03R\x0fdurationSeconds\x12\x1f\n" +
+	"\vremote_addr\x18\x04 \x01(\tR\n" +
+	"remoteAddr\x12$\n" +
+	"\vcert_serial\x18\x05 \x01(\tH\x01R\n" +
+	"certSerial\x88\x01\x01\x120\n" +
+	"\x11disconnect_reason\x18\x06 \x01(\tH\x02R\x10disconnectReason\x88\x01\x01\x12\x1f\n" +
+	"\bgraceful\x18\a \x01(\bH\x03R\bgraceful\x88\x01\x01\x12*\n" +
+	"\x0ewill_published\x18\b \x01(\bH\x04R\rwillPublished\x88\x01\x01B\x12\n" +
+	"\x10_disconnected_atB\x0e\n" +
+	"\f_cert_serialB\x14\n" +
+	"\x12_disconnect_reasonB\v\n" +
+	"\t_gracefulB\x11\n" +
+	"\x0f_will_published\"e\n" +
+	"\x13EnrollmentCodeProto\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\tR\texpiresAt\"\xb9\x02\n" +
+	"\x15RegisterDeviceRequest\x12\x14\n" +
+	"\x05class\x18\x01 \x01(\tR\x05class\x12\x12\n" +
+	"\x04tier\x18\x02 \x01(\x05R\x04tier\x12\x18\n" +
+	"\asensors\x18\x03 \x03(\tR\asensors\x12)\n" +
+	"\x10firmware_version\x18\x04 \x01(\tR\x0ffirmwareVersion\x12W\n" +
+	"\fsensor_units\x18\x05 \x03(\v24.rootstock.v1.RegisterDeviceRequest.SensorUnitsEntryR\vsensorUnits\x12\x18\n" +
+	"\agateway\x18\x06 \x01(\bR\agateway\x1a>\n" +
+	"\x10SensorUnitsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x99\x01\n" +
+	"\x16RegisterDeviceResponse\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12J\n" +
+	"\x0fenrollment_code\x18\x03 \x01(\v2!.rootstock.v1.EnrollmentCodeProtoR\x0eenrollmentCode\"\x1c\n" +
+	"\x1aListEnrollmentCodesRequest\"V\n" +
+	"\x1bListEnrollmentCodesResponse\x127\n" +
+	"\x05codes\x18\x01 \x03(\v2!.rootstock.v1.EnrollmentCodeProtoR\x05codes\">\n" +
+	"\x1fRegenerateEnrollmentCodeRequest\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\"n\n" +
+	" RegenerateEnrollmentCodeResponse\x12J\n" +
+	"\x0fenrollment_code\x18\x01 \x01(\v2!.rootstock.v1.EnrollmentCodeProtoR\x0eenrollmentCode\":\n" +
+	"\x1bExpireEnrollmentCodeRequest\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\"8\n" +
+	"\x1cExpireEnrollmentCodeResponse\x12\x18\n" +
+	"\aexpired\x18\x01 \x01(\x05R\aexpired\"\xd9\x01\n" +
+	"\x1bSetDeviceSensorUnitsRequest\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\x12]\n" +
+	"\fsensor_units\x18\x02 \x03(\v2:.rootstock.v1.SetDeviceSensorUnitsRequest.SensorUnitsEntryR\vsensorUnits\x1a>\n" +
+	"\x10SensorUnitsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xca\x01\n" +
+	"\x1cSetDeviceSensorUnitsResponse\x12g\n" +
+	"\x0feffective_units\x18\x01 \x03(\v2>.rootstock.v1.SetDeviceSensorUnitsResponse.EffectiveUnitsEntryR\x0eeffectiveUnits\x1aA\n" +
+	"\x13EffectiveUnitsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xe7\x01\n" +
+	"\x12LinkStationRequest\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\x12\x1a\n" +
+	"\bprotocol\x18\x02 \x01(\tR\bprotocol\x12W\n" +
+	"\rparameter_map\x18\x03 \x03(\v22.rootstock.v1.LinkStationRequest.ParameterMapEntryR\fparameterMap\x1a?\n" +
+	"\x11ParameterMapEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x8c\x01\n" +
+	"\x13LinkStationResponse\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\x12\x1a\n" +
+	"\bprotocol\x18\x02 \x01(\tR\bprotocol\x12\x1d\n" +
+	"\n" +
+	"upload_url\x18\x03 \x01(\tR\tuploadUrl\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\tR\tcreatedAt\"3\n" +
+	"\x14UnlinkStationRequest\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\"3\n" +
+	"\x15UnlinkStationResponse\x12\x1a\n" +
+	"\bunlinked\x18\x01 \x01(\bR\bunlinked\"\xc0\x01\n" +
 	"\x11NotificationProto\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04type\x18\x02 \x01(\tR\x04type\x12\x18\n" +
@@ -6450,7 +10319,7 @@ const file_rootstock_v1_rootstock_proto_rawDesc = "" +
 	"\vpreferences\x18\x01 \x03(\v2).rootstock.v1.NotificationPreferenceProtoR\vpreferences\"g\n" +
 	"\x18UpdatePreferencesRequest\x12K\n" +
 	"\vpreferences\x18\x01 \x03(\v2).rootstock.v1.NotificationPreferenceProtoR\vpreferences\"\x1b\n" +
-	"\x19UpdatePreferencesResponse\"\xda\x01\n" +
+	"\x19UpdatePreferencesResponse\"\x8b\x02\n" +
 	"\x15SuspendByClassRequest\x12!\n" +
 	"\fdevice_class\x18\x01 \x01(\tR\vdeviceClass\x12!\n" +
 	"\ffirmware_min\x18\x02 \x01(\tR\vfirmwareMin\x12!\n" +
@@ -6458,11 +10327,151 @@ const file_rootstock_v1_rootstock_proto_rawDesc = "" +
 	"\fwindow_start\x18\x04 \x01(\tR\vwindowStart\x12\x1d\n" +
 	"\n" +
 	"window_end\x18\x05 \x01(\tR\twindowEnd\x12\x16\n" +
-	"\x06reason\x18\x06 \x01(\tR\x06reason\"\xa3\x01\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\x12/\n" +
+	"\x13firmware_constraint\x18\a \x01(\tR\x12firmwareConstraint\"\xa3\x01\n" +
 	"\x16SuspendByClassResponse\x12'\n" +
 	"\x0fsuspended_count\x18\x01 \x01(\x05R\x0esuspendedCount\x121\n" +
 	"\x14quarantined_readings\x18\x02 \x01(\x03R\x13quarantinedReadings\x12-\n" +
-	"\x12notified_scitizens\x18\x03 \x01(\x05R\x11notifiedScitizens2Q\n" +
+	"\x12notified_scitizens\x18\x03 \x01(\x05R\x11notifiedScitizens\"\x93\x03\n" +
+	"\x0fDeadLetterProto\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05topic\x18\x02 \x01(\tR\x05topic\x12\x1b\n" +
+	"\tdevice_id\x18\x03 \x01(\tR\bdeviceId\x12$\n" +
+	"\vcampaign_id\x18\x04 \x01(\tH\x00R\n" +
+	"campaignId\x88\x01\x01\x12\x1f\n" +
+	"\verror_class\x18\x05 \x01(\tR\n" +
+	"errorClass\x12#\n" +
+	"\rerror_message\x18\x06 \x01(\tR\ferrorMessage\x12\x1f\n" +
+	"\vreceived_at\x18\a \x01(\tR\n" +
+	"receivedAt\x12\x16\n" +
+	"\x06status\x18\b \x01(\tR\x06status\x12!\n" +
+	"\freplay_count\x18\t \x01(\x05R\vreplayCount\x12-\n" +
+	"\x10last_replayed_at\x18\n" +
+	" \x01(\tH\x01R\x0elastReplayedAt\x88\x01\x01\x12!\n" +
+	"\fpayload_size\x18\v \x01(\x05R\vpayloadSizeB\x0e\n" +
+	"\f_campaign_idB\x13\n" +
+	"\x11_last_replayed_at\"\x9c\x01\n" +
+	"\x16ListDeadLettersRequest\x12\x1f\n" +
+	"\verror_class\x18\x01 \x01(\tR\n" +
+	"errorClass\x12\x1b\n" +
+	"\tdevice_id\x18\x02 \x01(\tR\bdeviceId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x05 \x01(\x05R\x06offset\"[\n" +
+	"\x17ListDeadLettersResponse\x12@\n" +
+	"\fdead_letters\x18\x01 \x03(\v2\x1d.rootstock.v1.DeadLetterProtoR\vdeadLetters\"&\n" +
+	"\x14GetDeadLetterRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"q\n" +
+	"\x15GetDeadLetterResponse\x12>\n" +
+	"\vdead_letter\x18\x01 \x01(\v2\x1d.rootstock.v1.DeadLetterProtoR\n" +
+	"deadLetter\x12\x18\n" +
+	"\apayload\x18\x02 \x01(\fR\apayload\")\n" +
+	"\x17ReplayDeadLetterRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x9e\x01\n" +
+	"\x18ReplayDeadLetterResponse\x12>\n" +
+	"\vdead_letter\x18\x01 \x01(\v2\x1d.rootstock.v1.DeadLetterProtoR\n" +
+	"deadLetter\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x12\n" +
+	"\x04code\x18\x03 \x01(\tR\x04code\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"u\n" +
+	"\x17PurgeDeadLettersRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\x12\x1f\n" +
+	"\verror_class\x18\x02 \x01(\tR\n" +
+	"errorClass\x12'\n" +
+	"\x0freceived_before\x18\x03 \x01(\tR\x0ereceivedBefore\"2\n" +
+	"\x18PurgeDeadLettersResponse\x12\x16\n" +
+	"\x06purged\x18\x01 \x01(\x03R\x06purged\"\xdd\x01\n" +
+	"\x1aSetDeviceClassUnitsRequest\x12!\n" +
+	"\fdevice_class\x18\x01 \x01(\tR\vdeviceClass\x12\\\n" +
+	"\fsensor_units\x18\x02 \x03(\v29.rootstock.v1.SetDeviceClassUnitsRequest.SensorUnitsEntryR\vsensorUnits\x1a>\n" +
+	"\x10SensorUnitsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xbc\x01\n" +
+	"\x1bSetDeviceClassUnitsResponse\x12]\n" +
+	"\fsensor_units\x18\x01 \x03(\v2:.rootstock.v1.SetDeviceClassUnitsResponse.SensorUnitsEntryR\vsensorUnits\x1a>\n" +
+	"\x10SensorUnitsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xf1\x01\n" +
+	"\x12RateLimitFlagProto\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\x12\x19\n" +
+	"\bowner_id\x18\x02 \x01(\tR\aownerId\x12!\n" +
+	"\fdevice_class\x18\x03 \x01(\tR\vdeviceClass\x12)\n" +
+	"\x10firmware_version\x18\x04 \x01(\tR\x0ffirmwareVersion\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x1e\n" +
+	"\n" +
+	"violations\x18\x06 \x01(\x03R\n" +
+	"violations\x12\x1d\n" +
+	"\n" +
+	"flagged_at\x18\a \x01(\tR\tflaggedAt\"$\n" +
+	"\"ListRateLimitFlaggedDevicesRequest\"a\n" +
+	"#ListRateLimitFlaggedDevicesResponse\x12:\n" +
+	"\adevices\x18\x01 \x03(\v2 .rootstock.v1.RateLimitFlagProtoR\adevices\"8\n" +
+	"\x19ClearRateLimitFlagRequest\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\"\x1c\n" +
+	"\x1aClearRateLimitFlagResponse\"\x97\x03\n" +
+	"\x14FirmwareReleaseProto\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
+	"\fdevice_class\x18\x02 \x01(\tR\vdeviceClass\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\tR\aversion\x12\x16\n" +
+	"\x06sha256\x18\x04 \x01(\tR\x06sha256\x12\x10\n" +
+	"\x03url\x18\x05 \x01(\tR\x03url\x12\"\n" +
+	"\n" +
+	"size_bytes\x18\x06 \x01(\x03H\x00R\tsizeBytes\x88\x01\x01\x12\x14\n" +
+	"\x05notes\x18\a \x01(\tR\x05notes\x12!\n" +
+	"\fsecurity_fix\x18\b \x01(\bR\vsecurityFix\x12'\n" +
+	"\x0frollout_percent\x18\t \x01(\x05R\x0erolloutPercent\x12\x16\n" +
+	"\x06cohort\x18\n" +
+	" \x03(\tR\x06cohort\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\v \x01(\tR\tcreatedBy\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\f \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\r \x01(\tR\tupdatedAtB\r\n" +
+	"\v_size_bytes\"\xcf\x01\n" +
+	"\x14FirmwareInstallProto\x12\x1d\n" +
+	"\n" +
+	"release_id\x18\x01 \x01(\tR\treleaseId\x12\x1b\n" +
+	"\tdevice_id\x18\x02 \x01(\tR\bdeviceId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x19\n" +
+	"\x05error\x18\x04 \x01(\tH\x00R\x05error\x88\x01\x01\x12\x1f\n" +
+	"\vnotified_at\x18\x05 \x01(\tR\n" +
+	"notifiedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\tR\tupdatedAtB\b\n" +
+	"\x06_error\"\xb2\x02\n" +
+	"\x1cCreateFirmwareReleaseRequest\x12!\n" +
+	"\fdevice_class\x18\x01 \x01(\tR\vdeviceClass\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12\x16\n" +
+	"\x06sha256\x18\x03 \x01(\tR\x06sha256\x12\x10\n" +
+	"\x03url\x18\x04 \x01(\tR\x03url\x12\"\n" +
+	"\n" +
+	"size_bytes\x18\x05 \x01(\x03H\x00R\tsizeBytes\x88\x01\x01\x12\x14\n" +
+	"\x05notes\x18\x06 \x01(\tR\x05notes\x12!\n" +
+	"\fsecurity_fix\x18\a \x01(\bR\vsecurityFix\x12'\n" +
+	"\x0frollout_percent\x18\b \x01(\x05R\x0erolloutPercent\x12\x16\n" +
+	"\x06cohort\x18\t \x03(\tR\x06cohortB\r\n" +
+	"\v_size_bytes\"{\n" +
+	"\x1dCreateFirmwareReleaseResponse\x12<\n" +
+	"\arelease\x18\x01 \x01(\v2\".rootstock.v1.FirmwareReleaseProtoR\arelease\x12\x1c\n" +
+	"\tpublished\x18\x02 \x01(\x05R\tpublished\"@\n" +
+	"\x1bListFirmwareReleasesRequest\x12!\n" +
+	"\fdevice_class\x18\x01 \x01(\tR\vdeviceClass\"^\n" +
+	"\x1cListFirmwareReleasesResponse\x12>\n" +
+	"\breleases\x18\x01 \x03(\v2\".rootstock.v1.FirmwareReleaseProtoR\breleases\"{\n" +
+	"\x19SetFirmwareRolloutRequest\x12\x1d\n" +
+	"\n" +
+	"release_id\x18\x01 \x01(\tR\treleaseId\x12'\n" +
+	"\x0frollout_percent\x18\x02 \x01(\x05R\x0erolloutPercent\x12\x16\n" +
+	"\x06cohort\x18\x03 \x03(\tR\x06cohort\"x\n" +
+	"\x1aSetFirmwareRolloutResponse\x12<\n" +
+	"\arelease\x18\x01 \x01(\v2\".rootstock.v1.FirmwareReleaseProtoR\arelease\x12\x1c\n" +
+	"\tpublished\x18\x02 \x01(\x05R\tpublished\"<\n" +
+	"\x1bListFirmwareInstallsRequest\x12\x1d\n" +
+	"\n" +
+	"release_id\x18\x01 \x01(\tR\treleaseId\"^\n" +
+	"\x1cListFirmwareInstallsResponse\x12>\n" +
+	"\binstalls\x18\x01 \x03(\v2\".rootstock.v1.FirmwareInstallProtoR\binstalls2Q\n" +
 	"\rHealthService\x12@\n" +
 	"\x05Check\x12\x1a.rootstock.v1.CheckRequest\x1a\x1b.rootstock.v1.CheckResponse2\x80\x04\n" +
 	"\x0fCampaignService\x12[\n" +
@@ -6482,12 +10491,16 @@ const file_rootstock_v1_rootstock_proto_rawDesc = "" +
 	"\n" +
 	"InviteUser\x12\x1f.rootstock.v1.InviteUserRequest\x1a .rootstock.v1.InviteUserResponse2n\n" +
 	"\fScoreService\x12^\n" +
-	"\x0fGetContribution\x12$.rootstock.v1.GetContributionRequest\x1a%.rootstock.v1.GetContributionResponse2\xf7\x02\n" +
+	"\x0fGetContribution\x12$.rootstock.v1.GetContributionRequest\x1a%.rootstock.v1.GetContributionResponse2\x8f\x06\n" +
 	"\rDeviceService\x12L\n" +
 	"\tGetDevice\x12\x1e.rootstock.v1.GetDeviceRequest\x1a\x1f.rootstock.v1.GetDeviceResponse\x12U\n" +
 	"\fRevokeDevice\x12!.rootstock.v1.RevokeDeviceRequest\x1a\".rootstock.v1.RevokeDeviceResponse\x12^\n" +
 	"\x0fReinstateDevice\x12$.rootstock.v1.ReinstateDeviceRequest\x1a%.rootstock.v1.ReinstateDeviceResponse\x12a\n" +
-	"\x10EnrollInCampaign\x12%.rootstock.v1.EnrollInCampaignRequest\x1a&.rootstock.v1.EnrollInCampaignResponse2\xc7\x04\n" +
+	"\x10EnrollInCampaign\x12%.rootstock.v1.EnrollInCampaignRequest\x1a&.rootstock.v1.EnrollInCampaignResponse\x12d\n" +
+	"\x11SendDeviceCommand\x12&.rootstock.v1.SendDeviceCommandRequest\x1a'.rootstock.v1.SendDeviceCommandResponse\x12g\n" +
+	"\x12ListDeviceCommands\x12'.rootstock.v1.ListDeviceCommandsRequest\x1a(.rootstock.v1.ListDeviceCommandsResponse\x12^\n" +
+	"\x0fGetDeviceShadow\x12$.rootstock.v1.GetDeviceShadowRequest\x1a%.rootstock.v1.GetDeviceShadowResponse\x12g\n" +
+	"\x12UpdateDeviceShadow\x12'.rootstock.v1.UpdateDeviceShadowRequest\x1a(.rootstock.v1.UpdateDeviceShadowResponse2\xc7\x04\n" +
 	"\vUserService\x12U\n" +
 	"\fRegisterUser\x12!.rootstock.v1.RegisterUserRequest\x1a\".rootstock.v1.RegisterUserResponse\x12@\n" +
 	"\x05GetMe\x12\x1a.rootstock.v1.GetMeRequest\x1a\x1b.rootstock.v1.GetMeResponse\x12@\n" +
@@ -6495,8 +10508,7 @@ const file_rootstock_v1_rootstock_proto_rawDesc = "" +
 	"\x06Logout\x12\x1b.rootstock.v1.LogoutRequest\x1a\x1c.rootstock.v1.LogoutResponse\x12g\n" +
 	"\x12RegisterResearcher\x12'.rootstock.v1.RegisterResearcherRequest\x1a(.rootstock.v1.RegisterResearcherResponse\x12R\n" +
 	"\vVerifyEmail\x12 .rootstock.v1.VerifyEmailRequest\x1a!.rootstock.v1.VerifyEmailResponse\x12[\n" +
-	"\x0eUpdateUserType\x12#.rootstock.v1.UpdateUserTypeRequest\x1a$.rootstock.v1.UpdateUserTypeResponse2\x89\n" +
-	"\n" +
+	"\x0eUpdateUserType\x12#.rootstock.v1.UpdateUserTypeRequest\x1a$.rootstock.v1.UpdateUserTypeResponse2\xd9\x0f\n" +
 	"\x0fScitizenService\x12a\n" +
 	"\x10RegisterScitizen\x12%.rootstock.v1.RegisterScitizenRequest\x1a&.rootstock.v1.RegisterScitizenResponse\x12U\n" +
 	"\fGetDashboard\x12!.rootstock.v1.GetDashboardRequest\x1a\".rootstock.v1.GetDashboardResponse\x12y\n" +
@@ -6511,14 +10523,32 @@ const file_rootstock_v1_rootstock_proto_rawDesc = "" +
 	"\x10GetNotifications\x12%.rootstock.v1.GetNotificationsRequest\x1a&.rootstock.v1.GetNotificationsResponse\x12a\n" +
 	"\x10GetContributions\x12%.rootstock.v1.GetContributionsRequest\x1a&.rootstock.v1.GetContributionsResponse\x12g\n" +
 	"\x12GetOnboardingState\x12'.rootstock.v1.GetOnboardingStateRequest\x1a(.rootstock.v1.GetOnboardingStateResponse\x12[\n" +
-	"\x0eGetLeaderboard\x12#.rootstock.v1.GetLeaderboardRequest\x1a$.rootstock.v1.GetLeaderboardResponse2\x89\x03\n" +
+	"\x0eGetLeaderboard\x12#.rootstock.v1.GetLeaderboardRequest\x1a$.rootstock.v1.GetLeaderboardResponse\x12[\n" +
+	"\x0eRegisterDevice\x12#.rootstock.v1.RegisterDeviceRequest\x1a$.rootstock.v1.RegisterDeviceResponse\x12j\n" +
+	"\x13ListEnrollmentCodes\x12(.rootstock.v1.ListEnrollmentCodesRequest\x1a).rootstock.v1.ListEnrollmentCodesResponse\x12y\n" +
+	"\x18RegenerateEnrollmentCode\x12-.rootstock.v1.RegenerateEnrollmentCodeRequest\x1a..rootstock.v1.RegenerateEnrollmentCodeResponse\x12m\n" +
+	"\x14ExpireEnrollmentCode\x12).rootstock.v1.ExpireEnrollmentCodeRequest\x1a*.rootstock.v1.ExpireEnrollmentCodeResponse\x12m\n" +
+	"\x14SetDeviceSensorUnits\x12).rootstock.v1.SetDeviceSensorUnitsRequest\x1a*.rootstock.v1.SetDeviceSensorUnitsResponse\x12R\n" +
+	"\vLinkStation\x12 .rootstock.v1.LinkStationRequest\x1a!.rootstock.v1.LinkStationResponse\x12X\n" +
+	"\rUnlinkStation\x12\".rootstock.v1.UnlinkStationRequest\x1a#.rootstock.v1.UnlinkStationResponse2\x89\x03\n" +
 	"\x13NotificationService\x12d\n" +
 	"\x11ListNotifications\x12&.rootstock.v1.ListNotificationsRequest\x1a'.rootstock.v1.ListNotificationsResponse\x12I\n" +
 	"\bMarkRead\x12\x1d.rootstock.v1.MarkReadRequest\x1a\x1e.rootstock.v1.MarkReadResponse\x12[\n" +
 	"\x0eGetPreferences\x12#.rootstock.v1.GetPreferencesRequest\x1a$.rootstock.v1.GetPreferencesResponse\x12d\n" +
-	"\x11UpdatePreferences\x12&.rootstock.v1.UpdatePreferencesRequest\x1a'.rootstock.v1.UpdatePreferencesResponse2k\n" +
+	"\x11UpdatePreferences\x12&.rootstock.v1.UpdatePreferencesRequest\x1a'.rootstock.v1.UpdatePreferencesResponse2\xfe\t\n" +
 	"\fAdminService\x12[\n" +
-	"\x0eSuspendByClass\x12#.rootstock.v1.SuspendByClassRequest\x1a$.rootstock.v1.SuspendByClassResponseB5Z3rootstock/web-server/proto/rootstock/v1;rootstockv1b\x06proto3"
+	"\x0eSuspendByClass\x12#.rootstock.v1.SuspendByClassRequest\x1a$.rootstock.v1.SuspendByClassResponse\x12^\n" +
+	"\x0fListDeadLetters\x12$.rootstock.v1.ListDeadLettersRequest\x1a%.rootstock.v1.ListDeadLettersResponse\x12X\n" +
+	"\rGetDeadLetter\x12\".rootstock.v1.GetDeadLetterRequest\x1a#.rootstock.v1.GetDeadLetterResponse\x12a\n" +
+	"\x10ReplayDeadLetter\x12%.rootstock.v1.ReplayDeadLetterRequest\x1a&.rootstock.v1.ReplayDeadLetterResponse\x12a\n" +
+	"\x10PurgeDeadLetters\x12%.rootstock.v1.PurgeDeadLettersRequest\x1a&.rootstock.v1.PurgeDeadLettersResponse\x12j\n" +
+	"\x13SetDeviceClassUnits\x12(.rootstock.v1.SetDeviceClassUnitsRequest\x1a).rootstock.v1.SetDeviceClassUnitsResponse\x12\x82\x01\n" +
+	"\x1bListRateLimitFlaggedDevices\x120.rootstock.v1.ListRateLimitFlaggedDevicesRequest\x1a1.rootstock.v1.ListRateLimitFlaggedDevicesResponse\x12g\n" +
+	"\x12ClearRateLimitFlag\x12'.rootstock.v1.ClearRateLimitFlagRequest\x1a(.rootstock.v1.ClearRateLimitFlagResponse\x12p\n" +
+	"\x15CreateFirmwareRelease\x12*.rootstock.v1.CreateFirmwareReleaseRequest\x1a+.rootstock.v1.CreateFirmwareReleaseResponse\x12m\n" +
+	"\x14ListFirmwareReleases\x12).rootstock.v1.ListFirmwareReleasesRequest\x1a*.rootstock.v1.ListFirmwareReleasesResponse\x12g\n" +
+	"\x12SetFirmwareRollout\x12'.rootstock.v1.SetFirmwareRolloutRequest\x1a(.rootstock.v1.SetFirmwareRolloutResponse\x12m\n" +
+	"\x14ListFirmwareInstalls\x12).rootstock.v1.ListFirmwareInstallsRequest\x1a*.rootstock.v1.ListFirmwareInstallsResponseB5Z3rootstock/web-server/proto/rootstock/v1;rootstockv1b\x06proto3"
 
 var (
 	file_rootstock_v1_rootstock_proto_rawDescOnce sync.Once
@@ -6532,113 +10562,176 @@ func file_rootstock_v1_rootstock_proto_rawDescGZIP() []byte {
 	return file_rootstock_v1_rootstock_proto_rawDescData
 }
 
-var file_rootstock_v1_rootstock_proto_msgTypes = make([]protoimpl.MessageInfo, 105)
+var file_rootstock_v1_rootstock_proto_msgTypes = make([]protoimpl.MessageInfo, 168)
 var file_rootstock_v1_rootstock_proto_goTypes = []any{
-	(*CheckRequest)(nil),                     // 0: rootstock.v1.CheckRequest
-	(*CheckResponse)(nil),                    // 1: rootstock.v1.CheckResponse
-	(*ParameterProto)(nil),                   // 2: rootstock.v1.ParameterProto
-	(*RegionProto)(nil),                      // 3: rootstock.v1.RegionProto
-	(*EligibilityProto)(nil),                 // 4: rootstock.v1.EligibilityProto
-	(*CampaignProto)(nil),                    // 5: rootstock.v1.CampaignProto
-	(*CreateCampaignRequest)(nil),            // 6: rootstock.v1.CreateCampaignRequest
-	(*CreateCampaignResponse)(nil),           // 7: rootstock.v1.CreateCampaignResponse
-	(*PublishCampaignRequest)(nil),           // 8: rootstock.v1.PublishCampaignRequest
-	(*PublishCampaignResponse)(nil),          // 9: rootstock.v1.PublishCampaignResponse
-	(*ListCampaignsRequest)(nil),             // 10: rootstock.v1.ListCampaignsRequest
-	(*ListCampaignsResponse)(nil),            // 11: rootstock.v1.ListCampaignsResponse
-	(*GetCampaignDashboardRequest)(nil),      // 12: rootstock.v1.GetCampaignDashboardRequest
-	(*ParameterQualityProto)(nil),            // 13: rootstock.v1.ParameterQualityProto
-	(*DeviceBreakdownProto)(nil),             // 14: rootstock.v1.DeviceBreakdownProto
-	(*EnrollmentFunnelProto)(nil),            // 15: rootstock.v1.EnrollmentFunnelProto
-	(*TemporalBucketProto)(nil),              // 16: rootstock.v1.TemporalBucketProto
-	(*GetCampaignDashboardResponse)(nil),     // 17: rootstock.v1.GetCampaignDashboardResponse
-	(*ExportedReadingProto)(nil),             // 18: rootstock.v1.ExportedReadingProto
-	(*ExportCampaignDataRequest)(nil),        // 19: rootstock.v1.ExportCampaignDataRequest
-	(*ExportCampaignDataResponse)(nil),       // 20: rootstock.v1.ExportCampaignDataResponse
-	(*CreateOrgRequest)(nil),                 // 21: rootstock.v1.CreateOrgRequest
-	(*CreateOrgResponse)(nil),                // 22: rootstock.v1.CreateOrgResponse
-	(*NestOrgRequest)(nil),                   // 23: rootstock.v1.NestOrgRequest
-	(*NestOrgResponse)(nil),                  // 24: rootstock.v1.NestOrgResponse
-	(*DefineRoleRequest)(nil),                // 25: rootstock.v1.DefineRoleRequest
-	(*DefineRoleResponse)(nil),               // 26: rootstock.v1.DefineRoleResponse
-	(*AssignRoleRequest)(nil),                // 27: rootstock.v1.AssignRoleRequest
-	(*AssignRoleResponse)(nil),               // 28: rootstock.v1.AssignRoleResponse
-	(*InviteUserRequest)(nil),                // 29: rootstock.v1.InviteUserRequest
-	(*InviteUserResponse)(nil),               // 30: rootstock.v1.InviteUserResponse
-	(*BadgeProto)(nil),                       // 31: rootstock.v1.BadgeProto
-	(*GetContributionRequest)(nil),           // 32: rootstock.v1.GetContributionRequest
-	(*GetContributionResponse)(nil),          // 33: rootstock.v1.GetContributionResponse
-	(*DeviceProto)(nil),                      // 34: rootstock.v1.DeviceProto
-	(*GetDeviceRequest)(nil),                 // 35: rootstock.v1.GetDeviceRequest
-	(*GetDeviceResponse)(nil),                // 36: rootstock.v1.GetDeviceResponse
-	(*RevokeDeviceRequest)(nil),              // 37: rootstock.v1.RevokeDeviceRequest
-	(*RevokeDeviceResponse)(nil),             // 38: rootstock.v1.RevokeDeviceResponse
-	(*ReinstateDeviceRequest)(nil),           // 39: rootstock.v1.ReinstateDeviceRequest
-	(*ReinstateDeviceResponse)(nil),          // 40: rootstock.v1.ReinstateDeviceResponse
-	(*EnrollInCampaignRequest)(nil),          // 41: rootstock.v1.EnrollInCampaignRequest
-	(*EnrollInCampaignResponse)(nil),         // 42: rootstock.v1.EnrollInCampaignResponse
-	(*UserProto)(nil),                        // 43: rootstock.v1.UserProto
-	(*RegisterUserRequest)(nil),              // 44: rootstock.v1.RegisterUserRequest
-	(*RegisterUserResponse)(nil),             // 45: rootstock.v1.RegisterUserResponse
-	(*GetMeRequest)(nil),                     // 46: rootstock.v1.GetMeRequest
-	(*GetMeResponse)(nil),                    // 47: rootstock.v1.GetMeResponse
-	(*LoginRequest)(nil),                     // 48: rootstock.v1.LoginRequest
-	(*LoginResponse)(nil),                    // 49: rootstock.v1.LoginResponse
-	(*LogoutRequest)(nil),                    // 50: rootstock.v1.LogoutRequest
-	(*LogoutResponse)(nil),                   // 51: rootstock.v1.LogoutResponse
-	(*RegisterResearcherRequest)(nil),        // 52: rootstock.v1.RegisterResearcherRequest
-	(*RegisterResearcherResponse)(nil),       // 53: rootstock.v1.RegisterResearcherResponse
-	(*VerifyEmailRequest)(nil),               // 54: rootstock.v1.VerifyEmailRequest
-	(*VerifyEmailResponse)(nil),              // 55: rootstock.v1.VerifyEmailResponse
-	(*UpdateUserTypeRequest)(nil),            // 56: rootstock.v1.UpdateUserTypeRequest
-	(*UpdateUserTypeResponse)(nil),           // 57: rootstock.v1.UpdateUserTypeResponse
-	(*RegisterScitizenRequest)(nil),          // 58: rootstock.v1.RegisterScitizenRequest
-	(*RegisterScitizenResponse)(nil),         // 59: rootstock.v1.RegisterScitizenResponse
-	(*OnboardingStateProto)(nil),             // 60: rootstock.v1.OnboardingStateProto
-	(*GetOnboardingStateRequest)(nil),        // 61: rootstock.v1.GetOnboardingStateRequest
-	(*GetOnboardingStateResponse)(nil),       // 62: rootstock.v1.GetOnboardingStateResponse
-	(*EnrollmentProto)(nil),                  // 63: rootstock.v1.EnrollmentProto
-	(*GetDashboardRequest)(nil),              // 64: rootstock.v1.GetDashboardRequest
-	(*GetDashboardResponse)(nil),             // 65: rootstock.v1.GetDashboardResponse
-	(*BrowsePublishedCampaignsRequest)(nil),  // 66: rootstock.v1.BrowsePublishedCampaignsRequest
-	(*CampaignSummaryProto)(nil),             // 67: rootstock.v1.CampaignSummaryProto
-	(*BrowsePublishedCampaignsResponse)(nil), // 68: rootstock.v1.BrowsePublishedCampaignsResponse
-	(*GetCampaignDetailRequest)(nil),         // 69: rootstock.v1.GetCampaignDetailRequest
-	(*GetCampaignDetailResponse)(nil),        // 70: rootstock.v1.GetCampaignDetailResponse
-	(*SearchCampaignsRequest)(nil),           // 71: rootstock.v1.SearchCampaignsRequest
-	(*SearchCampaignsResponse)(nil),          // 72: rootstock.v1.SearchCampaignsResponse
-	(*ConsentProto)(nil),                     // 73: rootstock.v1.ConsentProto
-	(*EnrollDeviceRequest)(nil),              // 74: rootstock.v1.EnrollDeviceRequest
-	(*EnrollDeviceResponse)(nil),             // 75: rootstock.v1.EnrollDeviceResponse
-	(*WithdrawEnrollmentRequest)(nil),        // 76: rootstock.v1.WithdrawEnrollmentRequest
-	(*WithdrawEnrollmentResponse)(nil),       // 77: rootstock.v1.WithdrawEnrollmentResponse
-	(*DeviceSummaryProto)(nil),               // 78: rootstock.v1.DeviceSummaryProto
-	(*GetDevicesRequest)(nil),                // 79: rootstock.v1.GetDevicesRequest
-	(*GetDevicesResponse)(nil),               // 80: rootstock.v1.GetDevicesResponse
-	(*ConnectionEventProto)(nil),             // 81: rootstock.v1.ConnectionEventProto
-	(*GetDeviceDetailRequest)(nil),           // 82: rootstock.v1.GetDeviceDetailRequest
-	(*GetDeviceDetailResponse)(nil),          // 83: rootstock.v1.GetDeviceDetailResponse
-	(*NotificationProto)(nil),                // 84: rootstock.v1.NotificationProto
-	(*GetNotificationsRequest)(nil),          // 85: rootstock.v1.GetNotificationsRequest
-	(*GetNotificationsResponse)(nil),         // 86: rootstock.v1.GetNotificationsResponse
-	(*ReadingHistoryProto)(nil),              // 87: rootstock.v1.ReadingHistoryProto
-	(*GetContributionsRequest)(nil),          // 88: rootstock.v1.GetContributionsRequest
-	(*GetContributionsResponse)(nil),         // 89: rootstock.v1.GetContributionsResponse
-	(*LeaderboardEntryProto)(nil),            // 90: rootstock.v1.LeaderboardEntryProto
-	(*GetLeaderboardRequest)(nil),            // 91: rootstock.v1.GetLeaderboardRequest
-	(*GetLeaderboardResponse)(nil),           // 92: rootstock.v1.GetLeaderboardResponse
-	(*ListNotificationsRequest)(nil),         // 93: rootstock.v1.ListNotificationsRequest
-	(*ListNotificationsResponse)(nil),        // 94: rootstock.v1.ListNotificationsResponse
-	(*MarkReadRequest)(nil),                  // 95: rootstock.v1.MarkReadRequest
-	(*MarkReadResponse)(nil),                 // 96: rootstock.v1.MarkReadResponse
-	(*NotificationPreferenceProto)(nil),      // 97: rootstock.v1.NotificationPreferenceProto
-	(*GetPreferencesRequest)(nil),            // 98: rootstock.v1.GetPreferencesRequest
-	(*GetPreferencesResponse)(nil),           // 99: rootstock.v1.GetPreferencesResponse
-	(*UpdatePreferencesRequest)(nil),         // 100: rootstock.v1.UpdatePreferencesRequest
-	(*UpdatePreferencesResponse)(nil),        // 101: rootstock.v1.UpdatePreferencesResponse
-	(*SuspendByClassRequest)(nil),            // 102: rootstock.v1.SuspendByClassRequest
-	(*SuspendByClassResponse)(nil),           // 103: rootstock.v1.SuspendByClassResponse
-	nil,                                      // 104: rootstock.v1.ExportedReadingProto.ValuesEntry
+	(*CheckRequest)(nil),                        // 0: rootstock.v1.CheckRequest
+	(*CheckResponse)(nil),                       // 1: rootstock.v1.CheckResponse
+	(*ParameterProto)(nil),                      // 2: rootstock.v1.ParameterProto
+	(*RegionProto)(nil),                         // 3: rootstock.v1.RegionProto
+	(*EligibilityProto)(nil),                    // 4: rootstock.v1.EligibilityProto
+	(*CampaignProto)(nil),                       // 5: rootstock.v1.CampaignProto
+	(*CreateCampaignRequest)(nil),               // 6: rootstock.v1.CreateCampaignRequest
+	(*CreateCampaignResponse)(nil),              // 7: rootstock.v1.CreateCampaignResponse
+	(*PublishCampaignRequest)(nil),              // 8: rootstock.v1.PublishCampaignRequest
+	(*PublishCampaignResponse)(nil),             // 9: rootstock.v1.PublishCampaignResponse
+	(*ListCampaignsRequest)(nil),                // 10: rootstock.v1.ListCampaignsRequest
+	(*ListCampaignsResponse)(nil),               // 11: rootstock.v1.ListCampaignsResponse
+	(*GetCampaignDashboardRequest)(nil),         // 12: rootstock.v1.GetCampaignDashboardRequest
+	(*ParameterQualityProto)(nil),               // 13: rootstock.v1.ParameterQualityProto
+	(*DeviceBreakdownProto)(nil),                // 14: rootstock.v1.DeviceBreakdownProto
+	(*EnrollmentFunnelProto)(nil),               // 15: rootstock.v1.EnrollmentFunnelProto
+	(*TemporalBucketProto)(nil),                 // 16: rootstock.v1.TemporalBucketProto
+	(*GetCampaignDashboardResponse)(nil),        // 17: rootstock.v1.GetCampaignDashboardResponse
+	(*ExportedReadingProto)(nil),                // 18: rootstock.v1.ExportedReadingProto
+	(*ValueConversionProto)(nil),                // 19: rootstock.v1.ValueConversionProto
+	(*ExportCampaignDataRequest)(nil),           // 20: rootstock.v1.ExportCampaignDataRequest
+	(*ExportCampaignDataResponse)(nil),          // 21: rootstock.v1.ExportCampaignDataResponse
+	(*CreateOrgRequest)(nil),                    // 22: rootstock.v1.CreateOrgRequest
+	(*CreateOrgResponse)(nil),                   // 23: rootstock.v1.CreateOrgResponse
+	(*NestOrgRequest)(nil),                      // 24: rootstock.v1.NestOrgRequest
+	(*NestOrgResponse)(nil),                     // 25: rootstock.v1.NestOrgResponse
+	(*DefineRoleRequest)(nil),                   // 26: rootstock.v1.DefineRoleRequest
+	(*DefineRoleResponse)(nil),                  // 27: rootstock.v1.DefineRoleResponse
+	(*AssignRoleRequest)(nil),                   // 28: rootstock.v1.AssignRoleRequest
+	(*AssignRoleResponse)(nil),                  // 29: rootstock.v1.AssignRoleResponse
+	(*InviteUserRequest)(nil),                   // 30: rootstock.v1.InviteUserRequest
+	(*InviteUserResponse)(nil),                  // 31: rootstock.v1.InviteUserResponse
+	(*BadgeProto)(nil),                          // 32: rootstock.v1.BadgeProto
+	(*GetContributionRequest)(nil),              // 33: rootstock.v1.GetContributionRequest
+	(*GetContributionResponse)(nil),             // 34: rootstock.v1.GetContributionResponse
+	(*DeviceProto)(nil),                         // 35: rootstock.v1.DeviceProto
+	(*GetDeviceRequest)(nil),                    // 36: rootstock.v1.GetDeviceRequest
+	(*GetDeviceResponse)(nil),                   // 37: rootstock.v1.GetDeviceResponse
+	(*RevokeDeviceRequest)(nil),                 // 38: rootstock.v1.RevokeDeviceRequest
+	(*RevokeDeviceResponse)(nil),                // 39: rootstock.v1.RevokeDeviceResponse
+	(*ReinstateDeviceRequest)(nil),              // 40: rootstock.v1.ReinstateDeviceRequest
+	(*ReinstateDeviceResponse)(nil),             // 41: rootstock.v1.ReinstateDeviceResponse
+	(*EnrollInCampaignRequest)(nil),             // 42: rootstock.v1.EnrollInCampaignRequest
+	(*EnrollInCampaignResponse)(nil),            // 43: rootstock.v1.EnrollInCampaignResponse
+	(*DeviceCommandProto)(nil),                  // 44: rootstock.v1.DeviceCommandProto
+	(*SendDeviceCommandRequest)(nil),            // 45: rootstock.v1.SendDeviceCommandRequest
+	(*SendDeviceCommandResponse)(nil),           // 46: rootstock.v1.SendDeviceCommandResponse
+	(*ListDeviceCommandsRequest)(nil),           // 47: rootstock.v1.ListDeviceCommandsRequest
+	(*ListDeviceCommandsResponse)(nil),          // 48: rootstock.v1.ListDeviceCommandsResponse
+	(*StringListProto)(nil),                     // 49: rootstock.v1.StringListProto
+	(*ShadowStateProto)(nil),                    // 50: rootstock.v1.ShadowStateProto
+	(*DeviceShadowProto)(nil),                   // 51: rootstock.v1.DeviceShadowProto
+	(*GetDeviceShadowRequest)(nil),              // 52: rootstock.v1.GetDeviceShadowRequest
+	(*GetDeviceShadowResponse)(nil),             // 53: rootstock.v1.GetDeviceShadowResponse
+	(*UpdateDeviceShadowRequest)(nil),           // 54: rootstock.v1.UpdateDeviceShadowRequest
+	(*UpdateDeviceShadowResponse)(nil),          // 55: rootstock.v1.UpdateDeviceShadowResponse
+	(*UserProto)(nil),                           // 56: rootstock.v1.UserProto
+	(*RegisterUserRequest)(nil),                 // 57: rootstock.v1.RegisterUserRequest
+	(*RegisterUserResponse)(nil),                // 58: rootstock.v1.RegisterUserResponse
+	(*GetMeRequest)(nil),                        // 59: rootstock.v1.GetMeRequest
+	(*GetMeResponse)(nil),                       // 60: rootstock.v1.GetMeResponse
+	(*LoginRequest)(nil),                        // 61: rootstock.v1.LoginRequest
+	(*LoginResponse)(nil),                       // 62: rootstock.v1.LoginResponse
+	(*LogoutRequest)(nil),                       // 63: rootstock.v1.LogoutRequest
+	(*LogoutResponse)(nil),                      // 64: rootstock.v1.LogoutResponse
+	(*RegisterResearcherRequest)(nil),           // 65: rootstock.v1.RegisterResearcherRequest
+	(*RegisterResearcherResponse)(nil),          // 66: rootstock.v1.RegisterResearcherResponse
+	(*VerifyEmailRequest)(nil),                  // 67: rootstock.v1.VerifyEmailRequest
+	(*VerifyEmailResponse)(nil),                 // 68: rootstock.v1.VerifyEmailResponse
+	(*UpdateUserTypeRequest)(nil),               // 69: rootstock.v1.UpdateUserTypeRequest
+	(*UpdateUserTypeResponse)(nil),              // 70: rootstock.v1.UpdateUserTypeResponse
+	(*RegisterScitizenRequest)(nil),             // 71: rootstock.v1.RegisterScitizenRequest
+	(*RegisterScitizenResponse)(nil),            // 72: rootstock.v1.RegisterScitizenResponse
+	(*OnboardingStateProto)(nil),                // 73: rootstock.v1.OnboardingStateProto
+	(*GetOnboardingStateRequest)(nil),           // 74: rootstock.v1.GetOnboardingStateRequest
+	(*GetOnboardingStateResponse)(nil),          // 75: rootstock.v1.GetOnboardingStateResponse
+	(*EnrollmentProto)(nil),                     // 76: rootstock.v1.EnrollmentProto
+	(*GetDashboardRequest)(nil),                 // 77: rootstock.v1.GetDashboardRequest
+	(*GetDashboardResponse)(nil),                // 78: rootstock.v1.GetDashboardResponse
+	(*BrowsePublishedCampaignsRequest)(nil),     // 79: rootstock.v1.BrowsePublishedCampaignsRequest
+	(*CampaignSummaryProto)(nil),                // 80: rootstock.v1.CampaignSummaryProto
+	(*BrowsePublishedCampaignsResponse)(nil),    // 81: rootstock.v1.BrowsePublishedCampaignsResponse
+	(*GetCampaignDetailRequest)(nil),            // 82: rootstock.v1.GetCampaignDetailRequest
+	(*GetCampaignDetailResponse)(nil),           // 83: rootstock.v1.GetCampaignDetailResponse
+	(*SearchCampaignsRequest)(nil),              // 84: rootstock.v1.SearchCampaignsRequest
+	(*SearchCampaignsResponse)(nil),             // 85: rootstock.v1.SearchCampaignsResponse
+	(*ConsentProto)(nil),                        // 86: rootstock.v1.ConsentProto
+	(*EnrollDeviceRequest)(nil),                 // 87: rootstock.v1.EnrollDeviceRequest
+	(*EnrollDeviceResponse)(nil),                // 88: rootstock.v1.EnrollDeviceResponse
+	(*WithdrawEnrollmentRequest)(nil),           // 89: rootstock.v1.WithdrawEnrollmentRequest
+	(*WithdrawEnrollmentResponse)(nil),          // 90: rootstock.v1.WithdrawEnrollmentResponse
+	(*DeviceSummaryProto)(nil),                  // 91: rootstock.v1.DeviceSummaryProto
+	(*GetDevicesRequest)(nil),                   // 92: rootstock.v1.GetDevicesRequest
+	(*GetDevicesResponse)(nil),                  // 93: rootstock.v1.GetDevicesResponse
+	(*ConnectionEventProto)(nil),                // 94: rootstock.v1.ConnectionEventProto
+	(*GetDeviceDetailRequest)(nil),              // 95: rootstock.v1.GetDeviceDetailRequest
+	(*GetDeviceDetailResponse)(nil),             // 96: rootstock.v1.GetDeviceDetailResponse
+	(*DeviceHealthProto)(nil),                   // 97: rootstock.v1.DeviceHealthProto
+	(*DeviceSessionProto)(nil),                  // 98: rootstock.v1.DeviceSessionProto
+	(*EnrollmentCodeProto)(nil),                 // 99: rootstock.v1.EnrollmentCodeProto
+	(*RegisterDeviceRequest)(nil),               // 100: rootstock.v1.RegisterDeviceRequest
+	(*RegisterDeviceResponse)(nil),              // 101: rootstock.v1.RegisterDeviceResponse
+	(*ListEnrollmentCodesRequest)(nil),          // 102: rootstock.v1.ListEnrollmentCodesRequest
+	(*ListEnrollmentCodesResponse)(nil),         // 103: rootstock.v1.ListEnrollmentCodesResponse
+	(*RegenerateEnrollmentCodeRequest)(nil),     // 104: rootstock.v1.RegenerateEnrollmentCodeRequest
+	(*RegenerateEnrollmentCodeResponse)(nil),    // 105: rootstock.v1.RegenerateEnrollmentCodeResponse
+	(*ExpireEnrollmentCodeRequest)(nil),         // 106: rootstock.v1.ExpireEnrollmentCodeRequest
+	(*ExpireEnrollmentCodeResponse)(nil),        // 107: rootstock.v1.ExpireEnrollmentCodeResponse
+	(*SetDeviceSensorUnitsRequest)(nil),         // 108: rootstock.v1.SetDeviceSensorUnitsRequest
+	(*SetDeviceSensorUnitsResponse)(nil),        // 109: rootstock.v1.SetDeviceSensorUnitsResponse
+	(*LinkStationRequest)(nil),                  // 110: rootstock.v1.LinkStationRequest
+	(*LinkStationResponse)(nil),                 // 111: rootstock.v1.LinkStationResponse
+	(*UnlinkStationRequest)(nil),                // 112: rootstock.v1.UnlinkStationRequest
+	(*UnlinkStationResponse)(nil),               // 113: rootstock.v1.UnlinkStationResponse
+	(*NotificationProto)(nil),                   // 114: rootstock.v1.NotificationProto
+	(*GetNotificationsRequest)(nil),             // 115: rootstock.v1.GetNotificationsRequest
+	(*GetNotificationsResponse)(nil),            // 116: rootstock.v1.GetNotificationsResponse
+	(*ReadingHistoryProto)(nil),                 // 117: rootstock.v1.ReadingHistoryProto
+	(*GetContributionsRequest)(nil),             // 118: rootstock.v1.GetContributionsRequest
+	(*GetContributionsResponse)(nil),            // 119: rootstock.v1.GetContributionsResponse
+	(*LeaderboardEntryProto)(nil),               // 120: rootstock.v1.LeaderboardEntryProto
+	(*GetLeaderboardRequest)(nil),               // 121: rootstock.v1.GetLeaderboardRequest
+	(*GetLeaderboardResponse)(nil),              // 122: rootstock.v1.GetLeaderboardResponse
+	(*ListNotificationsRequest)(nil),            // 123: rootstock.v1.ListNotificationsRequest
+	(*ListNotificationsResponse)(nil),           // 124: rootstock.v1.ListNotificationsResponse
+	(*MarkReadRequest)(nil),                     // 125: rootstock.v1.MarkReadRequest
+	(*MarkReadResponse)(nil),                    // 126: rootstock.v1.MarkReadResponse
+	(*NotificationPreferenceProto)(nil),         // 127: rootstock.v1.NotificationPreferenceProto
+	(*GetPreferencesRequest)(nil),               // 128: rootstock.v1.GetPreferencesRequest
+	(*GetPreferencesResponse)(nil),              // 129: rootstock.v1.GetPreferencesResponse
+	(*UpdatePreferencesRequest)(nil),            // 130: rootstock.v1.UpdatePreferencesRequest
+	(*UpdatePreferencesResponse)(nil),           // 131: rootstock.v1.UpdatePreferencesResponse
+	(*SuspendByClassRequest)(nil),               // 132: rootstock.v1.SuspendByClassRequest
+	(*SuspendByClassResponse)(nil),              // 133: rootstock.v1.SuspendByClassResponse
+	(*DeadLetterProto)(nil),                     // 134: rootstock.v1.DeadLetterProto
+	(*ListDeadLettersRequest)(nil),              // 135: rootstock.v1.ListDeadLettersRequest
+	(*ListDeadLettersResponse)(nil),             // 136: rootstock.v1.ListDeadLettersResponse
+	(*GetDeadLetterRequest)(nil),                // 137: rootstock.v1.GetDeadLetterRequest
+	(*GetDeadLetterResponse)(nil),               // 138: rootstock.v1.GetDeadLetterResponse
+	(*ReplayDeadLetterRequest)(nil),             // 139: rootstock.v1.ReplayDeadLetterRequest
+	(*ReplayDeadLetterResponse)(nil),            // 140: rootstock.v1.ReplayDeadLetterResponse
+	(*PurgeDeadLettersRequest)(nil),             // 141: rootstock.v1.PurgeDeadLettersRequest
+	(*PurgeDeadLettersResponse)(nil),            // 142: rootstock.v1.PurgeDeadLettersResponse
+	(*SetDeviceClassUnitsRequest)(nil),          // 143: rootstock.v1.SetDeviceClassUnitsRequest
+	(*SetDeviceClassUnitsResponse)(nil),         // 144: rootstock.v1.SetDeviceClassUnitsResponse
+	(*RateLimitFlagProto)(nil),                  // 145: rootstock.v1.RateLimitFlagProto
+	(*ListRateLimitFlaggedDevicesRequest)(nil),  // 146: rootstock.v1.ListRateLimitFlaggedDevicesRequest
+	(*ListRateLimitFlaggedDevicesResponse)(nil), // 147: rootstock.v1.ListRateLimitFlaggedDevicesResponse
+	(*ClearRateLimitFlagRequest)(nil),           // 148: rootstock.v1.ClearRateLimitFlagRequest
+	(*ClearRateLimitFlagResponse)(nil),          // 149: rootstock.v1.ClearRateLimitFlagResponse
+	(*FirmwareReleaseProto)(nil),                // 150: rootstock.v1.FirmwareReleaseProto
+	(*FirmwareInstallProto)(nil),                // 151: rootstock.v1.FirmwareInstallProto
+	(*CreateFirmwareReleaseRequest)(nil),        // 152: rootstock.v1.CreateFirmwareReleaseRequest
+	(*CreateFirmwareReleaseResponse)(nil),       // 153: rootstock.v1.CreateFirmwareReleaseResponse
+	(*ListFirmwareReleasesRequest)(nil),         // 154: rootstock.v1.ListFirmwareReleasesRequest
+	(*ListFirmwareReleasesResponse)(nil),        // 155: rootstock.v1.ListFirmwareReleasesResponse
+	(*SetFirmwareRolloutRequest)(nil),           // 156: rootstock.v1.SetFirmwareRolloutRequest
+	(*SetFirmwareRolloutResponse)(nil),          // 157: rootstock.v1.SetFirmwareRolloutResponse
+	(*ListFirmwareInstallsRequest)(nil),         // 158: rootstock.v1.ListFirmwareInstallsRequest
+	(*ListFirmwareInstallsResponse)(nil),        // 159: rootstock.v1.ListFirmwareInstallsResponse
+	nil,                                         // 160: rootstock.v1.ExportedReadingProto.ValuesEntry
+	nil,                                         // 161: rootstock.v1.ExportedReadingProto.ConversionsEntry
+	nil,                                         // 162: rootstock.v1.RegisterDeviceRequest.SensorUnitsEntry
+	nil,                                         // 163: rootstock.v1.SetDeviceSensorUnitsRequest.SensorUnitsEntry
+	nil,                                         // 164: rootstock.v1.SetDeviceSensorUnitsResponse.EffectiveUnitsEntry
+	nil,                                         // 165: rootstock.v1.LinkStationRequest.ParameterMapEntry
+	nil,                                         // 166: rootstock.v1.SetDeviceClassUnitsRequest.SensorUnitsEntry
+	nil,                                         // 167: rootstock.v1.SetDeviceClassUnitsResponse.SensorUnitsEntry
 }
 var file_rootstock_v1_rootstock_proto_depIdxs = []int32{
 	2,   // 0: rootstock.v1.CreateCampaignRequest.parameters:type_name -> rootstock.v1.ParameterProto
@@ -6650,122 +10743,198 @@ var file_rootstock_v1_rootstock_proto_depIdxs = []int32{
 	14,  // 6: rootstock.v1.GetCampaignDashboardResponse.device_breakdown:type_name -> rootstock.v1.DeviceBreakdownProto
 	15,  // 7: rootstock.v1.GetCampaignDashboardResponse.enrollment_funnel:type_name -> rootstock.v1.EnrollmentFunnelProto
 	16,  // 8: rootstock.v1.GetCampaignDashboardResponse.temporal_coverage:type_name -> rootstock.v1.TemporalBucketProto
-	104, // 9: rootstock.v1.ExportedReadingProto.values:type_name -> rootstock.v1.ExportedReadingProto.ValuesEntry
-	18,  // 10: rootstock.v1.ExportCampaignDataResponse.readings:type_name -> rootstock.v1.ExportedReadingProto
-	31,  // 11: rootstock.v1.GetContributionResponse.badges:type_name -> rootstock.v1.BadgeProto
-	34,  // 12: rootstock.v1.GetDeviceResponse.device:type_name -> rootstock.v1.DeviceProto
-	43,  // 13: rootstock.v1.RegisterUserResponse.user:type_name -> rootstock.v1.UserProto
-	43,  // 14: rootstock.v1.GetMeResponse.user:type_name -> rootstock.v1.UserProto
-	43,  // 15: rootstock.v1.LoginResponse.user:type_name -> rootstock.v1.UserProto
-	43,  // 16: rootstock.v1.UpdateUserTypeResponse.user:type_name -> rootstock.v1.UserProto
-	60,  // 17: rootstock.v1.GetOnboardingStateResponse.state:type_name -> rootstock.v1.OnboardingStateProto
-	31,  // 18: rootstock.v1.GetDashboardResponse.badges:type_name -> rootstock.v1.BadgeProto
-	63,  // 19: rootstock.v1.GetDashboardResponse.enrollments:type_name -> rootstock.v1.EnrollmentProto
-	67,  // 20: rootstock.v1.BrowsePublishedCampaignsResponse.campaigns:type_name -> rootstock.v1.CampaignSummaryProto
-	2,   // 21: rootstock.v1.GetCampaignDetailResponse.parameters:type_name -> rootstock.v1.ParameterProto
-	3,   // 22: rootstock.v1.GetCampaignDetailResponse.regions:type_name -> rootstock.v1.RegionProto
-	4,   // 23: rootstock.v1.GetCampaignDetailResponse.eligibility:type_name -> rootstock.v1.EligibilityProto
-	67,  // 24: rootstock.v1.SearchCampaignsResponse.campaigns:type_name -> rootstock.v1.CampaignSummaryProto
-	73,  // 25: rootstock.v1.EnrollDeviceRequest.consent:type_name -> rootstock.v1.ConsentProto
-	78,  // 26: rootstock.v1.GetDevicesResponse.devices:type_name -> rootstock.v1.DeviceSummaryProto
-	34,  // 27: rootstock.v1.GetDeviceDetailResponse.device:type_name -> rootstock.v1.DeviceProto
-	63,  // 28: rootstock.v1.GetDeviceDetailResponse.enrollments:type_name -> rootstock.v1.EnrollmentProto
-	81,  // 29: rootstock.v1.GetDeviceDetailResponse.connection_history:type_name -> rootstock.v1.ConnectionEventProto
-	84,  // 30: rootstock.v1.GetNotificationsResponse.notifications:type_name -> rootstock.v1.NotificationProto
-	87,  // 31: rootstock.v1.GetContributionsResponse.histories:type_name -> rootstock.v1.ReadingHistoryProto
-	31,  // 32: rootstock.v1.GetContributionsResponse.badges:type_name -> rootstock.v1.BadgeProto
-	90,  // 33: rootstock.v1.GetLeaderboardResponse.entries:type_name -> rootstock.v1.LeaderboardEntryProto
-	90,  // 34: rootstock.v1.GetLeaderboardResponse.requester:type_name -> rootstock.v1.LeaderboardEntryProto
-	84,  // 35: rootstock.v1.ListNotificationsResponse.notifications:type_name -> rootstock.v1.NotificationProto
-	97,  // 36: rootstock.v1.GetPreferencesResponse.preferences:type_name -> rootstock.v1.NotificationPreferenceProto
-	97,  // 37: rootstock.v1.UpdatePreferencesRequest.preferences:type_name -> rootstock.v1.NotificationPreferenceProto
-	0,   // 38: rootstock.v1.HealthService.Check:input_type -> rootstock.v1.CheckRequest
-	6,   // 39: rootstock.v1.CampaignService.CreateCampaign:input_type -> rootstock.v1.CreateCampaignRequest
-	8,   // 40: rootstock.v1.CampaignService.PublishCampaign:input_type -> rootstock.v1.PublishCampaignRequest
-	10,  // 41: rootstock.v1.CampaignService.ListCampaigns:input_type -> rootstock.v1.ListCampaignsRequest
-	12,  // 42: rootstock.v1.CampaignService.GetCampaignDashboard:input_type -> rootstock.v1.GetCampaignDashboardRequest
-	19,  // 43: rootstock.v1.CampaignService.ExportCampaignData:input_type -> rootstock.v1.ExportCampaignDataRequest
-	21,  // 44: rootstock.v1.OrgService.CreateOrg:input_type -> rootstock.v1.CreateOrgRequest
-	23,  // 45: rootstock.v1.OrgService.NestOrg:input_type -> rootstock.v1.NestOrgRequest
-	25,  // 46: rootstock.v1.OrgService.DefineRole:input_type -> rootstock.v1.DefineRoleRequest
-	27,  // 47: rootstock.v1.OrgService.AssignRole:input_type -> rootstock.v1.AssignRoleRequest
-	29,  // 48: rootstock.v1.OrgService.InviteUser:input_type -> rootstock.v1.InviteUserRequest
-	32,  // 49: rootstock.v1.ScoreService.GetContribution:input_type -> rootstock.v1.GetContributionRequest
-	35,  // 50: rootstock.v1.DeviceService.GetDevice:input_type -> rootstock.v1.GetDeviceRequest
-	37,  // 51: rootstock.v1.DeviceService.RevokeDevice:input_type -> rootstock.v1.RevokeDeviceRequest
-	39,  // 52: rootstock.v1.DeviceService.ReinstateDevice:input_type -> rootstock.v1.ReinstateDeviceRequest
-	41,  // 53: rootstock.v1.DeviceService.EnrollInCampaign:input_type -> rootstock.v1.EnrollInCampaignRequest
-	44,  // 54: rootstock.v1.UserService.RegisterUser:input_type -> rootstock.v1.RegisterUserRequest
-	46,  // 55: rootstock.v1.UserService.GetMe:input_type -> rootstock.v1.GetMeRequest
-	48,  // 56: rootstock.v1.UserService.Login:input_type -> rootstock.v1.LoginRequest
-	50,  // 57: rootstock.v1.UserService.Logout:input_type -> rootstock.v1.LogoutRequest
-	52,  // 58: rootstock.v1.UserService.RegisterResearcher:input_type -> rootstock.v1.RegisterResearcherRequest
-	54,  // 59: rootstock.v1.UserService.VerifyEmail:input_type -> rootstock.v1.VerifyEmailRequest
-	56,  // 60: rootstock.v1.UserService.UpdateUserType:input_type -> rootstock.v1.UpdateUserTypeRequest
-	58,  // 61: rootstock.v1.ScitizenService.RegisterScitizen:input_type -> rootstock.v1.RegisterScitizenRequest
-	64,  // 62: rootstock.v1.ScitizenService.GetDashboard:input_type -> rootstock.v1.GetDashboardRequest
-	66,  // 63: rootstock.v1.ScitizenService.BrowsePublishedCampaigns:input_type -> rootstock.v1.BrowsePublishedCampaignsRequest
-	69,  // 64: rootstock.v1.ScitizenService.GetCampaignDetail:input_type -> rootstock.v1.GetCampaignDetailRequest
-	71,  // 65: rootstock.v1.ScitizenService.SearchCampaigns:input_type -> rootstock.v1.SearchCampaignsRequest
-	74,  // 66: rootstock.v1.ScitizenService.EnrollDevice:input_type -> rootstock.v1.EnrollDeviceRequest
-	76,  // 67: rootstock.v1.ScitizenService.WithdrawEnrollment:input_type -> rootstock.v1.WithdrawEnrollmentRequest
-	79,  // 68: rootstock.v1.ScitizenService.GetDevices:input_type -> rootstock.v1.GetDevicesRequest
-	82,  // 69: rootstock.v1.ScitizenService.GetDeviceDetail:input_type -> rootstock.v1.GetDeviceDetailRequest
-	85,  // 70: rootstock.v1.ScitizenService.GetNotifications:input_type -> rootstock.v1.GetNotificationsRequest
-	88,  // 71: rootstock.v1.ScitizenService.GetContributions:input_type -> rootstock.v1.GetContributionsRequest
-	61,  // 72: rootstock.v1.ScitizenService.GetOnboardingState:input_type -> rootstock.v1.GetOnboardingStateRequest
-	91,  // 73: rootstock.v1.ScitizenService.GetLeaderboard:input_type -> rootstock.v1.GetLeaderboardRequest
-	93,  // 74: rootstock.v1.NotificationService.ListNotifications:input_type -> rootstock.v1.ListNotificationsRequest
-	95,  // 75: rootstock.v1.NotificationService.MarkRead:input_type -> rootstock.v1.MarkReadRequest
-	98,  // 76: rootstock.v1.NotificationService.GetPreferences:input_type -> rootstock.v1.GetPreferencesRequest
-	100, // 77: rootstock.v1.NotificationService.UpdatePreferences:input_type -> rootstock.v1.UpdatePreferencesRequest
-	102, // 78: rootstock.v1.AdminService.SuspendByClass:input_type -> rootstock.v1.SuspendByClassRequest
-	1,   // 79: rootstock.v1.HealthService.Check:output_type -> rootstock.v1.CheckResponse
-	7,   // 80: rootstock.v1.CampaignService.CreateCampaign:output_type -> rootstock.v1.CreateCampaignResponse
-	9,   // 81: rootstock.v1.CampaignService.PublishCampaign:output_type -> rootstock.v1.PublishCampaignResponse
-	11,  // 82: rootstock.v1.CampaignService.ListCampaigns:output_type -> rootstock.v1.ListCampaignsResponse
-	17,  // 83: rootstock.v1.CampaignService.GetCampaignDashboard:output_type -> rootstock.v1.GetCampaignDashboardResponse
-	20,  // 84: rootstock.v1.CampaignService.ExportCampaignData:output_type -> rootstock.v1.ExportCampaignDataResponse
-	22,  // 85: rootstock.v1.OrgService.CreateOrg:output_type -> rootstock.v1.CreateOrgResponse
-	24,  // 86: rootstock.v1.OrgService.NestOrg:output_type -> rootstock.v1.NestOrgResponse
-	26,  // 87: rootstock.v1.OrgService.DefineRole:output_type -> rootstock.v1.DefineRoleResponse
-	28,  // 88: rootstock.v1.OrgService.AssignRole:output_type -> rootstock.v1.AssignRoleResponse
-	30,  // 89: rootstock.v1.OrgService.InviteUser:output_type -> rootstock.v1.InviteUserResponse
-	33,  // 90: rootstock.v1.ScoreService.GetContribution:output_type -> rootstock.v1.GetContributionResponse
-	36,  // 91: rootstock.v1.DeviceService.GetDevice:output_type -> rootstock.v1.GetDeviceResponse
-	38,  // 92: rootstock.v1.DeviceService.RevokeDevice:output_type -> rootstock.v1.RevokeDeviceResponse
-	40,  // 93: rootstock.v1.DeviceService.ReinstateDevice:output_type -> rootstock.v1.ReinstateDeviceResponse
-	42,  // 94: rootstock.v1.DeviceService.EnrollInCampaign:output_type -> rootstock.v1.EnrollInCampaignResponse
-	45,  // 95: rootstock.v1.UserService.RegisterUser:output_type -> rootstock.v1.RegisterUserResponse
-	47,  // 96: rootstock.v1.UserService.GetMe:output_type -> rootstock.v1.GetMeResponse
-	49,  // 97: rootstock.v1.UserService.Login:output_type -> rootstock.v1.LoginResponse
-	51,  // 98: rootstock.v1.UserService.Logout:output_type -> rootstock.v1.LogoutResponse
-	53,  // 99: rootstock.v1.UserService.RegisterResearcher:output_type -> rootstock.v1.RegisterResearcherResponse
-	55,  // 100: rootstock.v1.UserService.VerifyEmail:output_type -> rootstock.v1.VerifyEmailResponse
-	57,  // 101: rootstock.v1.UserService.UpdateUserType:output_type -> rootstock.v1.UpdateUserTypeResponse
-	59,  // 102: rootstock.v1.ScitizenService.RegisterScitizen:output_type -> rootstock.v1.RegisterScitizenResponse
-	65,  // 103: rootstock.v1.ScitizenService.GetDashboard:output_type -> rootstock.v1.GetDashboardResponse
-	68,  // 104: rootstock.v1.ScitizenService.BrowsePublishedCampaigns:output_type -> rootstock.v1.BrowsePublishedCampaignsResponse
-	70,  // 105: rootstock.v1.ScitizenService.GetCampaignDetail:output_type -> rootstock.v1.GetCampaignDetailResponse
-	72,  // 106: rootstock.v1.ScitizenService.SearchCampaigns:output_type -> rootstock.v1.SearchCampaignsResponse
-	75,  // 107: rootstock.v1.ScitizenService.EnrollDevice:output_type -> rootstock.v1.EnrollDeviceResponse
-	77,  // 108: rootstock.v1.ScitizenService.WithdrawEnrollment:output_type -> rootstock.v1.WithdrawEnrollmentResponse
-	80,  // 109: rootstock.v1.ScitizenService.GetDevices:output_type -> rootstock.v1.GetDevicesResponse
-	83,  // 110: rootstock.v1.ScitizenService.GetDeviceDetail:output_type -> rootstock.v1.GetDeviceDetailResponse
-	86,  // 111: rootstock.v1.ScitizenService.GetNotifications:output_type -> rootstock.v1.GetNotificationsResponse
-	89,  // 112: rootstock.v1.ScitizenService.GetContributions:output_type -> rootstock.v1.GetContributionsResponse
-	62,  // 113: rootstock.v1.ScitizenService.GetOnboardingState:output_type -> rootstock.v1.GetOnboardingStateResponse
-	92,  // 114: rootstock.v1.ScitizenService.GetLeaderboard:output_type -> rootstock.v1.GetLeaderboardResponse
-	94,  // 115: rootstock.v1.NotificationService.ListNotifications:output_type -> rootstock.v1.ListNotificationsResponse
-	96,  // 116: rootstock.v1.NotificationService.MarkRead:output_type -> rootstock.v1.MarkReadResponse
-	99,  // 117: rootstock.v1.NotificationService.GetPreferences:output_type -> rootstock.v1.GetPreferencesResponse
-	101, // 118: rootstock.v1.NotificationService.UpdatePreferences:output_type -> rootstock.v1.UpdatePreferencesResponse
-	103, // 119: rootstock.v1.AdminService.SuspendByClass:output_type -> rootstock.v1.SuspendByClassResponse
-	79,  // [79:120] is the sub-list for method output_type
-	38,  // [38:79] is the sub-list for method input_type
-	38,  // [38:38] is the sub-list for extension type_name
-	38,  // [38:38] is the sub-list for extension extendee
-	0,   // [0:38] is the sub-list for field type_name
+	160, // 9: rootstock.v1.ExportedReadingProto.values:type_name -> rootstock.v1.ExportedReadingProto.ValuesEntry
+	161, // 10: rootstock.v1.ExportedReadingProto.conversions:type_name -> rootstock.v1.ExportedReadingProto.ConversionsEntry
+	18,  // 11: rootstock.v1.ExportCampaignDataResponse.readings:type_name -> rootstock.v1.ExportedReadingProto
+	32,  // 12: rootstock.v1.GetContributionResponse.badges:type_name -> rootstock.v1.BadgeProto
+	35,  // 13: rootstock.v1.GetDeviceResponse.device:type_name -> rootstock.v1.DeviceProto
+	44,  // 14: rootstock.v1.SendDeviceCommandResponse.commands:type_name -> rootstock.v1.DeviceCommandProto
+	44,  // 15: rootstock.v1.ListDeviceCommandsResponse.commands:type_name -> rootstock.v1.DeviceCommandProto
+	49,  // 16: rootstock.v1.ShadowStateProto.enabled_sensors:type_name -> rootstock.v1.StringListProto
+	49,  // 17: rootstock.v1.ShadowStateProto.active_campaigns:type_name -> rootstock.v1.StringListProto
+	50,  // 18: rootstock.v1.DeviceShadowProto.desired:type_name -> rootstock.v1.ShadowStateProto
+	50,  // 19: rootstock.v1.DeviceShadowProto.reported:type_name -> rootstock.v1.ShadowStateProto
+	50,  // 20: rootstock.v1.DeviceShadowProto.delta:type_name -> rootstock.v1.ShadowStateProto
+	51,  // 21: rootstock.v1.GetDeviceShadowResponse.shadow:type_name -> rootstock.v1.DeviceShadowProto
+	49,  // 22: rootstock.v1.UpdateDeviceShadowRequest.enabled_sensors:type_name -> rootstock.v1.StringListProto
+	51,  // 23: rootstock.v1.UpdateDeviceShadowResponse.shadow:type_name -> rootstock.v1.DeviceShadowProto
+	56,  // 24: rootstock.v1.RegisterUserResponse.user:type_name -> rootstock.v1.UserProto
+	56,  // 25: rootstock.v1.GetMeResponse.user:type_name -> rootstock.v1.UserProto
+	56,  // 26: rootstock.v1.LoginResponse.user:type_name -> rootstock.v1.UserProto
+	56,  // 27: rootstock.v1.UpdateUserTypeResponse.user:type_name -> rootstock.v1.UserProto
+	73,  // 28: rootstock.v1.GetOnboardingStateResponse.state:type_name -> rootstock.v1.OnboardingStateProto
+	32,  // 29: rootstock.v1.GetDashboardResponse.badges:type_name -> rootstock.v1.BadgeProto
+	76,  // 30: rootstock.v1.GetDashboardResponse.enrollments:type_name -> rootstock.v1.EnrollmentProto
+	80,  // 31: rootstock.v1.BrowsePublishedCampaignsResponse.campaigns:type_name -> rootstock.v1.CampaignSummaryProto
+	2,   // 32: rootstock.v1.GetCampaignDetailResponse.parameters:type_name -> rootstock.v1.ParameterProto
+	3,   // 33: rootstock.v1.GetCampaignDetailResponse.regions:type_name -> rootstock.v1.RegionProto
+	4,   // 34: rootstock.v1.GetCampaignDetailResponse.eligibility:type_name -> rootstock.v1.EligibilityProto
+	80,  // 35: rootstock.v1.SearchCampaignsResponse.campaigns:type_name -> rootstock.v1.CampaignSummaryProto
+	86,  // 36: rootstock.v1.EnrollDeviceRequest.consent:type_name -> rootstock.v1.ConsentProto
+	97,  // 37: rootstock.v1.DeviceSummaryProto.health:type_name -> rootstock.v1.DeviceHealthProto
+	91,  // 38: rootstock.v1.GetDevicesResponse.devices:type_name -> rootstock.v1.DeviceSummaryProto
+	35,  // 39: rootstock.v1.GetDeviceDetailResponse.device:type_name -> rootstock.v1.DeviceProto
+	76,  // 40: rootstock.v1.GetDeviceDetailResponse.enrollments:type_name -> rootstock.v1.EnrollmentProto
+	94,  // 41: rootstock.v1.GetDeviceDetailResponse.connection_history:type_name -> rootstock.v1.ConnectionEventProto
+	98,  // 42: rootstock.v1.GetDeviceDetailResponse.sessions:type_name -> rootstock.v1.DeviceSessionProto
+	97,  // 43: rootstock.v1.GetDeviceDetailResponse.health:type_name -> rootstock.v1.DeviceHealthProto
+	162, // 44: rootstock.v1.RegisterDeviceRequest.sensor_units:type_name -> rootstock.v1.RegisterDeviceRequest.SensorUnitsEntry
+	99,  // 45: rootstock.v1.RegisterDeviceResponse.enrollment_code:type_name -> rootstock.v1.EnrollmentCodeProto
+	99,  // 46: rootstock.v1.ListEnrollmentCodesResponse.codes:type_name -> rootstock.v1.EnrollmentCodeProto
+	99,  // 47: rootstock.v1.RegenerateEnrollmentCodeResponse.enrollment_code:type_name -> rootstock.v1.EnrollmentCodeProto
+	163, // 48: rootstock.v1.SetDeviceSensorUnitsRequest.sensor_units:type_name -> rootstock.v1.SetDeviceSensorUnitsRequest.SensorUnitsEntry
+	164, // 49: rootstock.v1.SetDeviceSensorUnitsResponse.effective_units:type_name -> rootstock.v1.SetDeviceSensorUnitsResponse.EffectiveUnitsEntry
+	165, // 50: rootstock.v1.LinkStationRequest.parameter_map:type_name -> rootstock.v1.LinkStationRequest.ParameterMapEntry
+	114, // 51: rootstock.v1.GetNotificationsResponse.notifications:type_name -> rootstock.v1.NotificationProto
+	117, // 52: rootstock.v1.GetContributionsResponse.histories:type_name -> rootstock.v1.ReadingHistoryProto
+	32,  // 53: rootstock.v1.GetContributionsResponse.badges:type_name -> rootstock.v1.BadgeProto
+	120, // 54: rootstock.v1.GetLeaderboardResponse.entries:type_name -> rootstock.v1.LeaderboardEntryProto
+	120, // 55: rootstock.v1.GetLeaderboardResponse.requester:type_name -> rootstock.v1.LeaderboardEntryProto
+	114, // 56: rootstock.v1.ListNotificationsResponse.notifications:type_name -> rootstock.v1.NotificationProto
+	127, // 57: rootstock.v1.GetPreferencesResponse.preferences:type_name -> rootstock.v1.NotificationPreferenceProto
+	127, // 58: rootstock.v1.UpdatePreferencesRequest.preferences:type_name -> rootstock.v1.NotificationPreferenceProto
+	134, // 59: rootstock.v1.ListDeadLettersResponse.dead_letters:type_name -> rootstock.v1.DeadLetterProto
+	134, // 60: rootstock.v1.GetDeadLetterResponse.dead_letter:type_name -> rootstock.v1.DeadLetterProto
+	134, // 61: rootstock.v1.ReplayDeadLetterResponse.dead_letter:type_name -> rootstock.v1.DeadLetterProto
+	166, // 62: rootstock.v1.SetDeviceClassUnitsRequest.sensor_units:type_name -> rootstock.v1.SetDeviceClassUnitsRequest.SensorUnitsEntry
+	167, // 63: rootstock.v1.SetDeviceClassUnitsResponse.sensor_units:type_name -> rootstock.v1.SetDeviceClassUnitsResponse.SensorUnitsEntry
+	145, // 64: rootstock.v1.ListRateLimitFlaggedDevicesResponse.devices:type_name -> rootstock.v1.RateLimitFlagProto
+	150, // 65: rootstock.v1.CreateFirmwareReleaseResponse.release:type_name -> rootstock.v1.FirmwareReleaseProto
+	150, // 66: rootstock.v1.ListFirmwareReleasesResponse.releases:type_name -> rootstock.v1.FirmwareReleaseProto
+	150, // 67: rootstock.v1.SetFirmwareRolloutResponse.release:type_name -> rootstock.v1.FirmwareReleaseProto
+	151, // 68: rootstock.v1.ListFirmwareInstallsResponse.installs:type_name -> rootstock.v1.FirmwareInstallProto
+	19,  // 69: rootstock.v1.ExportedReadingProto.ConversionsEntry.value:type_name -> rootstock.v1.ValueConversionProto
+	0,   // 70: rootstock.v1.HealthService.Check:input_type -> rootstock.v1.CheckRequest
+	6,   // 71: rootstock.v1.CampaignService.CreateCampaign:input_type -> rootstock.v1.CreateCampaignRequest
+	8,   // 72: rootstock.v1.CampaignService.PublishCampaign:input_type -> rootstock.v1.PublishCampaignRequest
+	10,  // 73: rootstock.v1.CampaignService.ListCampaigns:input_type -> rootstock.v1.ListCampaignsRequest
+	12,  // 74: rootstock.v1.CampaignService.GetCampaignDashboard:input_type -> rootstock.v1.GetCampaignDashboardRequest
+	20,  // 75: rootstock.v1.CampaignService.ExportCampaignData:input_type -> rootstock.v1.ExportCampaignDataRequest
+	22,  // 76: rootstock.v1.OrgService.CreateOrg:input_type -> rootstock.v1.CreateOrgRequest
+	24,  // 77: rootstock.v1.OrgService.NestOrg:input_type -> rootstock.v1.NestOrgRequest
+	26,  // 78: rootstock.v1.OrgService.DefineRole:input_type -> rootstock.v1.DefineRoleRequest
+	28,  // 79: rootstock.v1.OrgService.AssignRole:input_type -> rootstock.v1.AssignRoleRequest
+	30,  // 80: rootstock.v1.OrgService.InviteUser:input_type -> rootstock.v1.InviteUserRequest
+	33,  // 81: rootstock.v1.ScoreService.GetContribution:input_type -> rootstock.v1.GetContributionRequest
+	36,  // 82: rootstock.v1.DeviceService.GetDevice:input_type -> rootstock.v1.GetDeviceRequest
+	38,  // 83: rootstock.v1.DeviceService.RevokeDevice:input_type -> rootstock.v1.RevokeDeviceRequest
+	40,  // 84: rootstock.v1.DeviceService.ReinstateDevice:input_type -> rootstock.v1.ReinstateDeviceRequest
+	42,  // 85: rootstock.v1.DeviceService.EnrollInCampaign:input_type -> rootstock.v1.EnrollInCampaignRequest
+	45,  // 86: rootstock.v1.DeviceService.SendDeviceCommand:input_type -> rootstock.v1.SendDeviceCommandRequest
+	47,  // 87: rootstock.v1.DeviceService.ListDeviceCommands:input_type -> rootstock.v1.ListDeviceCommandsRequest
+	52,  // 88: rootstock.v1.DeviceService.GetDeviceShadow:input_type -> rootstock.v1.GetDeviceShadowRequest
+	54,  // 89: rootstock.v1.DeviceService.UpdateDeviceShadow:input_type -> rootstock.v1.UpdateDeviceShadowRequest
+	57,  // 90: rootstock.v1.UserService.RegisterUser:input_type -> rootstock.v1.RegisterUserRequest
+	59,  // 91: rootstock.v1.UserService.GetMe:input_type -> rootstock.v1.GetMeRequest
+	61,  // 92: rootstock.v1.UserService.Login:input_type -> rootstock.v1.LoginRequest
+	63,  // 93: rootstock.v1.UserService.Logout:input_type -> rootstock.v1.LogoutRequest
+	65,  // 94: rootstock.v1.UserService.RegisterResearcher:input_type -> rootstock.v1.RegisterResearcherRequest
+	67,  // 95: rootstock.v1.UserService.VerifyEmail:input_type -> rootstock.v1.VerifyEmailRequest
+	69,  // 96: rootstock.v1.UserService.UpdateUserType:input_type -> rootstock.v1.UpdateUserTypeRequest
+	71,  // 97: rootstock.v1.ScitizenService.RegisterScitizen:input_type -> rootstock.v1.RegisterScitizenRequest
+	77,  // 98: rootstock.v1.ScitizenService.GetDashboard:input_type -> rootstock.v1.GetDashboardRequest
+	79,  // 99: rootstock.v1.ScitizenService.BrowsePublishedCampaigns:input_type -> rootstock.v1.BrowsePublishedCampaignsRequest
+	82,  // 100: rootstock.v1.ScitizenService.GetCampaignDetail:input_type -> rootstock.v1.GetCampaignDetailRequest
+	84,  // 101: rootstock.v1.ScitizenService.SearchCampaigns:input_type -> rootstock.v1.SearchCampaignsRequest
+	87,  // 102: rootstock.v1.ScitizenService.EnrollDevice:input_type -> rootstock.v1.EnrollDeviceRequest
+	89,  // 103: rootstock.v1.ScitizenService.WithdrawEnrollment:input_type -> rootstock.v1.WithdrawEnrollmentRequest
+	92,  // 104: rootstock.v1.ScitizenService.GetDevices:input_type -> rootstock.v1.GetDevicesRequest
+	95,  // 105: rootstock.v1.ScitizenService.GetDeviceDetail:input_type -> rootstock.v1.GetDeviceDetailRequest
+	115, // 106: rootstock.v1.ScitizenService.GetNotifications:input_type -> rootstock.v1.GetNotificationsRequest
+	118, // 107: rootstock.v1.ScitizenService.GetContributions:input_type -> rootstock.v1.GetContributionsRequest
+	74,  // 108: rootstock.v1.ScitizenService.GetOnboardingState:input_type -> rootstock.v1.GetOnboardingStateRequest
+	121, // 109: rootstock.v1.ScitizenService.GetLeaderboard:input_type -> rootstock.v1.GetLeaderboardRequest
+	100, // 110: rootstock.v1.ScitizenService.RegisterDevice:input_type -> rootstock.v1.RegisterDeviceRequest
+	102, // 111: rootstock.v1.ScitizenService.ListEnrollmentCodes:input_type -> rootstock.v1.ListEnrollmentCodesRequest
+	104, // 112: rootstock.v1.ScitizenService.RegenerateEnrollmentCode:input_type -> rootstock.v1.RegenerateEnrollmentCodeRequest
+	106, // 113: rootstock.v1.ScitizenService.ExpireEnrollmentCode:input_type -> rootstock.v1.ExpireEnrollmentCodeRequest
+	108, // 114: rootstock.v1.ScitizenService.SetDeviceSensorUnits:input_type -> rootstock.v1.SetDeviceSensorUnitsRequest
+	110, // 115: rootstock.v1.ScitizenService.LinkStation:input_type -> rootstock.v1.LinkStationRequest
+	112, // 116: rootstock.v1.ScitizenService.UnlinkStation:input_type -> rootstock.v1.UnlinkStationRequest
+	123, // 117: rootstock.v1.NotificationService.ListNotifications:input_type -> rootstock.v1.ListNotificationsRequest
+	125, // 118: rootstock.v1.NotificationService.MarkRead:input_type -> rootstock.v1.MarkReadRequest
+	128, // 119: rootstock.v1.NotificationService.GetPreferences:input_type -> rootstock.v1.GetPreferencesRequest
+	130, // 120: rootstock.v1.NotificationService.UpdatePreferences:input_type -> rootstock.v1.UpdatePreferencesRequest
+	132, // 121: rootstock.v1.AdminService.SuspendByClass:input_type -> rootstock.v1.SuspendByClassRequest
+	135, // 122: rootstock.v1.AdminService.ListDeadLetters:input_type -> rootstock.v1.ListDeadLettersRequest
+	137, // 123: rootstock.v1.AdminService.GetDeadLetter:input_type -> rootstock.v1.GetDeadLetterRequest
+	139, // 124: rootstock.v1.AdminService.ReplayDeadLetter:input_type -> rootstock.v1.ReplayDeadLetterRequest
+	141, // 125: rootstock.v1.AdminService.PurgeDeadLetters:input_type -> rootstock.v1.PurgeDeadLettersRequest
+	143, // 126: rootstock.v1.AdminService.SetDeviceClassUnits:input_type -> rootstock.v1.SetDeviceClassUnitsRequest
+	146, // 127: rootstock.v1.AdminService.ListRateLimitFlaggedDevices:input_type -> rootstock.v1.ListRateLimitFlaggedDevicesRequest
+	148, // 128: rootstock.v1.AdminService.ClearRateLimitFlag:input_type -> rootstock.v1.ClearRateLimitFlagRequest
+	152, // 129: rootstock.v1.AdminService.CreateFirmwareRelease:input_type -> rootstock.v1.CreateFirmwareReleaseRequest
+	154, // 130: rootstock.v1.AdminService.ListFirmwareReleases:input_type -> rootstock.v1.ListFirmwareReleasesRequest
+	156, // 131: rootstock.v1.AdminService.SetFirmwareRollout:input_type -> rootstock.v1.SetFirmwareRolloutRequest
+	158, // 132: rootstock.v1.AdminService.ListFirmwareInstalls:input_type -> rootstock.v1.ListFirmwareInstallsRequest
+	1,   // 133: rootstock.v1.HealthService.Check:output_type -> rootstock.v1.CheckResponse
+	7,   // 134: rootstock.v1.CampaignService.CreateCampaign:output_type -> rootstock.v1.CreateCampaignResponse
+	9,   // 135: rootstock.v1.CampaignService.PublishCampaign:output_type -> rootstock.v1.PublishCampaignResponse
+	11,  // 136: rootstock.v1.CampaignService.ListCampaigns:output_type -> rootstock.v1.ListCampaignsResponse
+	17,  // 137: rootstock.v1.CampaignService.GetCampaignDashboard:output_type -> rootstock.v1.GetCampaignDashboardResponse
+	21,  // 138: rootstock.v1.CampaignService.ExportCampaignData:output_type -> rootstock.v1.ExportCampaignDataResponse
+	23,  // 139: rootstock.v1.OrgService.CreateOrg:output_type -> rootstock.v1.CreateOrgResponse
+	25,  // 140: rootstock.v1.OrgService.NestOrg:output_type -> rootstock.v1.NestOrgResponse
+	27,  // 141: rootstock.v1.OrgService.DefineRole:output_type -> rootstock.v1.DefineRoleResponse
+	29,  // 142: rootstock.v1.OrgService.AssignRole:output_type -> rootstock.v1.AssignRoleResponse
+	31,  // 143: rootstock.v1.OrgService.InviteUser:output_type -> rootstock.v1.InviteUserResponse
+	34,  // 144: rootstock.v1.ScoreService.GetContribution:output_type -> rootstock.v1.GetContributionResponse
+	37,  // 145: rootstock.v1.DeviceService.GetDevice:output_type -> rootstock.v1.GetDeviceResponse
+	39,  // 146: rootstock.v1.DeviceService.RevokeDevice:output_type -> rootstock.v1.RevokeDeviceResponse
+	41,  // 147: rootstock.v1.DeviceService.ReinstateDevice:output_type -> rootstock.v1.ReinstateDeviceResponse
+	43,  // 148: rootstock.v1.DeviceService.EnrollInCampaign:output_type -> rootstock.v1.EnrollInCampaignResponse
+	46,  // 149: rootstock.v1.DeviceService.SendDeviceCommand:output_type -> rootstock.v1.SendDeviceCommandResponse
+	48,  // 150: rootstock.v1.DeviceService.ListDeviceCommands:output_type -> rootstock.v1.ListDeviceCommandsResponse
+	53,  // 151: rootstock.v1.DeviceService.GetDeviceShadow:output_type -> rootstock.v1.GetDeviceShadowResponse
+	55,  // 152: rootstock.v1.DeviceService.UpdateDeviceShadow:output_type -> rootstock.v1.UpdateDeviceShadowResponse
+	58,  // 153: rootstock.v1.UserService.RegisterUser:output_type -> rootstock.v1.RegisterUserResponse
+	60,  // 154: rootstock.v1.UserService.GetMe:output_type -> rootstock.v1.GetMeResponse
+	62,  // 155: rootstock.v1.UserService.Login:output_type -> rootstock.v1.LoginResponse
+	64,  // 156: rootstock.v1.UserService.Logout:output_type -> rootstock.v1.LogoutResponse
+	66,  // 157: rootstock.v1.UserService.RegisterResearcher:output_type -> rootstock.v1.RegisterResearcherResponse
+	68,  // 158: rootstock.v1.UserService.VerifyEmail:output_type -> rootstock.v1.VerifyEmailResponse
+	70,  // 159: rootstock.v1.UserService.UpdateUserType:output_type -> rootstock.v1.UpdateUserTypeResponse
+	72,  // 160: rootstock.v1.ScitizenService.RegisterScitizen:output_type -> rootstock.v1.RegisterScitizenResponse
+	78,  // 161: rootstock.v1.ScitizenService.GetDashboard:output_type -> rootstock.v1.GetDashboardResponse
+	81,  // 162: rootstock.v1.ScitizenService.BrowsePublishedCampaigns:output_type -> rootstock.v1.BrowsePublishedCampaignsResponse
+	83,  // 163: rootstock.v1.ScitizenService.GetCampaignDetail:output_type -> rootstock.v1.GetCampaignDetailResponse
+	85,  // 164: rootstock.v1.ScitizenService.SearchCampaigns:output_type -> rootstock.v1.SearchCampaignsResponse
+	88,  // 165: rootstock.v1.ScitizenService.EnrollDevice:output_type -> rootstock.v1.EnrollDeviceResponse
+	90,  // 166: rootstock.v1.ScitizenService.WithdrawEnrollment:output_type -> rootstock.v1.WithdrawEnrollmentResponse
+	93,  // 167: rootstock.v1.ScitizenService.GetDevices:output_type -> rootstock.v1.GetDevicesResponse
+	96,  // 168: rootstock.v1.ScitizenService.GetDeviceDetail:output_type -> rootstock.v1.GetDeviceDetailResponse
+	116, // 169: rootstock.v1.ScitizenService.GetNotifications:output_type -> rootstock.v1.GetNotificationsResponse
+	119, // 170: rootstock.v1.ScitizenService.GetContributions:output_type -> rootstock.v1.GetContributionsResponse
+	75,  // 171: rootstock.v1.ScitizenService.GetOnboardingState:output_type -> rootstock.v1.GetOnboardingStateResponse
+	122, // 172: rootstock.v1.ScitizenService.GetLeaderboard:output_type -> rootstock.v1.GetLeaderboardResponse
+	101, // 173: rootstock.v1.ScitizenService.RegisterDevice:output_type -> rootstock.v1.RegisterDeviceResponse
+	103, // 174: rootstock.v1.ScitizenService.ListEnrollmentCodes:output_type -> rootstock.v1.ListEnrollmentCodesResponse
+	105, // 175: rootstock.v1.ScitizenService.RegenerateEnrollmentCode:output_type -> rootstock.v1.RegenerateEnrollmentCodeResponse
+	107, // 176: rootstock.v1.ScitizenService.ExpireEnrollmentCode:output_type -> rootstock.v1.ExpireEnrollmentCodeResponse
+	109, // 177: rootstock.v1.ScitizenService.SetDeviceSensorUnits:output_type -> rootstock.v1.SetDeviceSensorUnitsResponse
+	111, // 178: rootstock.v1.ScitizenService.LinkStation:output_type -> rootstock.v1.LinkStationResponse
+	113, // 179: rootstock.v1.ScitizenService.UnlinkStation:output_type -> rootstock.v1.UnlinkStationResponse
+	124, // 180: rootstock.v1.NotificationService.ListNotifications:output_type -> rootstock.v1.ListNotificationsResponse
+	126, // 181: rootstock.v1.NotificationService.MarkRead:output_type -> rootstock.v1.MarkReadResponse
+	129, // 182: rootstock.v1.NotificationService.GetPreferences:output_type -> rootstock.v1.GetPreferencesResponse
+	131, // 183: rootstock.v1.NotificationService.UpdatePreferences:output_type -> rootstock.v1.UpdatePreferencesResponse
+	133, // 184: rootstock.v1.AdminService.SuspendByClass:output_type -> rootstock.v1.SuspendByClassResponse
+	136, // 185: rootstock.v1.AdminService.ListDeadLetters:output_type -> rootstock.v1.ListDeadLettersResponse
+	138, // 186: rootstock.v1.AdminService.GetDeadLetter:output_type -> rootstock.v1.GetDeadLetterResponse
+	140, // 187: rootstock.v1.AdminService.ReplayDeadLetter:output_type -> rootstock.v1.ReplayDeadLetterResponse
+	142, // 188: rootstock.v1.AdminService.PurgeDeadLetters:output_type -> rootstock.v1.PurgeDeadLettersResponse
+	144, // 189: rootstock.v1.AdminService.SetDeviceClassUnits:output_type -> rootstock.v1.SetDeviceClassUnitsResponse
+	147, // 190: rootstock.v1.AdminService.ListRateLimitFlaggedDevices:output_type -> rootstock.v1.ListRateLimitFlaggedDevicesResponse
+	149, // 191: rootstock.v1.AdminService.ClearRateLimitFlag:output_type -> rootstock.v1.ClearRateLimitFlagResponse
+	153, // 192: rootstock.v1.AdminService.CreateFirmwareRelease:output_type -> rootstock.v1.CreateFirmwareReleaseResponse
+	155, // 193: rootstock.v1.AdminService.ListFirmwareReleases:output_type -> rootstock.v1.ListFirmwareReleasesResponse
+	157, // 194: rootstock.v1.AdminService.SetFirmwareRollout:output_type -> rootstock.v1.SetFirmwareRolloutResponse
+	159, // 195: rootstock.v1.AdminService.ListFirmwareInstalls:output_type -> rootstock.v1.ListFirmwareInstallsResponse
+	133, // [133:196] is the sub-list for method output_type
+	70,  // [70:133] is the sub-list for method input_type
+	70,  // [70:70] is the sub-list for extension type_name
+	70,  // [70:70] is the sub-list for extension extendee
+	0,   // [0:70] is the sub-list for field type_name
 }
 
 func init() { file_rootstock_v1_rootstock_proto_init() }
@@ -6777,25 +10946,38 @@ func file_rootstock_v1_rootstock_proto_init() {
 	file_rootstock_v1_rootstock_proto_msgTypes[5].OneofWrappers = []any{}
 	file_rootstock_v1_rootstock_proto_msgTypes[6].OneofWrappers = []any{}
 	file_rootstock_v1_rootstock_proto_msgTypes[10].OneofWrappers = []any{}
+	file_rootstock_v1_rootstock_proto_msgTypes[14].OneofWrappers = []any{}
 	file_rootstock_v1_rootstock_proto_msgTypes[18].OneofWrappers = []any{}
-	file_rootstock_v1_rootstock_proto_msgTypes[34].OneofWrappers = []any{}
-	file_rootstock_v1_rootstock_proto_msgTypes[66].OneofWrappers = []any{}
-	file_rootstock_v1_rootstock_proto_msgTypes[67].OneofWrappers = []any{}
-	file_rootstock_v1_rootstock_proto_msgTypes[70].OneofWrappers = []any{}
-	file_rootstock_v1_rootstock_proto_msgTypes[78].OneofWrappers = []any{}
-	file_rootstock_v1_rootstock_proto_msgTypes[81].OneofWrappers = []any{}
-	file_rootstock_v1_rootstock_proto_msgTypes[84].OneofWrappers = []any{}
-	file_rootstock_v1_rootstock_proto_msgTypes[85].OneofWrappers = []any{}
+	file_rootstock_v1_rootstock_proto_msgTypes[35].OneofWrappers = []any{}
+	file_rootstock_v1_rootstock_proto_msgTypes[44].OneofWrappers = []any{}
+	file_rootstock_v1_rootstock_proto_msgTypes[45].OneofWrappers = []any{}
+	file_rootstock_v1_rootstock_proto_msgTypes[50].OneofWrappers = []any{}
+	file_rootstock_v1_rootstock_proto_msgTypes[51].OneofWrappers = []any{}
+	file_rootstock_v1_rootstock_proto_msgTypes[54].OneofWrappers = []any{}
+	file_rootstock_v1_rootstock_proto_msgTypes[79].OneofWrappers = []any{}
+	file_rootstock_v1_rootstock_proto_msgTypes[80].OneofWrappers = []any{}
+	file_rootstock_v1_rootstock_proto_msgTypes[83].OneofWrappers = []any{}
 	file_rootstock_v1_rootstock_proto_msgTypes[91].OneofWrappers = []any{}
-	file_rootstock_v1_rootstock_proto_msgTypes[92].OneofWrappers = []any{}
-	file_rootstock_v1_rootstock_proto_msgTypes[93].OneofWrappers = []any{}
+	file_rootstock_v1_rootstock_proto_msgTypes[94].OneofWrappers = []any{}
+	file_rootstock_v1_rootstock_proto_msgTypes[96].OneofWrappers = []any{}
+	file_rootstock_v1_rootstock_proto_msgTypes[97].OneofWrappers = []any{}
+	file_rootstock_v1_rootstock_proto_msgTypes[98].OneofWrappers = []any{}
+	file_rootstock_v1_rootstock_proto_msgTypes[114].OneofWrappers = []any{}
+	file_rootstock_v1_rootstock_proto_msgTypes[115].OneofWrappers = []any{}
+	file_rootstock_v1_rootstock_proto_msgTypes[121].OneofWrappers = []any{}
+	file_rootstock_v1_rootstock_proto_msgTypes[122].OneofWrappers = []any{}
+	file_rootstock_v1_rootstock_proto_msgTypes[123].OneofWrappers = []any{}
+	file_rootstock_v1_rootstock_proto_msgTypes[134].OneofWrappers = []any{}
+	file_rootstock_v1_rootstock_proto_msgTypes[150].OneofWrappers = []any{}
+	file_rootstock_v1_rootstock_proto_msgTypes[151].OneofWrappers = []any{}
+	file_rootstock_v1_rootstock_proto_msgTypes[152].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rootstock_v1_rootstock_proto_rawDesc), len(file_rootstock_v1_rootstock_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   105,
+			NumMessages:   168,
 			NumExtensions: 0,
 			NumServices:   9,
 		},
@@ -90,6 +90,18 @@ const (
 	// DeviceServiceEnrollInCampaignProcedure is the fully-qualified name of the DeviceService's
 	// EnrollInCampaign RPC.
 	DeviceServiceEnrollInCampaignProcedure = "/rootstock.v1.DeviceService/EnrollInCampaign"
+	// DeviceServiceSendDeviceCommandProcedure is the fully-qualified name of the DeviceService's
+	// SendDeviceCommand RPC.
+	DeviceServiceSendDeviceCommandProcedure = "/rootstock.v1.DeviceService/SendDeviceCommand"
+	// DeviceServiceListDeviceCommandsProcedure is the fully-qualified name of the DeviceService's
+	// ListDeviceCommands RPC.
+	DeviceServiceListDeviceCommandsProcedure = "/rootstock.v1.DeviceService/ListDeviceCommands"
+	// DeviceServiceGetDeviceShadowProcedure is the fully-qualified name of the DeviceService's
+	// GetDeviceShadow RPC.
+	DeviceServiceGetDeviceShadowProcedure = "/rootstock.v1.DeviceService/GetDeviceShadow"
+	// DeviceServiceUpdateDeviceShadowProcedure is the fully-qualified name of the DeviceService's
+	// UpdateDeviceShadow RPC.
+	DeviceServiceUpdateDeviceShadowProcedure = "/rootstock.v1.DeviceService/UpdateDeviceShadow"
 	// UserServiceRegisterUserProcedure is the fully-qualified name of the UserService's RegisterUser
 	// RPC.
 	UserServiceRegisterUserProcedure = "/rootstock.v1.UserService/RegisterUser"
@@ -146,6 +158,27 @@ const (
 	// ScitizenServiceGetLeaderboardProcedure is the fully-qualified name of the ScitizenService's
 	// GetLeaderboard RPC.
 	ScitizenServiceGetLeaderboardProcedure = "/rootstock.v1.ScitizenService/GetLeaderboard"
+	// ScitizenServiceRegisterDeviceProcedure is the fully-qualified name of the ScitizenService's
+	// RegisterDevice RPC.
+	ScitizenServiceRegisterDeviceProcedure = "/rootstock.v1.ScitizenService/RegisterDevice"
+	// ScitizenServiceListEnrollmentCodesProcedure is the fully-qualified name of the ScitizenService's
+	// ListEnrollmentCodes RPC.
+	ScitizenServiceListEnrollmentCodesProcedure = "/rootstock.v1.ScitizenService/ListEnrollmentCodes"
+	// ScitizenServiceRegenerateEnrollmentCodeProcedure is the fully-qualified name of the
+	// ScitizenService's RegenerateEnrollmentCode RPC.
+	ScitizenServiceRegenerateEnrollmentCodeProcedure = "/rootstock.v1.ScitizenService/RegenerateEnrollmentCode"
+	// ScitizenServiceExpireEnrollmentCodeProcedure is the fully-qualified name of the ScitizenService's
+	// ExpireEnrollmentCode RPC.
+	ScitizenServiceExpireEnrollmentCodeProcedure = "/rootstock.v1.ScitizenService/ExpireEnrollmentCode"
+	// ScitizenServiceSetDeviceSensorUnitsProcedure is the fully-qualified name of the ScitizenService's
+	// SetDeviceSensorUnits RPC.
+	ScitizenServiceSetDeviceSensorUnitsProcedure = "/rootstock.v1.ScitizenService/SetDeviceSensorUnits"
+	// ScitizenServiceLinkStationProcedure is the fully-qualified name of the ScitizenService's
+	// LinkStation RPC.
+	ScitizenServiceLinkStationProcedure = "/rootstock.v1.ScitizenService/LinkStation"
+	// ScitizenServiceUnlinkStationProcedure is the fully-qualified name of the ScitizenService's
+	// UnlinkStation RPC.
+	ScitizenServiceUnlinkStationProcedure = "/rootstock.v1.ScitizenService/UnlinkStation"
 	// NotificationServiceListNotificationsProcedure is the fully-qualified name of the
 	// NotificationService's ListNotifications RPC.
 	NotificationServiceListNotificationsProcedure = "/rootstock.v1.NotificationService/ListNotifications"
@@ -161,6 +194,39 @@ const (
 	// AdminServiceSuspendByClassProcedure is the fully-qualified name of the AdminService's
 	// SuspendByClass RPC.
 	AdminServiceSuspendByClassProcedure = "/rootstock.v1.AdminService/SuspendByClass"
+	// AdminServiceListDeadLettersProcedure is the fully-qualified name of the AdminService's
+	// ListDeadLetters RPC.
+	AdminServiceListDeadLettersProcedure = "/rootstock.v1.AdminService/ListDeadLetters"
+	// AdminServiceGetDeadLetterProcedure is the fully-qualified name of the AdminService's
+	// GetDeadLetter RPC.
+	AdminServiceGetDeadLetterProcedure = "/rootstock.v1.AdminService/GetDeadLetter"
+	// AdminServiceReplayDeadLetterProcedure is the fully-qualified name of the AdminService's
+	// ReplayDeadLetter RPC.
+	AdminServiceReplayDeadLetterProcedure = "/rootstock.v1.AdminService/ReplayDeadLetter"
+	// AdminServicePurgeDeadLettersProcedure is the fully-qualified name of the AdminService's
+	// PurgeDeadLetters RPC.
+	AdminServicePurgeDeadLettersProcedure = "/rootstock.v1.AdminService/PurgeDeadLetters"
+	// AdminServiceSetDeviceClassUnitsProcedure is the fully-qualified name of the AdminService's
+	// SetDeviceClassUnits RPC.
+	AdminServiceSetDeviceClassUnitsProcedure = "/rootstock.v1.AdminService/SetDeviceClassUnits"
+	// AdminServiceListRateLimitFlaggedDevicesProcedure is the fully-qualified name of the
+	// AdminService's ListRateLimitFlaggedDevices RPC.
+	AdminServiceListRateLimitFlaggedDevicesProcedure = "/rootstock.v1.AdminService/ListRateLimitFlaggedDevices"
+	// AdminServiceClearRateLimitFlagProcedure is the fully-qualified name of the AdminService's
+	// ClearRateLimitFlag RPC.
+	AdminServiceClearRateLimitFlagProcedure = "/rootstock.v1.AdminService/ClearRateLimitFlag"
+	// AdminServiceCreateFirmwareReleaseProcedure is the fully-qualified name of the AdminService's
+	// CreateFirmwareRelease RPC.
+	AdminServiceCreateFirmwareReleaseProcedure = "/rootstock.v1.AdminService/CreateFirmwareRelease"
+	// AdminServiceListFirmwareReleasesProcedure is the fully-qualified name of the AdminService's
+	// ListFirmwareReleases RPC.
+	AdminServiceListFirmwareReleasesProcedure = "/rootstock.v1.AdminService/ListFirmwareReleases"
+	// AdminServiceSetFirmwareRolloutProcedure is the fully-qualified name of the AdminService's
+	// SetFirmwareRollout RPC.
+	AdminServiceSetFirmwareRolloutProcedure = "/rootstock.v1.AdminService/SetFirmwareRollout"
+	// AdminServiceListFirmwareInstallsProcedure is the fully-qualified name of the AdminService's
+	// ListFirmwareInstalls RPC.
+	AdminServiceListFirmwareInstallsProcedure = "/rootstock.v1.AdminService/ListFirmwareInstalls"
 )
 
 // HealthServiceClient is a client for the rootstock.v1.HealthService service.
@@ -657,6 +723,10 @@ type DeviceServiceClient interface {
 	RevokeDevice(context.Context, *connect.Request[v1.RevokeDeviceRequest]) (*connect.Response[v1.RevokeDeviceResponse], error)
 	ReinstateDevice(context.Context, *connect.Request[v1.ReinstateDeviceRequest]) (*connect.Response[v1.ReinstateDeviceResponse], error)
 	EnrollInCampaign(context.Context, *connect.Request[v1.EnrollInCampaignRequest]) (*connect.Response[v1.EnrollInCampaignResponse], error)
+	SendDeviceCommand(context.Context, *connect.Request[v1.SendDeviceCommandRequest]) (*connect.Response[v1.SendDeviceCommandResponse], error)
+	ListDeviceCommands(context.Context, *connect.Request[v1.ListDeviceCommandsRequest]) (*connect.Response[v1.ListDeviceCommandsResponse], error)
+	GetDeviceShadow(context.Context, *connect.Request[v1.GetDeviceShadowRequest]) (*connect.Response[v1.GetDeviceShadowResponse], error)
+	UpdateDeviceShadow(context.Context, *connect.Request[v1.UpdateDeviceShadowRequest]) (*connect.Response[v1.UpdateDeviceShadowResponse], error)
 }
 
 // NewDeviceServiceClient constructs a client for the rootstock.v1.DeviceService service. By
@@ -694,15 +764,43 @@ func NewDeviceServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(deviceServiceMethods.ByName("EnrollInCampaign")),
 			connect.WithClientOptions(opts...),
 		),
+		sendDeviceCommand: connect.NewClient[v1.SendDeviceCommandRequest, v1.SendDeviceCommandResponse](
+			httpClient,
+			baseURL+DeviceServiceSendDeviceCommandProcedure,
+			connect.WithSchema(deviceServiceMethods.ByName("SendDeviceCommand")),
+			connect.WithClientOptions(opts...),
+		),
+		listDeviceCommands: connect.NewClient[v1.ListDeviceCommandsRequest, v1.ListDeviceCommandsResponse](
+			httpClient,
+			baseURL+DeviceServiceListDeviceCommandsProcedure,
+			connect.WithSchema(deviceServiceMethods.ByName("ListDeviceCommands")),
+			connect.WithClientOptions(opts...),
+		),
+		getDeviceShadow: connect.NewClient[v1.GetDeviceShadowRequest, v1.GetDeviceShadowResponse](
+			httpClient,
+			baseURL+DeviceServiceGetDeviceShadowProcedure,
+			connect.WithSchema(deviceServiceMethods.ByName("GetDeviceShadow")),
+			connect.WithClientOptions(opts...),
+		),
+		updateDeviceShadow: connect.NewClient[v1.UpdateDeviceShadowRequest, v1.UpdateDeviceShadowResponse](
+			httpClient,
+			baseURL+DeviceServiceUpdateDeviceShadowProcedure,
+			connect.WithSchema(deviceServiceMethods.ByName("UpdateDeviceShadow")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // deviceServiceClient implements DeviceServiceClient.
 type deviceServiceClient struct {
-	getDevice        *connect.Client[v1.GetDeviceRequest, v1.GetDeviceResponse]
-	revokeDevice     *connect.Client[v1.RevokeDeviceRequest, v1.RevokeDeviceResponse]
-	reinstateDevice  *connect.Client[v1.ReinstateDeviceRequest, v1.ReinstateDeviceResponse]
-	enrollInCampaign *connect.Client[v1.EnrollInCampaignRequest, v1.EnrollInCampaignResponse]
+	getDevice          *connect.Client[v1.GetDeviceRequest, v1.GetDeviceResponse]
+	revokeDevice       *connect.Client[v1.RevokeDeviceRequest, v1.RevokeDeviceResponse]
+	reinstateDevice    *connect.Client[v1.ReinstateDeviceRequest, v1.ReinstateDeviceResponse]
+	enrollInCampaign   *connect.Client[v1.EnrollInCampaignRequest, v1.EnrollInCampaignResponse]
+	sendDeviceCommand  *connect.Client[v1.SendDeviceCommandRequest, v1.SendDeviceCommandResponse]
+	listDeviceCommands *connect.Client[v1.ListDeviceCommandsRequest, v1.ListDeviceCommandsResponse]
+	getDeviceShadow    *connect.Client[v1.GetDeviceShadowRequest, v1.GetDeviceShadowResponse]
+	updateDeviceShadow *connect.Client[v1.UpdateDeviceShadowRequest, v1.UpdateDeviceShadowResponse]
 }
 
 // GetDevice calls rootstock.v1.DeviceService.GetDevice.
@@ -725,12 +823,36 @@ func (c *deviceServiceClient) EnrollInCampaign(ctx context.Context, req *connect
 	return c.enrollInCampaign.CallUnary(ctx, req)
 }
 
+// SendDeviceCommand calls rootstock.v1.DeviceService.SendDeviceCommand.
+func (c *deviceServiceClient) SendDeviceCommand(ctx context.Context, req *connect.Request[v1.SendDeviceCommandRequest]) (*connect.Response[v1.SendDeviceCommandResponse], error) {
+	return c.sendDeviceCommand.CallUnary(ctx, req)
+}
+
+// ListDeviceCommands calls rootstock.v1.DeviceService.ListDeviceCommands.
+func (c *deviceServiceClient) ListDeviceCommands(ctx context.Context, req *connect.Request[v1.ListDeviceCommandsRequest]) (*connect.Response[v1.ListDeviceCommandsResponse], error) {
+	return c.listDeviceCommands.CallUnary(ctx, req)
+}
+
+// GetDeviceShadow calls rootstock.v1.DeviceService.GetDeviceShadow.
+func (c *deviceServiceClient) GetDeviceShadow(ctx context.Context, req *connect.Request[v1.GetDeviceShadowRequest]) (*connect.Response[v1.GetDeviceShadowResponse], error) {
+	return c.getDeviceShadow.CallUnary(ctx, req)
+}
+
+// UpdateDeviceShadow calls rootstock.v1.DeviceService.UpdateDeviceShadow.
+func (c *deviceServiceClient) UpdateDeviceShadow(ctx context.Context, req *connect.Request[v1.UpdateDeviceShadowRequest]) (*connect.Response[v1.UpdateDeviceShadowResponse], error) {
+	return c.updateDeviceShadow.CallUnary(ctx, req)
+}
+
 // DeviceServiceHandler is an implementation of the rootstock.v1.DeviceService service.
 type DeviceServiceHandler interface {
 	GetDevice(context.Context, *connect.Request[v1.GetDeviceRequest]) (*connect.Response[v1.GetDeviceResponse], error)
 	RevokeDevice(context.Context, *connect.Request[v1.RevokeDeviceRequest]) (*connect.Response[v1.RevokeDeviceResponse], error)
 	ReinstateDevice(context.Context, *connect.Request[v1.ReinstateDeviceRequest]) (*connect.Response[v1.ReinstateDeviceResponse], error)
 	EnrollInCampaign(context.Context, *connect.Request[v1.EnrollInCampaignRequest]) (*connect.Response[v1.EnrollInCampaignResponse], error)
+	SendDeviceCommand(context.Context, *connect.Request[v1.SendDeviceCommandRequest]) (*connect.Response[v1.SendDeviceCommandResponse], error)
+	ListDeviceCommands(context.Context, *connect.Request[v1.ListDeviceCommandsRequest]) (*connect.Response[v1.ListDeviceCommandsResponse], error)
+	GetDeviceShadow(context.Context, *connect.Request[v1.GetDeviceShadowRequest]) (*connect.Response[v1.GetDeviceShadowResponse], error)
+	UpdateDeviceShadow(context.Context, *connect.Request[v1.UpdateDeviceShadowRequest]) (*connect.Response[v1.UpdateDeviceShadowResponse], error)
 }
 
 // NewDeviceServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -764,6 +886,30 @@ func NewDeviceServiceHandler(svc DeviceServiceHandler, opts ...connect.HandlerOp
 		connect.WithSchema(deviceServiceMethods.ByName("EnrollInCampaign")),
 		connect.WithHandlerOptions(opts...),
 	)
+	deviceServiceSendDeviceCommandHandler := connect.NewUnaryHandler(
+		DeviceServiceSendDeviceCommandProcedure,
+		svc.SendDeviceCommand,
+		connect.WithSchema(deviceServiceMethods.ByName("SendDeviceCommand")),
+		connect.WithHandlerOptions(opts...),
+	)
+	deviceServiceListDeviceCommandsHandler := connect.NewUnaryHandler(
+		DeviceServiceListDeviceCommandsProcedure,
+		svc.ListDeviceCommands,
+		connect.WithSchema(deviceServiceMethods.ByName("ListDeviceCommands")),
+		connect.WithHandlerOptions(opts...),
+	)
+	deviceServiceGetDeviceShadowHandler := connect.NewUnaryHandler(
+		DeviceServiceGetDeviceShadowProcedure,
+		svc.GetDeviceShadow,
+		connect.WithSchema(deviceServiceMethods.ByName("GetDeviceShadow")),
+		connect.WithHandlerOptions(opts...),
+	)
+	deviceServiceUpdateDeviceShadowHandler := connect.NewUnaryHandler(
+		DeviceServiceUpdateDeviceShadowProcedure,
+		svc.UpdateDeviceShadow,
+		connect.WithSchema(deviceServiceMethods.ByName("UpdateDeviceShadow")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/rootstock.v1.DeviceService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case DeviceServiceGetDeviceProcedure:
@@ -774,6 +920,14 @@ func NewDeviceServiceHandler(svc DeviceServiceHandler, opts ...connect.HandlerOp
 			deviceServiceReinstateDeviceHandler.ServeHTTP(w, r)
 		case DeviceServiceEnrollInCampaignProcedure:
 			deviceServiceEnrollInCampaignHandler.ServeHTTP(w, r)
+		case DeviceServiceSendDeviceCommandProcedure:
+			deviceServiceSendDeviceCommandHandler.ServeHTTP(w, r)
+		case DeviceServiceListDeviceCommandsProcedure:
+			deviceServiceListDeviceCommandsHandler.ServeHTTP(w, r)
+		case DeviceServiceGetDeviceShadowProcedure:
+			deviceServiceGetDeviceShadowHandler.ServeHTTP(w, r)
+		case DeviceServiceUpdateDeviceShadowProcedure:
+			deviceServiceUpdateDeviceShadowHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -799,6 +953,22 @@ func (UnimplementedDeviceServiceHandler) EnrollInCampaign(context.Context, *conn
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rootstock.v1.DeviceService.EnrollInCampaign is not implemented"))
 }
 
+func (UnimplementedDeviceServiceHandler) SendDeviceCommand(context.Context, *connect.Request[v1.SendDeviceCommandRequest]) (*connect.Response[v1.SendDeviceCommandResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rootstock.v1.DeviceService.SendDeviceCommand is not implemented"))
+}
+
+func (UnimplementedDeviceServiceHandler) ListDeviceCommands(context.Context, *connect.Request[v1.ListDeviceCommandsRequest]) (*connect.Response[v1.ListDeviceCommandsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rootstock.v1.DeviceService.ListDeviceCommands is not implemented"))
+}
+
+func (UnimplementedDeviceServiceHandler) GetDeviceShadow(context.Context, *connect.Request[v1.GetDeviceShadowRequest]) (*connect.Response[v1.GetDeviceShadowResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rootstock.v1.DeviceService.GetDeviceShadow is not implemented"))
+}
+
+func (UnimplementedDeviceServiceHandler) UpdateDeviceShadow(context.Context, *connect.Request[v1.UpdateDeviceShadowRequest]) (*connect.Response[v1.UpdateDeviceShadowResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rootstock.v1.DeviceService.UpdateDeviceShadow is not implemented"))
+}
+
 // UserServiceClient is a client for the rootstock.v1.UserService service.
 type UserServiceClient interface {
 	RegisterUser(context.Context, *connect.Request[v1.RegisterUserRequest]) (*connect.Response[v1.RegisterUserResponse], error)
@@ -1040,6 +1210,13 @@ type ScitizenServiceClient interface {
 	GetContributions(context.Context, *connect.Request[v1.GetContributionsRequest]) (*connect.Response[v1.GetContributionsResponse], error)
 	GetOnboardingState(context.Context, *connect.Request[v1.GetOnboardingStateRequest]) (*connect.Response[v1.GetOnboardingStateResponse], error)
 	GetLeaderboard(context.Context, *connect.Request[v1.GetLeaderboardRequest]) (*connect.Response[v1.GetLeaderboardResponse], error)
+	RegisterDevice(context.Context, *connect.Request[v1.RegisterDeviceRequest]) (*connect.Response[v1.RegisterDeviceResponse], error)
+	ListEnrollmentCodes(context.Context, *connect.Request[v1.ListEnrollmentCodesRequest]) (*connect.Response[v1.ListEnrollmentCodesResponse], error)
+	RegenerateEnrollmentCode(context.Context, *connect.Request[v1.RegenerateEnrollmentCodeRequest]) (*connect.Response[v1.RegenerateEnrollmentCodeResponse], error)
+	ExpireEnrollmentCode(context.Context, *connect.Request[v1.ExpireEnrollmentCodeRequest]) (*connect.Response[v1.ExpireEnrollmentCodeResponse], error)
+	SetDeviceSensorUnits(context.Context, *connect.Request[v1.SetDeviceSensorUnitsRequest]) (*connect.Response[v1.SetDeviceSensorUnitsResponse], error)
+	LinkStation(context.Context, *connect.Request[v1.LinkStationRequest]) (*connect.Response[v1.LinkStationResponse], error)
+	UnlinkStation(context.Context, *connect.Request[v1.UnlinkStationRequest]) (*connect.Response[v1.UnlinkStationResponse], error)
 }
 
 // NewScitizenServiceClient constructs a client for the rootstock.v1.ScitizenService service. By
@@ -1131,6 +1308,48 @@ func NewScitizenServiceClient(httpClient connect.HTTPClient, baseURL string, opt
 			connect.WithSchema(scitizenServiceMethods.ByName("GetLeaderboard")),
 			connect.WithClientOptions(opts...),
 		),
+		registerDevice: connect.NewClient[v1.RegisterDeviceRequest, v1.RegisterDeviceResponse](
+			httpClient,
+			baseURL+ScitizenServiceRegisterDeviceProcedure,
+			connect.WithSchema(scitizenServiceMethods.ByName("RegisterDevice")),
+			connect.WithClientOptions(opts...),
+		),
+		listEnrollmentCodes: connect.NewClient[v1.ListEnrollmentCodesRequest, v1.ListEnrollmentCodesResponse](
+			httpClient,
+			baseURL+ScitizenServiceListEnrollmentCodesProcedure,
+			connect.WithSchema(scitizenServiceMethods.ByName("ListEnrollmentCodes")),
+			connect.WithClientOptions(opts...),
+		),
+		regenerateEnrollmentCode: connect.NewClient[v1.RegenerateEnrollmentCodeRequest, v1.RegenerateEnrollmentCodeResponse](
+			httpClient,
+			baseURL+ScitizenServiceRegenerateEnrollmentCodeProcedure,
+			connect.WithSchema(scitizenServiceMethods.ByName("RegenerateEnrollmentCode")),
+			connect.WithClientOptions(opts...),
+		),
+		expireEnrollmentCode: connect.NewClient[v1.ExpireEnrollmentCodeRequest, v1.ExpireEnrollmentCodeResponse](
+			httpClient,
+			baseURL+ScitizenServiceExpireEnrollmentCodeProcedure,
+			connect.WithSchema(scitizenServiceMethods.ByName("ExpireEnrollmentCode")),
+			connect.WithClientOptions(opts...),
+		),
+		setDeviceSensorUnits: connect.NewClient[v1.SetDeviceSensorUnitsRequest, v1.SetDeviceSensorUnitsResponse](
+			httpClient,
+			baseURL+ScitizenServiceSetDeviceSensorUnitsProcedure,
+			connect.WithSchema(scitizenServiceMethods.ByName("SetDeviceSensorUnits")),
+			connect.WithClientOptions(opts...),
+		),
+		linkStation: connect.NewClient[v1.LinkStationRequest, v1.LinkStationResponse](
+			httpClient,
+			baseURL+ScitizenServiceLinkStationProcedure,
+			connect.WithSchema(scitizenServiceMethods.ByName("LinkStation")),
+			connect.WithClientOptions(opts...),
+		),
+		unlinkStation: connect.NewClient[v1.UnlinkStationRequest, v1.UnlinkStationResponse](
+			httpClient,
+			baseURL+ScitizenServiceUnlinkStationProcedure,
+			connect.WithSchema(scitizenServiceMethods.ByName("UnlinkStation")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
@@ -1149,6 +1368,13 @@ type scitizenServiceClient struct {
 	getContributions         *connect.Client[v1.GetContributionsRequest, v1.GetContributionsResponse]
 	getOnboardingState       *connect.Client[v1.GetOnboardingStateRequest, v1.GetOnboardingStateResponse]
 	getLeaderboard           *connect.Client[v1.GetLeaderboardRequest, v1.GetLeaderboardResponse]
+	registerDevice           *connect.Client[v1.RegisterDeviceRequest, v1.RegisterDeviceResponse]
+	listEnrollmentCodes      *connect.Client[v1.ListEnrollmentCodesRequest, v1.ListEnrollmentCodesResponse]
+	regenerateEnrollmentCode *connect.Client[v1.RegenerateEnrollmentCodeRequest, v1.RegenerateEnrollmentCodeResponse]
+	expireEnrollmentCode     *connect.Client[v1.ExpireEnrollmentCodeRequest, v1.ExpireEnrollmentCodeResponse]
+	setDeviceSensorUnits     *connect.Client[v1.SetDeviceSensorUnitsRequest, v1.SetDeviceSensorUnitsResponse]
+	linkStation              *connect.Client[v1.LinkStationRequest, v1.LinkStationResponse]
+	unlinkStation            *connect.Client[v1.UnlinkStationRequest, v1.UnlinkStationResponse]
 }
 
 // RegisterScitizen calls rootstock.v1.ScitizenService.RegisterScitizen.
@@ -1216,6 +1442,41 @@ func (c *scitizenServiceClient) GetLeaderboard(ctx context.Context, req *connect
 	return c.getLeaderboard.CallUnary(ctx, req)
 }
 
+// RegisterDevice calls rootstock.v1.ScitizenService.RegisterDevice.
+func (c *scitizenServiceClient) RegisterDevice(ctx context.Context, req *connect.Request[v1.RegisterDeviceRequest]) (*connect.Response[v1.RegisterDeviceResponse], error) {
+	return c.registerDevice.CallUnary(ctx, req)
+}
+
+// ListEnrollmentCodes calls rootstock.v1.ScitizenService.ListEnrollmentCodes.
+func (c *scitizenServiceClient) ListEnrollmentCodes(ctx context.Context, req *connect.Request[v1.ListEnrollmentCodesRequest]) (*connect.Response[v1.ListEnrollmentCodesResponse], error) {
+	return c.listEnrollmentCodes.CallUnary(ctx, req)
+}
+
+// RegenerateEnrollmentCode calls rootstock.v1.ScitizenService.RegenerateEnrollmentCode.
+func (c *scitizenServiceClient) RegenerateEnrollmentCode(ctx context.Context, req *connect.Request[v1.RegenerateEnrollmentCodeRequest]) (*connect.Response[v1.RegenerateEnrollmentCodeResponse], error) {
+	return c.regenerateEnrollmentCode.CallUnary(ctx, req)
+}
+
+// ExpireEnrollmentCode calls rootstock.v1.ScitizenService.ExpireEnrollmentCode.
+func (c *scitizenServiceClient) ExpireEnrollmentCode(ctx context.Context, req *connect.Request[v1.ExpireEnrollmentCodeRequest]) (*connect.Response[v1.ExpireEnrollmentCodeResponse], error) {
+	return c.expireEnrollmentCode.CallUnary(ctx, req)
+}
+
+// SetDeviceSensorUnits calls rootstock.v1.ScitizenService.SetDeviceSensorUnits.
+func (c *scitizenServiceClient) SetDeviceSensorUnits(ctx context.Context, req *connect.Request[v1.SetDeviceSensorUnitsRequest]) (*connect.Response[v1.SetDeviceSensorUnitsResponse], error) {
+	return c.setDeviceSensorUnits.CallUnary(ctx, req)
+}
+
+// LinkStation calls rootstock.v1.ScitizenService.LinkStation.
+func (c *scitizenServiceClient) LinkStation(ctx context.Context, req *connect.Request[v1.LinkStationRequest]) (*connect.Response[v1.LinkStationResponse], error) {
+	return c.linkStation.CallUnary(ctx, req)
+}
+
+// UnlinkStation calls rootstock.v1.ScitizenService.UnlinkStation.
+func (c *scitizenServiceClient) UnlinkStation(ctx context.Context, req *connect.Request[v1.UnlinkStationRequest]) (*connect.Response[v1.UnlinkStationResponse], error) {
+	return c.unlinkStation.CallUnary(ctx, req)
+}
+
 // ScitizenServiceHandler is an implementation of the rootstock.v1.ScitizenService service.
 type ScitizenServiceHandler interface {
 	RegisterScitizen(context.Context, *connect.Request[v1.RegisterScitizenRequest]) (*connect.Response[v1.RegisterScitizenResponse], error)
@@ -1231,6 +1492,13 @@ type ScitizenServiceHandler interface {
 	GetContributions(context.Context, *connect.Request[v1.GetContributionsRequest]) (*connect.Response[v1.GetContributionsResponse], error)
 	GetOnboardingState(context.Context, *connect.Request[v1.GetOnboardingStateRequest]) (*connect.Response[v1.GetOnboardingStateResponse], error)
 	GetLeaderboard(context.Context, *connect.Request[v1.GetLeaderboardRequest]) (*connect.Response[v1.GetLeaderboardResponse], error)
+	RegisterDevice(context.Context, *connect.Request[v1.RegisterDeviceRequest]) (*connect.Response[v1.RegisterDeviceResponse], error)
+	ListEnrollmentCodes(context.Context, *connect.Request[v1.ListEnrollmentCodesRequest]) (*connect.Response[v1.ListEnrollmentCodesResponse], error)
+	RegenerateEnrollmentCode(context.Context, *connect.Request[v1.RegenerateEnrollmentCodeRequest]) (*connect.Response[v1.RegenerateEnrollmentCodeResponse], error)
+	ExpireEnrollmentCode(context.Context, *connect.Request[v1.ExpireEnrollmentCodeRequest]) (*connect.Response[v1.ExpireEnrollmentCodeResponse], error)
+	SetDeviceSensorUnits(context.Context, *connect.Request[v1.SetDeviceSensorUnitsRequest]) (*connect.Response[v1.SetDeviceSensorUnitsResponse], error)
+	LinkStation(context.Context, *connect.Request[v1.LinkStationRequest]) (*connect.Response[v1.LinkStationResponse], error)
+	UnlinkStation(context.Context, *connect.Request[v1.UnlinkStationRequest]) (*connect.Response[v1.UnlinkStationResponse], error)
 }
 
 // NewScitizenServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -1318,6 +1586,48 @@ func NewScitizenServiceHandler(svc ScitizenServiceHandler, opts ...connect.Handl
 		connect.WithSchema(scitizenServiceMethods.ByName("GetLeaderboard")),
 		connect.WithHandlerOptions(opts...),
 	)
+	scitizenServiceRegisterDeviceHandler := connect.NewUnaryHandler(
+		ScitizenServiceRegisterDeviceProcedure,
+		svc.RegisterDevice,
+		connect.WithSchema(scitizenServiceMethods.ByName("RegisterDevice")),
+		connect.WithHandlerOptions(opts...),
+	)
+	scitizenServiceListEnrollmentCodesHandler := connect.NewUnaryHandler(
+		ScitizenServiceListEnrollmentCodesProcedure,
+		svc.ListEnrollmentCodes,
+		connect.WithSchema(scitizenServiceMethods.ByName("ListEnrollmentCodes")),
+		connect.WithHandlerOptions(opts...),
+	)
+	scitizenServiceRegenerateEnrollmentCodeHandler := connect.NewUnaryHandler(
+		ScitizenServiceRegenerateEnrollmentCodeProcedure,
+		svc.RegenerateEnrollmentCode,
+		connect.WithSchema(scitizenServiceMethods.ByName("RegenerateEnrollmentCode")),
+		connect.WithHandlerOptions(opts...),
+	)
+	scitizenServiceExpireEnrollmentCodeHandler := connect.NewUnaryHandler(
+		ScitizenServiceExpireEnrollmentCodeProcedure,
+		svc.ExpireEnrollmentCode,
+		connect.WithSchema(scitizenServiceMethods.ByName("ExpireEnrollmentCode")),
+		connect.WithHandlerOptions(opts...),
+	)
+	scitizenServiceSetDeviceSensorUnitsHandler := connect.NewUnaryHandler(
+		ScitizenServiceSetDeviceSensorUnitsProcedure,
+		svc.SetDeviceSensorUnits,
+		connect.WithSchema(scitizenServiceMethods.ByName("SetDeviceSensorUnits")),
+		connect.WithHandlerOptions(opts...),
+	)
+	scitizenServiceLinkStationHandler := connect.NewUnaryHandler(
+		ScitizenServiceLinkStationProcedure,
+		svc.LinkStation,
+		connect.WithSchema(scitizenServiceMethods.ByName("LinkStation")),
+		connect.WithHandlerOptions(opts...),
+	)
+	scitizenServiceUnlinkStationHandler := connect.NewUnaryHandler(
+		ScitizenServiceUnlinkStationProcedure,
+		svc.UnlinkStation,
+		connect.WithSchema(scitizenServiceMethods.ByName("UnlinkStation")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/rootstock.v1.ScitizenService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case ScitizenServiceRegisterScitizenProcedure:
@@ -1346,6 +1656,20 @@ func NewScitizenServiceHandler(svc ScitizenServiceHandler, opts ...connect.Handl
 			scitizenServiceGetOnboardingStateHandler.ServeHTTP(w, r)
 		case ScitizenServiceGetLeaderboardProcedure:
 			scitizenServiceGetLeaderboardHandler.ServeHTTP(w, r)
+		case ScitizenServiceRegisterDeviceProcedure:
+			scitizenServiceRegisterDeviceHandler.ServeHTTP(w, r)
+		case ScitizenServiceListEnrollmentCodesProcedure:
+			scitizenServiceListEnrollmentCodesHandler.ServeHTTP(w, r)
+		case ScitizenServiceRegenerateEnrollmentCodeProcedure:
+			scitizenServiceRegenerateEnrollmentCodeHandler.ServeHTTP(w, r)
+		case ScitizenServiceExpireEnrollmentCodeProcedure:
+			scitizenServiceExpireEnrollmentCodeHandler.ServeHTTP(w, r)
+		case ScitizenServiceSetDeviceSensorUnitsProcedure:
+			scitizenServiceSetDeviceSensorUnitsHandler.ServeHTTP(w, r)
+		case ScitizenServiceLinkStationProcedure:
+			scitizenServiceLinkStationHandler.ServeHTTP(w, r)
+		case ScitizenServiceUnlinkStationProcedure:
+			scitizenServiceUnlinkStationHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -1407,6 +1731,34 @@ func (UnimplementedScitizenServiceHandler) GetLeaderboard(context.Context, *conn
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rootstock.v1.ScitizenService.GetLeaderboard is not implemented"))
 }
 
+func (UnimplementedScitizenServiceHandler) RegisterDevice(context.Context, *connect.Request[v1.RegisterDeviceRequest]) (*connect.Response[v1.RegisterDeviceResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rootstock.v1.ScitizenService.RegisterDevice is not implemented"))
+}
+
+func (UnimplementedScitizenServiceHandler) ListEnrollmentCodes(context.Context, *connect.Request[v1.ListEnrollmentCodesRequest]) (*connect.Response[v1.ListEnrollmentCodesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rootstock.v1.ScitizenService.ListEnrollmentCodes is not implemented"))
+}
+
+func (UnimplementedScitizenServiceHandler) RegenerateEnrollmentCode(context.Context, *connect.Request[v1.RegenerateEnrollmentCodeRequest]) (*connect.Response[v1.RegenerateEnrollmentCodeResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rootstock.v1.ScitizenService.RegenerateEnrollmentCode is not implemented"))
+}
+
+func (UnimplementedScitizenServiceHandler) ExpireEnrollmentCode(context.Context, *connect.Request[v1.ExpireEnrollmentCodeRequest]) (*connect.Response[v1.ExpireEnrollmentCodeResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rootstock.v1.ScitizenService.ExpireEnrollmentCode is not implemented"))
+}
+
+func (UnimplementedScitizenServiceHandler) SetDeviceSensorUnits(context.Context, *connect.Request[v1.SetDeviceSensorUnitsRequest]) (*connect.Response[v1.SetDeviceSensorUnitsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rootstock.v1.ScitizenService.SetDeviceSensorUnits is not implemented"))
+}
+
+func (UnimplementedScitizenServiceHandler) LinkStation(context.Context, *connect.Request[v1.LinkStationRequest]) (*connect.Response[v1.LinkStationResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rootstock.v1.ScitizenService.LinkStation is not implemented"))
+}
+
+func (UnimplementedScitizenServiceHandler) UnlinkStation(context.Context, *connect.Request[v1.UnlinkStationRequest]) (*connect.Response[v1.UnlinkStationResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rootstock.v1.ScitizenService.UnlinkStation is not implemented"))
+}
+
 // NotificationServiceClient is a client for the rootstock.v1.NotificationService service.
 type NotificationServiceClient interface {
 	ListNotifications(context.Context, *connect.Request[v1.ListNotificationsRequest]) (*connect.Response[v1.ListNotificationsResponse], error)
@@ -1558,6 +1910,17 @@ func (UnimplementedNotificationServiceHandler) UpdatePreferences(context.Context
 // AdminServiceClient is a client for the rootstock.v1.AdminService service.
 type AdminServiceClient interface {
 	SuspendByClass(context.Context, *connect.Request[v1.SuspendByClassRequest]) (*connect.Response[v1.SuspendByClassResponse], error)
+	ListDeadLetters(context.Context, *connect.Request[v1.ListDeadLettersRequest]) (*connect.Response[v1.ListDeadLettersResponse], error)
+	GetDeadLetter(context.Context, *connect.Request[v1.GetDeadLetterRequest]) (*connect.Response[v1.GetDeadLetterResponse], error)
+	ReplayDeadLetter(context.Context, *connect.Request[v1.ReplayDeadLetterRequest]) (*connect.Response[v1.ReplayDeadLetterResponse], error)
+	PurgeDeadLetters(context.Context, *connect.Request[v1.PurgeDeadLettersRequest]) (*connect.Response[v1.PurgeDeadLettersResponse], error)
+	SetDeviceClassUnits(context.Context, *connect.Request[v1.SetDeviceClassUnitsRequest]) (*connect.Response[v1.SetDeviceClassUnitsResponse], error)
+	ListRateLimitFlaggedDevices(context.Context, *connect.Request[v1.ListRateLimitFlaggedDevicesRequest]) (*connect.Response[v1.ListRateLimitFlaggedDevicesResponse], error)
+	ClearRateLimitFlag(context.Context, *connect.Request[v1.ClearRateLimitFlagRequest]) (*connect.Response[v1.ClearRateLimitFlagResponse], error)
+	CreateFirmwareRelease(context.Context, *connect.Request[v1.CreateFirmwareReleaseRequest]) (*connect.Response[v1.CreateFirmwareReleaseResponse], error)
+	ListFirmwareReleases(context.Context, *connect.Request[v1.ListFirmwareReleasesRequest]) (*connect.Response[v1.ListFirmwareReleasesResponse], error)
+	SetFirmwareRollout(context.Context, *connect.Request[v1.SetFirmwareRolloutRequest]) (*connect.Response[v1.SetFirmwareRolloutResponse], error)
+	ListFirmwareInstalls(context.Context, *connect.Request[v1.ListFirmwareInstallsRequest]) (*connect.Response[v1.ListFirmwareInstallsResponse], error)
 }
 
 // NewAdminServiceClient constructs a client for the rootstock.v1.AdminService service. By default,
@@ -1577,12 +1940,89 @@ func NewAdminServiceClient(httpClient connect.HTTPClient, baseURL string, opts .
 			connect.WithSchema(adminServiceMethods.ByName("SuspendByClass")),
 			connect.WithClientOptions(opts...),
 		),
+		listDeadLetters: connect.NewClient[v1.ListDeadLettersRequest, v1.ListDeadLettersResponse](
+			httpClient,
+			baseURL+AdminServiceListDeadLettersProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("ListDeadLetters")),
+			connect.WithClientOptions(opts...),
+		),
+		getDeadLetter: connect.NewClient[v1.GetDeadLetterRequest, v1.GetDeadLetterResponse](
+			httpClient,
+			baseURL+AdminServiceGetDeadLetterProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("GetDeadLetter")),
+			connect.WithClientOptions(opts...),
+		),
+		replayDeadLetter: connect.NewClient[v1.ReplayDeadLetterRequest, v1.ReplayDeadLetterResponse](
+			httpClient,
+			baseURL+AdminServiceReplayDeadLetterProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("ReplayDeadLetter")),
+			connect.WithClientOptions(opts...),
+		),
+		purgeDeadLetters: connect.NewClient[v1.PurgeDeadLettersRequest, v1.PurgeDeadLettersResponse](
+			httpClient,
+			baseURL+AdminServicePurgeDeadLettersProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("PurgeDeadLetters")),
+			connect.WithClientOptions(opts...),
+		),
+		setDeviceClassUnits: connect.NewClient[v1.SetDeviceClassUnitsRequest, v1.SetDeviceClassUnitsResponse](
+			httpClient,
+			baseURL+AdminServiceSetDeviceClassUnitsProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("SetDeviceClassUnits")),
+			connect.WithClientOptions(opts...),
+		),
+		listRateLimitFlaggedDevices: connect.NewClient[v1.ListRateLimitFlaggedDevicesRequest, v1.ListRateLimitFlaggedDevicesResponse](
+			httpClient,
+			baseURL+AdminServiceListRateLimitFlaggedDevicesProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("ListRateLimitFlaggedDevices")),
+			connect.WithClientOptions(opts...),
+		),
+		clearRateLimitFlag: connect.NewClient[v1.ClearRateLimitFlagRequest, v1.ClearRateLimitFlagResponse](
+			httpClient,
+			baseURL+AdminServiceClearRateLimitFlagProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("ClearRateLimitFlag")),
+			connect.WithClientOptions(opts...),
+		),
+		createFirmwareRelease: connect.NewClient[v1.CreateFirmwareReleaseRequest, v1.CreateFirmwareReleaseResponse](
+			httpClient,
+			baseURL+AdminServiceCreateFirmwareReleaseProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("CreateFirmwareRelease")),
+			connect.WithClientOptions(opts...),
+		),
+		listFirmwareReleases: connect.NewClient[v1.ListFirmwareReleasesRequest, v1.ListFirmwareReleasesResponse](
+			httpClient,
+			baseURL+AdminServiceListFirmwareReleasesProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("ListFirmwareReleases")),
+			connect.WithClientOptions(opts...),
+		),
+		setFirmwareRollout: connect.NewClient[v1.SetFirmwareRolloutRequest, v1.SetFirmwareRolloutResponse](
+			httpClient,
+			baseURL+AdminServiceSetFirmwareRolloutProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("SetFirmwareRollout")),
+			connect.WithClientOptions(opts...),
+		),
+		listFirmwareInstalls: connect.NewClient[v1.ListFirmwareInstallsRequest, v1.ListFirmwareInstallsResponse](
+			httpClient,
+			baseURL+AdminServiceListFirmwareInstallsProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("ListFirmwareInstalls")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // adminServiceClient implements AdminServiceClient.
 type adminServiceClient struct {
-	suspendByClass *connect.Client[v1.SuspendByClassRequest, v1.SuspendByClassResponse]
+	suspendByClass              *connect.Client[v1.SuspendByClassRequest, v1.SuspendByClassResponse]
+	listDeadLetters             *connect.Client[v1.ListDeadLettersRequest, v1.ListDeadLettersResponse]
+	getDeadLetter               *connect.Client[v1.GetDeadLetterRequest, v1.GetDeadLetterResponse]
+	replayDeadLetter            *connect.Client[v1.ReplayDeadLetterRequest, v1.ReplayDeadLetterResponse]
+	purgeDeadLetters            *connect.Client[v1.PurgeDeadLettersRequest, v1.PurgeDeadLettersResponse]
+	setDeviceClassUnits         *connect.Client[v1.SetDeviceClassUnitsRequest, v1.SetDeviceClassUnitsResponse]
+	listRateLimitFlaggedDevices *connect.Client[v1.ListRateLimitFlaggedDevicesRequest, v1.ListRateLimitFlaggedDevicesResponse]
+	clearRateLimitFlag          *connect.Client[v1.ClearRateLimitFlagRequest, v1.ClearRateLimitFlagResponse]
+	createFirmwareRelease       *connect.Client[v1.CreateFirmwareReleaseRequest, v1.CreateFirmwareReleaseResponse]
+	listFirmwareReleases        *connect.Client[v1.ListFirmwareReleasesRequest, v1.ListFirmwareReleasesResponse]
+	setFirmwareRollout          *connect.Client[v1.SetFirmwareRolloutRequest, v1.SetFirmwareRolloutResponse]
+	listFirmwareInstalls        *connect.Client[v1.ListFirmwareInstallsRequest, v1.ListFirmwareInstallsResponse]
 }
 
 // SuspendByClass calls rootstock.v1.AdminService.SuspendByClass.
@@ -1590,9 +2030,75 @@ func (c *adminServiceClient) SuspendByClass(ctx context.Context, req *connect.Re
 	return c.suspendByClass.CallUnary(ctx, req)
 }
 
+// ListDeadLetters calls rootstock.v1.AdminService.ListDeadLetters.
+func (c *adminServiceClient) ListDeadLetters(ctx context.Context, req *connect.Request[v1.ListDeadLettersRequest]) (*connect.Response[v1.ListDeadLettersResponse], error) {
+	return c.listDeadLetters.CallUnary(ctx, req)
+}
+
+// GetDeadLetter calls rootstock.v1.AdminService.GetDeadLetter.
+func (c *adminServiceClient) GetDeadLetter(ctx context.Context, req *connect.Request[v1.GetDeadLetterRequest]) (*connect.Response[v1.GetDeadLetterResponse], error) {
+	return c.getDeadLetter.CallUnary(ctx, req)
+}
+
+// ReplayDeadLetter calls rootstock.v1.AdminService.ReplayDeadLetter.
+func (c *adminServiceClient) ReplayDeadLetter(ctx context.Context, req *connect.Request[v1.ReplayDeadLetterRequest]) (*connect.Response[v1.ReplayDeadLetterResponse], error) {
+	return c.replayDeadLetter.CallUnary(ctx, req)
+}
+
+// PurgeDeadLetters calls rootstock.v1.AdminService.PurgeDeadLetters.
+func (c *adminServiceClient) PurgeDeadLetters(ctx context.Context, req *connect.Request[v1.PurgeDeadLettersRequest]) (*connect.Response[v1.PurgeDeadLettersResponse], error) {
+	return c.purgeDeadLetters.CallUnary(ctx, req)
+}
+
+// SetDeviceClassUnits calls rootstock.v1.AdminService.SetDeviceClassUnits.
+func (c *adminServiceClient) SetDeviceClassUnits(ctx context.Context, req *connect.Request[v1.SetDeviceClassUnitsRequest]) (*connect.Response[v1.SetDeviceClassUnitsResponse], error) {
+	return c.setDeviceClassUnits.CallUnary(ctx, req)
+}
+
+// ListRateLimitFlaggedDevices calls rootstock.v1.AdminService.ListRateLimitFlaggedDevices.
+func (c *adminServiceClient) ListRateLimitFlaggedDevices(ctx context.Context, req *connect.Request[v1.ListRateLimitFlaggedDevicesRequest]) (*connect.Response[v1.ListRateLimitFlaggedDevicesResponse], error) {
+	return c.listRateLimitFlaggedDevices.CallUnary(ctx, req)
+}
+
+// ClearRateLimitFlag calls rootstock.v1.AdminService.ClearRateLimitFlag.
+func (c *adminServiceClient) ClearRateLimitFlag(ctx context.Context, req *connect.Request[v1.ClearRateLimitFlagRequest]) (*connect.Response[v1.ClearRateLimitFlagResponse], error) {
+	return c.clearRateLimitFlag.CallUnary(ctx, req)
+}
+
+// CreateFirmwareRelease calls rootstock.v1.AdminService.CreateFirmwareRelease.
+func (c *adminServiceClient) CreateFirmwareRelease(ctx context.Context, req *connect.Request[v1.CreateFirmwareReleaseRequest]) (*connect.Response[v1.CreateFirmwareReleaseResponse], error) {
+	return c.createFirmwareRelease.CallUnary(ctx, req)
+}
+
+// ListFirmwareReleases calls rootstock.v1.AdminService.ListFirmwareReleases.
+func (c *adminServiceClient) ListFirmwareReleases(ctx context.Context, req *connect.Request[v1.ListFirmwareReleasesRequest]) (*connect.Response[v1.ListFirmwareReleasesResponse], error) {
+	return c.listFirmwareReleases.CallUnary(ctx, req)
+}
+
+// SetFirmwareRollout calls rootstock.v1.AdminService.SetFirmwareRollout.
+func (c *adminServiceClient) SetFirmwareRollout(ctx context.Context, req *connect.Request[v1.SetFirmwareRolloutRequest]) (*connect.Response[v1.SetFirmwareRolloutResponse], error) {
+	return c.setFirmwareRollout.CallUnary(ctx, req)
+}
+
+// ListFirmwareInstalls calls rootstock.v1.AdminService.ListFirmwareInstalls.
+func (c *adminServiceClient) ListFirmwareInstalls(ctx context.Context, req *connect.Request[v1.ListFirmwareInstallsRequest]) (*connect.Response[v1.ListFirmwareInstallsResponse], error) {
+	return c.listFirmwareInstalls.CallUnary(ctx, req)
+}
+
 // AdminServiceHandler is an implementation of the rootstock.v1.AdminService service.
 type AdminServiceHandler interface {
 	SuspendByClass(context.Context, *connect.Request[v1.SuspendByClassRequest]) (*connect.Response[v1.SuspendByClassResponse], error)
+	ListDeadLetters(context.Context, *connect.Request[v1.ListDeadLettersRequest]) (*connect.Response[v1.ListDeadLettersResponse], error)
+	GetDeadLetter(context.Context, *connect.Request[v1.GetDeadLetterRequest]) (*connect.Response[v1.GetDeadLetterResponse], error)
+	ReplayDeadLetter(context.Context, *connect.Request[v1.ReplayDeadLetterRequest]) (*connect.Response[v1.ReplayDeadLetterResponse], error)
+	PurgeDeadLetters(context.Context, *connect.Request[v1.PurgeDeadLettersRequest]) (*connect.Response[v1.PurgeDeadLettersResponse], error)
+	SetDeviceClassUnits(context.Context, *connect.Request[v1.SetDeviceClassUnitsRequest]) (*connect.Response[v1.SetDeviceClassUnitsResponse], error)
+	ListRateLimitFlaggedDevices(context.Context, *connect.Request[v1.ListRateLimitFlaggedDevicesRequest]) (*connect.Response[v1.ListRateLimitFlaggedDevicesResponse], error)
+	ClearRateLimitFlag(context.Context, *connect.Request[v1.ClearRateLimitFlagRequest]) (*connect.Response[v1.ClearRateLimitFlagResponse], error)
+	CreateFirmwareRelease(context.Context, *connect.Request[v1.CreateFirmwareReleaseRequest]) (*connect.Response[v1.CreateFirmwareReleaseResponse], error)
+	ListFirmwareReleases(context.Context, *connect.Request[v1.ListFirmwareReleasesRequest]) (*connect.Response[v1.ListFirmwareReleasesResponse], error)
+	SetFirmwareRollout(context.Context, *connect.Request[v1.SetFirmwareRolloutRequest]) (*connect.Response[v1.SetFirmwareRolloutResponse], error)
+	ListFirmwareInstalls(context.Context, *connect.Request[v1.ListFirmwareInstallsRequest]) (*connect.Response[v1.ListFirmwareInstallsResponse], error)
 }
 
 // NewAdminServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -1608,10 +2114,98 @@ func NewAdminServiceHandler(svc AdminServiceHandler, opts ...connect.HandlerOpti
 		connect.WithSchema(adminServiceMethods.ByName("SuspendByClass")),
 		connect.WithHandlerOptions(opts...),
 	)
+	adminServiceListDeadLettersHandler := connect.NewUnaryHandler(
+		AdminServiceListDeadLettersProcedure,
+		svc.ListDeadLetters,
+		connect.WithSchema(adminServiceMethods.ByName("ListDeadLetters")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceGetDeadLetterHandler := connect.NewUnaryHandler(
+		AdminServiceGetDeadLetterProcedure,
+		svc.GetDeadLetter,
+		connect.WithSchema(adminServiceMethods.ByName("GetDeadLetter")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceReplayDeadLetterHandler := connect.NewUnaryHandler(
+		AdminServiceReplayDeadLetterProcedure,
+		svc.ReplayDeadLetter,
+		connect.WithSchema(adminServiceMethods.ByName("ReplayDeadLetter")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServicePurgeDeadLettersHandler := connect.NewUnaryHandler(
+		AdminServicePurgeDeadLettersProcedure,
+		svc.PurgeDeadLetters,
+		connect.WithSchema(adminServiceMethods.ByName("PurgeDeadLetters")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceSetDeviceClassUnitsHandler := connect.NewUnaryHandler(
+		AdminServiceSetDeviceClassUnitsProcedure,
+		svc.SetDeviceClassUnits,
+		connect.WithSchema(adminServiceMethods.ByName("SetDeviceClassUnits")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceListRateLimitFlaggedDevicesHandler := connect.NewUnaryHandler(
+		AdminServiceListRateLimitFlaggedDevicesProcedure,
+		svc.ListRateLimitFlaggedDevices,
+		connect.WithSchema(adminServiceMethods.ByName("ListRateLimitFlaggedDevices")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceClearRateLimitFlagHandler := connect.NewUnaryHandler(
+		AdminServiceClearRateLimitFlagProcedure,
+		svc.ClearRateLimitFlag,
+		connect.WithSchema(adminServiceMethods.ByName("ClearRateLimitFlag")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceCreateFirmwareReleaseHandler := connect.NewUnaryHandler(
+		AdminServiceCreateFirmwareReleaseProcedure,
+		svc.CreateFirmwareRelease,
+		connect.WithSchema(adminServiceMethods.ByName("CreateFirmwareRelease")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceListFirmwareReleasesHandler := connect.NewUnaryHandler(
+		AdminServiceListFirmwareReleasesProcedure,
+		svc.ListFirmwareReleases,
+		connect.WithSchema(adminServiceMethods.ByName("ListFirmwareReleases")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceSetFirmwareRolloutHandler := connect.NewUnaryHandler(
+		AdminServiceSetFirmwareRolloutProcedure,
+		svc.SetFirmwareRollout,
+		connect.WithSchema(adminServiceMethods.ByName("SetFirmwareRollout")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceListFirmwareInstallsHandler := connect.NewUnaryHandler(
+		AdminServiceListFirmwareInstallsProcedure,
+		svc.ListFirmwareInstalls,
+		connect.WithSchema(adminServiceMethods.ByName("ListFirmwareInstalls")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/rootstock.v1.AdminService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case AdminServiceSuspendByClassProcedure:
 			adminServiceSuspendByClassHandler.ServeHTTP(w, r)
+		case AdminServiceListDeadLettersProcedure:
+			adminServiceListDeadLettersHandler.ServeHTTP(w, r)
+		case AdminServiceGetDeadLetterProcedure:
+			adminServiceGetDeadLetterHandler.ServeHTTP(w, r)
+		case AdminServiceReplayDeadLetterProcedure:
+			adminServiceReplayDeadLetterHandler.ServeHTTP(w, r)
+		case AdminServicePurgeDeadLettersProcedure:
+			adminServicePurgeDeadLettersHandler.ServeHTTP(w, r)
+		case AdminServiceSetDeviceClassUnitsProcedure:
+			adminServiceSetDeviceClassUnitsHandler.ServeHTTP(w, r)
+		case AdminServiceListRateLimitFlaggedDevicesProcedure:
+			adminServiceListRateLimitFlaggedDevicesHandler.ServeHTTP(w, r)
+		case AdminServiceClearRateLimitFlagProcedure:
+			adminServiceClearRateLimitFlagHandler.ServeHTTP(w, r)
+		case AdminServiceCreateFirmwareReleaseProcedure:
+			adminServiceCreateFirmwareReleaseHandler.ServeHTTP(w, r)
+		case AdminServiceListFirmwareReleasesProcedure:
+			adminServiceListFirmwareReleasesHandler.ServeHTTP(w, r)
+		case AdminServiceSetFirmwareRolloutProcedure:
+			adminServiceSetFirmwareRolloutHandler.ServeHTTP(w, r)
+		case AdminServiceListFirmwareInstallsProcedure:
+			adminServiceListFirmwareInstallsHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -1624,3 +2218,47 @@ type UnimplementedAdminServiceHandler struct{}
 func (UnimplementedAdminServiceHandler) SuspendByClass(context.Context, *connect.Request[v1.SuspendByClassRequest]) (*connect.Response[v1.SuspendByClassResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rootstock.v1.AdminService.SuspendByClass is not implemented"))
 }
+
+func (UnimplementedAdminServiceHandler) ListDeadLetters(context.Context, *connect.Request[v1.ListDeadLettersRequest]) (*connect.Response[v1.ListDeadLettersResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rootstock.v1.AdminService.ListDeadLetters is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) GetDeadLetter(context.Context, *connect.Request[v1.GetDeadLetterRequest]) (*connect.Response[v1.GetDeadLetterResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rootstock.v1.AdminService.GetDeadLetter is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) ReplayDeadLetter(context.Context, *connect.Request[v1.ReplayDeadLetterRequest]) (*connect.Response[v1.ReplayDeadLetterResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rootstock.v1.AdminService.ReplayDeadLetter is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) PurgeDeadLetters(context.Context, *connect.Request[v1.PurgeDeadLettersRequest]) (*connect.Response[v1.PurgeDeadLettersResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rootstock.v1.AdminService.PurgeDeadLetters is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) SetDeviceClassUnits(context.Context, *connect.Request[v1.SetDeviceClassUnitsRequest]) (*connect.Response[v1.SetDeviceClassUnitsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rootstock.v1.AdminService.SetDeviceClassUnits is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) ListRateLimitFlaggedDevices(context.Context, *connect.Request[v1.ListRateLimitFlaggedDevicesRequest]) (*connect.Response[v1.ListRateLimitFlaggedDevicesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rootstock.v1.AdminService.ListRateLimitFlaggedDevices is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) ClearRateLimitFlag(context.Context, *connect.Request[v1.ClearRateLimitFlagRequest]) (*connect.Response[v1.ClearRateLimitFlagResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rootstock.v1.AdminService.ClearRateLimitFlag is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) CreateFirmwareRelease(context.Context, *connect.Request[v1.CreateFirmwareReleaseRequest]) (*connect.Response[v1.CreateFirmwareReleaseResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rootstock.v1.AdminService.CreateFirmwareRelease is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) ListFirmwareReleases(context.Context, *connect.Request[v1.ListFirmwareReleasesRequest]) (*connect.Response[v1.ListFirmwareReleasesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rootstock.v1.AdminService.ListFirmwareReleases is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) SetFirmwareRollout(context.Context, *connect.Request[v1.SetFirmwareRolloutRequest]) (*connect.Response[v1.SetFirmwareRolloutResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rootstock.v1.AdminService.SetFirmwareRollout is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) ListFirmwareInstalls(context.Context, *connect.Request[v1.ListFirmwareInstallsRequest]) (*connect.Response[v1.ListFirmwareInstallsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rootstock.v1.AdminService.ListFirmwareInstalls is not implemented"))
+}
@@ -52,6 +52,11 @@ scitizen_methods := {
 	"/rootstock.v1.ScitizenService/GetContributions",
 	"/rootstock.v1.ScitizenService/GetOnboardingState",
 	"/rootstock.v1.ScitizenService/GetLeaderboard",
+	"/rootstock.v1.ScitizenService/RegisterDevice",
+	"/rootstock.v1.ScitizenService/ListEnrollmentCodes",
+	"/rootstock.v1.ScitizenService/RegenerateEnrollmentCode",
+	"/rootstock.v1.ScitizenService/ExpireEnrollmentCode",
+	"/rootstock.v1.ScitizenService/SetDeviceSensorUnits",
 	"/rootstock.v1.ScoreService/GetContribution",
 }
 
@@ -72,6 +77,26 @@ researcher_methods := {
 	"/rootstock.v1.DeviceService/ReinstateDevice",
 	"/rootstock.v1.DeviceService/EnrollInCampaign",
 	"/rootstock.v1.AdminService/SuspendByClass",
+	"/rootstock.v1.AdminService/ListDeadLetters",
+	"/rootstock.v1.AdminService/GetDeadLetter",
+	"/rootstock.v1.AdminService/ReplayDeadLetter",
+	"/rootstock.v1.AdminService/PurgeDeadLetters",
+	"/rootstock.v1.AdminService/SetDeviceClassUnits",
+	"/rootstock.v1.AdminService/ListRateLimitFlaggedDevices",
+	"/rootstock.v1.AdminService/ClearRateLimitFlag",
+	"/rootstock.v1.AdminService/CreateFirmwareRelease",
+	"/rootstock.v1.AdminService/ListFirmwareReleases",
+	"/rootstock.v1.AdminService/SetFirmwareRollout",
+	"/rootstock.v1.AdminService/ListFirmwareInstalls",
+}
+
+# Device command and shadow endpoints — open to scitizens (device owners) and researchers
+# (campaign runners). Which devices a caller may command is checked per device by the flows.
+device_command_methods := {
+	"/rootstock.v1.DeviceService/SendDeviceCommand",
+	"/rootstock.v1.DeviceService/ListDeviceCommands",
+	"/rootstock.v1.DeviceService/GetDeviceShadow",
+	"/rootstock.v1.DeviceService/UpdateDeviceShadow",
 }
 
 # --- Decision rules ---
@@ -118,3 +143,13 @@ decision := {
 	input.principal.role in researcher_roles
 	input.method in researcher_methods
 }
+
+# Rule: Device command and shadow endpoints — principal must have scitizen or researcher role
+decision := {
+	"allow": true,
+	"reason": "device_command_access",
+} if {
+	input.session_user_id != ""
+	input.principal.role in (scitizen_roles | researcher_roles)
+	input.method in device_command_methods
+}
@@ -156,10 +156,11 @@ func (r *pgRepo) doCreate(ctx context.Context, input CreateCampaignInput) (*Camp
 	var c Campaign
 	campaignID := ulid.Make().String()
 	err = tx.QueryRow(ctx,
-		`INSERT INTO campaigns (id, org_id, window_start, window_end, created_by)
-		 VALUES ($1, $2, $3, $4, $5)
+		`INSERT INTO campaigns (id, org_id, window_start, window_end, created_by, precision_policy, undeclared_parameter_policy, min_sampling_interval_seconds)
+		 VALUES ($1, $2, $3, $4, $5, COALESCE($6, 'round'), COALESCE($7, 'exclude'), $8)
 		 RETURNING id, org_id, status, window_start, window_end, created_by, created_at`,
 		campaignID, input.OrgID, input.WindowStart, input.WindowEnd, input.CreatedBy,
+		nullIfEmpty(input.PrecisionPolicy), nullIfEmpty(input.UndeclaredParameterPolicy), input.MinSamplingIntervalSecs,
 	).Scan(&c.ID, &c.OrgID, &c.Status, &c.WindowStart, &c.WindowEnd, &c.CreatedBy, &c.CreatedAt)
 	if err != nil {
 		return nil, fmt.Errorf("insert campaign: %w", err)
@@ -167,9 +168,9 @@ func (r *pgRepo) doCreate(ctx context.Context, input CreateCampaignInput) (*Camp
 
 	for _, p := range input.Parameters {
 		_, err := tx.Exec(ctx,
-			`INSERT INTO campaign_parameters (id, campaign_id, name, unit, min_range, max_range, precision)
-			 VALUES ($1, $2, $3, $4, $5, $6, $7)`,
-			ulid.Make().String(), c.ID, p.Name, p.Unit, p.MinRange, p.MaxRange, p.Precision,
+			`INSERT INTO campaign_parameters (id, campaign_id, name, unit, min_range, max_range, precision, required)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+			ulid.Make().String(), c.ID, p.Name, p.Unit, p.MinRange, p.MaxRange, p.Precision, p.Required,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("insert parameter: %w", err)
@@ -261,9 +262,10 @@ func (r *pgRepo) doGetRules(ctx context.Context, campaignID string) (*CampaignRu
 	rules := &CampaignRules{CampaignID: campaignID}
 
 	err := r.pool.QueryRow(ctx,
-		`SELECT window_start, window_end FROM campaigns WHERE id = $1`,
+		`SELECT window_start, window_end, precision_policy, undeclared_parameter_policy, min_sampling_interval_seconds
+		 FROM campaigns WHERE id = $1`,
 		campaignID,
-	).Scan(&rules.WindowStart, &rules.WindowEnd)
+	).Scan(&rules.WindowStart, &rules.WindowEnd, &rules.PrecisionPolicy, &rules.UndeclaredParameterPolicy, &rules.MinSamplingIntervalSecs)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, fmt.Errorf("campaign %s not found", campaignID)
@@ -272,7 +274,7 @@ func (r *pgRepo) doGetRules(ctx context.Context, campaignID string) (*CampaignRu
 	}
 
 	rows, err := r.pool.Query(ctx,
-		`SELECT name, unit, min_range, max_range, precision FROM campaign_parameters WHERE campaign_id = $1`,
+		`SELECT name, unit, min_range, max_range, precision, required FROM campaign_parameters WHERE campaign_id = $1`,
 		campaignID,
 	)
 	if err != nil {
@@ -282,7 +284,7 @@ func (r *pgRepo) doGetRules(ctx context.Context, campaignID string) (*CampaignRu
 
 	for rows.Next() {
 		var p Parameter
-		if err := rows.Scan(&p.Name, &p.Unit, &p.MinRange, &p.MaxRange, &p.Precision); err != nil {
+		if err := rows.Scan(&p.Name, &p.Unit, &p.MinRange, &p.MaxRange, &p.Precision, &p.Required); err != nil {
 			return nil, fmt.Errorf("scan parameter: %w", err)
 		}
 		rules.Parameters = append(rules.Parameters, p)
@@ -331,3 +333,9 @@ func (r *pgRepo) doGetEligibility(ctx context.Context, campaignID string) ([]Eli
 	return criteria, rows.Err()
 }
 
+func nullIfEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -6,5 +6,8 @@ import "context"
 type Repository interface {
 	IssueCert(ctx context.Context, input IssueCertInput) (*IssuedCert, error)
 	GetCACert(ctx context.Context) (*CACert, error)
+	SignCRL(ctx context.Context, input SignCRLInput) (*CRL, error)
+	ParseOCSPRequest(ctx context.Context, der []byte) (*OCSPRequest, error)
+	SignOCSPResponse(ctx context.Context, input OCSPResponseInput) (*OCSPResponse, error)
 	Shutdown()
 }
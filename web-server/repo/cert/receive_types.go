@@ -1,7 +1,31 @@
 package cert
 
+import "time"
+
 // IssueCertInput is what the IssueCert op sends to the repository.
 type IssueCertInput struct {
 	DeviceID string // becomes certificate CN — caller determines identity, not the CSR
 	CSR      []byte // DER-encoded PKCS#10
+	Kind     string // becomes certificate OU when set, e.g. for gateways
+}
+
+// CRLEntry is one revoked certificate to list in a CRL.
+type CRLEntry struct {
+	Serial     string // hex-encoded
+	RevokedAt  time.Time
+	ReasonCode int // RFC 5280 CRLReason
+}
+
+// SignCRLInput is what the SignCRL op sends to the repository.
+type SignCRLInput struct {
+	Number  int64 // CRL number; must increase with every CRL
+	Entries []CRLEntry
+}
+
+// OCSPResponseInput is what the SignOCSPResponse op sends to the repository.
+type OCSPResponseInput struct {
+	Serial     string // hex-encoded
+	Status     string // good, revoked or unknown
+	RevokedAt  time.Time
+	ReasonCode int
 }
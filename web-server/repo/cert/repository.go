@@ -1,6 +1,7 @@
 package cert
 
 import (
+	"bytes"
 	"context"
 	"crypto"
 	"crypto/ecdsa"
@@ -14,6 +15,8 @@ import (
 	"os"
 	"time"
 
+	"golang.org/x/crypto/ocsp"
+
 	"rootstock/web-server/config"
 )
 
@@ -33,18 +36,42 @@ type getCACertReq struct {
 	resp chan response[*CACert]
 }
 
+type signCRLReq struct {
+	ctx   context.Context
+	input SignCRLInput
+	resp  chan response[*CRL]
+}
+
+type parseOCSPReq struct {
+	ctx  context.Context
+	der  []byte
+	resp chan response[*OCSPRequest]
+}
+
+type signOCSPReq struct {
+	ctx   context.Context
+	input OCSPResponseInput
+	resp  chan response[*OCSPResponse]
+}
+
 type shutdownReq struct {
 	resp chan struct{}
 }
 
 type caRepo struct {
-	caCert       *x509.Certificate
-	caCertPEM    []byte
-	caSigner     crypto.Signer
-	lifetimeDays int
-	issueCh      chan issueReq
-	getCACertCh  chan getCACertReq
-	shutdownCh   chan shutdownReq
+	caCert        *x509.Certificate
+	caCertPEM     []byte
+	caSigner      crypto.Signer
+	lifetimeDays  int
+	crlValidity   time.Duration
+	ocspValidity  time.Duration
+	revocationURL string
+	issueCh       chan issueReq
+	getCACertCh   chan getCACertReq
+	signCRLCh     chan signCRLReq
+	parseOCSPCh   chan parseOCSPReq
+	signOCSPCh    chan signOCSPReq
+	shutdownCh    chan shutdownReq
 }
 
 // NewRepository creates a cert repository backed by an in-process X.509 CA.
@@ -91,13 +118,19 @@ func NewRepository(cfg config.CertConfig) (Repository, error) {
 	}
 
 	r := &caRepo{
-		caCert:       caCert,
-		caCertPEM:    certPEM,
-		caSigner:     signer,
-		lifetimeDays: cfg.CertLifetimeDays,
-		issueCh:      make(chan issueReq),
-		getCACertCh:  make(chan getCACertReq),
-		shutdownCh:   make(chan shutdownReq),
+		caCert:        caCert,
+		caCertPEM:     certPEM,
+		caSigner:      signer,
+		lifetimeDays:  cfg.CertLifetimeDays,
+		crlValidity:   time.Duration(cfg.CRLValidityHours) * time.Hour,
+		ocspValidity:  time.Duration(cfg.OCSPValidityMinutes) * time.Minute,
+		revocationURL: cfg.RevocationBaseURL,
+		issueCh:       make(chan issueReq),
+		getCACertCh:   make(chan getCACertReq),
+		signCRLCh:     make(chan signCRLReq),
+		parseOCSPCh:   make(chan parseOCSPReq),
+		signOCSPCh:    make(chan signOCSPReq),
+		shutdownCh:    make(chan shutdownReq),
 	}
 	go r.manage()
 	return r, nil
@@ -111,6 +144,15 @@ func (r *caRepo) manage() {
 			req.resp <- response[*IssuedCert]{val: val, err: err}
 		case req := <-r.getCACertCh:
 			req.resp <- response[*CACert]{val: &CACert{CertPEM: r.caCertPEM}}
+		case req := <-r.signCRLCh:
+			val, err := r.doSignCRL(req.ctx, req.input)
+			req.resp <- response[*CRL]{val: val, err: err}
+		case req := <-r.parseOCSPCh:
+			val, err := r.doParseOCSPRequest(req.ctx, req.der)
+			req.resp <- response[*OCSPRequest]{val: val, err: err}
+		case req := <-r.signOCSPCh:
+			val, err := r.doSignOCSPResponse(req.ctx, req.input)
+			req.resp <- response[*OCSPResponse]{val: val, err: err}
 		case req := <-r.shutdownCh:
 			close(req.resp)
 			return
@@ -132,6 +174,27 @@ func (r *caRepo) GetCACert(ctx context.Context) (*CACert, error) {
 	return res.val, res.err
 }
 
+func (r *caRepo) SignCRL(ctx context.Context, input SignCRLInput) (*CRL, error) {
+	resp := make(chan response[*CRL], 1)
+	r.signCRLCh <- signCRLReq{ctx: ctx, input: input, resp: resp}
+	res := <-resp
+	return res.val, res.err
+}
+
+func (r *caRepo) ParseOCSPRequest(ctx context.Context, der []byte) (*OCSPRequest, error) {
+	resp := make(chan response[*OCSPRequest], 1)
+	r.parseOCSPCh <- parseOCSPReq{ctx: ctx, der: der, resp: resp}
+	res := <-resp
+	return res.val, res.err
+}
+
+func (r *caRepo) SignOCSPResponse(ctx context.Context, input OCSPResponseInput) (*OCSPResponse, error) {
+	resp := make(chan response[*OCSPResponse], 1)
+	r.signOCSPCh <- signOCSPReq{ctx: ctx, input: input, resp: resp}
+	res := <-resp
+	return res.val, res.err
+}
+
 func (r *caRepo) Shutdown() {
 	resp := make(chan struct{}, 1)
 	r.shutdownCh <- shutdownReq{resp: resp}
@@ -182,6 +245,13 @@ func (r *caRepo) doIssue(_ context.Context, input IssueCertInput) (*IssuedCert,
 		KeyUsage:     x509.KeyUsageDigitalSignature,
 		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
 	}
+	if input.Kind != "" {
+		template.Subject.OrganizationalUnit = []string{input.Kind}
+	}
+	if r.revocationURL != "" {
+		template.CRLDistributionPoints = []string{r.revocationURL + "/crl"}
+		template.OCSPServer = []string{r.revocationURL + "/ocsp"}
+	}
 
 	certDER, err := x509.CreateCertificate(rand.Reader, template, r.caCert, csr.PublicKey, r.caSigner)
 	if err != nil {
@@ -197,3 +267,94 @@ func (r *caRepo) doIssue(_ context.Context, input IssueCertInput) (*IssuedCert,
 		NotAfter:  notAfter,
 	}, nil
 }
+
+// doSignCRL signs a CRL listing the given entries, valid for the configured CRL validity.
+func (r *caRepo) doSignCRL(_ context.Context, input SignCRLInput) (*CRL, error) {
+	entries := make([]x509.RevocationListEntry, len(input.Entries))
+	for i, e := range input.Entries {
+		serial, err := parseSerial(e.Serial)
+		if err != nil {
+			return nil, err
+		}
+		entries[i] = x509.RevocationListEntry{
+			SerialNumber:   serial,
+			RevocationTime: e.RevokedAt.UTC(),
+			ReasonCode:     e.ReasonCode,
+		}
+	}
+
+	now := time.Now().UTC()
+	next := now.Add(r.crlValidity)
+	der, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+		Number:                    big.NewInt(input.Number),
+		ThisUpdate:                now,
+		NextUpdate:                next,
+		RevokedCertificateEntries: entries,
+	}, r.caCert, r.caSigner)
+	if err != nil {
+		return nil, fmt.Errorf("create revocation list: %w", err)
+	}
+	return &CRL{DER: der, ThisUpdate: now, NextUpdate: next}, nil
+}
+
+// doParseOCSPRequest parses a DER OCSP request and reports whether it names this CA as issuer.
+func (r *caRepo) doParseOCSPRequest(_ context.Context, der []byte) (*OCSPRequest, error) {
+	req, err := ocsp.ParseRequest(der)
+	if err != nil {
+		return nil, fmt.Errorf("parse ocsp request: %w", err)
+	}
+
+	// Build the request this CA would expect for the serial and compare issuer hashes.
+	expectedDER, err := ocsp.CreateRequest(&x509.Certificate{SerialNumber: req.SerialNumber}, r.caCert, &ocsp.RequestOptions{Hash: req.HashAlgorithm})
+	if err != nil {
+		return nil, fmt.Errorf("parse ocsp request: %w", err)
+	}
+	expected, err := ocsp.ParseRequest(expectedDER)
+	if err != nil {
+		return nil, fmt.Errorf("parse ocsp request: %w", err)
+	}
+	return &OCSPRequest{
+		Serial:      fmt.Sprintf("%x", req.SerialNumber),
+		KnownIssuer: bytes.Equal(req.IssuerNameHash, expected.IssuerNameHash) && bytes.Equal(req.IssuerKeyHash, expected.IssuerKeyHash),
+	}, nil
+}
+
+// doSignOCSPResponse signs an OCSP response with the CA key, valid for the configured OCSP validity.
+func (r *caRepo) doSignOCSPResponse(_ context.Context, input OCSPResponseInput) (*OCSPResponse, error) {
+	serial, err := parseSerial(input.Serial)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	template := ocsp.Response{
+		SerialNumber: serial,
+		ThisUpdate:   now,
+		NextUpdate:   now.Add(r.ocspValidity),
+	}
+	switch input.Status {
+	case "good":
+		template.Status = ocsp.Good
+	case "revoked":
+		template.Status = ocsp.Revoked
+		template.RevokedAt = input.RevokedAt.UTC()
+		template.RevocationReason = input.ReasonCode
+	default:
+		template.Status = ocsp.Unknown
+	}
+
+	der, err := ocsp.CreateResponse(r.caCert, r.caCert, template, r.caSigner)
+	if err != nil {
+		return nil, fmt.Errorf("create ocsp response: %w", err)
+	}
+	return &OCSPResponse{DER: der, NextUpdate: template.NextUpdate}, nil
+}
+
+// parseSerial decodes a hex-encoded certificate serial.
+func parseSerial(hex string) (*big.Int, error) {
+	serial, ok := new(big.Int).SetString(hex, 16)
+	if !ok {
+		return nil, fmt.Errorf("invalid certificate serial %q", hex)
+	}
+	return serial, nil
+}
@@ -14,6 +14,8 @@ import (
 	"testing"
 	"time"
 
+	"golang.org/x/crypto/ocsp"
+
 	"rootstock/web-server/config"
 )
 
@@ -35,7 +37,7 @@ func setupTest(t *testing.T) Repository {
 		NotAfter:              mustNow().AddDate(1, 0, 0),
 		IsCA:                  true,
 		BasicConstraintsValid: true,
-		KeyUsage:              x509.KeyUsageCertSign,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
 	}
 	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
 	if err != nil {
@@ -61,9 +63,11 @@ func setupTest(t *testing.T) Repository {
 	}
 
 	cfg := config.CertConfig{
-		CACertPath:       certPath,
-		CAKeyPath:        keyPath,
-		CertLifetimeDays: 90,
+		CACertPath:          certPath,
+		CAKeyPath:           keyPath,
+		CertLifetimeDays:    90,
+		CRLValidityHours:    24,
+		OCSPValidityMinutes: 60,
 	}
 
 	repo, err := NewRepository(cfg)
@@ -145,6 +149,35 @@ func TestIssueCertSetsDeviceIDNotCSRSubject(t *testing.T) {
 	}
 }
 
+func TestIssueCertRecordsKind(t *testing.T) {
+	repo := setupTest(t)
+	ctx := context.Background()
+
+	issued, err := repo.IssueCert(ctx, IssueCertInput{
+		DeviceID: "gateway-1",
+		CSR:      generateTestCSR(t),
+		Kind:     "gateway",
+	})
+	if err != nil {
+		t.Fatalf("IssueCert(): %v", err)
+	}
+	block, _ := pem.Decode(issued.CertPEM)
+	cert, _ := x509.ParseCertificate(block.Bytes)
+	if len(cert.Subject.OrganizationalUnit) != 1 || cert.Subject.OrganizationalUnit[0] != "gateway" {
+		t.Errorf("OU = %v, want [gateway]", cert.Subject.OrganizationalUnit)
+	}
+
+	issued, err = repo.IssueCert(ctx, IssueCertInput{DeviceID: "device-1", CSR: generateTestCSR(t)})
+	if err != nil {
+		t.Fatalf("IssueCert(): %v", err)
+	}
+	block, _ = pem.Decode(issued.CertPEM)
+	cert, _ = x509.ParseCertificate(block.Bytes)
+	if len(cert.Subject.OrganizationalUnit) != 0 {
+		t.Errorf("device OU = %v, want none", cert.Subject.OrganizationalUnit)
+	}
+}
+
 func TestIssueCertRejectsWeakKey(t *testing.T) {
 	repo := setupTest(t)
 	ctx := context.Background()
@@ -189,8 +222,124 @@ func TestGetCACert(t *testing.T) {
 	}
 }
 
+func TestSignCRL(t *testing.T) {
+	repo := setupTest(t)
+	ctx := context.Background()
+
+	revokedAt := time.Now().UTC().Add(-time.Hour).Truncate(time.Second)
+	crl, err := repo.SignCRL(ctx, SignCRLInput{
+		Number: 7,
+		Entries: []CRLEntry{
+			{Serial: "0a1b", RevokedAt: revokedAt, ReasonCode: 4},
+			{Serial: "ff", RevokedAt: revokedAt, ReasonCode: 6},
+		},
+	})
+	if err != nil {
+		t.Fatalf("SignCRL(): %v", err)
+	}
+
+	parsed, err := x509.ParseRevocationList(crl.DER)
+	if err != nil {
+		t.Fatalf("parse crl: %v", err)
+	}
+	ca := caCertOf(t, repo)
+	if err := parsed.CheckSignatureFrom(ca); err != nil {
+		t.Errorf("crl signature: %v", err)
+	}
+	if parsed.Number.Int64() != 7 {
+		t.Errorf("crl number = %v, want 7", parsed.Number)
+	}
+	if len(parsed.RevokedCertificateEntries) != 2 {
+		t.Fatalf("entries = %d, want 2", len(parsed.RevokedCertificateEntries))
+	}
+	e := parsed.RevokedCertificateEntries[0]
+	if e.SerialNumber.Cmp(big.NewInt(0x0a1b)) != 0 || e.ReasonCode != 4 || !e.RevocationTime.Equal(revokedAt) {
+		t.Errorf("entry = serial %x reason %d at %v", e.SerialNumber, e.ReasonCode, e.RevocationTime)
+	}
+	if !parsed.NextUpdate.After(parsed.ThisUpdate) {
+		t.Errorf("nextUpdate %v not after thisUpdate %v", parsed.NextUpdate, parsed.ThisUpdate)
+	}
+}
+
+func TestOCSPRoundTrip(t *testing.T) {
+	repo := setupTest(t)
+	ctx := context.Background()
+	ca := caCertOf(t, repo)
+
+	issued, err := repo.IssueCert(ctx, IssueCertInput{DeviceID: "dev-ocsp", CSR: generateTestCSR(t)})
+	if err != nil {
+		t.Fatalf("IssueCert(): %v", err)
+	}
+	block, _ := pem.Decode(issued.CertPEM)
+	cert, _ := x509.ParseCertificate(block.Bytes)
+
+	reqDER, err := ocsp.CreateRequest(cert, ca, nil)
+	if err != nil {
+		t.Fatalf("create ocsp request: %v", err)
+	}
+	req, err := repo.ParseOCSPRequest(ctx, reqDER)
+	if err != nil {
+		t.Fatalf("ParseOCSPRequest(): %v", err)
+	}
+	if req.Serial != issued.Serial {
+		t.Errorf("serial = %q, want %q", req.Serial, issued.Serial)
+	}
+
+	revokedAt := time.Now().UTC().Add(-time.Minute).Truncate(time.Second)
+	resp, err := repo.SignOCSPResponse(ctx, OCSPResponseInput{Serial: req.Serial, Status: "revoked", RevokedAt: revokedAt, ReasonCode: 6})
+	if err != nil {
+		t.Fatalf("SignOCSPResponse(): %v", err)
+	}
+	parsed, err := ocsp.ParseResponseForCert(resp.DER, cert, ca)
+	if err != nil {
+		t.Fatalf("parse ocsp response: %v", err)
+	}
+	if parsed.Status != ocsp.Revoked || parsed.RevocationReason != 6 || !parsed.RevokedAt.Equal(revokedAt) {
+		t.Errorf("response = status %d reason %d at %v", parsed.Status, parsed.RevocationReason, parsed.RevokedAt)
+	}
+}
+
+func TestParseOCSPRequestRejectsOtherIssuer(t *testing.T) {
+	repo := setupTest(t)
+	other := setupTest(t)
+	ctx := context.Background()
+
+	issued, err := other.IssueCert(ctx, IssueCertInput{DeviceID: "dev-elsewhere", CSR: generateTestCSR(t)})
+	if err != nil {
+		t.Fatalf("IssueCert(): %v", err)
+	}
+	block, _ := pem.Decode(issued.CertPEM)
+	cert, _ := x509.ParseCertificate(block.Bytes)
+
+	reqDER, err := ocsp.CreateRequest(cert, caCertOf(t, other), nil)
+	if err != nil {
+		t.Fatalf("create ocsp request: %v", err)
+	}
+	req, err := repo.ParseOCSPRequest(ctx, reqDER)
+	if err != nil {
+		t.Fatalf("ParseOCSPRequest(): %v", err)
+	}
+	if req.KnownIssuer {
+		t.Error("ParseOCSPRequest() KnownIssuer = true for a request for another CA")
+	}
+}
+
 // --- helpers ---
 
+func caCertOf(t *testing.T, repo Repository) *x509.Certificate {
+	t.Helper()
+	ca, err := repo.GetCACert(context.Background())
+	if err != nil {
+		t.Fatalf("GetCACert(): %v", err)
+	}
+	block, _ := pem.Decode(ca.CertPEM)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatalf("parse ca cert: %v", err)
+	}
+	return cert
+}
+
 func generateTestCSR(t *testing.T) []byte {
 	t.Helper()
 	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
@@ -0,0 +1,18 @@
+package deadletter
+
+import "time"
+
+// DeadLetter is the dead_letters record.
+type DeadLetter struct {
+	ID             string
+	Topic          string
+	DeviceID       string
+	CampaignID     *string
+	Payload        []byte
+	ErrorClass     string
+	ErrorMessage   string
+	ReceivedAt     time.Time
+	Status         string // pending | replayed | spooled (held in memory until Postgres is reachable)
+	ReplayCount    int
+	LastReplayedAt *time.Time
+}
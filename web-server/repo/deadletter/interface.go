@@ -0,0 +1,13 @@
+package deadletter
+
+import "context"
+
+// Repository defines the interface for dead-letter storage.
+type Repository interface {
+	Record(ctx context.Context, input RecordInput) (*DeadLetter, error)
+	List(ctx context.Context, input ListInput) ([]DeadLetter, error)
+	Get(ctx context.Context, id string) (*DeadLetter, error)
+	MarkReplayed(ctx context.Context, id string) (*DeadLetter, error)
+	Purge(ctx context.Context, input PurgeInput) (int64, error)
+	Shutdown()
+}
@@ -0,0 +1,29 @@
+package deadletter
+
+import "time"
+
+// RecordInput is what the RecordDeadLetter op sends to the repository.
+type RecordInput struct {
+	Topic        string
+	DeviceID     string
+	CampaignID   string // may be empty
+	Payload      []byte
+	ErrorClass   string
+	ErrorMessage string
+}
+
+// ListInput is what the ListDeadLetters op sends to the repository. Empty filters match all.
+type ListInput struct {
+	ErrorClass string
+	DeviceID   string
+	Status     string
+	Limit      int
+	Offset     int
+}
+
+// PurgeInput selects dead letters to delete. Set filters are ANDed together.
+type PurgeInput struct {
+	IDs            []string
+	ErrorClass     string
+	ReceivedBefore *time.Time
+}
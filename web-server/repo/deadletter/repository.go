@@ -0,0 +1,410 @@
+package deadletter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/oklog/ulid/v2"
+)
+
+type response[T any] struct {
+	val T
+	err error
+}
+
+type recordReq struct {
+	ctx   context.Context
+	input RecordInput
+	resp  chan response[*DeadLetter]
+}
+
+type listReq struct {
+	ctx   context.Context
+	input ListInput
+	resp  chan response[[]DeadLetter]
+}
+
+type getReq struct {
+	ctx  context.Context
+	id   string
+	resp chan response[*DeadLetter]
+}
+
+type markReplayedReq struct {
+	ctx  context.Context
+	id   string
+	resp chan response[*DeadLetter]
+}
+
+type purgeReq struct {
+	ctx   context.Context
+	input PurgeInput
+	resp  chan response[int64]
+}
+
+type shutdownReq struct {
+	resp chan struct{}
+}
+
+// Dead letters that cannot be stored because Postgres is unreachable are spooled in memory,
+// up to spoolCapacity, and retried every spoolRetryInterval in arrival order. When the spool
+// is full the oldest letter is dropped to the log. The spool does not survive a restart.
+// Every insert is bounded by insertTimeout, so an unresponsive database holds the
+// repository's goroutine for at most that long per attempt.
+const (
+	spoolCapacity      = 10000
+	spoolRetryInterval = 30 * time.Second
+	insertTimeout      = 5 * time.Second
+)
+
+// StatusSpooled is the status of a dead letter held in memory until it can be stored.
+const StatusSpooled = "spooled"
+
+type spooledLetter struct {
+	id         string
+	input      RecordInput
+	receivedAt time.Time
+}
+
+type pgRepo struct {
+	pool           *pgxpool.Pool
+	recordCh       chan recordReq
+	listCh         chan listReq
+	getCh          chan getReq
+	markReplayedCh chan markReplayedReq
+	purgeCh        chan purgeReq
+	shutdownCh     chan shutdownReq
+	spool          []spooledLetter
+	spoolCapacity  int
+}
+
+// NewRepository creates a dead-letter repository backed by Postgres.
+func NewRepository(pool *pgxpool.Pool) Repository {
+	r := &pgRepo{
+		pool:           pool,
+		recordCh:       make(chan recordReq),
+		listCh:         make(chan listReq),
+		getCh:          make(chan getReq),
+		markReplayedCh: make(chan markReplayedReq),
+		purgeCh:        make(chan purgeReq),
+		shutdownCh:     make(chan shutdownReq),
+		spoolCapacity:  spoolCapacity,
+	}
+	go r.manage()
+	return r
+}
+
+func (r *pgRepo) manage() {
+	retry := time.NewTicker(spoolRetryInterval)
+	defer retry.Stop()
+	for {
+		select {
+		case <-retry.C:
+			r.flushSpool(context.Background())
+		case req := <-r.recordCh:
+			val, err := r.doRecord(req.ctx, req.input)
+			req.resp <- response[*DeadLetter]{val: val, err: err}
+		case req := <-r.listCh:
+			val, err := r.doList(req.ctx, req.input)
+			req.resp <- response[[]DeadLetter]{val: val, err: err}
+		case req := <-r.getCh:
+			val, err := r.doGet(req.ctx, req.id)
+			req.resp <- response[*DeadLetter]{val: val, err: err}
+		case req := <-r.markReplayedCh:
+			val, err := r.doMarkReplayed(req.ctx, req.id)
+			req.resp <- response[*DeadLetter]{val: val, err: err}
+		case req := <-r.purgeCh:
+			val, err := r.doPurge(req.ctx, req.input)
+			req.resp <- response[int64]{val: val, err: err}
+		case req := <-r.shutdownCh:
+			r.flushSpool(context.Background())
+			for _, l := range r.spool {
+				slog.Error("dead letter: spooled letter lost at shutdown",
+					"topic", l.input.Topic, "device_id", l.input.DeviceID, "error_class", l.input.ErrorClass, "payload", string(l.input.Payload))
+			}
+			close(req.resp)
+			return
+		}
+	}
+}
+
+// Record stores a dead letter. While Postgres is unreachable the letter is spooled and
+// returned with status spooled and no error; it is stored when the database comes back.
+// A letter the database refuses is not spooled: the error is returned.
+func (r *pgRepo) Record(ctx context.Context, input RecordInput) (*DeadLetter, error) {
+	resp := make(chan response[*DeadLetter], 1)
+	r.recordCh <- recordReq{ctx: ctx, input: input, resp: resp}
+	res := <-resp
+	return res.val, res.err
+}
+
+func (r *pgRepo) List(ctx context.Context, input ListInput) ([]DeadLetter, error) {
+	resp := make(chan response[[]DeadLetter], 1)
+	r.listCh <- listReq{ctx: ctx, input: input, resp: resp}
+	res := <-resp
+	return res.val, res.err
+}
+
+func (r *pgRepo) Get(ctx context.Context, id string) (*DeadLetter, error) {
+	resp := make(chan response[*DeadLetter], 1)
+	r.getCh <- getReq{ctx: ctx, id: id, resp: resp}
+	res := <-resp
+	return res.val, res.err
+}
+
+func (r *pgRepo) MarkReplayed(ctx context.Context, id string) (*DeadLetter, error) {
+	resp := make(chan response[*DeadLetter], 1)
+	r.markReplayedCh <- markReplayedReq{ctx: ctx, id: id, resp: resp}
+	res := <-resp
+	return res.val, res.err
+}
+
+func (r *pgRepo) Purge(ctx context.Context, input PurgeInput) (int64, error) {
+	resp := make(chan response[int64], 1)
+	r.purgeCh <- purgeReq{ctx: ctx, input: input, resp: resp}
+	res := <-resp
+	return res.val, res.err
+}
+
+func (r *pgRepo) Shutdown() {
+	resp := make(chan struct{}, 1)
+	r.shutdownCh <- shutdownReq{resp: resp}
+	<-resp
+}
+
+// --- implementation ---
+
+const deadLetterColumns = `id, topic, device_id, campaign_id, payload, error_class, error_message, received_at, status, replay_count, last_replayed_at`
+
+func scanDeadLetter(row pgx.Row) (*DeadLetter, error) {
+	var d DeadLetter
+	err := row.Scan(&d.ID, &d.Topic, &d.DeviceID, &d.CampaignID, &d.Payload, &d.ErrorClass, &d.ErrorMessage, &d.ReceivedAt, &d.Status, &d.ReplayCount, &d.LastReplayedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (r *pgRepo) doRecord(ctx context.Context, input RecordInput) (*DeadLetter, error) {
+	letter := spooledLetter{id: ulid.Make().String(), input: input, receivedAt: time.Now().UTC()}
+	// Keep arrival order: while letters are spooled, new ones queue behind them
+	if len(r.spool) == 0 {
+		d, err := r.insert(ctx, letter)
+		if err == nil {
+			return d, nil
+		}
+		if !isConnectionError(err) {
+			return nil, err
+		}
+		slog.WarnContext(ctx, "dead letter: database unreachable, spooling", "error", err)
+	}
+	r.spoolLetter(ctx, letter)
+
+	var campaignID *string
+	if input.CampaignID != "" {
+		campaignID = &input.CampaignID
+	}
+	return &DeadLetter{
+		ID:           letter.id,
+		Topic:        input.Topic,
+		DeviceID:     input.DeviceID,
+		CampaignID:   campaignID,
+		Payload:      input.Payload,
+		ErrorClass:   input.ErrorClass,
+		ErrorMessage: input.ErrorMessage,
+		ReceivedAt:   letter.receivedAt,
+		Status:       StatusSpooled,
+	}, nil
+}
+
+// insert stores a letter under the ID and time it was first recorded with, so a letter
+// stored by an attempt whose reply was lost is not stored twice.
+func (r *pgRepo) insert(ctx context.Context, l spooledLetter) (*DeadLetter, error) {
+	var campaignID *string
+	if l.input.CampaignID != "" {
+		campaignID = &l.input.CampaignID
+	}
+	payload := l.input.Payload
+	if payload == nil {
+		payload = []byte{}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, insertTimeout)
+	defer cancel()
+	d, err := scanDeadLetter(r.pool.QueryRow(ctx,
+		`INSERT INTO dead_letters (id, topic, device_id, campaign_id, payload, error_class, error_message, received_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		 ON CONFLICT (id) DO UPDATE SET id = EXCLUDED.id
+		 RETURNING `+deadLetterColumns,
+		l.id, l.input.Topic, l.input.DeviceID, campaignID, payload, l.input.ErrorClass, l.input.ErrorMessage, l.receivedAt,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("insert dead letter: %w", err)
+	}
+	return d, nil
+}
+
+// spoolLetter holds a letter for a later attempt, dropping the oldest to the log when full.
+func (r *pgRepo) spoolLetter(ctx context.Context, l spooledLetter) {
+	if len(r.spool) >= r.spoolCapacity {
+		dropped := r.spool[0]
+		r.spool = r.spool[1:]
+		slog.ErrorContext(ctx, "dead letter: spool full, dropping oldest",
+			"topic", dropped.input.Topic, "device_id", dropped.input.DeviceID, "error_class", dropped.input.ErrorClass, "payload", string(dropped.input.Payload))
+	}
+	r.spool = append(r.spool, l)
+}
+
+// flushSpool stores spooled letters in order, stopping while the database is still
+// unreachable. A letter the database refuses outright is dropped to the log rather than
+// holding up the letters behind it.
+func (r *pgRepo) flushSpool(ctx context.Context) {
+	done, stored := 0, 0
+	for _, l := range r.spool {
+		if _, err := r.insert(ctx, l); err != nil {
+			if isConnectionError(err) {
+				break
+			}
+			slog.ErrorContext(ctx, "dead letter: spooled letter refused, dropping",
+				"topic", l.input.Topic, "device_id", l.input.DeviceID, "error_class", l.input.ErrorClass, "payload", string(l.input.Payload), "error", err)
+		} else {
+			stored++
+		}
+		done++
+	}
+	if done == 0 {
+		return
+	}
+	r.spool = append([]spooledLetter(nil), r.spool[done:]...)
+	slog.InfoContext(ctx, "dead letter: flushed spooled letters", "stored", stored, "dropped", done-stored, "remaining", len(r.spool))
+}
+
+// isConnectionError reports whether err means the database could not be reached or went
+// away mid-statement, rather than that it refused the statement.
+func isConnectionError(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		// Class 08 is connection exception; 57P01-57P03 are shutdowns and "cannot connect now"
+		return strings.HasPrefix(pgErr.Code, "08") || strings.HasPrefix(pgErr.Code, "57P")
+	}
+	var connectErr *pgconn.ConnectError
+	var netErr net.Error
+	return errors.As(err, &connectErr) || errors.As(err, &netErr) ||
+		errors.Is(err, context.DeadlineExceeded) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		pgconn.Timeout(err)
+}
+
+func (r *pgRepo) doList(ctx context.Context, input ListInput) ([]DeadLetter, error) {
+	query := `SELECT ` + deadLetterColumns + ` FROM dead_letters WHERE 1=1`
+	args := []any{}
+	argIdx := 1
+
+	if input.ErrorClass != "" {
+		query += fmt.Sprintf(" AND error_class = $%d", argIdx)
+		args = append(args, input.ErrorClass)
+		argIdx++
+	}
+	if input.DeviceID != "" {
+		query += fmt.Sprintf(" AND device_id = $%d", argIdx)
+		args = append(args, input.DeviceID)
+		argIdx++
+	}
+	if input.Status != "" {
+		query += fmt.Sprintf(" AND status = $%d", argIdx)
+		args = append(args, input.Status)
+		argIdx++
+	}
+
+	query += " ORDER BY received_at DESC"
+
+	if input.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", argIdx)
+		args = append(args, input.Limit)
+		argIdx++
+	}
+	if input.Offset > 0 {
+		query += fmt.Sprintf(" OFFSET $%d", argIdx)
+		args = append(args, input.Offset)
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query dead letters: %w", err)
+	}
+	defer rows.Close()
+
+	var out []DeadLetter
+	for rows.Next() {
+		d, err := scanDeadLetter(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan dead letter: %w", err)
+		}
+		out = append(out, *d)
+	}
+	return out, rows.Err()
+}
+
+func (r *pgRepo) doGet(ctx context.Context, id string) (*DeadLetter, error) {
+	d, err := scanDeadLetter(r.pool.QueryRow(ctx,
+		`SELECT `+deadLetterColumns+` FROM dead_letters WHERE id = $1`, id,
+	))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("dead letter %s not found", id)
+		}
+		return nil, fmt.Errorf("get dead letter: %w", err)
+	}
+	return d, nil
+}
+
+func (r *pgRepo) doMarkReplayed(ctx context.Context, id string) (*DeadLetter, error) {
+	d, err := scanDeadLetter(r.pool.QueryRow(ctx,
+		`UPDATE dead_letters
+		 SET status = 'replayed', replay_count = replay_count + 1, last_replayed_at = now()
+		 WHERE id = $1
+		 RETURNING `+deadLetterColumns, id,
+	))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("dead letter %s not found", id)
+		}
+		return nil, fmt.Errorf("mark dead letter replayed: %w", err)
+	}
+	return d, nil
+}
+
+func (r *pgRepo) doPurge(ctx context.Context, input PurgeInput) (int64, error) {
+	query := `DELETE FROM dead_letters WHERE 1=1`
+	args := []any{}
+	argIdx := 1
+
+	if len(input.IDs) > 0 {
+		query += fmt.Sprintf(" AND id = ANY($%d)", argIdx)
+		args = append(args, input.IDs)
+		argIdx++
+	}
+	if input.ErrorClass != "" {
+		query += fmt.Sprintf(" AND error_class = $%d", argIdx)
+		args = append(args, input.ErrorClass)
+		argIdx++
+	}
+	if input.ReceivedBefore != nil {
+		query += fmt.Sprintf(" AND received_at < $%d", argIdx)
+		args = append(args, input.ReceivedBefore)
+	}
+
+	tag, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("purge dead letters: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
@@ -11,7 +11,10 @@ type Device struct {
 	FirmwareVersion string
 	Tier            int
 	Sensors         []string
+	SensorUnits     map[string]string // sensor -> UCUM unit, as declared for this device
 	CertSerial      *string
+	Gateway         bool    // enrolls and relays for child devices
+	GatewayID       *string // gateway that enrolled this device, if any
 	CreatedAt       time.Time
 }
 
@@ -30,3 +33,173 @@ type EnrollmentCode struct {
 	ExpiresAt time.Time
 	Used      bool
 }
+
+// ClockSkew is a device's smoothed clock skew estimate.
+type ClockSkew struct {
+	DeviceID   string
+	OwnerID    string
+	EstimateMs int64 // device clock minus server clock
+	Samples    int
+	UpdatedAt  time.Time
+	FlaggedAt  *time.Time // set while the owner has been told the clock is wrong
+}
+
+// RateLimitFlag is a device flagged for repeatedly exceeding ingestion rate limits.
+type RateLimitFlag struct {
+	DeviceID        string
+	OwnerID         string
+	Class           string
+	FirmwareVersion string
+	Status          string
+	Violations      int64 // messages dropped or quarantined for rate, all time
+	FlaggedAt       time.Time
+}
+
+// IssuedCertRecord is a certificate in the issued-certificate ledger.
+type IssuedCertRecord struct {
+	Serial           string
+	DeviceID         string
+	NotBefore        *time.Time // nil for certificates issued before the ledger existed
+	NotAfter         *time.Time
+	IssuedAt         time.Time
+	RevokedAt        *time.Time
+	RevocationReason string // superseded or certificate_hold; empty while the certificate is good
+}
+
+// RevokedCert is one entry of the certificate revocation list.
+type RevokedCert struct {
+	Serial    string
+	RevokedAt time.Time
+	Reason    string // superseded or certificate_hold
+}
+
+// RevocationList is the set of revoked, unexpired certificates and the CRL number to sign it under.
+// Revision identifies the state of the revoked set the entries were read at.
+type RevocationList struct {
+	Number   int64
+	Revision int64
+	Entries  []RevokedCert
+}
+
+// HealthState is a device's owner and currently raised health alerts, read after a heartbeat.
+type HealthState struct {
+	DeviceID     string
+	OwnerID      string
+	ActiveAlerts []string
+}
+
+// SilentDevice is an active device that has stopped sending heartbeats.
+type SilentDevice struct {
+	DeviceID      string
+	OwnerID       string
+	LastHeartbeat time.Time
+}
+
+// DeviceConfig is the campaign configuration last pushed to a device.
+type DeviceConfig struct {
+	DeviceID     string
+	Version      int64
+	Digest       string
+	CampaignIDs  []string
+	Campaigns    []byte // JSON
+	PushedAt     time.Time
+	AckedVersion *int64 // nil until the device acknowledges a version
+	AckedAt      *time.Time
+	Changed      bool // set by SaveDeviceConfig when the version moved
+}
+
+// DeviceCommand is one command sent to a device, with what the device reported back.
+type DeviceCommand struct {
+	ID          string
+	DeviceID    string
+	CampaignID  *string // set when sent to every device in a campaign
+	BatchID     string  // shared by the commands sent in one request
+	Command     string
+	Params      []byte // JSON
+	IssuedBy    string
+	IssuedAt    time.Time
+	ExpiresAt   time.Time
+	Status      string // sent, acknowledged, succeeded, failed or expired
+	AckedAt     *time.Time
+	CompletedAt *time.Time
+	Result      []byte // JSON; nil unless the device reported one
+	Error       *string
+}
+
+// CommandTargets are the devices an issuer asked to command and whether they may.
+type CommandTargets struct {
+	Permitted bool
+	DeviceIDs []string
+}
+
+// ShadowState is one side of a device shadow. A nil field is not set; an empty list is
+// set to nothing.
+type ShadowState struct {
+	SamplingIntervalSeconds *int
+	EnabledSensors          []string
+	ActiveCampaigns         []string
+	FirmwareVersion         *string
+}
+
+// DeviceShadow is the state a device should run and the state it last reported.
+type DeviceShadow struct {
+	DeviceID          string
+	Version           int64
+	Desired           ShadowState
+	Reported          ShadowState
+	DesiredUpdatedAt  *time.Time // when the desired side last changed
+	ReportedUpdatedAt *time.Time
+	Changed           bool // set by the updates when the version moved
+}
+
+// FirmwareRelease is a firmware build offered to a device class, with its rollout stage.
+type FirmwareRelease struct {
+	ID             string
+	Class          string
+	Version        string
+	SHA256         string
+	URL            string
+	SizeBytes      *int64
+	Notes          string
+	SecurityFix    bool // installing it reinstates a suspended device
+	RolloutPercent int
+	Cohort         []string // device IDs offered the release whatever the percentage
+	CreatedBy      string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// FirmwareCandidate is a device of a release's class not yet running its version.
+type FirmwareCandidate struct {
+	DeviceID        string
+	OwnerID         string
+	Status          string // active or suspended
+	FirmwareVersion string
+	InstallStatus   *string // nil until the device is sent the release
+}
+
+// FirmwareInstall is one device's progress installing a release.
+type FirmwareInstall struct {
+	ReleaseID  string
+	DeviceID   string
+	Status     string // notified, downloading, installing, installed or failed
+	Error      *string
+	NotifiedAt time.Time
+	UpdatedAt  time.Time
+}
+
+// GatewayChild is a device enrolled through a gateway.
+type GatewayChild struct {
+	DeviceID string
+	Status   string
+	Class    string
+}
+
+// StationLink is a device that uploads as a consumer weather station.
+type StationLink struct {
+	DeviceID     string
+	Protocol     string
+	ParameterMap map[string]string
+	CreatedAt    time.Time
+	LastUploadAt *time.Time
+}
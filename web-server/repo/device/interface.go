@@ -1,6 +1,13 @@
 package device
 
-import "context"
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrDeviceNotFound is returned by Get when no device has the ID.
+var ErrDeviceNotFound = errors.New("device not found")
 
 // Repository defines the interface for device registry operations.
 type Repository interface {
@@ -11,7 +18,57 @@ type Repository interface {
 	QueryByClass(ctx context.Context, input QueryByClassInput) ([]Device, error)
 	GenerateEnrollmentCode(ctx context.Context, input GenerateCodeInput) (*EnrollmentCode, error)
 	RedeemEnrollmentCode(ctx context.Context, code string) (*EnrollmentCode, error)
+	ListPendingEnrollmentCodes(ctx context.Context, ownerID string) ([]EnrollmentCode, error)
+	ExpireEnrollmentCodes(ctx context.Context, deviceID string) (int, error)
+	RegenerateEnrollmentCode(ctx context.Context, input GenerateCodeInput) (*EnrollmentCode, error)
 	EnrollInCampaign(ctx context.Context, deviceID string, campaignID string) error
 	UpdateCertSerial(ctx context.Context, id string, serial string) error
+	SetSensorUnits(ctx context.Context, id string, units map[string]string) error
+	SetClassUnits(ctx context.Context, input SetClassUnitsInput) error
+	GetClassUnits(ctx context.Context, class string) (map[string]string, error)
+	ResolveSensorUnits(ctx context.Context, id string) (map[string]string, error)
+	RecordClockSkew(ctx context.Context, input RecordClockSkewInput) (*ClockSkew, error)
+	SetClockSkewFlag(ctx context.Context, id string, flagged bool) error
+	FlagRateLimitViolator(ctx context.Context, input FlagRateLimitInput) (*RateLimitFlag, error)
+	ClearRateLimitFlag(ctx context.Context, id string) error
+	ListRateLimitFlagged(ctx context.Context) ([]RateLimitFlag, error)
+	RecordIssuedCert(ctx context.Context, input RecordIssuedCertInput) error
+	SetCertHold(ctx context.Context, id string, held bool) (int64, error)
+	ListRevokedCerts(ctx context.Context) (*RevocationList, error)
+	GetCRLRevision(ctx context.Context) (int64, error)
+	GetIssuedCert(ctx context.Context, serial string) (*IssuedCertRecord, error)
+	OpenSession(ctx context.Context, input OpenSessionInput) (string, error)
+	CloseSession(ctx context.Context, input CloseSessionInput) error
+	CloseOpenSessions(ctx context.Context, reason string) (int64, error)
+	RecordHealth(ctx context.Context, input RecordHealthInput) (*HealthState, error)
+	SetHealthAlerts(ctx context.Context, input SetHealthAlertsInput) error
+	ListSilentDevices(ctx context.Context, before time.Time) ([]SilentDevice, error)
+	ListConfigCampaigns(ctx context.Context, deviceID string, at time.Time) ([]string, error)
+	ListCampaignDevices(ctx context.Context, campaignID string) ([]string, error)
+	ListEndedConfigDevices(ctx context.Context, at time.Time) ([]string, error)
+	SaveDeviceConfig(ctx context.Context, input SaveDeviceConfigInput) (*DeviceConfig, error)
+	GetDeviceConfig(ctx context.Context, deviceID string) (*DeviceConfig, error)
+	AckDeviceConfig(ctx context.Context, deviceID string, version int64) (bool, error)
+	ListCommandTargets(ctx context.Context, input CommandTargetsInput) (*CommandTargets, error)
+	CreateCommands(ctx context.Context, input CreateCommandsInput) ([]DeviceCommand, error)
+	ListCommands(ctx context.Context, input ListCommandsInput) ([]DeviceCommand, error)
+	ListPendingCommands(ctx context.Context, deviceID string, at time.Time) ([]DeviceCommand, error)
+	RecordCommandResult(ctx context.Context, input RecordCommandResultInput) (bool, error)
+	GetShadow(ctx context.Context, deviceID string) (*DeviceShadow, error)
+	UpdateShadowDesired(ctx context.Context, input UpdateShadowInput) (*DeviceShadow, error)
+	UpdateShadowReported(ctx context.Context, input UpdateShadowInput) (*DeviceShadow, error)
+	CreateFirmwareRelease(ctx context.Context, input CreateFirmwareReleaseInput) (*FirmwareRelease, error)
+	GetFirmwareRelease(ctx context.Context, id string) (*FirmwareRelease, error)
+	ListFirmwareReleases(ctx context.Context, class string) ([]FirmwareRelease, error)
+	SetFirmwareRollout(ctx context.Context, input SetFirmwareRolloutInput) (*FirmwareRelease, error)
+	ListFirmwareCandidates(ctx context.Context, releaseID string) ([]FirmwareCandidate, error)
+	MarkFirmwareNotified(ctx context.Context, releaseID string, deviceIDs []string) error
+	RecordFirmwareInstall(ctx context.Context, input RecordFirmwareInstallInput) (bool, error)
+	ListFirmwareInstalls(ctx context.Context, releaseID string) ([]FirmwareInstall, error)
+	RedeemDelegatedCode(ctx context.Context, input RedeemDelegatedCodeInput) (*EnrollmentCode, error)
+	ListGatewayChildren(ctx context.Context, gatewayID string) ([]GatewayChild, error)
+	SaveStationLink(ctx context.Context, input SaveStationLinkInput) (*StationLink, error)
+	ResolveStationToken(ctx context.Context, tokenHash string) (*StationLink, error)
+	DeleteStationLink(ctx context.Context, deviceID string) (bool, error)
 	Shutdown()
 }
@@ -1,5 +1,7 @@
 package device
 
+import "time"
+
 // CreateDeviceInput is what the CreateDevice op sends to the repository.
 type CreateDeviceInput struct {
 	OwnerID         string
@@ -7,13 +9,14 @@ type CreateDeviceInput struct {
 	FirmwareVersion string
 	Tier            int
 	Sensors         []string
+	SensorUnits     map[string]string
+	Gateway         bool
 }
 
 // QueryByClassInput is what the QueryDevicesByClass op sends to the repository.
+// Firmware versions are matched by callers, which can compare them as versions.
 type QueryByClassInput struct {
-	Class          string
-	FirmwareMinGte string // firmware_version >= this
-	FirmwareMaxLte string // firmware_version <= this
+	Class string
 }
 
 // GenerateCodeInput is what the GenerateEnrollmentCode op sends to the repository.
@@ -22,3 +25,161 @@ type GenerateCodeInput struct {
 	Code     string
 	TTL      int // seconds
 }
+
+// SetClassUnitsInput is what the SetClassUnits op sends to the repository.
+// Units replaces every unit declared for the class.
+type SetClassUnitsInput struct {
+	Class string
+	Units map[string]string
+}
+
+// RecordClockSkewInput is what the RecordClockSkew op sends to the repository.
+// The stored estimate moves toward ObservedMs by Smoothing (0-1).
+type RecordClockSkewInput struct {
+	DeviceID   string
+	ObservedMs int64
+	Smoothing  float64
+}
+
+// FlagRateLimitInput is what the FlagRateLimitViolator op sends to the repository.
+type FlagRateLimitInput struct {
+	DeviceID   string
+	Violations int64 // added to the device's running count
+}
+
+// RecordIssuedCertInput is what the RecordIssuedCert op sends to the repository.
+type RecordIssuedCertInput struct {
+	DeviceID  string
+	Serial    string // hex-encoded
+	NotBefore time.Time
+	NotAfter  time.Time
+}
+
+// OpenSessionInput is what the OpenSession op sends to the repository.
+type OpenSessionInput struct {
+	DeviceID    string
+	ConnectedAt time.Time
+	RemoteAddr  string
+	CertSerial  string // hex-encoded; empty if unknown
+}
+
+// CloseSessionInput is what the CloseSession op sends to the repository.
+type CloseSessionInput struct {
+	ID             string
+	DisconnectedAt time.Time
+	Reason         string
+	Graceful       bool // the client sent DISCONNECT
+	WillPublished  bool // the broker published the client's last will as it dropped
+}
+
+// RecordHealthInput is what the RecordHealth op sends to the repository.
+type RecordHealthInput struct {
+	DeviceID        string
+	ReportedAt      time.Time
+	BatteryPercent  *float64
+	RSSIDbm         *int
+	UptimeSeconds   *int64
+	FreeMemoryBytes *int64
+	SensorFaults    []string
+	FirmwareBuild   *string
+}
+
+// SetHealthAlertsInput is what the SetHealthAlerts op sends to the repository.
+type SetHealthAlertsInput struct {
+	DeviceID string
+	Raise    []string
+	Clear    []string
+}
+
+// SaveDeviceConfigInput is what the SaveDeviceConfig op sends to the repository.
+type SaveDeviceConfigInput struct {
+	DeviceID    string
+	Digest      string // the version only moves when this changes
+	CampaignIDs []string
+	Campaigns   []byte // JSON
+}
+
+// CommandTargetsInput names one device or one campaign's devices to command.
+type CommandTargetsInput struct {
+	IssuerID   string // app user ID
+	DeviceID   string
+	CampaignID string
+	At         time.Time
+}
+
+// CreateCommandsInput is what the CreateCommands op sends to the repository: one command
+// for each device.
+type CreateCommandsInput struct {
+	DeviceIDs  []string
+	CampaignID *string
+	Command    string
+	Params     []byte // JSON
+	IssuedBy   string
+	ExpiresAt  time.Time
+}
+
+// ListCommandsInput filters command history by device or campaign, newest first.
+type ListCommandsInput struct {
+	DeviceID   string
+	CampaignID string
+	Limit      int
+}
+
+// RecordCommandResultInput is what the RecordCommandResult op sends to the repository.
+type RecordCommandResultInput struct {
+	DeviceID  string
+	CommandID string
+	Status    string // acknowledged, succeeded or failed
+	Result    []byte // JSON; nil if none
+	Error     *string
+}
+
+// UpdateShadowInput is what the shadow update ops send to the repository. Set fields
+// replace the stored ones; nil fields are left as they are.
+type UpdateShadowInput struct {
+	DeviceID string
+	State    ShadowState
+}
+
+// CreateFirmwareReleaseInput is what the CreateFirmwareRelease op sends to the repository.
+type CreateFirmwareReleaseInput struct {
+	Class          string
+	Version        string
+	SHA256         string
+	URL            string
+	SizeBytes      *int64
+	Notes          string
+	SecurityFix    bool
+	RolloutPercent int
+	Cohort         []string
+	CreatedBy      string
+}
+
+// SetFirmwareRolloutInput moves a release to a new rollout stage.
+type SetFirmwareRolloutInput struct {
+	ReleaseID      string
+	RolloutPercent int
+	Cohort         []string
+}
+
+// RecordFirmwareInstallInput is what the RecordFirmwareInstall op sends to the repository.
+type RecordFirmwareInstallInput struct {
+	DeviceID  string
+	ReleaseID string
+	Status    string // downloading, installing, installed or failed
+	Error     *string
+}
+
+// RedeemDelegatedCodeInput is what the RedeemDelegatedCode op sends to the repository.
+type RedeemDelegatedCodeInput struct {
+	Code      string
+	GatewayID string // must have the same owner as the code's device
+}
+
+// SaveStationLinkInput is what the LinkStation op sends to the repository.
+type SaveStationLinkInput struct {
+	DeviceID     string
+	Protocol     string
+	TokenHash    string // hex SHA-256 of the upload URL's token
+	ParameterMap map[string]string
+}
@@ -3,6 +3,7 @@ package device
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5"
@@ -58,6 +59,18 @@ type redeemCodeReq struct {
 	resp chan response[*EnrollmentCode]
 }
 
+type listPendingCodesReq struct {
+	ctx     context.Context
+	ownerID string
+	resp    chan response[[]EnrollmentCode]
+}
+
+type expireCodesReq struct {
+	ctx      context.Context
+	deviceID string
+	resp     chan response[int]
+}
+
 type enrollReq struct {
 	ctx        context.Context
 	deviceID   string
@@ -72,6 +85,287 @@ type updateCertSerialReq struct {
 	resp   chan response[struct{}]
 }
 
+type setSensorUnitsReq struct {
+	ctx   context.Context
+	id    string
+	units map[string]string
+	resp  chan response[struct{}]
+}
+
+type setClassUnitsReq struct {
+	ctx   context.Context
+	input SetClassUnitsInput
+	resp  chan response[struct{}]
+}
+
+type getClassUnitsReq struct {
+	ctx   context.Context
+	class string
+	resp  chan response[map[string]string]
+}
+
+type resolveUnitsReq struct {
+	ctx  context.Context
+	id   string
+	resp chan response[map[string]string]
+}
+
+type recordClockSkewReq struct {
+	ctx   context.Context
+	input RecordClockSkewInput
+	resp  chan response[*ClockSkew]
+}
+
+type setClockSkewFlagReq struct {
+	ctx     context.Context
+	id      string
+	flagged bool
+	resp    chan response[struct{}]
+}
+
+type flagRateLimitReq struct {
+	ctx   context.Context
+	input FlagRateLimitInput
+	resp  chan response[*RateLimitFlag]
+}
+
+type clearRateLimitFlagReq struct {
+	ctx  context.Context
+	id   string
+	resp chan response[struct{}]
+}
+
+type listRateLimitFlaggedReq struct {
+	ctx  context.Context
+	resp chan response[[]RateLimitFlag]
+}
+
+type recordIssuedCertReq struct {
+	ctx   context.Context
+	input RecordIssuedCertInput
+	resp  chan response[struct{}]
+}
+
+type setCertHoldReq struct {
+	ctx  context.Context
+	id   string
+	held bool
+	resp chan response[int64]
+}
+
+type listRevokedCertsReq struct {
+	ctx  context.Context
+	resp chan response[*RevocationList]
+}
+
+type getCRLRevisionReq struct {
+	ctx  context.Context
+	resp chan response[int64]
+}
+
+type getIssuedCertReq struct {
+	ctx    context.Context
+	serial string
+	resp   chan response[*IssuedCertRecord]
+}
+
+type openSessionReq struct {
+	ctx   context.Context
+	input OpenSessionInput
+	resp  chan response[string]
+}
+
+type closeSessionReq struct {
+	ctx   context.Context
+	input CloseSessionInput
+	resp  chan response[struct{}]
+}
+
+type closeOpenSessionsReq struct {
+	ctx    context.Context
+	reason string
+	resp   chan response[int64]
+}
+
+type recordHealthReq struct {
+	ctx   context.Context
+	input RecordHealthInput
+	resp  chan response[*HealthState]
+}
+
+type setHealthAlertsReq struct {
+	ctx   context.Context
+	input SetHealthAlertsInput
+	resp  chan response[struct{}]
+}
+
+type listSilentReq struct {
+	ctx    context.Context
+	before time.Time
+	resp   chan response[[]SilentDevice]
+}
+
+type listConfigCampaignsReq struct {
+	ctx      context.Context
+	deviceID string
+	at       time.Time
+	resp     chan response[[]string]
+}
+
+type listCampaignDevicesReq struct {
+	ctx        context.Context
+	campaignID string
+	resp       chan response[[]string]
+}
+
+type listEndedConfigReq struct {
+	ctx  context.Context
+	at   time.Time
+	resp chan response[[]string]
+}
+
+type saveConfigReq struct {
+	ctx   context.Context
+	input SaveDeviceConfigInput
+	resp  chan response[*DeviceConfig]
+}
+
+type getConfigReq struct {
+	ctx      context.Context
+	deviceID string
+	resp     chan response[*DeviceConfig]
+}
+
+type ackConfigReq struct {
+	ctx      context.Context
+	deviceID string
+	version  int64
+	resp     chan response[bool]
+}
+
+type cmdTargetsReq struct {
+	ctx   context.Context
+	input CommandTargetsInput
+	resp  chan response[*CommandTargets]
+}
+
+type createCmdsReq struct {
+	ctx   context.Context
+	input CreateCommandsInput
+	resp  chan response[[]DeviceCommand]
+}
+
+type listCmdsReq struct {
+	ctx   context.Context
+	input ListCommandsInput
+	resp  chan response[[]DeviceCommand]
+}
+
+type listPendingCmdsReq struct {
+	ctx      context.Context
+	deviceID string
+	at       time.Time
+	resp     chan response[[]DeviceCommand]
+}
+
+type cmdResultReq struct {
+	ctx   context.Context
+	input RecordCommandResultInput
+	resp  chan response[bool]
+}
+
+type getShadowReq struct {
+	ctx      context.Context
+	deviceID string
+	resp     chan response[*DeviceShadow]
+}
+
+type updateShadowReq struct {
+	ctx   context.Context
+	side  string // desired or reported
+	input UpdateShadowInput
+	resp  chan response[*DeviceShadow]
+}
+
+type createReleaseReq struct {
+	ctx   context.Context
+	input CreateFirmwareReleaseInput
+	resp  chan response[*FirmwareRelease]
+}
+
+type getReleaseReq struct {
+	ctx  context.Context
+	id   string
+	resp chan response[*FirmwareRelease]
+}
+
+type listReleasesReq struct {
+	ctx   context.Context
+	class string
+	resp  chan response[[]FirmwareRelease]
+}
+
+type setRolloutReq struct {
+	ctx   context.Context
+	input SetFirmwareRolloutInput
+	resp  chan response[*FirmwareRelease]
+}
+
+type listCandidatesReq struct {
+	ctx       context.Context
+	releaseID string
+	resp      chan response[[]FirmwareCandidate]
+}
+
+type markNotifiedReq struct {
+	ctx       context.Context
+	releaseID string
+	deviceIDs []string
+	resp      chan response[struct{}]
+}
+
+type recordInstallReq struct {
+	ctx   context.Context
+	input RecordFirmwareInstallInput
+	resp  chan response[bool]
+}
+
+type listInstallsReq struct {
+	ctx       context.Context
+	releaseID string
+	resp      chan response[[]FirmwareInstall]
+}
+
+type redeemDelegatedReq struct {
+	ctx   context.Context
+	input RedeemDelegatedCodeInput
+	resp  chan response[*EnrollmentCode]
+}
+
+type listChildrenReq struct {
+	ctx       context.Context
+	gatewayID string
+	resp      chan response[[]GatewayChild]
+}
+
+type saveStationLinkReq struct {
+	ctx   context.Context
+	input SaveStationLinkInput
+	resp  chan response[*StationLink]
+}
+
+type resolveStationReq struct {
+	ctx       context.Context
+	tokenHash string
+	resp      chan response[*StationLink]
+}
+
+type deleteStationLinkReq struct {
+	ctx      context.Context
+	deviceID string
+	resp     chan response[bool]
+}
+
 type shutdownReq struct {
 	resp chan struct{}
 }
@@ -84,9 +378,58 @@ type pgRepo struct {
 	updateStatusCh     chan updateStatusReq
 	queryByClassCh     chan queryByClassReq
 	genCodeCh          chan genCodeReq
+	regenCodeCh        chan genCodeReq
 	redeemCodeCh       chan redeemCodeReq
+	listPendingCodesCh chan listPendingCodesReq
+	expireCodesCh      chan expireCodesReq
 	enrollCh           chan enrollReq
 	updateCertSerialCh chan updateCertSerialReq
+	setSensorUnitsCh   chan setSensorUnitsReq
+	setClassUnitsCh    chan setClassUnitsReq
+	getClassUnitsCh    chan getClassUnitsReq
+	resolveUnitsCh     chan resolveUnitsReq
+	recordSkewCh       chan recordClockSkewReq
+	setSkewFlagCh      chan setClockSkewFlagReq
+	flagRateLimitCh    chan flagRateLimitReq
+	clearRateLimitCh   chan clearRateLimitFlagReq
+	listRateLimitCh    chan listRateLimitFlaggedReq
+	recordIssuedCh     chan recordIssuedCertReq
+	setCertHoldCh      chan setCertHoldReq
+	listRevokedCh      chan listRevokedCertsReq
+	crlRevisionCh      chan getCRLRevisionReq
+	getIssuedCh        chan getIssuedCertReq
+	openSessionCh      chan openSessionReq
+	closeSessionCh     chan closeSessionReq
+	closeOpenCh        chan closeOpenSessionsReq
+	recordHealthCh     chan recordHealthReq
+	setHealthAlertsCh  chan setHealthAlertsReq
+	listSilentCh       chan listSilentReq
+	listConfigCampsCh  chan listConfigCampaignsReq
+	listCampDevicesCh  chan listCampaignDevicesReq
+	listEndedConfigCh  chan listEndedConfigReq
+	saveConfigCh       chan saveConfigReq
+	getConfigCh        chan getConfigReq
+	ackConfigCh        chan ackConfigReq
+	cmdTargetsCh       chan cmdTargetsReq
+	createCmdsCh       chan createCmdsReq
+	listCmdsCh         chan listCmdsReq
+	listPendingCmdsCh  chan listPendingCmdsReq
+	cmdResultCh        chan cmdResultReq
+	getShadowCh        chan getShadowReq
+	updateShadowCh     chan updateShadowReq
+	createReleaseCh    chan createReleaseReq
+	getReleaseCh       chan getReleaseReq
+	listReleasesCh     chan listReleasesReq
+	setRolloutCh       chan setRolloutReq
+	listCandidatesCh   chan listCandidatesReq
+	markNotifiedCh     chan markNotifiedReq
+	recordInstallCh    chan recordInstallReq
+	listInstallsCh     chan listInstallsReq
+	redeemDelegatedCh  chan redeemDelegatedReq
+	listChildrenCh     chan listChildrenReq
+	saveStationCh      chan saveStationLinkReq
+	resolveStationCh   chan resolveStationReq
+	deleteStationCh    chan deleteStationLinkReq
 	shutdownCh         chan shutdownReq
 }
 
@@ -100,9 +443,58 @@ func NewRepository(pool *pgxpool.Pool) Repository {
 		updateStatusCh:     make(chan updateStatusReq),
 		queryByClassCh:     make(chan queryByClassReq),
 		genCodeCh:          make(chan genCodeReq),
+		regenCodeCh:        make(chan genCodeReq),
 		redeemCodeCh:       make(chan redeemCodeReq),
+		listPendingCodesCh: make(chan listPendingCodesReq),
+		expireCodesCh:      make(chan expireCodesReq),
 		enrollCh:           make(chan enrollReq),
 		updateCertSerialCh: make(chan updateCertSerialReq),
+		setSensorUnitsCh:   make(chan setSensorUnitsReq),
+		setClassUnitsCh:    make(chan setClassUnitsReq),
+		getClassUnitsCh:    make(chan getClassUnitsReq),
+		resolveUnitsCh:     make(chan resolveUnitsReq),
+		recordSkewCh:       make(chan recordClockSkewReq),
+		setSkewFlagCh:      make(chan setClockSkewFlagReq),
+		flagRateLimitCh:    make(chan flagRateLimitReq),
+		clearRateLimitCh:   make(chan clearRateLimitFlagReq),
+		listRateLimitCh:    make(chan listRateLimitFlaggedReq),
+		recordIssuedCh:     make(chan recordIssuedCertReq),
+		setCertHoldCh:      make(chan setCertHoldReq),
+		listRevokedCh:      make(chan listRevokedCertsReq),
+		crlRevisionCh:      make(chan getCRLRevisionReq),
+		getIssuedCh:        make(chan getIssuedCertReq),
+		openSessionCh:      make(chan openSessionReq),
+		closeSessionCh:     make(chan closeSessionReq),
+		closeOpenCh:        make(chan closeOpenSessionsReq),
+		recordHealthCh:     make(chan recordHealthReq),
+		setHealthAlertsCh:  make(chan setHealthAlertsReq),
+		listSilentCh:       make(chan listSilentReq),
+		listConfigCampsCh:  make(chan listConfigCampaignsReq),
+		listCampDevicesCh:  make(chan listCampaignDevicesReq),
+		listEndedConfigCh:  make(chan listEndedConfigReq),
+		saveConfigCh:       make(chan saveConfigReq),
+		getConfigCh:        make(chan getConfigReq),
+		ackConfigCh:        make(chan ackConfigReq),
+		cmdTargetsCh:       make(chan cmdTargetsReq),
+		createCmdsCh:       make(chan createCmdsReq),
+		listCmdsCh:         make(chan listCmdsReq),
+		listPendingCmdsCh:  make(chan listPendingCmdsReq),
+		cmdResultCh:        make(chan cmdResultReq),
+		getShadowCh:        make(chan getShadowReq),
+		updateShadowCh:     make(chan updateShadowReq),
+		createReleaseCh:    make(chan createReleaseReq),
+		getReleaseCh:       make(chan getReleaseReq),
+		listReleasesCh:     make(chan listReleasesReq),
+		setRolloutCh:       make(chan setRolloutReq),
+		listCandidatesCh:   make(chan listCandidatesReq),
+		markNotifiedCh:     make(chan markNotifiedReq),
+		recordInstallCh:    make(chan recordInstallReq),
+		listInstallsCh:     make(chan listInstallsReq),
+		redeemDelegatedCh:  make(chan redeemDelegatedReq),
+		listChildrenCh:     make(chan listChildrenReq),
+		saveStationCh:      make(chan saveStationLinkReq),
+		resolveStationCh:   make(chan resolveStationReq),
+		deleteStationCh:    make(chan deleteStationLinkReq),
 		shutdownCh:         make(chan shutdownReq),
 	}
 	go r.manage()
@@ -130,15 +522,162 @@ func (r *pgRepo) manage() {
 		case req := <-r.genCodeCh:
 			val, err := r.doGenerateCode(req.ctx, req.input)
 			req.resp <- response[*EnrollmentCode]{val: val, err: err}
+		case req := <-r.regenCodeCh:
+			val, err := r.doRegenerateCode(req.ctx, req.input)
+			req.resp <- response[*EnrollmentCode]{val: val, err: err}
 		case req := <-r.redeemCodeCh:
 			val, err := r.doRedeemCode(req.ctx, req.code)
 			req.resp <- response[*EnrollmentCode]{val: val, err: err}
+		case req := <-r.listPendingCodesCh:
+			val, err := r.doListPendingCodes(req.ctx, req.ownerID)
+			req.resp <- response[[]EnrollmentCode]{val: val, err: err}
+		case req := <-r.expireCodesCh:
+			val, err := r.doExpireCodes(req.ctx, req.deviceID)
+			req.resp <- response[int]{val: val, err: err}
 		case req := <-r.enrollCh:
 			err := r.doEnroll(req.ctx, req.deviceID, req.campaignID)
 			req.resp <- response[struct{}]{err: err}
 		case req := <-r.updateCertSerialCh:
 			err := r.doUpdateCertSerial(req.ctx, req.id, req.serial)
 			req.resp <- response[struct{}]{err: err}
+		case req := <-r.setSensorUnitsCh:
+			err := r.doSetSensorUnits(req.ctx, req.id, req.units)
+			req.resp <- response[struct{}]{err: err}
+		case req := <-r.setClassUnitsCh:
+			err := r.doSetClassUnits(req.ctx, req.input)
+			req.resp <- response[struct{}]{err: err}
+		case req := <-r.getClassUnitsCh:
+			val, err := r.doGetClassUnits(req.ctx, req.class)
+			req.resp <- response[map[string]string]{val: val, err: err}
+		case req := <-r.resolveUnitsCh:
+			val, err := r.doResolveSensorUnits(req.ctx, req.id)
+			req.resp <- response[map[string]string]{val: val, err: err}
+		case req := <-r.recordSkewCh:
+			val, err := r.doRecordClockSkew(req.ctx, req.input)
+			req.resp <- response[*ClockSkew]{val: val, err: err}
+		case req := <-r.setSkewFlagCh:
+			err := r.doSetClockSkewFlag(req.ctx, req.id, req.flagged)
+			req.resp <- response[struct{}]{err: err}
+		case req := <-r.flagRateLimitCh:
+			val, err := r.doFlagRateLimitViolator(req.ctx, req.input)
+			req.resp <- response[*RateLimitFlag]{val: val, err: err}
+		case req := <-r.clearRateLimitCh:
+			err := r.doClearRateLimitFlag(req.ctx, req.id)
+			req.resp <- response[struct{}]{err: err}
+		case req := <-r.listRateLimitCh:
+			val, err := r.doListRateLimitFlagged(req.ctx)
+			req.resp <- response[[]RateLimitFlag]{val: val, err: err}
+		case req := <-r.recordIssuedCh:
+			err := r.doRecordIssuedCert(req.ctx, req.input)
+			req.resp <- response[struct{}]{err: err}
+		case req := <-r.setCertHoldCh:
+			val, err := r.doSetCertHold(req.ctx, req.id, req.held)
+			req.resp <- response[int64]{val: val, err: err}
+		case req := <-r.listRevokedCh:
+			val, err := r.doListRevokedCerts(req.ctx)
+			req.resp <- response[*RevocationList]{val: val, err: err}
+		case req := <-r.crlRevisionCh:
+			val, err := r.doGetCRLRevision(req.ctx)
+			req.resp <- response[int64]{val: val, err: err}
+		case req := <-r.getIssuedCh:
+			val, err := r.doGetIssuedCert(req.ctx, req.serial)
+			req.resp <- response[*IssuedCertRecord]{val: val, err: err}
+		case req := <-r.openSessionCh:
+			val, err := r.doOpenSession(req.ctx, req.input)
+			req.resp <- response[string]{val: val, err: err}
+		case req := <-r.closeSessionCh:
+			err := r.doCloseSession(req.ctx, req.input)
+			req.resp <- response[struct{}]{err: err}
+		case req := <-r.closeOpenCh:
+			val, err := r.doCloseOpenSessions(req.ctx, req.reason)
+			req.resp <- response[int64]{val: val, err: err}
+		case req := <-r.recordHealthCh:
+			val, err := r.doRecordHealth(req.ctx, req.input)
+			req.resp <- response[*HealthState]{val: val, err: err}
+		case req := <-r.setHealthAlertsCh:
+			err := r.doSetHealthAlerts(req.ctx, req.input)
+			req.resp <- response[struct{}]{err: err}
+		case req := <-r.listSilentCh:
+			val, err := r.doListSilentDevices(req.ctx, req.before)
+			req.resp <- response[[]SilentDevice]{val: val, err: err}
+		case req := <-r.listConfigCampsCh:
+			val, err := r.doListConfigCampaigns(req.ctx, req.deviceID, req.at)
+			req.resp <- response[[]string]{val: val, err: err}
+		case req := <-r.listCampDevicesCh:
+			val, err := r.doListCampaignDevices(req.ctx, req.campaignID)
+			req.resp <- response[[]string]{val: val, err: err}
+		case req := <-r.listEndedConfigCh:
+			val, err := r.doListEndedConfigDevices(req.ctx, req.at)
+			req.resp <- response[[]string]{val: val, err: err}
+		case req := <-r.saveConfigCh:
+			val, err := r.doSaveDeviceConfig(req.ctx, req.input)
+			req.resp <- response[*DeviceConfig]{val: val, err: err}
+		case req := <-r.getConfigCh:
+			val, err := r.doGetDeviceConfig(req.ctx, req.deviceID)
+			req.resp <- response[*DeviceConfig]{val: val, err: err}
+		case req := <-r.ackConfigCh:
+			val, err := r.doAckDeviceConfig(req.ctx, req.deviceID, req.version)
+			req.resp <- response[bool]{val: val, err: err}
+		case req := <-r.cmdTargetsCh:
+			val, err := r.doListCommandTargets(req.ctx, req.input)
+			req.resp <- response[*CommandTargets]{val: val, err: err}
+		case req := <-r.createCmdsCh:
+			val, err := r.doCreateCommands(req.ctx, req.input)
+			req.resp <- response[[]DeviceCommand]{val: val, err: err}
+		case req := <-r.listCmdsCh:
+			val, err := r.doListCommands(req.ctx, req.input)
+			req.resp <- response[[]DeviceCommand]{val: val, err: err}
+		case req := <-r.listPendingCmdsCh:
+			val, err := r.doListPendingCommands(req.ctx, req.deviceID, req.at)
+			req.resp <- response[[]DeviceCommand]{val: val, err: err}
+		case req := <-r.cmdResultCh:
+			val, err := r.doRecordCommandResult(req.ctx, req.input)
+			req.resp <- response[bool]{val: val, err: err}
+		case req := <-r.getShadowCh:
+			val, err := r.doGetShadow(req.ctx, req.deviceID)
+			req.resp <- response[*DeviceShadow]{val: val, err: err}
+		case req := <-r.updateShadowCh:
+			val, err := r.doUpdateShadow(req.ctx, req.side, req.input)
+			req.resp <- response[*DeviceShadow]{val: val, err: err}
+		case req := <-r.createReleaseCh:
+			val, err := r.doCreateFirmwareRelease(req.ctx, req.input)
+			req.resp <- response[*FirmwareRelease]{val: val, err: err}
+		case req := <-r.getReleaseCh:
+			val, err := r.doGetFirmwareRelease(req.ctx, req.id)
+			req.resp <- response[*FirmwareRelease]{val: val, err: err}
+		case req := <-r.listReleasesCh:
+			val, err := r.doListFirmwareReleases(req.ctx, req.class)
+			req.resp <- response[[]FirmwareRelease]{val: val, err: err}
+		case req := <-r.setRolloutCh:
+			val, err := r.doSetFirmwareRollout(req.ctx, req.input)
+			req.resp <- response[*FirmwareRelease]{val: val, err: err}
+		case req := <-r.listCandidatesCh:
+			val, err := r.doListFirmwareCandidates(req.ctx, req.releaseID)
+			req.resp <- response[[]FirmwareCandidate]{val: val, err: err}
+		case req := <-r.markNotifiedCh:
+			err := r.doMarkFirmwareNotified(req.ctx, req.releaseID, req.deviceIDs)
+			req.resp <- response[struct{}]{err: err}
+		case req := <-r.recordInstallCh:
+			val, err := r.doRecordFirmwareInstall(req.ctx, req.input)
+			req.resp <- response[bool]{val: val, err: err}
+		case req := <-r.listInstallsCh:
+			val, err := r.doListFirmwareInstalls(req.ctx, req.releaseID)
+			req.resp <- response[[]FirmwareInstall]{val: val, err: err}
+		case req := <-r.redeemDelegatedCh:
+			val, err := r.doRedeemDelegatedCode(req.ctx, req.input)
+			req.resp <- response[*EnrollmentCode]{val: val, err: err}
+		case req := <-r.listChildrenCh:
+			val, err := r.doListGatewayChildren(req.ctx, req.gatewayID)
+			req.resp <- response[[]GatewayChild]{val: val, err: err}
+		case req := <-r.saveStationCh:
+			val, err := r.doSaveStationLink(req.ctx, req.input)
+			req.resp <- response[*StationLink]{val: val, err: err}
+		case req := <-r.resolveStationCh:
+			val, err := r.doResolveStationToken(req.ctx, req.tokenHash)
+			req.resp <- response[*StationLink]{val: val, err: err}
+		case req := <-r.deleteStationCh:
+			val, err := r.doDeleteStationLink(req.ctx, req.deviceID)
+			req.resp <- response[bool]{val: val, err: err}
 		case req := <-r.shutdownCh:
 			close(req.resp)
 			return
@@ -188,6 +727,13 @@ func (r *pgRepo) GenerateEnrollmentCode(ctx context.Context, input GenerateCodeI
 	return res.val, res.err
 }
 
+func (r *pgRepo) RegenerateEnrollmentCode(ctx context.Context, input GenerateCodeInput) (*EnrollmentCode, error) {
+	resp := make(chan response[*EnrollmentCode], 1)
+	r.regenCodeCh <- genCodeReq{ctx: ctx, input: input, resp: resp}
+	res := <-resp
+	return res.val, res.err
+}
+
 func (r *pgRepo) RedeemEnrollmentCode(ctx context.Context, code string) (*EnrollmentCode, error) {
 	resp := make(chan response[*EnrollmentCode], 1)
 	r.redeemCodeCh <- redeemCodeReq{ctx: ctx, code: code, resp: resp}
@@ -195,6 +741,20 @@ func (r *pgRepo) RedeemEnrollmentCode(ctx context.Context, code string) (*Enroll
 	return res.val, res.err
 }
 
+func (r *pgRepo) ListPendingEnrollmentCodes(ctx context.Context, ownerID string) ([]EnrollmentCode, error) {
+	resp := make(chan response[[]EnrollmentCode], 1)
+	r.listPendingCodesCh <- listPendingCodesReq{ctx: ctx, ownerID: ownerID, resp: resp}
+	res := <-resp
+	return res.val, res.err
+}
+
+func (r *pgRepo) ExpireEnrollmentCodes(ctx context.Context, deviceID string) (int, error) {
+	resp := make(chan response[int], 1)
+	r.expireCodesCh <- expireCodesReq{ctx: ctx, deviceID: deviceID, resp: resp}
+	res := <-resp
+	return res.val, res.err
+}
+
 func (r *pgRepo) EnrollInCampaign(ctx context.Context, deviceID string, campaignID string) error {
 	resp := make(chan response[struct{}], 1)
 	r.enrollCh <- enrollReq{ctx: ctx, deviceID: deviceID, campaignID: campaignID, resp: resp}
@@ -209,111 +769,437 @@ func (r *pgRepo) UpdateCertSerial(ctx context.Context, id string, serial string)
 	return res.err
 }
 
-func (r *pgRepo) Shutdown() {
-	resp := make(chan struct{}, 1)
-	r.shutdownCh <- shutdownReq{resp: resp}
-	<-resp
+func (r *pgRepo) SetSensorUnits(ctx context.Context, id string, units map[string]string) error {
+	resp := make(chan response[struct{}], 1)
+	r.setSensorUnitsCh <- setSensorUnitsReq{ctx: ctx, id: id, units: units, resp: resp}
+	res := <-resp
+	return res.err
 }
 
-// --- implementation ---
+func (r *pgRepo) SetClassUnits(ctx context.Context, input SetClassUnitsInput) error {
+	resp := make(chan response[struct{}], 1)
+	r.setClassUnitsCh <- setClassUnitsReq{ctx: ctx, input: input, resp: resp}
+	res := <-resp
+	return res.err
+}
 
-func (r *pgRepo) doCreate(ctx context.Context, input CreateDeviceInput) (*Device, error) {
-	var d Device
-	err := r.pool.QueryRow(ctx,
-		`INSERT INTO devices (id, owner_id, class, firmware_version, tier, sensors)
-		 VALUES ($1, $2, $3, $4, $5, $6)
-		 RETURNING id, owner_id, status, class, firmware_version, tier, sensors, cert_serial, created_at`,
-		ulid.Make().String(), input.OwnerID, input.Class, input.FirmwareVersion, input.Tier, input.Sensors,
-	).Scan(&d.ID, &d.OwnerID, &d.Status, &d.Class, &d.FirmwareVersion, &d.Tier, &d.Sensors, &d.CertSerial, &d.CreatedAt)
-	if err != nil {
-		return nil, fmt.Errorf("insert device: %w", err)
-	}
-	return &d, nil
+func (r *pgRepo) GetClassUnits(ctx context.Context, class string) (map[string]string, error) {
+	resp := make(chan response[map[string]string], 1)
+	r.getClassUnitsCh <- getClassUnitsReq{ctx: ctx, class: class, resp: resp}
+	res := <-resp
+	return res.val, res.err
 }
 
-func (r *pgRepo) doGet(ctx context.Context, id string) (*Device, error) {
-	var d Device
-	err := r.pool.QueryRow(ctx,
-		`SELECT id, owner_id, status, class, firmware_version, tier, sensors, cert_serial, created_at
-		 FROM devices WHERE id = $1`,
-		id,
-	).Scan(&d.ID, &d.OwnerID, &d.Status, &d.Class, &d.FirmwareVersion, &d.Tier, &d.Sensors, &d.CertSerial, &d.CreatedAt)
-	if err != nil {
-		if err == pgx.ErrNoRows {
-			return nil, fmt.Errorf("device %s not found", id)
-		}
-		return nil, fmt.Errorf("get device: %w", err)
-	}
-	return &d, nil
+func (r *pgRepo) ResolveSensorUnits(ctx context.Context, id string) (map[string]string, error) {
+	resp := make(chan response[map[string]string], 1)
+	r.resolveUnitsCh <- resolveUnitsReq{ctx: ctx, id: id, resp: resp}
+	res := <-resp
+	return res.val, res.err
 }
 
-func (r *pgRepo) doGetCapabilities(ctx context.Context, id string) (*DeviceCapabilities, error) {
-	var c DeviceCapabilities
-	err := r.pool.QueryRow(ctx,
-		`SELECT class, tier, sensors, firmware_version FROM devices WHERE id = $1`,
-		id,
-	).Scan(&c.Class, &c.Tier, &c.Sensors, &c.FirmwareVersion)
-	if err != nil {
-		if err == pgx.ErrNoRows {
-			return nil, fmt.Errorf("device %s not found", id)
-		}
-		return nil, fmt.Errorf("get capabilities: %w", err)
-	}
-	return &c, nil
+func (r *pgRepo) RecordClockSkew(ctx context.Context, input RecordClockSkewInput) (*ClockSkew, error) {
+	resp := make(chan response[*ClockSkew], 1)
+	r.recordSkewCh <- recordClockSkewReq{ctx: ctx, input: input, resp: resp}
+	res := <-resp
+	return res.val, res.err
 }
 
-func (r *pgRepo) doUpdateStatus(ctx context.Context, id string, status string) error {
-	tag, err := r.pool.Exec(ctx,
-		`UPDATE devices SET status = $1 WHERE id = $2`,
-		status, id,
-	)
-	if err != nil {
-		return fmt.Errorf("update status: %w", err)
-	}
-	if tag.RowsAffected() == 0 {
-		return fmt.Errorf("device %s not found", id)
-	}
-	return nil
+func (r *pgRepo) SetClockSkewFlag(ctx context.Context, id string, flagged bool) error {
+	resp := make(chan response[struct{}], 1)
+	r.setSkewFlagCh <- setClockSkewFlagReq{ctx: ctx, id: id, flagged: flagged, resp: resp}
+	res := <-resp
+	return res.err
 }
 
-func (r *pgRepo) doQueryByClass(ctx context.Context, input QueryByClassInput) ([]Device, error) {
-	query := `SELECT id, owner_id, status, class, firmware_version, tier, sensors, cert_serial, created_at
-	          FROM devices WHERE class = $1`
-	args := []any{input.Class}
-	argIdx := 2
-
-	if input.FirmwareMinGte != "" {
-		query += fmt.Sprintf(" AND firmware_version >= $%d", argIdx)
-		args = append(args, input.FirmwareMinGte)
-		argIdx++
-	}
-	if input.FirmwareMaxLte != "" {
-		query += fmt.Sprintf(" AND firmware_version <= $%d", argIdx)
-		args = append(args, input.FirmwareMaxLte)
-		argIdx++
-	}
+func (r *pgRepo) FlagRateLimitViolator(ctx context.Context, input FlagRateLimitInput) (*RateLimitFlag, error) {
+	resp := make(chan response[*RateLimitFlag], 1)
+	r.flagRateLimitCh <- flagRateLimitReq{ctx: ctx, input: input, resp: resp}
+	res := <-resp
+	return res.val, res.err
+}
 
-	rows, err := r.pool.Query(ctx, query, args...)
-	if err != nil {
-		return nil, fmt.Errorf("query by class: %w", err)
-	}
-	defer rows.Close()
+func (r *pgRepo) ClearRateLimitFlag(ctx context.Context, id string) error {
+	resp := make(chan response[struct{}], 1)
+	r.clearRateLimitCh <- clearRateLimitFlagReq{ctx: ctx, id: id, resp: resp}
+	res := <-resp
+	return res.err
+}
 
-	var devices []Device
-	for rows.Next() {
-		var d Device
-		if err := rows.Scan(&d.ID, &d.OwnerID, &d.Status, &d.Class, &d.FirmwareVersion, &d.Tier, &d.Sensors, &d.CertSerial, &d.CreatedAt); err != nil {
-			return nil, fmt.Errorf("scan device: %w", err)
-		}
-		devices = append(devices, d)
-	}
-	return devices, rows.Err()
+func (r *pgRepo) ListRateLimitFlagged(ctx context.Context) ([]RateLimitFlag, error) {
+	resp := make(chan response[[]RateLimitFlag], 1)
+	r.listRateLimitCh <- listRateLimitFlaggedReq{ctx: ctx, resp: resp}
+	res := <-resp
+	return res.val, res.err
 }
 
-func (r *pgRepo) doGenerateCode(ctx context.Context, input GenerateCodeInput) (*EnrollmentCode, error) {
-	expiresAt := time.Now().UTC().Add(time.Duration(input.TTL) * time.Second)
-	var ec EnrollmentCode
-	err := r.pool.QueryRow(ctx,
+func (r *pgRepo) RecordIssuedCert(ctx context.Context, input RecordIssuedCertInput) error {
+	resp := make(chan response[struct{}], 1)
+	r.recordIssuedCh <- recordIssuedCertReq{ctx: ctx, input: input, resp: resp}
+	res := <-resp
+	return res.err
+}
+
+func (r *pgRepo) SetCertHold(ctx context.Context, id string, held bool) (int64, error) {
+	resp := make(chan response[int64], 1)
+	r.setCertHoldCh <- setCertHoldReq{ctx: ctx, id: id, held: held, resp: resp}
+	res := <-resp
+	return res.val, res.err
+}
+
+func (r *pgRepo) ListRevokedCerts(ctx context.Context) (*RevocationList, error) {
+	resp := make(chan response[*RevocationList], 1)
+	r.listRevokedCh <- listRevokedCertsReq{ctx: ctx, resp: resp}
+	res := <-resp
+	return res.val, res.err
+}
+
+func (r *pgRepo) GetCRLRevision(ctx context.Context) (int64, error) {
+	resp := make(chan response[int64], 1)
+	r.crlRevisionCh <- getCRLRevisionReq{ctx: ctx, resp: resp}
+	res := <-resp
+	return res.val, res.err
+}
+
+func (r *pgRepo) GetIssuedCert(ctx context.Context, serial string) (*IssuedCertRecord, error) {
+	resp := make(chan response[*IssuedCertRecord], 1)
+	r.getIssuedCh <- getIssuedCertReq{ctx: ctx, serial: serial, resp: resp}
+	res := <-resp
+	return res.val, res.err
+}
+
+func (r *pgRepo) OpenSession(ctx context.Context, input OpenSessionInput) (string, error) {
+	resp := make(chan response[string], 1)
+	r.openSessionCh <- openSessionReq{ctx: ctx, input: input, resp: resp}
+	res := <-resp
+	return res.val, res.err
+}
+
+func (r *pgRepo) CloseSession(ctx context.Context, input CloseSessionInput) error {
+	resp := make(chan response[struct{}], 1)
+	r.closeSessionCh <- closeSessionReq{ctx: ctx, input: input, resp: resp}
+	res := <-resp
+	return res.err
+}
+
+func (r *pgRepo) CloseOpenSessions(ctx context.Context, reason string) (int64, error) {
+	resp := make(chan response[int64], 1)
+	r.closeOpenCh <- closeOpenSessionsReq{ctx: ctx, reason: reason, resp: resp}
+	res := <-resp
+	return res.val, res.err
+}
+
+func (r *pgRepo) RecordHealth(ctx context.Context, input RecordHealthInput) (*HealthState, error) {
+	resp := make(chan response[*HealthState], 1)
+	r.recordHealthCh <- recordHealthReq{ctx: ctx, input: input, resp: resp}
+	res := <-resp
+	return res.val, res.err
+}
+
+func (r *pgRepo) SetHealthAlerts(ctx context.Context, input SetHealthAlertsInput) error {
+	resp := make(chan response[struct{}], 1)
+	r.setHealthAlertsCh <- setHealthAlertsReq{ctx: ctx, input: input, resp: resp}
+	res := <-resp
+	return res.err
+}
+
+func (r *pgRepo) ListSilentDevices(ctx context.Context, before time.Time) ([]SilentDevice, error) {
+	resp := make(chan response[[]SilentDevice], 1)
+	r.listSilentCh <- listSilentReq{ctx: ctx, before: before, resp: resp}
+	res := <-resp
+	return res.val, res.err
+}
+
+func (r *pgRepo) ListConfigCampaigns(ctx context.Context, deviceID string, at time.Time) ([]string, error) {
+	resp := make(chan response[[]string], 1)
+	r.listConfigCampsCh <- listConfigCampaignsReq{ctx: ctx, deviceID: deviceID, at: at, resp: resp}
+	res := <-resp
+	return res.val, res.err
+}
+
+func (r *pgRepo) ListCampaignDevices(ctx context.Context, campaignID string) ([]string, error) {
+	resp := make(chan response[[]string], 1)
+	r.listCampDevicesCh <- listCampaignDevicesReq{ctx: ctx, campaignID: campaignID, resp: resp}
+	res := <-resp
+	return res.val, res.err
+}
+
+func (r *pgRepo) ListEndedConfigDevices(ctx context.Context, at time.Time) ([]string, error) {
+	resp := make(chan response[[]string], 1)
+	r.listEndedConfigCh <- listEndedConfigReq{ctx: ctx, at: at, resp: resp}
+	res := <-resp
+	return res.val, res.err
+}
+
+func (r *pgRepo) SaveDeviceConfig(ctx context.Context, input SaveDeviceConfigInput) (*DeviceConfig, error) {
+	resp := make(chan response[*DeviceConfig], 1)
+	r.saveConfigCh <- saveConfigReq{ctx: ctx, input: input, resp: resp}
+	res := <-resp
+	return res.val, res.err
+}
+
+func (r *pgRepo) GetDeviceConfig(ctx context.Context, deviceID string) (*DeviceConfig, error) {
+	resp := make(chan response[*DeviceConfig], 1)
+	r.getConfigCh <- getConfigReq{ctx: ctx, deviceID: deviceID, resp: resp}
+	res := <-resp
+	return res.val, res.err
+}
+
+func (r *pgRepo) AckDeviceConfig(ctx context.Context, deviceID string, version int64) (bool, error) {
+	resp := make(chan response[bool], 1)
+	r.ackConfigCh <- ackConfigReq{ctx: ctx, deviceID: deviceID, version: version, resp: resp}
+	res := <-resp
+	return res.val, res.err
+}
+
+func (r *pgRepo) ListCommandTargets(ctx context.Context, input CommandTargetsInput) (*CommandTargets, error) {
+	resp := make(chan response[*CommandTargets], 1)
+	r.cmdTargetsCh <- cmdTargetsReq{ctx: ctx, input: input, resp: resp}
+	res := <-resp
+	return res.val, res.err
+}
+
+func (r *pgRepo) CreateCommands(ctx context.Context, input CreateCommandsInput) ([]DeviceCommand, error) {
+	resp := make(chan response[[]DeviceCommand], 1)
+	r.createCmdsCh <- createCmdsReq{ctx: ctx, input: input, resp: resp}
+	res := <-resp
+	return res.val, res.err
+}
+
+func (r *pgRepo) ListCommands(ctx context.Context, input ListCommandsInput) ([]DeviceCommand, error) {
+	resp := make(chan response[[]DeviceCommand], 1)
+	r.listCmdsCh <- listCmdsReq{ctx: ctx, input: input, resp: resp}
+	res := <-resp
+	return res.val, res.err
+}
+
+func (r *pgRepo) ListPendingCommands(ctx context.Context, deviceID string, at time.Time) ([]DeviceCommand, error) {
+	resp := make(chan response[[]DeviceCommand], 1)
+	r.listPendingCmdsCh <- listPendingCmdsReq{ctx: ctx, deviceID: deviceID, at: at, resp: resp}
+	res := <-resp
+	return res.val, res.err
+}
+
+func (r *pgRepo) RecordCommandResult(ctx context.Context, input RecordCommandResultInput) (bool, error) {
+	resp := make(chan response[bool], 1)
+	r.cmdResultCh <- cmdResultReq{ctx: ctx, input: input, resp: resp}
+	res := <-resp
+	return res.val, res.err
+}
+
+func (r *pgRepo) GetShadow(ctx context.Context, deviceID string) (*DeviceShadow, error) {
+	resp := make(chan response[*DeviceShadow], 1)
+	r.getShadowCh <- getShadowReq{ctx: ctx, deviceID: deviceID, resp: resp}
+	res := <-resp
+	return res.val, res.err
+}
+
+func (r *pgRepo) UpdateShadowDesired(ctx context.Context, input UpdateShadowInput) (*DeviceShadow, error) {
+	resp := make(chan response[*DeviceShadow], 1)
+	r.updateShadowCh <- updateShadowReq{ctx: ctx, side: "desired", input: input, resp: resp}
+	res := <-resp
+	return res.val, res.err
+}
+
+func (r *pgRepo) UpdateShadowReported(ctx context.Context, input UpdateShadowInput) (*DeviceShadow, error) {
+	resp := make(chan response[*DeviceShadow], 1)
+	r.updateShadowCh <- updateShadowReq{ctx: ctx, side: "reported", input: input, resp: resp}
+	res := <-resp
+	return res.val, res.err
+}
+
+func (r *pgRepo) CreateFirmwareRelease(ctx context.Context, input CreateFirmwareReleaseInput) (*FirmwareRelease, error) {
+	resp := make(chan response[*FirmwareRelease], 1)
+	r.createReleaseCh <- createReleaseReq{ctx: ctx, input: input, resp: resp}
+	res := <-resp
+	return res.val, res.err
+}
+
+func (r *pgRepo) GetFirmwareRelease(ctx context.Context, id string) (*FirmwareRelease, error) {
+	resp := make(chan response[*FirmwareRelease], 1)
+	r.getReleaseCh <- getReleaseReq{ctx: ctx, id: id, resp: resp}
+	res := <-resp
+	return res.val, res.err
+}
+
+func (r *pgRepo) ListFirmwareReleases(ctx context.Context, class string) ([]FirmwareRelease, error) {
+	resp := make(chan response[[]FirmwareRelease], 1)
+	r.listReleasesCh <- listReleasesReq{ctx: ctx, class: class, resp: resp}
+	res := <-resp
+	return res.val, res.err
+}
+
+func (r *pgRepo) SetFirmwareRollout(ctx context.Context, input SetFirmwareRolloutInput) (*FirmwareRelease, error) {
+	resp := make(chan response[*FirmwareRelease], 1)
+	r.setRolloutCh <- setRolloutReq{ctx: ctx, input: input, resp: resp}
+	res := <-resp
+	return res.val, res.err
+}
+
+func (r *pgRepo) ListFirmwareCandidates(ctx context.Context, releaseID string) ([]FirmwareCandidate, error) {
+	resp := make(chan response[[]FirmwareCandidate], 1)
+	r.listCandidatesCh <- listCandidatesReq{ctx: ctx, releaseID: releaseID, resp: resp}
+	res := <-resp
+	return res.val, res.err
+}
+
+func (r *pgRepo) MarkFirmwareNotified(ctx context.Context, releaseID string, deviceIDs []string) error {
+	resp := make(chan response[struct{}], 1)
+	r.markNotifiedCh <- markNotifiedReq{ctx: ctx, releaseID: releaseID, deviceIDs: deviceIDs, resp: resp}
+	res := <-resp
+	return res.err
+}
+
+func (r *pgRepo) RecordFirmwareInstall(ctx context.Context, input RecordFirmwareInstallInput) (bool, error) {
+	resp := make(chan response[bool], 1)
+	r.recordInstallCh <- recordInstallReq{ctx: ctx, input: input, resp: resp}
+	res := <-resp
+	return res.val, res.err
+}
+
+func (r *pgRepo) ListFirmwareInstalls(ctx context.Context, releaseID string) ([]FirmwareInstall, error) {
+	resp := make(chan response[[]FirmwareInstall], 1)
+	r.listInstallsCh <- listInstallsReq{ctx: ctx, releaseID: releaseID, resp: resp}
+	res := <-resp
+	return res.val, res.err
+}
+
+// RedeemDelegatedCode marks a code as used on behalf of a gateway and records the gateway
+// as the device's. Fails like RedeemEnrollmentCode, and when the device is owned by someone
+// other than the gateway's owner, is the gateway itself, or is a gateway.
+func (r *pgRepo) RedeemDelegatedCode(ctx context.Context, input RedeemDelegatedCodeInput) (*EnrollmentCode, error) {
+	resp := make(chan response[*EnrollmentCode], 1)
+	r.redeemDelegatedCh <- redeemDelegatedReq{ctx: ctx, input: input, resp: resp}
+	res := <-resp
+	return res.val, res.err
+}
+
+// ListGatewayChildren returns the devices enrolled through a gateway.
+func (r *pgRepo) ListGatewayChildren(ctx context.Context, gatewayID string) ([]GatewayChild, error) {
+	resp := make(chan response[[]GatewayChild], 1)
+	r.listChildrenCh <- listChildrenReq{ctx: ctx, gatewayID: gatewayID, resp: resp}
+	res := <-resp
+	return res.val, res.err
+}
+
+// SaveStationLink links a device to a weather-station protocol, replacing any earlier link
+// and so invalidating its token.
+func (r *pgRepo) SaveStationLink(ctx context.Context, input SaveStationLinkInput) (*StationLink, error) {
+	resp := make(chan response[*StationLink], 1)
+	r.saveStationCh <- saveStationLinkReq{ctx: ctx, input: input, resp: resp}
+	res := <-resp
+	return res.val, res.err
+}
+
+// ResolveStationToken returns the link whose token hashes to tokenHash and records the
+// upload. Returns nil, nil when no link has that token.
+func (r *pgRepo) ResolveStationToken(ctx context.Context, tokenHash string) (*StationLink, error) {
+	resp := make(chan response[*StationLink], 1)
+	r.resolveStationCh <- resolveStationReq{ctx: ctx, tokenHash: tokenHash, resp: resp}
+	res := <-resp
+	return res.val, res.err
+}
+
+// DeleteStationLink unlinks a device. Returns false when it was not linked.
+func (r *pgRepo) DeleteStationLink(ctx context.Context, deviceID string) (bool, error) {
+	resp := make(chan response[bool], 1)
+	r.deleteStationCh <- deleteStationLinkReq{ctx: ctx, deviceID: deviceID, resp: resp}
+	res := <-resp
+	return res.val, res.err
+}
+
+func (r *pgRepo) Shutdown() {
+	resp := make(chan struct{}, 1)
+	r.shutdownCh <- shutdownReq{resp: resp}
+	<-resp
+}
+
+// --- implementation ---
+
+func (r *pgRepo) doCreate(ctx context.Context, input CreateDeviceInput) (*Device, error) {
+	var d Device
+	err := r.pool.QueryRow(ctx,
+		`INSERT INTO devices (id, owner_id, class, firmware_version, tier, sensors, sensor_units, gateway)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		 RETURNING id, owner_id, status, class, firmware_version, tier, sensors, sensor_units, cert_serial, gateway, gateway_id, created_at`,
+		ulid.Make().String(), input.OwnerID, input.Class, input.FirmwareVersion, input.Tier, input.Sensors, unitsOrEmpty(input.SensorUnits), input.Gateway,
+	).Scan(&d.ID, &d.OwnerID, &d.Status, &d.Class, &d.FirmwareVersion, &d.Tier, &d.Sensors, &d.SensorUnits, &d.CertSerial, &d.Gateway, &d.GatewayID, &d.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("insert device: %w", err)
+	}
+	return &d, nil
+}
+
+func (r *pgRepo) doGet(ctx context.Context, id string) (*Device, error) {
+	var d Device
+	err := r.pool.QueryRow(ctx,
+		`SELECT id, owner_id, status, class, firmware_version, tier, sensors, sensor_units, cert_serial, gateway, gateway_id, created_at
+		 FROM devices WHERE id = $1`,
+		id,
+	).Scan(&d.ID, &d.OwnerID, &d.Status, &d.Class, &d.FirmwareVersion, &d.Tier, &d.Sensors, &d.SensorUnits, &d.CertSerial, &d.Gateway, &d.GatewayID, &d.CreatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("%w: %s", ErrDeviceNotFound, id)
+		}
+		return nil, fmt.Errorf("get device: %w", err)
+	}
+	return &d, nil
+}
+
+func (r *pgRepo) doGetCapabilities(ctx context.Context, id string) (*DeviceCapabilities, error) {
+	var c DeviceCapabilities
+	err := r.pool.QueryRow(ctx,
+		`SELECT class, tier, sensors, firmware_version FROM devices WHERE id = $1`,
+		id,
+	).Scan(&c.Class, &c.Tier, &c.Sensors, &c.FirmwareVersion)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("device %s not found", id)
+		}
+		return nil, fmt.Errorf("get capabilities: %w", err)
+	}
+	return &c, nil
+}
+
+func (r *pgRepo) doUpdateStatus(ctx context.Context, id string, status string) error {
+	tag, err := r.pool.Exec(ctx,
+		`UPDATE devices SET status = $1 WHERE id = $2`,
+		status, id,
+	)
+	if err != nil {
+		return fmt.Errorf("update status: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("device %s not found", id)
+	}
+	return nil
+}
+
+func (r *pgRepo) doQueryByClass(ctx context.Context, input QueryByClassInput) ([]Device, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT id, owner_id, status, class, firmware_version, tier, sensors, sensor_units, cert_serial, gateway, gateway_id, created_at
+		 FROM devices WHERE class = $1`,
+		input.Class,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query by class: %w", err)
+	}
+	defer rows.Close()
+
+	var devices []Device
+	for rows.Next() {
+		var d Device
+		if err := rows.Scan(&d.ID, &d.OwnerID, &d.Status, &d.Class, &d.FirmwareVersion, &d.Tier, &d.Sensors, &d.SensorUnits, &d.CertSerial, &d.Gateway, &d.GatewayID, &d.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan device: %w", err)
+		}
+		devices = append(devices, d)
+	}
+	return devices, rows.Err()
+}
+
+func (r *pgRepo) doGenerateCode(ctx context.Context, input GenerateCodeInput) (*EnrollmentCode, error) {
+	expiresAt := time.Now().UTC().Add(time.Duration(input.TTL) * time.Second)
+	var ec EnrollmentCode
+	err := r.pool.QueryRow(ctx,
 		`INSERT INTO enrollment_codes (code, device_id, expires_at)
 		 VALUES ($1, $2, $3)
 		 RETURNING code, device_id, expires_at, used`,
@@ -325,6 +1211,28 @@ func (r *pgRepo) doGenerateCode(ctx context.Context, input GenerateCodeInput) (*
 	return &ec, nil
 }
 
+// doRegenerateCode expires the device's pending codes and inserts the new one in a single
+// statement, so a failure leaves the old codes usable.
+func (r *pgRepo) doRegenerateCode(ctx context.Context, input GenerateCodeInput) (*EnrollmentCode, error) {
+	expiresAt := time.Now().UTC().Add(time.Duration(input.TTL) * time.Second)
+	var ec EnrollmentCode
+	err := r.pool.QueryRow(ctx,
+		`WITH expired AS (
+		     UPDATE enrollment_codes
+		     SET expires_at = now()
+		     WHERE device_id = $2 AND used = false AND expires_at > now()
+		 )
+		 INSERT INTO enrollment_codes (code, device_id, expires_at)
+		 VALUES ($1, $2, $3)
+		 RETURNING code, device_id, expires_at, used`,
+		input.Code, input.DeviceID, expiresAt,
+	).Scan(&ec.Code, &ec.DeviceID, &ec.ExpiresAt, &ec.Used)
+	if err != nil {
+		return nil, fmt.Errorf("regenerate enrollment code: %w", err)
+	}
+	return &ec, nil
+}
+
 func (r *pgRepo) doRedeemCode(ctx context.Context, code string) (*EnrollmentCode, error) {
 	var ec EnrollmentCode
 	err := r.pool.QueryRow(ctx,
@@ -343,16 +1251,54 @@ func (r *pgRepo) doRedeemCode(ctx context.Context, code string) (*EnrollmentCode
 	return &ec, nil
 }
 
-func (r *pgRepo) doEnroll(ctx context.Context, deviceID string, campaignID string) error {
-	_, err := r.pool.Exec(ctx,
-		`INSERT INTO device_campaigns (device_id, campaign_id) VALUES ($1, $2)`,
-		deviceID, campaignID,
+func (r *pgRepo) doListPendingCodes(ctx context.Context, ownerID string) ([]EnrollmentCode, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT ec.code, ec.device_id, ec.expires_at, ec.used
+		 FROM enrollment_codes ec
+		 JOIN devices d ON d.id = ec.device_id
+		 WHERE d.owner_id = $1 AND ec.used = false AND ec.expires_at > now()
+		 ORDER BY ec.expires_at`,
+		ownerID,
 	)
 	if err != nil {
-		return fmt.Errorf("enroll device: %w", err)
+		return nil, fmt.Errorf("list pending codes: %w", err)
 	}
-	return nil
-}
+	defer rows.Close()
+
+	var codes []EnrollmentCode
+	for rows.Next() {
+		var ec EnrollmentCode
+		if err := rows.Scan(&ec.Code, &ec.DeviceID, &ec.ExpiresAt, &ec.Used); err != nil {
+			return nil, fmt.Errorf("scan enrollment code: %w", err)
+		}
+		codes = append(codes, ec)
+	}
+	return codes, rows.Err()
+}
+
+func (r *pgRepo) doExpireCodes(ctx context.Context, deviceID string) (int, error) {
+	tag, err := r.pool.Exec(ctx,
+		`UPDATE enrollment_codes
+		 SET expires_at = now()
+		 WHERE device_id = $1 AND used = false AND expires_at > now()`,
+		deviceID,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("expire codes: %w", err)
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+func (r *pgRepo) doEnroll(ctx context.Context, deviceID string, campaignID string) error {
+	_, err := r.pool.Exec(ctx,
+		`INSERT INTO device_campaigns (device_id, campaign_id) VALUES ($1, $2)`,
+		deviceID, campaignID,
+	)
+	if err != nil {
+		return fmt.Errorf("enroll device: %w", err)
+	}
+	return nil
+}
 
 func (r *pgRepo) doUpdateCertSerial(ctx context.Context, id string, serial string) error {
 	tag, err := r.pool.Exec(ctx,
@@ -367,3 +1313,1144 @@ func (r *pgRepo) doUpdateCertSerial(ctx context.Context, id string, serial strin
 	}
 	return nil
 }
+
+func (r *pgRepo) doSetSensorUnits(ctx context.Context, id string, units map[string]string) error {
+	tag, err := r.pool.Exec(ctx,
+		`UPDATE devices SET sensor_units = $1 WHERE id = $2`,
+		unitsOrEmpty(units), id,
+	)
+	if err != nil {
+		return fmt.Errorf("update sensor units: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("device %s not found", id)
+	}
+	return nil
+}
+
+func (r *pgRepo) doSetClassUnits(ctx context.Context, input SetClassUnitsInput) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM device_class_units WHERE device_class = $1`, input.Class); err != nil {
+		return fmt.Errorf("clear class units: %w", err)
+	}
+	for parameter, unit := range input.Units {
+		if _, err := tx.Exec(ctx,
+			`INSERT INTO device_class_units (device_class, parameter, unit) VALUES ($1, $2, $3)`,
+			input.Class, parameter, unit,
+		); err != nil {
+			return fmt.Errorf("insert class unit %s: %w", parameter, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit: %w", err)
+	}
+	return nil
+}
+
+func (r *pgRepo) doGetClassUnits(ctx context.Context, class string) (map[string]string, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT parameter, unit FROM device_class_units WHERE device_class = $1`,
+		class,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query class units: %w", err)
+	}
+	defer rows.Close()
+
+	units := make(map[string]string)
+	for rows.Next() {
+		var parameter, unit string
+		if err := rows.Scan(&parameter, &unit); err != nil {
+			return nil, fmt.Errorf("scan class unit: %w", err)
+		}
+		units[parameter] = unit
+	}
+	return units, rows.Err()
+}
+
+// doResolveSensorUnits merges the device class defaults with the device's own declarations,
+// which take precedence.
+func (r *pgRepo) doResolveSensorUnits(ctx context.Context, id string) (map[string]string, error) {
+	var units map[string]string
+	err := r.pool.QueryRow(ctx,
+		`SELECT COALESCE(
+		        (SELECT jsonb_object_agg(cu.parameter, cu.unit) FROM device_class_units cu WHERE cu.device_class = d.class),
+		        '{}'::jsonb) || d.sensor_units
+		 FROM devices d WHERE d.id = $1`,
+		id,
+	).Scan(&units)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("device %s not found", id)
+		}
+		return nil, fmt.Errorf("resolve sensor units: %w", err)
+	}
+	return units, nil
+}
+
+// doRecordClockSkew folds an observation into the device's estimate in one statement, so
+// concurrent readings from the same device cannot lose updates.
+func (r *pgRepo) doRecordClockSkew(ctx context.Context, input RecordClockSkewInput) (*ClockSkew, error) {
+	s := ClockSkew{DeviceID: input.DeviceID}
+	err := r.pool.QueryRow(ctx,
+		`UPDATE devices
+		 SET clock_skew_ms = CASE WHEN clock_skew_ms IS NULL THEN $2
+		                          ELSE round(clock_skew_ms * (1 - $3::float8) + $2 * $3::float8)::bigint END,
+		     clock_skew_samples = clock_skew_samples + 1,
+		     clock_skew_updated_at = now()
+		 WHERE id = $1
+		 RETURNING owner_id, clock_skew_ms, clock_skew_samples, clock_skew_updated_at, clock_skew_flagged_at`,
+		input.DeviceID, input.ObservedMs, input.Smoothing,
+	).Scan(&s.OwnerID, &s.EstimateMs, &s.Samples, &s.UpdatedAt, &s.FlaggedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("device %s not found", input.DeviceID)
+		}
+		return nil, fmt.Errorf("record clock skew: %w", err)
+	}
+	return &s, nil
+}
+
+func (r *pgRepo) doSetClockSkewFlag(ctx context.Context, id string, flagged bool) error {
+	tag, err := r.pool.Exec(ctx,
+		`UPDATE devices SET clock_skew_flagged_at = CASE WHEN $2 THEN COALESCE(clock_skew_flagged_at, now()) END WHERE id = $1`,
+		id, flagged,
+	)
+	if err != nil {
+		return fmt.Errorf("set clock skew flag: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("device %s not found", id)
+	}
+	return nil
+}
+
+// doFlagRateLimitViolator adds to the violation count and flags the device; a device already
+// flagged keeps its original flag time.
+func (r *pgRepo) doFlagRateLimitViolator(ctx context.Context, input FlagRateLimitInput) (*RateLimitFlag, error) {
+	f := RateLimitFlag{DeviceID: input.DeviceID}
+	err := r.pool.QueryRow(ctx,
+		`UPDATE devices
+		 SET rate_limit_violations = rate_limit_violations + $2,
+		     rate_limit_flagged_at = COALESCE(rate_limit_flagged_at, now())
+		 WHERE id = $1
+		 RETURNING owner_id, class, firmware_version, status, rate_limit_violations, rate_limit_flagged_at`,
+		input.DeviceID, input.Violations,
+	).Scan(&f.OwnerID, &f.Class, &f.FirmwareVersion, &f.Status, &f.Violations, &f.FlaggedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("device %s not found", input.DeviceID)
+		}
+		return nil, fmt.Errorf("flag rate limit violator: %w", err)
+	}
+	return &f, nil
+}
+
+func (r *pgRepo) doClearRateLimitFlag(ctx context.Context, id string) error {
+	tag, err := r.pool.Exec(ctx, `UPDATE devices SET rate_limit_flagged_at = NULL WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("clear rate limit flag: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("device %s not found", id)
+	}
+	return nil
+}
+
+func (r *pgRepo) doListRateLimitFlagged(ctx context.Context) ([]RateLimitFlag, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT id, owner_id, class, firmware_version, status, rate_limit_violations, rate_limit_flagged_at
+		 FROM devices WHERE rate_limit_flagged_at IS NOT NULL
+		 ORDER BY rate_limit_flagged_at DESC`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list rate limit flagged: %w", err)
+	}
+	defer rows.Close()
+
+	var flags []RateLimitFlag
+	for rows.Next() {
+		var f RateLimitFlag
+		if err := rows.Scan(&f.DeviceID, &f.OwnerID, &f.Class, &f.FirmwareVersion, &f.Status, &f.Violations, &f.FlaggedAt); err != nil {
+			return nil, fmt.Errorf("scan rate limit flag: %w", err)
+		}
+		flags = append(flags, f)
+	}
+	return flags, rows.Err()
+}
+
+// doRecordIssuedCert adds a certificate to the ledger, makes it the device's current
+// certificate and revokes the certificates it supersedes, in one transaction.
+func (r *pgRepo) doRecordIssuedCert(ctx context.Context, input RecordIssuedCertInput) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, `UPDATE devices SET cert_serial = $1 WHERE id = $2`, input.Serial, input.DeviceID)
+	if err != nil {
+		return fmt.Errorf("update cert serial: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("device %s not found", input.DeviceID)
+	}
+
+	if _, err := tx.Exec(ctx,
+		`INSERT INTO issued_certificates (serial, device_id, not_before, not_after)
+		 VALUES ($1, $2, $3, $4)`,
+		input.Serial, input.DeviceID, input.NotBefore, input.NotAfter,
+	); err != nil {
+		return fmt.Errorf("insert issued certificate: %w", err)
+	}
+
+	tag, err = tx.Exec(ctx,
+		`UPDATE issued_certificates
+		 SET revoked_at = now(), revocation_reason = 'superseded', superseded_by = $2
+		 WHERE device_id = $1 AND serial <> $2
+		   AND (revoked_at IS NULL OR revocation_reason = 'certificate_hold')`,
+		input.DeviceID, input.Serial,
+	)
+	if err != nil {
+		return fmt.Errorf("supersede certificates: %w", err)
+	}
+	if tag.RowsAffected() > 0 {
+		if err := bumpCRLRevision(ctx, tx); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit: %w", err)
+	}
+	return nil
+}
+
+// doSetCertHold places the device's unrevoked certificates on hold, or lifts the hold.
+// Superseded certificates are never released. Returns the number of certificates changed.
+func (r *pgRepo) doSetCertHold(ctx context.Context, id string, held bool) (int64, error) {
+	query := `UPDATE issued_certificates
+		 SET revoked_at = now(), revocation_reason = 'certificate_hold'
+		 WHERE device_id = $1 AND revoked_at IS NULL`
+	if !held {
+		query = `UPDATE issued_certificates
+		 SET revoked_at = NULL, revocation_reason = NULL
+		 WHERE device_id = $1 AND revocation_reason = 'certificate_hold'`
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, query, id)
+	if err != nil {
+		return 0, fmt.Errorf("set cert hold: %w", err)
+	}
+	if tag.RowsAffected() > 0 {
+		if err := bumpCRLRevision(ctx, tx); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("commit: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// bumpCRLRevision marks the revoked set as changed so the cached CRL is re-signed.
+func bumpCRLRevision(ctx context.Context, tx pgx.Tx) error {
+	if _, err := tx.Exec(ctx, `UPDATE crl_revision SET revision = revision + 1`); err != nil {
+		return fmt.Errorf("bump crl revision: %w", err)
+	}
+	return nil
+}
+
+// doGetCRLRevision returns the revision of the revoked set, bumped on every change to it.
+func (r *pgRepo) doGetCRLRevision(ctx context.Context) (int64, error) {
+	var revision int64
+	if err := r.pool.QueryRow(ctx, `SELECT revision FROM crl_revision`).Scan(&revision); err != nil {
+		return 0, fmt.Errorf("get crl revision: %w", err)
+	}
+	return revision, nil
+}
+
+// doListRevokedCerts returns revoked certificates that have not yet expired, with the
+// next CRL number and the revision of the revoked set they were read at. The revision
+// is read first, so a change racing the scan leaves the list marked stale.
+func (r *pgRepo) doListRevokedCerts(ctx context.Context) (*RevocationList, error) {
+	var list RevocationList
+	if err := r.pool.QueryRow(ctx, `SELECT revision FROM crl_revision`).Scan(&list.Revision); err != nil {
+		return nil, fmt.Errorf("get crl revision: %w", err)
+	}
+	if err := r.pool.QueryRow(ctx, `SELECT nextval('crl_number_seq')`).Scan(&list.Number); err != nil {
+		return nil, fmt.Errorf("next crl number: %w", err)
+	}
+
+	rows, err := r.pool.Query(ctx,
+		`SELECT serial, revoked_at, revocation_reason
+		 FROM issued_certificates
+		 WHERE revoked_at IS NOT NULL AND (not_after IS NULL OR not_after > now())
+		 ORDER BY revoked_at`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list revoked certificates: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var c RevokedCert
+		if err := rows.Scan(&c.Serial, &c.RevokedAt, &c.Reason); err != nil {
+			return nil, fmt.Errorf("scan revoked certificate: %w", err)
+		}
+		list.Entries = append(list.Entries, c)
+	}
+	return &list, rows.Err()
+}
+
+// doGetIssuedCert returns nil when the CA never issued the serial.
+func (r *pgRepo) doGetIssuedCert(ctx context.Context, serial string) (*IssuedCertRecord, error) {
+	var c IssuedCertRecord
+	err := r.pool.QueryRow(ctx,
+		`SELECT serial, device_id, not_before, not_after, issued_at, revoked_at, COALESCE(revocation_reason, '')
+		 FROM issued_certificates WHERE serial = $1`,
+		serial,
+	).Scan(&c.Serial, &c.DeviceID, &c.NotBefore, &c.NotAfter, &c.IssuedAt, &c.RevokedAt, &c.RevocationReason)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get issued certificate: %w", err)
+	}
+	return &c, nil
+}
+
+// doOpenSession records an authenticated broker connection and returns the session ID.
+func (r *pgRepo) doOpenSession(ctx context.Context, input OpenSessionInput) (string, error) {
+	var certSerial *string
+	if input.CertSerial != "" {
+		certSerial = &input.CertSerial
+	}
+	id := ulid.Make().String()
+	_, err := r.pool.Exec(ctx,
+		`INSERT INTO device_sessions (id, device_id, connected_at, remote_addr, cert_serial)
+		 VALUES ($1, $2, $3, $4, $5)`,
+		id, input.DeviceID, input.ConnectedAt, input.RemoteAddr, certSerial,
+	)
+	if err != nil {
+		return "", fmt.Errorf("open device session: %w", err)
+	}
+	return id, nil
+}
+
+// doCloseSession stamps the end of a session. Closing an already-closed session is a no-op.
+func (r *pgRepo) doCloseSession(ctx context.Context, input CloseSessionInput) error {
+	_, err := r.pool.Exec(ctx,
+		`UPDATE device_sessions
+		 SET disconnected_at = $2, disconnect_reason = $3, graceful = $4, will_published = $5
+		 WHERE id = $1 AND disconnected_at IS NULL`,
+		input.ID, input.DisconnectedAt, input.Reason, input.Graceful, input.WillPublished,
+	)
+	if err != nil {
+		return fmt.Errorf("close device session: %w", err)
+	}
+	return nil
+}
+
+// doCloseOpenSessions closes every open session, for sessions left behind by a broker
+// that stopped without seeing its clients disconnect. Returns the number closed.
+func (r *pgRepo) doCloseOpenSessions(ctx context.Context, reason string) (int64, error) {
+	tag, err := r.pool.Exec(ctx,
+		`UPDATE device_sessions
+		 SET disconnected_at = now(), disconnect_reason = $1, graceful = false, will_published = false
+		 WHERE disconnected_at IS NULL`,
+		reason,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("close open device sessions: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// doRecordHealth stores a heartbeat and returns the device's owner and active alerts.
+func (r *pgRepo) doRecordHealth(ctx context.Context, input RecordHealthInput) (*HealthState, error) {
+	faults := input.SensorFaults
+	if faults == nil {
+		faults = []string{}
+	}
+	_, err := r.pool.Exec(ctx,
+		`INSERT INTO device_health (id, device_id, reported_at, battery_percent, rssi_dbm, uptime_seconds, free_memory_bytes, sensor_faults, firmware_build)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		ulid.Make().String(), input.DeviceID, input.ReportedAt, input.BatteryPercent, input.RSSIDbm,
+		input.UptimeSeconds, input.FreeMemoryBytes, faults, input.FirmwareBuild,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("insert device health: %w", err)
+	}
+
+	state := HealthState{DeviceID: input.DeviceID}
+	err = r.pool.QueryRow(ctx,
+		`SELECT owner_id, ARRAY(SELECT alert FROM device_health_alerts WHERE device_id = $1 ORDER BY alert)
+		 FROM devices WHERE id = $1`,
+		input.DeviceID,
+	).Scan(&state.OwnerID, &state.ActiveAlerts)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("device %s not found", input.DeviceID)
+		}
+		return nil, fmt.Errorf("get device health alerts: %w", err)
+	}
+	return &state, nil
+}
+
+// doSetHealthAlerts raises and clears a device's health alerts in one transaction.
+// Raising an active alert keeps its original raised_at.
+func (r *pgRepo) doSetHealthAlerts(ctx context.Context, input SetHealthAlertsInput) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if len(input.Raise) > 0 {
+		if _, err := tx.Exec(ctx,
+			`INSERT INTO device_health_alerts (device_id, alert)
+			 SELECT $1, unnest($2::text[])
+			 ON CONFLICT (device_id, alert) DO NOTHING`,
+			input.DeviceID, input.Raise,
+		); err != nil {
+			return fmt.Errorf("raise health alerts: %w", err)
+		}
+	}
+	if len(input.Clear) > 0 {
+		if _, err := tx.Exec(ctx,
+			`DELETE FROM device_health_alerts WHERE device_id = $1 AND alert = ANY($2)`,
+			input.DeviceID, input.Clear,
+		); err != nil {
+			return fmt.Errorf("clear health alerts: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit: %w", err)
+	}
+	return nil
+}
+
+// doListSilentDevices returns active devices that have sent heartbeats but none since before,
+// and are not already flagged silent. Devices that never reported health are not listed.
+func (r *pgRepo) doListSilentDevices(ctx context.Context, before time.Time) ([]SilentDevice, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT d.id, d.owner_id, h.last_heartbeat
+		 FROM devices d
+		 JOIN (SELECT device_id, MAX(received_at) AS last_heartbeat FROM device_health GROUP BY device_id) h
+		   ON h.device_id = d.id
+		 WHERE d.status = 'active' AND h.last_heartbeat < $1
+		   AND NOT EXISTS (SELECT 1 FROM device_health_alerts a WHERE a.device_id = d.id AND a.alert = 'silent')
+		 ORDER BY h.last_heartbeat`,
+		before,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list silent devices: %w", err)
+	}
+	defer rows.Close()
+
+	var devices []SilentDevice
+	for rows.Next() {
+		var d SilentDevice
+		if err := rows.Scan(&d.DeviceID, &d.OwnerID, &d.LastHeartbeat); err != nil {
+			return nil, fmt.Errorf("scan silent device: %w", err)
+		}
+		devices = append(devices, d)
+	}
+	return devices, rows.Err()
+}
+
+func unitsOrEmpty(units map[string]string) map[string]string {
+	if units == nil {
+		return map[string]string{}
+	}
+	return units
+}
+
+// configCampaignCondition matches a live campaign k that device d is enrolled in, through
+// either a scitizen enrollment or a direct device enrollment, at time $1.
+const configCampaignCondition = `k.status IN ('published', 'active') AND (k.window_end IS NULL OR k.window_end > $1)
+		   AND (EXISTS (SELECT 1 FROM campaign_enrollments e WHERE e.campaign_id = k.id AND e.device_id = d.id AND e.status = 'active')
+		     OR EXISTS (SELECT 1 FROM device_campaigns dc WHERE dc.campaign_id = k.id AND dc.device_id = d.id))`
+
+// doListConfigCampaigns returns the campaigns a device should be configured for.
+func (r *pgRepo) doListConfigCampaigns(ctx context.Context, deviceID string, at time.Time) ([]string, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT k.id FROM campaigns k, devices d
+		 WHERE d.id = $2 AND `+configCampaignCondition+`
+		 ORDER BY k.id`,
+		at, deviceID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list config campaigns: %w", err)
+	}
+	return collectIDs(rows, "config campaign")
+}
+
+// doListCampaignDevices returns the devices enrolled in a campaign, plus any whose last
+// pushed config still lists it.
+func (r *pgRepo) doListCampaignDevices(ctx context.Context, campaignID string) ([]string, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT device_id FROM campaign_enrollments WHERE campaign_id = $1 AND status = 'active'
+		 UNION
+		 SELECT device_id FROM device_campaigns WHERE campaign_id = $1
+		 UNION
+		 SELECT device_id FROM device_configs WHERE $1 = ANY(campaign_ids)
+		 ORDER BY device_id`,
+		campaignID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list campaign devices: %w", err)
+	}
+	return collectIDs(rows, "campaign device")
+}
+
+// doListEndedConfigDevices returns devices whose last pushed config lists a campaign they
+// should no longer run: it ended or was cancelled, or the device was withdrawn from it.
+func (r *pgRepo) doListEndedConfigDevices(ctx context.Context, at time.Time) ([]string, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT d.id FROM device_configs c
+		 JOIN devices d ON d.id = c.device_id
+		 WHERE EXISTS (
+		   SELECT 1 FROM unnest(c.campaign_ids) AS cid
+		   WHERE NOT EXISTS (SELECT 1 FROM campaigns k WHERE k.id = cid AND `+configCampaignCondition+`)
+		 )
+		 ORDER BY d.id`,
+		at,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list ended config devices: %w", err)
+	}
+	return collectIDs(rows, "ended config device")
+}
+
+// doSaveDeviceConfig stores the config about to be pushed. The version moves only when the
+// digest changes; otherwise the stored config is returned unchanged.
+func (r *pgRepo) doSaveDeviceConfig(ctx context.Context, input SaveDeviceConfigInput) (*DeviceConfig, error) {
+	campaignIDs := input.CampaignIDs
+	if campaignIDs == nil {
+		campaignIDs = []string{}
+	}
+	var version int64
+	err := r.pool.QueryRow(ctx,
+		`INSERT INTO device_configs (device_id, version, digest, campaign_ids, campaigns)
+		 VALUES ($1, 1, $2, $3, $4)
+		 ON CONFLICT (device_id) DO UPDATE
+		   SET version = device_configs.version + 1, digest = EXCLUDED.digest,
+		       campaign_ids = EXCLUDED.campaign_ids, campaigns = EXCLUDED.campaigns, pushed_at = now()
+		   WHERE device_configs.digest <> EXCLUDED.digest
+		 RETURNING version`,
+		input.DeviceID, input.Digest, campaignIDs, input.Campaigns,
+	).Scan(&version)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, fmt.Errorf("save device config: %w", err)
+	}
+	changed := err == nil
+
+	cfg, err := r.doGetDeviceConfig(ctx, input.DeviceID)
+	if err != nil {
+		return nil, err
+	}
+	if cfg == nil {
+		return nil, fmt.Errorf("device %s not found", input.DeviceID)
+	}
+	cfg.Changed = changed
+	return cfg, nil
+}
+
+// doGetDeviceConfig returns nil when no config has been pushed to the device.
+func (r *pgRepo) doGetDeviceConfig(ctx context.Context, deviceID string) (*DeviceConfig, error) {
+	c := DeviceConfig{DeviceID: deviceID}
+	err := r.pool.QueryRow(ctx,
+		`SELECT version, digest, campaign_ids, campaigns, pushed_at, acked_version, acked_at
+		 FROM device_configs WHERE device_id = $1`,
+		deviceID,
+	).Scan(&c.Version, &c.Digest, &c.CampaignIDs, &c.Campaigns, &c.PushedAt, &c.AckedVersion, &c.AckedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get device config: %w", err)
+	}
+	return &c, nil
+}
+
+// doAckDeviceConfig records the device's acknowledgement of a config version. Acks for
+// versions never pushed, or older than one already acknowledged, are ignored.
+func (r *pgRepo) doAckDeviceConfig(ctx context.Context, deviceID string, version int64) (bool, error) {
+	tag, err := r.pool.Exec(ctx,
+		`UPDATE device_configs SET acked_version = $2, acked_at = now()
+		 WHERE device_id = $1 AND $2 <= version AND (acked_version IS NULL OR acked_version < $2)`,
+		deviceID, version,
+	)
+	if err != nil {
+		return false, fmt.Errorf("ack device config: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// doListCommandTargets decides who may command what. A device may be commanded by its
+// owner, or by the creator of a live campaign it is enrolled in. A campaign's devices may
+// be commanded together only by the campaign's creator. An unknown device or campaign
+// reads as not permitted, so callers cannot probe which IDs exist.
+func (r *pgRepo) doListCommandTargets(ctx context.Context, input CommandTargetsInput) (*CommandTargets, error) {
+	if input.CampaignID == "" {
+		var permitted bool
+		err := r.pool.QueryRow(ctx,
+			`SELECT d.owner_id = $3
+			        OR EXISTS (SELECT 1 FROM campaigns k WHERE k.created_by = $3 AND `+configCampaignCondition+`)
+			 FROM devices d WHERE d.id = $2`,
+			input.At, input.DeviceID, input.IssuerID,
+		).Scan(&permitted)
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				return &CommandTargets{}, nil
+			}
+			return nil, fmt.Errorf("check command access: %w", err)
+		}
+		if !permitted {
+			return &CommandTargets{}, nil
+		}
+		return &CommandTargets{Permitted: true, DeviceIDs: []string{input.DeviceID}}, nil
+	}
+
+	var createdBy string
+	err := r.pool.QueryRow(ctx, `SELECT created_by FROM campaigns WHERE id = $1`, input.CampaignID).Scan(&createdBy)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return &CommandTargets{}, nil
+		}
+		return nil, fmt.Errorf("get campaign creator: %w", err)
+	}
+	if createdBy != input.IssuerID {
+		return &CommandTargets{}, nil
+	}
+	rows, err := r.pool.Query(ctx,
+		`SELECT d.id FROM campaigns k, devices d
+		 WHERE k.id = $2 AND `+configCampaignCondition+`
+		 ORDER BY d.id`,
+		input.At, input.CampaignID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list command targets: %w", err)
+	}
+	ids, err := collectIDs(rows, "command target")
+	if err != nil {
+		return nil, err
+	}
+	return &CommandTargets{Permitted: true, DeviceIDs: ids}, nil
+}
+
+// commandColumns selects a device_commands row. A command left unacknowledged past its
+// expiry reads as expired.
+const commandColumns = `id, device_id, campaign_id, batch_id, command, params, issued_by, issued_at, expires_at,
+		        CASE WHEN status = 'sent' AND expires_at <= now() THEN 'expired' ELSE status END,
+		        acked_at, completed_at, result, error`
+
+func scanCommand(row pgx.Row) (*DeviceCommand, error) {
+	var c DeviceCommand
+	err := row.Scan(&c.ID, &c.DeviceID, &c.CampaignID, &c.BatchID, &c.Command, &c.Params, &c.IssuedBy, &c.IssuedAt,
+		&c.ExpiresAt, &c.Status, &c.AckedAt, &c.CompletedAt, &c.Result, &c.Error)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// doCreateCommands records one command per device under a shared batch ID, in one
+// transaction.
+func (r *pgRepo) doCreateCommands(ctx context.Context, input CreateCommandsInput) ([]DeviceCommand, error) {
+	params := input.Params
+	if params == nil {
+		params = []byte(`{}`)
+	}
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	batchID := ulid.Make().String()
+	commands := make([]DeviceCommand, 0, len(input.DeviceIDs))
+	for _, deviceID := range input.DeviceIDs {
+		c, err := scanCommand(tx.QueryRow(ctx,
+			`INSERT INTO device_commands (id, device_id, campaign_id, batch_id, command, params, issued_by, expires_at)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			 RETURNING `+commandColumns,
+			ulid.Make().String(), deviceID, input.CampaignID, batchID, input.Command, params, input.IssuedBy, input.ExpiresAt,
+		))
+		if err != nil {
+			return nil, fmt.Errorf("insert device command: %w", err)
+		}
+		commands = append(commands, *c)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+	return commands, nil
+}
+
+func (r *pgRepo) doListCommands(ctx context.Context, input ListCommandsInput) ([]DeviceCommand, error) {
+	query := `SELECT ` + commandColumns + ` FROM device_commands WHERE 1=1`
+	var args []any
+	argIdx := 1
+
+	if input.DeviceID != "" {
+		query += fmt.Sprintf(" AND device_id = $%d", argIdx)
+		args = append(args, input.DeviceID)
+		argIdx++
+	}
+	if input.CampaignID != "" {
+		query += fmt.Sprintf(" AND campaign_id = $%d", argIdx)
+		args = append(args, input.CampaignID)
+		argIdx++
+	}
+	query += " ORDER BY issued_at DESC, id DESC"
+	if input.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", argIdx)
+		args = append(args, input.Limit)
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list device commands: %w", err)
+	}
+	return collectCommands(rows)
+}
+
+// doListPendingCommands returns the device's commands still waiting for an
+// acknowledgement and not yet expired, oldest first.
+func (r *pgRepo) doListPendingCommands(ctx context.Context, deviceID string, at time.Time) ([]DeviceCommand, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT `+commandColumns+` FROM device_commands
+		 WHERE device_id = $1 AND status = 'sent' AND expires_at > $2
+		 ORDER BY issued_at, id`,
+		deviceID, at,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list pending device commands: %w", err)
+	}
+	return collectCommands(rows)
+}
+
+// doRecordCommandResult applies a device's report on one of its commands. An expired
+// command can no longer be acknowledged, and a finished one can no longer change.
+// Reports whether the report was applied.
+func (r *pgRepo) doRecordCommandResult(ctx context.Context, input RecordCommandResultInput) (bool, error) {
+	tag, err := r.pool.Exec(ctx,
+		`UPDATE device_commands
+		 SET status = $3::text,
+		     acked_at = COALESCE(acked_at, now()),
+		     completed_at = CASE WHEN $3::text IN ('succeeded', 'failed') THEN now() END,
+		     result = COALESCE($4, result),
+		     error = $5
+		 WHERE id = $1 AND device_id = $2
+		   AND ((status = 'sent' AND expires_at > now())
+		     OR (status = 'acknowledged' AND $3::text IN ('succeeded', 'failed')))`,
+		input.CommandID, input.DeviceID, input.Status, input.Result, input.Error,
+	)
+	if err != nil {
+		return false, fmt.Errorf("record command result: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func collectCommands(rows pgx.Rows) ([]DeviceCommand, error) {
+	defer rows.Close()
+	var commands []DeviceCommand
+	for rows.Next() {
+		c, err := scanCommand(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan device command: %w", err)
+		}
+		commands = append(commands, *c)
+	}
+	return commands, rows.Err()
+}
+
+// shadowFields are the state columns on each side of device_shadows, prefixed with
+// desired_ or reported_.
+var shadowFields = []string{"sampling_interval_seconds", "enabled_sensors", "active_campaigns", "firmware_version"}
+
+// doGetShadow returns nil when the device has no shadow yet.
+func (r *pgRepo) doGetShadow(ctx context.Context, deviceID string) (*DeviceShadow, error) {
+	s := DeviceShadow{DeviceID: deviceID}
+	err := r.pool.QueryRow(ctx,
+		`SELECT version,
+		        desired_sampling_interval_seconds, desired_enabled_sensors, desired_active_campaigns, desired_firmware_version, desired_updated_at,
+		        reported_sampling_interval_seconds, reported_enabled_sensors, reported_active_campaigns, reported_firmware_version, reported_updated_at
+		 FROM device_shadows WHERE device_id = $1`,
+		deviceID,
+	).Scan(&s.Version,
+		&s.Desired.SamplingIntervalSeconds, &s.Desired.EnabledSensors, &s.Desired.ActiveCampaigns, &s.Desired.FirmwareVersion, &s.DesiredUpdatedAt,
+		&s.Reported.SamplingIntervalSeconds, &s.Reported.EnabledSensors, &s.Reported.ActiveCampaigns, &s.Reported.FirmwareVersion, &s.ReportedUpdatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get device shadow: %w", err)
+	}
+	return &s, nil
+}
+
+// doUpdateShadow merges the set fields into one side of the shadow, creating it if need
+// be. The version and the side's updated_at move only when a stored value changes.
+func (r *pgRepo) doUpdateShadow(ctx context.Context, side string, input UpdateShadowInput) (*DeviceShadow, error) {
+	cols := make([]string, len(shadowFields))
+	sets := make([]string, len(shadowFields))
+	changes := make([]string, len(shadowFields))
+	for i, f := range shadowFields {
+		col := side + "_" + f
+		cols[i] = col
+		sets[i] = fmt.Sprintf("%[1]s = COALESCE(EXCLUDED.%[1]s, device_shadows.%[1]s)", col)
+		changes[i] = fmt.Sprintf("(EXCLUDED.%[1]s IS NOT NULL AND EXCLUDED.%[1]s IS DISTINCT FROM device_shadows.%[1]s)", col)
+	}
+	query := fmt.Sprintf(
+		`INSERT INTO device_shadows (device_id, %[1]s, %[2]s_updated_at)
+		 VALUES ($1, $2, $3, $4, $5, now())
+		 ON CONFLICT (device_id) DO UPDATE
+		   SET %[3]s, %[2]s_updated_at = now(), version = device_shadows.version + 1
+		   WHERE %[4]s
+		 RETURNING version`,
+		strings.Join(cols, ", "), side, strings.Join(sets, ", "), strings.Join(changes, " OR "),
+	)
+
+	var version int64
+	err := r.pool.QueryRow(ctx, query,
+		input.DeviceID, input.State.SamplingIntervalSeconds, input.State.EnabledSensors,
+		input.State.ActiveCampaigns, input.State.FirmwareVersion,
+	).Scan(&version)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, fmt.Errorf("update device shadow %s: %w", side, err)
+	}
+	changed := err == nil
+
+	shadow, err := r.doGetShadow(ctx, input.DeviceID)
+	if err != nil {
+		return nil, err
+	}
+	if shadow == nil {
+		return nil, fmt.Errorf("device %s not found", input.DeviceID)
+	}
+	shadow.Changed = changed
+	return shadow, nil
+}
+
+const releaseColumns = `id, class, version, sha256, url, size_bytes, notes, security_fix,
+	rollout_percent, cohort, created_by, created_at, updated_at`
+
+func scanRelease(row pgx.Row) (*FirmwareRelease, error) {
+	var f FirmwareRelease
+	err := row.Scan(&f.ID, &f.Class, &f.Version, &f.SHA256, &f.URL, &f.SizeBytes, &f.Notes, &f.SecurityFix,
+		&f.RolloutPercent, &f.Cohort, &f.CreatedBy, &f.CreatedAt, &f.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+// doCreateFirmwareRelease returns nil when the class already has a release of that version.
+func (r *pgRepo) doCreateFirmwareRelease(ctx context.Context, input CreateFirmwareReleaseInput) (*FirmwareRelease, error) {
+	cohort := input.Cohort
+	if cohort == nil {
+		cohort = []string{}
+	}
+	f, err := scanRelease(r.pool.QueryRow(ctx,
+		`INSERT INTO firmware_releases (id, class, version, sha256, url, size_bytes, notes, security_fix, rollout_percent, cohort, created_by)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		 ON CONFLICT (class, version) DO NOTHING
+		 RETURNING `+releaseColumns,
+		ulid.Make().String(), input.Class, input.Version, input.SHA256, input.URL, input.SizeBytes,
+		input.Notes, input.SecurityFix, input.RolloutPercent, cohort, input.CreatedBy,
+	))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("insert firmware release: %w", err)
+	}
+	return f, nil
+}
+
+// doGetFirmwareRelease returns nil when there is no such release.
+func (r *pgRepo) doGetFirmwareRelease(ctx context.Context, id string) (*FirmwareRelease, error) {
+	f, err := scanRelease(r.pool.QueryRow(ctx,
+		`SELECT `+releaseColumns+` FROM firmware_releases WHERE id = $1`, id,
+	))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get firmware release: %w", err)
+	}
+	return f, nil
+}
+
+// doListFirmwareReleases lists a class's releases, or every release when class is empty,
+// newest first.
+func (r *pgRepo) doListFirmwareReleases(ctx context.Context, class string) ([]FirmwareRelease, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT `+releaseColumns+` FROM firmware_releases
+		 WHERE $1 = '' OR class = $1
+		 ORDER BY created_at DESC, id DESC`,
+		class,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list firmware releases: %w", err)
+	}
+	defer rows.Close()
+
+	var releases []FirmwareRelease
+	for rows.Next() {
+		f, err := scanRelease(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan firmware release: %w", err)
+		}
+		releases = append(releases, *f)
+	}
+	return releases, rows.Err()
+}
+
+func (r *pgRepo) doSetFirmwareRollout(ctx context.Context, input SetFirmwareRolloutInput) (*FirmwareRelease, error) {
+	cohort := input.Cohort
+	if cohort == nil {
+		cohort = []string{}
+	}
+	f, err := scanRelease(r.pool.QueryRow(ctx,
+		`UPDATE firmware_releases SET rollout_percent = $2, cohort = $3, updated_at = now()
+		 WHERE id = $1
+		 RETURNING `+releaseColumns,
+		input.ReleaseID, input.RolloutPercent, cohort,
+	))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("firmware release %s not found", input.ReleaseID)
+		}
+		return nil, fmt.Errorf("set firmware rollout: %w", err)
+	}
+	return f, nil
+}
+
+// doListFirmwareCandidates lists the active and suspended devices of the release's class
+// that are not running its version, with how far each has got installing it.
+func (r *pgRepo) doListFirmwareCandidates(ctx context.Context, releaseID string) ([]FirmwareCandidate, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT d.id, d.owner_id, d.status, d.firmware_version, i.status
+		 FROM firmware_releases f
+		 JOIN devices d ON d.class = f.class AND d.firmware_version <> f.version
+		   AND d.status IN ('active', 'suspended')
+		 LEFT JOIN firmware_installs i ON i.release_id = f.id AND i.device_id = d.id
+		 WHERE f.id = $1
+		 ORDER BY d.id`,
+		releaseID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list firmware candidates: %w", err)
+	}
+	defer rows.Close()
+
+	var candidates []FirmwareCandidate
+	for rows.Next() {
+		var c FirmwareCandidate
+		if err := rows.Scan(&c.DeviceID, &c.OwnerID, &c.Status, &c.FirmwareVersion, &c.InstallStatus); err != nil {
+			return nil, fmt.Errorf("scan firmware candidate: %w", err)
+		}
+		candidates = append(candidates, c)
+	}
+	return candidates, rows.Err()
+}
+
+// doMarkFirmwareNotified records that the devices were sent the release. A device that
+// failed to install it is reset to notified; other progress is kept.
+func (r *pgRepo) doMarkFirmwareNotified(ctx context.Context, releaseID string, deviceIDs []string) error {
+	_, err := r.pool.Exec(ctx,
+		`INSERT INTO firmware_installs (release_id, device_id)
+		 SELECT $1, unnest($2::text[])
+		 ON CONFLICT (release_id, device_id) DO UPDATE
+		   SET status = 'notified', error = NULL, notified_at = now(), updated_at = now()
+		   WHERE firmware_installs.status = 'failed'`,
+		releaseID, deviceIDs,
+	)
+	if err != nil {
+		return fmt.Errorf("mark firmware notified: %w", err)
+	}
+	return nil
+}
+
+// doRecordFirmwareInstall applies a device's report on a release it was sent. Reports on
+// a release the device was not sent, or already installed, are refused. Once installed,
+// the device's firmware version becomes the release's.
+func (r *pgRepo) doRecordFirmwareInstall(ctx context.Context, input RecordFirmwareInstallInput) (bool, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx,
+		`UPDATE firmware_installs SET status = $3, error = $4, updated_at = now()
+		 WHERE release_id = $1 AND device_id = $2 AND status <> 'installed'`,
+		input.ReleaseID, input.DeviceID, input.Status, input.Error,
+	)
+	if err != nil {
+		return false, fmt.Errorf("record firmware install: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return false, nil
+	}
+	if input.Status == "installed" {
+		if _, err := tx.Exec(ctx,
+			`UPDATE devices SET firmware_version = f.version
+			 FROM firmware_releases f
+			 WHERE devices.id = $2 AND f.id = $1`,
+			input.ReleaseID, input.DeviceID,
+		); err != nil {
+			return false, fmt.Errorf("update device firmware: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("commit: %w", err)
+	}
+	return true, nil
+}
+
+func (r *pgRepo) doListFirmwareInstalls(ctx context.Context, releaseID string) ([]FirmwareInstall, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT release_id, device_id, status, error, notified_at, updated_at
+		 FROM firmware_installs WHERE release_id = $1
+		 ORDER BY updated_at DESC, device_id`,
+		releaseID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list firmware installs: %w", err)
+	}
+	defer rows.Close()
+
+	var installs []FirmwareInstall
+	for rows.Next() {
+		var i FirmwareInstall
+		if err := rows.Scan(&i.ReleaseID, &i.DeviceID, &i.Status, &i.Error, &i.NotifiedAt, &i.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scan firmware install: %w", err)
+		}
+		installs = append(installs, i)
+	}
+	return installs, rows.Err()
+}
+
+func collectIDs(rows pgx.Rows, what string) ([]string, error) {
+	defer rows.Close()
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan %s: %w", what, err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func (r *pgRepo) doRedeemDelegatedCode(ctx context.Context, input RedeemDelegatedCodeInput) (*EnrollmentCode, error) {
+	var ec EnrollmentCode
+	err := r.pool.QueryRow(ctx,
+		`WITH redeemed AS (
+		     UPDATE enrollment_codes ec
+		     SET used = true
+		     FROM devices child, devices gw
+		     WHERE ec.code = $1 AND ec.used = false AND ec.expires_at > now()
+		       AND child.id = ec.device_id AND NOT child.gateway
+		       AND gw.id = $2 AND gw.id <> child.id AND gw.owner_id = child.owner_id
+		     RETURNING ec.code, ec.device_id, ec.expires_at, ec.used
+		 )
+		 UPDATE devices d SET gateway_id = $2
+		 FROM redeemed WHERE d.id = redeemed.device_id
+		 RETURNING redeemed.code, redeemed.device_id, redeemed.expires_at, redeemed.used`,
+		input.Code, input.GatewayID,
+	).Scan(&ec.Code, &ec.DeviceID, &ec.ExpiresAt, &ec.Used)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("redeem delegated code: %w", err)
+	}
+	return &ec, nil
+}
+
+func (r *pgRepo) doListGatewayChildren(ctx context.Context, gatewayID string) ([]GatewayChild, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT id, status, class FROM devices WHERE gateway_id = $1 ORDER BY created_at`,
+		gatewayID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list gateway children: %w", err)
+	}
+	defer rows.Close()
+
+	var children []GatewayChild
+	for rows.Next() {
+		var c GatewayChild
+		if err := rows.Scan(&c.DeviceID, &c.Status, &c.Class); err != nil {
+			return nil, fmt.Errorf("scan gateway child: %w", err)
+		}
+		children = append(children, c)
+	}
+	return children, rows.Err()
+}
+
+func (r *pgRepo) doSaveStationLink(ctx context.Context, input SaveStationLinkInput) (*StationLink, error) {
+	var l StationLink
+	err := r.pool.QueryRow(ctx,
+		`INSERT INTO station_links (device_id, protocol, token_hash, parameter_map)
+		 VALUES ($1, $2, $3, $4)
+		 ON CONFLICT (device_id) DO UPDATE
+		 SET protocol = EXCLUDED.protocol, token_hash = EXCLUDED.token_hash,
+		     parameter_map = EXCLUDED.parameter_map, created_at = now(), last_upload_at = NULL
+		 RETURNING device_id, protocol, parameter_map, created_at, last_upload_at`,
+		input.DeviceID, input.Protocol, input.TokenHash, unitsOrEmpty(input.ParameterMap),
+	).Scan(&l.DeviceID, &l.Protocol, &l.ParameterMap, &l.CreatedAt, &l.LastUploadAt)
+	if err != nil {
+		return nil, fmt.Errorf("save station link: %w", err)
+	}
+	return &l, nil
+}
+
+func (r *pgRepo) doResolveStationToken(ctx context.Context, tokenHash string) (*StationLink, error) {
+	var l StationLink
+	err := r.pool.QueryRow(ctx,
+		`UPDATE station_links SET last_upload_at = now()
+		 WHERE token_hash = $1
+		 RETURNING device_id, protocol, parameter_map, created_at, last_upload_at`,
+		tokenHash,
+	).Scan(&l.DeviceID, &l.Protocol, &l.ParameterMap, &l.CreatedAt, &l.LastUploadAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("resolve station token: %w", err)
+	}
+	return &l, nil
+}
+
+func (r *pgRepo) doDeleteStationLink(ctx context.Context, deviceID string) (bool, error) {
+	tag, err := r.pool.Exec(ctx, `DELETE FROM station_links WHERE device_id = $1`, deviceID)
+	if err != nil {
+		return false, fmt.Errorf("delete station link: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
@@ -3,7 +3,9 @@ package device
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/oklog/ulid/v2"
@@ -156,6 +158,164 @@ func TestEnrollmentCodeLifecycle(t *testing.T) {
 	}
 }
 
+func TestDelegatedEnrollment(t *testing.T) {
+	repo, _ := setupTest(t)
+	ctx := context.Background()
+
+	gw, err := repo.Create(ctx, CreateDeviceInput{
+		OwnerID: "user-1", Class: "lora-hub", FirmwareVersion: "1.0.0", Tier: 2, Sensors: []string{"rssi"}, Gateway: true,
+	})
+	if err != nil {
+		t.Fatalf("Create(gateway): %v", err)
+	}
+	if !gw.Gateway || gw.GatewayID != nil {
+		t.Errorf("gateway = %v, gateway_id = %v; want true, nil", gw.Gateway, gw.GatewayID)
+	}
+	child, _ := repo.Create(ctx, CreateDeviceInput{
+		OwnerID: "user-1", Class: "soil-probe", FirmwareVersion: "1.0.0", Tier: 2, Sensors: []string{"moisture"},
+	})
+	other, _ := repo.Create(ctx, CreateDeviceInput{
+		OwnerID: "user-2", Class: "soil-probe", FirmwareVersion: "1.0.0", Tier: 2, Sensors: []string{"moisture"},
+	})
+	repo.GenerateEnrollmentCode(ctx, GenerateCodeInput{DeviceID: child.ID, Code: "CHILD1", TTL: 900})
+	repo.GenerateEnrollmentCode(ctx, GenerateCodeInput{DeviceID: other.ID, Code: "OTHER1", TTL: 900})
+
+	// Another owner's device cannot be enrolled through the gateway, and the code survives
+	if code, err := repo.RedeemDelegatedCode(ctx, RedeemDelegatedCodeInput{Code: "OTHER1", GatewayID: gw.ID}); err != nil || code != nil {
+		t.Errorf("redeeming another owner's code = %+v, %v; want nil, nil", code, err)
+	}
+	if _, err := repo.RedeemEnrollmentCode(ctx, "OTHER1"); err != nil {
+		t.Errorf("refused delegated redeem should leave the code usable: %v", err)
+	}
+
+	code, err := repo.RedeemDelegatedCode(ctx, RedeemDelegatedCodeInput{Code: "CHILD1", GatewayID: gw.ID})
+	if err != nil {
+		t.Fatalf("RedeemDelegatedCode(): %v", err)
+	}
+	if code.DeviceID != child.ID || !code.Used {
+		t.Errorf("redeemed = %+v, want used code for %s", code, child.ID)
+	}
+	got, _ := repo.Get(ctx, child.ID)
+	if got.GatewayID == nil || *got.GatewayID != gw.ID {
+		t.Errorf("child gateway_id = %v, want %s", got.GatewayID, gw.ID)
+	}
+
+	children, err := repo.ListGatewayChildren(ctx, gw.ID)
+	if err != nil {
+		t.Fatalf("ListGatewayChildren(): %v", err)
+	}
+	if len(children) != 1 || children[0].DeviceID != child.ID || children[0].Status != "pending" {
+		t.Errorf("children = %+v, want [%s pending]", children, child.ID)
+	}
+}
+
+func TestStationLinks(t *testing.T) {
+	repo, _ := setupTest(t)
+	ctx := context.Background()
+
+	d, _ := repo.Create(ctx, CreateDeviceInput{
+		OwnerID: "user-1", Class: "weather-station", FirmwareVersion: "1.0.0", Tier: 1, Sensors: []string{"temperature"},
+	})
+	if _, err := repo.SaveStationLink(ctx, SaveStationLinkInput{DeviceID: d.ID, Protocol: "wunderground", TokenHash: "hash-1"}); err != nil {
+		t.Fatalf("SaveStationLink(): %v", err)
+	}
+	// Relinking replaces the token
+	if _, err := repo.SaveStationLink(ctx, SaveStationLinkInput{
+		DeviceID: d.ID, Protocol: "ecowitt", TokenHash: "hash-2", ParameterMap: map[string]string{"temperature": "air_temp"},
+	}); err != nil {
+		t.Fatalf("SaveStationLink(relink): %v", err)
+	}
+
+	if l, err := repo.ResolveStationToken(ctx, "hash-1"); err != nil || l != nil {
+		t.Errorf("ResolveStationToken(old) = %+v, %v; want nil, nil", l, err)
+	}
+	l, err := repo.ResolveStationToken(ctx, "hash-2")
+	if err != nil {
+		t.Fatalf("ResolveStationToken(): %v", err)
+	}
+	if l == nil || l.DeviceID != d.ID || l.Protocol != "ecowitt" || l.ParameterMap["temperature"] != "air_temp" || l.LastUploadAt == nil {
+		t.Errorf("link = %+v, want ecowitt link for %s with an upload recorded", l, d.ID)
+	}
+
+	if deleted, err := repo.DeleteStationLink(ctx, d.ID); err != nil || !deleted {
+		t.Errorf("DeleteStationLink() = %v, %v; want true", deleted, err)
+	}
+	if deleted, _ := repo.DeleteStationLink(ctx, d.ID); deleted {
+		t.Error("second DeleteStationLink() = true, want false")
+	}
+}
+
+func TestListAndExpirePendingCodes(t *testing.T) {
+	repo, _ := setupTest(t)
+	ctx := context.Background()
+
+	d, _ := repo.Create(ctx, CreateDeviceInput{
+		OwnerID: "user-1", Class: "sensor", FirmwareVersion: "1.0.0", Tier: 1, Sensors: []string{"temp"},
+	})
+	other, _ := repo.Create(ctx, CreateDeviceInput{
+		OwnerID: "user-2", Class: "sensor", FirmwareVersion: "1.0.0", Tier: 1, Sensors: []string{"temp"},
+	})
+	repo.GenerateEnrollmentCode(ctx, GenerateCodeInput{DeviceID: d.ID, Code: "PEND01", TTL: 900})
+	repo.GenerateEnrollmentCode(ctx, GenerateCodeInput{DeviceID: other.ID, Code: "PEND02", TTL: 900})
+
+	codes, err := repo.ListPendingEnrollmentCodes(ctx, "user-1")
+	if err != nil {
+		t.Fatalf("ListPendingEnrollmentCodes(): %v", err)
+	}
+	if len(codes) != 1 || codes[0].Code != "PEND01" {
+		t.Fatalf("pending codes = %+v, want [PEND01]", codes)
+	}
+
+	n, err := repo.ExpireEnrollmentCodes(ctx, d.ID)
+	if err != nil {
+		t.Fatalf("ExpireEnrollmentCodes(): %v", err)
+	}
+	if n != 1 {
+		t.Errorf("expired = %d, want 1", n)
+	}
+
+	codes, _ = repo.ListPendingEnrollmentCodes(ctx, "user-1")
+	if len(codes) != 0 {
+		t.Errorf("pending codes after expire = %d, want 0", len(codes))
+	}
+
+	// Expired code cannot be redeemed
+	if _, err := repo.RedeemEnrollmentCode(ctx, "PEND01"); err == nil {
+		t.Error("redeeming expired code should fail")
+	}
+}
+
+func TestRegenerateEnrollmentCode(t *testing.T) {
+	repo, _ := setupTest(t)
+	ctx := context.Background()
+
+	d, _ := repo.Create(ctx, CreateDeviceInput{
+		OwnerID: "user-1", Class: "sensor", FirmwareVersion: "1.0.0", Tier: 1, Sensors: []string{"temp"},
+	})
+	repo.GenerateEnrollmentCode(ctx, GenerateCodeInput{DeviceID: d.ID, Code: "REGN01", TTL: 900})
+
+	// A code that collides fails the whole statement, leaving the old code pending
+	if _, err := repo.RegenerateEnrollmentCode(ctx, GenerateCodeInput{DeviceID: d.ID, Code: "REGN01", TTL: 900}); err == nil {
+		t.Fatal("RegenerateEnrollmentCode() with a used code should fail")
+	}
+	codes, _ := repo.ListPendingEnrollmentCodes(ctx, "user-1")
+	if len(codes) != 1 || codes[0].Code != "REGN01" {
+		t.Fatalf("pending codes after failed regenerate = %+v, want [REGN01]", codes)
+	}
+
+	ec, err := repo.RegenerateEnrollmentCode(ctx, GenerateCodeInput{DeviceID: d.ID, Code: "REGN02", TTL: 900})
+	if err != nil {
+		t.Fatalf("RegenerateEnrollmentCode(): %v", err)
+	}
+	if ec.Code != "REGN02" || ec.Used {
+		t.Errorf("code = %+v, want unused REGN02", ec)
+	}
+	codes, _ = repo.ListPendingEnrollmentCodes(ctx, "user-1")
+	if len(codes) != 1 || codes[0].Code != "REGN02" {
+		t.Errorf("pending codes = %+v, want [REGN02]", codes)
+	}
+}
+
 func TestQueryByClass(t *testing.T) {
 	repo, _ := setupTest(t)
 	ctx := context.Background()
@@ -177,17 +337,6 @@ func TestQueryByClass(t *testing.T) {
 	if len(devices) != 2 {
 		t.Errorf("QueryByClass(weather-station) = %d, want 2", len(devices))
 	}
-
-	devices, err = repo.QueryByClass(ctx, QueryByClassInput{
-		Class:          "weather-station",
-		FirmwareMinGte: "1.5.0",
-	})
-	if err != nil {
-		t.Fatalf("QueryByClass(gte): %v", err)
-	}
-	if len(devices) != 1 {
-		t.Errorf("QueryByClass(gte 1.5.0) = %d, want 1", len(devices))
-	}
 }
 
 func TestEnrollInCampaign(t *testing.T) {
@@ -216,3 +365,446 @@ func TestEnrollInCampaign(t *testing.T) {
 		t.Error("duplicate enrollment should fail")
 	}
 }
+
+func TestIssuedCertLedger(t *testing.T) {
+	repo, _ := setupTest(t)
+	ctx := context.Background()
+
+	d, _ := repo.Create(ctx, CreateDeviceInput{
+		OwnerID: "user-1", Class: "sensor", FirmwareVersion: "1.0.0", Tier: 1, Sensors: []string{"temp"},
+	})
+	now := time.Now().UTC()
+
+	if err := repo.RecordIssuedCert(ctx, RecordIssuedCertInput{DeviceID: d.ID, Serial: "0a1b", NotBefore: now, NotAfter: now.AddDate(0, 0, 90)}); err != nil {
+		t.Fatalf("RecordIssuedCert(first): %v", err)
+	}
+	if err := repo.RecordIssuedCert(ctx, RecordIssuedCertInput{DeviceID: d.ID, Serial: "0c2d", NotBefore: now, NotAfter: now.AddDate(0, 0, 90)}); err != nil {
+		t.Fatalf("RecordIssuedCert(renewal): %v", err)
+	}
+
+	got, _ := repo.Get(ctx, d.ID)
+	if got.CertSerial == nil || *got.CertSerial != "0c2d" {
+		t.Errorf("cert_serial = %v, want 0c2d", got.CertSerial)
+	}
+
+	old, err := repo.GetIssuedCert(ctx, "0a1b")
+	if err != nil {
+		t.Fatalf("GetIssuedCert(): %v", err)
+	}
+	if old.RevokedAt == nil || old.RevocationReason != "superseded" {
+		t.Errorf("superseded cert = %+v, want revoked as superseded", old)
+	}
+	if missing, err := repo.GetIssuedCert(ctx, "ffff"); err != nil || missing != nil {
+		t.Errorf("GetIssuedCert(unissued) = %v, %v; want nil, nil", missing, err)
+	}
+
+	first, err := repo.ListRevokedCerts(ctx)
+	if err != nil {
+		t.Fatalf("ListRevokedCerts(): %v", err)
+	}
+	if len(first.Entries) != 1 || first.Entries[0].Serial != "0a1b" {
+		t.Errorf("revoked = %+v, want only 0a1b", first.Entries)
+	}
+
+	// Revoking the device holds the current cert; reinstating releases it but not the superseded one.
+	if n, err := repo.SetCertHold(ctx, d.ID, true); err != nil || n != 1 {
+		t.Fatalf("SetCertHold(true) = %d, %v; want 1", n, err)
+	}
+	second, _ := repo.ListRevokedCerts(ctx)
+	if len(second.Entries) != 2 {
+		t.Errorf("revoked after hold = %d, want 2", len(second.Entries))
+	}
+	if second.Revision <= first.Revision {
+		t.Errorf("crl revision %d did not change from %d after hold", second.Revision, first.Revision)
+	}
+	if second.Number <= first.Number {
+		t.Errorf("crl number %d did not increase from %d", second.Number, first.Number)
+	}
+
+	if n, err := repo.SetCertHold(ctx, d.ID, false); err != nil || n != 1 {
+		t.Fatalf("SetCertHold(false) = %d, %v; want 1", n, err)
+	}
+	if rev, _ := repo.GetCRLRevision(ctx); rev <= second.Revision {
+		t.Errorf("crl revision %d did not change from %d after release", rev, second.Revision)
+	}
+	current, _ := repo.GetIssuedCert(ctx, "0c2d")
+	if current.RevokedAt != nil {
+		t.Errorf("released cert still revoked: %+v", current)
+	}
+	old, _ = repo.GetIssuedCert(ctx, "0a1b")
+	if old.RevocationReason != "superseded" {
+		t.Errorf("superseded cert reason = %q after release", old.RevocationReason)
+	}
+}
+
+func TestDeviceSessions(t *testing.T) {
+	repo, pool := setupTest(t)
+	ctx := context.Background()
+
+	d, _ := repo.Create(ctx, CreateDeviceInput{
+		OwnerID: "user-1", Class: "sensor", FirmwareVersion: "1.0.0", Tier: 1, Sensors: []string{"temp"},
+	})
+	now := time.Now().UTC()
+
+	id, err := repo.OpenSession(ctx, OpenSessionInput{DeviceID: d.ID, ConnectedAt: now, RemoteAddr: "203.0.113.7:51234", CertSerial: "0a1b"})
+	if err != nil {
+		t.Fatalf("OpenSession(): %v", err)
+	}
+	if err := repo.CloseSession(ctx, CloseSessionInput{ID: id, DisconnectedAt: now.Add(time.Minute), Reason: "keep alive timeout", WillPublished: true}); err != nil {
+		t.Fatalf("CloseSession(): %v", err)
+	}
+	// A second close keeps the first reason
+	if err := repo.CloseSession(ctx, CloseSessionInput{ID: id, DisconnectedAt: now.Add(time.Hour), Reason: "client_disconnect", Graceful: true}); err != nil {
+		t.Fatalf("CloseSession(again): %v", err)
+	}
+	var reason string
+	var willPublished bool
+	pool.QueryRow(ctx, `SELECT disconnect_reason, will_published FROM device_sessions WHERE id = $1`, id).Scan(&reason, &willPublished)
+	if reason != "keep alive timeout" || !willPublished {
+		t.Errorf("disconnect_reason = %q, will_published = %v; want keep alive timeout, true", reason, willPublished)
+	}
+
+	if _, err := repo.OpenSession(ctx, OpenSessionInput{DeviceID: d.ID, ConnectedAt: now.Add(2 * time.Minute), RemoteAddr: "203.0.113.7:51300"}); err != nil {
+		t.Fatalf("OpenSession(second): %v", err)
+	}
+	n, err := repo.CloseOpenSessions(ctx, "server_restart")
+	if err != nil || n != 1 {
+		t.Errorf("CloseOpenSessions() = %d, %v; want 1", n, err)
+	}
+}
+
+func TestDeviceHealth(t *testing.T) {
+	repo, _ := setupTest(t)
+	ctx := context.Background()
+
+	d, _ := repo.Create(ctx, CreateDeviceInput{
+		OwnerID: "user-1", Class: "sensor", FirmwareVersion: "1.0.0", Tier: 1, Sensors: []string{"temp"},
+	})
+	repo.UpdateStatus(ctx, d.ID, "active")
+
+	battery := 12.5
+	state, err := repo.RecordHealth(ctx, RecordHealthInput{
+		DeviceID: d.ID, ReportedAt: time.Now().UTC(), BatteryPercent: &battery, SensorFaults: []string{"bme280"},
+	})
+	if err != nil {
+		t.Fatalf("RecordHealth(): %v", err)
+	}
+	if state.OwnerID != "user-1" || len(state.ActiveAlerts) != 0 {
+		t.Errorf("state = %+v, want owner user-1 and no alerts", state)
+	}
+
+	if err := repo.SetHealthAlerts(ctx, SetHealthAlertsInput{DeviceID: d.ID, Raise: []string{"low_battery", "sensor_fault"}}); err != nil {
+		t.Fatalf("SetHealthAlerts(raise): %v", err)
+	}
+	// Raising an open alert again is a no-op
+	if err := repo.SetHealthAlerts(ctx, SetHealthAlertsInput{DeviceID: d.ID, Raise: []string{"low_battery"}, Clear: []string{"sensor_fault"}}); err != nil {
+		t.Fatalf("SetHealthAlerts(clear): %v", err)
+	}
+	state, err = repo.RecordHealth(ctx, RecordHealthInput{DeviceID: d.ID, ReportedAt: time.Now().UTC()})
+	if err != nil {
+		t.Fatalf("RecordHealth(second): %v", err)
+	}
+	if len(state.ActiveAlerts) != 1 || state.ActiveAlerts[0] != "low_battery" {
+		t.Errorf("ActiveAlerts = %v, want [low_battery]", state.ActiveAlerts)
+	}
+
+	silent, err := repo.ListSilentDevices(ctx, time.Now().Add(time.Hour))
+	if err != nil || len(silent) != 1 || silent[0].DeviceID != d.ID {
+		t.Fatalf("ListSilentDevices() = %+v, %v; want %s", silent, err, d.ID)
+	}
+	repo.SetHealthAlerts(ctx, SetHealthAlertsInput{DeviceID: d.ID, Raise: []string{"silent"}})
+	silent, _ = repo.ListSilentDevices(ctx, time.Now().Add(time.Hour))
+	if len(silent) != 0 {
+		t.Errorf("ListSilentDevices() after alert = %+v, want none", silent)
+	}
+}
+
+func TestDeviceConfig(t *testing.T) {
+	repo, pool := setupTest(t)
+	ctx := context.Background()
+
+	d, _ := repo.Create(ctx, CreateDeviceInput{
+		OwnerID: "user-1", Class: "sensor", FirmwareVersion: "1.0.0", Tier: 1, Sensors: []string{"temp"},
+	})
+	campaignID := ulid.Make().String()
+	if _, err := pool.Exec(ctx,
+		`INSERT INTO campaigns (id, org_id, created_by, status) VALUES ($1, 'org-1', 'user-1', 'published')`, campaignID); err != nil {
+		t.Fatalf("insert campaign: %v", err)
+	}
+	repo.EnrollInCampaign(ctx, d.ID, campaignID)
+
+	now := time.Now()
+	ids, err := repo.ListConfigCampaigns(ctx, d.ID, now)
+	if err != nil || len(ids) != 1 || ids[0] != campaignID {
+		t.Fatalf("ListConfigCampaigns() = %v, %v; want [%s]", ids, err, campaignID)
+	}
+
+	save := SaveDeviceConfigInput{DeviceID: d.ID, Digest: "a", CampaignIDs: ids, Campaigns: []byte(`[]`)}
+	cfg, err := repo.SaveDeviceConfig(ctx, save)
+	if err != nil || cfg.Version != 1 || !cfg.Changed {
+		t.Fatalf("SaveDeviceConfig() = %+v, %v; want version 1, changed", cfg, err)
+	}
+	// Same digest keeps the version
+	cfg, _ = repo.SaveDeviceConfig(ctx, save)
+	if cfg.Version != 1 || cfg.Changed {
+		t.Errorf("unchanged save = version %d, changed %v; want 1, false", cfg.Version, cfg.Changed)
+	}
+
+	if ok, _ := repo.AckDeviceConfig(ctx, d.ID, 2); ok {
+		t.Error("ack for a version never pushed should be ignored")
+	}
+	if ok, err := repo.AckDeviceConfig(ctx, d.ID, 1); err != nil || !ok {
+		t.Fatalf("AckDeviceConfig(1) = %v, %v; want true", ok, err)
+	}
+
+	save.Digest = "b"
+	cfg, _ = repo.SaveDeviceConfig(ctx, save)
+	if cfg.Version != 2 || cfg.AckedVersion == nil || *cfg.AckedVersion != 1 {
+		t.Errorf("changed save = %+v, want version 2 acked 1", cfg)
+	}
+
+	// Ending the campaign leaves the device configured for it until re-pushed
+	if ended, _ := repo.ListEndedConfigDevices(ctx, now); len(ended) != 0 {
+		t.Errorf("ListEndedConfigDevices() = %v before the campaign ended", ended)
+	}
+	pool.Exec(ctx, `UPDATE campaigns SET status = 'completed' WHERE id = $1`, campaignID)
+	ended, err := repo.ListEndedConfigDevices(ctx, now)
+	if err != nil || len(ended) != 1 || ended[0] != d.ID {
+		t.Errorf("ListEndedConfigDevices() = %v, %v; want [%s]", ended, err, d.ID)
+	}
+	if devices, _ := repo.ListCampaignDevices(ctx, campaignID); len(devices) != 1 {
+		t.Errorf("ListCampaignDevices() = %v, want the enrolled device", devices)
+	}
+}
+
+func TestDeviceCommands(t *testing.T) {
+	repo, pool := setupTest(t)
+	ctx := context.Background()
+
+	d, _ := repo.Create(ctx, CreateDeviceInput{
+		OwnerID: "owner-1", Class: "sensor", FirmwareVersion: "1.0.0", Tier: 1, Sensors: []string{"temp"},
+	})
+	campaignID := ulid.Make().String()
+	if _, err := pool.Exec(ctx,
+		`INSERT INTO campaigns (id, org_id, created_by, status) VALUES ($1, 'org-1', 'researcher-1', 'published')`, campaignID); err != nil {
+		t.Fatalf("insert campaign: %v", err)
+	}
+	now := time.Now()
+
+	// Before enrollment only the owner may command the device
+	for issuer, want := range map[string]bool{"owner-1": true, "researcher-1": false, "stranger": false} {
+		targets, err := repo.ListCommandTargets(ctx, CommandTargetsInput{IssuerID: issuer, DeviceID: d.ID, At: now})
+		if err != nil || targets.Permitted != want {
+			t.Errorf("ListCommandTargets(%s) = %+v, %v; want permitted %v", issuer, targets, err, want)
+		}
+	}
+	// Unknown IDs are refused like any other target, without saying they do not exist
+	for _, input := range []CommandTargetsInput{
+		{IssuerID: "owner-1", DeviceID: "no-such-device", At: now},
+		{IssuerID: "owner-1", CampaignID: "no-such-campaign", At: now},
+	} {
+		if targets, err := repo.ListCommandTargets(ctx, input); err != nil || targets.Permitted {
+			t.Errorf("ListCommandTargets(%+v) = %+v, %v; want not permitted", input, targets, err)
+		}
+	}
+	repo.EnrollInCampaign(ctx, d.ID, campaignID)
+	if targets, _ := repo.ListCommandTargets(ctx, CommandTargetsInput{IssuerID: "researcher-1", DeviceID: d.ID, At: now}); !targets.Permitted {
+		t.Error("campaign researcher should command an enrolled device")
+	}
+	targets, err := repo.ListCommandTargets(ctx, CommandTargetsInput{IssuerID: "researcher-1", CampaignID: campaignID, At: now})
+	if err != nil || !targets.Permitted || len(targets.DeviceIDs) != 1 || targets.DeviceIDs[0] != d.ID {
+		t.Fatalf("campaign ListCommandTargets() = %+v, %v; want [%s]", targets, err, d.ID)
+	}
+	if targets, _ := repo.ListCommandTargets(ctx, CommandTargetsInput{IssuerID: "owner-1", CampaignID: campaignID, At: now}); targets.Permitted {
+		t.Error("only the campaign's creator may command all of its devices")
+	}
+
+	commands, err := repo.CreateCommands(ctx, CreateCommandsInput{
+		DeviceIDs: []string{d.ID}, CampaignID: &campaignID, Command: "reboot",
+		IssuedBy: "researcher-1", ExpiresAt: now.Add(time.Hour),
+	})
+	if err != nil || len(commands) != 1 || commands[0].Status != "sent" {
+		t.Fatalf("CreateCommands() = %+v, %v; want one sent command", commands, err)
+	}
+	cmd := commands[0]
+	expired, _ := repo.CreateCommands(ctx, CreateCommandsInput{
+		DeviceIDs: []string{d.ID}, Command: "renew_certificate", IssuedBy: "owner-1", ExpiresAt: now.Add(-time.Minute),
+	})
+
+	pending, err := repo.ListPendingCommands(ctx, d.ID, now)
+	if err != nil || len(pending) != 1 || pending[0].ID != cmd.ID {
+		t.Fatalf("ListPendingCommands() = %+v, %v; want [%s]", pending, err, cmd.ID)
+	}
+
+	if ok, _ := repo.RecordCommandResult(ctx, RecordCommandResultInput{DeviceID: d.ID, CommandID: expired[0].ID, Status: "acknowledged"}); ok {
+		t.Error("an expired command should not be acknowledged")
+	}
+	if ok, _ := repo.RecordCommandResult(ctx, RecordCommandResultInput{DeviceID: "other", CommandID: cmd.ID, Status: "acknowledged"}); ok {
+		t.Error("another device's report should be refused")
+	}
+	if ok, err := repo.RecordCommandResult(ctx, RecordCommandResultInput{DeviceID: d.ID, CommandID: cmd.ID, Status: "acknowledged"}); err != nil || !ok {
+		t.Fatalf("acknowledge = %v, %v; want true", ok, err)
+	}
+	if ok, err := repo.RecordCommandResult(ctx, RecordCommandResultInput{
+		DeviceID: d.ID, CommandID: cmd.ID, Status: "succeeded", Result: []byte(`{"uptime_seconds":3}`),
+	}); err != nil || !ok {
+		t.Fatalf("succeed = %v, %v; want true", ok, err)
+	}
+	if ok, _ := repo.RecordCommandResult(ctx, RecordCommandResultInput{DeviceID: d.ID, CommandID: cmd.ID, Status: "acknowledged"}); ok {
+		t.Error("a finished command should not change")
+	}
+
+	history, err := repo.ListCommands(ctx, ListCommandsInput{DeviceID: d.ID})
+	if err != nil || len(history) != 2 {
+		t.Fatalf("ListCommands() = %+v, %v; want 2", history, err)
+	}
+	statuses := map[string]string{}
+	for _, c := range history {
+		statuses[c.ID] = c.Status
+	}
+	if statuses[cmd.ID] != "succeeded" || statuses[expired[0].ID] != "expired" {
+		t.Errorf("history statuses = %v, want succeeded and expired", statuses)
+	}
+	if byCampaign, _ := repo.ListCommands(ctx, ListCommandsInput{CampaignID: campaignID}); len(byCampaign) != 1 {
+		t.Errorf("campaign history = %d commands, want 1", len(byCampaign))
+	}
+}
+
+func TestDeviceShadow(t *testing.T) {
+	repo, _ := setupTest(t)
+	ctx := context.Background()
+
+	d, _ := repo.Create(ctx, CreateDeviceInput{
+		OwnerID: "owner-1", Class: "sensor", FirmwareVersion: "1.0.0", Tier: 1, Sensors: []string{"temp"},
+	})
+	if shadow, err := repo.GetShadow(ctx, d.ID); err != nil || shadow != nil {
+		t.Fatalf("GetShadow() = %+v, %v; want nil before any update", shadow, err)
+	}
+
+	interval := 300
+	shadow, err := repo.UpdateShadowDesired(ctx, UpdateShadowInput{DeviceID: d.ID, State: ShadowState{
+		SamplingIntervalSeconds: &interval, EnabledSensors: []string{"temp"},
+	}})
+	if err != nil || !shadow.Changed || shadow.Version != 1 || shadow.DesiredUpdatedAt == nil || shadow.ReportedUpdatedAt != nil {
+		t.Fatalf("UpdateShadowDesired() = %+v, %v; want new shadow at version 1", shadow, err)
+	}
+
+	// Setting the same value again changes nothing
+	shadow, _ = repo.UpdateShadowDesired(ctx, UpdateShadowInput{DeviceID: d.ID, State: ShadowState{SamplingIntervalSeconds: &interval}})
+	if shadow.Changed || shadow.Version != 1 {
+		t.Errorf("unchanged desired = changed %v version %d; want unchanged at 1", shadow.Changed, shadow.Version)
+	}
+
+	// Unset fields are kept; an empty list is stored as empty
+	shadow, _ = repo.UpdateShadowDesired(ctx, UpdateShadowInput{DeviceID: d.ID, State: ShadowState{ActiveCampaigns: []string{}}})
+	if !shadow.Changed || shadow.Version != 2 || shadow.Desired.ActiveCampaigns == nil ||
+		shadow.Desired.SamplingIntervalSeconds == nil || len(shadow.Desired.EnabledSensors) != 1 {
+		t.Errorf("merged desired = %+v; want interval and sensors kept, empty campaigns set", shadow)
+	}
+
+	firmware := "1.0.0"
+	shadow, err = repo.UpdateShadowReported(ctx, UpdateShadowInput{DeviceID: d.ID, State: ShadowState{
+		SamplingIntervalSeconds: &interval, FirmwareVersion: &firmware,
+	}})
+	if err != nil || !shadow.Changed || shadow.Version != 3 || shadow.ReportedUpdatedAt == nil {
+		t.Fatalf("UpdateShadowReported() = %+v, %v; want version 3", shadow, err)
+	}
+	if shadow.Reported.EnabledSensors != nil || *shadow.Reported.FirmwareVersion != firmware || *shadow.Desired.SamplingIntervalSeconds != interval {
+		t.Errorf("reported = %+v; want only interval and firmware, desired untouched", shadow.Reported)
+	}
+
+	if _, err := repo.UpdateShadowReported(ctx, UpdateShadowInput{DeviceID: "missing", State: ShadowState{FirmwareVersion: &firmware}}); err == nil {
+		t.Error("shadow for an unknown device should fail")
+	}
+}
+
+func TestFirmwareReleases(t *testing.T) {
+	repo, pool := setupTest(t)
+	ctx := context.Background()
+	pool.Exec(ctx, "TRUNCATE firmware_releases CASCADE")
+
+	active, _ := repo.Create(ctx, CreateDeviceInput{
+		OwnerID: "owner-1", Class: "ota-sensor", FirmwareVersion: "1.0.0", Tier: 1, Sensors: []string{"temp"},
+	})
+	suspended, _ := repo.Create(ctx, CreateDeviceInput{
+		OwnerID: "owner-2", Class: "ota-sensor", FirmwareVersion: "1.0.0", Tier: 1, Sensors: []string{"temp"},
+	})
+	current, _ := repo.Create(ctx, CreateDeviceInput{
+		OwnerID: "owner-3", Class: "ota-sensor", FirmwareVersion: "1.1.0", Tier: 1, Sensors: []string{"temp"},
+	})
+	for _, d := range []*Device{active, suspended, current} {
+		repo.UpdateStatus(ctx, d.ID, "active")
+	}
+	repo.UpdateStatus(ctx, suspended.ID, "suspended")
+
+	input := CreateFirmwareReleaseInput{
+		Class: "ota-sensor", Version: "1.1.0", SHA256: strings.Repeat("ab", 32),
+		URL: "https://firmware.example.org/ota-1.1.0.bin", SecurityFix: true, RolloutPercent: 10, CreatedBy: "admin-1",
+	}
+	release, err := repo.CreateFirmwareRelease(ctx, input)
+	if err != nil || release == nil || release.Cohort == nil || !release.SecurityFix {
+		t.Fatalf("CreateFirmwareRelease() = %+v, %v", release, err)
+	}
+	if dup, err := repo.CreateFirmwareRelease(ctx, input); err != nil || dup != nil {
+		t.Errorf("duplicate CreateFirmwareRelease() = %+v, %v; want nil", dup, err)
+	}
+
+	release, err = repo.SetFirmwareRollout(ctx, SetFirmwareRolloutInput{ReleaseID: release.ID, RolloutPercent: 50, Cohort: []string{active.ID}})
+	if err != nil || release.RolloutPercent != 50 || len(release.Cohort) != 1 {
+		t.Fatalf("SetFirmwareRollout() = %+v, %v", release, err)
+	}
+	if _, err := repo.SetFirmwareRollout(ctx, SetFirmwareRolloutInput{ReleaseID: "missing"}); err == nil {
+		t.Error("rollout of an unknown release should fail")
+	}
+
+	// Devices already on the release's version are not candidates
+	candidates, err := repo.ListFirmwareCandidates(ctx, release.ID)
+	if err != nil || len(candidates) != 2 {
+		t.Fatalf("ListFirmwareCandidates() = %+v, %v; want the two 1.0.0 devices", candidates, err)
+	}
+	for _, c := range candidates {
+		if c.InstallStatus != nil {
+			t.Errorf("candidate %s install status = %v before notification", c.DeviceID, *c.InstallStatus)
+		}
+	}
+
+	// Only devices that were sent the release may report on it
+	if ok, _ := repo.RecordFirmwareInstall(ctx, RecordFirmwareInstallInput{DeviceID: active.ID, ReleaseID: release.ID, Status: "downloading"}); ok {
+		t.Error("report before notification should be refused")
+	}
+	if err := repo.MarkFirmwareNotified(ctx, release.ID, []string{active.ID, suspended.ID}); err != nil {
+		t.Fatalf("MarkFirmwareNotified() error = %v", err)
+	}
+	if ok, err := repo.RecordFirmwareInstall(ctx, RecordFirmwareInstallInput{DeviceID: active.ID, ReleaseID: release.ID, Status: "installed"}); err != nil || !ok {
+		t.Fatalf("installed = %v, %v; want true", ok, err)
+	}
+	if d, _ := repo.Get(ctx, active.ID); d.FirmwareVersion != "1.1.0" {
+		t.Errorf("firmware after install = %s, want 1.1.0", d.FirmwareVersion)
+	}
+	if ok, _ := repo.RecordFirmwareInstall(ctx, RecordFirmwareInstallInput{DeviceID: active.ID, ReleaseID: release.ID, Status: "failed"}); ok {
+		t.Error("an installed release should not change")
+	}
+
+	// A failed install is notified again; other progress is kept
+	failure := "checksum mismatch"
+	repo.RecordFirmwareInstall(ctx, RecordFirmwareInstallInput{DeviceID: suspended.ID, ReleaseID: release.ID, Status: "failed", Error: &failure})
+	repo.MarkFirmwareNotified(ctx, release.ID, []string{suspended.ID})
+	installs, err := repo.ListFirmwareInstalls(ctx, release.ID)
+	if err != nil || len(installs) != 2 {
+		t.Fatalf("ListFirmwareInstalls() = %+v, %v; want 2", installs, err)
+	}
+	statuses := map[string]string{}
+	for _, in := range installs {
+		statuses[in.DeviceID] = in.Status
+	}
+	if statuses[active.ID] != "installed" || statuses[suspended.ID] != "notified" {
+		t.Errorf("install statuses = %v, want installed and notified", statuses)
+	}
+
+	if releases, _ := repo.ListFirmwareReleases(ctx, "ota-sensor"); len(releases) != 1 {
+		t.Errorf("ListFirmwareReleases() = %d releases, want 1", len(releases))
+	}
+	if got, _ := repo.GetFirmwareRelease(ctx, "missing"); got != nil {
+		t.Errorf("GetFirmwareRelease(missing) = %+v, want nil", got)
+	}
+}
@@ -9,6 +9,7 @@ type Repository interface {
 	Withdraw(ctx context.Context, enrollmentID string) error
 	GetByID(ctx context.Context, id string) (*Enrollment, error)
 	GetByDeviceCampaign(ctx context.Context, deviceID, campaignID string) (*Enrollment, error)
+	FlagLocation(ctx context.Context, input FlagLocationInput) error
 	MarkRead(ctx context.Context, userID string, ids []string) (int, error)
 	CreateNotification(ctx context.Context, input CreateNotificationInput) error
 	GetPreferences(ctx context.Context, userID string) ([]NotificationPreference, error)
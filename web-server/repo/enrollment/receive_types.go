@@ -2,11 +2,18 @@ package enrollment
 
 // EnrollInput is what the op sends to create an enrollment.
 type EnrollInput struct {
-	DeviceID     string
-	CampaignID   string
-	ScitizenID   string
-	ConsentVersion string
-	ConsentScope   string
+	DeviceID           string
+	CampaignID         string
+	ScitizenID         string
+	ConsentVersion     string
+	ConsentScope       string
+	DeploymentLocation string // GeoJSON Point; empty when not given
+}
+
+// FlagLocationInput flags an enrollment whose reported location needs review.
+type FlagLocationInput struct {
+	EnrollmentID string
+	Reason       string
 }
 
 // CreateNotificationInput creates a new notification.
@@ -40,6 +40,12 @@ type getByDeviceCampaignReq struct {
 	resp       chan response[*Enrollment]
 }
 
+type flagLocationReq struct {
+	ctx   context.Context
+	input FlagLocationInput
+	resp  chan response[struct{}]
+}
+
 type markReadReq struct {
 	ctx    context.Context
 	userID string
@@ -76,6 +82,7 @@ type pgRepo struct {
 	withdrawCh            chan withdrawReq
 	getByIDCh             chan getByIDReq
 	getByDeviceCampaignCh chan getByDeviceCampaignReq
+	flagLocationCh        chan flagLocationReq
 	markReadCh            chan markReadReq
 	createNotificationCh  chan createNotificationReq
 	getPreferencesCh      chan getPreferencesReq
@@ -92,6 +99,7 @@ func NewRepository(pool *pgxpool.Pool) Repository {
 		withdrawCh:            make(chan withdrawReq),
 		getByIDCh:             make(chan getByIDReq),
 		getByDeviceCampaignCh: make(chan getByDeviceCampaignReq),
+		flagLocationCh:        make(chan flagLocationReq),
 		markReadCh:            make(chan markReadReq),
 		createNotificationCh:  make(chan createNotificationReq),
 		getPreferencesCh:      make(chan getPreferencesReq),
@@ -117,6 +125,9 @@ func (r *pgRepo) manage() {
 		case req := <-r.getByDeviceCampaignCh:
 			val, err := r.doGetByDeviceCampaign(req.ctx, req.deviceID, req.campaignID)
 			req.resp <- response[*Enrollment]{val: val, err: err}
+		case req := <-r.flagLocationCh:
+			err := r.doFlagLocation(req.ctx, req.input)
+			req.resp <- response[struct{}]{err: err}
 		case req := <-r.markReadCh:
 			val, err := r.doMarkRead(req.ctx, req.userID, req.ids)
 			req.resp <- response[int]{val: val, err: err}
@@ -164,6 +175,13 @@ func (r *pgRepo) GetByDeviceCampaign(ctx context.Context, deviceID, campaignID s
 	return res.val, res.err
 }
 
+func (r *pgRepo) FlagLocation(ctx context.Context, input FlagLocationInput) error {
+	resp := make(chan response[struct{}], 1)
+	r.flagLocationCh <- flagLocationReq{ctx: ctx, input: input, resp: resp}
+	res := <-resp
+	return res.err
+}
+
 func (r *pgRepo) MarkRead(ctx context.Context, userID string, ids []string) (int, error) {
 	resp := make(chan response[int], 1)
 	r.markReadCh <- markReadReq{ctx: ctx, userID: userID, ids: ids, resp: resp}
@@ -209,12 +227,16 @@ func (r *pgRepo) doEnroll(ctx context.Context, input EnrollInput) (*Enrollment,
 
 	enrollmentID := ulid.Make().String()
 	var e Enrollment
+	var deployment *string
+	if input.DeploymentLocation != "" {
+		deployment = &input.DeploymentLocation
+	}
 	err = tx.QueryRow(ctx,
-		`INSERT INTO campaign_enrollments (id, device_id, campaign_id, scitizen_id)
-		 VALUES ($1, $2, $3, $4)
-		 RETURNING id, device_id, campaign_id, scitizen_id, status, enrolled_at`,
-		enrollmentID, input.DeviceID, input.CampaignID, input.ScitizenID,
-	).Scan(&e.ID, &e.DeviceID, &e.CampaignID, &e.ScitizenID, &e.Status, &e.EnrolledAt)
+		`INSERT INTO campaign_enrollments (id, device_id, campaign_id, scitizen_id, deployment_location)
+		 VALUES ($1, $2, $3, $4, $5::jsonb)
+		 RETURNING id, device_id, campaign_id, scitizen_id, status, enrolled_at, deployment_location::text`,
+		enrollmentID, input.DeviceID, input.CampaignID, input.ScitizenID, deployment,
+	).Scan(&e.ID, &e.DeviceID, &e.CampaignID, &e.ScitizenID, &e.Status, &e.EnrolledAt, &e.DeploymentLocation)
 	if err != nil {
 		return nil, fmt.Errorf("insert enrollment: %w", err)
 	}
@@ -256,10 +278,12 @@ func (r *pgRepo) doWithdraw(ctx context.Context, enrollmentID string) error {
 func (r *pgRepo) doGetByID(ctx context.Context, id string) (*Enrollment, error) {
 	var e Enrollment
 	err := r.pool.QueryRow(ctx,
-		`SELECT id, device_id, campaign_id, scitizen_id, status, enrolled_at, withdrawn_at
+		`SELECT id, device_id, campaign_id, scitizen_id, status, enrolled_at, withdrawn_at,
+		        deployment_location::text, location_flagged_at, location_flag_reason
 		 FROM campaign_enrollments WHERE id = $1`,
 		id,
-	).Scan(&e.ID, &e.DeviceID, &e.CampaignID, &e.ScitizenID, &e.Status, &e.EnrolledAt, &e.WithdrawnAt)
+	).Scan(&e.ID, &e.DeviceID, &e.CampaignID, &e.ScitizenID, &e.Status, &e.EnrolledAt, &e.WithdrawnAt,
+		&e.DeploymentLocation, &e.LocationFlaggedAt, &e.LocationFlagReason)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, fmt.Errorf("enrollment %s not found", id)
@@ -272,10 +296,12 @@ func (r *pgRepo) doGetByID(ctx context.Context, id string) (*Enrollment, error)
 func (r *pgRepo) doGetByDeviceCampaign(ctx context.Context, deviceID, campaignID string) (*Enrollment, error) {
 	var e Enrollment
 	err := r.pool.QueryRow(ctx,
-		`SELECT id, device_id, campaign_id, scitizen_id, status, enrolled_at, withdrawn_at
+		`SELECT id, device_id, campaign_id, scitizen_id, status, enrolled_at, withdrawn_at,
+		        deployment_location::text, location_flagged_at, location_flag_reason
 		 FROM campaign_enrollments WHERE device_id = $1 AND campaign_id = $2`,
 		deviceID, campaignID,
-	).Scan(&e.ID, &e.DeviceID, &e.CampaignID, &e.ScitizenID, &e.Status, &e.EnrolledAt, &e.WithdrawnAt)
+	).Scan(&e.ID, &e.DeviceID, &e.CampaignID, &e.ScitizenID, &e.Status, &e.EnrolledAt, &e.WithdrawnAt,
+		&e.DeploymentLocation, &e.LocationFlaggedAt, &e.LocationFlagReason)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, nil
@@ -285,6 +311,23 @@ func (r *pgRepo) doGetByDeviceCampaign(ctx context.Context, deviceID, campaignID
 	return &e, nil
 }
 
+// doFlagLocation keeps the time of the first flag and the most recent reason.
+func (r *pgRepo) doFlagLocation(ctx context.Context, input FlagLocationInput) error {
+	tag, err := r.pool.Exec(ctx,
+		`UPDATE campaign_enrollments
+		 SET location_flagged_at = COALESCE(location_flagged_at, now()), location_flag_reason = $2
+		 WHERE id = $1`,
+		input.EnrollmentID, input.Reason,
+	)
+	if err != nil {
+		return fmt.Errorf("flag enrollment location: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("enrollment %s not found", input.EnrollmentID)
+	}
+	return nil
+}
+
 func (r *pgRepo) doMarkRead(ctx context.Context, userID string, ids []string) (int, error) {
 	tag, err := r.pool.Exec(ctx,
 		`UPDATE notifications SET read = true
@@ -12,5 +12,9 @@ type Repository interface {
 	// PublishToDevice publishes a non-retained message to a device-specific topic.
 	PublishToDevice(ctx context.Context, input PublishInput) error
 
+	// DisconnectDevice ends the device's live broker session, if it has one.
+	// Reports whether a session was open.
+	DisconnectDevice(ctx context.Context, deviceID string) (bool, error)
+
 	Shutdown()
 }
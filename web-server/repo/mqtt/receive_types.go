@@ -11,4 +11,5 @@ type PublishInput struct {
 	Topic   string
 	Payload []byte
 	QoS     byte
+	Retain  bool
 }
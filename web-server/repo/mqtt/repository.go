@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	mochi "github.com/mochi-mqtt/server/v2"
+	"github.com/mochi-mqtt/server/v2/packets"
 )
 
 type response[T any] struct {
@@ -24,6 +25,12 @@ type publishReq struct {
 	resp  chan response[struct{}]
 }
 
+type disconnectReq struct {
+	ctx      context.Context
+	deviceID string
+	resp     chan response[bool]
+}
+
 type shutdownReq struct {
 	resp chan struct{}
 }
@@ -32,6 +39,7 @@ type mqttRepo struct {
 	server      *mochi.Server
 	pushCfgCh   chan pushConfigReq
 	publishCh   chan publishReq
+	disconnectCh chan disconnectReq
 	shutdownCh  chan shutdownReq
 }
 
@@ -42,6 +50,7 @@ func NewRepository(server *mochi.Server) Repository {
 		server:     server,
 		pushCfgCh:  make(chan pushConfigReq),
 		publishCh:  make(chan publishReq),
+		disconnectCh: make(chan disconnectReq),
 		shutdownCh: make(chan shutdownReq),
 	}
 	go r.manage()
@@ -57,6 +66,9 @@ func (r *mqttRepo) manage() {
 		case req := <-r.publishCh:
 			err := r.doPublish(req.input)
 			req.resp <- response[struct{}]{err: err}
+		case req := <-r.disconnectCh:
+			val := r.doDisconnect(req.deviceID)
+			req.resp <- response[bool]{val: val}
 		case req := <-r.shutdownCh:
 			close(req.resp)
 			return
@@ -70,7 +82,19 @@ func (r *mqttRepo) doPushConfig(input PushConfigInput) error {
 }
 
 func (r *mqttRepo) doPublish(input PublishInput) error {
-	return r.server.Publish(input.Topic, input.Payload, false, input.QoS)
+	return r.server.Publish(input.Topic, input.Payload, input.Retain, input.QoS)
+}
+
+// doDisconnect sends an administrative-action DISCONNECT and closes the connection. Device
+// client IDs are their device IDs (the auth hook enforces it). DisconnectClient reports the
+// reason code itself as an error for failure codes, so there is nothing further to check.
+func (r *mqttRepo) doDisconnect(deviceID string) bool {
+	cl, ok := r.server.Clients.Get(deviceID)
+	if !ok || cl.Net.Inline || cl.Closed() {
+		return false
+	}
+	_ = r.server.DisconnectClient(cl, packets.ErrAdministrativeAction)
+	return true
 }
 
 func (r *mqttRepo) PushDeviceConfig(_ context.Context, input PushConfigInput) error {
@@ -87,6 +111,13 @@ func (r *mqttRepo) PublishToDevice(_ context.Context, input PublishInput) error
 	return res.err
 }
 
+func (r *mqttRepo) DisconnectDevice(_ context.Context, deviceID string) (bool, error) {
+	resp := make(chan response[bool], 1)
+	r.disconnectCh <- disconnectReq{deviceID: deviceID, resp: resp}
+	res := <-resp
+	return res.val, res.err
+}
+
 func (r *mqttRepo) Shutdown() {
 	resp := make(chan struct{}, 1)
 	r.shutdownCh <- shutdownReq{resp: resp}
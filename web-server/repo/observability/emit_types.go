@@ -31,6 +31,8 @@ type Meter interface {
 // Counter is a monotonically increasing metric.
 type Counter interface {
 	Add(ctx context.Context, value float64)
+	// AddWithAttributes adds to the series the attributes label, e.g. one per failure reason.
+	AddWithAttributes(ctx context.Context, value float64, attrs map[string]string)
 }
 
 // Histogram records a distribution of values.
@@ -238,6 +238,14 @@ func (c *otelCounter) Add(ctx context.Context, value float64) {
 	c.counter.Add(ctx, value)
 }
 
+func (c *otelCounter) AddWithAttributes(ctx context.Context, value float64, attrs map[string]string) {
+	kvs := make([]attribute.KeyValue, 0, len(attrs))
+	for k, v := range attrs {
+		kvs = append(kvs, attribute.String(k, v))
+	}
+	c.counter.Add(ctx, value, metric.WithAttributes(kvs...))
+}
+
 type otelHistogram struct {
 	histogram metric.Float64Histogram
 }
@@ -10,6 +10,9 @@ type ReadingValue struct {
 	Value            float64
 	Status           string
 	QuarantineReason *string
+	Unit             *string  // unit Value was converted to; nil when stored as sent
+	OriginalValue    *float64 // value as the device sent it, before unit conversion
+	OriginalUnit     *string
 }
 
 // Reading is the core reading record returned by the repository.
@@ -26,6 +29,7 @@ type Reading struct {
 	IngestedAt       time.Time
 	Status           string
 	QuarantineReason *string
+	MessageID        *string // device-supplied message ID, nullable
 }
 
 // ParameterQuality holds per-parameter quality metrics.
@@ -45,11 +49,20 @@ type QualityMetrics struct {
 
 // DeviceBreakdown holds per-device stats for a campaign.
 type DeviceBreakdown struct {
-	PseudoDeviceID string
-	DeviceClass    string
-	AcceptanceRate float64
-	ReadingCount   int
-	LastSeen       *string
+	PseudoDeviceID  string
+	DeviceClass     string
+	AcceptanceRate  float64
+	ReadingCount    int
+	LastSeen        *string
+	Online          bool     // has an open broker session
+	LastConnectedAt *string  // start of the latest broker session
+	BatteryPercent  *float64 // from the latest heartbeat
+	RSSIDbm         *int
+	LastHeartbeatAt *string
+	HealthAlerts    []string // raised health alerts
+
+	ConfigVersion      *int64 // config last pushed; nil if none
+	ConfigAckedVersion *int64 // config the device last acknowledged
 }
 
 // TemporalBucket holds reading counts for a time bucket.
@@ -5,6 +5,8 @@ import "context"
 // Repository defines the interface for reading data operations.
 type Repository interface {
 	Persist(ctx context.Context, input PersistReadingInput) (*Reading, error)
+	PersistBatch(ctx context.Context, inputs []PersistReadingInput) ([]Reading, error)
+	FindDuplicateCandidates(ctx context.Context, input DuplicateCandidatesInput) ([]Reading, error)
 	Quarantine(ctx context.Context, id string, reason string) error
 	QuarantineValue(ctx context.Context, readingValueID string, reason string) error
 	Query(ctx context.Context, input QueryReadingsInput) ([]Reading, error)
@@ -4,19 +4,40 @@ import "time"
 
 // ReadingValueInput is a single parameter measurement to persist.
 type ReadingValueInput struct {
-	ParameterName string
-	Value         float64
+	ParameterName    string
+	Value            float64
+	Status           string // accepted | quarantined | excluded; "" means accepted
+	QuarantineReason string
+
+	// Set when Value was converted from the unit the device reported in.
+	Unit          string
+	OriginalValue *float64
+	OriginalUnit  string
 }
 
 // PersistReadingInput is what the PersistReading op sends to the repository.
 type PersistReadingInput struct {
-	DeviceID        string
-	CampaignID      string
-	Values          []ReadingValueInput
-	Timestamp       time.Time
-	Geolocation     string // GeoJSON point, may be empty
-	FirmwareVersion string
-	CertSerial      string
+	DeviceID         string
+	CampaignID       string
+	Values           []ReadingValueInput
+	Timestamp        time.Time
+	Geolocation      string // GeoJSON point, may be empty
+	FirmwareVersion  string
+	CertSerial       string
+	Status           string // accepted | quarantined; "" means accepted
+	QuarantineReason string
+	MessageID        string // device-supplied message ID, may be empty
+}
+
+// DuplicateCandidatesInput is what the FindDuplicateCandidates op sends to the repository.
+// Matches readings from the device with one of MessageIDs, or in the campaign with a
+// timestamp between Since and Until.
+type DuplicateCandidatesInput struct {
+	DeviceID   string
+	CampaignID string
+	MessageIDs []string
+	Since      time.Time
+	Until      time.Time
 }
 
 // QueryReadingsInput is what the QueryReadings op sends to the repository.
@@ -24,6 +24,18 @@ type persistReq struct {
 	resp  chan response[*Reading]
 }
 
+type persistBatchReq struct {
+	ctx    context.Context
+	inputs []PersistReadingInput
+	resp   chan response[[]Reading]
+}
+
+type findDuplicatesReq struct {
+	ctx   context.Context
+	input DuplicateCandidatesInput
+	resp  chan response[[]Reading]
+}
+
 type quarantineReq struct {
 	ctx    context.Context
 	id     string
@@ -88,6 +100,8 @@ type quarantineValueReq struct {
 type pgRepo struct {
 	pool                    *pgxpool.Pool
 	persistCh               chan persistReq
+	persistBatchCh          chan persistBatchReq
+	findDuplicatesCh        chan findDuplicatesReq
 	quarantineCh            chan quarantineReq
 	quarantineValueCh       chan quarantineValueReq
 	queryCh                 chan queryReq
@@ -105,6 +119,8 @@ func NewRepository(pool *pgxpool.Pool) Repository {
 	r := &pgRepo{
 		pool:                    pool,
 		persistCh:               make(chan persistReq),
+		persistBatchCh:          make(chan persistBatchReq),
+		findDuplicatesCh:        make(chan findDuplicatesReq),
 		quarantineCh:            make(chan quarantineReq),
 		quarantineValueCh:       make(chan quarantineValueReq),
 		queryCh:                 make(chan queryReq),
@@ -126,6 +142,12 @@ func (r *pgRepo) manage() {
 		case req := <-r.persistCh:
 			val, err := r.doPersist(req.ctx, req.input)
 			req.resp <- response[*Reading]{val: val, err: err}
+		case req := <-r.persistBatchCh:
+			val, err := r.doPersistBatch(req.ctx, req.inputs)
+			req.resp <- response[[]Reading]{val: val, err: err}
+		case req := <-r.findDuplicatesCh:
+			val, err := r.doFindDuplicateCandidates(req.ctx, req.input)
+			req.resp <- response[[]Reading]{val: val, err: err}
 		case req := <-r.quarantineCh:
 			err := r.doQuarantine(req.ctx, req.id, req.reason)
 			req.resp <- response[struct{}]{err: err}
@@ -167,6 +189,20 @@ func (r *pgRepo) Persist(ctx context.Context, input PersistReadingInput) (*Readi
 	return res.val, res.err
 }
 
+func (r *pgRepo) PersistBatch(ctx context.Context, inputs []PersistReadingInput) ([]Reading, error) {
+	resp := make(chan response[[]Reading], 1)
+	r.persistBatchCh <- persistBatchReq{ctx: ctx, inputs: inputs, resp: resp}
+	res := <-resp
+	return res.val, res.err
+}
+
+func (r *pgRepo) FindDuplicateCandidates(ctx context.Context, input DuplicateCandidatesInput) ([]Reading, error) {
+	resp := make(chan response[[]Reading], 1)
+	r.findDuplicatesCh <- findDuplicatesReq{ctx: ctx, input: input, resp: resp}
+	res := <-resp
+	return res.val, res.err
+}
+
 func (r *pgRepo) Quarantine(ctx context.Context, id string, reason string) error {
 	resp := make(chan response[struct{}], 1)
 	r.quarantineCh <- quarantineReq{ctx: ctx, id: id, reason: reason, resp: resp}
@@ -253,11 +289,12 @@ func (r *pgRepo) doPersist(ctx context.Context, input PersistReadingInput) (*Rea
 	var rd Reading
 	readingID := ulid.Make().String()
 	err = tx.QueryRow(ctx,
-		`INSERT INTO readings (id, device_id, campaign_id, value, timestamp, geolocation, firmware_version, cert_serial)
-		 VALUES ($1, $2, $3, NULL, $4, $5::jsonb, $6, $7)
-		 RETURNING id, device_id, campaign_id, value, timestamp, geolocation::text, firmware_version, cert_serial, ingested_at, status, quarantine_reason`,
+		`INSERT INTO readings (id, device_id, campaign_id, value, timestamp, geolocation, firmware_version, cert_serial, status, quarantine_reason, message_id)
+		 VALUES ($1, $2, $3, NULL, $4, $5::jsonb, $6, $7, $8, $9, $10)
+		 RETURNING id, device_id, campaign_id, value, timestamp, geolocation::text, firmware_version, cert_serial, ingested_at, status, quarantine_reason, message_id`,
 		readingID, input.DeviceID, input.CampaignID, input.Timestamp, geo, input.FirmwareVersion, input.CertSerial,
-	).Scan(&rd.ID, &rd.DeviceID, &rd.CampaignID, &rd.Value, &rd.Timestamp, &rd.Geolocation, &rd.FirmwareVersion, &rd.CertSerial, &rd.IngestedAt, &rd.Status, &rd.QuarantineReason)
+		statusOrAccepted(input.Status), nullIfEmpty(input.QuarantineReason), nullIfEmpty(input.MessageID),
+	).Scan(&rd.ID, &rd.DeviceID, &rd.CampaignID, &rd.Value, &rd.Timestamp, &rd.Geolocation, &rd.FirmwareVersion, &rd.CertSerial, &rd.IngestedAt, &rd.Status, &rd.QuarantineReason, &rd.MessageID)
 	if err != nil {
 		return nil, fmt.Errorf("insert reading: %w", err)
 	}
@@ -265,11 +302,12 @@ func (r *pgRepo) doPersist(ctx context.Context, input PersistReadingInput) (*Rea
 	for _, v := range input.Values {
 		var rv ReadingValue
 		err = tx.QueryRow(ctx,
-			`INSERT INTO reading_values (id, reading_id, parameter_name, value)
-			 VALUES ($1, $2, $3, $4)
-			 RETURNING id, reading_id, parameter_name, value, status, quarantine_reason`,
-			ulid.Make().String(), readingID, v.ParameterName, v.Value,
-		).Scan(&rv.ID, &rv.ReadingID, &rv.ParameterName, &rv.Value, &rv.Status, &rv.QuarantineReason)
+			`INSERT INTO reading_values (id, reading_id, parameter_name, value, status, quarantine_reason, unit, original_value, original_unit)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			 RETURNING id, reading_id, parameter_name, value, status, quarantine_reason, unit, original_value, original_unit`,
+			ulid.Make().String(), readingID, v.ParameterName, v.Value, statusOrAccepted(v.Status), nullIfEmpty(v.QuarantineReason),
+			nullIfEmpty(v.Unit), v.OriginalValue, nullIfEmpty(v.OriginalUnit),
+		).Scan(&rv.ID, &rv.ReadingID, &rv.ParameterName, &rv.Value, &rv.Status, &rv.QuarantineReason, &rv.Unit, &rv.OriginalValue, &rv.OriginalUnit)
 		if err != nil {
 			return nil, fmt.Errorf("insert reading value %s: %w", v.ParameterName, err)
 		}
@@ -283,6 +321,109 @@ func (r *pgRepo) doPersist(ctx context.Context, input PersistReadingInput) (*Rea
 	return &rd, nil
 }
 
+// doPersistBatch writes many readings in one transaction using COPY for both
+// readings and reading_values. IDs and ingested_at are assigned here so the
+// rows can be returned without reading them back; ingested_at is the database's
+// now(), as the column default gives single readings.
+func (r *pgRepo) doPersistBatch(ctx context.Context, inputs []PersistReadingInput) ([]Reading, error) {
+	if len(inputs) == 0 {
+		return []Reading{}, nil
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// now() is the transaction's start time, the same for every row COPY writes
+	var ingestedAt time.Time
+	if err := tx.QueryRow(ctx, `SELECT now()`).Scan(&ingestedAt); err != nil {
+		return nil, fmt.Errorf("read ingest time: %w", err)
+	}
+
+	out := make([]Reading, len(inputs))
+	readingRows := make([][]any, len(inputs))
+	var valueRows [][]any
+
+	for i, in := range inputs {
+		var geo *string
+		if in.Geolocation != "" {
+			g := in.Geolocation
+			geo = &g
+		}
+		rd := Reading{
+			ID:               ulid.Make().String(),
+			DeviceID:         in.DeviceID,
+			CampaignID:       in.CampaignID,
+			Timestamp:        in.Timestamp,
+			Geolocation:      geo,
+			FirmwareVersion:  in.FirmwareVersion,
+			CertSerial:       in.CertSerial,
+			IngestedAt:       ingestedAt,
+			Status:           statusOrAccepted(in.Status),
+			QuarantineReason: nullIfEmpty(in.QuarantineReason),
+			MessageID:        nullIfEmpty(in.MessageID),
+		}
+		readingRows[i] = []any{rd.ID, rd.DeviceID, rd.CampaignID, rd.Timestamp, rd.Geolocation, rd.FirmwareVersion, rd.CertSerial, rd.IngestedAt, rd.Status, rd.QuarantineReason, rd.MessageID}
+
+		for _, v := range in.Values {
+			rv := ReadingValue{
+				ID:               ulid.Make().String(),
+				ReadingID:        rd.ID,
+				ParameterName:    v.ParameterName,
+				Value:            v.Value,
+				Status:           statusOrAccepted(v.Status),
+				QuarantineReason: nullIfEmpty(v.QuarantineReason),
+				Unit:             nullIfEmpty(v.Unit),
+				OriginalValue:    v.OriginalValue,
+				OriginalUnit:     nullIfEmpty(v.OriginalUnit),
+			}
+			valueRows = append(valueRows, []any{rv.ID, rv.ReadingID, rv.ParameterName, rv.Value, rv.Status, rv.QuarantineReason, rv.Unit, rv.OriginalValue, rv.OriginalUnit})
+			rd.Values = append(rd.Values, rv)
+		}
+		out[i] = rd
+	}
+
+	if _, err := tx.CopyFrom(ctx,
+		pgx.Identifier{"readings"},
+		[]string{"id", "device_id", "campaign_id", "timestamp", "geolocation", "firmware_version", "cert_serial", "ingested_at", "status", "quarantine_reason", "message_id"},
+		pgx.CopyFromRows(readingRows),
+	); err != nil {
+		return nil, fmt.Errorf("copy readings: %w", err)
+	}
+
+	if len(valueRows) > 0 {
+		if _, err := tx.CopyFrom(ctx,
+			pgx.Identifier{"reading_values"},
+			[]string{"id", "reading_id", "parameter_name", "value", "status", "quarantine_reason", "unit", "original_value", "original_unit"},
+			pgx.CopyFromRows(valueRows),
+		); err != nil {
+			return nil, fmt.Errorf("copy reading values: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+
+	return out, nil
+}
+
+func statusOrAccepted(status string) string {
+	if status == "" {
+		return "accepted"
+	}
+	return status
+}
+
+func nullIfEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
 func (r *pgRepo) doQuarantine(ctx context.Context, id string, reason string) error {
 	tag, err := r.pool.Exec(ctx,
 		`UPDATE readings SET status = 'quarantined', quarantine_reason = $1 WHERE id = $2`,
@@ -298,7 +439,7 @@ func (r *pgRepo) doQuarantine(ctx context.Context, id string, reason string) err
 }
 
 func (r *pgRepo) doQuery(ctx context.Context, input QueryReadingsInput) ([]Reading, error) {
-	query := `SELECT id, device_id, campaign_id, value, timestamp, geolocation::text, firmware_version, cert_serial, ingested_at, status, quarantine_reason
+	query := `SELECT id, device_id, campaign_id, value, timestamp, geolocation::text, firmware_version, cert_serial, ingested_at, status, quarantine_reason, message_id
 	          FROM readings WHERE 1=1`
 	args := []any{}
 	argIdx := 1
@@ -346,16 +487,40 @@ func (r *pgRepo) doQuery(ctx context.Context, input QueryReadingsInput) ([]Readi
 	if err != nil {
 		return nil, fmt.Errorf("query readings: %w", err)
 	}
-	defer rows.Close()
+	return r.scanReadingsWithValues(ctx, rows)
+}
 
+func (r *pgRepo) doFindDuplicateCandidates(ctx context.Context, input DuplicateCandidatesInput) ([]Reading, error) {
+	messageIDs := input.MessageIDs
+	if messageIDs == nil {
+		messageIDs = []string{}
+	}
+	rows, err := r.pool.Query(ctx,
+		`SELECT id, device_id, campaign_id, value, timestamp, geolocation::text, firmware_version, cert_serial, ingested_at, status, quarantine_reason, message_id
+		 FROM readings
+		 WHERE device_id = $1
+		   AND (message_id = ANY($2) OR (campaign_id = $3 AND timestamp BETWEEN $4 AND $5))
+		 ORDER BY timestamp`,
+		input.DeviceID, messageIDs, input.CampaignID, input.Since, input.Until,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query duplicate candidates: %w", err)
+	}
+	return r.scanReadingsWithValues(ctx, rows)
+}
+
+// scanReadingsWithValues scans reading rows, closes them, and loads each reading's values.
+func (r *pgRepo) scanReadingsWithValues(ctx context.Context, rows pgx.Rows) ([]Reading, error) {
 	var readings []Reading
 	for rows.Next() {
 		var rd Reading
-		if err := rows.Scan(&rd.ID, &rd.DeviceID, &rd.CampaignID, &rd.Value, &rd.Timestamp, &rd.Geolocation, &rd.FirmwareVersion, &rd.CertSerial, &rd.IngestedAt, &rd.Status, &rd.QuarantineReason); err != nil {
+		if err := rows.Scan(&rd.ID, &rd.DeviceID, &rd.CampaignID, &rd.Value, &rd.Timestamp, &rd.Geolocation, &rd.FirmwareVersion, &rd.CertSerial, &rd.IngestedAt, &rd.Status, &rd.QuarantineReason, &rd.MessageID); err != nil {
+			rows.Close()
 			return nil, fmt.Errorf("scan reading: %w", err)
 		}
 		readings = append(readings, rd)
 	}
+	rows.Close()
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
@@ -363,7 +528,7 @@ func (r *pgRepo) doQuery(ctx context.Context, input QueryReadingsInput) ([]Readi
 	// Load reading_values for each reading
 	for i := range readings {
 		valRows, err := r.pool.Query(ctx,
-			`SELECT id, reading_id, parameter_name, value, status, quarantine_reason
+			`SELECT id, reading_id, parameter_name, value, status, quarantine_reason, unit, original_value, original_unit
 			 FROM reading_values WHERE reading_id = $1`,
 			readings[i].ID,
 		)
@@ -372,7 +537,7 @@ func (r *pgRepo) doQuery(ctx context.Context, input QueryReadingsInput) ([]Readi
 		}
 		for valRows.Next() {
 			var rv ReadingValue
-			if err := valRows.Scan(&rv.ID, &rv.ReadingID, &rv.ParameterName, &rv.Value, &rv.Status, &rv.QuarantineReason); err != nil {
+			if err := valRows.Scan(&rv.ID, &rv.ReadingID, &rv.ParameterName, &rv.Value, &rv.Status, &rv.QuarantineReason, &rv.Unit, &rv.OriginalValue, &rv.OriginalUnit); err != nil {
 				valRows.Close()
 				return nil, fmt.Errorf("scan reading value: %w", err)
 			}
@@ -481,19 +646,34 @@ func pseudonymizeDeviceID(deviceID, secret string) string {
 }
 
 func (r *pgRepo) doGetDeviceBreakdown(ctx context.Context, campaignID string, hmacSecret string) ([]DeviceBreakdown, error) {
+	// Session and health columns are joined after aggregation so they are looked up once per device
 	rows, err := r.pool.Query(ctx,
 		`SELECT
-			r.device_id,
-			d.class,
-			COALESCE(COUNT(rv.*) FILTER (WHERE rv.status = 'accepted')::float / NULLIF(COUNT(rv.*), 0), 0) AS acceptance_rate,
-			COUNT(DISTINCT r.id) AS reading_count,
-			MAX(r.timestamp) AS last_seen
-		 FROM readings r
-		 JOIN devices d ON d.id = r.device_id
-		 LEFT JOIN reading_values rv ON rv.reading_id = r.id
-		 WHERE r.campaign_id = $1
-		 GROUP BY r.device_id, d.class
-		 ORDER BY reading_count DESC`,
+			b.device_id, b.class, b.acceptance_rate, b.reading_count, b.last_seen,
+			EXISTS(SELECT 1 FROM device_sessions s WHERE s.device_id = b.device_id AND s.disconnected_at IS NULL) AS online,
+			(SELECT MAX(s.connected_at) FROM device_sessions s WHERE s.device_id = b.device_id) AS last_connected_at,
+			h.battery_percent, h.rssi_dbm, h.received_at AS last_heartbeat_at,
+			ARRAY(SELECT a.alert FROM device_health_alerts a WHERE a.device_id = b.device_id ORDER BY a.alert) AS health_alerts,
+			cfg.version, cfg.acked_version
+		 FROM (
+			SELECT
+				r.device_id,
+				d.class,
+				COALESCE(COUNT(rv.*) FILTER (WHERE rv.status = 'accepted')::float / NULLIF(COUNT(rv.*), 0), 0) AS acceptance_rate,
+				COUNT(DISTINCT r.id) AS reading_count,
+				MAX(r.timestamp) AS last_seen
+			 FROM readings r
+			 JOIN devices d ON d.id = r.device_id
+			 LEFT JOIN reading_values rv ON rv.reading_id = r.id
+			 WHERE r.campaign_id = $1
+			 GROUP BY r.device_id, d.class
+		 ) b
+		 LEFT JOIN LATERAL (
+			SELECT battery_percent, rssi_dbm, received_at
+			FROM device_health WHERE device_id = b.device_id ORDER BY received_at DESC LIMIT 1
+		 ) h ON true
+		 LEFT JOIN device_configs cfg ON cfg.device_id = b.device_id
+		 ORDER BY b.reading_count DESC`,
 		campaignID,
 	)
 	if err != nil {
@@ -507,17 +687,30 @@ func (r *pgRepo) doGetDeviceBreakdown(ctx context.Context, campaignID string, hm
 		var acceptanceRate float64
 		var readingCount int
 		var lastSeen time.Time
-		if err := rows.Scan(&deviceID, &class, &acceptanceRate, &readingCount, &lastSeen); err != nil {
+		var online bool
+		var lastConnected, lastHeartbeat *time.Time
+		var item DeviceBreakdown
+		if err := rows.Scan(&deviceID, &class, &acceptanceRate, &readingCount, &lastSeen, &online, &lastConnected,
+			&item.BatteryPercent, &item.RSSIDbm, &lastHeartbeat, &item.HealthAlerts,
+			&item.ConfigVersion, &item.ConfigAckedVersion); err != nil {
 			return nil, fmt.Errorf("scan device breakdown: %w", err)
 		}
 		ls := lastSeen.Format(time.RFC3339)
-		result = append(result, DeviceBreakdown{
-			PseudoDeviceID: pseudonymizeDeviceID(deviceID, hmacSecret),
-			DeviceClass:    class,
-			AcceptanceRate: acceptanceRate,
-			ReadingCount:   readingCount,
-			LastSeen:       &ls,
-		})
+		item.PseudoDeviceID = pseudonymizeDeviceID(deviceID, hmacSecret)
+		item.DeviceClass = class
+		item.AcceptanceRate = acceptanceRate
+		item.ReadingCount = readingCount
+		item.LastSeen = &ls
+		item.Online = online
+		if lastConnected != nil {
+			lc := lastConnected.Format(time.RFC3339)
+			item.LastConnectedAt = &lc
+		}
+		if lastHeartbeat != nil {
+			lh := lastHeartbeat.Format(time.RFC3339)
+			item.LastHeartbeatAt = &lh
+		}
+		result = append(result, item)
 	}
 	return result, rows.Err()
 }
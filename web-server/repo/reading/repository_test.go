@@ -116,6 +116,118 @@ func TestPersistAndQuery(t *testing.T) {
 	}
 }
 
+func TestPersistBatch(t *testing.T) {
+	repo, pool := setupTest(t)
+	ctx := context.Background()
+	deviceID, campaignID := createFixtures(t, pool)
+
+	ts := time.Now().UTC().Truncate(time.Microsecond)
+	created, err := repo.PersistBatch(ctx, []PersistReadingInput{
+		{
+			DeviceID: deviceID, CampaignID: campaignID, Timestamp: ts.Add(-2 * time.Minute),
+			Values:      []ReadingValueInput{{ParameterName: "temp", Value: 21.0}},
+			Geolocation: `{"type":"Point","coordinates":[-73.98,40.74]}`, FirmwareVersion: "1.0.0", CertSerial: "s1",
+		},
+		{
+			DeviceID: deviceID, CampaignID: campaignID, Timestamp: ts.Add(-1 * time.Minute),
+			Values: []ReadingValueInput{
+				{ParameterName: "temp", Value: 999.0, Status: "quarantined", QuarantineReason: "out of range"},
+				{ParameterName: "humidity", Value: 40.0},
+			},
+			FirmwareVersion: "1.0.0", CertSerial: "s1",
+		},
+		{
+			DeviceID: deviceID, CampaignID: campaignID, Timestamp: ts,
+			Values:          []ReadingValueInput{{ParameterName: "temp", Value: 22.0}},
+			FirmwareVersion: "1.0.0", CertSerial: "s1", Status: "quarantined", QuarantineReason: "device suspended",
+		},
+	})
+	if err != nil {
+		t.Fatalf("PersistBatch(): %v", err)
+	}
+	if len(created) != 3 {
+		t.Fatalf("PersistBatch() = %d readings, want 3", len(created))
+	}
+	if created[1].Values[0].Status != "quarantined" {
+		t.Errorf("value status = %q, want quarantined", created[1].Values[0].Status)
+	}
+
+	readings, err := repo.Query(ctx, QueryReadingsInput{CampaignID: campaignID})
+	if err != nil {
+		t.Fatalf("Query(): %v", err)
+	}
+	if len(readings) != 3 {
+		t.Fatalf("Query() = %d, want 3", len(readings))
+	}
+
+	quarantined, _ := repo.Query(ctx, QueryReadingsInput{CampaignID: campaignID, Status: "quarantined"})
+	if len(quarantined) != 1 || quarantined[0].ID != created[2].ID {
+		t.Fatalf("quarantined readings = %d, want only %s", len(quarantined), created[2].ID)
+	}
+	if quarantined[0].QuarantineReason == nil || *quarantined[0].QuarantineReason != "device suspended" {
+		t.Errorf("quarantine_reason = %v, want 'device suspended'", quarantined[0].QuarantineReason)
+	}
+
+	var valueCount int
+	pool.QueryRow(ctx, `SELECT count(*) FROM reading_values WHERE reading_id = $1`, created[1].ID).Scan(&valueCount)
+	if valueCount != 2 {
+		t.Errorf("reading values = %d, want 2", valueCount)
+	}
+
+	// ingested_at comes from the database clock, as for single readings
+	var ingestedAt time.Time
+	pool.QueryRow(ctx, `SELECT ingested_at FROM readings WHERE id = $1`, created[0].ID).Scan(&ingestedAt)
+	if !created[0].IngestedAt.Equal(ingestedAt) {
+		t.Errorf("returned ingested_at = %v, stored %v", created[0].IngestedAt, ingestedAt)
+	}
+}
+
+func TestFindDuplicateCandidates(t *testing.T) {
+	repo, pool := setupTest(t)
+	ctx := context.Background()
+	deviceID, campaignID := createFixtures(t, pool)
+
+	ts := time.Now().UTC().Truncate(time.Microsecond)
+	withID, err := repo.Persist(ctx, PersistReadingInput{
+		DeviceID: deviceID, CampaignID: campaignID, Values: []ReadingValueInput{{ParameterName: "temp", Value: 20}},
+		Timestamp: ts.Add(-24 * time.Hour), FirmwareVersion: "1.0.0", CertSerial: "s1", MessageID: "msg-1",
+	})
+	if err != nil {
+		t.Fatalf("Persist(): %v", err)
+	}
+	if withID.MessageID == nil || *withID.MessageID != "msg-1" {
+		t.Errorf("message_id = %v, want msg-1", withID.MessageID)
+	}
+	inWindow, _ := repo.Persist(ctx, PersistReadingInput{
+		DeviceID: deviceID, CampaignID: campaignID, Values: []ReadingValueInput{{ParameterName: "temp", Value: 21}},
+		Timestamp: ts, FirmwareVersion: "1.0.0", CertSerial: "s1",
+	})
+	repo.Persist(ctx, PersistReadingInput{
+		DeviceID: deviceID, CampaignID: campaignID, Values: []ReadingValueInput{{ParameterName: "temp", Value: 22}},
+		Timestamp: ts.Add(-1 * time.Hour), FirmwareVersion: "1.0.0", CertSerial: "s1",
+	})
+
+	found, err := repo.FindDuplicateCandidates(ctx, DuplicateCandidatesInput{
+		DeviceID:   deviceID,
+		CampaignID: campaignID,
+		MessageIDs: []string{"msg-1"},
+		Since:      ts.Add(-time.Second),
+		Until:      ts.Add(time.Second),
+	})
+	if err != nil {
+		t.Fatalf("FindDuplicateCandidates(): %v", err)
+	}
+	if len(found) != 2 {
+		t.Fatalf("candidates = %d, want 2", len(found))
+	}
+	if found[0].ID != withID.ID || found[1].ID != inWindow.ID {
+		t.Errorf("candidates = [%s %s], want [%s %s]", found[0].ID, found[1].ID, withID.ID, inWindow.ID)
+	}
+	if len(found[1].Values) != 1 {
+		t.Errorf("candidate values = %d, want 1", len(found[1].Values))
+	}
+}
+
 func TestQuarantine(t *testing.T) {
 	repo, pool := setupTest(t)
 	ctx := context.Background()
@@ -637,10 +637,11 @@ func (r *pgRepo) doSearchCampaigns(ctx context.Context, input SearchInput) (brow
 func (r *pgRepo) doGetDevices(ctx context.Context, ownerID string) ([]DeviceSummary, error) {
 	rows, err := r.pool.Query(ctx,
 		`SELECT d.id, d.status, d.class, d.firmware_version, d.tier, d.sensors,
-		        COALESCE(e.cnt, 0) as active_enrollments, d.created_at
+		        COALESCE(e.cnt, 0) as active_enrollments, d.created_at, `+deviceHealthColumns+`
 		 FROM devices d
 		 LEFT JOIN (SELECT device_id, COUNT(*) as cnt FROM campaign_enrollments WHERE status = 'active' GROUP BY device_id) e
 		   ON d.id = e.device_id
+		 `+deviceHealthJoin+`
 		 WHERE d.owner_id = $1
 		 ORDER BY d.created_at DESC`,
 		ownerID,
@@ -653,28 +654,75 @@ func (r *pgRepo) doGetDevices(ctx context.Context, ownerID string) ([]DeviceSumm
 	var devices []DeviceSummary
 	for rows.Next() {
 		var ds DeviceSummary
-		if err := rows.Scan(&ds.ID, &ds.Status, &ds.Class, &ds.FirmwareVersion, &ds.Tier, &ds.Sensors,
-			&ds.ActiveEnrollments, &ds.LastSeen); err != nil {
+		var h healthRow
+		if err := rows.Scan(append([]any{&ds.ID, &ds.Status, &ds.Class, &ds.FirmwareVersion, &ds.Tier, &ds.Sensors,
+			&ds.ActiveEnrollments, &ds.LastSeen}, h.dest()...)...); err != nil {
 			return nil, fmt.Errorf("scan device: %w", err)
 		}
+		ds.Health = h.health()
 		devices = append(devices, ds)
 	}
 	return devices, rows.Err()
 }
 
+// deviceHealthJoin attaches each device's latest heartbeat as h; deviceHealthColumns selects
+// it with the device's raised health alerts, in healthRow.dest order.
+const (
+	deviceHealthJoin = `LEFT JOIN LATERAL (
+		   SELECT reported_at, received_at, battery_percent, rssi_dbm, uptime_seconds, free_memory_bytes, sensor_faults, firmware_build
+		   FROM device_health WHERE device_id = d.id ORDER BY received_at DESC LIMIT 1
+		 ) h ON true`
+	deviceHealthColumns = `h.reported_at, h.received_at, h.battery_percent, h.rssi_dbm, h.uptime_seconds,
+		        h.free_memory_bytes, h.sensor_faults, h.firmware_build,
+		        ARRAY(SELECT alert FROM device_health_alerts a WHERE a.device_id = d.id ORDER BY alert)`
+)
+
+// healthRow receives deviceHealthColumns; every column is NULL for a device that never reported.
+type healthRow struct {
+	reportedAt, receivedAt *time.Time
+	h                      DeviceHealth
+}
+
+func (r *healthRow) dest() []any {
+	return []any{&r.reportedAt, &r.receivedAt, &r.h.BatteryPercent, &r.h.RSSIDbm, &r.h.UptimeSeconds,
+		&r.h.FreeMemoryBytes, &r.h.SensorFaults, &r.h.FirmwareBuild, &r.h.Alerts}
+}
+
+func (r *healthRow) health() *DeviceHealth {
+	if r.receivedAt == nil {
+		if len(r.h.Alerts) == 0 {
+			return nil
+		}
+		return &DeviceHealth{Alerts: r.h.Alerts}
+	}
+	h := r.h
+	h.ReportedAt, h.ReceivedAt = *r.reportedAt, *r.receivedAt
+	return &h
+}
+
+// deviceSessionHistoryLimit caps the sessions returned with a device's detail.
+const deviceSessionHistoryLimit = 50
+
 func (r *pgRepo) doGetDeviceDetail(ctx context.Context, deviceID string) (*DeviceDetail, error) {
 	d := &DeviceDetail{}
+	var h healthRow
 	err := r.pool.QueryRow(ctx,
-		`SELECT id, owner_id, status, class, firmware_version, tier, sensors, cert_serial, created_at
-		 FROM devices WHERE id = $1`,
+		`SELECT d.id, d.owner_id, d.status, d.class, d.firmware_version, d.tier, d.sensors, d.cert_serial, d.created_at,
+		        d.clock_skew_ms, d.clock_skew_flagged_at, cfg.version, cfg.acked_version, `+deviceHealthColumns+`
+		 FROM devices d
+		 LEFT JOIN device_configs cfg ON cfg.device_id = d.id
+		 `+deviceHealthJoin+`
+		 WHERE d.id = $1`,
 		deviceID,
-	).Scan(&d.ID, &d.OwnerID, &d.Status, &d.Class, &d.FirmwareVersion, &d.Tier, &d.Sensors, &d.CertSerial, &d.CreatedAt)
+	).Scan(append([]any{&d.ID, &d.OwnerID, &d.Status, &d.Class, &d.FirmwareVersion, &d.Tier, &d.Sensors, &d.CertSerial, &d.CreatedAt,
+		&d.ClockSkewMs, &d.ClockSkewFlaggedAt, &d.ConfigVersion, &d.ConfigAckedVersion}, h.dest()...)...)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, fmt.Errorf("device %s not found", deviceID)
 		}
 		return nil, fmt.Errorf("get device: %w", err)
 	}
+	d.Health = h.health()
 
 	// Enrollments
 	enrollRows, err := r.pool.Query(ctx,
@@ -694,6 +742,41 @@ func (r *pgRepo) doGetDeviceDetail(ctx context.Context, deviceID string) (*Devic
 		d.Enrollments = append(d.Enrollments, e)
 	}
 
+	// Broker sessions, with the connection history derived from them
+	if err := r.pool.QueryRow(ctx,
+		`SELECT EXISTS(SELECT 1 FROM device_sessions WHERE device_id = $1 AND disconnected_at IS NULL)`,
+		deviceID,
+	).Scan(&d.Online); err != nil {
+		return nil, fmt.Errorf("get device presence: %w", err)
+	}
+
+	sessionRows, err := r.pool.Query(ctx,
+		`SELECT connected_at, disconnected_at,
+		        EXTRACT(EPOCH FROM COALESCE(disconnected_at, now()) - connected_at)::bigint,
+		        remote_addr, cert_serial, disconnect_reason, graceful, will_published
+		 FROM device_sessions WHERE device_id = $1
+		 ORDER BY connected_at DESC LIMIT $2`,
+		deviceID, deviceSessionHistoryLimit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get device sessions: %w", err)
+	}
+	defer sessionRows.Close()
+	for sessionRows.Next() {
+		var s DeviceSession
+		if err := sessionRows.Scan(&s.ConnectedAt, &s.DisconnectedAt, &s.DurationSeconds, &s.RemoteAddr, &s.CertSerial, &s.DisconnectReason, &s.Graceful, &s.WillPublished); err != nil {
+			return nil, fmt.Errorf("scan device session: %w", err)
+		}
+		d.Sessions = append(d.Sessions, s)
+		if s.DisconnectedAt != nil {
+			d.ConnectionHistory = append(d.ConnectionHistory, ConnectionEvent{EventType: "disconnected", Timestamp: *s.DisconnectedAt, Reason: s.DisconnectReason})
+		}
+		d.ConnectionHistory = append(d.ConnectionHistory, ConnectionEvent{EventType: "connected", Timestamp: s.ConnectedAt})
+	}
+	if err := sessionRows.Err(); err != nil {
+		return nil, fmt.Errorf("get device sessions: %w", err)
+	}
+
 	return d, nil
 }
 
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_readings_device_campaign_ts;
+DROP INDEX IF EXISTS idx_readings_device_message;
+
+ALTER TABLE readings
+  DROP COLUMN message_id;
@@ -0,0 +1,8 @@
+-- Optional device-supplied message ID for idempotent ingestion.
+-- Hypertable unique indexes must include the partition column, so duplicates
+-- are detected at ingest time rather than by a UNIQUE constraint.
+ALTER TABLE readings
+  ADD COLUMN message_id TEXT;
+
+CREATE INDEX idx_readings_device_message ON readings (device_id, message_id) WHERE message_id IS NOT NULL;
+CREATE INDEX idx_readings_device_campaign_ts ON readings (device_id, campaign_id, timestamp);
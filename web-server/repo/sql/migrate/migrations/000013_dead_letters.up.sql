@@ -0,0 +1,20 @@
+-- dead_letters: telemetry messages that could not be ingested, kept for replay.
+-- device_id and campaign_id come from the topic and are not foreign keys: the
+-- message may name a device or campaign that does not exist.
+CREATE TABLE dead_letters (
+    id                TEXT PRIMARY KEY,
+    topic             TEXT        NOT NULL,
+    device_id         TEXT        NOT NULL,
+    campaign_id       TEXT,
+    payload           BYTEA       NOT NULL,
+    error_class       TEXT        NOT NULL,
+    error_message     TEXT        NOT NULL,
+    received_at       TIMESTAMPTZ NOT NULL DEFAULT now(),
+    status            TEXT        NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'replayed')),
+    replay_count      INTEGER     NOT NULL DEFAULT 0,
+    last_replayed_at  TIMESTAMPTZ
+);
+
+CREATE INDEX idx_dead_letters_received_at ON dead_letters (received_at);
+CREATE INDEX idx_dead_letters_class_status ON dead_letters (error_class, status);
+CREATE INDEX idx_dead_letters_device ON dead_letters (device_id);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_enrollments_location_flagged;
+
+ALTER TABLE campaign_enrollments
+  DROP COLUMN location_flag_reason,
+  DROP COLUMN location_flagged_at,
+  DROP COLUMN deployment_location;
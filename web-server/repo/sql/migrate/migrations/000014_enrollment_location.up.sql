@@ -0,0 +1,8 @@
+-- Where the scitizen says the device is deployed for this campaign (GeoJSON Point),
+-- and whether reported locations have drifted far enough from it to need review (FR-124).
+ALTER TABLE campaign_enrollments
+  ADD COLUMN deployment_location JSONB,
+  ADD COLUMN location_flagged_at TIMESTAMPTZ,
+  ADD COLUMN location_flag_reason TEXT;
+
+CREATE INDEX idx_enrollments_location_flagged ON campaign_enrollments (campaign_id) WHERE location_flagged_at IS NOT NULL;
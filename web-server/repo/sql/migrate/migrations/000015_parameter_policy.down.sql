@@ -0,0 +1,12 @@
+DELETE FROM reading_values WHERE status = 'excluded';
+
+ALTER TABLE reading_values
+  DROP CONSTRAINT reading_values_status_check,
+  ADD CONSTRAINT reading_values_status_check CHECK (status IN ('accepted', 'quarantined'));
+
+ALTER TABLE campaign_parameters
+  DROP COLUMN required;
+
+ALTER TABLE campaigns
+  DROP COLUMN undeclared_parameter_policy,
+  DROP COLUMN precision_policy;
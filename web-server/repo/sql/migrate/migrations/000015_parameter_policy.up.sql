@@ -0,0 +1,14 @@
+-- Per-campaign handling of over-precise values and of parameters the campaign never declared.
+ALTER TABLE campaigns
+  ADD COLUMN precision_policy TEXT NOT NULL DEFAULT 'round'
+      CHECK (precision_policy IN ('round', 'quarantine')),
+  ADD COLUMN undeclared_parameter_policy TEXT NOT NULL DEFAULT 'exclude'
+      CHECK (undeclared_parameter_policy IN ('drop', 'exclude', 'quarantine'));
+
+ALTER TABLE campaign_parameters
+  ADD COLUMN required BOOLEAN NOT NULL DEFAULT false;
+
+-- Undeclared values kept under the 'exclude' policy are stored but never exported.
+ALTER TABLE reading_values
+  DROP CONSTRAINT reading_values_status_check,
+  ADD CONSTRAINT reading_values_status_check CHECK (status IN ('accepted', 'quarantined', 'excluded'));
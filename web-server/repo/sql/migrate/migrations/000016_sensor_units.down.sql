@@ -0,0 +1,9 @@
+ALTER TABLE reading_values
+  DROP COLUMN original_unit,
+  DROP COLUMN original_value,
+  DROP COLUMN unit;
+
+DROP TABLE IF EXISTS device_class_units;
+
+ALTER TABLE devices
+  DROP COLUMN sensor_units;
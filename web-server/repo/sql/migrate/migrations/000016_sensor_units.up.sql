@@ -0,0 +1,18 @@
+-- Units devices report each sensor in, as UCUM codes. A device's own declaration
+-- overrides its class default.
+ALTER TABLE devices
+  ADD COLUMN sensor_units JSONB NOT NULL DEFAULT '{}';
+
+CREATE TABLE device_class_units (
+    device_class TEXT        NOT NULL,
+    parameter    TEXT        NOT NULL,
+    unit         TEXT        NOT NULL,
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (device_class, parameter)
+);
+
+-- Provenance for values converted to the campaign unit at ingestion.
+ALTER TABLE reading_values
+  ADD COLUMN unit           TEXT,
+  ADD COLUMN original_value DOUBLE PRECISION,
+  ADD COLUMN original_unit  TEXT;
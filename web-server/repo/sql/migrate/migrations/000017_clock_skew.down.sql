@@ -0,0 +1,5 @@
+ALTER TABLE devices
+  DROP COLUMN clock_skew_flagged_at,
+  DROP COLUMN clock_skew_updated_at,
+  DROP COLUMN clock_skew_samples,
+  DROP COLUMN clock_skew_ms;
@@ -0,0 +1,6 @@
+-- Smoothed estimate of how far each device clock is from server time, from live readings.
+ALTER TABLE devices
+  ADD COLUMN clock_skew_ms         BIGINT,
+  ADD COLUMN clock_skew_samples    INT NOT NULL DEFAULT 0,
+  ADD COLUMN clock_skew_updated_at TIMESTAMPTZ,
+  ADD COLUMN clock_skew_flagged_at TIMESTAMPTZ;
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_devices_rate_limit_flagged;
+
+ALTER TABLE devices
+  DROP COLUMN rate_limit_flagged_at,
+  DROP COLUMN rate_limit_violations;
+
+ALTER TABLE campaigns
+  DROP COLUMN min_sampling_interval_seconds;
@@ -0,0 +1,10 @@
+-- Minimum spacing between readings a campaign accepts from one device; NULL means no minimum.
+ALTER TABLE campaigns
+  ADD COLUMN min_sampling_interval_seconds INT CHECK (min_sampling_interval_seconds > 0);
+
+-- Devices that keep exceeding ingestion rate limits, held for the security response tooling.
+ALTER TABLE devices
+  ADD COLUMN rate_limit_violations BIGINT NOT NULL DEFAULT 0,
+  ADD COLUMN rate_limit_flagged_at TIMESTAMPTZ;
+
+CREATE INDEX idx_devices_rate_limit_flagged ON devices (rate_limit_flagged_at) WHERE rate_limit_flagged_at IS NOT NULL;
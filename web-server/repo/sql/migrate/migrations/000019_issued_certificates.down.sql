@@ -0,0 +1,2 @@
+DROP SEQUENCE IF EXISTS crl_number_seq;
+DROP TABLE IF EXISTS issued_certificates;
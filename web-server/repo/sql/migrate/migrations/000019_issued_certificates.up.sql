@@ -0,0 +1,27 @@
+-- Every certificate the device CA issues. Revoked rows feed the CRL and OCSP responses.
+-- not_before/not_after are NULL for certificates issued before the ledger existed.
+CREATE TABLE issued_certificates (
+    serial            TEXT PRIMARY KEY, -- hex, as stored in devices.cert_serial
+    device_id         TEXT        NOT NULL REFERENCES devices(id) ON DELETE CASCADE,
+    not_before        TIMESTAMPTZ,
+    not_after         TIMESTAMPTZ,
+    issued_at         TIMESTAMPTZ NOT NULL DEFAULT now(),
+    revoked_at        TIMESTAMPTZ,
+    revocation_reason TEXT CHECK (revocation_reason IN ('superseded', 'certificate_hold')),
+    superseded_by     TEXT,
+    CHECK ((revoked_at IS NULL) = (revocation_reason IS NULL))
+);
+
+CREATE INDEX idx_issued_certificates_device ON issued_certificates (device_id);
+CREATE INDEX idx_issued_certificates_revoked ON issued_certificates (revoked_at) WHERE revoked_at IS NOT NULL;
+
+-- CRL numbers must increase with every CRL the CA signs (RFC 5280 5.2.3).
+CREATE SEQUENCE crl_number_seq;
+
+-- Current certificates of existing devices; revoked devices start on hold.
+INSERT INTO issued_certificates (serial, device_id, revoked_at, revocation_reason)
+SELECT cert_serial, id,
+       CASE WHEN status = 'revoked' THEN now() END,
+       CASE WHEN status = 'revoked' THEN 'certificate_hold' END
+FROM devices
+WHERE cert_serial IS NOT NULL;
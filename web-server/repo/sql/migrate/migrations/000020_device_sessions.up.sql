@@ -0,0 +1,16 @@
+-- Broker sessions, one row per authenticated MQTT connection. A device is online
+-- while it has a session with no disconnected_at.
+CREATE TABLE device_sessions (
+    id                TEXT PRIMARY KEY,
+    device_id         TEXT        NOT NULL REFERENCES devices(id) ON DELETE CASCADE,
+    connected_at      TIMESTAMPTZ NOT NULL,
+    disconnected_at   TIMESTAMPTZ,
+    remote_addr       TEXT        NOT NULL,
+    cert_serial       TEXT,
+    disconnect_reason TEXT,
+    graceful          BOOLEAN, -- MQTT DISCONNECT sent
+    will_published    BOOLEAN  -- the broker published the device's last will as it dropped
+);
+
+CREATE INDEX idx_device_sessions_device ON device_sessions (device_id, connected_at DESC);
+CREATE INDEX idx_device_sessions_open ON device_sessions (device_id) WHERE disconnected_at IS NULL;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS device_health_alerts;
+DROP TABLE IF EXISTS device_health;
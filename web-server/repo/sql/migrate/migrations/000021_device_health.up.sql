@@ -0,0 +1,24 @@
+-- Device heartbeats from rootstock/{id}/health, kept apart from scientific readings.
+CREATE TABLE device_health (
+    id                TEXT PRIMARY KEY,
+    device_id         TEXT        NOT NULL REFERENCES devices(id) ON DELETE CASCADE,
+    reported_at       TIMESTAMPTZ NOT NULL, -- device clock
+    received_at       TIMESTAMPTZ NOT NULL DEFAULT now(),
+    battery_percent   REAL,
+    rssi_dbm          INTEGER,
+    uptime_seconds    BIGINT,
+    free_memory_bytes BIGINT,
+    sensor_faults     TEXT[]      NOT NULL DEFAULT '{}',
+    firmware_build    TEXT
+);
+
+CREATE INDEX idx_device_health_device ON device_health (device_id, received_at DESC);
+
+-- Health alerts currently raised for a device. A row exists while the condition holds,
+-- so the owner is notified once per episode.
+CREATE TABLE device_health_alerts (
+    device_id  TEXT        NOT NULL REFERENCES devices(id) ON DELETE CASCADE,
+    alert      TEXT        NOT NULL CHECK (alert IN ('low_battery', 'sensor_fault', 'silent')),
+    raised_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (device_id, alert)
+);
@@ -0,0 +1,15 @@
+-- The campaign configuration last pushed to each device on rootstock/{id}/config.
+-- version increases only when the config content (digest) changes; a device runs stale
+-- config while acked_version is behind it.
+CREATE TABLE device_configs (
+    device_id     TEXT        PRIMARY KEY REFERENCES devices(id) ON DELETE CASCADE,
+    version       BIGINT      NOT NULL,
+    digest        TEXT        NOT NULL, -- sha256 of campaigns
+    campaign_ids  TEXT[]      NOT NULL DEFAULT '{}',
+    campaigns     JSONB       NOT NULL,
+    pushed_at     TIMESTAMPTZ NOT NULL DEFAULT now(),
+    acked_version BIGINT,
+    acked_at      TIMESTAMPTZ
+);
+
+CREATE INDEX idx_device_configs_campaigns ON device_configs USING GIN (campaign_ids);
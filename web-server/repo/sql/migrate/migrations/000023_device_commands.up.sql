@@ -0,0 +1,23 @@
+-- Commands sent to devices on rootstock/{id}/command, kept as an audit trail. A command
+-- sent to every device in a campaign gets one row per device sharing a batch_id.
+-- A command still 'sent' after expires_at is reported as expired; the device must not
+-- act on it and its late acknowledgement is refused.
+CREATE TABLE device_commands (
+    id           TEXT        PRIMARY KEY,
+    device_id    TEXT        NOT NULL REFERENCES devices(id) ON DELETE CASCADE,
+    campaign_id  TEXT        REFERENCES campaigns(id) ON DELETE SET NULL,
+    batch_id     TEXT        NOT NULL,
+    command      TEXT        NOT NULL CHECK (command IN ('set_sampling_interval', 'recalibrate', 'reboot', 'renew_certificate')),
+    params       JSONB       NOT NULL DEFAULT '{}',
+    issued_by    TEXT        NOT NULL, -- app user ID
+    issued_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+    expires_at   TIMESTAMPTZ NOT NULL,
+    status       TEXT        NOT NULL DEFAULT 'sent' CHECK (status IN ('sent', 'acknowledged', 'succeeded', 'failed')),
+    acked_at     TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ,
+    result       JSONB,
+    error        TEXT
+);
+
+CREATE INDEX idx_device_commands_device ON device_commands (device_id, issued_at DESC);
+CREATE INDEX idx_device_commands_campaign ON device_commands (campaign_id, issued_at DESC) WHERE campaign_id IS NOT NULL;
@@ -0,0 +1,17 @@
+-- Each device's shadow: the state it should run (desired) and the state it last said it
+-- runs (reported), synced over rootstock/{id}/shadow/{get,update,delta}. A NULL column is
+-- not set; an empty array is set to nothing. version moves on every change to either side.
+CREATE TABLE device_shadows (
+    device_id                          TEXT        PRIMARY KEY REFERENCES devices(id) ON DELETE CASCADE,
+    version                            BIGINT      NOT NULL DEFAULT 1,
+    desired_sampling_interval_seconds  INT,
+    desired_enabled_sensors            TEXT[],
+    desired_active_campaigns           TEXT[],
+    desired_firmware_version           TEXT,
+    desired_updated_at                 TIMESTAMPTZ,
+    reported_sampling_interval_seconds INT,
+    reported_enabled_sensors           TEXT[],
+    reported_active_campaigns          TEXT[],
+    reported_firmware_version          TEXT,
+    reported_updated_at                TIMESTAMPTZ
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS firmware_installs;
+DROP TABLE IF EXISTS firmware_releases;
@@ -0,0 +1,35 @@
+-- Firmware builds offered to a device class, and each device's progress installing them.
+-- A release is rolled out in stages: devices whose rollout bucket is below rollout_percent,
+-- plus the devices named in cohort. A security fix release reinstates a suspended device
+-- once it reports the release installed.
+CREATE TABLE firmware_releases (
+    id              TEXT        PRIMARY KEY,
+    class           TEXT        NOT NULL,
+    version         TEXT        NOT NULL,
+    sha256          TEXT        NOT NULL,
+    url             TEXT        NOT NULL,
+    size_bytes      BIGINT,
+    notes           TEXT        NOT NULL DEFAULT '',
+    security_fix    BOOLEAN     NOT NULL DEFAULT false,
+    rollout_percent INT         NOT NULL DEFAULT 0 CHECK (rollout_percent BETWEEN 0 AND 100),
+    cohort          TEXT[]      NOT NULL DEFAULT '{}',
+    created_by      TEXT        NOT NULL, -- app user ID
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (class, version)
+);
+
+CREATE INDEX idx_firmware_releases_class ON firmware_releases (class, created_at DESC);
+
+CREATE TABLE firmware_installs (
+    release_id  TEXT        NOT NULL REFERENCES firmware_releases(id) ON DELETE CASCADE,
+    device_id   TEXT        NOT NULL REFERENCES devices(id) ON DELETE CASCADE,
+    status      TEXT        NOT NULL DEFAULT 'notified'
+                CHECK (status IN ('notified', 'downloading', 'installing', 'installed', 'failed')),
+    error       TEXT,
+    notified_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (release_id, device_id)
+);
+
+CREATE INDEX idx_firmware_installs_device ON firmware_installs (device_id, updated_at DESC);
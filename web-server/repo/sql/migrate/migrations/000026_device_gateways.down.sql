@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_devices_gateway_id;
+
+ALTER TABLE devices
+  DROP COLUMN gateway_id,
+  DROP COLUMN gateway;
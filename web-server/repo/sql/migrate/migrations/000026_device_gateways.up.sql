@@ -0,0 +1,8 @@
+-- Gateways enroll and relay for child devices (ESP32 hubs, LoRa gateways). A gateway is
+-- issued a certificate marked as a gateway's, and may publish on its children's topics.
+-- Each child keeps its own certificate; gateway_id records which gateway enrolled it.
+ALTER TABLE devices
+  ADD COLUMN gateway    BOOLEAN NOT NULL DEFAULT false,
+  ADD COLUMN gateway_id TEXT REFERENCES devices(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_devices_gateway_id ON devices (gateway_id) WHERE gateway_id IS NOT NULL;
@@ -0,0 +1,12 @@
+-- Consumer weather stations (Weather Underground, Ecowitt, Ambient) upload to a per-device
+-- URL instead of connecting over MQTT. The URL's token is the station's credential; only its
+-- SHA-256 is stored. parameter_map renames the protocol's standard parameters to the
+-- campaign's, e.g. {"temperature": "air_temp"}.
+CREATE TABLE station_links (
+    device_id      TEXT PRIMARY KEY REFERENCES devices(id) ON DELETE CASCADE,
+    protocol       TEXT NOT NULL CHECK (protocol IN ('wunderground', 'ecowitt', 'ambient')),
+    token_hash     TEXT NOT NULL UNIQUE,
+    parameter_map  JSONB NOT NULL DEFAULT '{}',
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_upload_at TIMESTAMPTZ
+);
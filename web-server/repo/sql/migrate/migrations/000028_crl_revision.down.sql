@@ -0,0 +1 @@
+DROP TABLE IF EXISTS crl_revision;
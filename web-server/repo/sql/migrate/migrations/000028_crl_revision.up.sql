@@ -0,0 +1,8 @@
+-- Bumped whenever a certificate enters or leaves the revoked set, so the signed CRL
+-- can be served from cache until the set changes.
+CREATE TABLE crl_revision (
+    singleton BOOLEAN PRIMARY KEY DEFAULT true CHECK (singleton),
+    revision  BIGINT  NOT NULL DEFAULT 0
+);
+
+INSERT INTO crl_revision DEFAULT VALUES;
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	readingflows "rootstock/web-server/flows/reading"
+	connecthandlers "rootstock/web-server/handlers/connect"
+	"rootstock/web-server/ops/pure"
+	mqttrepo "rootstock/web-server/repo/mqtt"
+)
+
+// deadLetterReplayTimeout bounds how long a replay waits for ingestion to answer.
+const deadLetterReplayTimeout = 30 * time.Second
+
+// deadLetterReplayer replays dead letters through the telemetry ingester, as if the server
+// had published them itself: they skip the rate limiter and are answered to the operator
+// instead of the device. A replay that fails is dead-lettered again by the ingester.
+type deadLetterReplayer struct {
+	ingest *telemetryIngester
+}
+
+func newDeadLetterReplayer(ingest *telemetryIngester) *deadLetterReplayer {
+	return &deadLetterReplayer{ingest: ingest}
+}
+
+// ReplayDeadLetter ingests dl's payload on its original topic and returns the acknowledgement.
+func (r *deadLetterReplayer) ReplayDeadLetter(ctx context.Context, dl *readingflows.DeadLetter) (connecthandlers.DeadLetterReplay, error) {
+	segments := strings.Split(dl.Topic, "/")
+	if len(segments) != 4 || segments[0] != mqttrepo.TopicPrefix || segments[1] == "" || segments[3] == "" {
+		return connecthandlers.DeadLetterReplay{}, fmt.Errorf("%w: %s", connecthandlers.ErrDeadLetterNotReplayable, dl.Topic)
+	}
+	deviceID, kind, campaignID := segments[1], segments[2], segments[3]
+
+	ctx, cancel := context.WithTimeout(ctx, deadLetterReplayTimeout)
+	defer cancel()
+
+	replies := make(chan connecthandlers.DeadLetterReplay, 1)
+	switch kind {
+	case "data":
+		r.ingest.reading(ctx, dl.Topic, deviceID, campaignID, true, dl.Payload, func(ack ReadingAck) {
+			replies <- newDeadLetterReplay(ack.Status, ack.Code, ack.Reason)
+		})
+	case "data-batch":
+		r.ingest.batch(ctx, dl.Topic, deviceID, campaignID, true, dl.Payload, func(ack BatchAck) {
+			replies <- newDeadLetterReplay(ack.Status, ack.Code, ack.Reason)
+		})
+	default:
+		return connecthandlers.DeadLetterReplay{}, fmt.Errorf("%w: %s", connecthandlers.ErrDeadLetterNotReplayable, dl.Topic)
+	}
+
+	select {
+	case replay := <-replies:
+		return replay, nil
+	case <-ctx.Done():
+		return connecthandlers.DeadLetterReplay{}, ctx.Err()
+	}
+}
+
+// newDeadLetterReplay reports an acknowledgement; only a message the pipeline shed went unprocessed.
+func newDeadLetterReplay(status, code, reason string) connecthandlers.DeadLetterReplay {
+	return connecthandlers.DeadLetterReplay{
+		Processed: code != pure.FeedbackServerBusy,
+		Status:    status,
+		Code:      code,
+		Reason:    reason,
+	}
+}
@@ -0,0 +1,39 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"rootstock/web-server/config"
+	readingflows "rootstock/web-server/flows/reading"
+	connecthandlers "rootstock/web-server/handlers/connect"
+	"rootstock/web-server/ops/pure"
+)
+
+func TestDeadLetterReplayer_RejectsNonTelemetryTopics(t *testing.T) {
+	r := newDeadLetterReplayer(newTelemetryIngester(&MQTTFlows{}, config.MQTTConfig{}, nil, nil, discardLogger{}, newCountingMeter()))
+	for _, topic := range []string{"rootstock/device-001/health", "rootstock/device-001/renew/x", "other/device-001/data/c1", "rootstock//data/c1"} {
+		_, err := r.ReplayDeadLetter(context.Background(), &readingflows.DeadLetter{Topic: topic})
+		if !errors.Is(err, connecthandlers.ErrDeadLetterNotReplayable) {
+			t.Errorf("ReplayDeadLetter(%q) error = %v, want ErrDeadLetterNotReplayable", topic, err)
+		}
+	}
+}
+
+func TestNewDeadLetterReplay(t *testing.T) {
+	tests := []struct {
+		status, code  string
+		wantProcessed bool
+	}{
+		{"accepted", pure.FeedbackAccepted, true},
+		{"rejected", pure.FeedbackMalformedPayload, true}, // dead-lettered again
+		{"error", pure.FeedbackServerError, true},         // dead-lettered again
+		{"error", pure.FeedbackServerBusy, false},         // shed, never ingested
+	}
+	for _, tt := range tests {
+		if got := newDeadLetterReplay(tt.status, tt.code, ""); got.Processed != tt.wantProcessed {
+			t.Errorf("newDeadLetterReplay(%s, %s).Processed = %v, want %v", tt.status, tt.code, got.Processed, tt.wantProcessed)
+		}
+	}
+}
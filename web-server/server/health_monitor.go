@@ -0,0 +1,39 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// SilentDeviceChecker raises the silent alert for devices that stopped sending heartbeats.
+// Satisfied by *deviceflows.DeviceHealthFlow.
+type SilentDeviceChecker interface {
+	RunCheckSilent(ctx context.Context) (int, error)
+}
+
+// HealthMonitor runs the silent-device check on a fixed interval. Heartbeats clear the
+// alert as they arrive; only raising it needs a clock.
+type HealthMonitor struct {
+	task *periodicTask
+}
+
+// NewHealthMonitor starts checking every interval. A zero interval starts nothing.
+// Checks run with ctx's values but not its cancellation; call Stop to end them.
+func NewHealthMonitor(ctx context.Context, interval time.Duration, checker SilentDeviceChecker) *HealthMonitor {
+	return &HealthMonitor{task: startPeriodic(ctx, interval, func(ctx context.Context) {
+		raised, err := checker.RunCheckSilent(ctx)
+		if err != nil {
+			slog.WarnContext(ctx, "health monitor: silent device check failed", "error", err)
+			return
+		}
+		if raised > 0 {
+			slog.InfoContext(ctx, "health monitor: devices gone silent", "count", raised)
+		}
+	})}
+}
+
+// Stop ends the loop, waiting for a check in progress to finish.
+func (m *HealthMonitor) Stop() {
+	m.task.Stop()
+}
@@ -0,0 +1,47 @@
+package server
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type countingChecker struct {
+	calls atomic.Int32
+}
+
+func (c *countingChecker) RunCheckSilent(context.Context) (int, error) {
+	c.calls.Add(1)
+	return 0, nil
+}
+
+func TestHealthMonitor_ChecksOnInterval(t *testing.T) {
+	checker := &countingChecker{}
+	m := NewHealthMonitor(context.Background(), 20*time.Millisecond, checker)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for checker.calls.Load() < 2 {
+		if time.Now().After(deadline) {
+			t.Fatalf("checks = %d after 2s, want at least 2", checker.calls.Load())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	m.Stop()
+
+	after := checker.calls.Load()
+	time.Sleep(60 * time.Millisecond)
+	if got := checker.calls.Load(); got != after {
+		t.Errorf("checks continued after Stop: %d -> %d", after, got)
+	}
+}
+
+func TestHealthMonitor_ZeroIntervalDisabled(t *testing.T) {
+	checker := &countingChecker{}
+	m := NewHealthMonitor(context.Background(), 0, checker)
+	m.Stop()
+	m.Stop() // idempotent
+	if checker.calls.Load() != 0 {
+		t.Error("a zero interval should not run checks")
+	}
+}
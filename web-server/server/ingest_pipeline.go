@@ -0,0 +1,154 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"rootstock/web-server/config"
+	o11yrepo "rootstock/web-server/repo/observability"
+)
+
+// IngestPipeline runs telemetry ingestion off the broker's delivery path.
+// Jobs are sharded by key (the campaign ID) onto a fixed set of workers, each with
+// its own bounded queue, so one campaign's readings are processed in arrival order
+// while a slow Postgres or Dgraph only holds up its own shard.
+//
+// When a shard is full, Submit waits up to the configured enqueue timeout (backpressure
+// on the publishing client) and then sheds the job. Both are counted.
+type IngestPipeline struct {
+	ctx            context.Context
+	shards         []chan ingestJob
+	enqueueTimeout time.Duration
+	wg             sync.WaitGroup
+
+	mu     sync.RWMutex
+	closed bool
+
+	enqueued   o11yrepo.Counter
+	blocked    o11yrepo.Counter
+	shed       o11yrepo.Counter
+	completed  o11yrepo.Counter
+	queueDepth o11yrepo.Histogram
+	queueWait  o11yrepo.Histogram
+}
+
+type ingestJob struct {
+	run        func(ctx context.Context)
+	enqueuedAt time.Time
+}
+
+// NewIngestPipeline starts cfg.Workers workers sharing cfg.QueueSize queue slots.
+// Jobs run with ctx's values but not its cancellation, so in-flight work survives
+// the shutdown signal and is finished by Drain.
+func NewIngestPipeline(ctx context.Context, cfg config.IngestConfig, meter o11yrepo.Meter) *IngestPipeline {
+	workers := cfg.Workers
+	if workers < 1 {
+		workers = 1
+	}
+	perShard := cfg.QueueSize / workers
+	if perShard < 1 {
+		perShard = 1
+	}
+
+	p := &IngestPipeline{
+		ctx:            context.WithoutCancel(ctx),
+		shards:         make([]chan ingestJob, workers),
+		enqueueTimeout: time.Duration(cfg.EnqueueTimeoutMs) * time.Millisecond,
+		enqueued:       meter.Counter("ingest.enqueued"),
+		blocked:        meter.Counter("ingest.backpressure"),
+		shed:           meter.Counter("ingest.shed"),
+		completed:      meter.Counter("ingest.completed"),
+		queueDepth:     meter.Histogram("ingest.queue_depth"),
+		queueWait:      meter.Histogram("ingest.queue_wait_ms"),
+	}
+	for i := range p.shards {
+		p.shards[i] = make(chan ingestJob, perShard)
+		p.wg.Add(1)
+		go p.work(p.shards[i])
+	}
+	return p
+}
+
+// Submit queues run on the shard for key. Returns false if the job was shed because the
+// shard stayed full for the enqueue timeout or the pipeline is draining.
+func (p *IngestPipeline) Submit(key string, run func(ctx context.Context)) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.closed {
+		p.shed.Add(p.ctx, 1)
+		return false
+	}
+
+	shard := p.shards[p.shardFor(key)]
+	p.queueDepth.Record(p.ctx, float64(len(shard)))
+	job := ingestJob{run: run, enqueuedAt: time.Now()}
+
+	select {
+	case shard <- job:
+		p.enqueued.Add(p.ctx, 1)
+		return true
+	default:
+	}
+
+	if p.enqueueTimeout <= 0 {
+		p.shed.Add(p.ctx, 1)
+		return false
+	}
+
+	p.blocked.Add(p.ctx, 1)
+	timer := time.NewTimer(p.enqueueTimeout)
+	defer timer.Stop()
+	select {
+	case shard <- job:
+		p.enqueued.Add(p.ctx, 1)
+		return true
+	case <-timer.C:
+		p.shed.Add(p.ctx, 1)
+		return false
+	}
+}
+
+// Drain stops accepting jobs and waits for queued and in-flight jobs to finish,
+// or for ctx to expire.
+func (p *IngestPipeline) Drain(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		for _, shard := range p.shards {
+			close(shard)
+		}
+	}
+	p.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("drain ingest pipeline: %w", ctx.Err())
+	}
+}
+
+func (p *IngestPipeline) work(shard chan ingestJob) {
+	defer p.wg.Done()
+	for job := range shard {
+		p.queueWait.Record(p.ctx, float64(time.Since(job.enqueuedAt).Milliseconds()))
+		job.run(p.ctx)
+		p.completed.Add(p.ctx, 1)
+	}
+}
+
+func (p *IngestPipeline) shardFor(key string) int {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return int(h.Sum32() % uint32(len(p.shards)))
+}
@@ -0,0 +1,160 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"rootstock/web-server/config"
+	o11yrepo "rootstock/web-server/repo/observability"
+)
+
+type countingMeter struct {
+	mu       sync.Mutex
+	counters map[string]*countingInstrument
+}
+
+type countingInstrument struct {
+	total  atomic.Int64
+	mu     sync.Mutex
+	series map[string]int64 // by attributes, "k=v" sorted and comma-separated
+}
+
+func (c *countingInstrument) Add(_ context.Context, v float64)    { c.total.Add(int64(v)) }
+func (c *countingInstrument) Record(_ context.Context, _ float64) {}
+
+func (c *countingInstrument) AddWithAttributes(_ context.Context, v float64, attrs map[string]string) {
+	c.total.Add(int64(v))
+	pairs := make([]string, 0, len(attrs))
+	for k, val := range attrs {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", k, val))
+	}
+	sort.Strings(pairs)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.series == nil {
+		c.series = map[string]int64{}
+	}
+	c.series[strings.Join(pairs, ",")] += int64(v)
+}
+
+func newCountingMeter() *countingMeter {
+	return &countingMeter{counters: map[string]*countingInstrument{}}
+}
+
+func (m *countingMeter) instrument(name string) *countingInstrument {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.counters[name]; !ok {
+		m.counters[name] = &countingInstrument{}
+	}
+	return m.counters[name]
+}
+
+func (m *countingMeter) Counter(name string) o11yrepo.Counter     { return m.instrument(name) }
+func (m *countingMeter) Histogram(name string) o11yrepo.Histogram { return m.instrument(name) }
+
+func TestIngestPipeline_PreservesOrderPerKey(t *testing.T) {
+	p := NewIngestPipeline(context.Background(), config.IngestConfig{Workers: 4, QueueSize: 400, EnqueueTimeoutMs: 1000}, newCountingMeter())
+
+	var mu sync.Mutex
+	got := map[string][]int{}
+	for i := 0; i < 50; i++ {
+		for _, key := range []string{"campaign-a", "campaign-b", "campaign-c"} {
+			key, i := key, i
+			if !p.Submit(key, func(ctx context.Context) {
+				mu.Lock()
+				got[key] = append(got[key], i)
+				mu.Unlock()
+			}) {
+				t.Fatalf("Submit(%s, %d) shed", key, i)
+			}
+		}
+	}
+	if err := p.Drain(context.Background()); err != nil {
+		t.Fatalf("Drain(): %v", err)
+	}
+
+	for key, seq := range got {
+		if len(seq) != 50 {
+			t.Errorf("%s: %d jobs ran, want 50", key, len(seq))
+		}
+		for i := range seq {
+			if seq[i] != i {
+				t.Errorf("%s: job %d ran at position %d", key, seq[i], i)
+				break
+			}
+		}
+	}
+}
+
+func TestIngestPipeline_ShedsWhenFull(t *testing.T) {
+	meter := newCountingMeter()
+	p := NewIngestPipeline(context.Background(), config.IngestConfig{Workers: 1, QueueSize: 1, EnqueueTimeoutMs: 10}, meter)
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	p.Submit("k", func(ctx context.Context) {
+		close(started)
+		<-release
+	})
+	<-started
+
+	if !p.Submit("k", func(ctx context.Context) {}) {
+		t.Fatal("second job should fill the queue, not be shed")
+	}
+	if p.Submit("k", func(ctx context.Context) {}) {
+		t.Error("third job should be shed while the queue is full")
+	}
+	if n := meter.instrument("ingest.shed").total.Load(); n != 1 {
+		t.Errorf("shed = %d, want 1", n)
+	}
+	if n := meter.instrument("ingest.backpressure").total.Load(); n != 1 {
+		t.Errorf("backpressure = %d, want 1", n)
+	}
+
+	close(release)
+	if err := p.Drain(context.Background()); err != nil {
+		t.Fatalf("Drain(): %v", err)
+	}
+}
+
+func TestIngestPipeline_DrainFinishesQueuedJobs(t *testing.T) {
+	p := NewIngestPipeline(context.Background(), config.IngestConfig{Workers: 2, QueueSize: 100}, newCountingMeter())
+
+	var ran atomic.Int64
+	for i := 0; i < 20; i++ {
+		p.Submit("k", func(ctx context.Context) {
+			time.Sleep(time.Millisecond)
+			ran.Add(1)
+		})
+	}
+	if err := p.Drain(context.Background()); err != nil {
+		t.Fatalf("Drain(): %v", err)
+	}
+	if ran.Load() != 20 {
+		t.Errorf("ran = %d, want 20", ran.Load())
+	}
+	if p.Submit("k", func(ctx context.Context) {}) {
+		t.Error("Submit after Drain should be shed")
+	}
+}
+
+func TestIngestPipeline_DrainTimeout(t *testing.T) {
+	p := NewIngestPipeline(context.Background(), config.IngestConfig{Workers: 1, QueueSize: 1}, newCountingMeter())
+
+	release := make(chan struct{})
+	defer close(release)
+	p.Submit("k", func(ctx context.Context) { <-release })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := p.Drain(ctx); err == nil {
+		t.Error("Drain() should time out while a job is still running")
+	}
+}
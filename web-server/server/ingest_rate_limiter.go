@@ -0,0 +1,200 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"rootstock/web-server/config"
+	"rootstock/web-server/ops/pure"
+	o11yrepo "rootstock/web-server/repo/observability"
+)
+
+// Rate limit scopes reported by IngestRateLimiter.Allow.
+const (
+	RateLimitDevice   = "device"
+	RateLimitCampaign = "campaign"
+)
+
+// rateLimitSweepInterval is how often idle buckets and expired violation windows are forgotten.
+const rateLimitSweepInterval = time.Minute
+
+// IngestRateLimiter applies per-device and per-campaign token buckets to telemetry before it is
+// queued, so a flooding device costs a map lookup rather than a Postgres write. It also counts
+// each device's violations in a fixed window and reports the device once per window when the
+// count reaches the flag threshold.
+//
+// Control traffic (heartbeats, config and command acknowledgements, shadow syncs, install
+// reports, child enrollments) has its own bucket per device and channel, so it can neither
+// spend a device's reading budget nor count as a violation.
+//
+// State is in memory and per process: limits restart full after a deploy, which is acceptable
+// for flood protection.
+type IngestRateLimiter struct {
+	deviceRate    float64 // tokens per second
+	deviceBurst   float64
+	campaignRate  float64
+	campaignBurst float64
+	controlRate   float64
+	controlBurst  float64
+	window        time.Duration
+	threshold     int
+	now           func() time.Time
+
+	mu         sync.Mutex
+	devices    map[string]pure.TokenBucket
+	campaigns  map[string]pure.TokenBucket
+	controls   map[controlKey]pure.TokenBucket
+	violations map[string]*violationWindow
+	lastSweep  time.Time
+
+	limited        o11yrepo.Counter
+	controlLimited o11yrepo.Counter
+	sampling       o11yrepo.Counter
+	flagged        o11yrepo.Counter
+}
+
+type controlKey struct {
+	deviceID string
+	channel  string
+}
+
+type violationWindow struct {
+	start    time.Time
+	count    int
+	reported bool
+}
+
+// NewIngestRateLimiter creates a limiter from the ingest rate limit settings.
+func NewIngestRateLimiter(cfg config.RateLimitConfig, meter o11yrepo.Meter) *IngestRateLimiter {
+	return &IngestRateLimiter{
+		deviceRate:     float64(cfg.DeviceRatePerMinute) / 60,
+		deviceBurst:    float64(cfg.DeviceBurst),
+		campaignRate:   float64(cfg.CampaignRatePerSecond),
+		campaignBurst:  float64(cfg.CampaignBurst),
+		controlRate:    float64(cfg.ControlRatePerMinute) / 60,
+		controlBurst:   float64(cfg.ControlBurst),
+		window:         time.Duration(cfg.ViolationWindowSeconds) * time.Second,
+		threshold:      cfg.ViolationFlagThreshold,
+		now:            time.Now,
+		devices:        make(map[string]pure.TokenBucket),
+		campaigns:      make(map[string]pure.TokenBucket),
+		controls:       make(map[controlKey]pure.TokenBucket),
+		violations:     make(map[string]*violationWindow),
+		limited:        meter.Counter("ingest.rate_limited"),
+		controlLimited: meter.Counter("ingest.control_rate_limited"),
+		sampling:       meter.Counter("ingest.sampling_quarantined"),
+		flagged:        meter.Counter("ingest.rate_limit_flagged"),
+	}
+}
+
+// Allow takes one token from the device's bucket and one from the campaign's. When either is
+// empty the message is refused, no token is kept, and the refusing scope is returned.
+// A batch upload is one message.
+func (l *IngestRateLimiter) Allow(ctx context.Context, deviceID, campaignID string) (string, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	device, ok := pure.TakeToken(l.devices[deviceID], now, l.deviceRate, l.deviceBurst)
+	if !ok {
+		l.devices[deviceID] = device
+		l.limited.Add(ctx, 1)
+		return RateLimitDevice, false
+	}
+	campaign, ok := pure.TakeToken(l.campaigns[campaignID], now, l.campaignRate, l.campaignBurst)
+	l.campaigns[campaignID] = campaign
+	if !ok {
+		// The device did nothing wrong; give its token back
+		device.Tokens++
+		l.devices[deviceID] = device
+		l.limited.Add(ctx, 1)
+		return RateLimitCampaign, false
+	}
+	l.devices[deviceID] = device
+	return "", true
+}
+
+// AllowControl takes one token from the device's bucket for a control channel. Refusals are
+// not violations: control traffic is bounded, not policed.
+func (l *IngestRateLimiter) AllowControl(ctx context.Context, deviceID, channel string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	key := controlKey{deviceID: deviceID, channel: channel}
+	bucket, ok := pure.TakeToken(l.controls[key], now, l.controlRate, l.controlBurst)
+	l.controls[key] = bucket
+	if !ok {
+		l.controlLimited.Add(ctx, 1)
+	}
+	return ok
+}
+
+// SamplingQuarantined counts a reading the ingest flow quarantined for sampling faster than
+// its campaign's minimum interval, as a violation by the device.
+func (l *IngestRateLimiter) SamplingQuarantined(ctx context.Context, deviceID string) bool {
+	l.sampling.Add(ctx, 1)
+	return l.Violation(ctx, deviceID)
+}
+
+// Violation counts one violation by deviceID. Reports true exactly once per window, when the
+// device's count reaches the flag threshold.
+func (l *IngestRateLimiter) Violation(ctx context.Context, deviceID string) bool {
+	if l.threshold <= 0 {
+		return false
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	v, ok := l.violations[deviceID]
+	if !ok || now.Sub(v.start) >= l.window {
+		v = &violationWindow{start: now}
+		l.violations[deviceID] = v
+	}
+	v.count++
+	if v.count < l.threshold || v.reported {
+		return false
+	}
+	v.reported = true
+	l.flagged.Add(ctx, 1)
+	return true
+}
+
+// FlagThreshold is the violation count that flags a device.
+func (l *IngestRateLimiter) FlagThreshold() int {
+	return l.threshold
+}
+
+// sweep forgets buckets that have refilled and violation windows that have ended. Caller holds mu.
+func (l *IngestRateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < rateLimitSweepInterval {
+		return
+	}
+	l.lastSweep = now
+	for id, b := range l.devices {
+		if pure.BucketIdle(b, now, l.deviceRate, l.deviceBurst) {
+			delete(l.devices, id)
+		}
+	}
+	for id, b := range l.campaigns {
+		if pure.BucketIdle(b, now, l.campaignRate, l.campaignBurst) {
+			delete(l.campaigns, id)
+		}
+	}
+	for key, b := range l.controls {
+		if pure.BucketIdle(b, now, l.controlRate, l.controlBurst) {
+			delete(l.controls, key)
+		}
+	}
+	for id, v := range l.violations {
+		if now.Sub(v.start) >= l.window {
+			delete(l.violations, id)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"rootstock/web-server/config"
+)
+
+func newTestRateLimiter(cfg config.RateLimitConfig, now *time.Time) (*IngestRateLimiter, *countingMeter) {
+	meter := newCountingMeter()
+	l := NewIngestRateLimiter(cfg, meter)
+	l.now = func() time.Time { return *now }
+	return l, meter
+}
+
+func TestIngestRateLimiter_DeviceBurstThenRefill(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	l, meter := newTestRateLimiter(config.RateLimitConfig{DeviceRatePerMinute: 60, DeviceBurst: 3}, &now)
+
+	for i := 0; i < 3; i++ {
+		if _, ok := l.Allow(ctx, "dev-1", "camp-1"); !ok {
+			t.Fatalf("message %d refused within burst", i)
+		}
+	}
+	scope, ok := l.Allow(ctx, "dev-1", "camp-1")
+	if ok || scope != RateLimitDevice {
+		t.Fatalf("Allow() = %q, %v; want device limit", scope, ok)
+	}
+	if _, ok := l.Allow(ctx, "dev-2", "camp-1"); !ok {
+		t.Error("another device should have its own bucket")
+	}
+
+	now = now.Add(time.Second)
+	if _, ok := l.Allow(ctx, "dev-1", "camp-1"); !ok {
+		t.Error("expected one token refilled after a second at 60/min")
+	}
+	if got := meter.instrument("ingest.rate_limited").total.Load(); got != 1 {
+		t.Errorf("rate_limited = %d, want 1", got)
+	}
+}
+
+func TestIngestRateLimiter_CampaignLimitRefundsDevice(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	l, _ := newTestRateLimiter(config.RateLimitConfig{
+		DeviceRatePerMinute: 60, DeviceBurst: 2,
+		CampaignRatePerSecond: 1, CampaignBurst: 1,
+	}, &now)
+
+	if _, ok := l.Allow(ctx, "dev-1", "camp-1"); !ok {
+		t.Fatal("first message refused")
+	}
+	scope, ok := l.Allow(ctx, "dev-2", "camp-1")
+	if ok || scope != RateLimitCampaign {
+		t.Fatalf("Allow() = %q, %v; want campaign limit", scope, ok)
+	}
+	// dev-2's token was refunded, so it still has its full burst for other campaigns
+	for _, campaignID := range []string{"camp-2", "camp-3"} {
+		if _, ok := l.Allow(ctx, "dev-2", campaignID); !ok {
+			t.Fatalf("dev-2 refused in %s after refund", campaignID)
+		}
+	}
+}
+
+func TestIngestRateLimiter_FlagsRepeatViolatorOncePerWindow(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	l, _ := newTestRateLimiter(config.RateLimitConfig{ViolationWindowSeconds: 60, ViolationFlagThreshold: 3}, &now)
+
+	var flags int
+	for i := 0; i < 10; i++ {
+		if l.Violation(ctx, "dev-1") {
+			flags++
+		}
+	}
+	if flags != 1 {
+		t.Errorf("flagged %d times in one window, want 1", flags)
+	}
+
+	now = now.Add(2 * time.Minute)
+	l.Violation(ctx, "dev-1")
+	l.Violation(ctx, "dev-1")
+	if !l.Violation(ctx, "dev-1") {
+		t.Error("expected the device to be reported again in a new window")
+	}
+}
+
+func TestIngestRateLimiter_ControlChannelsApartFromReadings(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	l, meter := newTestRateLimiter(config.RateLimitConfig{
+		DeviceRatePerMinute: 60, DeviceBurst: 1,
+		ControlRatePerMinute: 60, ControlBurst: 2,
+		ViolationWindowSeconds: 60, ViolationFlagThreshold: 1,
+	}, &now)
+
+	for i := 0; i < 2; i++ {
+		if !l.AllowControl(ctx, "dev-1", "health") {
+			t.Fatalf("heartbeat %d refused within control burst", i)
+		}
+	}
+	if l.AllowControl(ctx, "dev-1", "health") {
+		t.Error("expected heartbeats past the control burst to be refused")
+	}
+	if !l.AllowControl(ctx, "dev-1", "shadow") || !l.AllowControl(ctx, "dev-2", "health") {
+		t.Error("each device and channel should have its own control bucket")
+	}
+	if _, ok := l.Allow(ctx, "dev-1", "camp-1"); !ok {
+		t.Error("control traffic should not spend the device's reading budget")
+	}
+	if got := meter.instrument("ingest.control_rate_limited").total.Load(); got != 1 {
+		t.Errorf("control_rate_limited = %d, want 1", got)
+	}
+	if got := meter.instrument("ingest.rate_limited").total.Load(); got != 0 {
+		t.Errorf("rate_limited = %d, want 0", got)
+	}
+	if !l.Violation(ctx, "dev-1") {
+		t.Error("control refusals should not have counted as violations")
+	}
+}
+
+func TestIngestRateLimiter_Disabled(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	l, _ := newTestRateLimiter(config.RateLimitConfig{}, &now)
+	for i := 0; i < 100; i++ {
+		if _, ok := l.Allow(ctx, "dev-1", "camp-1"); !ok {
+			t.Fatal("zero config should not limit")
+		}
+	}
+	if l.Violation(ctx, "dev-1") {
+		t.Error("zero threshold should never flag")
+	}
+}
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"context"
 	"crypto"
 	"crypto/ecdsa"
 	"crypto/elliptic"
@@ -10,6 +11,7 @@ import (
 	"crypto/x509/pkix"
 	"encoding/pem"
 	"fmt"
+	"log/slog"
 	"math/big"
 	"os"
 	"time"
@@ -18,19 +20,27 @@ import (
 	"github.com/mochi-mqtt/server/v2/listeners"
 
 	"rootstock/web-server/config"
+	deviceflows "rootstock/web-server/flows/device"
+	deviceops "rootstock/web-server/ops/device"
 )
 
 // NewMQTTServer creates an embedded Mochi MQTT broker with:
 //   - InlineClient enabled (for server-side publish/subscribe)
-//   - mTLS auth hook (device identity from cert CN, topic ACL)
+//   - mTLS auth hook (device identity from cert CN, registry status and
+//     current cert serial, topic ACL, gateways relaying for their children)
+//   - session hook recording connects and disconnects (presence, history)
 //   - TLS listener on cfg.MQTT.Port with RequireAndVerifyClientCert
 //
-// The broker generates an ephemeral server certificate from the CA at startup.
-// Devices trust the CA, so they trust the server cert. No persistent server cert needed.
+// By default the broker issues its own server certificate from the CA and rotates
+// it before expiry. Devices trust the CA, so they trust the server cert. Operators
+// can instead supply a certificate and key on disk, reloaded when the files change.
+//
+// dOps backs the registry check on connect and the session history; nil skips both
+// (chain validation only, nothing recorded).
 //
 // Call Serve() on the returned server to start accepting connections.
 // The cleanup function closes the broker gracefully.
-func NewMQTTServer(cfg *config.Config) (*mochi.Server, func(), error) {
+func NewMQTTServer(cfg *config.Config, dOps *deviceops.Ops) (*mochi.Server, func(), error) {
 	// Create broker with inline client
 	server := mochi.New(&mochi.Options{
 		InlineClient: true,
@@ -48,29 +58,74 @@ func NewMQTTServer(cfg *config.Config) (*mochi.Server, func(), error) {
 	}
 
 	// Add mTLS auth hook
+	var authorizer ConnectionAuthorizer
+	var children GatewayChildLister
+	if dOps != nil {
+		authorizeFlow := deviceflows.NewAuthorizeConnectionFlow(dOps)
+		authorizer, children = authorizeFlow, authorizeFlow
+	}
 	if err := server.AddHook(&MQTTAuthHook{}, &MQTTAuthHookConfig{
 		CACertPool:      caCertPool,
 		GracePeriodDays: cfg.MQTT.GracePeriodDays,
+		Authorizer:      authorizer,
+		Children:        children,
 	}); err != nil {
 		return nil, nil, fmt.Errorf("add mqtt auth hook: %w", err)
 	}
 
-	// Generate ephemeral server cert signed by the CA
-	serverCert, err := generateServerCert(caCert, caSigner, cfg.MQTT.ServerSANs)
+	// Session history and presence. Sessions still open are from a previous process
+	// that never saw its clients leave.
+	if dOps != nil {
+		sessions := deviceflows.NewDeviceSessionFlow(dOps)
+		ctx, cancel := context.WithTimeout(context.Background(), sessionWriteTimeout)
+		closed, err := sessions.RunCloseStale(ctx, DisconnectServerRestart)
+		cancel()
+		if err != nil {
+			return nil, nil, fmt.Errorf("close stale device sessions: %w", err)
+		}
+		if closed > 0 {
+			slog.Info("mqtt sessions: closed sessions left open by previous run", "count", closed)
+		}
+		if err := server.AddHook(&MQTTSessionHook{}, &MQTTSessionHookConfig{Recorder: sessions}); err != nil {
+			return nil, nil, fmt.Errorf("add mqtt session hook: %w", err)
+		}
+	}
+
+	// Server cert: operator-supplied pair, or issued from the CA and rotated
+	certProvider, err := newServerCertProvider(cfg.MQTT, caCert, caSigner)
 	if err != nil {
-		return nil, nil, fmt.Errorf("generate mqtt server cert: %w", err)
-	}
-
-	// TLS listener with mTLS + grace period for expired certs.
-	// RequireAnyClientCert ensures a cert is presented but skips Go's built-in
-	// expiry check. VerifyPeerCertificate does full chain validation with a
-	// relaxed expiry window so devices with recently-expired certs can still
-	// connect to renew.
-	gracePeriodDays := cfg.MQTT.GracePeriodDays
-	tlsCfg := &tls.Config{
-		Certificates: []tls.Certificate{serverCert},
-		ClientCAs:    caCertPool,
-		ClientAuth:   tls.RequireAnyClientCert,
+		return nil, nil, fmt.Errorf("mqtt server cert: %w", err)
+	}
+
+	// TLS listener with mTLS + grace period for expired certs
+	tlsCfg := newDeviceTLSConfig(certProvider.GetCertificate, caCertPool, cfg.MQTT.GracePeriodDays)
+	tcp := listeners.NewTCP(listeners.Config{
+		ID:        "mqtt-tls",
+		Address:   fmt.Sprintf(":%d", cfg.MQTT.Port),
+		TLSConfig: tlsCfg,
+	})
+	if err := server.AddListener(tcp); err != nil {
+		certProvider.Close()
+		return nil, nil, fmt.Errorf("add mqtt listener: %w", err)
+	}
+
+	cleanup := func() {
+		server.Close()
+		certProvider.Close()
+	}
+
+	return server, cleanup, nil
+}
+
+// newDeviceTLSConfig is the TLS config of every device listener: mTLS with a grace
+// period for expired certs. RequireAnyClientCert ensures a cert is presented but skips
+// Go's built-in expiry check. VerifyPeerCertificate does full chain validation with a
+// relaxed expiry window so devices with recently-expired certs can still connect to renew.
+func newDeviceTLSConfig(getCertificate func(*tls.ClientHelloInfo) (*tls.Certificate, error), caCertPool *x509.CertPool, gracePeriodDays int) *tls.Config {
+	return &tls.Config{
+		GetCertificate: getCertificate,
+		ClientCAs:      caCertPool,
+		ClientAuth:     tls.RequireAnyClientCert,
 		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
 			if len(rawCerts) == 0 {
 				return fmt.Errorf("no client certificate")
@@ -110,21 +165,20 @@ func NewMQTTServer(cfg *config.Config) (*mochi.Server, func(), error) {
 			return err
 		},
 	}
+}
 
-	tcp := listeners.NewTCP(listeners.Config{
-		ID:        "mqtt-tls",
-		Address:   fmt.Sprintf(":%d", cfg.MQTT.Port),
-		TLSConfig: tlsCfg,
-	})
-	if err := server.AddListener(tcp); err != nil {
-		return nil, nil, fmt.Errorf("add mqtt listener: %w", err)
-	}
-
-	cleanup := func() {
-		server.Close()
+// newServerCertProvider picks the file-backed provider when the operator configured a
+// cert and key, and the CA-issued rotating provider otherwise.
+func newServerCertProvider(cfg config.MQTTConfig, caCert *x509.Certificate, caSigner crypto.Signer) (ServerCertProvider, error) {
+	if cfg.ServerCertFile != "" || cfg.ServerKeyFile != "" {
+		if cfg.ServerCertFile == "" || cfg.ServerKeyFile == "" {
+			return nil, fmt.Errorf("server_cert_file and server_key_file must be set together")
+		}
+		return NewFileServerCert(cfg.ServerCertFile, cfg.ServerKeyFile)
 	}
-
-	return server, cleanup, nil
+	return NewRotatingServerCert(caCert, caSigner, cfg.ServerSANs,
+		time.Duration(cfg.ServerCertLifetimeHours)*time.Hour,
+		time.Duration(cfg.ServerCertRenewBeforeHours)*time.Hour)
 }
 
 // loadCA reads and parses the CA cert and key files.
@@ -169,9 +223,9 @@ func loadCA(certPath, keyPath string) (*x509.Certificate, crypto.Signer, []byte,
 }
 
 // generateServerCert creates an ephemeral ECDSA server certificate signed by
-// the CA for the MQTT TLS listener. Valid for 24 hours — the broker restarts
-// will generate a new one.
-func generateServerCert(caCert *x509.Certificate, caSigner crypto.Signer, sans []string) (tls.Certificate, error) {
+// the CA for the MQTT TLS listener, valid for lifetime. RotatingServerCert
+// issues a replacement before it expires.
+func generateServerCert(caCert *x509.Certificate, caSigner crypto.Signer, sans []string, lifetime time.Duration) (tls.Certificate, error) {
 	if len(sans) == 0 {
 		return tls.Certificate{}, fmt.Errorf("server_sans must not be empty")
 	}
@@ -192,7 +246,7 @@ func generateServerCert(caCert *x509.Certificate, caSigner crypto.Signer, sans [
 		Subject:      pkix.Name{CommonName: sans[0]},
 		DNSNames:     sans,
 		NotBefore:    now,
-		NotAfter:     now.Add(24 * time.Hour),
+		NotAfter:     now.Add(lifetime),
 		KeyUsage:     x509.KeyUsageDigitalSignature,
 		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
 	}
@@ -2,34 +2,97 @@ package server
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"fmt"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	mochi "github.com/mochi-mqtt/server/v2"
 	"github.com/mochi-mqtt/server/v2/packets"
+
+	deviceflows "rootstock/web-server/flows/device"
+	"rootstock/web-server/ops/pure"
+)
+
+// connectAuthorizeTimeout bounds the registry lookup made while a device connects.
+const connectAuthorizeTimeout = 5 * time.Second
+
+// A gateway session's children are reloaded from the registry once they are
+// gatewayChildrenTTL old, so changes such as a reinstatement are picked up, and sooner
+// (but at most every gatewayChildrenRetry) when the gateway uses an unknown device's
+// topic, so a child just enrolled through it is picked up. Revoking or suspending a
+// child does not wait for either: it disconnects the gateway, whose next session
+// loads its children afresh.
+const (
+	gatewayChildrenTTL   = time.Minute
+	gatewayChildrenRetry = 5 * time.Second
 )
 
+// ConnectionAuthorizer checks a connecting device against the registry.
+// Satisfied by *deviceflows.AuthorizeConnectionFlow.
+type ConnectionAuthorizer interface {
+	Run(ctx context.Context, input deviceflows.AuthorizeConnectionInput) (*deviceflows.ConnectionDecision, error)
+}
+
+// GatewayChildLister lists the devices enrolled through a gateway.
+// Satisfied by *deviceflows.AuthorizeConnectionFlow.
+type GatewayChildLister interface {
+	RunListChildren(ctx context.Context, gatewayID string) ([]deviceflows.GatewayChild, error)
+}
+
+// deviceSession is what a device's certificate and registry record admit it to.
+type deviceSession struct {
+	deviceID   string
+	cert       *x509.Certificate // nil when the client presented none
+	restricted bool              // suspended: renew, cert and firmware updates only
+	gateway    *gatewaySession   // set when admitted as a gateway
+}
+
+// gatewaySession caches the children of a connected gateway: child ID -> status.
+type gatewaySession struct {
+	mu       sync.Mutex
+	children map[string]string
+	loadedAt time.Time
+}
+
 // MQTTAuthHook implements mochi-mqtt's Hook interface for mTLS device
 // authentication and topic-level ACL enforcement.
 //
 // Authentication: extracts device ID from the client certificate's CommonName.
 // The TLS listener uses RequireAnyClientCert + custom VerifyPeerCertificate,
 // so chain validation (with grace-period expiry) is already done at TLS level.
-// This hook just extracts the identity.
+// This hook extracts the identity, then asks the authorizer whether the device's
+// registry status and current certificate serial allow a session.
 //
 // ACL: devices can only publish/subscribe to rootstock/{own-device-id}/*.
+// Suspended devices get a restricted session limited to renew/cert and firmware updates.
+// A gateway — registered as one and presenting a gateway certificate — may also use the
+// topics of the devices enrolled through it.
+//
+// The HTTPS telemetry listener holds its own hook and authorizes each request with
+// admit and topicAllowed, so both transports apply the same rules.
 type MQTTAuthHook struct {
 	mochi.HookBase
 	caCertPool      *x509.CertPool
 	gracePeriodDays int
+	authorizer      ConnectionAuthorizer
+	children        GatewayChildLister
+	restricted      sync.Map // *mochi.Client -> struct{}
+	gateways        sync.Map // *mochi.Client -> *gatewaySession
 }
 
 // MQTTAuthHookConfig holds configuration for the auth hook.
+// A nil Authorizer skips the registry check (chain validation only).
+// A nil Children gives gateways no access beyond their own topics.
 type MQTTAuthHookConfig struct {
 	CACertPool      *x509.CertPool
 	GracePeriodDays int
+	Authorizer      ConnectionAuthorizer
+	Children        GatewayChildLister
 }
 
 func (h *MQTTAuthHook) ID() string {
@@ -40,6 +103,7 @@ func (h *MQTTAuthHook) Provides(b byte) bool {
 	return bytes.Contains([]byte{
 		mochi.OnConnectAuthenticate,
 		mochi.OnACLCheck,
+		mochi.OnDisconnect,
 	}, []byte{b})
 }
 
@@ -47,13 +111,17 @@ func (h *MQTTAuthHook) Init(config any) error {
 	if cfg, ok := config.(*MQTTAuthHookConfig); ok && cfg != nil {
 		h.caCertPool = cfg.CACertPool
 		h.gracePeriodDays = cfg.GracePeriodDays
+		h.authorizer = cfg.Authorizer
+		h.children = cfg.Children
 	}
 	return nil
 }
 
 // OnConnectAuthenticate verifies the client presented a valid mTLS certificate
 // issued by our CA. The device ID is the certificate's CommonName, which must
-// match the MQTT client ID.
+// match the MQTT client ID. The device must be active (or suspended, for a
+// restricted session) and present the certificate currently on record; a
+// superseded certificate is refused. Registry errors refuse the connection.
 func (h *MQTTAuthHook) OnConnectAuthenticate(cl *mochi.Client, pk packets.Packet) bool {
 	// Allow the inline client (server-side publish/subscribe)
 	if cl.Net.Inline {
@@ -83,69 +151,189 @@ func (h *MQTTAuthHook) OnConnectAuthenticate(cl *mochi.Client, pk packets.Packet
 		return false
 	}
 
+	session, ok := h.admit(deviceID, peerCert)
+	if !ok {
+		return false
+	}
+	if session.restricted {
+		h.restricted.Store(cl, struct{}{})
+	}
+	if session.gateway != nil {
+		h.gateways.Store(cl, session.gateway)
+	}
+
 	h.Log.Info("mqtt auth: device authenticated",
 		"device_id", deviceID,
-		"serial", peerCert.SerialNumber.String())
+		"serial", fmt.Sprintf("%x", peerCert.SerialNumber))
 	return true
 }
 
-// isInGracePeriod re-derives grace status from the TLS connection state.
-// Returns true if the client's cert is expired but within the grace window.
-func (h *MQTTAuthHook) isInGracePeriod(cl *mochi.Client) bool {
+// admit asks the authorizer whether the device presenting cert may have a session, and what
+// kind. Registry errors refuse the device.
+func (h *MQTTAuthHook) admit(deviceID string, cert *x509.Certificate) (*deviceSession, bool) {
+	session := &deviceSession{deviceID: deviceID, cert: cert}
+	if h.authorizer == nil {
+		return session, true
+	}
+
+	serial := fmt.Sprintf("%x", cert.SerialNumber)
+	ctx, cancel := context.WithTimeout(context.Background(), connectAuthorizeTimeout)
+	defer cancel()
+	decision, err := h.authorizer.Run(ctx, deviceflows.AuthorizeConnectionInput{DeviceID: deviceID, CertSerial: serial})
+	if err != nil {
+		h.Log.Warn("mqtt auth: registry check failed",
+			"device_id", deviceID,
+			"error", err)
+		return nil, false
+	}
+	switch decision.Action {
+	case pure.ConnectAllow:
+		if decision.Gateway && slices.Contains(cert.Subject.OrganizationalUnit, pure.CertKindGateway) {
+			session.gateway = &gatewaySession{}
+			h.Log.Info("mqtt auth: gateway session", "device_id", deviceID)
+		}
+	case pure.ConnectRestricted:
+		session.restricted = true
+		h.Log.Warn("mqtt auth: device restricted to renew/cert/ota",
+			"device_id", deviceID,
+			"reason", decision.Reason)
+	default:
+		h.Log.Warn("mqtt auth: device refused",
+			"device_id", deviceID,
+			"serial", serial,
+			"reason", decision.Reason)
+		return nil, false
+	}
+	return session, true
+}
+
+// OnDisconnect forgets any restriction or gateway state recorded for the session.
+func (h *MQTTAuthHook) OnDisconnect(cl *mochi.Client, err error, expire bool) {
+	h.restricted.Delete(cl)
+	h.gateways.Delete(cl)
+}
+
+// isRestricted reports whether the session was admitted for a suspended device.
+func (h *MQTTAuthHook) isRestricted(cl *mochi.Client) bool {
+	_, ok := h.restricted.Load(cl)
+	return ok
+}
+
+// peerCertificate returns the client's leaf certificate, or nil when it has none.
+func peerCertificate(cl *mochi.Client) *x509.Certificate {
 	tlsConn, ok := cl.Net.Conn.(*tls.Conn)
 	if !ok {
-		return false
+		return nil
 	}
 	state := tlsConn.ConnectionState()
 	if len(state.PeerCertificates) == 0 {
-		return false
+		return nil
 	}
-	return time.Now().After(state.PeerCertificates[0].NotAfter)
+	return state.PeerCertificates[0]
+}
+
+// inGracePeriod reports whether cert is expired but was still accepted, being within the
+// grace window checked at the TLS handshake.
+func inGracePeriod(cert *x509.Certificate) bool {
+	return cert != nil && time.Now().After(cert.NotAfter)
 }
 
 // OnACLCheck enforces that devices can only access their own topic namespace:
 // rootstock/{device-id}/*. The device ID comes from the MQTT client ID,
-// which was verified against the cert CN in OnConnectAuthenticate.
+// which was verified against the cert CN in OnConnectAuthenticate. A gateway
+// session may also use the topics of its children, as far as each child's status allows.
 //
-// Grace period: devices with expired (but within grace window) certs can only
-// access renew and cert subtopics.
+// Grace period and suspension: devices with expired (but within grace window)
+// certs, and suspended devices, can only access renew and cert subtopics.
 func (h *MQTTAuthHook) OnACLCheck(cl *mochi.Client, topic string, write bool) bool {
 	// Allow the inline client (server-side operations)
 	if cl.Net.Inline {
 		return true
 	}
 
+	session := &deviceSession{deviceID: cl.ID, cert: peerCertificate(cl), restricted: h.isRestricted(cl)}
+	if v, ok := h.gateways.Load(cl); ok {
+		session.gateway = v.(*gatewaySession)
+	}
+	return h.topicAllowed(session, topic)
+}
+
+// topicAllowed applies the topic ACL to a session, on the broker or another device transport.
+func (h *MQTTAuthHook) topicAllowed(s *deviceSession, topic string) bool {
 	// Topic format: rootstock/{device-id}/...
 	parts := strings.SplitN(topic, "/", 3)
 	if len(parts) < 2 || parts[0] != "rootstock" {
 		h.Log.Debug("mqtt acl: invalid topic prefix",
-			"client", cl.ID,
+			"client", s.deviceID,
 			"topic", topic)
 		return false
 	}
 
 	topicDeviceID := parts[1]
-	if topicDeviceID != cl.ID {
+	subtopic := ""
+	if len(parts) == 3 {
+		subtopic = parts[2]
+	}
+
+	if topicDeviceID != s.deviceID {
+		if h.gatewayMayRelay(s, topicDeviceID, subtopic) {
+			return true
+		}
 		h.Log.Warn("mqtt acl: cross-device access denied",
-			"client", cl.ID,
+			"client", s.deviceID,
 			"topic_device", topicDeviceID,
 			"topic", topic)
 		return false
 	}
 
-	// Grace period restriction: expired certs can only use renew and cert topics
-	if h.isInGracePeriod(cl) {
-		subtopic := ""
-		if len(parts) == 3 {
-			subtopic = parts[2]
-		}
-		if subtopic != "renew" && subtopic != "cert" {
-			h.Log.Warn("mqtt acl: grace period restricts to renew/cert only",
-				"client", cl.ID,
-				"topic", topic)
-			return false
-		}
+	// Suspension restriction: renew, cert, and the firmware update topics a suspended
+	// device needs to install the patch that reinstates it
+	if s.restricted && !pure.SuspendedTopicAllowed(subtopic) {
+		h.Log.Warn("mqtt acl: session restricted to renew/cert/ota only",
+			"client", s.deviceID,
+			"topic", topic)
+		return false
+	}
+
+	// Grace period: only renew and cert topics
+	if inGracePeriod(s.cert) && subtopic != "renew" && subtopic != "cert" {
+		h.Log.Warn("mqtt acl: session restricted to renew/cert only",
+			"client", s.deviceID,
+			"topic", topic)
+		return false
 	}
 
 	return true
 }
+
+// gatewayMayRelay reports whether a gateway session may use a child device's subtopic.
+// Gateways whose own certificate is in its grace period relay for no one.
+func (h *MQTTAuthHook) gatewayMayRelay(s *deviceSession, childID, subtopic string) bool {
+	gw := s.gateway
+	if gw == nil || h.children == nil || inGracePeriod(s.cert) {
+		return false
+	}
+	gw.mu.Lock()
+	defer gw.mu.Unlock()
+
+	status, known := gw.children[childID]
+	age := time.Since(gw.loadedAt)
+	if age > gatewayChildrenTTL || (!known && age > gatewayChildrenRetry) {
+		ctx, cancel := context.WithTimeout(context.Background(), connectAuthorizeTimeout)
+		defer cancel()
+		children, err := h.children.RunListChildren(ctx, s.deviceID)
+		if err != nil {
+			h.Log.Warn("mqtt acl: gateway children lookup failed",
+				"client", s.deviceID,
+				"error", err)
+			return false
+		}
+		gw.children = make(map[string]string, len(children))
+		for _, c := range children {
+			gw.children[c.DeviceID] = c.Status
+		}
+		gw.loadedAt = time.Now()
+		status, known = gw.children[childID]
+	}
+	return known && pure.GatewayChildTopicAllowed(status, subtopic)
+}
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
@@ -8,6 +9,7 @@ import (
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/pem"
+	"errors"
 	"fmt"
 	"log/slog"
 	"math/big"
@@ -19,6 +21,8 @@ import (
 	"github.com/mochi-mqtt/server/v2/packets"
 
 	"rootstock/web-server/config"
+	deviceflows "rootstock/web-server/flows/device"
+	"rootstock/web-server/ops/pure"
 )
 
 // testCA generates a throwaway CA + device cert for testing.
@@ -89,6 +93,9 @@ func TestAuthHook_Provides(t *testing.T) {
 	if !h.Provides(mochi.OnACLCheck) {
 		t.Error("should provide OnACLCheck")
 	}
+	if !h.Provides(mochi.OnDisconnect) {
+		t.Error("should provide OnDisconnect")
+	}
 	if h.Provides(mochi.OnPublish) {
 		t.Error("should not provide OnPublish")
 	}
@@ -199,6 +206,18 @@ func TestAuthHook_ACLAllowInlineClient(t *testing.T) {
 
 // makeDeviceCert creates a device cert signed by the test CA with specific timing.
 func (ca *testCA) makeDeviceCert(t *testing.T, deviceID string, notBefore, notAfter time.Time) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+	return ca.makeCert(t, pkix.Name{CommonName: deviceID}, notBefore, notAfter)
+}
+
+// makeGatewayCert creates a gateway cert (OU=gateway) signed by the test CA.
+func (ca *testCA) makeGatewayCert(t *testing.T, deviceID string) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+	now := time.Now()
+	return ca.makeCert(t, pkix.Name{CommonName: deviceID, OrganizationalUnit: []string{pure.CertKindGateway}}, now.Add(-time.Hour), now.AddDate(0, 3, 0))
+}
+
+func (ca *testCA) makeCert(t *testing.T, subject pkix.Name, notBefore, notAfter time.Time) (*x509.Certificate, *ecdsa.PrivateKey) {
 	t.Helper()
 	devKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 	if err != nil {
@@ -207,7 +226,7 @@ func (ca *testCA) makeDeviceCert(t *testing.T, deviceID string, notBefore, notAf
 
 	template := &x509.Certificate{
 		SerialNumber: mustSerial(t),
-		Subject:      pkix.Name{CommonName: deviceID},
+		Subject:      subject,
 		NotBefore:    notBefore,
 		NotAfter:     notAfter,
 		KeyUsage:     x509.KeyUsageDigitalSignature,
@@ -419,13 +438,15 @@ func TestMQTTServer_GracePeriodCertAccepted(t *testing.T) {
 			CAKeyPath:  fmt.Sprintf("%s/ca.key", dir),
 		},
 		MQTT: config.MQTTConfig{
-			Port:            port,
-			ServerSANs:      []string{"localhost"},
-			GracePeriodDays: 7,
+			Port:                       port,
+			ServerSANs:                 []string{"localhost"},
+			GracePeriodDays:            7,
+			ServerCertLifetimeHours:    24,
+			ServerCertRenewBeforeHours: 6,
 		},
 	}
 
-	mqttServer, cleanup, err := NewMQTTServer(cfg)
+	mqttServer, cleanup, err := NewMQTTServer(cfg, nil)
 	if err != nil {
 		t.Fatalf("NewMQTTServer(): %v", err)
 	}
@@ -468,3 +489,217 @@ func TestMQTTServer_GracePeriodCertAccepted(t *testing.T) {
 	}
 	conn.Close()
 }
+
+// fakeAuthorizer returns a fixed decision and records what it was asked.
+type fakeAuthorizer struct {
+	decision deviceflows.ConnectionDecision
+	err      error
+	got      deviceflows.AuthorizeConnectionInput
+}
+
+func (f *fakeAuthorizer) Run(_ context.Context, input deviceflows.AuthorizeConnectionInput) (*deviceflows.ConnectionDecision, error) {
+	f.got = input
+	if f.err != nil {
+		return nil, f.err
+	}
+	d := f.decision
+	return &d, nil
+}
+
+func newHookWithAuthorizer(t *testing.T, ca *testCA, authorizer ConnectionAuthorizer) *MQTTAuthHook {
+	t.Helper()
+	h := &MQTTAuthHook{}
+	h.SetOpts(slog.Default(), &mochi.HookOptions{})
+	if err := h.Init(&MQTTAuthHookConfig{CACertPool: ca.CACertPool, GracePeriodDays: 7, Authorizer: authorizer}); err != nil {
+		t.Fatalf("init hook: %v", err)
+	}
+	return h
+}
+
+func TestAuthHook_RegistryAllowPassesSerial(t *testing.T) {
+	ca := newTestCA(t)
+	authz := &fakeAuthorizer{decision: deviceflows.ConnectionDecision{Action: pure.ConnectAllow}}
+	h := newHookWithAuthorizer(t, ca, authz)
+
+	now := time.Now()
+	devCert, devKey := ca.makeDeviceCert(t, "device-active", now.Add(-time.Hour), now.AddDate(0, 3, 0))
+	cl := makeTLSClient(t, ca, "device-active", devCert, devKey)
+
+	if !h.OnConnectAuthenticate(cl, packets.Packet{}) {
+		t.Fatal("active device should authenticate")
+	}
+	if authz.got.DeviceID != "device-active" {
+		t.Errorf("DeviceID = %q, want device-active", authz.got.DeviceID)
+	}
+	if want := fmt.Sprintf("%x", devCert.SerialNumber); authz.got.CertSerial != want {
+		t.Errorf("CertSerial = %q, want %q", authz.got.CertSerial, want)
+	}
+	if !h.OnACLCheck(cl, "rootstock/device-active/data/campaign-1", true) {
+		t.Error("active device should publish data")
+	}
+}
+
+func TestAuthHook_RegistryDenyRejects(t *testing.T) {
+	ca := newTestCA(t)
+	h := newHookWithAuthorizer(t, ca, &fakeAuthorizer{decision: deviceflows.ConnectionDecision{Action: pure.ConnectDeny, Reason: "certificate superseded"}})
+
+	now := time.Now()
+	devCert, devKey := ca.makeDeviceCert(t, "device-old-cert", now.Add(-time.Hour), now.AddDate(0, 3, 0))
+	cl := makeTLSClient(t, ca, "device-old-cert", devCert, devKey)
+
+	if h.OnConnectAuthenticate(cl, packets.Packet{}) {
+		t.Error("denied device should not authenticate")
+	}
+}
+
+func TestAuthHook_RegistryErrorFailsClosed(t *testing.T) {
+	ca := newTestCA(t)
+	h := newHookWithAuthorizer(t, ca, &fakeAuthorizer{err: errors.New("registry unavailable")})
+
+	now := time.Now()
+	devCert, devKey := ca.makeDeviceCert(t, "device-unknown", now.Add(-time.Hour), now.AddDate(0, 3, 0))
+	cl := makeTLSClient(t, ca, "device-unknown", devCert, devKey)
+
+	if h.OnConnectAuthenticate(cl, packets.Packet{}) {
+		t.Error("registry error should refuse the connection")
+	}
+}
+
+func TestAuthHook_SuspendedDeviceRestrictedToRenewCertAndOTA(t *testing.T) {
+	ca := newTestCA(t)
+	h := newHookWithAuthorizer(t, ca, &fakeAuthorizer{decision: deviceflows.ConnectionDecision{Action: pure.ConnectRestricted, Reason: "device suspended"}})
+
+	now := time.Now()
+	devCert, devKey := ca.makeDeviceCert(t, "device-suspended", now.Add(-time.Hour), now.AddDate(0, 3, 0))
+	cl := makeTLSClient(t, ca, "device-suspended", devCert, devKey)
+
+	if !h.OnConnectAuthenticate(cl, packets.Packet{}) {
+		t.Fatal("suspended device should get a restricted session")
+	}
+	if !h.OnACLCheck(cl, "rootstock/device-suspended/renew", true) {
+		t.Error("restricted session should allow renew topic")
+	}
+	if !h.OnACLCheck(cl, "rootstock/device-suspended/cert", false) {
+		t.Error("restricted session should allow cert topic")
+	}
+	if !h.OnACLCheck(cl, "rootstock/device-suspended/ota", false) {
+		t.Error("restricted session should allow ota topic")
+	}
+	if !h.OnACLCheck(cl, "rootstock/device-suspended/ota/status", true) {
+		t.Error("restricted session should allow ota status topic")
+	}
+	if h.OnACLCheck(cl, "rootstock/device-suspended/data/campaign-1", true) {
+		t.Error("restricted session should deny data topics")
+	}
+
+	h.OnDisconnect(cl, nil, false)
+	if h.isRestricted(cl) {
+		t.Error("restriction should be cleared on disconnect")
+	}
+}
+
+// fakeChildLister returns fixed children and counts lookups.
+type fakeChildLister struct {
+	children []deviceflows.GatewayChild
+	calls    int
+}
+
+func (f *fakeChildLister) RunListChildren(_ context.Context, gatewayID string) ([]deviceflows.GatewayChild, error) {
+	f.calls++
+	return f.children, nil
+}
+
+func newGatewayHook(t *testing.T, ca *testCA, gateway bool, children GatewayChildLister) *MQTTAuthHook {
+	t.Helper()
+	h := &MQTTAuthHook{}
+	h.SetOpts(slog.Default(), &mochi.HookOptions{})
+	if err := h.Init(&MQTTAuthHookConfig{
+		CACertPool:      ca.CACertPool,
+		GracePeriodDays: 7,
+		Authorizer:      &fakeAuthorizer{decision: deviceflows.ConnectionDecision{Action: pure.ConnectAllow, Gateway: gateway}},
+		Children:        children,
+	}); err != nil {
+		t.Fatalf("init hook: %v", err)
+	}
+	return h
+}
+
+func TestAuthHook_GatewayRelaysForChildren(t *testing.T) {
+	ca := newTestCA(t)
+	lister := &fakeChildLister{children: []deviceflows.GatewayChild{
+		{DeviceID: "child-active", Status: "active"},
+		{DeviceID: "child-suspended", Status: "suspended"},
+		{DeviceID: "child-revoked", Status: "revoked"},
+	}}
+	h := newGatewayHook(t, ca, true, lister)
+
+	gwCert, gwKey := ca.makeGatewayCert(t, "gateway-1")
+	cl := makeTLSClient(t, ca, "gateway-1", gwCert, gwKey)
+	if !h.OnConnectAuthenticate(cl, packets.Packet{}) {
+		t.Fatal("gateway should authenticate")
+	}
+
+	if !h.OnACLCheck(cl, "rootstock/gateway-1/children/enroll", true) {
+		t.Error("gateway should use its own topics")
+	}
+	if !h.OnACLCheck(cl, "rootstock/child-active/data/campaign-1", true) {
+		t.Error("gateway should publish data for an active child")
+	}
+	if !h.OnACLCheck(cl, "rootstock/child-active/config", false) {
+		t.Error("gateway should subscribe for an active child")
+	}
+	if !h.OnACLCheck(cl, "rootstock/child-suspended/ota/status", true) {
+		t.Error("gateway should report firmware updates for a suspended child")
+	}
+	// Certificates are issued only to the child's own session, for a key only it holds
+	for _, topic := range []string{"rootstock/child-active/renew", "rootstock/child-active/cert", "rootstock/child-suspended/renew"} {
+		if h.OnACLCheck(cl, topic, true) {
+			t.Errorf("gateway should not use %s", topic)
+		}
+	}
+	if h.OnACLCheck(cl, "rootstock/child-suspended/data/campaign-1", true) {
+		t.Error("gateway should not publish data for a suspended child")
+	}
+	if h.OnACLCheck(cl, "rootstock/child-revoked/data/campaign-1", true) {
+		t.Error("gateway should not publish for a revoked child")
+	}
+	if h.OnACLCheck(cl, "rootstock/someone-else/data/campaign-1", true) {
+		t.Error("gateway should not publish for devices that are not its children")
+	}
+	if lister.calls != 1 {
+		t.Errorf("children looked up %d times, want 1 (cached, retry not yet due)", lister.calls)
+	}
+
+	h.OnDisconnect(cl, nil, false)
+	if _, ok := h.gateways.Load(cl); ok {
+		t.Error("gateway state should be cleared on disconnect")
+	}
+}
+
+func TestAuthHook_GatewayNeedsRegistryAndCertificate(t *testing.T) {
+	ca := newTestCA(t)
+	lister := &fakeChildLister{children: []deviceflows.GatewayChild{{DeviceID: "child-1", Status: "active"}}}
+
+	// Registered as a gateway, but presenting a plain device certificate
+	h := newGatewayHook(t, ca, true, lister)
+	now := time.Now()
+	devCert, devKey := ca.makeDeviceCert(t, "gateway-1", now.Add(-time.Hour), now.AddDate(0, 3, 0))
+	cl := makeTLSClient(t, ca, "gateway-1", devCert, devKey)
+	if !h.OnConnectAuthenticate(cl, packets.Packet{}) {
+		t.Fatal("device should authenticate")
+	}
+	if h.OnACLCheck(cl, "rootstock/child-1/data/campaign-1", true) {
+		t.Error("plain certificate should not relay for children")
+	}
+
+	// Presenting a gateway certificate, but not registered as a gateway
+	h = newGatewayHook(t, ca, false, lister)
+	gwCert, gwKey := ca.makeGatewayCert(t, "gateway-1")
+	cl = makeTLSClient(t, ca, "gateway-1", gwCert, gwKey)
+	if !h.OnConnectAuthenticate(cl, packets.Packet{}) {
+		t.Fatal("device should authenticate")
+	}
+	if h.OnACLCheck(cl, "rootstock/child-1/data/campaign-1", true) {
+		t.Error("unregistered gateway should not relay for children")
+	}
+}
@@ -0,0 +1,76 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	mochi "github.com/mochi-mqtt/server/v2"
+	"github.com/mochi-mqtt/server/v2/packets"
+)
+
+// CommandResender republishes a device's unacknowledged, unexpired commands.
+// Satisfied by *deviceflows.DeviceCommandFlow.
+type CommandResender interface {
+	RunResend(ctx context.Context, deviceID string) (int, error)
+}
+
+// MQTTCommandSyncHook resends a device's pending commands when it subscribes to its
+// command topic. Commands are not retained, so a device that was offline when a command
+// was sent, or whose session did not survive, gets it here until it expires. Resends run
+// on the pipeline, sharded by device like command results.
+type MQTTCommandSyncHook struct {
+	mochi.HookBase
+	resender CommandResender
+	jobs     jobSubmitter
+}
+
+// MQTTCommandSyncHookConfig holds configuration for the command sync hook.
+type MQTTCommandSyncHookConfig struct {
+	Resender CommandResender
+	Jobs     jobSubmitter
+}
+
+func (h *MQTTCommandSyncHook) ID() string {
+	return "mqtt-device-command-sync"
+}
+
+func (h *MQTTCommandSyncHook) Provides(b byte) bool {
+	return bytes.Contains([]byte{
+		mochi.OnSubscribed,
+	}, []byte{b})
+}
+
+func (h *MQTTCommandSyncHook) Init(config any) error {
+	cfg, ok := config.(*MQTTCommandSyncHookConfig)
+	if !ok || cfg == nil || cfg.Resender == nil || cfg.Jobs == nil {
+		return fmt.Errorf("mqtt command sync hook: resender and jobs are required")
+	}
+	h.resender = cfg.Resender
+	h.jobs = cfg.Jobs
+	return nil
+}
+
+// OnSubscribed queues a resend when a granted filter covers the device's command topic.
+func (h *MQTTCommandSyncHook) OnSubscribed(cl *mochi.Client, pk packets.Packet, reasonCodes []byte) {
+	if cl.Net.Inline {
+		return
+	}
+	deviceID := cl.ID
+	for i, sub := range pk.Filters {
+		if i < len(reasonCodes) && reasonCodes[i] >= packets.ErrUnspecifiedError.Code {
+			continue
+		}
+		if !coversDeviceTopic(sub.Filter, deviceID, "command") {
+			continue
+		}
+		if !h.jobs.Submit(deviceID, func(ctx context.Context) {
+			if _, err := h.resender.RunResend(ctx, deviceID); err != nil {
+				h.Log.Warn("mqtt command: resend on subscribe failed", "device_id", deviceID, "error", err)
+			}
+		}) {
+			h.Log.Warn("mqtt command: pipeline shed resend", "device_id", deviceID)
+		}
+		return
+	}
+}
@@ -0,0 +1,74 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"testing"
+
+	mochi "github.com/mochi-mqtt/server/v2"
+	"github.com/mochi-mqtt/server/v2/packets"
+)
+
+type fakeCommandResender struct {
+	mu      sync.Mutex
+	resends []string
+}
+
+func (f *fakeCommandResender) RunResend(_ context.Context, deviceID string) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.resends = append(f.resends, deviceID)
+	return 1, nil
+}
+
+func newCommandSyncHook(t *testing.T, resender CommandResender) *MQTTCommandSyncHook {
+	t.Helper()
+	h := &MQTTCommandSyncHook{}
+	h.SetOpts(slog.Default(), &mochi.HookOptions{})
+	if err := h.Init(&MQTTCommandSyncHookConfig{Resender: resender, Jobs: inlineJobs{}}); err != nil {
+		t.Fatalf("Init(): %v", err)
+	}
+	return h
+}
+
+func TestMQTTCommandSyncHook_ResendsOnCommandSubscribe(t *testing.T) {
+	resender := &fakeCommandResender{}
+	h := newCommandSyncHook(t, resender)
+	srv := mochi.New(nil)
+	cl := srv.NewClient(nil, "mqtt-tls", "device-1", false)
+
+	// Other topics and refused subscriptions do not resend
+	h.OnSubscribed(cl, packets.Packet{Filters: packets.Subscriptions{{Filter: "rootstock/device-1/config"}}}, []byte{0x01})
+	h.OnSubscribed(cl, packets.Packet{Filters: packets.Subscriptions{{Filter: "rootstock/device-1/command"}}}, []byte{packets.ErrNotAuthorized.Code})
+	if len(resender.resends) != 0 {
+		t.Fatalf("resends = %v, want none", resender.resends)
+	}
+
+	h.OnSubscribed(cl, packets.Packet{Filters: packets.Subscriptions{
+		{Filter: "rootstock/device-1/command"},
+		{Filter: "rootstock/device-1/+"},
+	}}, []byte{0x01, 0x01})
+	if len(resender.resends) != 1 || resender.resends[0] != "device-1" {
+		t.Errorf("resends = %v, want [device-1]", resender.resends)
+	}
+}
+
+func TestMQTTCommandSyncHook_IgnoresInline(t *testing.T) {
+	resender := &fakeCommandResender{}
+	h := newCommandSyncHook(t, resender)
+	srv := mochi.New(nil)
+	cl := srv.NewClient(nil, "local", "inline", true)
+
+	h.OnSubscribed(cl, packets.Packet{Filters: packets.Subscriptions{{Filter: "rootstock/inline/command"}}}, []byte{0x01})
+	if len(resender.resends) != 0 {
+		t.Errorf("resends = %v, want none", resender.resends)
+	}
+}
+
+func TestMQTTCommandSyncHook_InitRequiresResender(t *testing.T) {
+	h := &MQTTCommandSyncHook{}
+	if err := h.Init(&MQTTCommandSyncHookConfig{Jobs: inlineJobs{}}); err == nil {
+		t.Error("Init() without resender succeeded")
+	}
+}
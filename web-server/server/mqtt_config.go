@@ -0,0 +1,123 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	mochi "github.com/mochi-mqtt/server/v2"
+	"github.com/mochi-mqtt/server/v2/packets"
+
+	deviceflows "rootstock/web-server/flows/device"
+	mqttrepo "rootstock/web-server/repo/mqtt"
+)
+
+// ConfigPusher rebuilds a device's config and sends it if it changed or the device has not
+// acknowledged it. Satisfied by *deviceflows.DeviceConfigFlow.
+type ConfigPusher interface {
+	RunPush(ctx context.Context, deviceID string) (*deviceflows.DeviceConfigStatus, error)
+}
+
+// jobSubmitter queues work off the broker's delivery path. Satisfied by *IngestPipeline.
+type jobSubmitter interface {
+	Submit(key string, run func(ctx context.Context)) bool
+}
+
+// MQTTConfigSyncHook brings a device's config up to date when it subscribes to its config
+// topic. The retained copy covers a device that reconnects to the same broker; this covers
+// a broker restart, which loses retained messages, and a push that failed while the device
+// was away. Pushes run on the pipeline, sharded by device like config acks.
+type MQTTConfigSyncHook struct {
+	mochi.HookBase
+	pusher ConfigPusher
+	jobs   jobSubmitter
+}
+
+// MQTTConfigSyncHookConfig holds configuration for the config sync hook.
+type MQTTConfigSyncHookConfig struct {
+	Pusher ConfigPusher
+	Jobs   jobSubmitter
+}
+
+func (h *MQTTConfigSyncHook) ID() string {
+	return "mqtt-device-config-sync"
+}
+
+func (h *MQTTConfigSyncHook) Provides(b byte) bool {
+	return bytes.Contains([]byte{
+		mochi.OnSubscribed,
+	}, []byte{b})
+}
+
+func (h *MQTTConfigSyncHook) Init(config any) error {
+	cfg, ok := config.(*MQTTConfigSyncHookConfig)
+	if !ok || cfg == nil || cfg.Pusher == nil || cfg.Jobs == nil {
+		return fmt.Errorf("mqtt config sync hook: pusher and jobs are required")
+	}
+	h.pusher = cfg.Pusher
+	h.jobs = cfg.Jobs
+	return nil
+}
+
+// OnSubscribed queues a push when a granted filter covers the device's config topic.
+func (h *MQTTConfigSyncHook) OnSubscribed(cl *mochi.Client, pk packets.Packet, reasonCodes []byte) {
+	if cl.Net.Inline {
+		return
+	}
+	deviceID := cl.ID
+	for i, sub := range pk.Filters {
+		if i < len(reasonCodes) && reasonCodes[i] >= packets.ErrUnspecifiedError.Code {
+			continue
+		}
+		if !coversDeviceTopic(sub.Filter, deviceID, "config") {
+			continue
+		}
+		if !h.jobs.Submit(deviceID, func(ctx context.Context) {
+			if _, err := h.pusher.RunPush(ctx, deviceID); err != nil {
+				h.Log.Warn("mqtt config: push on subscribe failed", "device_id", deviceID, "error", err)
+			}
+		}) {
+			h.Log.Warn("mqtt config: pipeline shed push", "device_id", deviceID)
+		}
+		return
+	}
+}
+
+// coversDeviceTopic reports whether a subscription filter receives rootstock/{id}/{leaf}.
+func coversDeviceTopic(filter, deviceID, leaf string) bool {
+	base := fmt.Sprintf("%s/%s/", mqttrepo.TopicPrefix, deviceID)
+	return filter == base+leaf || filter == base+"#" || filter == base+"+"
+}
+
+// EndedConfigPusher re-pushes config to devices still configured for a campaign they
+// should no longer run. Satisfied by *deviceflows.DeviceConfigFlow.
+type EndedConfigPusher interface {
+	RunPushEnded(ctx context.Context) (int, error)
+}
+
+// ConfigMonitor re-pushes device config on a fixed interval when a campaign has ended.
+// Enrollment and campaign changes push as they happen; a window closing needs a clock.
+type ConfigMonitor struct {
+	task *periodicTask
+}
+
+// NewConfigMonitor starts sweeping every interval. A zero interval starts nothing.
+func NewConfigMonitor(ctx context.Context, interval time.Duration, pusher EndedConfigPusher) *ConfigMonitor {
+	return &ConfigMonitor{task: startPeriodic(ctx, interval, func(ctx context.Context) {
+		pushed, err := pusher.RunPushEnded(ctx)
+		if err != nil {
+			slog.WarnContext(ctx, "config monitor: ended campaign sweep failed", "error", err)
+			return
+		}
+		if pushed > 0 {
+			slog.InfoContext(ctx, "config monitor: re-pushed config for ended campaigns", "devices", pushed)
+		}
+	})}
+}
+
+// Stop ends the loop, waiting for a sweep in progress to finish.
+func (m *ConfigMonitor) Stop() {
+	m.task.Stop()
+}
@@ -0,0 +1,102 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"testing"
+
+	mochi "github.com/mochi-mqtt/server/v2"
+	"github.com/mochi-mqtt/server/v2/packets"
+
+	deviceflows "rootstock/web-server/flows/device"
+)
+
+type fakeConfigPusher struct {
+	mu     sync.Mutex
+	pushed []string
+}
+
+func (f *fakeConfigPusher) RunPush(_ context.Context, deviceID string) (*deviceflows.DeviceConfigStatus, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.pushed = append(f.pushed, deviceID)
+	return &deviceflows.DeviceConfigStatus{DeviceID: deviceID, Published: true}, nil
+}
+
+// inlineJobs runs submitted jobs immediately.
+type inlineJobs struct{}
+
+func (inlineJobs) Submit(_ string, run func(ctx context.Context)) bool {
+	run(context.Background())
+	return true
+}
+
+func newConfigSyncHook(t *testing.T, pusher ConfigPusher) *MQTTConfigSyncHook {
+	t.Helper()
+	h := &MQTTConfigSyncHook{}
+	h.SetOpts(slog.Default(), &mochi.HookOptions{})
+	if err := h.Init(&MQTTConfigSyncHookConfig{Pusher: pusher, Jobs: inlineJobs{}}); err != nil {
+		t.Fatalf("Init(): %v", err)
+	}
+	return h
+}
+
+func TestMQTTConfigSyncHook_PushesOnConfigSubscribe(t *testing.T) {
+	pusher := &fakeConfigPusher{}
+	h := newConfigSyncHook(t, pusher)
+	srv := mochi.New(nil)
+	cl := srv.NewClient(nil, "mqtt-tls", "device-1", false)
+
+	// Unrelated subscriptions and refused ones do not push
+	h.OnSubscribed(cl, packets.Packet{Filters: packets.Subscriptions{{Filter: "rootstock/device-1/cert"}}}, []byte{0x01})
+	h.OnSubscribed(cl, packets.Packet{Filters: packets.Subscriptions{{Filter: "rootstock/device-1/config"}}}, []byte{packets.ErrNotAuthorized.Code})
+
+	// One push for a SUBSCRIBE that covers the config topic twice
+	h.OnSubscribed(cl, packets.Packet{Filters: packets.Subscriptions{
+		{Filter: "rootstock/device-1/config"},
+		{Filter: "rootstock/device-1/#"},
+	}}, []byte{0x01, 0x01})
+
+	if len(pusher.pushed) != 1 || pusher.pushed[0] != "device-1" {
+		t.Errorf("pushed = %v, want [device-1]", pusher.pushed)
+	}
+}
+
+func TestMQTTConfigSyncHook_IgnoresInline(t *testing.T) {
+	pusher := &fakeConfigPusher{}
+	h := newConfigSyncHook(t, pusher)
+	srv := mochi.New(nil)
+	cl := srv.NewClient(nil, "local", "inline", true)
+
+	h.OnSubscribed(cl, packets.Packet{Filters: packets.Subscriptions{{Filter: "rootstock/inline/config"}}}, []byte{0x01})
+	if len(pusher.pushed) != 0 {
+		t.Errorf("pushed = %v, want none", pusher.pushed)
+	}
+}
+
+func TestCoversDeviceTopic(t *testing.T) {
+	tests := []struct {
+		filter string
+		leaf   string
+		want   bool
+	}{
+		{"rootstock/device-1/config", "config", true},
+		{"rootstock/device-1/#", "config", true},
+		{"rootstock/device-1/+", "config", true},
+		{"rootstock/device-1/config/ack", "config", false},
+		{"rootstock/device-2/config", "config", false},
+		{"rootstock/device-1/cert", "config", false},
+		{"rootstock/device-1/command", "command", true},
+		{"rootstock/device-1/#", "command", true},
+		{"rootstock/device-1/command/result", "command", false},
+		{"rootstock/device-1/config", "command", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filter+"~"+tt.leaf, func(t *testing.T) {
+			if got := coversDeviceTopic(tt.filter, "device-1", tt.leaf); got != tt.want {
+				t.Errorf("coversDeviceTopic(%q, %q) = %v, want %v", tt.filter, tt.leaf, got, tt.want)
+			}
+		})
+	}
+}
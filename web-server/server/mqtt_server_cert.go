@@ -0,0 +1,241 @@
+package server
+
+import (
+	"crypto"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// rotationRetryInterval is how long the rotating provider waits after a failed re-issue.
+const rotationRetryInterval = time.Minute
+
+// ServerCertProvider supplies the MQTT listener's certificate for each TLS handshake.
+// Swapping the certificate only affects new handshakes; established sessions keep theirs.
+type ServerCertProvider interface {
+	GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error)
+	Close()
+}
+
+// RotatingServerCert issues the broker's server certificate from the CA and re-issues
+// it renewBefore its expiry, in the background. A handshake that finds the certificate
+// past its renewal point (the process was suspended, say) re-issues it inline.
+type RotatingServerCert struct {
+	caCert      *x509.Certificate
+	caSigner    crypto.Signer
+	sans        []string
+	lifetime    time.Duration
+	renewBefore time.Duration
+
+	mu      sync.Mutex
+	cert    *tls.Certificate
+	renewAt time.Time
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewRotatingServerCert issues the first certificate and starts the rotation loop.
+func NewRotatingServerCert(caCert *x509.Certificate, caSigner crypto.Signer, sans []string, lifetime, renewBefore time.Duration) (*RotatingServerCert, error) {
+	if lifetime <= 0 {
+		return nil, fmt.Errorf("server cert lifetime must be positive")
+	}
+	if renewBefore <= 0 || renewBefore >= lifetime {
+		return nil, fmt.Errorf("server cert renew-before must be positive and shorter than the lifetime")
+	}
+
+	p := &RotatingServerCert{
+		caCert:      caCert,
+		caSigner:    caSigner,
+		sans:        sans,
+		lifetime:    lifetime,
+		renewBefore: renewBefore,
+		stop:        make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+	p.mu.Lock()
+	err := p.rotateLocked()
+	p.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	go p.run()
+	return p, nil
+}
+
+// GetCertificate implements tls.Config.GetCertificate.
+func (p *RotatingServerCert) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !time.Now().Before(p.renewAt) {
+		if err := p.rotateLocked(); err != nil {
+			// Keep serving the current certificate until it actually expires, and back off
+			// so every handshake does not retry the issue
+			slog.Warn("mqtt server cert: inline rotation failed", "error", err, "retry_in", rotationRetryInterval)
+			p.renewAt = time.Now().Add(rotationRetryInterval)
+		}
+	}
+	return p.cert, nil
+}
+
+// Close stops the rotation loop.
+func (p *RotatingServerCert) Close() {
+	close(p.stop)
+	<-p.done
+}
+
+func (p *RotatingServerCert) run() {
+	defer close(p.done)
+	for {
+		p.mu.Lock()
+		wait := time.Until(p.renewAt)
+		p.mu.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-p.stop:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		p.mu.Lock()
+		if !time.Now().Before(p.renewAt) {
+			if err := p.rotateLocked(); err != nil {
+				slog.Error("mqtt server cert: rotation failed, retrying", "error", err, "retry_in", rotationRetryInterval)
+				p.renewAt = time.Now().Add(rotationRetryInterval)
+			}
+		}
+		p.mu.Unlock()
+	}
+}
+
+// rotateLocked issues a new certificate. Callers hold p.mu.
+func (p *RotatingServerCert) rotateLocked() error {
+	cert, err := generateServerCert(p.caCert, p.caSigner, p.sans, p.lifetime)
+	if err != nil {
+		return err
+	}
+	p.cert = &cert
+	p.renewAt = cert.Leaf.NotAfter.Add(-p.renewBefore)
+	slog.Info("mqtt server cert: issued",
+		"serial", fmt.Sprintf("%x", cert.Leaf.SerialNumber),
+		"not_after", cert.Leaf.NotAfter,
+		"renew_at", p.renewAt)
+	return nil
+}
+
+// FileServerCert serves an operator-supplied certificate and key, reloading the pair
+// when either file changes. A pair that fails to load is logged and the previous
+// one kept, so a half-written rotation never takes the listener down.
+type FileServerCert struct {
+	certPath string
+	keyPath  string
+
+	mu   sync.RWMutex
+	cert *tls.Certificate
+
+	watcher *fsnotify.Watcher
+	done    chan struct{}
+}
+
+// NewFileServerCert loads the pair and starts watching the files' directories.
+// Directories, not files, are watched so atomic renames and symlink swaps
+// (Kubernetes secret volumes) are seen.
+func NewFileServerCert(certPath, keyPath string) (*FileServerCert, error) {
+	p := &FileServerCert{certPath: certPath, keyPath: keyPath, done: make(chan struct{})}
+	if err := p.reload(); err != nil {
+		return nil, err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("watch server cert: %w", err)
+	}
+	for _, dir := range uniqueDirs(certPath, keyPath) {
+		if err := watcher.Add(dir); err != nil {
+			watcher.Close()
+			return nil, fmt.Errorf("watch %s: %w", dir, err)
+		}
+	}
+	p.watcher = watcher
+
+	go p.watch()
+	return p, nil
+}
+
+// GetCertificate implements tls.Config.GetCertificate.
+func (p *FileServerCert) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.cert, nil
+}
+
+// Close stops watching the files.
+func (p *FileServerCert) Close() {
+	p.watcher.Close()
+	<-p.done
+}
+
+func (p *FileServerCert) watch() {
+	defer close(p.done)
+	for {
+		select {
+		case event, ok := <-p.watcher.Events:
+			if !ok {
+				return
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename|fsnotify.Remove) == 0 {
+				continue
+			}
+			if err := p.reload(); err != nil {
+				slog.Warn("mqtt server cert: reload failed, keeping previous certificate", "error", err)
+			}
+		case err, ok := <-p.watcher.Errors:
+			if !ok {
+				return
+			}
+			slog.Warn("mqtt server cert: watch error", "error", err)
+		}
+	}
+}
+
+// reload loads the pair from disk and swaps it in if it differs from the current one.
+func (p *FileServerCert) reload() error {
+	cert, err := tls.LoadX509KeyPair(p.certPath, p.keyPath)
+	if err != nil {
+		return fmt.Errorf("load server cert: %w", err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.cert != nil && cert.Leaf.Equal(p.cert.Leaf) {
+		return nil
+	}
+	p.cert = &cert
+	slog.Info("mqtt server cert: loaded",
+		"path", p.certPath,
+		"serial", fmt.Sprintf("%x", cert.Leaf.SerialNumber),
+		"not_after", cert.Leaf.NotAfter)
+	return nil
+}
+
+func uniqueDirs(paths ...string) []string {
+	seen := make(map[string]bool)
+	var dirs []string
+	for _, path := range paths {
+		dir := filepath.Dir(path)
+		if !seen[dir] {
+			seen[dir] = true
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
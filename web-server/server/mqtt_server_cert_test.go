@@ -0,0 +1,178 @@
+package server
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"rootstock/web-server/config"
+)
+
+func currentSerial(t *testing.T, p ServerCertProvider) string {
+	t.Helper()
+	cert, err := p.GetCertificate(&tls.ClientHelloInfo{})
+	if err != nil {
+		t.Fatalf("GetCertificate(): %v", err)
+	}
+	return cert.Leaf.SerialNumber.String()
+}
+
+func TestRotatingServerCert_RotatesBeforeExpiry(t *testing.T) {
+	caCert, caKey := writeTestCA(t, t.TempDir())
+
+	p, err := NewRotatingServerCert(caCert, caKey, []string{"localhost"}, 3*time.Second, 2*time.Second)
+	if err != nil {
+		t.Fatalf("NewRotatingServerCert(): %v", err)
+	}
+	defer p.Close()
+
+	first := currentSerial(t, p)
+
+	deadline := time.Now().Add(3 * time.Second)
+	for time.Now().Before(deadline) {
+		if currentSerial(t, p) != first {
+			return
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	t.Error("server cert was not rotated before its renewal point")
+}
+
+func TestRotatingServerCert_RotatesInlineWhenOverdue(t *testing.T) {
+	caCert, caKey := writeTestCA(t, t.TempDir())
+
+	p, err := NewRotatingServerCert(caCert, caKey, []string{"localhost"}, 24*time.Hour, 6*time.Hour)
+	if err != nil {
+		t.Fatalf("NewRotatingServerCert(): %v", err)
+	}
+	defer p.Close()
+
+	first := currentSerial(t, p)
+
+	// Simulate a missed timer, e.g. after the process was suspended
+	p.mu.Lock()
+	p.renewAt = time.Now().Add(-time.Second)
+	p.mu.Unlock()
+
+	if currentSerial(t, p) == first {
+		t.Error("overdue server cert should be re-issued on handshake")
+	}
+}
+
+// failingSigner refuses to sign, as an unreachable KMS or HSM would.
+type failingSigner struct{ crypto.Signer }
+
+func (failingSigner) Sign(io.Reader, []byte, crypto.SignerOpts) ([]byte, error) {
+	return nil, errors.New("signer unavailable")
+}
+
+func TestRotatingServerCert_BacksOffAfterInlineFailure(t *testing.T) {
+	caCert, caKey := writeTestCA(t, t.TempDir())
+
+	p, err := NewRotatingServerCert(caCert, caKey, []string{"localhost"}, 24*time.Hour, 6*time.Hour)
+	if err != nil {
+		t.Fatalf("NewRotatingServerCert(): %v", err)
+	}
+	defer p.Close()
+
+	first := currentSerial(t, p)
+
+	p.mu.Lock()
+	p.caSigner = failingSigner{caKey}
+	p.renewAt = time.Now().Add(-time.Second)
+	p.mu.Unlock()
+
+	if currentSerial(t, p) != first {
+		t.Error("failed rotation should keep serving the current cert")
+	}
+	p.mu.Lock()
+	renewAt := p.renewAt
+	p.mu.Unlock()
+	if !renewAt.After(time.Now().Add(rotationRetryInterval / 2)) {
+		t.Errorf("renewAt = %v after a failed rotation, want about %v from now", renewAt, rotationRetryInterval)
+	}
+}
+
+func TestRotatingServerCert_RejectsBadDurations(t *testing.T) {
+	caCert, caKey := writeTestCA(t, t.TempDir())
+
+	if _, err := NewRotatingServerCert(caCert, caKey, []string{"localhost"}, 0, time.Hour); err == nil {
+		t.Error("zero lifetime should be rejected")
+	}
+	if _, err := NewRotatingServerCert(caCert, caKey, []string{"localhost"}, time.Hour, time.Hour); err == nil {
+		t.Error("renew-before equal to lifetime should be rejected")
+	}
+}
+
+// writeServerPair issues a server cert from the CA and writes it and its key as PEM.
+func writeServerPair(t *testing.T, caCert *x509.Certificate, caKey *ecdsa.PrivateKey, certPath, keyPath string) string {
+	t.Helper()
+	cert, err := generateServerCert(caCert, caKey, []string{"localhost"}, time.Hour)
+	if err != nil {
+		t.Fatalf("generate server cert: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(cert.PrivateKey.(*ecdsa.PrivateKey))
+	if err != nil {
+		t.Fatalf("marshal key: %v", err)
+	}
+	// Key first, so a watcher that reloads on the cert write sees a matching pair
+	if err := os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600); err != nil {
+		t.Fatalf("write key: %v", err)
+	}
+	if err := os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Certificate[0]}), 0644); err != nil {
+		t.Fatalf("write cert: %v", err)
+	}
+	return cert.Leaf.SerialNumber.String()
+}
+
+func TestFileServerCert_ReloadsOnChange(t *testing.T) {
+	caCert, caKey := writeTestCA(t, t.TempDir())
+	dir := t.TempDir()
+	certPath, keyPath := filepath.Join(dir, "server.crt"), filepath.Join(dir, "server.key")
+
+	first := writeServerPair(t, caCert, caKey, certPath, keyPath)
+	p, err := NewFileServerCert(certPath, keyPath)
+	if err != nil {
+		t.Fatalf("NewFileServerCert(): %v", err)
+	}
+	defer p.Close()
+
+	if got := currentSerial(t, p); got != first {
+		t.Fatalf("serial = %s, want %s", got, first)
+	}
+
+	second := writeServerPair(t, caCert, caKey, certPath, keyPath)
+	deadline := time.Now().Add(2 * time.Second)
+	for currentSerial(t, p) != second {
+		if time.Now().After(deadline) {
+			t.Fatal("file-backed server cert was not reloaded after the files changed")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	// A broken write keeps the previous pair
+	if err := os.WriteFile(certPath, []byte("not a certificate"), 0644); err != nil {
+		t.Fatalf("write cert: %v", err)
+	}
+	time.Sleep(200 * time.Millisecond)
+	if got := currentSerial(t, p); got != second {
+		t.Errorf("serial = %s after bad write, want previous %s", got, second)
+	}
+}
+
+func TestNewServerCertProvider_RequiresCertAndKeyTogether(t *testing.T) {
+	caCert, caKey := writeTestCA(t, t.TempDir())
+
+	_, err := newServerCertProvider(config.MQTTConfig{ServerCertFile: "/tmp/server.crt"}, caCert, caKey)
+	if err == nil {
+		t.Error("cert file without key file should be rejected")
+	}
+}
@@ -0,0 +1,233 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	mochi "github.com/mochi-mqtt/server/v2"
+	"github.com/mochi-mqtt/server/v2/packets"
+
+	deviceflows "rootstock/web-server/flows/device"
+)
+
+const (
+	// sessionWriteTimeout bounds each session insert or update.
+	sessionWriteTimeout = 5 * time.Second
+	// sessionQueueSize is how many session events may wait for the database before
+	// new ones are dropped. Broker callbacks never block on Postgres.
+	sessionQueueSize = 1024
+)
+
+// Disconnect reasons recorded on a session, besides the broker's own error text.
+const (
+	DisconnectClient         = "client_disconnect"
+	DisconnectConnectionLost = "connection_lost"
+	DisconnectServerRestart  = "server_restart"
+)
+
+// SessionRecorder persists broker sessions.
+// Satisfied by *deviceflows.DeviceSessionFlow.
+type SessionRecorder interface {
+	RunOpen(ctx context.Context, input deviceflows.OpenSessionInput) (string, error)
+	RunClose(ctx context.Context, input deviceflows.CloseSessionInput) error
+}
+
+// sessionEvent is a connect (open), published will or disconnect waiting to be written.
+type sessionEvent struct {
+	cl    *mochi.Client
+	open  *deviceflows.OpenSessionInput
+	will  bool
+	close *deviceflows.CloseSessionInput
+}
+
+// MQTTSessionHook records each device's broker sessions: connect time, remote address,
+// certificate serial, and on disconnect the time and reason, including whether the
+// device left cleanly and whether the broker published its last will.
+//
+// Mochi publishes a will (OnWillSent) before it reports the disconnect, so the close
+// carries it. A will the device delayed (MQTT 5 Will Delay Interval) is published after
+// its session closed, and is not recorded.
+//
+// Sessions open in OnSessionEstablished, the first callback after authentication
+// succeeds, so refused connections never appear. Events are written in order by a
+// single worker, so a disconnect always finds its session. Sessions are keyed by
+// *mochi.Client rather than client ID: on session takeover the old connection's
+// disconnect can arrive after the new one has connected.
+type MQTTSessionHook struct {
+	mochi.HookBase
+	recorder SessionRecorder
+
+	mu      sync.Mutex // guards sends on events against Stop closing it
+	stopped bool
+	events  chan sessionEvent
+	done    chan struct{}
+}
+
+// MQTTSessionHookConfig holds configuration for the session hook.
+type MQTTSessionHookConfig struct {
+	Recorder SessionRecorder
+}
+
+func (h *MQTTSessionHook) ID() string {
+	return "mqtt-device-sessions"
+}
+
+func (h *MQTTSessionHook) Provides(b byte) bool {
+	return bytes.Contains([]byte{
+		mochi.OnSessionEstablished,
+		mochi.OnWillSent,
+		mochi.OnDisconnect,
+	}, []byte{b})
+}
+
+func (h *MQTTSessionHook) Init(config any) error {
+	cfg, ok := config.(*MQTTSessionHookConfig)
+	if !ok || cfg == nil || cfg.Recorder == nil {
+		return fmt.Errorf("mqtt session hook: recorder is required")
+	}
+	h.recorder = cfg.Recorder
+	h.events = make(chan sessionEvent, sessionQueueSize)
+	h.done = make(chan struct{})
+	go h.run()
+	return nil
+}
+
+// Stop drains queued events and stops the worker. Mochi calls it when the broker closes;
+// disconnects that race past it are dropped, and their sessions are closed as
+// DisconnectServerRestart on the next start.
+func (h *MQTTSessionHook) Stop() error {
+	h.mu.Lock()
+	if h.stopped {
+		h.mu.Unlock()
+		return nil
+	}
+	h.stopped = true
+	close(h.events)
+	h.mu.Unlock()
+	<-h.done
+	return nil
+}
+
+// OnSessionEstablished opens a session for an authenticated device.
+func (h *MQTTSessionHook) OnSessionEstablished(cl *mochi.Client, _ packets.Packet) {
+	if cl.Net.Inline {
+		return
+	}
+	h.enqueue(sessionEvent{cl: cl, open: &deviceflows.OpenSessionInput{
+		DeviceID:    cl.ID,
+		ConnectedAt: time.Now(),
+		RemoteAddr:  cl.Net.Remote,
+		CertSerial:  peerCertSerial(cl),
+	}})
+}
+
+// OnWillSent notes that the broker published the client's last will.
+func (h *MQTTSessionHook) OnWillSent(cl *mochi.Client, _ packets.Packet) {
+	if cl.Net.Inline {
+		return
+	}
+	h.enqueue(sessionEvent{cl: cl, will: true})
+}
+
+// OnDisconnect closes the client's session. err is nil when the device sent DISCONNECT.
+func (h *MQTTSessionHook) OnDisconnect(cl *mochi.Client, err error, _ bool) {
+	if cl.Net.Inline {
+		return
+	}
+	h.enqueue(sessionEvent{cl: cl, close: &deviceflows.CloseSessionInput{
+		DisconnectedAt: time.Now(),
+		Reason:         disconnectReason(err, cl.StopCause()),
+		Graceful:       err == nil,
+	}})
+}
+
+func (h *MQTTSessionHook) enqueue(ev sessionEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.stopped {
+		return
+	}
+	select {
+	case h.events <- ev:
+	default:
+		h.Log.Warn("mqtt sessions: queue full, dropping session event", "client", ev.cl.ID)
+	}
+}
+
+func (h *MQTTSessionHook) run() {
+	defer close(h.done)
+	open := make(map[*mochi.Client]string)
+	wills := make(map[*mochi.Client]bool) // open sessions whose will was published
+	for ev := range h.events {
+		ctx, cancel := context.WithTimeout(context.Background(), sessionWriteTimeout)
+		switch {
+		case ev.open != nil:
+			id, err := h.recorder.RunOpen(ctx, *ev.open)
+			if err != nil {
+				h.Log.Warn("mqtt sessions: open failed", "device_id", ev.open.DeviceID, "error", err)
+				break
+			}
+			open[ev.cl] = id
+		case ev.will:
+			// A delayed will arrives after its session closed
+			if _, ok := open[ev.cl]; ok {
+				wills[ev.cl] = true
+			}
+		case ev.close != nil:
+			// Nothing to close if the open failed or was dropped
+			id, ok := open[ev.cl]
+			if !ok {
+				break
+			}
+			delete(open, ev.cl)
+			ev.close.ID = id
+			ev.close.WillPublished = wills[ev.cl]
+			delete(wills, ev.cl)
+			if err := h.recorder.RunClose(ctx, *ev.close); err != nil {
+				h.Log.Warn("mqtt sessions: close failed", "device_id", ev.cl.ID, "error", err)
+			}
+		}
+		cancel()
+	}
+}
+
+// disconnectReason names why a client left. err is what ended the read loop; cause is the
+// reason the broker stopped the client, if it did (session takeover, keepalive timeout,
+// administrative action, shutdown), and wins over the read error it provoked.
+func disconnectReason(err, cause error) string {
+	if err == nil {
+		return DisconnectClient
+	}
+	if cause != nil {
+		err = cause
+	}
+	var code packets.Code
+	switch {
+	case errors.As(err, &code):
+		return code.Reason
+	case errors.Is(err, io.EOF), errors.Is(err, net.ErrClosed):
+		return DisconnectConnectionLost
+	default:
+		return err.Error()
+	}
+}
+
+// peerCertSerial returns the hex serial of the client's TLS certificate, or "".
+func peerCertSerial(cl *mochi.Client) string {
+	tlsConn, ok := cl.Net.Conn.(*tls.Conn)
+	if !ok {
+		return ""
+	}
+	state := tlsConn.ConnectionState()
+	if len(state.PeerCertificates) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%x", state.PeerCertificates[0].SerialNumber)
+}
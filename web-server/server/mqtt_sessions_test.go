@@ -0,0 +1,174 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+
+	mochi "github.com/mochi-mqtt/server/v2"
+	"github.com/mochi-mqtt/server/v2/packets"
+
+	deviceflows "rootstock/web-server/flows/device"
+)
+
+type fakeSessionRecorder struct {
+	mu     sync.Mutex
+	opened []deviceflows.OpenSessionInput
+	closed []deviceflows.CloseSessionInput
+}
+
+func (f *fakeSessionRecorder) RunOpen(_ context.Context, input deviceflows.OpenSessionInput) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.opened = append(f.opened, input)
+	return input.DeviceID + "-session", nil
+}
+
+func (f *fakeSessionRecorder) RunClose(_ context.Context, input deviceflows.CloseSessionInput) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.closed = append(f.closed, input)
+	return nil
+}
+
+func newSessionHook(t *testing.T, rec SessionRecorder) *MQTTSessionHook {
+	t.Helper()
+	h := &MQTTSessionHook{}
+	h.SetOpts(slog.Default(), &mochi.HookOptions{})
+	if err := h.Init(&MQTTSessionHookConfig{Recorder: rec}); err != nil {
+		t.Fatalf("Init(): %v", err)
+	}
+	return h
+}
+
+func TestMQTTSessionHook_OpensAndClosesInOrder(t *testing.T) {
+	rec := &fakeSessionRecorder{}
+	h := newSessionHook(t, rec)
+	srv := mochi.New(nil)
+
+	cl := srv.NewClient(nil, "mqtt-tls", "device-1", false)
+	cl.Net.Remote = "203.0.113.7:51234"
+	h.OnSessionEstablished(cl, packets.Packet{})
+	h.OnDisconnect(cl, io.EOF, true)
+
+	// A disconnect for a client whose session never opened is ignored
+	stray := srv.NewClient(nil, "mqtt-tls", "device-2", false)
+	h.OnDisconnect(stray, nil, true)
+
+	h.Stop()
+
+	if len(rec.opened) != 1 || rec.opened[0].DeviceID != "device-1" || rec.opened[0].RemoteAddr != "203.0.113.7:51234" {
+		t.Fatalf("opened = %+v, want one session for device-1", rec.opened)
+	}
+	if len(rec.closed) != 1 {
+		t.Fatalf("closed = %+v, want one", rec.closed)
+	}
+	if got := rec.closed[0]; got.ID != "device-1-session" || got.Graceful || got.Reason != DisconnectConnectionLost {
+		t.Errorf("close = %+v, want device-1-session, ungraceful, %s", got, DisconnectConnectionLost)
+	}
+}
+
+func TestMQTTSessionHook_RecordsPublishedWill(t *testing.T) {
+	rec := &fakeSessionRecorder{}
+	h := newSessionHook(t, rec)
+	srv := mochi.New(nil)
+
+	dropped := srv.NewClient(nil, "mqtt-tls", "device-1", false)
+	h.OnSessionEstablished(dropped, packets.Packet{})
+	h.OnWillSent(dropped, packets.Packet{})
+	h.OnDisconnect(dropped, io.EOF, false)
+
+	// Without a will, dropping the connection publishes nothing
+	noWill := srv.NewClient(nil, "mqtt-tls", "device-2", false)
+	h.OnSessionEstablished(noWill, packets.Packet{})
+	h.OnDisconnect(noWill, io.EOF, false)
+
+	// A delayed will is published after the session closed
+	h.OnWillSent(noWill, packets.Packet{})
+	h.Stop()
+
+	if len(rec.closed) != 2 {
+		t.Fatalf("closed = %+v, want two", rec.closed)
+	}
+	if !rec.closed[0].WillPublished {
+		t.Errorf("device-1 close = %+v, want will published", rec.closed[0])
+	}
+	if rec.closed[1].WillPublished {
+		t.Errorf("device-2 close = %+v, want no will published", rec.closed[1])
+	}
+}
+
+func TestMQTTSessionHook_SessionTakeover(t *testing.T) {
+	rec := &fakeSessionRecorder{}
+	h := newSessionHook(t, rec)
+	srv := mochi.New(nil)
+
+	// Same client ID; the old connection's disconnect arrives after the new one connects
+	old := srv.NewClient(nil, "mqtt-tls", "device-1", false)
+	h.OnSessionEstablished(old, packets.Packet{})
+	replacement := srv.NewClient(nil, "mqtt-tls", "device-1", false)
+	h.OnSessionEstablished(replacement, packets.Packet{})
+	h.OnDisconnect(old, packets.ErrSessionTakenOver, false)
+	h.Stop()
+
+	if len(rec.opened) != 2 || len(rec.closed) != 1 {
+		t.Fatalf("opened %d, closed %d; want 2 and 1", len(rec.opened), len(rec.closed))
+	}
+	if rec.closed[0].Reason != packets.ErrSessionTakenOver.Reason {
+		t.Errorf("reason = %q, want %q", rec.closed[0].Reason, packets.ErrSessionTakenOver.Reason)
+	}
+}
+
+func TestMQTTSessionHook_IgnoresInlineAndEventsAfterStop(t *testing.T) {
+	rec := &fakeSessionRecorder{}
+	h := newSessionHook(t, rec)
+	srv := mochi.New(nil)
+
+	inline := srv.NewClient(nil, "local", "inline", true)
+	h.OnSessionEstablished(inline, packets.Packet{})
+	h.Stop()
+
+	late := srv.NewClient(nil, "mqtt-tls", "device-1", false)
+	h.OnSessionEstablished(late, packets.Packet{}) // must not panic on the closed queue
+
+	if len(rec.opened) != 0 {
+		t.Errorf("opened = %+v, want none", rec.opened)
+	}
+}
+
+func TestDisconnectReason(t *testing.T) {
+	tests := []struct {
+		name  string
+		err   error
+		cause error
+		want  string
+	}{
+		{"clean disconnect", nil, nil, DisconnectClient},
+		{"connection dropped", io.EOF, io.EOF, DisconnectConnectionLost},
+		{"keepalive", io.EOF, packets.ErrKeepAliveTimeout, packets.ErrKeepAliveTimeout.Reason},
+		{"revoked by operator", errors.New("read: use of closed connection"), packets.ErrAdministrativeAction, packets.ErrAdministrativeAction.Reason},
+		{"other error", errors.New("tls: bad record MAC"), nil, "tls: bad record MAC"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := disconnectReason(tt.err, tt.cause); got != tt.want {
+				t.Errorf("disconnectReason() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMQTTSessionHook_Provides(t *testing.T) {
+	h := &MQTTSessionHook{}
+	for _, b := range []byte{mochi.OnSessionEstablished, mochi.OnWillSent, mochi.OnDisconnect} {
+		if !h.Provides(b) {
+			t.Errorf("Provides(%d) = false, want true", b)
+		}
+	}
+	if h.Provides(mochi.OnPublish) {
+		t.Error("Provides(OnPublish) = true, want false")
+	}
+}
@@ -3,6 +3,8 @@ package server
 import (
 	"context"
 	"encoding/json"
+	"encoding/pem"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -10,10 +12,13 @@ import (
 	mochi "github.com/mochi-mqtt/server/v2"
 	"github.com/mochi-mqtt/server/v2/packets"
 
+	"rootstock/web-server/config"
 	deviceflows "rootstock/web-server/flows/device"
 	readingflows "rootstock/web-server/flows/reading"
 	scoreflows "rootstock/web-server/flows/score"
+	securityflows "rootstock/web-server/flows/security"
 	"rootstock/web-server/global/observability"
+	"rootstock/web-server/ops/pure"
 	mqttrepo "rootstock/web-server/repo/mqtt"
 )
 
@@ -22,12 +27,130 @@ type MQTTFlows struct {
 	IngestReading        *readingflows.IngestReadingFlow
 	RenewCert            *deviceflows.RenewCertFlow
 	RefreshScitizenScore *scoreflows.RefreshScitizenScoreFlow
+	DeadLetter           *readingflows.DeadLetterFlow
+	RateLimitFlag        *securityflows.RateLimitFlagFlow
+	DeviceHealth         *deviceflows.DeviceHealthFlow
+	DeviceConfig         *deviceflows.DeviceConfigFlow
+	DeviceCommand        *deviceflows.DeviceCommandFlow
+	DeviceShadow         *deviceflows.DeviceShadowFlow
+	FirmwareOTA          *deviceflows.FirmwareOTAFlow
+	GatewayEnrollment    *deviceflows.GatewayEnrollmentFlow
+}
+
+// healthRateKey is the control channel heartbeats are rate limited on, per device.
+const healthRateKey = "health"
+
+// HealthPayload is the JSON heartbeat published on rootstock/{id}/health. Every metric
+// is optional; devices report what their hardware can measure. A missing timestamp
+// means "now".
+type HealthPayload struct {
+	Timestamp       time.Time `json:"timestamp"`
+	BatteryPercent  *float64  `json:"battery_percent,omitempty"`
+	RSSIDbm         *int      `json:"rssi_dbm,omitempty"`
+	UptimeSeconds   *int64    `json:"uptime_seconds,omitempty"`
+	FreeMemoryBytes *int64    `json:"free_memory_bytes,omitempty"`
+	SensorFaults    []string  `json:"sensor_faults,omitempty"`
+	FirmwareBuild   string    `json:"firmware_build,omitempty"`
+}
+
+// configAckRateKey is the control channel config acks are rate limited on, per device.
+const configAckRateKey = "config"
+
+// ConfigAckPayload is published by a device on rootstock/{id}/config/ack once it has
+// applied the config version it names.
+type ConfigAckPayload struct {
+	Version int64 `json:"version"`
+}
+
+// commandResultRateKey is the control channel command results are rate limited on, per device.
+const commandResultRateKey = "command"
+
+// CommandResultPayload is published by a device on rootstock/{id}/command/result: once
+// with status acknowledged when it accepts a command, then succeeded or failed.
+type CommandResultPayload struct {
+	CommandID string          `json:"command_id"`
+	Status    string          `json:"status"`
+	Result    json.RawMessage `json:"result,omitempty"`
+	Error     string          `json:"error,omitempty"`
+}
+
+// shadowRateKey is the control channel shadow gets and updates are rate limited on, per device.
+const shadowRateKey = "shadow"
+
+// ShadowUpdatePayload is published by a device on rootstock/{id}/shadow/update with the
+// state it is running. Omitted fields are left as last reported; an empty list is
+// reported as empty.
+type ShadowUpdatePayload struct {
+	Reported struct {
+		SamplingIntervalSeconds *int      `json:"sampling_interval_seconds"`
+		EnabledSensors          *[]string `json:"enabled_sensors"`
+		ActiveCampaigns         *[]string `json:"active_campaigns"`
+		FirmwareVersion         *string   `json:"firmware_version"`
+	} `json:"reported"`
+}
+
+// otaStatusRateKey is the control channel firmware install reports are rate limited on, per device.
+const otaStatusRateKey = "ota"
+
+// OTAStatusPayload is published by a device on rootstock/{id}/ota/status as it downloads
+// and installs a release: downloading, installing, then installed or failed.
+type OTAStatusPayload struct {
+	ReleaseID string `json:"release_id"`
+	Status    string `json:"status"`
+	Error     string `json:"error,omitempty"`
+}
+
+// childEnrollRateKey is the control channel child enrollments are rate limited on, per gateway.
+const childEnrollRateKey = "enroll"
+
+// ChildEnrollPayload is published by a gateway on rootstock/{id}/children/enroll to enroll
+// a child device: the child's enrollment code and the PEM CSR the child generated.
+type ChildEnrollPayload struct {
+	EnrollmentCode string `json:"enrollment_code"`
+	CSR            string `json:"csr"`
+}
+
+// ChildCertPayload answers a child enrollment on rootstock/{id}/children/cert, naming the
+// enrollment code it answers: the child's certificate, or why it was refused.
+type ChildCertPayload struct {
+	EnrollmentCode string `json:"enrollment_code"`
+	DeviceID       string `json:"device_id,omitempty"`
+	CertPEM        string `json:"cert_pem,omitempty"`
+	Serial         string `json:"serial,omitempty"`
+	NotBefore      string `json:"not_before,omitempty"`
+	NotAfter       string `json:"not_after,omitempty"`
+	Error          string `json:"error,omitempty"`
+}
+
+// Reasons a child enrollment is refused, as sent to the gateway. Fixed strings, so nothing
+// internal to the server reaches the device.
+const (
+	childEnrollNotGateway  = "device is not an active gateway"
+	childEnrollInvalidCode = "enrollment code not found, expired, already used, or not for a device of the gateway's owner"
+	childEnrollInvalidCSR  = "csr must be a PEM CERTIFICATE REQUEST signed by the child's key"
+	childEnrollBusy        = "server busy, retry later"
+	childEnrollFailed      = "enrollment failed"
+)
+
+// childEnrollRefusal is the reason sent to a gateway for a refused child enrollment.
+func childEnrollRefusal(err error) string {
+	switch {
+	case errors.Is(err, deviceflows.ErrNotGateway):
+		return childEnrollNotGateway
+	case errors.Is(err, deviceflows.ErrInvalidChildCode):
+		return childEnrollInvalidCode
+	case errors.Is(err, deviceflows.ErrInvalidChildCSR):
+		return childEnrollInvalidCSR
+	}
+	return childEnrollFailed
 }
 
 // ReadingPayload is the JSON payload published by devices on telemetry topics.
 // Supports multi-value format: {"values": {"PM2.5": 23.5, "temp": 22.1}, ...}
 // Backward compat: if "values" is nil but "value" is set, converts to {"value": <value>}.
+// MessageID is optional; devices that send it get exact redelivery detection.
 type ReadingPayload struct {
+	MessageID       string             `json:"message_id,omitempty"`
 	Values          map[string]float64 `json:"values,omitempty"`
 	Value           *float64           `json:"value,omitempty"`
 	Timestamp       time.Time          `json:"timestamp"`
@@ -47,12 +170,170 @@ func (p *ReadingPayload) ResolvedValues() map[string]float64 {
 	return map[string]float64{}
 }
 
+// BatchPayload is published on rootstock/{device}/data-batch/{campaign} by devices
+// uploading readings they buffered while offline (store-and-forward).
+type BatchPayload struct {
+	Readings []ReadingPayload `json:"readings"`
+}
+
+// ReadingAck is published on rootstock/{device}/ack after every telemetry message.
+// Reason and Action are owner-facing and never carry validation thresholds (FR-125).
+// For a duplicate, ReadingID is the reading first stored for that message.
+type ReadingAck struct {
+	ReadingID             string    `json:"reading_id,omitempty"`
+	MessageID             string    `json:"message_id,omitempty"`
+	CampaignID            string    `json:"campaign_id"`
+	Timestamp             time.Time `json:"timestamp"`
+	Status                string    `json:"status"` // accepted | quarantined | duplicate | rejected | error
+	Code                  string    `json:"code"`
+	Reason                string    `json:"reason"`
+	Action                string    `json:"action,omitempty"`
+	QuarantinedParameters []string  `json:"quarantined_parameters,omitempty"`
+	MissingParameters     []string  `json:"missing_parameters,omitempty"`
+}
+
+// BatchItemAck is the result for one reading in a batch upload; Index is its position in the payload.
+type BatchItemAck struct {
+	Index int `json:"index"`
+	ReadingAck
+}
+
+// BatchAck is published on rootstock/{device}/ack after every batch upload.
+// Status is "rejected" or "error" only when no reading in the batch was processed;
+// otherwise each item carries its own outcome.
+type BatchAck struct {
+	CampaignID  string         `json:"campaign_id"`
+	Status      string         `json:"status"` // accepted | rejected | error
+	Code        string         `json:"code"`
+	Reason      string         `json:"reason"`
+	Action      string         `json:"action,omitempty"`
+	Accepted    int            `json:"accepted"`
+	Quarantined int            `json:"quarantined"`
+	Rejected    int            `json:"rejected"`
+	Duplicates  int            `json:"duplicates"`
+	Results     []BatchItemAck `json:"results,omitempty"`
+}
+
+// newReadingAck builds the device acknowledgement for an ingested reading.
+func newReadingAck(rd *readingflows.Reading) ReadingAck {
+	code := rd.FeedbackCode
+	if code == "" {
+		code = pure.FeedbackAccepted
+	}
+	fb := pure.FeedbackFor(code)
+	ack := ReadingAck{
+		ReadingID:  rd.ID,
+		MessageID:  rd.MessageID,
+		CampaignID: rd.CampaignID,
+		Timestamp:  rd.Timestamp,
+		Status:     rd.Status,
+		Code:       fb.Code,
+		Reason:     fb.Message,
+		Action:     fb.Action,
+
+		MissingParameters: rd.MissingParameters,
+	}
+	for _, v := range rd.Values {
+		if v.Status == "quarantined" {
+			ack.QuarantinedParameters = append(ack.QuarantinedParameters, v.ParameterName)
+		}
+	}
+	return ack
+}
+
+// newFailureAck builds an acknowledgement for a message that never produced a reading.
+func newFailureAck(campaignID string, timestamp time.Time, status string, code string) ReadingAck {
+	fb := pure.FeedbackFor(code)
+	return ReadingAck{
+		CampaignID: campaignID,
+		Timestamp:  timestamp,
+		Status:     status,
+		Code:       fb.Code,
+		Reason:     fb.Message,
+		Action:     fb.Action,
+	}
+}
+
+// newBatchAck builds the device acknowledgement for an ingested batch.
+// When every reading was rejected the batch status and code come from the first item.
+func newBatchAck(campaignID string, result *readingflows.BatchResult) BatchAck {
+	ack := BatchAck{
+		CampaignID:  campaignID,
+		Status:      "accepted",
+		Accepted:    result.Accepted,
+		Quarantined: result.Quarantined,
+		Rejected:    result.Rejected,
+		Duplicates:  result.Duplicates,
+		Results:     make([]BatchItemAck, len(result.Readings)),
+	}
+	for i := range result.Readings {
+		ack.Results[i] = BatchItemAck{Index: i, ReadingAck: newReadingAck(&result.Readings[i])}
+	}
+
+	code := pure.FeedbackAccepted
+	if len(result.Readings) > 0 && result.Rejected == len(result.Readings) {
+		ack.Status = "rejected"
+		code = ack.Results[0].Code
+	}
+	fb := pure.FeedbackFor(code)
+	ack.Code = fb.Code
+	ack.Reason = fb.Message
+	ack.Action = fb.Action
+	return ack
+}
+
+// newBatchFailureAck builds an acknowledgement for a batch that was not processed at all.
+func newBatchFailureAck(campaignID string, status string, code string) BatchAck {
+	fb := pure.FeedbackFor(code)
+	return BatchAck{
+		CampaignID: campaignID,
+		Status:     status,
+		Code:       fb.Code,
+		Reason:     fb.Message,
+		Action:     fb.Action,
+	}
+}
+
 // SetupMQTTSubscriptions registers inline subscriptions on the embedded broker
 // that route MQTT messages to the appropriate flows. Call after all flows are
-// constructed but before server.Serve().
-func SetupMQTTSubscriptions(ctx context.Context, server *mochi.Server, flows *MQTTFlows) error {
+// constructed but before server.Serve(). Telemetry callbacks only rate-limit and parse the
+// payload; ingestion runs on the pipeline so the broker's delivery path never waits on storage.
+func SetupMQTTSubscriptions(ctx context.Context, server *mochi.Server, flows *MQTTFlows, cfg config.MQTTConfig, pipeline *IngestPipeline, limiter *IngestRateLimiter) error {
 	logger := observability.GetLogger("mqtt-subscriptions")
 
+	publishAck := func(deviceID string, ack any) {
+		body, err := json.Marshal(ack)
+		if err != nil {
+			logger.Error(ctx, "telemetry: marshal ack failed", map[string]interface{}{
+				"device_id": deviceID,
+				"error":     err.Error(),
+			})
+			return
+		}
+		ackTopic := fmt.Sprintf("%s/%s/ack", mqttrepo.TopicPrefix, deviceID)
+		if err := server.Publish(ackTopic, body, false, 1); err != nil {
+			logger.Error(ctx, "telemetry: publish ack failed", map[string]interface{}{
+				"device_id": deviceID,
+				"error":     err.Error(),
+			})
+		}
+	}
+
+	ingest := newTelemetryIngester(flows, cfg, pipeline, limiter, logger, observability.GetMeter("telemetry-ingest"))
+
+	// controlAllowed applies the device's control channel limit, apart from its readings'.
+	controlAllowed := func(deviceID, channel, topic string) bool {
+		if limiter.AllowControl(ctx, deviceID, channel) {
+			return true
+		}
+		logger.Warn(ctx, "control: rate limited", map[string]interface{}{
+			"device_id": deviceID,
+			"channel":   channel,
+			"topic":     topic,
+		})
+		return false
+	}
+
 	// Telemetry: rootstock/+/data/+
 	telemetryTopic := fmt.Sprintf("%s/+/data/+", mqttrepo.TopicPrefix)
 	if err := server.Subscribe(telemetryTopic, 1, func(cl *mochi.Client, sub packets.Subscription, pk packets.Packet) {
@@ -64,89 +345,498 @@ func SetupMQTTSubscriptions(ctx context.Context, server *mochi.Server, flows *MQ
 			return
 		}
 		deviceID := segments[1]
-		campaignID := segments[3]
+		ingest.reading(ctx, pk.TopicName, deviceID, segments[3], cl.Net.Inline, pk.Payload, func(ack ReadingAck) { publishAck(deviceID, ack) })
+	}); err != nil {
+		return fmt.Errorf("subscribe telemetry: %w", err)
+	}
+
+	// Store-and-forward batches: rootstock/+/data-batch/+
+	batchTopic := fmt.Sprintf("%s/+/data-batch/+", mqttrepo.TopicPrefix)
+	if err := server.Subscribe(batchTopic, 1, func(cl *mochi.Client, sub packets.Subscription, pk packets.Packet) {
+		segments := strings.Split(pk.TopicName, "/")
+		if len(segments) < 4 {
+			logger.Error(ctx, "batch: unexpected topic format", map[string]interface{}{
+				"topic": pk.TopicName,
+			})
+			return
+		}
+		deviceID := segments[1]
+		ingest.batch(ctx, pk.TopicName, deviceID, segments[3], cl.Net.Inline, pk.Payload, func(ack BatchAck) { publishAck(deviceID, ack) })
+	}); err != nil {
+		return fmt.Errorf("subscribe batch: %w", err)
+	}
+
+	// Renewal: rootstock/+/renew
+	renewTopic := fmt.Sprintf("%s/+/renew", mqttrepo.TopicPrefix)
+	if err := server.Subscribe(renewTopic, 1, func(cl *mochi.Client, sub packets.Subscription, pk packets.Packet) {
+		segments := strings.Split(pk.TopicName, "/")
+		if len(segments) < 3 {
+			logger.Error(ctx, "renew: unexpected topic format", map[string]interface{}{
+				"topic": pk.TopicName,
+			})
+			return
+		}
+		deviceID := segments[1]
+
+		result, err := flows.RenewCert.Run(ctx, deviceflows.RenewCertInput{
+			DeviceID: deviceID,
+			CSR:      pk.Payload,
+		})
+		if err != nil {
+			logger.Error(ctx, "renew: certificate renewal failed", map[string]interface{}{
+				"device_id": deviceID,
+				"error":     err.Error(),
+			})
+			return
+		}
+
+		certTopic := fmt.Sprintf("%s/%s/cert", mqttrepo.TopicPrefix, deviceID)
+		if err := server.Publish(certTopic, result.CertPEM, false, 1); err != nil {
+			logger.Error(ctx, "renew: publish cert response failed", map[string]interface{}{
+				"device_id": deviceID,
+				"error":     err.Error(),
+			})
+		}
+	}); err != nil {
+		return fmt.Errorf("subscribe renew: %w", err)
+	}
+
+	// Child enrollment: rootstock/+/children/enroll, answered on rootstock/{id}/children/cert.
+	// Only gateways may enroll children; the flow refuses other devices.
+	childEnrollTopic := fmt.Sprintf("%s/+/children/enroll", mqttrepo.TopicPrefix)
+	if err := server.Subscribe(childEnrollTopic, 1, func(cl *mochi.Client, sub packets.Subscription, pk packets.Packet) {
+		segments := strings.Split(pk.TopicName, "/")
+		if len(segments) < 4 {
+			logger.Error(ctx, "child enroll: unexpected topic format", map[string]interface{}{
+				"topic": pk.TopicName,
+			})
+			return
+		}
+		gatewayID := segments[1]
+
+		if !controlAllowed(gatewayID, childEnrollRateKey, pk.TopicName) {
+			return
+		}
+
+		var payload ChildEnrollPayload
+		if err := json.Unmarshal(pk.Payload, &payload); err != nil {
+			logger.Warn(ctx, "child enroll: invalid payload", map[string]interface{}{
+				"gateway_id": gatewayID,
+				"error":      err.Error(),
+			})
+			return
+		}
+
+		certTopic := fmt.Sprintf("%s/%s/children/cert", mqttrepo.TopicPrefix, gatewayID)
+		answer := func(answer ChildCertPayload) {
+			body, err := json.Marshal(answer)
+			if err != nil {
+				logger.Error(ctx, "child enroll: marshal cert response failed", map[string]interface{}{
+					"gateway_id": gatewayID,
+					"error":      err.Error(),
+				})
+				return
+			}
+			if err := server.Publish(certTopic, body, false, 1); err != nil {
+				logger.Error(ctx, "child enroll: publish cert response failed", map[string]interface{}{
+					"gateway_id": gatewayID,
+					"error":      err.Error(),
+				})
+			}
+		}
+
+		block, _ := pem.Decode([]byte(payload.CSR))
+		if block == nil || block.Type != "CERTIFICATE REQUEST" {
+			answer(ChildCertPayload{EnrollmentCode: payload.EnrollmentCode, Error: childEnrollInvalidCSR})
+			return
+		}
+
+		// Issuing a certificate touches the CA and the database, so it runs off the broker's delivery path
+		submitted := pipeline.Submit(gatewayID, func(ctx context.Context) {
+			result, err := flows.GatewayEnrollment.Run(ctx, deviceflows.EnrollChildInput{
+				GatewayID:      gatewayID,
+				EnrollmentCode: payload.EnrollmentCode,
+				CSR:            block.Bytes,
+			})
+			if err != nil {
+				logger.Warn(ctx, "child enroll: enrollment refused", map[string]interface{}{
+					"gateway_id": gatewayID,
+					"error":      err.Error(),
+				})
+				answer(ChildCertPayload{EnrollmentCode: payload.EnrollmentCode, Error: childEnrollRefusal(err)})
+				return
+			}
+			answer(ChildCertPayload{
+				EnrollmentCode: payload.EnrollmentCode,
+				DeviceID:       result.DeviceID,
+				CertPEM:        string(result.CertPEM),
+				Serial:         result.Serial,
+				NotBefore:      result.NotBefore.Format(time.RFC3339),
+				NotAfter:       result.NotAfter.Format(time.RFC3339),
+			})
+		})
+		if !submitted {
+			logger.Warn(ctx, "child enroll: ingest pipeline shed enrollment", map[string]interface{}{
+				"gateway_id": gatewayID,
+			})
+			answer(ChildCertPayload{EnrollmentCode: payload.EnrollmentCode, Error: childEnrollBusy})
+		}
+	}); err != nil {
+		return fmt.Errorf("subscribe child enroll: %w", err)
+	}
+
+	// Health: rootstock/+/health. Stored apart from readings; not acknowledged.
+	healthTopic := fmt.Sprintf("%s/+/health", mqttrepo.TopicPrefix)
+	if err := server.Subscribe(healthTopic, 1, func(cl *mochi.Client, sub packets.Subscription, pk packets.Packet) {
+		segments := strings.Split(pk.TopicName, "/")
+		if len(segments) < 3 {
+			logger.Error(ctx, "health: unexpected topic format", map[string]interface{}{
+				"topic": pk.TopicName,
+			})
+			return
+		}
+		deviceID := segments[1]
+
+		if !controlAllowed(deviceID, healthRateKey, pk.TopicName) {
+			return
+		}
 
-		var payload ReadingPayload
+		var payload HealthPayload
 		if err := json.Unmarshal(pk.Payload, &payload); err != nil {
-			logger.Error(ctx, "telemetry: invalid payload JSON", map[string]interface{}{
+			logger.Warn(ctx, "health: invalid payload JSON", map[string]interface{}{
 				"device_id": deviceID,
 				"error":     err.Error(),
 			})
 			return
 		}
 
-		input := readingflows.IngestReadingInput{
+		input := deviceflows.ReportHealthInput{
 			DeviceID:        deviceID,
-			CampaignID:      campaignID,
-			Values:          payload.ResolvedValues(),
-			Timestamp:       payload.Timestamp,
-			Geolocation:     payload.Geolocation,
-			FirmwareVersion: payload.FirmwareVersion,
-			CertSerial:      payload.CertSerial,
+			ReportedAt:      payload.Timestamp,
+			BatteryPercent:  payload.BatteryPercent,
+			RSSIDbm:         payload.RSSIDbm,
+			UptimeSeconds:   payload.UptimeSeconds,
+			FreeMemoryBytes: payload.FreeMemoryBytes,
+			SensorFaults:    payload.SensorFaults,
+		}
+		if payload.FirmwareBuild != "" {
+			input.FirmwareBuild = &payload.FirmwareBuild
 		}
 
-		result, err := flows.IngestReading.Run(ctx, input)
-		if err != nil {
-			logger.Error(ctx, "telemetry: ingest reading failed", map[string]interface{}{
-				"device_id":   deviceID,
-				"campaign_id": campaignID,
-				"error":       err.Error(),
+		// Sharded by device so one device's heartbeats are stored in order
+		submitted := pipeline.Submit(deviceID, func(ctx context.Context) {
+			result, err := flows.DeviceHealth.RunReport(ctx, input)
+			if err != nil {
+				logger.Error(ctx, "health: record heartbeat failed", map[string]interface{}{
+					"device_id": deviceID,
+					"error":     err.Error(),
+				})
+				return
+			}
+			if !result.Accepted {
+				logger.Warn(ctx, "health: heartbeat refused", map[string]interface{}{
+					"device_id": deviceID,
+					"reason":    result.Reason,
+				})
+			}
+		})
+		if !submitted {
+			logger.Warn(ctx, "health: ingest pipeline shed heartbeat", map[string]interface{}{
+				"device_id": deviceID,
+			})
+		}
+	}); err != nil {
+		return fmt.Errorf("subscribe health: %w", err)
+	}
+
+	// Config acks: rootstock/+/config/ack
+	configAckTopic := fmt.Sprintf("%s/+/config/ack", mqttrepo.TopicPrefix)
+	if err := server.Subscribe(configAckTopic, 1, func(cl *mochi.Client, sub packets.Subscription, pk packets.Packet) {
+		segments := strings.Split(pk.TopicName, "/")
+		if len(segments) < 4 {
+			logger.Error(ctx, "config ack: unexpected topic format", map[string]interface{}{
+				"topic": pk.TopicName,
+			})
+			return
+		}
+		deviceID := segments[1]
+
+		if !controlAllowed(deviceID, configAckRateKey, pk.TopicName) {
+			return
+		}
+
+		var payload ConfigAckPayload
+		if err := json.Unmarshal(pk.Payload, &payload); err != nil {
+			logger.Warn(ctx, "config ack: invalid payload JSON", map[string]interface{}{
+				"device_id": deviceID,
+				"error":     err.Error(),
 			})
 			return
 		}
 
-		if result.Status == "accepted" {
-			if _, err := flows.RefreshScitizenScore.Run(ctx, scoreflows.RefreshScitizenScoreInput{
+		// Sharded by device, like the push on subscribe it may answer
+		submitted := pipeline.Submit(deviceID, func(ctx context.Context) {
+			accepted, err := flows.DeviceConfig.RunAck(ctx, deviceflows.AckConfigInput{
 				DeviceID: deviceID,
-			}); err != nil {
-				logger.Error(ctx, "telemetry: refresh scitizen score failed", map[string]interface{}{
+				Version:  payload.Version,
+			})
+			if err != nil {
+				logger.Error(ctx, "config ack: record failed", map[string]interface{}{
 					"device_id": deviceID,
+					"version":   payload.Version,
 					"error":     err.Error(),
 				})
+				return
 			}
+			if !accepted {
+				logger.Warn(ctx, "config ack: ignored stale or unknown version", map[string]interface{}{
+					"device_id": deviceID,
+					"version":   payload.Version,
+				})
+			}
+		})
+		if !submitted {
+			logger.Warn(ctx, "config ack: ingest pipeline shed ack", map[string]interface{}{
+				"device_id": deviceID,
+			})
 		}
 	}); err != nil {
-		return fmt.Errorf("subscribe telemetry: %w", err)
+		return fmt.Errorf("subscribe config ack: %w", err)
 	}
 
-	// Renewal: rootstock/+/renew
-	renewTopic := fmt.Sprintf("%s/+/renew", mqttrepo.TopicPrefix)
-	if err := server.Subscribe(renewTopic, 1, func(cl *mochi.Client, sub packets.Subscription, pk packets.Packet) {
+	// Command results: rootstock/+/command/result
+	commandResultTopic := fmt.Sprintf("%s/+/command/result", mqttrepo.TopicPrefix)
+	if err := server.Subscribe(commandResultTopic, 1, func(cl *mochi.Client, sub packets.Subscription, pk packets.Packet) {
 		segments := strings.Split(pk.TopicName, "/")
-		if len(segments) < 3 {
-			logger.Error(ctx, "renew: unexpected topic format", map[string]interface{}{
+		if len(segments) < 4 {
+			logger.Error(ctx, "command result: unexpected topic format", map[string]interface{}{
 				"topic": pk.TopicName,
 			})
 			return
 		}
 		deviceID := segments[1]
 
-		result, err := flows.RenewCert.Run(ctx, deviceflows.RenewCertInput{
-			DeviceID: deviceID,
-			CSR:      pk.Payload,
+		if !controlAllowed(deviceID, commandResultRateKey, pk.TopicName) {
+			return
+		}
+
+		var payload CommandResultPayload
+		if err := json.Unmarshal(pk.Payload, &payload); err != nil {
+			logger.Warn(ctx, "command result: invalid payload JSON", map[string]interface{}{
+				"device_id": deviceID,
+				"error":     err.Error(),
+			})
+			return
+		}
+
+		// Sharded by device so an acknowledgement is recorded before the outcome that follows it
+		submitted := pipeline.Submit(deviceID, func(ctx context.Context) {
+			result, err := flows.DeviceCommand.RunResult(ctx, deviceflows.CommandResultInput{
+				DeviceID:  deviceID,
+				CommandID: payload.CommandID,
+				Status:    payload.Status,
+				Result:    payload.Result,
+				Error:     payload.Error,
+			})
+			if err != nil {
+				logger.Error(ctx, "command result: record failed", map[string]interface{}{
+					"device_id":  deviceID,
+					"command_id": payload.CommandID,
+					"error":      err.Error(),
+				})
+				return
+			}
+			if !result.Accepted {
+				logger.Warn(ctx, "command result: refused", map[string]interface{}{
+					"device_id":  deviceID,
+					"command_id": payload.CommandID,
+					"reason":     result.Reason,
+				})
+			}
 		})
-		if err != nil {
-			logger.Error(ctx, "renew: certificate renewal failed", map[string]interface{}{
+		if !submitted {
+			logger.Warn(ctx, "command result: ingest pipeline shed result", map[string]interface{}{
+				"device_id": deviceID,
+			})
+		}
+	}); err != nil {
+		return fmt.Errorf("subscribe command result: %w", err)
+	}
+
+	// Shadow gets: rootstock/+/shadow/get, answered on rootstock/{id}/shadow/get/accepted
+	shadowGetTopic := fmt.Sprintf("%s/+/shadow/get", mqttrepo.TopicPrefix)
+	if err := server.Subscribe(shadowGetTopic, 1, func(cl *mochi.Client, sub packets.Subscription, pk packets.Packet) {
+		segments := strings.Split(pk.TopicName, "/")
+		if len(segments) < 4 {
+			logger.Error(ctx, "shadow get: unexpected topic format", map[string]interface{}{
+				"topic": pk.TopicName,
+			})
+			return
+		}
+		deviceID := segments[1]
+
+		if !controlAllowed(deviceID, shadowRateKey, pk.TopicName) {
+			return
+		}
+
+		// Sharded by device so a get issued after an update sees it
+		submitted := pipeline.Submit(deviceID, func(ctx context.Context) {
+			if err := flows.DeviceShadow.RunGet(ctx, deviceID); err != nil {
+				logger.Error(ctx, "shadow get: publish failed", map[string]interface{}{
+					"device_id": deviceID,
+					"error":     err.Error(),
+				})
+			}
+		})
+		if !submitted {
+			logger.Warn(ctx, "shadow get: ingest pipeline shed get", map[string]interface{}{
+				"device_id": deviceID,
+			})
+		}
+	}); err != nil {
+		return fmt.Errorf("subscribe shadow get: %w", err)
+	}
+
+	// Shadow updates: rootstock/+/shadow/update
+	shadowUpdateTopic := fmt.Sprintf("%s/+/shadow/update", mqttrepo.TopicPrefix)
+	if err := server.Subscribe(shadowUpdateTopic, 1, func(cl *mochi.Client, sub packets.Subscription, pk packets.Packet) {
+		segments := strings.Split(pk.TopicName, "/")
+		if len(segments) < 4 {
+			logger.Error(ctx, "shadow update: unexpected topic format", map[string]interface{}{
+				"topic": pk.TopicName,
+			})
+			return
+		}
+		deviceID := segments[1]
+
+		if !controlAllowed(deviceID, shadowRateKey, pk.TopicName) {
+			return
+		}
+
+		var payload ShadowUpdatePayload
+		if err := json.Unmarshal(pk.Payload, &payload); err != nil {
+			logger.Warn(ctx, "shadow update: invalid payload JSON", map[string]interface{}{
 				"device_id": deviceID,
 				"error":     err.Error(),
 			})
 			return
 		}
+		reported := deviceflows.ShadowState{
+			SamplingIntervalSeconds: payload.Reported.SamplingIntervalSeconds,
+			FirmwareVersion:         payload.Reported.FirmwareVersion,
+		}
+		if payload.Reported.EnabledSensors != nil {
+			reported.EnabledSensors = append([]string{}, *payload.Reported.EnabledSensors...)
+		}
+		if payload.Reported.ActiveCampaigns != nil {
+			reported.ActiveCampaigns = append([]string{}, *payload.Reported.ActiveCampaigns...)
+		}
 
-		certTopic := fmt.Sprintf("%s/%s/cert", mqttrepo.TopicPrefix, deviceID)
-		if err := server.Publish(certTopic, result.CertPEM, false, 1); err != nil {
-			logger.Error(ctx, "renew: publish cert response failed", map[string]interface{}{
+		// Sharded by device so updates apply in the order they were published
+		submitted := pipeline.Submit(deviceID, func(ctx context.Context) {
+			result, err := flows.DeviceShadow.RunReport(ctx, deviceflows.ReportShadowInput{
+				DeviceID: deviceID,
+				Reported: reported,
+			})
+			if err != nil {
+				logger.Error(ctx, "shadow update: record failed", map[string]interface{}{
+					"device_id": deviceID,
+					"error":     err.Error(),
+				})
+				return
+			}
+			if !result.Accepted {
+				logger.Warn(ctx, "shadow update: refused", map[string]interface{}{
+					"device_id": deviceID,
+					"reason":    result.Reason,
+				})
+			}
+		})
+		if !submitted {
+			logger.Warn(ctx, "shadow update: ingest pipeline shed update", map[string]interface{}{
+				"device_id": deviceID,
+			})
+		}
+	}); err != nil {
+		return fmt.Errorf("subscribe shadow update: %w", err)
+	}
+
+	// Firmware install reports: rootstock/+/ota/status
+	otaStatusTopic := fmt.Sprintf("%s/+/ota/status", mqttrepo.TopicPrefix)
+	if err := server.Subscribe(otaStatusTopic, 1, func(cl *mochi.Client, sub packets.Subscription, pk packets.Packet) {
+		segments := strings.Split(pk.TopicName, "/")
+		if len(segments) < 4 {
+			logger.Error(ctx, "ota status: unexpected topic format", map[string]interface{}{
+				"topic": pk.TopicName,
+			})
+			return
+		}
+		deviceID := segments[1]
+
+		if !controlAllowed(deviceID, otaStatusRateKey, pk.TopicName) {
+			return
+		}
+
+		var payload OTAStatusPayload
+		if err := json.Unmarshal(pk.Payload, &payload); err != nil {
+			logger.Warn(ctx, "ota status: invalid payload JSON", map[string]interface{}{
 				"device_id": deviceID,
 				"error":     err.Error(),
 			})
+			return
+		}
+
+		// Sharded by device so progress is recorded in the order it was reported
+		submitted := pipeline.Submit(deviceID, func(ctx context.Context) {
+			result, err := flows.FirmwareOTA.RunInstallReport(ctx, deviceflows.FirmwareInstallReportInput{
+				DeviceID:  deviceID,
+				ReleaseID: payload.ReleaseID,
+				Status:    payload.Status,
+				Error:     payload.Error,
+			})
+			if err != nil {
+				logger.Error(ctx, "ota status: record failed", map[string]interface{}{
+					"device_id":  deviceID,
+					"release_id": payload.ReleaseID,
+					"error":      err.Error(),
+				})
+				return
+			}
+			if !result.Accepted {
+				logger.Warn(ctx, "ota status: refused", map[string]interface{}{
+					"device_id":  deviceID,
+					"release_id": payload.ReleaseID,
+					"reason":     result.Reason,
+				})
+				return
+			}
+			if result.Reinstated {
+				logger.Info(ctx, "ota status: patched device reinstated", map[string]interface{}{
+					"device_id":  deviceID,
+					"release_id": payload.ReleaseID,
+				})
+			}
+		})
+		if !submitted {
+			logger.Warn(ctx, "ota status: ingest pipeline shed report", map[string]interface{}{
+				"device_id": deviceID,
+			})
 		}
 	}); err != nil {
-		return fmt.Errorf("subscribe renew: %w", err)
+		return fmt.Errorf("subscribe ota status: %w", err)
 	}
 
 	logger.Info(ctx, "mqtt subscriptions registered", map[string]interface{}{
-		"telemetry": telemetryTopic,
-		"renew":     renewTopic,
+		"telemetry":      telemetryTopic,
+		"batch":          batchTopic,
+		"renew":          renewTopic,
+		"child_enroll":   childEnrollTopic,
+		"health":         healthTopic,
+		"config_ack":     configAckTopic,
+		"command_result": commandResultTopic,
+		"shadow_get":     shadowGetTopic,
+		"shadow_update":  shadowUpdateTopic,
+		"ota_status":     otaStatusTopic,
 	})
 
 	return nil
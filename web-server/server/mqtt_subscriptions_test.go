@@ -0,0 +1,135 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	deviceflows "rootstock/web-server/flows/device"
+	readingflows "rootstock/web-server/flows/reading"
+	"rootstock/web-server/ops/pure"
+)
+
+func TestNewReadingAck_MissingParameters(t *testing.T) {
+	ack := newReadingAck(&readingflows.Reading{
+		ID:                "reading-1",
+		Status:            "quarantined",
+		FeedbackCode:      pure.FeedbackMissingParameters,
+		MissingParameters: []string{"humidity"},
+	})
+	if ack.Code != pure.FeedbackMissingParameters {
+		t.Errorf("code = %q, want %s", ack.Code, pure.FeedbackMissingParameters)
+	}
+	if len(ack.MissingParameters) != 1 || ack.MissingParameters[0] != "humidity" {
+		t.Errorf("missing_parameters = %v, want [humidity]", ack.MissingParameters)
+	}
+}
+
+func TestNewReadingAck_QuarantinedValue(t *testing.T) {
+	internal := "value 999.000000 above max range 50.000000 for temp"
+	rd := &readingflows.Reading{
+		ID:         "reading-1",
+		CampaignID: "campaign-1",
+		Timestamp:  time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		Status:     "quarantined",
+		Values: []readingflows.ReadingValue{
+			{ParameterName: "temp", Status: "quarantined", QuarantineReason: &internal},
+			{ParameterName: "humidity", Status: "accepted"},
+		},
+		QuarantineReason: &internal,
+		FeedbackCode:     pure.FeedbackValueOutOfRange,
+	}
+
+	ack := newReadingAck(rd)
+	if ack.ReadingID != "reading-1" {
+		t.Errorf("reading_id = %q, want reading-1", ack.ReadingID)
+	}
+	if ack.Status != "quarantined" {
+		t.Errorf("status = %q, want quarantined", ack.Status)
+	}
+	if ack.Reason == internal {
+		t.Error("ack must not expose internal quarantine reason")
+	}
+	if len(ack.QuarantinedParameters) != 1 || ack.QuarantinedParameters[0] != "temp" {
+		t.Errorf("quarantined_parameters = %v, want [temp]", ack.QuarantinedParameters)
+	}
+}
+
+func TestNewReadingAck_DefaultsToAccepted(t *testing.T) {
+	ack := newReadingAck(&readingflows.Reading{ID: "reading-2", Status: "accepted"})
+	if ack.Code != pure.FeedbackAccepted {
+		t.Errorf("code = %q, want %q", ack.Code, pure.FeedbackAccepted)
+	}
+}
+
+func TestNewBatchAck_PerItemResults(t *testing.T) {
+	result := &readingflows.BatchResult{
+		Readings: []readingflows.Reading{
+			{ID: "reading-1", Status: "accepted", FeedbackCode: pure.FeedbackAccepted},
+			{ID: "reading-2", Status: "quarantined", FeedbackCode: pure.FeedbackTimestampOutsideWindow},
+		},
+		Accepted:    1,
+		Quarantined: 1,
+	}
+
+	ack := newBatchAck("campaign-1", result)
+	if ack.Status != "accepted" {
+		t.Errorf("status = %q, want accepted", ack.Status)
+	}
+	if len(ack.Results) != 2 {
+		t.Fatalf("results = %d, want 2", len(ack.Results))
+	}
+	if ack.Results[1].Index != 1 || ack.Results[1].ReadingID != "reading-2" {
+		t.Errorf("result[1] = %d/%q, want 1/reading-2", ack.Results[1].Index, ack.Results[1].ReadingID)
+	}
+	if ack.Results[1].Code != pure.FeedbackTimestampOutsideWindow {
+		t.Errorf("result[1] code = %q, want %q", ack.Results[1].Code, pure.FeedbackTimestampOutsideWindow)
+	}
+}
+
+func TestNewBatchAck_AllRejected(t *testing.T) {
+	result := &readingflows.BatchResult{
+		Readings: []readingflows.Reading{
+			{Status: "rejected", FeedbackCode: pure.FeedbackNotEnrolled},
+			{Status: "rejected", FeedbackCode: pure.FeedbackNotEnrolled},
+		},
+		Rejected: 2,
+	}
+
+	ack := newBatchAck("campaign-1", result)
+	if ack.Status != "rejected" {
+		t.Errorf("status = %q, want rejected", ack.Status)
+	}
+	if ack.Code != pure.FeedbackNotEnrolled {
+		t.Errorf("code = %q, want %q", ack.Code, pure.FeedbackNotEnrolled)
+	}
+}
+
+func TestNewReadingAck_Duplicate(t *testing.T) {
+	ack := newReadingAck(&readingflows.Reading{ID: "reading-1", MessageID: "msg-1", Status: "duplicate", FeedbackCode: pure.FeedbackDuplicate})
+	if ack.ReadingID != "reading-1" || ack.MessageID != "msg-1" {
+		t.Errorf("reading/message = %q/%q, want reading-1/msg-1", ack.ReadingID, ack.MessageID)
+	}
+	if ack.Code != pure.FeedbackDuplicate {
+		t.Errorf("code = %q, want %q", ack.Code, pure.FeedbackDuplicate)
+	}
+}
+
+func TestChildEnrollRefusal_FixedReasons(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{fmt.Errorf("%w: gw-1", deviceflows.ErrNotGateway), childEnrollNotGateway},
+		{deviceflows.ErrInvalidChildCode, childEnrollInvalidCode},
+		{fmt.Errorf("%w: asn1: structure error", deviceflows.ErrInvalidChildCSR), childEnrollInvalidCSR},
+		// Internal errors never reach the gateway
+		{errors.New(`record issued cert: ERROR: relation "certificates" does not exist`), childEnrollFailed},
+	}
+	for _, tt := range tests {
+		if got := childEnrollRefusal(tt.err); got != tt.want {
+			t.Errorf("childEnrollRefusal(%v) = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+}
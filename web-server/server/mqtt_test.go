@@ -108,12 +108,14 @@ func TestNewMQTTServer_StartsAndAcceptsMTLS(t *testing.T) {
 			CAKeyPath:  filepath.Join(dir, "ca.key"),
 		},
 		MQTT: config.MQTTConfig{
-			Port:       port,
-			ServerSANs: []string{"localhost"},
+			Port:                       port,
+			ServerSANs:                 []string{"localhost"},
+			ServerCertLifetimeHours:    24,
+			ServerCertRenewBeforeHours: 6,
 		},
 	}
 
-	mqttServer, cleanup, err := NewMQTTServer(cfg)
+	mqttServer, cleanup, err := NewMQTTServer(cfg, nil)
 	if err != nil {
 		t.Fatalf("NewMQTTServer(): %v", err)
 	}
@@ -160,12 +162,14 @@ func TestNewMQTTServer_RejectsNoCert(t *testing.T) {
 			CAKeyPath:  filepath.Join(dir, "ca.key"),
 		},
 		MQTT: config.MQTTConfig{
-			Port:       port,
-			ServerSANs: []string{"localhost"},
+			Port:                       port,
+			ServerSANs:                 []string{"localhost"},
+			ServerCertLifetimeHours:    24,
+			ServerCertRenewBeforeHours: 6,
 		},
 	}
 
-	mqttServer, cleanup, err := NewMQTTServer(cfg)
+	mqttServer, cleanup, err := NewMQTTServer(cfg, nil)
 	if err != nil {
 		t.Fatalf("NewMQTTServer(): %v", err)
 	}
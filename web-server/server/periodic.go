@@ -0,0 +1,50 @@
+package server
+
+import (
+	"context"
+	"time"
+)
+
+// periodicTask calls a function on a fixed interval until stopped. Each call gets a
+// deadline of one interval.
+type periodicTask struct {
+	stop chan struct{}
+	done chan struct{}
+}
+
+// startPeriodic starts calling tick every interval. A zero interval starts nothing.
+// Calls run with ctx's values but not its cancellation; call Stop to end them.
+func startPeriodic(ctx context.Context, interval time.Duration, tick func(ctx context.Context)) *periodicTask {
+	t := &periodicTask{stop: make(chan struct{}), done: make(chan struct{})}
+	if interval <= 0 {
+		close(t.done)
+		return t
+	}
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		defer close(t.done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-t.stop:
+				return
+			case <-ticker.C:
+				tickCtx, cancel := context.WithTimeout(ctx, interval)
+				tick(tickCtx)
+				cancel()
+			}
+		}
+	}()
+	return t
+}
+
+// Stop ends the loop, waiting for a call in progress to finish.
+func (t *periodicTask) Stop() {
+	select {
+	case <-t.stop:
+	default:
+		close(t.stop)
+	}
+	<-t.done
+}
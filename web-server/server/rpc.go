@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 
 	"connectrpc.com/connect"
 	"connectrpc.com/otelconnect"
@@ -19,10 +20,12 @@ import (
 	scoreflows "rootstock/web-server/flows/score"
 	securityflows "rootstock/web-server/flows/security"
 	userflows "rootstock/web-server/flows/user"
+	"rootstock/web-server/global/observability"
 	connecthandlers "rootstock/web-server/handlers/connect"
 	httphandlers "rootstock/web-server/handlers/http"
 	campaignops "rootstock/web-server/ops/campaign"
 	certops "rootstock/web-server/ops/cert"
+	deadletterops "rootstock/web-server/ops/deadletter"
 	deviceops "rootstock/web-server/ops/device"
 	enrollmentops "rootstock/web-server/ops/enrollment"
 	graphops "rootstock/web-server/ops/graph"
@@ -36,6 +39,7 @@ import (
 	"rootstock/web-server/proto/rootstock/v1/rootstockv1connect"
 	"rootstock/web-server/repo/authorization"
 	campaignrepo "rootstock/web-server/repo/campaign"
+	deadletterrepo "rootstock/web-server/repo/deadletter"
 	enrollmentrepo "rootstock/web-server/repo/enrollment"
 	graphrepo "rootstock/web-server/repo/graph"
 	identityrepo "rootstock/web-server/repo/identity"
@@ -49,7 +53,8 @@ import (
 
 // NewRPCServer wires repos → ops → flows → Connect RPC handlers and returns
 // an http.Handler, the MQTTFlows for subscription wiring, and a shutdown function.
-func NewRPCServer(ctx context.Context, cfg *config.Config, pool *pgxpool.Pool, iRepo identityrepo.Repository, dOps *deviceops.Ops, crtOps *certops.Ops, mOps *mqttops.Ops) (http.Handler, *MQTTFlows, func(), error) {
+// Readings the handlers ingest (station uploads, dead-letter replays) go through pipeline and limiter.
+func NewRPCServer(ctx context.Context, cfg *config.Config, pool *pgxpool.Pool, iRepo identityrepo.Repository, dOps *deviceops.Ops, crtOps *certops.Ops, mOps *mqttops.Ops, pipeline *IngestPipeline, limiter *IngestRateLimiter) (http.Handler, *MQTTFlows, func(), error) {
 	// Session repo (Zitadel Session API)
 	sessRepo, err := sessionrepo.NewRepository(ctx, cfg.Identity.Zitadel)
 	if err != nil {
@@ -69,6 +74,7 @@ func NewRPCServer(ctx context.Context, cfg *config.Config, pool *pgxpool.Pool, i
 	uRepo := userrepo.NewRepository(pool)
 	scRepo := scitizenrepo.NewRepository(pool)
 	eRepo := enrollmentrepo.NewRepository(pool)
+	dlRepo := deadletterrepo.NewRepository(pool)
 
 	// Notification repo (SMTP)
 	nRepo := notificationrepo.NewRepository(cfg.SMTP.Host, cfg.SMTP.Port, cfg.SMTP.From)
@@ -89,6 +95,7 @@ func NewRPCServer(ctx context.Context, cfg *config.Config, pool *pgxpool.Pool, i
 	gOps := graphops.NewOps(gRepo)
 	scOps := scitizenops.NewOps(scRepo)
 	eOps := enrollmentops.NewOps(eRepo)
+	dlOps := deadletterops.NewOps(dlRepo)
 
 	// Interceptors (session-based auth)
 	otelInterceptor, err := otelconnect.NewInterceptor()
@@ -105,16 +112,40 @@ func NewRPCServer(ctx context.Context, cfg *config.Config, pool *pgxpool.Pool, i
 
 	// Device flows
 	getDeviceFlow := deviceflows.NewGetDeviceFlow(dOps)
-	revokeDeviceFlow := deviceflows.NewRevokeDeviceFlow(dOps)
-	reinstateDeviceFlow := deviceflows.NewReinstateDeviceFlow(dOps)
+	revokeDeviceFlow := deviceflows.NewRevokeDeviceFlow(dOps, mOps)
+	reinstateDeviceFlow := deviceflows.NewReinstateDeviceFlow(dOps, mOps)
 	registerDeviceFlow := deviceflows.NewRegisterDeviceFlow(dOps, crtOps)
 	getCACertFlow := deviceflows.NewGetCACertFlow(crtOps)
+	getCRLFlow := deviceflows.NewGetCRLFlow(dOps, crtOps)
+	checkCertStatusFlow := deviceflows.NewCheckCertStatusFlow(dOps, crtOps)
 	enrollInCampaignFlow := deviceflows.NewEnrollInCampaignFlow(dOps, cOps, mOps, gOps)
+	deviceConfigFlow := deviceflows.NewDeviceConfigFlow(dOps, cOps, mOps)
+	deviceCommandFlow := deviceflows.NewDeviceCommandFlow(dOps, mOps, deviceflows.CommandSettings{
+		DefaultTTLMinutes: cfg.Commands.DefaultTTLMinutes,
+		MaxTTLMinutes:     cfg.Commands.MaxTTLMinutes,
+	})
+	deviceShadowFlow := deviceflows.NewDeviceShadowFlow(dOps, mOps)
+	firmwareOTAFlow := deviceflows.NewFirmwareOTAFlow(dOps, mOps)
 	renewCertFlow := deviceflows.NewRenewCertFlow(dOps, crtOps)
+	gatewayEnrollmentFlow := deviceflows.NewGatewayEnrollmentFlow(dOps, crtOps)
+	stationUploadFlow := deviceflows.NewStationUploadFlow(dOps)
+	deviceHealthFlow := deviceflows.NewDeviceHealthFlow(dOps, eOps, deviceflows.HealthSettings{
+		LowBatteryPercent: cfg.Health.LowBatteryPercent,
+		SilentHours:       cfg.Health.SilentHours,
+	})
+	setClassUnitsFlow := deviceflows.NewSetClassUnitsFlow(dOps)
 
 	// Reading flows
-	ingestReadingFlow := readingflows.NewIngestReadingFlow(cOps, rOps, gOps)
+	ingestReadingFlow := readingflows.NewIngestReadingFlow(cOps, rOps, gOps, dOps, eOps, readingflows.IngestSettings{
+		NearDuplicateWindowMs: cfg.Ingest.NearDuplicateWindowMs,
+		RegionBufferMeters:    cfg.Ingest.RegionBufferMeters,
+		LocationDriftMeters:   cfg.Ingest.LocationDriftMeters,
+		MaxFutureDriftSeconds: cfg.Ingest.MaxFutureDriftSeconds,
+		MaxBacklogHours:       cfg.Ingest.MaxBacklogHours,
+		ClockSkewAlertSeconds: cfg.Ingest.ClockSkewAlertSeconds,
+	})
 	exportDataFlow := readingflows.NewExportDataFlow(rOps)
+	deadLetterFlow := readingflows.NewDeadLetterFlow(dlOps)
 
 	// Score flows
 	getContributionFlow := scoreflows.NewGetContributionFlow(sOps)
@@ -122,7 +153,8 @@ func NewRPCServer(ctx context.Context, cfg *config.Config, pool *pgxpool.Pool, i
 	getLeaderboardFlow := scoreflows.NewGetLeaderboardFlow(sOps)
 
 	// Security flows
-	securityResponseFlow := securityflows.NewSecurityResponseFlow(dOps, rOps, nOps)
+	securityResponseFlow := securityflows.NewSecurityResponseFlow(dOps, rOps, nOps, mOps)
+	rateLimitFlagFlow := securityflows.NewRateLimitFlagFlow(dOps)
 
 	// User flows
 	registerUserFlow := userflows.NewRegisterUserFlow(uOps)
@@ -139,12 +171,14 @@ func NewRPCServer(ctx context.Context, cfg *config.Config, pool *pgxpool.Pool, i
 	scitizenBrowseCampaignsFlow := scitizenflows.NewBrowseCampaignsFlow(scOps)
 	scitizenCampaignDetailFlow := scitizenflows.NewCampaignDetailFlow(scOps)
 	scitizenCampaignSearchFlow := scitizenflows.NewCampaignSearchFlow(scOps)
-	scitizenEnrollDeviceFlow := scitizenflows.NewEnrollDeviceCampaignFlow(scOps, eOps)
+	scitizenEnrollDeviceFlow := scitizenflows.NewEnrollDeviceCampaignFlow(scOps, eOps, cfg.Ingest.RegionBufferMeters)
 	scitizenWithdrawFlow := scitizenflows.NewWithdrawEnrollmentFlow(eOps)
 	scitizenDeviceFlow := scitizenflows.NewDeviceManagementFlow(scOps)
 	scitizenOnboardingFlow := scitizenflows.NewOnboardingFlow(scOps)
 	scitizenNotificationFlow := scitizenflows.NewNotificationFlow(scOps)
 	scitizenProgressFlow := scitizenflows.NewCampaignProgressFlow(scOps)
+	scitizenDeviceRegistrationFlow := scitizenflows.NewDeviceRegistrationFlow(dOps, scOps, cfg.Cert.EnrollmentCodeTTLMinutes)
+	scitizenStationLinkFlow := scitizenflows.NewStationLinkFlow(dOps, cfg.Server.PublicBaseURL)
 
 	// Notification flows
 	notifListFlow := notificationflows.NewListNotificationsFlow(scOps)
@@ -159,13 +193,30 @@ func NewRPCServer(ctx context.Context, cfg *config.Config, pool *pgxpool.Pool, i
 	assignRoleFlow := orgflows.NewAssignRoleFlow(oOps)
 	inviteUserFlow := orgflows.NewInviteUserFlow(oOps)
 
+	mqttFlows := &MQTTFlows{
+		IngestReading:        ingestReadingFlow,
+		RenewCert:            renewCertFlow,
+		RefreshScitizenScore: refreshScitizenScoreFlow,
+		DeadLetter:           deadLetterFlow,
+		RateLimitFlag:        rateLimitFlagFlow,
+		DeviceHealth:         deviceHealthFlow,
+		DeviceConfig:         deviceConfigFlow,
+		DeviceCommand:        deviceCommandFlow,
+		DeviceShadow:         deviceShadowFlow,
+		FirmwareOTA:          firmwareOTAFlow,
+		GatewayEnrollment:    gatewayEnrollmentFlow,
+	}
+
+	// Readings ingested outside the broker and telemetry endpoint
+	deadLetterReplayer := newDeadLetterReplayer(newTelemetryIngester(mqttFlows, cfg.MQTT, pipeline, limiter, observability.GetLogger("dead-letter-replay"), observability.GetMeter("telemetry-ingest")))
+
 	// Handlers
 	healthHandler := connecthandlers.NewHealthServiceHandler()
 	healthPath, healthH := rootstockv1connect.NewHealthServiceHandler(healthHandler, interceptors)
 
 	campaignHandler := connecthandlers.NewCampaignServiceHandler(
 		createCampaignFlow, publishCampaignFlow, browseCampaignsFlow, campaignDashboardFlow,
-		exportDataFlow, cfg.Export.HMACSecret,
+		exportDataFlow, deviceConfigFlow, cfg.Export.HMACSecret,
 	)
 	campaignPath, campaignH := rootstockv1connect.NewCampaignServiceHandler(campaignHandler, interceptors)
 
@@ -177,7 +228,9 @@ func NewRPCServer(ctx context.Context, cfg *config.Config, pool *pgxpool.Pool, i
 	scoreHandler := connecthandlers.NewScoreServiceHandler(getContributionFlow)
 	scorePath, scoreH := rootstockv1connect.NewScoreServiceHandler(scoreHandler, interceptors)
 
-	deviceHandler := connecthandlers.NewDeviceServiceHandler(getDeviceFlow, revokeDeviceFlow, reinstateDeviceFlow, enrollInCampaignFlow)
+	deviceHandler := connecthandlers.NewDeviceServiceHandler(
+		getDeviceFlow, revokeDeviceFlow, reinstateDeviceFlow, enrollInCampaignFlow, deviceCommandFlow, deviceShadowFlow, getUserFlow,
+	)
 	devicePath, deviceH := rootstockv1connect.NewDeviceServiceHandler(deviceHandler, interceptors)
 
 	userHandler := connecthandlers.NewUserServiceHandler(registerUserFlow, getUserFlow, loginFlow, logoutFlow, registerResearcherFlow, verifyEmailFlow, updateUserTypeFlow)
@@ -188,11 +241,13 @@ func NewRPCServer(ctx context.Context, cfg *config.Config, pool *pgxpool.Pool, i
 		scitizenBrowseCampaignsFlow, scitizenCampaignDetailFlow, scitizenCampaignSearchFlow,
 		scitizenEnrollDeviceFlow, scitizenWithdrawFlow, scitizenDeviceFlow,
 		scitizenOnboardingFlow, scitizenNotificationFlow, scitizenProgressFlow,
-		getLeaderboardFlow,
+		getLeaderboardFlow, scitizenDeviceRegistrationFlow, scitizenStationLinkFlow, deviceConfigFlow,
 	)
 	scitizenPath, scitizenH := rootstockv1connect.NewScitizenServiceHandler(scitizenHandler, interceptors)
 
-	adminHandler := connecthandlers.NewAdminServiceHandler(securityResponseFlow)
+	adminHandler := connecthandlers.NewAdminServiceHandler(
+		securityResponseFlow, deadLetterFlow, deadLetterReplayer, setClassUnitsFlow, rateLimitFlagFlow, firmwareOTAFlow, getUserFlow,
+	)
 	adminPath, adminH := rootstockv1connect.NewAdminServiceHandler(adminHandler, interceptors)
 
 	notificationHandler := connecthandlers.NewNotificationServiceHandler(
@@ -216,11 +271,18 @@ func NewRPCServer(ctx context.Context, cfg *config.Config, pool *pgxpool.Pool, i
 	mux.HandleFunc("/enroll", enrollHandler.Enroll)
 	mux.HandleFunc("/ca", enrollHandler.GetCACert)
 
-	mqttFlows := &MQTTFlows{
-		IngestReading:        ingestReadingFlow,
-		RenewCert:            renewCertFlow,
-		RefreshScitizenScore: refreshScitizenScoreFlow,
-	}
+	// Certificate revocation status for verifiers other than our broker (public)
+	revocationHandler := httphandlers.NewRevocationHandler(getCRLFlow, checkCertStatusFlow)
+	mux.HandleFunc("/crl", revocationHandler.GetCRL)
+	mux.HandleFunc("/ocsp", revocationHandler.OCSP)
+	mux.HandleFunc("/ocsp/", revocationHandler.OCSP)
+
+	// Consumer weather-station uploads (token in the URL, set up with LinkStation)
+	stationLogger := observability.GetLogger("station-uploads")
+	stationIngest := newTelemetryIngester(mqttFlows, cfg.MQTT, pipeline, limiter, stationLogger, observability.GetMeter("telemetry-ingest"))
+	stationHandler := newStationHTTPHandler(stationUploadFlow, stationIngest, stationLogger,
+		time.Duration(cfg.TelemetryHTTP.AckTimeoutSeconds)*time.Second)
+	mux.HandleFunc("/station/", stationHandler.Upload)
 
 	shutdown := func() {
 		cRepo.Shutdown()
@@ -231,6 +293,7 @@ func NewRPCServer(ctx context.Context, cfg *config.Config, pool *pgxpool.Pool, i
 		gRepo.Shutdown()
 		scRepo.Shutdown()
 		eRepo.Shutdown()
+		dlRepo.Shutdown()
 		sessRepo.Shutdown()
 	}
 
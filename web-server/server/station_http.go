@@ -0,0 +1,197 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	deviceflows "rootstock/web-server/flows/device"
+	"rootstock/web-server/ops/pure"
+	mqttrepo "rootstock/web-server/repo/mqtt"
+	o11yrepo "rootstock/web-server/repo/observability"
+)
+
+// maxStationUploadBytes bounds a station upload; an Ecowitt POST with every sensor is a few KB.
+const maxStationUploadBytes = 64 << 10
+
+// stationHTTPHandler receives uploads from consumer weather stations on the RPC port.
+// No mTLS or session — the token in the upload URL identifies and authenticates the device.
+// The translated reading is ingested like a device's own telemetry, one message per campaign
+// on rootstock/{device}/data/{campaign}: rate limited, queued on the ingest pipeline and
+// dead-lettered when it cannot be ingested.
+type stationHTTPHandler struct {
+	upload     *deviceflows.StationUploadFlow
+	ingest     *telemetryIngester
+	logger     o11yrepo.Logger
+	ackTimeout time.Duration
+}
+
+func newStationHTTPHandler(upload *deviceflows.StationUploadFlow, ingest *telemetryIngester, logger o11yrepo.Logger, ackTimeout time.Duration) *stationHTTPHandler {
+	return &stationHTTPHandler{upload: upload, ingest: ingest, logger: logger, ackTimeout: ackTimeout}
+}
+
+// Upload handles GET and POST /station/{token}[/...] — Weather Underground's
+// updateweatherstation query string, Ecowitt's form POST and Ambient's query string, as the
+// stations' custom-server settings send them. Anything after the token, such as a path the
+// station firmware insists on appending, is ignored. The reading is ingested into every live
+// campaign the device is enrolled in, and answered with "success" as the vendors' servers do
+// when any campaign stored it. Each campaign's message is charged to the device's rate limit
+// as its own publish would be, so an upload spends one token per campaign; a station enrolled
+// in several campaigns should upload correspondingly less often. Upload fields that were not
+// used are logged and listed in the X-Ignored-Fields response header.
+func (h *stationHTTPHandler) Upload(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	token, fields, err := parseStationUpload(w, r)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, "upload too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "invalid upload: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	upload, err := h.upload.Run(r.Context(), deviceflows.StationUploadInput{
+		Token:      token,
+		Fields:     fields,
+		ReceivedAt: time.Now(),
+	})
+	switch {
+	case errors.Is(err, deviceflows.ErrUnknownStationToken):
+		http.Error(w, "unknown station", http.StatusUnauthorized)
+		return
+	case errors.Is(err, deviceflows.ErrInvalidStationUpload):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	case err != nil:
+		h.logger.Error(r.Context(), "station: upload failed", map[string]interface{}{"error": err.Error()})
+		http.Error(w, "upload failed", http.StatusInternalServerError)
+		return
+	}
+	if len(upload.CampaignIDs) == 0 {
+		http.Error(w, "device is not enrolled in a live campaign", http.StatusConflict)
+		return
+	}
+
+	if len(upload.IgnoredFields) > 0 {
+		// Fields the protocol does not map or that did not parse; a station sends the same
+		// ones every upload, so the scitizen can fix the link's parameter map from this
+		h.logger.Info(r.Context(), "station: upload fields ignored", map[string]interface{}{
+			"device_id": upload.DeviceID,
+			"protocol":  upload.Protocol,
+			"fields":    strings.Join(upload.IgnoredFields, ","),
+		})
+		w.Header().Set("X-Ignored-Fields", strings.Join(upload.IgnoredFields, ","))
+	}
+
+	status, body := stationResponse(h.ingestUpload(r.Context(), upload))
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(status)
+	w.Write([]byte(body))
+}
+
+// stationResponse answers an upload from its campaigns' acknowledgements. The upload succeeds
+// when at least one campaign stored the reading (accepted, quarantined or a duplicate).
+// Otherwise the status is that of the first campaign's refusal — 422 when the ingestion gate
+// rejected it — and the body lists each campaign's code and reason.
+func stationResponse(acks []ReadingAck) (int, string) {
+	var body strings.Builder
+	for _, ack := range acks {
+		switch ack.Status {
+		case "accepted", "quarantined", "duplicate":
+			return http.StatusOK, "success\n"
+		}
+		fmt.Fprintf(&body, "%s: %s: %s\n", ack.CampaignID, ack.Code, ack.Reason)
+	}
+	status := ackStatusCode(acks[0].Status, acks[0].Code)
+	if status == http.StatusOK {
+		status = http.StatusUnprocessableEntity
+	}
+	return status, body.String()
+}
+
+// ingestUpload ingests the upload into each of its campaigns and returns the acknowledgements,
+// in campaign order. A campaign that does not answer within the ack timeout is reported busy.
+func (h *stationHTTPHandler) ingestUpload(ctx context.Context, upload *deviceflows.StationUpload) []ReadingAck {
+	body, err := json.Marshal(ReadingPayload{
+		Values:          upload.Values,
+		Timestamp:       upload.Timestamp,
+		FirmwareVersion: upload.FirmwareVersion,
+	})
+	if err != nil {
+		acks := make([]ReadingAck, len(upload.CampaignIDs))
+		for i, campaignID := range upload.CampaignIDs {
+			acks[i] = newFailureAck(campaignID, upload.Timestamp, "error", pure.FeedbackServerError)
+		}
+		return acks
+	}
+
+	replies := make(chan ReadingAck, len(upload.CampaignIDs))
+	for _, campaignID := range upload.CampaignIDs {
+		topic := fmt.Sprintf("%s/%s/data/%s", mqttrepo.TopicPrefix, upload.DeviceID, campaignID)
+		h.ingest.reading(ctx, topic, upload.DeviceID, campaignID, false, body, func(ack ReadingAck) { replies <- ack })
+	}
+
+	byCampaign := make(map[string]ReadingAck, len(upload.CampaignIDs))
+	timeout := time.After(h.ackTimeout)
+collect:
+	for len(byCampaign) < len(upload.CampaignIDs) {
+		select {
+		case ack := <-replies:
+			byCampaign[ack.CampaignID] = ack
+		case <-timeout:
+			break collect
+		case <-ctx.Done():
+			break collect
+		}
+	}
+
+	acks := make([]ReadingAck, len(upload.CampaignIDs))
+	for i, campaignID := range upload.CampaignIDs {
+		ack, ok := byCampaign[campaignID]
+		if !ok {
+			ack = newFailureAck(campaignID, upload.Timestamp, "error", pure.FeedbackServerBusy)
+		}
+		acks[i] = ack
+	}
+	return acks
+}
+
+// parseStationUpload returns the upload URL's token and the upload's query and form fields.
+// Ambient stations append their fields to the configured path without a "?", so anything
+// after an "&" in the path is parsed as query string too.
+func parseStationUpload(w http.ResponseWriter, r *http.Request) (string, url.Values, error) {
+	path := strings.TrimPrefix(r.URL.Path, "/station/")
+	fields := url.Values{}
+	if i := strings.IndexByte(path, '&'); i >= 0 {
+		extra, err := url.ParseQuery(path[i+1:])
+		if err != nil {
+			return "", nil, err
+		}
+		fields = extra
+		path = path[:i]
+	}
+	token, _, _ := strings.Cut(path, "/")
+	if token == "" {
+		return "", nil, errors.New("missing station token")
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxStationUploadBytes)
+	if err := r.ParseForm(); err != nil {
+		return "", nil, err
+	}
+	for name, values := range r.Form {
+		fields[name] = append(fields[name], values...)
+	}
+	return token, fields, nil
+}
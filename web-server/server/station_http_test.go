@@ -0,0 +1,166 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"rootstock/web-server/config"
+	deviceflows "rootstock/web-server/flows/device"
+	"rootstock/web-server/ops/pure"
+)
+
+func TestParseStationUpload(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		form       string
+		wantToken  string
+		wantFields url.Values
+		wantErr    bool
+	}{
+		{
+			name:       "wunderground query string",
+			method:     http.MethodGet,
+			target:     "/station/tok123/weatherstation/updateweatherstation.php?ID=KXX1&tempf=70.0",
+			wantToken:  "tok123",
+			wantFields: url.Values{"ID": {"KXX1"}, "tempf": {"70.0"}},
+		},
+		{
+			name:       "ambient appends fields to the path",
+			method:     http.MethodGet,
+			target:     "/station/tok123&tempf=70.0&humidity=55",
+			wantToken:  "tok123",
+			wantFields: url.Values{"tempf": {"70.0"}, "humidity": {"55"}},
+		},
+		{
+			name:       "ecowitt form post",
+			method:     http.MethodPost,
+			target:     "/station/tok123/data/report/",
+			form:       "PASSKEY=abc&tempf=32",
+			wantToken:  "tok123",
+			wantFields: url.Values{"PASSKEY": {"abc"}, "tempf": {"32"}},
+		},
+		{name: "missing token", method: http.MethodGet, target: "/station/?tempf=70", wantErr: true},
+		{name: "missing token before fields", method: http.MethodGet, target: "/station/&tempf=70", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.form))
+			if tt.form != "" {
+				r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			}
+			token, fields, err := parseStationUpload(httptest.NewRecorder(), r)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseStationUpload() = %q, %v; want error", token, fields)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseStationUpload() error = %v", err)
+			}
+			if token != tt.wantToken {
+				t.Errorf("token = %q, want %q", token, tt.wantToken)
+			}
+			if !reflect.DeepEqual(fields, tt.wantFields) {
+				t.Errorf("fields = %v, want %v", fields, tt.wantFields)
+			}
+		})
+	}
+}
+
+func TestStationHTTP_RefusedBeforeLookup(t *testing.T) {
+	// Refused before the token is looked up, so the handler needs no flows
+	h := newStationHTTPHandler(nil, nil, discardLogger{}, time.Second)
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   string
+		want   int
+	}{
+		{"method", http.MethodPut, "/station/tok123?tempf=70", "", http.StatusMethodNotAllowed},
+		{"missing token", http.MethodGet, "/station/", "", http.StatusBadRequest},
+		{"body limit", http.MethodPost, "/station/tok123", "tempf=" + strings.Repeat("7", maxStationUploadBytes), http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			w := httptest.NewRecorder()
+			h.Upload(w, r)
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestStationHTTP_IngestUploadIsRateLimited(t *testing.T) {
+	limiter := NewIngestRateLimiter(config.RateLimitConfig{DeviceRatePerMinute: 1, DeviceBurst: 1}, newCountingMeter())
+	ingest := newTelemetryIngester(&MQTTFlows{}, config.MQTTConfig{}, nil, limiter, discardLogger{}, newCountingMeter())
+	h := newStationHTTPHandler(nil, ingest, discardLogger{}, time.Second)
+
+	// Spend the device's only token, as its last upload would have
+	limiter.Allow(context.Background(), "device-001", "campaign-1")
+
+	acks := h.ingestUpload(context.Background(), &deviceflows.StationUpload{
+		DeviceID:    "device-001",
+		CampaignIDs: []string{"campaign-1", "campaign-2"},
+		Values:      map[string]float64{"temperature": 21.5},
+		Timestamp:   time.Now(),
+	})
+	if len(acks) != 2 || acks[0].CampaignID != "campaign-1" || acks[1].CampaignID != "campaign-2" {
+		t.Fatalf("acks = %+v, want one per campaign in order", acks)
+	}
+	for _, ack := range acks {
+		if ack.Status != "rejected" || ack.Code != pure.FeedbackRateLimited {
+			t.Errorf("ack = %+v, want rejected %s", ack, pure.FeedbackRateLimited)
+		}
+	}
+	if status, _ := stationResponse(acks); status != http.StatusTooManyRequests {
+		t.Errorf("stationResponse() status = %d, want %d", status, http.StatusTooManyRequests)
+	}
+}
+
+func TestStationResponse(t *testing.T) {
+	ack := func(campaignID, status, code string) ReadingAck {
+		return newFailureAck(campaignID, time.Time{}, status, code)
+	}
+	tests := []struct {
+		name     string
+		acks     []ReadingAck
+		want     int
+		wantBody string
+	}{
+		{"accepted", []ReadingAck{ack("c1", "accepted", pure.FeedbackAccepted)}, http.StatusOK, "success\n"},
+		{"stored in one campaign", []ReadingAck{
+			ack("c1", "rejected", pure.FeedbackNotEnrolled), ack("c2", "quarantined", pure.FeedbackAccepted),
+		}, http.StatusOK, "success\n"},
+		{"duplicate", []ReadingAck{ack("c1", "duplicate", pure.FeedbackDuplicate)}, http.StatusOK, "success\n"},
+		{"rejected everywhere", []ReadingAck{
+			ack("c1", "rejected", pure.FeedbackNotEnrolled), ack("c2", "rejected", pure.FeedbackNotEnrolled),
+		}, http.StatusUnprocessableEntity, "c1: " + pure.FeedbackNotEnrolled},
+		{"busy", []ReadingAck{ack("c1", "error", pure.FeedbackServerBusy)}, http.StatusServiceUnavailable, "c1: " + pure.FeedbackServerBusy},
+		{"failed", []ReadingAck{ack("c1", "error", pure.FeedbackServerError)}, http.StatusInternalServerError, "c1: " + pure.FeedbackServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body := stationResponse(tt.acks)
+			if status != tt.want {
+				t.Errorf("status = %d, want %d", status, tt.want)
+			}
+			if !strings.HasPrefix(body, tt.wantBody) {
+				t.Errorf("body = %q, want prefix %q", body, tt.wantBody)
+			}
+		})
+	}
+}
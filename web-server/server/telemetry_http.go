@@ -0,0 +1,271 @@
+package server
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	mochi "github.com/mochi-mqtt/server/v2"
+
+	"rootstock/web-server/config"
+	deviceflows "rootstock/web-server/flows/device"
+	"rootstock/web-server/global/observability"
+	deviceops "rootstock/web-server/ops/device"
+	"rootstock/web-server/ops/pure"
+	mqttrepo "rootstock/web-server/repo/mqtt"
+	o11yrepo "rootstock/web-server/repo/observability"
+)
+
+// telemetryHTTPHandler serves device telemetry over HTTPS. Each request is authorized like an
+// MQTT connect followed by a publish on the equivalent topic, by the broker's own auth hook,
+// and answered with the acknowledgement the broker would publish on rootstock/{id}/ack.
+type telemetryHTTPHandler struct {
+	access     *MQTTAuthHook
+	ingest     *telemetryIngester
+	renew      *deviceflows.RenewCertFlow
+	logger     o11yrepo.Logger
+	maxBody    int64
+	ackTimeout time.Duration
+}
+
+// NewTelemetryHTTPServer creates the HTTPS telemetry listener on cfg.TelemetryHTTP.Port, for
+// devices that can make an HTTPS POST but not speak MQTT:
+//
+//	POST /v1/devices/{device_id}/data/{campaign_id}        ReadingPayload -> ReadingAck
+//	POST /v1/devices/{device_id}/data-batch/{campaign_id}  BatchPayload   -> BatchAck
+//	POST /v1/devices/{device_id}/renew                     CSR (DER or PEM) -> certificate PEM
+//
+// Devices present the same client certificate as on the broker, verified with the same
+// grace period, and are held to the same registry check and topic ACL: an expired
+// certificate or a suspended device may only renew, and a gateway may post readings for
+// its children but never renew for them. Readings share the broker's rate limits and ingest pipeline. The response
+// carries the acknowledgement; a request that gives up waiting for it leaves the reading
+// to be ingested, and resending it with the same message_id reports the outcome.
+//
+// Returns a nil server when the port is 0. Call ListenAndServeTLS("", "") on the returned
+// server; the cleanup function stops the server certificate's rotation or file watch.
+func NewTelemetryHTTPServer(ctx context.Context, cfg *config.Config, dOps *deviceops.Ops, flows *MQTTFlows, pipeline *IngestPipeline, limiter *IngestRateLimiter) (*http.Server, func(), error) {
+	if cfg.TelemetryHTTP.Port == 0 {
+		return nil, func() {}, nil
+	}
+
+	caCert, caSigner, caCertPEM, err := loadCA(cfg.Cert.CACertPath, cfg.Cert.CAKeyPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("load ca for telemetry http: %w", err)
+	}
+	caCertPool := x509.NewCertPool()
+	if !caCertPool.AppendCertsFromPEM(caCertPEM) {
+		return nil, nil, fmt.Errorf("failed to add ca cert to telemetry http pool")
+	}
+
+	var authorizer ConnectionAuthorizer
+	var children GatewayChildLister
+	if dOps != nil {
+		authorizeFlow := deviceflows.NewAuthorizeConnectionFlow(dOps)
+		authorizer, children = authorizeFlow, authorizeFlow
+	}
+	access := &MQTTAuthHook{}
+	access.SetOpts(slog.Default().With("listener", "telemetry-https"), &mochi.HookOptions{})
+	if err := access.Init(&MQTTAuthHookConfig{
+		CACertPool:      caCertPool,
+		GracePeriodDays: cfg.MQTT.GracePeriodDays,
+		Authorizer:      authorizer,
+		Children:        children,
+	}); err != nil {
+		return nil, nil, fmt.Errorf("init telemetry http auth: %w", err)
+	}
+
+	certProvider, err := newServerCertProvider(cfg.MQTT, caCert, caSigner)
+	if err != nil {
+		return nil, nil, fmt.Errorf("telemetry http server cert: %w", err)
+	}
+
+	logger := observability.GetLogger("telemetry-https")
+	h := &telemetryHTTPHandler{
+		access:     access,
+		ingest:     newTelemetryIngester(flows, cfg.MQTT, pipeline, limiter, logger, observability.GetMeter("telemetry-ingest")),
+		renew:      flows.RenewCert,
+		logger:     logger,
+		maxBody:    cfg.TelemetryHTTP.MaxBodyBytes,
+		ackTimeout: time.Duration(cfg.TelemetryHTTP.AckTimeoutSeconds) * time.Second,
+	}
+
+	srv := &http.Server{
+		Addr:              fmt.Sprintf(":%d", cfg.TelemetryHTTP.Port),
+		Handler:           h.routes(),
+		TLSConfig:         newDeviceTLSConfig(certProvider.GetCertificate, caCertPool, cfg.MQTT.GracePeriodDays),
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+	return srv, certProvider.Close, nil
+}
+
+func (h *telemetryHTTPHandler) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/devices/{device_id}/data/{campaign_id}", h.postReading)
+	mux.HandleFunc("POST /v1/devices/{device_id}/data-batch/{campaign_id}", h.postBatch)
+	mux.HandleFunc("POST /v1/devices/{device_id}/renew", h.postRenew)
+	return mux
+}
+
+// postReading handles POST /v1/devices/{device_id}/data/{campaign_id}.
+func (h *telemetryHTTPHandler) postReading(w http.ResponseWriter, r *http.Request) {
+	deviceID, campaignID := r.PathValue("device_id"), r.PathValue("campaign_id")
+	topic, ok := deviceTopic(w, deviceID, "data", campaignID)
+	if !ok || !h.authorize(w, r, topic) {
+		return
+	}
+	body, ok := h.readBody(w, r)
+	if !ok {
+		return
+	}
+
+	acks := make(chan ReadingAck, 1)
+	h.ingest.reading(r.Context(), topic, deviceID, campaignID, false, body, func(ack ReadingAck) { acks <- ack })
+	select {
+	case ack := <-acks:
+		writeAck(w, ackStatusCode(ack.Status, ack.Code), ack)
+	case <-time.After(h.ackTimeout):
+		http.Error(w, "acknowledgement timed out; resend with the same message_id for the outcome", http.StatusGatewayTimeout)
+	case <-r.Context().Done():
+	}
+}
+
+// postBatch handles POST /v1/devices/{device_id}/data-batch/{campaign_id}.
+func (h *telemetryHTTPHandler) postBatch(w http.ResponseWriter, r *http.Request) {
+	deviceID, campaignID := r.PathValue("device_id"), r.PathValue("campaign_id")
+	topic, ok := deviceTopic(w, deviceID, "data-batch", campaignID)
+	if !ok || !h.authorize(w, r, topic) {
+		return
+	}
+	body, ok := h.readBody(w, r)
+	if !ok {
+		return
+	}
+
+	acks := make(chan BatchAck, 1)
+	h.ingest.batch(r.Context(), topic, deviceID, campaignID, false, body, func(ack BatchAck) { acks <- ack })
+	select {
+	case ack := <-acks:
+		writeAck(w, ackStatusCode(ack.Status, ack.Code), ack)
+	case <-time.After(h.ackTimeout):
+		http.Error(w, "acknowledgement timed out; resend with the same message_ids for the outcome", http.StatusGatewayTimeout)
+	case <-r.Context().Done():
+	}
+}
+
+// postRenew handles POST /v1/devices/{device_id}/renew, the one request a device whose
+// certificate is in its grace period, or which is suspended, may still make.
+func (h *telemetryHTTPHandler) postRenew(w http.ResponseWriter, r *http.Request) {
+	deviceID := r.PathValue("device_id")
+	topic, ok := deviceTopic(w, deviceID, "renew")
+	if !ok || !h.authorize(w, r, topic) {
+		return
+	}
+	body, ok := h.readBody(w, r)
+	if !ok {
+		return
+	}
+
+	csr := body
+	if block, _ := pem.Decode(body); block != nil {
+		csr = block.Bytes
+	}
+	if _, err := x509.ParseCertificateRequest(csr); err != nil {
+		http.Error(w, "invalid csr: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.renew.Run(r.Context(), deviceflows.RenewCertInput{DeviceID: deviceID, CSR: csr})
+	if err != nil {
+		h.logger.Error(r.Context(), "renew: certificate renewal failed", map[string]interface{}{
+			"device_id": deviceID,
+			"error":     err.Error(),
+		})
+		http.Error(w, "certificate renewal failed", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-pem-file")
+	w.Write(result.CertPEM)
+}
+
+// deviceTopic is the MQTT topic equivalent to a request, rootstock/{device_id}/{subtopic...}.
+// IDs that could not appear in a topic name are refused.
+func deviceTopic(w http.ResponseWriter, deviceID string, subtopic ...string) (string, bool) {
+	for _, id := range append([]string{deviceID}, subtopic...) {
+		if strings.ContainsAny(id, "/+#") {
+			http.Error(w, "invalid device or campaign id", http.StatusBadRequest)
+			return "", false
+		}
+	}
+	return strings.Join(append([]string{mqttrepo.TopicPrefix, deviceID}, subtopic...), "/"), true
+}
+
+// authorize admits the request's client certificate and checks it may use topic, writing
+// the refusal when it may not. Nothing is kept between requests: each is admitted against
+// the registry, and a gateway's children are loaded for each request it relays, so a
+// superseded certificate or a revoked child is refused from the next request on.
+func (h *telemetryHTTPHandler) authorize(w http.ResponseWriter, r *http.Request, topic string) bool {
+	if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+		http.Error(w, "client certificate required", http.StatusUnauthorized)
+		return false
+	}
+	cert := r.TLS.PeerCertificates[0]
+	session, ok := h.access.admit(cert.Subject.CommonName, cert)
+	if !ok {
+		http.Error(w, "device not authorized", http.StatusForbidden)
+		return false
+	}
+	if !h.access.topicAllowed(session, topic) {
+		http.Error(w, "device may not post here", http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+// readBody reads the request body up to the configured limit.
+func (h *telemetryHTTPHandler) readBody(w http.ResponseWriter, r *http.Request) ([]byte, bool) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, h.maxBody))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+			return nil, false
+		}
+		http.Error(w, "read body: "+err.Error(), http.StatusBadRequest)
+		return nil, false
+	}
+	return body, true
+}
+
+// ackStatusCode is the HTTP status of a response carrying an acknowledgement. A message the
+// ingestion path processed is 200 whatever its outcome, which the acknowledgement reports;
+// messages refused before ingestion get the matching client or server error.
+func ackStatusCode(status, code string) int {
+	switch {
+	case code == pure.FeedbackRateLimited:
+		return http.StatusTooManyRequests
+	case code == pure.FeedbackMalformedPayload, code == pure.FeedbackBatchTooLarge:
+		return http.StatusBadRequest
+	case code == pure.FeedbackServerBusy:
+		return http.StatusServiceUnavailable
+	case status == "error":
+		return http.StatusInternalServerError
+	}
+	return http.StatusOK
+}
+
+func writeAck(w http.ResponseWriter, status int, ack any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(ack)
+}
@@ -0,0 +1,225 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	mochi "github.com/mochi-mqtt/server/v2"
+
+	"rootstock/web-server/config"
+	deviceflows "rootstock/web-server/flows/device"
+	readingflows "rootstock/web-server/flows/reading"
+	"rootstock/web-server/ops/pure"
+)
+
+// discardLogger drops everything logged through it.
+type discardLogger struct{}
+
+func (discardLogger) Info(context.Context, string, map[string]interface{})  {}
+func (discardLogger) Error(context.Context, string, map[string]interface{}) {}
+func (discardLogger) Warn(context.Context, string, map[string]interface{})  {}
+func (discardLogger) Debug(context.Context, string, map[string]interface{}) {}
+
+func newTelemetryHandler(t *testing.T, ca *testCA, authorizer ConnectionAuthorizer, limits config.RateLimitConfig) *telemetryHTTPHandler {
+	t.Helper()
+	access := &MQTTAuthHook{}
+	access.SetOpts(slog.Default(), &mochi.HookOptions{})
+	if err := access.Init(&MQTTAuthHookConfig{
+		CACertPool:      ca.CACertPool,
+		GracePeriodDays: 7,
+		Authorizer:      authorizer,
+	}); err != nil {
+		t.Fatalf("init access: %v", err)
+	}
+	limiter := NewIngestRateLimiter(limits, newCountingMeter())
+	return &telemetryHTTPHandler{
+		access:     access,
+		ingest:     newTelemetryIngester(&MQTTFlows{}, config.MQTTConfig{MaxBatchSize: 10}, nil, limiter, discardLogger{}, newCountingMeter()),
+		logger:     discardLogger{},
+		maxBody:    1 << 10,
+		ackTimeout: time.Second,
+	}
+}
+
+// postAs sends body to path as a device presenting cert (nil for none).
+func postAs(h *telemetryHTTPHandler, cert *x509.Certificate, path, body string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	if cert != nil {
+		r.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}
+	}
+	w := httptest.NewRecorder()
+	h.routes().ServeHTTP(w, r)
+	return w
+}
+
+func allowAll() *fakeAuthorizer {
+	return &fakeAuthorizer{decision: deviceflows.ConnectionDecision{Action: pure.ConnectAllow}}
+}
+
+func TestTelemetryHTTP_RequiresClientCertificate(t *testing.T) {
+	h := newTelemetryHandler(t, newTestCA(t), allowAll(), config.RateLimitConfig{})
+
+	if w := postAs(h, nil, "/v1/devices/device-001/data/campaign-1", `{}`); w.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestTelemetryHTTP_AppliesBrokerACL(t *testing.T) {
+	ca := newTestCA(t)
+	now := time.Now()
+	valid, _ := ca.makeDeviceCert(t, "device-001", now.Add(-time.Hour), now.AddDate(0, 3, 0))
+	grace, _ := ca.makeDeviceCert(t, "device-grace", now.AddDate(0, 0, -100), now.AddDate(0, 0, -2))
+
+	cases := []struct {
+		name       string
+		authorizer *fakeAuthorizer
+		cert       *x509.Certificate
+		path       string
+		want       int
+	}{
+		{"other device's readings", allowAll(), valid, "/v1/devices/device-002/data/campaign-1", http.StatusForbidden},
+		{"other device's batch", allowAll(), valid, "/v1/devices/device-002/data-batch/campaign-1", http.StatusForbidden},
+		{"refused by registry", &fakeAuthorizer{decision: deviceflows.ConnectionDecision{Action: pure.ConnectDeny, Reason: "device revoked"}}, valid, "/v1/devices/device-001/data/campaign-1", http.StatusForbidden},
+		{"suspended device posting data", &fakeAuthorizer{decision: deviceflows.ConnectionDecision{Action: pure.ConnectRestricted}}, valid, "/v1/devices/device-001/data/campaign-1", http.StatusForbidden},
+		{"grace period posting data", allowAll(), grace, "/v1/devices/device-grace/data/campaign-1", http.StatusForbidden},
+		{"wildcard in campaign", allowAll(), valid, "/v1/devices/device-001/data/campaign%2B1", http.StatusBadRequest},
+		{"grace period renewing with a bad csr", allowAll(), grace, "/v1/devices/device-grace/renew", http.StatusBadRequest},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			h := newTelemetryHandler(t, ca, tc.authorizer, config.RateLimitConfig{})
+			if w := postAs(h, tc.cert, tc.path, `not a csr`); w.Code != tc.want {
+				t.Errorf("status = %d, want %d (%s)", w.Code, tc.want, strings.TrimSpace(w.Body.String()))
+			}
+		})
+	}
+}
+
+func TestTelemetryHTTP_GatewayChildrenLoadedPerRequest(t *testing.T) {
+	ca := newTestCA(t)
+	gateway := &fakeAuthorizer{decision: deviceflows.ConnectionDecision{Action: pure.ConnectAllow, Gateway: true}}
+	h := newTelemetryHandler(t, ca, gateway, config.RateLimitConfig{})
+	lister := &fakeChildLister{children: []deviceflows.GatewayChild{{DeviceID: "child-1", Status: "active"}}}
+	h.access.children = lister
+	gwCert, _ := ca.makeGatewayCert(t, "gateway-1")
+
+	authorize := func() bool {
+		r := httptest.NewRequest(http.MethodPost, "/v1/devices/child-1/data/campaign-1", nil)
+		r.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{gwCert}}
+		return h.authorize(httptest.NewRecorder(), r, "rootstock/child-1/data/campaign-1")
+	}
+	if !authorize() {
+		t.Fatal("gateway should post for an active child")
+	}
+	lister.children[0].Status = "revoked"
+	if authorize() {
+		t.Error("gateway should be refused for the child from the request after its revocation")
+	}
+	if lister.calls != 2 {
+		t.Errorf("children looked up %d times, want once per request", lister.calls)
+	}
+}
+
+func TestTelemetryHTTP_RateLimitedReadingIsAcknowledged(t *testing.T) {
+	ca := newTestCA(t)
+	now := time.Now()
+	cert, _ := ca.makeDeviceCert(t, "device-001", now.Add(-time.Hour), now.AddDate(0, 3, 0))
+	h := newTelemetryHandler(t, ca, allowAll(), config.RateLimitConfig{DeviceRatePerMinute: 1, DeviceBurst: 1})
+
+	// Spend the device's only token through the ingester, as an MQTT publish would
+	h.ingest.limiter.Allow(context.Background(), "device-001", "campaign-1")
+
+	w := postAs(h, cert, "/v1/devices/device-001/data/campaign-1", `{"values":{"temp":21.5}}`)
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusTooManyRequests)
+	}
+	var ack ReadingAck
+	if err := json.Unmarshal(w.Body.Bytes(), &ack); err != nil {
+		t.Fatalf("decode ack: %v", err)
+	}
+	if ack.Status != "rejected" || ack.Code != pure.FeedbackRateLimited || ack.CampaignID != "campaign-1" {
+		t.Errorf("ack = %+v, want rejected %s for campaign-1", ack, pure.FeedbackRateLimited)
+	}
+}
+
+func TestTelemetryIngester_InlinePublishesSkipLimiter(t *testing.T) {
+	ca := newTestCA(t)
+	h := newTelemetryHandler(t, ca, allowAll(), config.RateLimitConfig{DeviceRatePerMinute: 1, DeviceBurst: 1})
+	ctx := context.Background()
+
+	h.ingest.limiter.Allow(ctx, "device-001", "campaign-1")
+	// A replayed reading arrives from the server's inline client after the device's budget is spent
+	if _, ok := h.ingest.allow(ctx, "device-001", "campaign-1", true); !ok {
+		t.Errorf("inline publish was rate limited")
+	}
+	if _, ok := h.ingest.allow(ctx, "device-001", "campaign-1", false); ok {
+		t.Errorf("device publish allowed past its budget")
+	}
+}
+
+func TestTelemetryIngester_CountsGateFailuresByCheck(t *testing.T) {
+	meter := newCountingMeter()
+	ingest := newTelemetryIngester(&MQTTFlows{}, config.MQTTConfig{}, nil, nil, discardLogger{}, meter)
+
+	ingest.countGateFailures(context.Background(), []readingflows.Reading{
+		{Status: "accepted"},
+		{Status: "quarantined", FailedCheck: pure.GateCheckDeviceStatus},
+		{Status: "rejected", FailedCheck: pure.GateCheckEnrollment},
+		{Status: "rejected", FailedCheck: pure.GateCheckEnrollment},
+	})
+
+	want := map[string]int64{
+		"check=" + pure.GateCheckDeviceStatus + ",status=quarantined": 1,
+		"check=" + pure.GateCheckEnrollment + ",status=rejected":      2,
+	}
+	got := meter.instrument("ingest.gate_failed").series
+	if len(got) != len(want) {
+		t.Fatalf("series = %v, want %v", got, want)
+	}
+	for series, n := range want {
+		if got[series] != n {
+			t.Errorf("%s = %d, want %d", series, got[series], n)
+		}
+	}
+}
+
+func TestTelemetryHTTP_BodyLimit(t *testing.T) {
+	ca := newTestCA(t)
+	now := time.Now()
+	cert, _ := ca.makeDeviceCert(t, "device-001", now.Add(-time.Hour), now.AddDate(0, 3, 0))
+	h := newTelemetryHandler(t, ca, allowAll(), config.RateLimitConfig{})
+
+	w := postAs(h, cert, "/v1/devices/device-001/data-batch/campaign-1", strings.Repeat("x", 2<<10))
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusRequestEntityTooLarge)
+	}
+}
+
+func TestAckStatusCode(t *testing.T) {
+	cases := []struct {
+		status, code string
+		want         int
+	}{
+		{"accepted", pure.FeedbackAccepted, http.StatusOK},
+		{"quarantined", pure.FeedbackSamplingTooFrequent, http.StatusOK},
+		{"rejected", pure.FeedbackRejected, http.StatusOK},
+		{"rejected", pure.FeedbackRateLimited, http.StatusTooManyRequests},
+		{"rejected", pure.FeedbackMalformedPayload, http.StatusBadRequest},
+		{"rejected", pure.FeedbackBatchTooLarge, http.StatusBadRequest},
+		{"error", pure.FeedbackServerBusy, http.StatusServiceUnavailable},
+		{"error", pure.FeedbackServerError, http.StatusInternalServerError},
+	}
+	for _, tc := range cases {
+		if got := ackStatusCode(tc.status, tc.code); got != tc.want {
+			t.Errorf("ackStatusCode(%q, %q) = %d, want %d", tc.status, tc.code, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,351 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"rootstock/web-server/config"
+	readingflows "rootstock/web-server/flows/reading"
+	scoreflows "rootstock/web-server/flows/score"
+	securityflows "rootstock/web-server/flows/security"
+	"rootstock/web-server/ops/pure"
+	deadletterrepo "rootstock/web-server/repo/deadletter"
+	o11yrepo "rootstock/web-server/repo/observability"
+)
+
+// telemetryIngester takes readings from any device transport. It rate-limits and parses a
+// message on the caller's path, then ingests it on the pipeline so no transport waits on
+// storage. Every message is answered with exactly one acknowledgement, through the reply
+// function the transport passes in, possibly from a pipeline worker. Messages that cannot
+// be ingested are dead-lettered under their MQTT topic, so replay sends them through the broker.
+// Messages the server publishes itself (inline, as replay does) are not rate limited: they
+// were limited when the device first sent them, and refusing them would lose the reading.
+// Readings that fail the ingestion gate are counted on ingest.gate_failed by check and status.
+type telemetryIngester struct {
+	flows      *MQTTFlows
+	cfg        config.MQTTConfig
+	pipeline   *IngestPipeline
+	limiter    *IngestRateLimiter
+	logger     o11yrepo.Logger
+	gateFailed o11yrepo.Counter
+}
+
+func newTelemetryIngester(flows *MQTTFlows, cfg config.MQTTConfig, pipeline *IngestPipeline, limiter *IngestRateLimiter, logger o11yrepo.Logger, meter o11yrepo.Meter) *telemetryIngester {
+	return &telemetryIngester{
+		flows:      flows,
+		cfg:        cfg,
+		pipeline:   pipeline,
+		limiter:    limiter,
+		logger:     logger,
+		gateFailed: meter.Counter("ingest.gate_failed"),
+	}
+}
+
+// reading ingests one message of rootstock/{device}/data/{campaign}. inline is true for a
+// message the server published itself.
+func (t *telemetryIngester) reading(ctx context.Context, topic, deviceID, campaignID string, inline bool, body []byte, reply func(ReadingAck)) {
+	if scope, ok := t.allow(ctx, deviceID, campaignID, inline); !ok {
+		t.rateLimited(ctx, deviceID, campaignID, scope, topic)
+		reply(newFailureAck(campaignID, time.Time{}, "rejected", pure.FeedbackRateLimited))
+		return
+	}
+
+	var payload ReadingPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.logger.Error(ctx, "telemetry: invalid payload JSON", map[string]interface{}{
+			"device_id": deviceID,
+			"error":     err.Error(),
+		})
+		reply(newFailureAck(campaignID, time.Time{}, "rejected", pure.FeedbackMalformedPayload))
+		t.deadLetterAsync(ctx, readingflows.RecordDeadLetterInput{
+			Topic: topic, DeviceID: deviceID, CampaignID: campaignID, Payload: body,
+			ErrorClass: readingflows.DeadLetterMalformedPayload, ErrorMessage: err.Error(),
+		})
+		return
+	}
+
+	input := readingflows.IngestReadingInput{
+		DeviceID:        deviceID,
+		CampaignID:      campaignID,
+		MessageID:       payload.MessageID,
+		Values:          payload.ResolvedValues(),
+		Timestamp:       payload.Timestamp,
+		Geolocation:     payload.Geolocation,
+		FirmwareVersion: payload.FirmwareVersion,
+		CertSerial:      payload.CertSerial,
+	}
+
+	raw := append([]byte(nil), body...)
+	submitted := t.pipeline.Submit(campaignID, func(ctx context.Context) {
+		result, err := t.flows.IngestReading.Run(ctx, input)
+		if err != nil {
+			t.logger.Error(ctx, "telemetry: ingest reading failed", map[string]interface{}{
+				"device_id":   deviceID,
+				"campaign_id": campaignID,
+				"error":       err.Error(),
+			})
+			reply(newFailureAck(campaignID, payload.Timestamp, "error", pure.FeedbackServerError))
+			t.recordDeadLetter(ctx, readingflows.RecordDeadLetterInput{
+				Topic: topic, DeviceID: deviceID, CampaignID: campaignID, Payload: raw,
+				ErrorClass: readingflows.DeadLetterIngestError, ErrorMessage: err.Error(),
+			})
+			return
+		}
+
+		reply(newReadingAck(result))
+
+		if result.FeedbackCode == pure.FeedbackSamplingTooFrequent && t.limiter.SamplingQuarantined(ctx, deviceID) {
+			t.flagViolator(ctx, deviceID)
+		}
+
+		if result.FailedCheck == pure.GateCheckEnrollment {
+			t.recordDeadLetter(ctx, readingflows.RecordDeadLetterInput{
+				Topic: topic, DeviceID: deviceID, CampaignID: campaignID, Payload: raw,
+				ErrorClass: readingflows.DeadLetterNotEnrolled, ErrorMessage: *result.RejectReason,
+			})
+		}
+
+		t.countGateFailures(ctx, []readingflows.Reading{*result})
+		if result.FailedCheck != "" {
+			attrs := map[string]interface{}{
+				"device_id":    deviceID,
+				"campaign_id":  campaignID,
+				"status":       result.Status,
+				"failed_check": result.FailedCheck,
+			}
+			if result.RejectReason != nil {
+				attrs["reason"] = *result.RejectReason
+			} else if result.QuarantineReason != nil {
+				attrs["reason"] = *result.QuarantineReason
+			}
+			t.logger.Warn(ctx, "telemetry: reading failed ingestion gate", attrs)
+		}
+
+		if result.Status == "accepted" {
+			if _, err := t.flows.RefreshScitizenScore.Run(ctx, scoreflows.RefreshScitizenScoreInput{
+				DeviceID: deviceID,
+			}); err != nil {
+				t.logger.Error(ctx, "telemetry: refresh scitizen score failed", map[string]interface{}{
+					"device_id": deviceID,
+					"error":     err.Error(),
+				})
+			}
+		}
+	})
+	if !submitted {
+		t.logger.Warn(ctx, "telemetry: ingest pipeline shed message", map[string]interface{}{
+			"device_id":   deviceID,
+			"campaign_id": campaignID,
+		})
+		reply(newFailureAck(campaignID, payload.Timestamp, "error", pure.FeedbackServerBusy))
+	}
+}
+
+// batch ingests one store-and-forward upload of rootstock/{device}/data-batch/{campaign}.
+// inline is true for an upload the server published itself.
+func (t *telemetryIngester) batch(ctx context.Context, topic, deviceID, campaignID string, inline bool, body []byte, reply func(BatchAck)) {
+	if scope, ok := t.allow(ctx, deviceID, campaignID, inline); !ok {
+		t.rateLimited(ctx, deviceID, campaignID, scope, topic)
+		reply(newBatchFailureAck(campaignID, "rejected", pure.FeedbackRateLimited))
+		return
+	}
+
+	var payload BatchPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.logger.Error(ctx, "batch: invalid payload JSON", map[string]interface{}{
+			"device_id": deviceID,
+			"error":     err.Error(),
+		})
+		reply(newBatchFailureAck(campaignID, "rejected", pure.FeedbackMalformedPayload))
+		t.deadLetterAsync(ctx, readingflows.RecordDeadLetterInput{
+			Topic: topic, DeviceID: deviceID, CampaignID: campaignID, Payload: body,
+			ErrorClass: readingflows.DeadLetterMalformedPayload, ErrorMessage: err.Error(),
+		})
+		return
+	}
+	if len(payload.Readings) > t.cfg.MaxBatchSize {
+		t.logger.Warn(ctx, "batch: upload exceeds max batch size", map[string]interface{}{
+			"device_id": deviceID,
+			"readings":  len(payload.Readings),
+			"max":       t.cfg.MaxBatchSize,
+		})
+		reply(newBatchFailureAck(campaignID, "rejected", pure.FeedbackBatchTooLarge))
+		return
+	}
+
+	input := readingflows.IngestBatchInput{
+		DeviceID:   deviceID,
+		CampaignID: campaignID,
+		Readings:   make([]readingflows.IngestReadingInput, len(payload.Readings)),
+	}
+	for i, p := range payload.Readings {
+		input.Readings[i] = readingflows.IngestReadingInput{
+			MessageID:       p.MessageID,
+			Values:          p.ResolvedValues(),
+			Timestamp:       p.Timestamp,
+			Geolocation:     p.Geolocation,
+			FirmwareVersion: p.FirmwareVersion,
+			CertSerial:      p.CertSerial,
+		}
+	}
+
+	raw := append([]byte(nil), body...)
+	submitted := t.pipeline.Submit(campaignID, func(ctx context.Context) {
+		result, err := t.flows.IngestReading.RunBatch(ctx, input)
+		if err != nil {
+			t.logger.Error(ctx, "batch: ingest batch failed", map[string]interface{}{
+				"device_id":   deviceID,
+				"campaign_id": campaignID,
+				"readings":    len(payload.Readings),
+				"error":       err.Error(),
+			})
+			reply(newBatchFailureAck(campaignID, "error", pure.FeedbackServerError))
+			t.recordDeadLetter(ctx, readingflows.RecordDeadLetterInput{
+				Topic: topic, DeviceID: deviceID, CampaignID: campaignID, Payload: raw,
+				ErrorClass: readingflows.DeadLetterIngestError, ErrorMessage: err.Error(),
+			})
+			return
+		}
+
+		reply(newBatchAck(campaignID, result))
+
+		// A batch sampled too often counts as one violation, however many items it quarantined
+		for _, rd := range result.Readings {
+			if rd.FeedbackCode == pure.FeedbackSamplingTooFrequent {
+				if t.limiter.SamplingQuarantined(ctx, deviceID) {
+					t.flagViolator(ctx, deviceID)
+				}
+				break
+			}
+		}
+
+		t.countGateFailures(ctx, result.Readings)
+
+		// The gate decides enrollment once per batch, so an unenrolled upload rejects every item.
+		if len(result.Readings) > 0 && result.Readings[0].FailedCheck == pure.GateCheckEnrollment {
+			t.recordDeadLetter(ctx, readingflows.RecordDeadLetterInput{
+				Topic: topic, DeviceID: deviceID, CampaignID: campaignID, Payload: raw,
+				ErrorClass: readingflows.DeadLetterNotEnrolled, ErrorMessage: *result.Readings[0].RejectReason,
+			})
+		}
+
+		t.logger.Info(ctx, "batch: ingested", map[string]interface{}{
+			"device_id":   deviceID,
+			"campaign_id": campaignID,
+			"accepted":    result.Accepted,
+			"quarantined": result.Quarantined,
+			"rejected":    result.Rejected,
+			"duplicates":  result.Duplicates,
+		})
+
+		if result.Accepted > 0 {
+			if _, err := t.flows.RefreshScitizenScore.Run(ctx, scoreflows.RefreshScitizenScoreInput{
+				DeviceID: deviceID,
+			}); err != nil {
+				t.logger.Error(ctx, "batch: refresh scitizen score failed", map[string]interface{}{
+					"device_id": deviceID,
+					"error":     err.Error(),
+				})
+			}
+		}
+	})
+	if !submitted {
+		t.logger.Warn(ctx, "batch: ingest pipeline shed message", map[string]interface{}{
+			"device_id":   deviceID,
+			"campaign_id": campaignID,
+		})
+		reply(newBatchFailureAck(campaignID, "error", pure.FeedbackServerBusy))
+	}
+}
+
+// countGateFailures counts the readings that failed the ingestion gate, by the check they
+// failed and whether they were quarantined or rejected.
+func (t *telemetryIngester) countGateFailures(ctx context.Context, readings []readingflows.Reading) {
+	type series struct{ check, status string }
+	counts := make(map[series]int)
+	for _, rd := range readings {
+		if rd.FailedCheck != "" {
+			counts[series{rd.FailedCheck, rd.Status}]++
+		}
+	}
+	for s, n := range counts {
+		t.gateFailed.AddWithAttributes(ctx, float64(n), map[string]string{"check": s.check, "status": s.status})
+	}
+}
+
+// recordDeadLetter keeps a message that cannot be ingested with its raw payload for
+// inspection and replay. While Postgres is down the letter is spooled in memory until it
+// can be stored. If it can be neither stored nor spooled the payload goes to the log so
+// it is not lost outright.
+func (t *telemetryIngester) recordDeadLetter(ctx context.Context, input readingflows.RecordDeadLetterInput) {
+	dl, err := t.flows.DeadLetter.RunRecord(ctx, input)
+	if err == nil && dl.Status == deadletterrepo.StatusSpooled {
+		t.logger.Warn(ctx, "dead letter: spooled until the database is reachable", map[string]interface{}{
+			"dead_letter_id": dl.ID,
+			"topic":          input.Topic,
+			"device_id":      input.DeviceID,
+		})
+	}
+	if err != nil {
+		t.logger.Error(ctx, "dead letter: record failed", map[string]interface{}{
+			"topic":       input.Topic,
+			"device_id":   input.DeviceID,
+			"error_class": input.ErrorClass,
+			"payload":     string(input.Payload),
+			"error":       err.Error(),
+		})
+	}
+}
+
+// deadLetterAsync records from a transport's delivery path without waiting on storage.
+func (t *telemetryIngester) deadLetterAsync(ctx context.Context, input readingflows.RecordDeadLetterInput) {
+	input.Payload = append([]byte(nil), input.Payload...)
+	if !t.pipeline.Submit(input.CampaignID, func(ctx context.Context) { t.recordDeadLetter(ctx, input) }) {
+		t.logger.Error(ctx, "dead letter: pipeline shed record", map[string]interface{}{
+			"topic":       input.Topic,
+			"device_id":   input.DeviceID,
+			"error_class": input.ErrorClass,
+			"payload":     string(input.Payload),
+		})
+	}
+}
+
+// flagViolator flags a repeat rate limit violator for the security response tooling.
+func (t *telemetryIngester) flagViolator(ctx context.Context, deviceID string) {
+	if _, err := t.flows.RateLimitFlag.RunFlag(ctx, securityflows.FlagRateLimitInput{
+		DeviceID:   deviceID,
+		Violations: int64(t.limiter.FlagThreshold()),
+	}); err != nil {
+		t.logger.Error(ctx, "rate limit: flag device failed", map[string]interface{}{
+			"device_id": deviceID,
+			"error":     err.Error(),
+		})
+	}
+}
+
+// allow applies the rate limiter to device messages; server-published ones always pass.
+func (t *telemetryIngester) allow(ctx context.Context, deviceID, campaignID string, inline bool) (string, bool) {
+	if inline {
+		return "", true
+	}
+	return t.limiter.Allow(ctx, deviceID, campaignID)
+}
+
+// rateLimited handles a message refused by the limiter on the delivery path. Only the device's
+// own limit counts against it: a busy campaign is not the device's fault.
+func (t *telemetryIngester) rateLimited(ctx context.Context, deviceID, campaignID, scope, topic string) {
+	t.logger.Warn(ctx, "telemetry: rate limited", map[string]interface{}{
+		"device_id":   deviceID,
+		"campaign_id": campaignID,
+		"scope":       scope,
+		"topic":       topic,
+	})
+	if scope == RateLimitDevice && t.limiter.Violation(ctx, deviceID) {
+		if !t.pipeline.Submit(campaignID, func(ctx context.Context) { t.flagViolator(ctx, deviceID) }) {
+			t.logger.Error(ctx, "rate limit: pipeline shed flag", map[string]interface{}{
+				"device_id": deviceID,
+			})
+		}
+	}
+}